@@ -41,12 +41,14 @@ func main() {
 
 	certFile, keyFile := getCert()
 	config := exportServer.ExportServerConfig{
-		CertFile:   certFile,
-		KeyFile:    keyFile,
-		Deadline:   getDeadline(),
-		ListenAddr: getListenAddr(),
-		TokenFile:  getTokenFile(),
-		Paths:      export.CreateServerPaths(export.EnvironToMap()),
+		CertFile:      certFile,
+		KeyFile:       keyFile,
+		Deadline:      getDeadline(),
+		ListenAddr:    getListenAddr(),
+		NBDListenAddr: os.Getenv("NBD_LISTEN_ADDR"),
+		NBDReadOnly:   os.Getenv("NBD_READ_ONLY") != "false",
+		TokenFile:     getTokenFile(),
+		Paths:         export.CreateServerPaths(export.EnvironToMap()),
 	}
 	server := exportServer.NewExportServer(config)
 	service.Setup(server)
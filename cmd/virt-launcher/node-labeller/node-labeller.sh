@@ -46,3 +46,22 @@ if [ "$ARCH" == "x86_64" ] || [ "$ARCH" == "s390x" ]; then
 fi
 
 virsh -c qemu:///system capabilities > /var/lib/kubevirt-node-labeller/capabilities.xml
+
+# list the hypervisors which can run on this node as "<name> <version>"
+HYPERVISORS_FILE=/var/lib/kubevirt-node-labeller/hypervisors
+: > $HYPERVISORS_FILE
+
+if [ "$VIRTTYPE" == "kvm" ]; then
+    QEMU_VERSION=$(virsh -c qemu:///system version | awk '/Running hypervisor/ {print $4}')
+    echo "qemu $QEMU_VERSION" >> $HYPERVISORS_FILE
+fi
+
+if command -v virtchd > /dev/null && command -v cloud-hypervisor > /dev/null; then
+    CH_VERSION=$(cloud-hypervisor --version | awk '{print $2}' | sed 's/^v//')
+    if [ -e /dev/mshv ]; then
+        echo "ch $CH_VERSION" >> $HYPERVISORS_FILE
+    fi
+    if [ "$VIRTTYPE" == "kvm" ]; then
+        echo "ch-kvm $CH_VERSION" >> $HYPERVISORS_FILE
+    fi
+fi
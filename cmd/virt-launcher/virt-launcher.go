@@ -109,7 +109,7 @@ func startCmdServer(socketPath string,
 	return done
 }
 
-func createLibvirtConnection(hypervisor hypervisorInterface.Hypervisor) virtcli.Connection {
+func createLibvirtConnection(hypervisor hypervisorInterface.Hypervisor, capabilities hypervisorInterface.Capabilities) virtcli.Connection {
 	libvirtUri, user := hypervisor.GetLibvirtUriAndUser()
 
 	domainConn, err := virtcli.NewConnection(libvirtUri, user, "", 10*time.Second)
@@ -117,6 +117,17 @@ func createLibvirtConnection(hypervisor hypervisorInterface.Hypervisor) virtcli.
 		panic(fmt.Sprintf("failed to connect to virtqemud: %v", err))
 	}
 
+	// Collect the domain stats from the VMM if the libvirt driver does not provide them
+	if hypervisor.HasVMMAPI() {
+		domainConn = virtcli.NewCloudHypervisorConnection(domainConn, hypervisor.GetVMMAPISocketPath, hypervisor.GetVcpuRegex())
+	}
+
+	// Talk to the guest agent over the vsock device if the libvirt driver does not provide a guest agent channel
+	if locator, isLocator := domainConn.(virtcli.VSOCKSocketLocator); isLocator && !capabilities.GuestAgentChannel {
+		transport := virtcli.NewVSOCKAgentTransport(locator.VSOCKSocketPath, virtcli.GuestAgentVSOCKPort)
+		domainConn = virtcli.NewAgentTransportConnection(domainConn, transport)
+	}
+
 	return domainConn
 }
 
@@ -411,7 +422,8 @@ func main() {
 
 	l.StartVirtlog(stopChan, domainName)
 
-	domainConn := createLibvirtConnection(l)
+	capabilities, _ := hypervisorInterface.GetCapabilities(*hypervisor)
+	domainConn := createLibvirtConnection(l, capabilities)
 	defer domainConn.Close()
 
 	var agentStore = agentpoller.NewAsyncAgentStore()
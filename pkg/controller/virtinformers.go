@@ -135,6 +135,15 @@ type KubeInformerFactory interface {
 	// Watches VirtualMachineRestore objects
 	VirtualMachineRestore() cache.SharedIndexInformer
 
+	// Watches VirtualMachineSnapshotSchedule objects
+	VirtualMachineSnapshotSchedule() cache.SharedIndexInformer
+
+	// Watches VirtualMachineGroupSnapshot objects
+	VirtualMachineGroupSnapshot() cache.SharedIndexInformer
+
+	// Watches VirtualMachineBackup objects
+	VirtualMachineBackup() cache.SharedIndexInformer
+
 	// Watches MigrationPolicy objects
 	MigrationPolicy() cache.SharedIndexInformer
 
@@ -644,6 +653,18 @@ func GetVirtualMachineSnapshotInformerIndexers() cache.Indexers {
 				return []string{fmt.Sprintf("%s/%s", vms.Namespace, vms.Spec.Source.Name)}, nil
 			}
 
+			return nil, nil
+		},
+		"schedule": func(obj interface{}) ([]string, error) {
+			vms, ok := obj.(*snapshotv1.VirtualMachineSnapshot)
+			if !ok {
+				return nil, unexpectedObjectError
+			}
+
+			if schedule, ok := vms.Labels[snapshotv1.VirtualMachineSnapshotScheduleLabel]; ok {
+				return []string{fmt.Sprintf("%s/%s", vms.Namespace, schedule)}, nil
+			}
+
 			return nil, nil
 		},
 	}
@@ -694,7 +715,11 @@ func GetVirtualMachineRestoreInformerIndexers() cache.Indexers {
 			if vmr.Spec.Target.APIGroup != nil &&
 				*vmr.Spec.Target.APIGroup == core.GroupName &&
 				vmr.Spec.Target.Kind == "VirtualMachine" {
-				return []string{fmt.Sprintf("%s/%s", vmr.Namespace, vmr.Spec.Target.Name)}, nil
+				namespace := vmr.Namespace
+				if vmr.Spec.TargetNamespace != nil && *vmr.Spec.TargetNamespace != "" {
+					namespace = *vmr.Spec.TargetNamespace
+				}
+				return []string{fmt.Sprintf("%s/%s", namespace, vmr.Spec.Target.Name)}, nil
 			}
 
 			return nil, nil
@@ -709,6 +734,46 @@ func (f *kubeInformerFactory) VirtualMachineRestore() cache.SharedIndexInformer
 	})
 }
 
+func (f *kubeInformerFactory) VirtualMachineSnapshotSchedule() cache.SharedIndexInformer {
+	return f.getInformer("vmSnapshotScheduleInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().SnapshotV1beta1().RESTClient(), "virtualmachinesnapshotschedules", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &snapshotv1.VirtualMachineSnapshotSchedule{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineGroupSnapshot() cache.SharedIndexInformer {
+	return f.getInformer("vmGroupSnapshotInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().SnapshotV1beta1().RESTClient(), "virtualmachinegroupsnapshots", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &snapshotv1.VirtualMachineGroupSnapshot{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func GetVirtualMachineBackupInformerIndexers() cache.Indexers {
+	return cache.Indexers{
+		"vm": func(obj interface{}) ([]string, error) {
+			vmBackup, ok := obj.(*snapshotv1.VirtualMachineBackup)
+			if !ok {
+				return nil, unexpectedObjectError
+			}
+
+			if vmBackup.Spec.Source.APIGroup != nil &&
+				*vmBackup.Spec.Source.APIGroup == core.GroupName &&
+				vmBackup.Spec.Source.Kind == "VirtualMachine" {
+				return []string{fmt.Sprintf("%s/%s", vmBackup.Namespace, vmBackup.Spec.Source.Name)}, nil
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+func (f *kubeInformerFactory) VirtualMachineBackup() cache.SharedIndexInformer {
+	return f.getInformer("vmBackupInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().SnapshotV1beta1().RESTClient(), "virtualmachinebackups", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &snapshotv1.VirtualMachineBackup{}, f.defaultResync, GetVirtualMachineBackupInformerIndexers())
+	})
+}
+
 func (f *kubeInformerFactory) MigrationPolicy() cache.SharedIndexInformer {
 	return f.getInformer("migrationPolicyInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().MigrationsV1alpha1().RESTClient(), migrations.ResourceMigrationPolicies, k8sv1.NamespaceAll, fields.Everything())
@@ -720,6 +785,14 @@ func GetVirtualMachineCloneInformerIndexers() cache.Indexers {
 	getkey := func(vmClone *clonev1alpha1.VirtualMachineClone, resourceName string) string {
 		return fmt.Sprintf("%s/%s", vmClone.Namespace, resourceName)
 	}
+	// the snapshot and the restore of a clone live in the namespace of its source
+	getSourceKey := func(vmClone *clonev1alpha1.VirtualMachineClone, resourceName string) string {
+		namespace := vmClone.Namespace
+		if vmClone.Spec.SourceNamespace != nil && *vmClone.Spec.SourceNamespace != "" {
+			namespace = *vmClone.Spec.SourceNamespace
+		}
+		return fmt.Sprintf("%s/%s", namespace, resourceName)
+	}
 
 	return cache.Indexers{
 		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
@@ -732,7 +805,7 @@ func GetVirtualMachineCloneInformerIndexers() cache.Indexers {
 
 			source := vmClone.Spec.Source
 			if source != nil && *source.APIGroup == snapshot.GroupName && source.Kind == "VirtualMachineSnapshot" {
-				return []string{getkey(vmClone, source.Name)}, nil
+				return []string{getSourceKey(vmClone, source.Name)}, nil
 			}
 
 			return nil, nil
@@ -745,7 +818,7 @@ func GetVirtualMachineCloneInformerIndexers() cache.Indexers {
 			}
 
 			if vmClone.Status.Phase == clonev1alpha1.SnapshotInProgress && vmClone.Status.SnapshotName != nil {
-				return []string{getkey(vmClone, *vmClone.Status.SnapshotName)}, nil
+				return []string{getSourceKey(vmClone, *vmClone.Status.SnapshotName)}, nil
 			}
 
 			return nil, nil
@@ -758,12 +831,12 @@ func GetVirtualMachineCloneInformerIndexers() cache.Indexers {
 			}
 
 			if vmClone.Status.Phase == clonev1alpha1.RestoreInProgress && vmClone.Status.RestoreName != nil {
-				return []string{getkey(vmClone, *vmClone.Status.RestoreName)}, nil
+				return []string{getSourceKey(vmClone, *vmClone.Status.RestoreName)}, nil
 			}
 
 			return nil, nil
 		},
-		// Gets: restore key. Returns: clones in phase Succeeded
+		// Gets: restore name keyed by the namespace of the restored PVCs. Returns: clones in phase Succeeded
 		string(clonev1alpha1.Succeeded): func(obj interface{}) ([]string, error) {
 			vmClone, ok := obj.(*clonev1alpha1.VirtualMachineClone)
 			if !ok {
@@ -41,6 +41,7 @@ It has these top-level messages:
 	SEVInfoResponse
 	LaunchMeasurementResponse
 	InjectLaunchSecretRequest
+	BackupRequest
 */
 package v1
 
@@ -839,6 +840,7 @@ func (m *FreezeRequest) GetUnfreezeTimeoutSeconds() int32 {
 type MemoryDumpRequest struct {
 	Vmi      *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
 	DumpPath string `protobuf:"bytes,2,opt,name=dumpPath" json:"dumpPath,omitempty"`
+	Type     string `protobuf:"bytes,3,opt,name=type" json:"type,omitempty"`
 }
 
 func (m *MemoryDumpRequest) Reset()                    { *m = MemoryDumpRequest{} }
@@ -860,6 +862,13 @@ func (m *MemoryDumpRequest) GetDumpPath() string {
 	return ""
 }
 
+func (m *MemoryDumpRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
 type SEVInfoResponse struct {
 	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 	SevInfo  []byte    `protobuf:"bytes,2,opt,name=sevInfo,proto3" json:"sevInfo,omitempty"`
@@ -932,6 +941,38 @@ func (m *InjectLaunchSecretRequest) GetOptions() []byte {
 	return nil
 }
 
+type BackupRequest struct {
+	Vmi        *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	BackupPath string `protobuf:"bytes,2,opt,name=backupPath" json:"backupPath,omitempty"`
+	Options    []byte `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *BackupRequest) Reset()                    { *m = BackupRequest{} }
+func (m *BackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()               {}
+func (*BackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *BackupRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *BackupRequest) GetBackupPath() string {
+	if m != nil {
+		return m.BackupPath
+	}
+	return ""
+}
+
+func (m *BackupRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*QemuVersionResponse)(nil), "kubevirt.cmd.v1.QemuVersionResponse")
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
@@ -964,6 +1005,7 @@ func init() {
 	proto.RegisterType((*SEVInfoResponse)(nil), "kubevirt.cmd.v1.SEVInfoResponse")
 	proto.RegisterType((*LaunchMeasurementResponse)(nil), "kubevirt.cmd.v1.LaunchMeasurementResponse")
 	proto.RegisterType((*InjectLaunchSecretRequest)(nil), "kubevirt.cmd.v1.InjectLaunchSecretRequest")
+	proto.RegisterType((*BackupRequest)(nil), "kubevirt.cmd.v1.BackupRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1007,6 +1049,7 @@ type CmdClient interface {
 	GetSEVInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SEVInfoResponse, error)
 	GetLaunchMeasurement(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*LaunchMeasurementResponse, error)
 	InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecretRequest, opts ...grpc.CallOption) (*Response, error)
+	VirtualMachineBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type cmdClient struct {
@@ -1287,6 +1330,15 @@ func (c *cmdClient) InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecr
 	return out, nil
 }
 
+func (c *cmdClient) VirtualMachineBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/VirtualMachineBackup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cmd service
 
 type CmdServer interface {
@@ -1320,6 +1372,7 @@ type CmdServer interface {
 	GetSEVInfo(context.Context, *EmptyRequest) (*SEVInfoResponse, error)
 	GetLaunchMeasurement(context.Context, *VMIRequest) (*LaunchMeasurementResponse, error)
 	InjectLaunchSecret(context.Context, *InjectLaunchSecretRequest) (*Response, error)
+	VirtualMachineBackup(context.Context, *BackupRequest) (*Response, error)
 }
 
 func RegisterCmdServer(s *grpc.Server, srv CmdServer) {
@@ -1866,6 +1919,24 @@ func _Cmd_InjectLaunchSecret_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_VirtualMachineBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).VirtualMachineBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/VirtualMachineBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).VirtualMachineBackup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubevirt.cmd.v1.Cmd",
 	HandlerType: (*CmdServer)(nil),
@@ -1990,6 +2061,10 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "InjectLaunchSecret",
 			Handler:    _Cmd_InjectLaunchSecret_Handler,
 		},
+		{
+			MethodName: "VirtualMachineBackup",
+			Handler:    _Cmd_VirtualMachineBackup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/handler-launcher-com/cmd/v1/cmd.proto",
@@ -1998,118 +2073,120 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1802 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x5f, 0x6f, 0x23, 0xb7,
-	0x11, 0x3f, 0x59, 0xb2, 0x2d, 0x8d, 0xff, 0xe4, 0x8e, 0xfe, 0x93, 0xb5, 0xdb, 0xbb, 0x73, 0x89,
-	0xe2, 0xe0, 0x14, 0x89, 0xdd, 0xbb, 0x5c, 0x82, 0xe2, 0x50, 0x04, 0x17, 0xcb, 0xb2, 0xe3, 0xe4,
-	0x7c, 0xa7, 0xac, 0x6c, 0x1f, 0x9a, 0x36, 0x08, 0xe8, 0x5d, 0x5a, 0x66, 0xbd, 0x4b, 0x2a, 0x4b,
-	0xae, 0x7a, 0xca, 0x53, 0x81, 0x14, 0x7d, 0x28, 0xd0, 0xcf, 0xd7, 0xb7, 0x7e, 0x8b, 0x02, 0x7d,
-	0x0c, 0xc8, 0xe5, 0xca, 0x2b, 0xed, 0xae, 0x7d, 0x86, 0xf4, 0x64, 0x92, 0x33, 0xf3, 0x9b, 0x21,
-	0x39, 0x33, 0xfc, 0x69, 0x0d, 0x1f, 0xf5, 0xae, 0xba, 0xbb, 0x97, 0x84, 0xfb, 0x01, 0x8d, 0x3e,
-	0x09, 0x48, 0xcc, 0xbd, 0x4b, 0x1a, 0x7d, 0xe2, 0x89, 0x70, 0xd7, 0x0b, 0xfd, 0xdd, 0xfe, 0x53,
-	0xfd, 0x67, 0xa7, 0x17, 0x09, 0x25, 0xd0, 0x07, 0x57, 0xf1, 0x39, 0xed, 0xb3, 0x48, 0xed, 0xe8,
-	0xb5, 0xfe, 0x53, 0x7c, 0x01, 0x2b, 0xdf, 0xd2, 0x30, 0x3e, 0xa3, 0x91, 0x64, 0x82, 0xbb, 0x54,
-	0xf6, 0x04, 0x97, 0x14, 0x7d, 0x06, 0xf5, 0xc8, 0x8e, 0x9d, 0xca, 0x56, 0x65, 0x7b, 0xe1, 0xd9,
-	0xc6, 0xce, 0x98, 0xe9, 0x4e, 0xaa, 0xec, 0x0e, 0x55, 0x91, 0x03, 0xf3, 0xfd, 0x04, 0xc9, 0x99,
-	0xd9, 0xaa, 0x6c, 0x37, 0xdc, 0x74, 0x8a, 0x1f, 0x43, 0xf5, 0xec, 0xf8, 0xc8, 0x28, 0x84, 0xec,
-	0x6b, 0x29, 0xb8, 0x81, 0x5d, 0x74, 0xd3, 0x29, 0x7e, 0x0a, 0xd5, 0x66, 0xfb, 0x14, 0x2d, 0xc3,
-	0x0c, 0xf3, 0x8d, 0x6c, 0xc9, 0x9d, 0x61, 0x3e, 0xda, 0x84, 0xba, 0x64, 0xe7, 0x01, 0xe3, 0x5d,
-	0xe9, 0xcc, 0x6c, 0x55, 0xb7, 0x97, 0xdc, 0xe1, 0x1c, 0xef, 0xc2, 0x7c, 0x27, 0x19, 0xe7, 0xcc,
-	0x56, 0x61, 0xb6, 0x4f, 0x82, 0x98, 0x9a, 0x30, 0x6a, 0x6e, 0x32, 0xc1, 0x2d, 0x98, 0x6d, 0x93,
-	0x2e, 0x95, 0x5a, 0xec, 0x89, 0x98, 0x2b, 0x63, 0x51, 0x73, 0x93, 0x09, 0x42, 0x50, 0x8b, 0x39,
-	0x53, 0x36, 0x74, 0x33, 0xd6, 0x6b, 0x92, 0xfd, 0x44, 0x9d, 0xaa, 0x81, 0x36, 0x63, 0xfc, 0x1c,
-	0xe6, 0x8e, 0x69, 0x28, 0xa2, 0x01, 0x5a, 0x87, 0x39, 0x12, 0x66, 0x80, 0xec, 0xac, 0x08, 0x09,
-	0xff, 0xa7, 0x02, 0xb5, 0x26, 0x0d, 0x82, 0x5c, 0xac, 0xbb, 0x30, 0x17, 0x1a, 0x38, 0xa3, 0xbe,
-	0xf0, 0xec, 0xc3, 0xdc, 0x49, 0x27, 0xde, 0x5c, 0xab, 0x86, 0x3e, 0x86, 0xd9, 0x9e, 0xde, 0x86,
-	0x53, 0xdd, 0xaa, 0x6e, 0x2f, 0x3c, 0x5b, 0xcf, 0xe9, 0x9b, 0x4d, 0xba, 0x89, 0x12, 0xfa, 0x1c,
-	0x1a, 0x3e, 0x93, 0x8a, 0x70, 0x8f, 0x4a, 0xa7, 0x66, 0x2c, 0x9c, 0x9c, 0x85, 0x3d, 0x47, 0xf7,
-	0x5a, 0x15, 0x6d, 0x43, 0xcd, 0xeb, 0xc5, 0xd2, 0x99, 0x35, 0x26, 0xab, 0x39, 0x93, 0x66, 0xfb,
-	0xd4, 0x35, 0x1a, 0xf8, 0x25, 0xd4, 0x4f, 0x44, 0x4f, 0x04, 0xa2, 0x3b, 0x40, 0xcf, 0x01, 0x78,
-	0x1c, 0x92, 0x1f, 0x3c, 0x1a, 0x04, 0xd2, 0xa9, 0x18, 0xdb, 0xb5, 0xbc, 0x2d, 0x0d, 0x02, 0xb7,
-	0xa1, 0x15, 0xf5, 0x48, 0xe2, 0x7f, 0x55, 0x60, 0xae, 0x73, 0xbc, 0xc7, 0x84, 0x44, 0x18, 0x16,
-	0x43, 0xc2, 0xe3, 0x0b, 0xe2, 0xa9, 0x38, 0xa2, 0x91, 0x39, 0xa7, 0x86, 0x3b, 0xb2, 0xa6, 0xb3,
-	0xa8, 0x17, 0x09, 0x3f, 0xf6, 0xd2, 0x13, 0x4e, 0xa7, 0xd9, 0x04, 0xac, 0x8e, 0x24, 0x20, 0xba,
-	0x0f, 0x55, 0x79, 0x15, 0x3b, 0x35, 0xb3, 0xaa, 0x87, 0xfa, 0xf2, 0x2e, 0x48, 0xc8, 0x82, 0x81,
-	0x33, 0x6b, 0x16, 0xed, 0x0c, 0xff, 0xb3, 0x02, 0xf5, 0x7d, 0x26, 0xaf, 0x8e, 0xf8, 0x85, 0x30,
-	0x4a, 0x22, 0x0a, 0x89, 0xb2, 0x81, 0xd8, 0x19, 0xda, 0x82, 0x85, 0x73, 0xe2, 0x5d, 0x31, 0xde,
-	0x3d, 0x60, 0x01, 0xb5, 0x61, 0x64, 0x97, 0xd0, 0x23, 0x00, 0x1d, 0x2f, 0x09, 0x3a, 0x69, 0xfe,
-	0xd4, 0xdc, 0xcc, 0x8a, 0x46, 0xd0, 0x47, 0x92, 0x2a, 0xd4, 0x8c, 0x42, 0x76, 0x09, 0xff, 0xaf,
-	0x02, 0x4b, 0xcd, 0x20, 0x96, 0x8a, 0x46, 0x4d, 0xc1, 0x2f, 0x58, 0x17, 0xed, 0x00, 0x6a, 0xbd,
-	0xeb, 0x11, 0xee, 0xeb, 0xf8, 0x64, 0x8b, 0x93, 0xf3, 0x80, 0x26, 0xa9, 0x54, 0x77, 0x0b, 0x24,
-	0xe8, 0x8f, 0xb0, 0x71, 0x10, 0x51, 0xaa, 0xf3, 0xc1, 0xa5, 0x3d, 0x11, 0x29, 0xc6, 0xbb, 0xfb,
-	0x4c, 0x26, 0x66, 0x33, 0xc6, 0xac, 0x5c, 0x01, 0xbd, 0x00, 0x67, 0x4f, 0x78, 0x97, 0x72, 0x9f,
-	0xc9, 0x5e, 0x40, 0x06, 0x07, 0x22, 0x6a, 0x1d, 0x1c, 0x1d, 0xc6, 0x54, 0x2a, 0x69, 0xf6, 0x53,
-	0x77, 0x4b, 0xe5, 0xda, 0xb6, 0x43, 0x23, 0x46, 0x82, 0xa6, 0xe0, 0x52, 0x04, 0xf4, 0x95, 0xb8,
-	0x76, 0x5c, 0x4b, 0x6c, 0xcb, 0xe4, 0xf8, 0x53, 0xd8, 0x38, 0xe2, 0x8a, 0x46, 0x17, 0xc4, 0xa3,
-	0x7b, 0x8c, 0xfb, 0x8c, 0x77, 0x8f, 0x59, 0x37, 0x22, 0x4a, 0xdf, 0xe3, 0xba, 0x2e, 0x3e, 0x75,
-	0x29, 0xfc, 0xf4, 0x42, 0x92, 0x19, 0xfe, 0xef, 0x3c, 0xac, 0x9d, 0x25, 0x87, 0x77, 0x4c, 0xbc,
-	0x4b, 0xc6, 0xe9, 0x9b, 0x9e, 0x36, 0x90, 0xe8, 0x1b, 0x58, 0x1d, 0x15, 0x24, 0x99, 0x66, 0xfb,
-	0x5a, 0xbe, 0xda, 0x12, 0xb1, 0x5b, 0x68, 0x84, 0x9e, 0xc3, 0xda, 0x31, 0x0d, 0xf7, 0x48, 0x10,
-	0x08, 0xc1, 0x3b, 0x8a, 0x28, 0xd9, 0xa6, 0x11, 0x13, 0xc9, 0x69, 0x2e, 0xb9, 0xc5, 0x42, 0xf4,
-	0x7b, 0x58, 0x69, 0x47, 0x54, 0xaf, 0x7b, 0x44, 0x51, 0xff, 0x4c, 0x04, 0x71, 0x68, 0xeb, 0xb7,
-	0xe1, 0x16, 0x89, 0x74, 0x03, 0x56, 0xb6, 0xa6, 0xcc, 0x79, 0x15, 0x35, 0xe0, 0xb4, 0xe8, 0xdc,
-	0xa1, 0x2a, 0xea, 0x40, 0xc3, 0x24, 0x80, 0xce, 0x5d, 0x5b, 0xb9, 0x9f, 0xe5, 0xec, 0x0a, 0x8f,
-	0x69, 0x67, 0x68, 0xd7, 0xe2, 0x2a, 0x1a, 0xb8, 0xd7, 0x38, 0x25, 0x59, 0x37, 0x57, 0x9a, 0x75,
-	0xfb, 0xb0, 0xe4, 0x65, 0xd3, 0xd6, 0x99, 0x37, 0x1b, 0x78, 0x94, 0x6f, 0x03, 0x59, 0x2d, 0x77,
-	0xd4, 0x08, 0xfd, 0x5c, 0x81, 0x0d, 0x96, 0xa6, 0xc1, 0xbe, 0x08, 0x09, 0xe3, 0x5f, 0x2a, 0x45,
-	0xbc, 0xcb, 0x90, 0x72, 0xe5, 0xd4, 0xcd, 0xde, 0x5a, 0xef, 0xb9, 0xb7, 0xa3, 0x32, 0x9c, 0x64,
-	0xaf, 0xe5, 0x7e, 0x10, 0x07, 0x34, 0x14, 0x0e, 0x93, 0xd0, 0x69, 0x18, 0xef, 0x5f, 0xdc, 0xd5,
-	0xfb, 0x10, 0x20, 0x71, 0x5b, 0x80, 0xbc, 0xf9, 0x16, 0x96, 0x47, 0x2f, 0x42, 0x37, 0xae, 0x2b,
-	0x3a, 0xb0, 0xd9, 0xae, 0x87, 0x68, 0x37, 0xfb, 0xb8, 0x15, 0x25, 0x46, 0xda, 0xbd, 0xec, 0xbb,
-	0xf7, 0x62, 0xe6, 0x0f, 0x95, 0xcd, 0x57, 0xf0, 0xe8, 0xe6, 0x53, 0x28, 0x70, 0x34, 0xf2, 0x8a,
-	0x36, 0xb2, 0x68, 0x3f, 0xc2, 0x87, 0x25, 0xbb, 0x2a, 0x80, 0x79, 0x39, 0x1a, 0xef, 0xef, 0x72,
-	0xf1, 0x96, 0x56, 0x7b, 0xc6, 0x25, 0xee, 0x03, 0x9c, 0x1d, 0x1f, 0xb9, 0xf4, 0x47, 0xdd, 0x60,
-	0xd0, 0x13, 0xa8, 0xf6, 0x43, 0x66, 0x6b, 0x38, 0xff, 0x38, 0x69, 0x4d, 0xad, 0x80, 0x5e, 0xc2,
-	0xbc, 0x48, 0xae, 0xc1, 0x7a, 0x7f, 0xf2, 0x7e, 0x97, 0xe6, 0xa6, 0x66, 0xf8, 0x04, 0xee, 0x5f,
-	0xc7, 0x73, 0x47, 0xef, 0xce, 0xa8, 0xf7, 0xc5, 0x6b, 0xd4, 0x9f, 0x2b, 0xb0, 0xd0, 0x7a, 0x47,
-	0xbd, 0x14, 0xf1, 0x11, 0x80, 0x6f, 0x6e, 0xe5, 0x35, 0x09, 0xa9, 0x3d, 0xbc, 0xcc, 0x8a, 0x46,
-	0x6a, 0x8a, 0x30, 0x24, 0xdc, 0x4f, 0x9f, 0x3c, 0x3b, 0xd5, 0x5c, 0xe3, 0xcb, 0xa8, 0x9b, 0x36,
-	0x13, 0x33, 0x46, 0x4f, 0x60, 0x59, 0xb1, 0x90, 0x8a, 0x58, 0x75, 0xa8, 0x27, 0xb8, 0x2f, 0x4d,
-	0x0f, 0x99, 0x75, 0xc7, 0x56, 0xf1, 0x32, 0x2c, 0xb6, 0xc2, 0x9e, 0x1a, 0xd8, 0x28, 0xf0, 0x17,
-	0x50, 0x77, 0x33, 0x5c, 0x4e, 0xc6, 0x9e, 0x47, 0xa5, 0xb4, 0x0f, 0x4c, 0x3a, 0xd5, 0x92, 0x90,
-	0x4a, 0x49, 0xba, 0x69, 0x62, 0xa4, 0x53, 0xfc, 0x03, 0x2c, 0x27, 0xb9, 0x35, 0x29, 0x91, 0x5c,
-	0x87, 0xb9, 0x64, 0xf3, 0xd6, 0x83, 0x9d, 0x61, 0x0e, 0x2b, 0x89, 0x03, 0xd3, 0x5d, 0x27, 0xf5,
-	0xb2, 0x05, 0x0b, 0xfe, 0x35, 0x5a, 0xfa, 0x88, 0x67, 0x96, 0xf0, 0x3b, 0x78, 0x60, 0x1e, 0x34,
-	0x53, 0x4d, 0x13, 0x7a, 0xfb, 0x18, 0x1e, 0x74, 0xc7, 0xb1, 0xac, 0xcf, 0xbc, 0x00, 0xff, 0xa3,
-	0x02, 0x6b, 0xc6, 0xf5, 0xa9, 0xa4, 0xd1, 0x2b, 0x26, 0xd5, 0xa4, 0xee, 0x9f, 0xc3, 0x5a, 0xb7,
-	0x08, 0xcf, 0x86, 0x50, 0x2c, 0xc4, 0xff, 0xae, 0x80, 0x63, 0xc2, 0xd0, 0x9c, 0x46, 0x0e, 0xa4,
-	0xa2, 0xe1, 0xc4, 0xc7, 0xfe, 0x02, 0x9c, 0x6e, 0x09, 0xa4, 0x0d, 0xa6, 0x54, 0x8e, 0x07, 0xb0,
-	0x98, 0x94, 0xcd, 0x64, 0x21, 0x6c, 0x42, 0x9d, 0xbe, 0x63, 0xaa, 0x29, 0xfc, 0xc4, 0xe5, 0xac,
-	0x3b, 0x9c, 0xeb, 0xdc, 0x93, 0xca, 0x7f, 0x13, 0x2b, 0x4b, 0x21, 0xed, 0x0c, 0x7f, 0x07, 0xf7,
-	0xcd, 0x49, 0xb4, 0x35, 0x51, 0x7e, 0xcf, 0xb2, 0xcd, 0x17, 0xe2, 0x4c, 0x61, 0x21, 0x7e, 0x6d,
-	0xf3, 0x2c, 0xc1, 0x9e, 0x68, 0x6f, 0x58, 0xc0, 0x92, 0xe6, 0x74, 0x3f, 0xd1, 0xbb, 0x76, 0xab,
-	0xcf, 0x61, 0x3d, 0xe6, 0x17, 0xc6, 0xf4, 0xa4, 0x28, 0xe8, 0x12, 0x29, 0x7e, 0x0b, 0x0f, 0x92,
-	0x5f, 0x28, 0xfb, 0x71, 0xd8, 0xbb, 0xab, 0xd3, 0x4d, 0xa8, 0xfb, 0x71, 0xd8, 0x6b, 0x13, 0x75,
-	0x69, 0x2f, 0x7f, 0x38, 0xc7, 0xe7, 0xf0, 0x41, 0xa7, 0x75, 0x36, 0x8d, 0xda, 0xd3, 0xcd, 0x8c,
-	0xf6, 0x0d, 0x2b, 0xb2, 0x8d, 0xd8, 0x4e, 0xf1, 0xdf, 0x2b, 0xb0, 0xf1, 0xca, 0xfc, 0x66, 0x3e,
-	0xa6, 0x44, 0xc6, 0x11, 0xd5, 0x0f, 0xe2, 0x14, 0x4a, 0x3d, 0x18, 0xc7, 0xb4, 0x8e, 0xf3, 0x02,
-	0xfc, 0xbd, 0xe6, 0xbb, 0x7f, 0xa5, 0x9e, 0x4a, 0xe2, 0xe8, 0x50, 0x2f, 0xa2, 0x6a, 0x6a, 0x4f,
-	0xcd, 0xb3, 0xff, 0xaf, 0x40, 0xb5, 0x19, 0xfa, 0xe8, 0x35, 0xa0, 0xce, 0x80, 0x7b, 0xa3, 0xcf,
-	0x1d, 0xfa, 0x55, 0x21, 0x64, 0xe2, 0x7c, 0xb3, 0x7c, 0xb3, 0xf8, 0x1e, 0x7a, 0x03, 0x2b, 0x6d,
-	0x12, 0x4b, 0x3a, 0x35, 0xc0, 0x6f, 0x61, 0xed, 0x94, 0xf7, 0xa6, 0x0a, 0xd9, 0x81, 0xd5, 0xa4,
-	0x16, 0xc6, 0x10, 0xf3, 0x5c, 0x74, 0xa4, 0x64, 0x6e, 0x06, 0x75, 0x61, 0xfd, 0xd4, 0x56, 0xc2,
-	0xd4, 0x02, 0x3d, 0x01, 0xa7, 0x23, 0x2e, 0x94, 0x4b, 0xcf, 0x85, 0x50, 0x53, 0x43, 0x75, 0x61,
-	0xbd, 0x73, 0x19, 0x2b, 0x5f, 0xfc, 0x8d, 0x4f, 0x0d, 0xf3, 0x35, 0xa0, 0x6f, 0x58, 0x10, 0x4c,
-	0x0d, 0xaf, 0x0d, 0xab, 0xfb, 0x34, 0xa0, 0x6a, 0x7a, 0x67, 0xf9, 0x16, 0xd6, 0x12, 0xc6, 0x36,
-	0x0e, 0xf9, 0x9b, 0xfc, 0x97, 0x95, 0x31, 0x66, 0x77, 0x6b, 0xc6, 0xeb, 0x0a, 0x1a, 0x1a, 0x9d,
-	0x90, 0xa8, 0x4b, 0xd5, 0x04, 0x91, 0xfe, 0x09, 0x1e, 0x36, 0x09, 0xf7, 0xe8, 0xd8, 0x69, 0x5e,
-	0xff, 0xda, 0x9d, 0xec, 0xea, 0x59, 0x97, 0x93, 0x20, 0x09, 0xb2, 0x2d, 0xfc, 0x66, 0x40, 0x09,
-	0x8f, 0x7b, 0x13, 0x60, 0xfe, 0x19, 0x1e, 0x1f, 0x30, 0x4e, 0x02, 0x36, 0x9e, 0xf8, 0xd3, 0x08,
-	0xf8, 0x35, 0xa0, 0xaf, 0x84, 0xea, 0x05, 0x71, 0xf7, 0x2b, 0x21, 0xd5, 0x3e, 0xed, 0x33, 0x8f,
-	0xca, 0x09, 0xf0, 0x8e, 0xa1, 0x71, 0x48, 0x55, 0xc2, 0x16, 0xd1, 0xc3, 0x9c, 0x66, 0x96, 0xf7,
-	0x6e, 0x3e, 0xce, 0xff, 0x84, 0x1a, 0xa1, 0xb1, 0x26, 0xa9, 0x96, 0x87, 0x70, 0x86, 0x1b, 0xde,
-	0x86, 0xf9, 0xdb, 0x12, 0xcc, 0x11, 0xe6, 0x6a, 0x5a, 0xd4, 0xe2, 0x21, 0x55, 0x43, 0x96, 0x79,
-	0x1b, 0x2c, 0xce, 0x89, 0x73, 0x04, 0xd5, 0x80, 0xd6, 0x0f, 0xa9, 0x61, 0x73, 0xb7, 0xc6, 0xf9,
-	0xa4, 0x18, 0x30, 0xc7, 0x04, 0xef, 0xa1, 0xbf, 0x98, 0x23, 0xc8, 0xb0, 0xb2, 0xdb, 0xa0, 0x3f,
-	0x2a, 0x86, 0x2e, 0xe2, 0x75, 0xf7, 0xd0, 0x1e, 0xd4, 0x34, 0xfb, 0xb9, 0x0d, 0xf3, 0xc6, 0x3b,
-	0x6f, 0x41, 0x4d, 0xb3, 0x43, 0xf4, 0xeb, 0x3c, 0xc6, 0xf5, 0x6f, 0xad, 0xcd, 0x87, 0x25, 0xd2,
-	0x4c, 0x33, 0x6e, 0x0c, 0xd9, 0x58, 0x41, 0xd3, 0x18, 0x67, 0x81, 0x65, 0x77, 0x92, 0x25, 0x73,
-	0xa6, 0x7a, 0x9c, 0xb1, 0xaa, 0x19, 0x92, 0x26, 0x84, 0x4b, 0xbe, 0xf9, 0x66, 0x18, 0xd5, 0x6d,
-	0x3d, 0x4f, 0xdf, 0x4d, 0xe6, 0x53, 0xfe, 0xdd, 0xd3, 0xb3, 0xe0, 0xff, 0x00, 0xb6, 0x8f, 0xe4,
-	0x58, 0x43, 0xb3, 0x7d, 0x2a, 0x27, 0x7c, 0xec, 0x72, 0x98, 0xf6, 0x93, 0xfa, 0x24, 0x7c, 0x04,
-	0x0e, 0xa9, 0xb2, 0x84, 0xf1, 0xb6, 0xed, 0x6f, 0xe5, 0x3f, 0xfb, 0x8d, 0x32, 0x4d, 0x7c, 0x0f,
-	0x11, 0x58, 0x3d, 0xa4, 0x2a, 0x47, 0x0e, 0x6f, 0x0e, 0x31, 0xff, 0x75, 0xa3, 0x94, 0x5d, 0xe2,
-	0x7b, 0xe8, 0x7b, 0x40, 0x79, 0xea, 0x87, 0x8a, 0xbe, 0x90, 0x94, 0xf0, 0xc3, 0x1b, 0x8f, 0x64,
-	0xaf, 0xf6, 0xdd, 0x4c, 0xff, 0xe9, 0xf9, 0x9c, 0xf9, 0xdf, 0xcf, 0xa7, 0xbf, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x7a, 0xa3, 0xf8, 0x64, 0x28, 0x1a, 0x00, 0x00,
+	// 1840 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x5f, 0x73, 0x1b, 0xb7,
+	0x11, 0x17, 0x45, 0x4a, 0x22, 0x57, 0x7f, 0x62, 0xc3, 0x92, 0x72, 0x52, 0x6b, 0x59, 0xc5, 0x74,
+	0x3c, 0x4a, 0x27, 0x91, 0x6a, 0xc7, 0xc9, 0x74, 0x3c, 0x9d, 0x8c, 0x23, 0x8a, 0x52, 0x94, 0x58,
+	0x36, 0x73, 0x94, 0x94, 0x69, 0xda, 0x4c, 0x06, 0xba, 0x83, 0x28, 0x54, 0x77, 0xc0, 0xf9, 0x80,
+	0x63, 0x4d, 0x3f, 0x75, 0xc6, 0x9d, 0x3e, 0x74, 0xa6, 0x1f, 0xad, 0xcf, 0x7d, 0xeb, 0xb7, 0xe8,
+	0x7b, 0x07, 0xb8, 0x3b, 0xea, 0xc8, 0xbb, 0x13, 0xad, 0x92, 0x4f, 0x02, 0xb0, 0xbb, 0xbf, 0x5d,
+	0x00, 0xbb, 0x8b, 0x1f, 0x4f, 0xf0, 0x49, 0x70, 0xdd, 0xdd, 0xbb, 0x22, 0xdc, 0xf5, 0x68, 0xf8,
+	0x99, 0x47, 0x22, 0xee, 0x5c, 0xd1, 0xf0, 0x33, 0x47, 0xf8, 0x7b, 0x8e, 0xef, 0xee, 0xf5, 0x9e,
+	0xe8, 0x3f, 0xbb, 0x41, 0x28, 0x94, 0x40, 0x1f, 0x5d, 0x47, 0x17, 0xb4, 0xc7, 0x42, 0xb5, 0xab,
+	0xd7, 0x7a, 0x4f, 0xf0, 0x25, 0x3c, 0xf8, 0x9e, 0xfa, 0xd1, 0x39, 0x0d, 0x25, 0x13, 0xdc, 0xa6,
+	0x32, 0x10, 0x5c, 0x52, 0xf4, 0x05, 0xd4, 0xc3, 0x64, 0x6c, 0x55, 0xb6, 0x2b, 0x3b, 0x8b, 0x4f,
+	0x37, 0x76, 0x47, 0x4c, 0x77, 0x53, 0x65, 0x7b, 0xa0, 0x8a, 0x2c, 0x58, 0xe8, 0xc5, 0x48, 0xd6,
+	0xec, 0x76, 0x65, 0xa7, 0x61, 0xa7, 0x53, 0xfc, 0x08, 0xaa, 0xe7, 0x27, 0xc7, 0x46, 0xc1, 0x67,
+	0xdf, 0x4a, 0xc1, 0x0d, 0xec, 0x92, 0x9d, 0x4e, 0xf1, 0x13, 0xa8, 0x36, 0xdb, 0x67, 0x68, 0x05,
+	0x66, 0x99, 0x6b, 0x64, 0xcb, 0xf6, 0x2c, 0x73, 0xd1, 0x26, 0xd4, 0x25, 0xbb, 0xf0, 0x18, 0xef,
+	0x4a, 0x6b, 0x76, 0xbb, 0xba, 0xb3, 0x6c, 0x0f, 0xe6, 0x78, 0x0f, 0x16, 0x3a, 0xf1, 0x38, 0x67,
+	0xb6, 0x0a, 0x73, 0x3d, 0xe2, 0x45, 0xd4, 0x84, 0x51, 0xb3, 0xe3, 0x09, 0x6e, 0xc1, 0x5c, 0x9b,
+	0x74, 0xa9, 0xd4, 0x62, 0x47, 0x44, 0x5c, 0x19, 0x8b, 0x9a, 0x1d, 0x4f, 0x10, 0x82, 0x5a, 0xc4,
+	0x99, 0x4a, 0x42, 0x37, 0x63, 0xbd, 0x26, 0xd9, 0x3b, 0x6a, 0x55, 0x0d, 0xb4, 0x19, 0xe3, 0x67,
+	0x30, 0x7f, 0x42, 0x7d, 0x11, 0xf6, 0xd1, 0x3a, 0xcc, 0x13, 0x3f, 0x03, 0x94, 0xcc, 0x8a, 0x90,
+	0xf0, 0xbf, 0x2b, 0x50, 0x6b, 0x52, 0xcf, 0xcb, 0xc5, 0xba, 0x07, 0xf3, 0xbe, 0x81, 0x33, 0xea,
+	0x8b, 0x4f, 0x3f, 0xce, 0x9d, 0x74, 0xec, 0xcd, 0x4e, 0xd4, 0xd0, 0xa7, 0x30, 0x17, 0xe8, 0x6d,
+	0x58, 0xd5, 0xed, 0xea, 0xce, 0xe2, 0xd3, 0xf5, 0x9c, 0xbe, 0xd9, 0xa4, 0x1d, 0x2b, 0xa1, 0x2f,
+	0xa1, 0xe1, 0x32, 0xa9, 0x08, 0x77, 0xa8, 0xb4, 0x6a, 0xc6, 0xc2, 0xca, 0x59, 0x24, 0xe7, 0x68,
+	0xdf, 0xa8, 0xa2, 0x1d, 0xa8, 0x39, 0x41, 0x24, 0xad, 0x39, 0x63, 0xb2, 0x9a, 0x33, 0x69, 0xb6,
+	0xcf, 0x6c, 0xa3, 0x81, 0x5f, 0x40, 0xfd, 0x54, 0x04, 0xc2, 0x13, 0xdd, 0x3e, 0x7a, 0x06, 0xc0,
+	0x23, 0x9f, 0xfc, 0xec, 0x50, 0xcf, 0x93, 0x56, 0xc5, 0xd8, 0xae, 0xe5, 0x6d, 0xa9, 0xe7, 0xd9,
+	0x0d, 0xad, 0xa8, 0x47, 0x12, 0xff, 0xa3, 0x02, 0xf3, 0x9d, 0x93, 0x7d, 0x26, 0x24, 0xc2, 0xb0,
+	0xe4, 0x13, 0x1e, 0x5d, 0x12, 0x47, 0x45, 0x21, 0x0d, 0xcd, 0x39, 0x35, 0xec, 0xa1, 0x35, 0x9d,
+	0x45, 0x41, 0x28, 0xdc, 0xc8, 0x49, 0x4f, 0x38, 0x9d, 0x66, 0x13, 0xb0, 0x3a, 0x94, 0x80, 0xe8,
+	0x1e, 0x54, 0xe5, 0x75, 0x64, 0xd5, 0xcc, 0xaa, 0x1e, 0xea, 0xcb, 0xbb, 0x24, 0x3e, 0xf3, 0xfa,
+	0xd6, 0x9c, 0x59, 0x4c, 0x66, 0xf8, 0xef, 0x15, 0xa8, 0x1f, 0x30, 0x79, 0x7d, 0xcc, 0x2f, 0x85,
+	0x51, 0x12, 0xa1, 0x4f, 0x54, 0x12, 0x48, 0x32, 0x43, 0xdb, 0xb0, 0x78, 0x41, 0x9c, 0x6b, 0xc6,
+	0xbb, 0x87, 0xcc, 0xa3, 0x49, 0x18, 0xd9, 0x25, 0xb4, 0x05, 0xa0, 0xe3, 0x25, 0x5e, 0x27, 0xcd,
+	0x9f, 0x9a, 0x9d, 0x59, 0xd1, 0x08, 0xfa, 0x48, 0x52, 0x85, 0x9a, 0x51, 0xc8, 0x2e, 0xe1, 0xff,
+	0x56, 0x60, 0xb9, 0xe9, 0x45, 0x52, 0xd1, 0xb0, 0x29, 0xf8, 0x25, 0xeb, 0xa2, 0x5d, 0x40, 0xad,
+	0xb7, 0x01, 0xe1, 0xae, 0x8e, 0x4f, 0xb6, 0x38, 0xb9, 0xf0, 0x68, 0x9c, 0x4a, 0x75, 0xbb, 0x40,
+	0x82, 0x7e, 0x0f, 0x1b, 0x87, 0x21, 0xa5, 0x3a, 0x1f, 0x6c, 0x1a, 0x88, 0x50, 0x31, 0xde, 0x3d,
+	0x60, 0x32, 0x36, 0x9b, 0x35, 0x66, 0xe5, 0x0a, 0xe8, 0x39, 0x58, 0xfb, 0xc2, 0xb9, 0x92, 0x07,
+	0x4c, 0x06, 0x1e, 0xe9, 0x1f, 0x8a, 0xb0, 0x75, 0x78, 0x7c, 0x14, 0x51, 0xa9, 0xa4, 0xd9, 0x4f,
+	0xdd, 0x2e, 0x95, 0x6b, 0xdb, 0x0e, 0x0d, 0x19, 0xf1, 0x9a, 0x82, 0x4b, 0xe1, 0xd1, 0x97, 0xe2,
+	0xc6, 0x71, 0x2d, 0xb6, 0x2d, 0x93, 0xe3, 0xcf, 0x61, 0xe3, 0x98, 0x2b, 0x1a, 0x5e, 0x12, 0x87,
+	0xee, 0x33, 0xee, 0x32, 0xde, 0x3d, 0x61, 0xdd, 0x90, 0x28, 0x7d, 0x8f, 0xeb, 0xba, 0xf8, 0xd4,
+	0x95, 0x70, 0xd3, 0x0b, 0x89, 0x67, 0xf8, 0x3f, 0x0b, 0xb0, 0x76, 0x1e, 0x1f, 0xde, 0x09, 0x71,
+	0xae, 0x18, 0xa7, 0xaf, 0x03, 0x6d, 0x20, 0xd1, 0x77, 0xb0, 0x3a, 0x2c, 0x88, 0x33, 0xcd, 0xaa,
+	0x94, 0x54, 0x5b, 0x2c, 0xb6, 0x0b, 0x8d, 0xd0, 0x33, 0x58, 0x3b, 0xa1, 0xfe, 0x3e, 0xf1, 0x3c,
+	0x21, 0x78, 0x47, 0x11, 0x25, 0xdb, 0x34, 0x64, 0x22, 0x3e, 0xcd, 0x65, 0xbb, 0x58, 0x88, 0x7e,
+	0x0b, 0x0f, 0xda, 0x21, 0xd5, 0xeb, 0x0e, 0x51, 0xd4, 0x3d, 0x17, 0x5e, 0xe4, 0x27, 0xf5, 0xdb,
+	0xb0, 0x8b, 0x44, 0xba, 0x01, 0xab, 0xa4, 0xa6, 0xac, 0x5a, 0x49, 0x03, 0x4e, 0x8b, 0xce, 0x1e,
+	0xa8, 0xa2, 0x0e, 0x34, 0x4c, 0x02, 0xe8, 0xdc, 0x4d, 0x2a, 0xf7, 0x8b, 0x9c, 0x5d, 0xe1, 0x31,
+	0xed, 0x0e, 0xec, 0x5a, 0x5c, 0x85, 0x7d, 0xfb, 0x06, 0xa7, 0x24, 0xeb, 0xe6, 0x4b, 0xb3, 0xee,
+	0x00, 0x96, 0x9d, 0x6c, 0xda, 0x5a, 0x0b, 0x66, 0x03, 0x5b, 0xf9, 0x36, 0x90, 0xd5, 0xb2, 0x87,
+	0x8d, 0xd0, 0xfb, 0x0a, 0x6c, 0xb0, 0x34, 0x0d, 0x0e, 0x84, 0x4f, 0x18, 0xff, 0x5a, 0x29, 0xe2,
+	0x5c, 0xf9, 0x94, 0x2b, 0xab, 0x6e, 0xf6, 0xd6, 0xfa, 0xc0, 0xbd, 0x1d, 0x97, 0xe1, 0xc4, 0x7b,
+	0x2d, 0xf7, 0x83, 0x38, 0xa0, 0x81, 0x70, 0x90, 0x84, 0x56, 0xc3, 0x78, 0xff, 0xea, 0xae, 0xde,
+	0x07, 0x00, 0xb1, 0xdb, 0x02, 0xe4, 0xcd, 0x1f, 0x60, 0x65, 0xf8, 0x22, 0x74, 0xe3, 0xba, 0xa6,
+	0xfd, 0x24, 0xdb, 0xf5, 0x10, 0xed, 0x65, 0x1f, 0xb7, 0xa2, 0xc4, 0x48, 0xbb, 0x57, 0xf2, 0xee,
+	0x3d, 0x9f, 0xfd, 0x5d, 0x65, 0xf3, 0x25, 0x6c, 0xdd, 0x7e, 0x0a, 0x05, 0x8e, 0x86, 0x5e, 0xd1,
+	0x46, 0x16, 0xed, 0x0d, 0x7c, 0x5c, 0xb2, 0xab, 0x02, 0x98, 0x17, 0xc3, 0xf1, 0xfe, 0x26, 0x17,
+	0x6f, 0x69, 0xb5, 0x67, 0x5c, 0xe2, 0x1e, 0xc0, 0xf9, 0xc9, 0xb1, 0x4d, 0xdf, 0xe8, 0x06, 0x83,
+	0x1e, 0x43, 0xb5, 0xe7, 0xb3, 0xa4, 0x86, 0xf3, 0x8f, 0x93, 0xd6, 0xd4, 0x0a, 0xe8, 0x05, 0x2c,
+	0x88, 0xf8, 0x1a, 0x12, 0xef, 0x8f, 0x3f, 0xec, 0xd2, 0xec, 0xd4, 0x0c, 0x9f, 0xc2, 0xbd, 0x9b,
+	0x78, 0xee, 0xe8, 0xdd, 0x1a, 0xf6, 0xbe, 0x74, 0x83, 0xfa, 0xbe, 0x02, 0x8b, 0xad, 0xb7, 0xd4,
+	0x49, 0x11, 0xb7, 0x00, 0x5c, 0x73, 0x2b, 0xaf, 0x88, 0x4f, 0x93, 0xc3, 0xcb, 0xac, 0x68, 0xa4,
+	0xa6, 0xf0, 0x7d, 0xc2, 0xdd, 0xf4, 0xc9, 0x4b, 0xa6, 0x9a, 0x6b, 0x7c, 0x1d, 0x76, 0xd3, 0x66,
+	0x62, 0xc6, 0xe8, 0x31, 0xac, 0x28, 0xe6, 0x53, 0x11, 0xa9, 0x0e, 0x75, 0x04, 0x77, 0xa5, 0xe9,
+	0x21, 0x73, 0xf6, 0xc8, 0x2a, 0x5e, 0x81, 0xa5, 0x96, 0x1f, 0xa8, 0x7e, 0x12, 0x05, 0xfe, 0x0a,
+	0xea, 0x76, 0x86, 0xcb, 0xc9, 0xc8, 0x71, 0xa8, 0x94, 0xc9, 0x03, 0x93, 0x4e, 0xb5, 0xc4, 0xa7,
+	0x52, 0x92, 0x6e, 0x9a, 0x18, 0xe9, 0x14, 0xff, 0x0c, 0x2b, 0x71, 0x6e, 0x4d, 0x4a, 0x24, 0xd7,
+	0x61, 0x3e, 0xde, 0x7c, 0xe2, 0x21, 0x99, 0x61, 0x0e, 0x0f, 0x62, 0x07, 0xa6, 0xbb, 0x4e, 0xea,
+	0x65, 0x1b, 0x16, 0xdd, 0x1b, 0xb4, 0xf4, 0x11, 0xcf, 0x2c, 0xe1, 0xb7, 0x70, 0xdf, 0x3c, 0x68,
+	0xa6, 0x9a, 0x26, 0xf4, 0xf6, 0x29, 0xdc, 0xef, 0x8e, 0x62, 0x25, 0x3e, 0xf3, 0x02, 0xfc, 0xb7,
+	0x0a, 0xac, 0x19, 0xd7, 0x67, 0x92, 0x86, 0x2f, 0x99, 0x54, 0x93, 0xba, 0x7f, 0x06, 0x6b, 0xdd,
+	0x22, 0xbc, 0x24, 0x84, 0x62, 0x21, 0xfe, 0x67, 0x05, 0x2c, 0x13, 0x86, 0xe6, 0x34, 0xb2, 0x2f,
+	0x15, 0xf5, 0x27, 0x3e, 0xf6, 0xe7, 0x60, 0x75, 0x4b, 0x20, 0x93, 0x60, 0x4a, 0xe5, 0xb8, 0x0f,
+	0x4b, 0x71, 0xd9, 0x4c, 0x16, 0xc2, 0x26, 0xd4, 0xe9, 0x5b, 0xa6, 0x9a, 0xc2, 0x8d, 0x5d, 0xce,
+	0xd9, 0x83, 0xb9, 0xce, 0x3d, 0xa9, 0xdc, 0xd7, 0x91, 0x4a, 0x28, 0x64, 0x32, 0xc3, 0x3f, 0xc2,
+	0x3d, 0x73, 0x12, 0x6d, 0x4d, 0x94, 0x3f, 0xb0, 0x6c, 0xf3, 0x85, 0x38, 0x5b, 0x58, 0x88, 0xdf,
+	0xc2, 0xfd, 0x0c, 0xf6, 0x44, 0x7b, 0xc3, 0x02, 0x96, 0x35, 0xa7, 0x7b, 0x47, 0xef, 0xda, 0xad,
+	0xbe, 0x84, 0xf5, 0x88, 0x5f, 0x1a, 0xd3, 0xd3, 0xa2, 0xa0, 0x4b, 0xa4, 0xf8, 0x1a, 0xee, 0xc7,
+	0xbf, 0x50, 0x0e, 0x22, 0x3f, 0xb8, 0xab, 0xd3, 0x4d, 0xa8, 0xbb, 0x91, 0x1f, 0xb4, 0x89, 0xba,
+	0x4a, 0x2e, 0x7f, 0x30, 0xd7, 0xad, 0x4d, 0xf5, 0x03, 0x9a, 0xdc, 0x83, 0x19, 0xe3, 0x0b, 0xf8,
+	0xa8, 0xd3, 0x3a, 0x9f, 0x46, 0x3d, 0xea, 0x06, 0x47, 0x7b, 0x86, 0x29, 0x25, 0xcd, 0x39, 0x99,
+	0xe2, 0xbf, 0x56, 0x60, 0xe3, 0xa5, 0xf9, 0x1d, 0x7d, 0x42, 0x89, 0x8c, 0x42, 0xaa, 0x1f, 0xc9,
+	0x29, 0x94, 0xbf, 0x37, 0x8a, 0x99, 0x38, 0xce, 0x0b, 0xf0, 0x4f, 0x9a, 0x03, 0xff, 0x99, 0x3a,
+	0x2a, 0x8e, 0xa3, 0x43, 0x9d, 0x90, 0xaa, 0xe9, 0x3d, 0x3f, 0x6f, 0x60, 0x79, 0x9f, 0x38, 0xd7,
+	0xd1, 0x9d, 0xaf, 0x6b, 0x0b, 0xe0, 0xc2, 0x18, 0x66, 0x2e, 0x2c, 0xb3, 0x92, 0x75, 0x59, 0x1d,
+	0x72, 0xf9, 0xf4, 0x5f, 0xab, 0x50, 0x6d, 0xfa, 0x2e, 0x7a, 0x05, 0xa8, 0xd3, 0xe7, 0xce, 0xf0,
+	0xab, 0x8b, 0x7e, 0x51, 0xe8, 0x32, 0x0e, 0x6e, 0xb3, 0xfc, 0x7c, 0xf1, 0x0c, 0x7a, 0x0d, 0x0f,
+	0xda, 0x24, 0x92, 0x74, 0x6a, 0x80, 0xdf, 0xc3, 0xda, 0x19, 0x0f, 0xa6, 0x0a, 0xd9, 0x81, 0xd5,
+	0xb8, 0x24, 0x47, 0x10, 0xf3, 0x94, 0x78, 0xa8, 0x72, 0x6f, 0x07, 0xb5, 0x61, 0xfd, 0x8c, 0x5f,
+	0x16, 0xc1, 0xfe, 0xff, 0x81, 0x9e, 0x82, 0xd5, 0x11, 0x97, 0xca, 0xa6, 0x17, 0x42, 0xa8, 0xa9,
+	0xa1, 0xda, 0xb0, 0xde, 0xb9, 0x8a, 0x94, 0x2b, 0xfe, 0xc2, 0xa7, 0x86, 0xf9, 0x0a, 0xd0, 0x77,
+	0xcc, 0xf3, 0xa6, 0x86, 0xd7, 0x86, 0xd5, 0x03, 0xea, 0x51, 0x35, 0xbd, 0xb3, 0xfc, 0x01, 0xd6,
+	0x62, 0xe2, 0x38, 0x0a, 0xf9, 0xab, 0x9c, 0xd5, 0x28, 0xc1, 0x1c, 0x9b, 0xf1, 0xba, 0x82, 0x06,
+	0x46, 0xa7, 0x24, 0xec, 0x52, 0x35, 0x41, 0xa4, 0x7f, 0x80, 0x87, 0x4d, 0xfd, 0xd1, 0x67, 0xe4,
+	0x34, 0x07, 0x0e, 0x26, 0xbc, 0x7a, 0xd6, 0xe5, 0xc4, 0x8b, 0x83, 0x6c, 0x0b, 0xb7, 0xe9, 0x51,
+	0xc2, 0xa3, 0x60, 0x02, 0xcc, 0x3f, 0xc2, 0xa3, 0x43, 0xc6, 0x89, 0xc7, 0xde, 0xd1, 0xe9, 0x07,
+	0xfc, 0x0a, 0xd0, 0x37, 0x42, 0x05, 0x5e, 0xd4, 0xfd, 0x46, 0x48, 0x75, 0x40, 0x7b, 0xcc, 0xa1,
+	0x72, 0x02, 0xbc, 0x13, 0x68, 0x1c, 0x51, 0x15, 0x93, 0x56, 0xf4, 0x30, 0xa7, 0x99, 0xa5, 0xdf,
+	0x9b, 0x8f, 0xf2, 0xbf, 0xe4, 0x86, 0xd8, 0xb4, 0x49, 0xaa, 0x95, 0x01, 0x9c, 0xa1, 0xa8, 0xe3,
+	0x30, 0x7f, 0x5d, 0x82, 0x39, 0x44, 0xa0, 0x4d, 0x8b, 0x5a, 0x3a, 0xa2, 0x6a, 0x40, 0x76, 0xc7,
+	0xc1, 0xe2, 0x9c, 0x38, 0xc7, 0x93, 0x0d, 0x68, 0xfd, 0x88, 0x1a, 0x52, 0x39, 0x36, 0xce, 0xc7,
+	0xc5, 0x80, 0x39, 0x42, 0x3a, 0x83, 0xfe, 0x64, 0x8e, 0x20, 0x43, 0x0e, 0xc7, 0x41, 0x7f, 0x52,
+	0x0c, 0x5d, 0x44, 0x2f, 0x67, 0xd0, 0x3e, 0xd4, 0x34, 0x09, 0x1b, 0x87, 0x79, 0xeb, 0x9d, 0xb7,
+	0xa0, 0xa6, 0x49, 0x2a, 0xfa, 0x65, 0x1e, 0xe3, 0xe6, 0x27, 0xdf, 0xe6, 0xc3, 0x12, 0x69, 0xa6,
+	0x19, 0x37, 0x06, 0xa4, 0xb0, 0xa0, 0x69, 0x8c, 0x92, 0xd1, 0x4d, 0x7c, 0x9b, 0x4a, 0xa6, 0x7a,
+	0xac, 0x91, 0xaa, 0x19, 0x70, 0x37, 0x84, 0x4b, 0x3e, 0x3d, 0x67, 0x88, 0xdd, 0xb8, 0x9e, 0xa7,
+	0xef, 0x26, 0xf3, 0x1f, 0x85, 0xbb, 0xa7, 0x67, 0xc1, 0xbf, 0x23, 0x92, 0x3e, 0x92, 0x63, 0x0d,
+	0xcd, 0xf6, 0x99, 0x9c, 0xf0, 0xb1, 0xcb, 0x61, 0xc6, 0x1b, 0x9e, 0x88, 0x8f, 0xc0, 0x11, 0x55,
+	0x09, 0x47, 0x1d, 0xb7, 0xfd, 0xed, 0x9c, 0x78, 0x84, 0xdc, 0xe2, 0x19, 0x44, 0x60, 0xf5, 0x88,
+	0xaa, 0x1c, 0x1f, 0xbd, 0x3d, 0xc4, 0xfc, 0x47, 0x96, 0x52, 0x42, 0x8b, 0x67, 0xd0, 0x4f, 0x80,
+	0xf2, 0x6c, 0x13, 0x15, 0x7d, 0xa8, 0x29, 0xa1, 0xa4, 0x63, 0xe9, 0xcf, 0xf0, 0x21, 0xc7, 0xdc,
+	0xb3, 0x80, 0xfe, 0x0c, 0x91, 0xd2, 0x5b, 0x41, 0xf7, 0x6b, 0x3f, 0xce, 0xf6, 0x9e, 0x5c, 0xcc,
+	0x9b, 0xff, 0x6b, 0x7d, 0xfe, 0xbf, 0x01, 0x00, 0xd3, 0x79, 0x52, 0xfa, 0x04, 0x1b, 0x00, 0x00,
 }
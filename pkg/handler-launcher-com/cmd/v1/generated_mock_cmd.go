@@ -510,6 +510,22 @@ func (_mr *_MockCmdClientRecorder) InjectLaunchSecret(arg0, arg1 interface{}, ar
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "InjectLaunchSecret", _s...)
 }
 
+func (_m *MockCmdClient) VirtualMachineBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error) {
+	_s := []interface{}{ctx, in}
+	for _, _x := range opts {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "VirtualMachineBackup", _s...)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdClientRecorder) VirtualMachineBackup(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1}, arg2...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineBackup", _s...)
+}
+
 // Mock of CmdServer interface
 type MockCmdServer struct {
 	ctrl     *gomock.Controller
@@ -860,3 +876,14 @@ func (_m *MockCmdServer) InjectLaunchSecret(_param0 context.Context, _param1 *In
 func (_mr *_MockCmdServerRecorder) InjectLaunchSecret(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "InjectLaunchSecret", arg0, arg1)
 }
+
+func (_m *MockCmdServer) VirtualMachineBackup(_param0 context.Context, _param1 *BackupRequest) (*Response, error) {
+	ret := _m.ctrl.Call(_m, "VirtualMachineBackup", _param0, _param1)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdServerRecorder) VirtualMachineBackup(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineBackup", arg0, arg1)
+}
@@ -0,0 +1,92 @@
+package hypervisor
+
+// Capabilities describes which optional VMI features a hypervisor is able to provide.
+// Features which are not supported are rejected at admission time instead of being
+// silently dropped by the converter or failing when the domain is started.
+type Capabilities struct {
+	// Graphics devices and VNC access
+	Graphics bool
+
+	// USB redirection through client passthrough devices
+	USBRedirection bool
+
+	// AMD SEV launch security
+	SEV bool
+
+	// UEFI Secure Boot, which requires a firmware build enrolling the Secure Boot keys
+	SecureBoot bool
+
+	// EFI variables kept across reboots in a pflash variable store on the backend storage.
+	// Cloud Hypervisor loads its firmware like a kernel and has no pflash device, so the firmware keeps
+	// the variables in guest memory and they are lost when the VMI stops. Persistent EFI is intentionally
+	// not offered for it rather than keeping a store which the guest never writes to.
+	PersistentEFI bool
+
+	// CPU hotplug by raising the number of sockets of a running VMI
+	CPUHotplug bool
+
+	// Memory hotplug by raising the guest memory of a running VMI
+	MemoryHotplug bool
+
+	// Hotplug and hotunplug of disks on a running VMI
+	DiskHotplug bool
+
+	// Saving the memory state of a running VMI into a VirtualMachineSnapshot and resuming the VMI from it on restore.
+	// It relies on a libvirt memory only snapshot, which keeps the domain running. The libvirt ch driver can
+	// only save a domain by stopping it, so memory state snapshots are intentionally limited to qemu.
+	MemoryState bool
+
+	// Guest agent channel provided by libvirt, otherwise the guest agent is reached over a vsock device
+	GuestAgentChannel bool
+
+	// Emulated watchdog devices
+	Watchdog bool
+
+	// Emulated sound devices
+	Sound bool
+
+	// Live migration of running VMIs
+	LiveMigration bool
+
+	// Live migration of VMIs with local disks which need to be copied to the target
+	BlockMigration bool
+
+	// Switching a stalled live migration to post-copy mode
+	PostCopyMigration bool
+
+	// Throttling the vCPUs of a live migration which does not converge
+	AutoConvergeMigration bool
+
+	// Transferring the guest memory over multiple parallel connections
+	ParallelMigration bool
+
+	// Reporting the remaining data of a live migration, otherwise stuck migrations are detected from the transferred data
+	MigrationProgress bool
+}
+
+var qemuCapabilities = Capabilities{
+	Graphics:       true,
+	USBRedirection: true,
+	SEV:            true,
+	SecureBoot:     true,
+	PersistentEFI:  true,
+	CPUHotplug:     true,
+	MemoryHotplug:  true,
+	DiskHotplug:    true,
+	MemoryState:    true,
+	Watchdog:       true,
+	Sound:          true,
+
+	GuestAgentChannel: true,
+
+	LiveMigration:         true,
+	BlockMigration:        true,
+	PostCopyMigration:     true,
+	AutoConvergeMigration: true,
+	ParallelMigration:     true,
+	MigrationProgress:     true,
+}
+
+var cloudHypervisorCapabilities = Capabilities{
+	LiveMigration: true,
+}
@@ -1,16 +1,24 @@
 package hypervisor
 
 import (
+	"fmt"
+	"path/filepath"
 	"regexp"
 
+	v1 "kubevirt.io/api/core/v1"
+
 	"kubevirt.io/kubevirt/pkg/util"
 )
 
-// TODO These global variables should be changed to accessor functions in the Hypervisor interface
-var HypervisorDaemonExecutables []string = []string{"virtqemud", "virtchd"}
+const (
+	cloudHypervisorEFIFirmware  = "/usr/share/cloud-hypervisor/CLOUDHV_EFI.fd"
+	cloudHypervisorBIOSFirmware = "/usr/share/cloud-hypervisor/hypervisor-fw"
+)
 
+// CloudHypervisor runs VMIs with Cloud Hypervisor, accelerated either by MSHV or by KVM
 type CloudHypervisor struct {
 	user uint32
+	kvm  bool
 }
 
 // Implement SupportsMemoryBallooning method for CloudHypervisor
@@ -30,27 +38,41 @@ func (c *CloudHypervisor) GetDiskDriver() string {
 
 // Implement GetDomainType method for CloudHypervisor
 func (c *CloudHypervisor) GetDomainType() string {
+	if c.kvm {
+		return "kvm"
+	}
 	return "hyperv"
 }
 
-// Implement GetLibvirtSocketPath method for CloudHypervisor
+// Implement GetLibvirtSocketPath method for CloudHypervisor. virtchd runs as a modular daemon
+// and listens on its default socket, the same one GetLibvirtUriAndUser connects to.
 func (c *CloudHypervisor) GetLibvirtSocketPath() string {
-	return "libvirt/ch-sock" // TODO: Check this
+	return "libvirt/virtchd-sock"
 }
 
 // Implement GetVcpuRegex method for CloudHypervisor
 func (c *CloudHypervisor) GetVcpuRegex() *regexp.Regexp {
-	// parse thread comm value expression for MSHV
+	// parse thread comm value expression, Cloud Hypervisor names its vCPU threads the same way on MSHV and KVM
 	return regexp.MustCompile(`^vcpu(\d+)\n$`) // These threads follow this naming pattern as their command value (/proc/{pid}/task/{taskid}/comm)
 }
 
 // Implement ShouldRunPrivileged method for CloudHypervisor
 func (c *CloudHypervisor) ShouldRunPrivileged() bool {
-	return true
+	// /dev/mshv and /dev/kvm are provided by the device plugin, and a root Cloud Hypervisor
+	// sets up the guest networking with CAP_NET_ADMIN
+	return false
+}
+
+// Implement ShouldUseRuntimeDefaultSeccompProfile method for CloudHypervisor
+func (c *CloudHypervisor) ShouldUseRuntimeDefaultSeccompProfile() bool {
+	return !c.Root()
 }
 
 // Implement GetHypervisorDevice method for CloudHypervisor
 func (c *CloudHypervisor) GetHypervisorDevice() string {
+	if c.kvm {
+		return "devices.kubevirt.io/kvm"
+	}
 	return "devices.kubevirt.io/mshv"
 }
 
@@ -79,9 +101,13 @@ func (c *CloudHypervisor) GetHypervisorOverhead() string {
 	return "30Mi"
 }
 
-// Implement GetDefaultKernelPath method for CloudHypervisor
-func (c *CloudHypervisor) GetDefaultKernelPath() (string, string) {
-	return "/usr/share/cloud-hypervisor/CLOUDHV_EFI.fd", ""
+// Implement GetDefaultKernelPath method for CloudHypervisor. The firmware is loaded as the kernel:
+// rust-hypervisor-firmware for BIOS, which boots the kernel found on the disk, and OVMF otherwise.
+func (c *CloudHypervisor) GetDefaultKernelPath(bootloader *v1.Bootloader) (string, string) {
+	if bootloader != nil && bootloader.BIOS != nil {
+		return cloudHypervisorBIOSFirmware, ""
+	}
+	return cloudHypervisorEFIFirmware, ""
 }
 
 func (c *CloudHypervisor) SetupLibvirt(customLogFilters *string) (err error) {
@@ -105,17 +131,44 @@ func (c *CloudHypervisor) StartHypervisorDaemon(stopChan chan struct{}) {
 }
 
 func (c *CloudHypervisor) GetPidDir() string {
-	return "/run/libvirt/ch"
+	if c.Root() {
+		return "/run/libvirt/ch"
+	} else {
+		return "/run/libvirt/ch/run"
+	}
 }
 
 func (c *CloudHypervisor) GetLibvirtUriAndUser() (string, string) {
-	return "ch:///system", ""
+	libvirtUri := "ch:///system"
+	user := ""
+	if !c.Root() {
+		user = util.NonRootUserString
+		libvirtUri = "ch+unix:///session?socket=/var/run/libvirt/virtchd-sock"
+	}
+	return libvirtUri, user
+}
+
+func (c *CloudHypervisor) GetMigrationUri(socket string) string {
+	if !c.Root() {
+		return fmt.Sprintf("ch+unix:///session?socket=%s", socket)
+	}
+	return fmt.Sprintf("ch+unix:///system?socket=%s", socket)
 }
 
 func (c *CloudHypervisor) GetHypervisorCommandPrefix() []string {
 	return []string{"cloud-hypervisor"}
 }
 
+// The libvirt ch driver implements only a small part of the bulk stats API
+func (c *CloudHypervisor) HasVMMAPI() bool {
+	return true
+}
+
+// The libvirt ch driver creates the API socket of the VMM next to its pid file
+func (c *CloudHypervisor) GetVMMAPISocketPath(domainName string) string {
+	return filepath.Join(c.GetPidDir(), domainName+"-socket")
+}
+
 func (l *CloudHypervisor) StartVirtlog(stopChan chan struct{}, domainName string) {
-	go startVirtlogdLogging("/usr/sbin/virtlogd", stopChan, domainName, l.user != util.RootUser)
+	go startVirtlogdLogging("/usr/sbin/virtlogd", stopChan, domainName, l.GetVmm(), l.user != util.RootUser)
 }
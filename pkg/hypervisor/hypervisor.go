@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"golang.org/x/sys/unix"
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/util"
@@ -52,6 +53,10 @@ type Hypervisor interface {
 	// Return true if the virt-launcher container should run privileged
 	ShouldRunPrivileged() bool
 
+	// Return true if the virt-launcher pod should be confined by the runtime default seccomp profile
+	// when no custom profile is configured for VMIs
+	ShouldUseRuntimeDefaultSeccompProfile() bool
+
 	// Return a regex that matches the thread comm value for vCPUs
 	GetVcpuRegex() *regexp.Regexp
 
@@ -67,9 +72,9 @@ type Hypervisor interface {
 	// Return true if the hypervisor supports memory ballooning
 	SupportsMemoryBallooning() bool
 
-	// Return the default kernel path and initrd path for the hypervisor
+	// Return the default kernel path and initrd path for the hypervisor, e.g., the firmware implementing the given bootloader
 	// If default kernel is not needed return "", ""
-	GetDefaultKernelPath() (string, string)
+	GetDefaultKernelPath(bootloader *v1.Bootloader) (string, string)
 
 	// Return the domain type in Libvirt domain XML
 	GetDomainType() string
@@ -95,29 +100,49 @@ type Hypervisor interface {
 
 	GetLibvirtUriAndUser() (string, string)
 
+	// Return the libvirt URI of the migration target, which is reached through the given unix socket
+	GetMigrationUri(socket string) string
+
 	// Return a list of potential prefixes of the specific hypervisor's process, e.g., qemu-system or cloud-hypervisor
 	GetHypervisorCommandPrefix() []string
+
+	// Return true if the VMM provides an HTTP API serving the domain statistics the libvirt driver does not implement
+	HasVMMAPI() bool
+
+	// Return the path of the HTTP API socket of the VMM running the given domain
+	GetVMMAPISocketPath(domainName string) string
 }
 
 func NewHypervisor(hypervisor string) Hypervisor {
 	return NewHypervisorWithUser(hypervisor, false)
 }
 
+// NewHypervisorWithUser returns the implementation of the backend registered under the given name,
+// or nil if there is no such backend
 func NewHypervisorWithUser(hypervisor string, nonRoot bool) Hypervisor {
-	if hypervisor == "qemu" {
-		if nonRoot {
-			return &QemuHypervisor{
-				user: util.NonRootUID,
-			}
-		}
-		return &QemuHypervisor{
-			user: util.RootUser,
-		}
-	} else if hypervisor == "ch" {
-		return &CloudHypervisor{util.RootUser}
-	} else {
+	backend, exists := Lookup(hypervisor)
+	if !exists {
 		return nil
 	}
+	return backend.New(nonRoot)
+}
+
+// LoadsOwnFirmware returns true if the hypervisor boots the VMI with a firmware of its own, which it loads as kernel,
+// instead of the OVMF loader
+func LoadsOwnFirmware(hv Hypervisor, vmi *v1.VirtualMachineInstance) bool {
+	var bootloader *v1.Bootloader
+	if vmi.Spec.Domain.Firmware != nil {
+		bootloader = vmi.Spec.Domain.Firmware.Bootloader
+	}
+	firmwarePath, _ := hv.GetDefaultKernelPath(bootloader)
+	return firmwarePath != ""
+}
+
+func userFor(nonRoot bool) uint32 {
+	if nonRoot {
+		return util.NonRootUID
+	}
+	return util.RootUser
 }
 
 func setupLibvirt(l Hypervisor, customLogFilters *string, shouldConfigureVmmConf bool) (err error) {
@@ -333,7 +358,7 @@ func startModularLibvirtDaemon(l Hypervisor, stopChan chan struct{}) {
 	}()
 }
 
-func startVirtlogdLogging(virtlogdBinaryPath string, stopChan chan struct{}, domainName string, nonRoot bool) {
+func startVirtlogdLogging(virtlogdBinaryPath string, stopChan chan struct{}, domainName string, vmm string, nonRoot bool) {
 	for {
 		cmd := exec.Command(virtlogdBinaryPath, "-f", "/etc/libvirt/virtlogd.conf")
 
@@ -346,9 +371,9 @@ func startVirtlogdLogging(virtlogdBinaryPath string, stopChan chan struct{}, dom
 		}
 
 		go func() {
-			logfile := fmt.Sprintf("/var/log/libvirt/qemu/%s.log", domainName)
+			logfile := fmt.Sprintf("/var/log/libvirt/%s/%s.log", vmm, domainName)
 			if nonRoot {
-				logfile = filepath.Join("/var", "run", "kubevirt-private", "libvirt", "qemu", "log", fmt.Sprintf("%s.log", domainName))
+				logfile = filepath.Join("/var", "run", "kubevirt-private", "libvirt", vmm, "log", fmt.Sprintf("%s.log", domainName))
 			}
 
 			// It can take a few seconds to the log file to be created
@@ -0,0 +1,11 @@
+package hypervisor
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestHypervisor(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,22 @@
+package hypervisor
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+var _ = Describe("Hypervisor firmware", func() {
+	DescribeTable("should tell whether the hypervisor loads its own firmware", func(hypervisor string, firmware *v1.Firmware, expected bool) {
+		vmi := &v1.VirtualMachineInstance{}
+		vmi.Spec.Domain.Firmware = firmware
+		Expect(LoadsOwnFirmware(NewHypervisor(hypervisor), vmi)).To(Equal(expected))
+	},
+		Entry("not with qemu and EFI", "qemu", &v1.Firmware{Bootloader: &v1.Bootloader{EFI: &v1.EFI{}}}, false),
+		Entry("not with qemu without firmware", "qemu", nil, false),
+		Entry("with ch and EFI", "ch", &v1.Firmware{Bootloader: &v1.Bootloader{EFI: &v1.EFI{}}}, true),
+		Entry("with ch and BIOS", "ch", &v1.Firmware{Bootloader: &v1.Bootloader{BIOS: &v1.BIOS{}}}, true),
+		Entry("with ch without firmware", "ch", nil, true),
+	)
+})
@@ -7,13 +7,12 @@ import (
 	"regexp"
 	"syscall"
 
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/util"
 )
 
-var QemuProcessExecutablePrefixes []string = []string{"qemu-system", "qemu-kvm", "cloud-hypervisor"}
-
 const QEMUSeaBiosDebugPipe = "/var/run/kubevirt-private/QEMUSeaBiosDebugPipe"
 
 // Define QemuHypervisor struct that implements the Hypervisor interface
@@ -58,6 +57,11 @@ func (q *QemuHypervisor) ShouldRunPrivileged() bool {
 	return false
 }
 
+// Implement ShouldUseRuntimeDefaultSeccompProfile method for QemuHypervisor
+func (q *QemuHypervisor) ShouldUseRuntimeDefaultSeccompProfile() bool {
+	return false
+}
+
 // Implement GetHypervisorDevice method for QemuHypervisor
 func (q *QemuHypervisor) GetHypervisorDevice() string {
 	return "devices.kubevirt.io/kvm"
@@ -88,8 +92,8 @@ func (q *QemuHypervisor) GetHypervisorOverhead() string {
 	return "30Mi"
 }
 
-// Implement GetDefaultKernelPath method for QemuHypervisor
-func (q *QemuHypervisor) GetDefaultKernelPath() (string, string) {
+// Implement GetDefaultKernelPath method for QemuHypervisor, libvirt loads SeaBIOS or the OVMF loader instead
+func (q *QemuHypervisor) GetDefaultKernelPath(_ *v1.Bootloader) (string, string) {
 	return "", ""
 }
 
@@ -131,6 +135,13 @@ func (q *QemuHypervisor) GetLibvirtUriAndUser() (string, string) {
 	return libvirtUri, user
 }
 
+func (q *QemuHypervisor) GetMigrationUri(socket string) string {
+	if !q.Root() {
+		return fmt.Sprintf("qemu+unix:///session?socket=%s", socket)
+	}
+	return fmt.Sprintf("qemu+unix:///system?socket=%s", socket)
+}
+
 func (q *QemuHypervisor) GetHypervisorCommandPrefix() []string {
 	// give qemu some time to shut down in case it survived virt-handler
 	// Most of the time we call `qemu-system=* binaries, but qemu-system-* packages
@@ -139,8 +150,17 @@ func (q *QemuHypervisor) GetHypervisorCommandPrefix() []string {
 	return []string{"qemu-system", "qemu-kvm"}
 }
 
+// libvirt provides all domain statistics of QEMU
+func (q *QemuHypervisor) HasVMMAPI() bool {
+	return false
+}
+
+func (q *QemuHypervisor) GetVMMAPISocketPath(_ string) string {
+	return ""
+}
+
 func (l *QemuHypervisor) StartVirtlog(stopChan chan struct{}, domainName string) {
-	go startVirtlogdLogging("/usr/sbin/virtlogd", stopChan, domainName, l.user != util.RootUser)
+	go startVirtlogdLogging("/usr/sbin/virtlogd", stopChan, domainName, l.GetVmm(), l.user != util.RootUser)
 	go startQEMUSeaBiosLogging(stopChan)
 }
 
@@ -0,0 +1,140 @@
+package hypervisor
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Backend describes a hypervisor which can run VMIs. Backends are registered with Register,
+// usually from an init function, and resolved by the name used in spec.hypervisor.
+type Backend struct {
+	// Name of the backend as used in spec.hypervisor
+	Name string
+
+	// New returns the hypervisor implementation, running as root or as the non-root user
+	New func(nonRoot bool) Hypervisor
+
+	// The libvirt daemons which fork the hypervisor processes in virt-launcher, e.g. virtqemud
+	DaemonExecutables []string
+
+	// The optional VMI features supported by the backend
+	Capabilities Capabilities
+}
+
+// DefaultBackend is the backend of VMs and VMIs which don't set spec.hypervisor. Objects created before
+// the hypervisor was defaulted run on it, so an unset hypervisor keeps meaning qemu in every component,
+// whatever default the cluster or namespace configures for new objects.
+const DefaultBackend = "qemu"
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]Backend{}
+	// registration order, which keeps the aggregated executable lists stable
+	registeredNames []string
+)
+
+func init() {
+	Register(Backend{
+		Name: "qemu",
+		New: func(nonRoot bool) Hypervisor {
+			return &QemuHypervisor{user: userFor(nonRoot)}
+		},
+		DaemonExecutables: []string{"virtqemud"},
+		Capabilities:      qemuCapabilities,
+	})
+	Register(Backend{
+		Name: "ch",
+		New: func(nonRoot bool) Hypervisor {
+			return &CloudHypervisor{user: userFor(nonRoot)}
+		},
+		DaemonExecutables: []string{"virtchd"},
+		Capabilities:      cloudHypervisorCapabilities,
+	})
+	Register(Backend{
+		Name: "ch-kvm",
+		New: func(nonRoot bool) Hypervisor {
+			return &CloudHypervisor{user: userFor(nonRoot), kvm: true}
+		},
+		DaemonExecutables: []string{"virtchd"},
+		Capabilities:      cloudHypervisorCapabilities,
+	})
+}
+
+// Register makes a backend available to all KubeVirt components under its name.
+// It panics if the backend is incomplete or its name is already taken.
+func Register(backend Backend) {
+	if backend.Name == "" {
+		panic("hypervisor: cannot register a backend without a name")
+	}
+	if backend.New == nil {
+		panic(fmt.Sprintf("hypervisor: backend %s does not provide an implementation", backend.Name))
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if _, exists := registry[backend.Name]; exists {
+		panic(fmt.Sprintf("hypervisor: backend %s is already registered", backend.Name))
+	}
+	registry[backend.Name] = backend
+	registeredNames = append(registeredNames, backend.Name)
+}
+
+// NameOrDefault returns the name of the backend which runs an object with the given spec.hypervisor
+func NameOrDefault(name string) string {
+	if name == "" {
+		return DefaultBackend
+	}
+	return name
+}
+
+// Lookup returns the backend registered under the given name, or the default backend for an empty name
+func Lookup(name string) (Backend, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	backend, exists := registry[NameOrDefault(name)]
+	return backend, exists
+}
+
+// GetCapabilities returns the capabilities of the backend registered under the given name
+func GetCapabilities(name string) (Capabilities, bool) {
+	backend, exists := Lookup(name)
+	return backend.Capabilities, exists
+}
+
+// RegisteredBackends returns the names of all registered backends in registration order
+func RegisteredBackends() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return append([]string{}, registeredNames...)
+}
+
+// DaemonExecutables returns the libvirt daemon executables of all registered backends
+func DaemonExecutables() []string {
+	return collect(func(backend Backend) []string {
+		return backend.DaemonExecutables
+	})
+}
+
+// ProcessExecutablePrefixes returns the prefixes of the hypervisor process executables of all registered backends
+func ProcessExecutablePrefixes() []string {
+	return collect(func(backend Backend) []string {
+		return backend.New(false).GetHypervisorCommandPrefix()
+	})
+}
+
+func collect(values func(Backend) []string) []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	var result []string
+	seen := map[string]bool{}
+	for _, name := range registeredNames {
+		for _, value := range values(registry[name]) {
+			if !seen[value] {
+				seen[value] = true
+				result = append(result, value)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+package hypervisor
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+type fakeHypervisor struct {
+	*QemuHypervisor
+}
+
+func (f *fakeHypervisor) GetHypervisorCommandPrefix() []string {
+	return []string{"fake-vmm"}
+}
+
+// unregister removes a backend registered by a test, so that it does not leak into other tests
+func unregister(name string) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	delete(registry, name)
+	for i, registeredName := range registeredNames {
+		if registeredName == name {
+			registeredNames = append(registeredNames[:i], registeredNames[i+1:]...)
+			break
+		}
+	}
+}
+
+var _ = Describe("Hypervisor registry", func() {
+	It("should provide the built-in backends", func() {
+		Expect(RegisteredBackends()).To(ContainElements("qemu", "ch", "ch-kvm"))
+		Expect(NewHypervisor("qemu")).To(BeAssignableToTypeOf(&QemuHypervisor{}))
+		Expect(NewHypervisor("ch-kvm").GetHypervisorDevice()).To(Equal("devices.kubevirt.io/kvm"))
+		Expect(NewHypervisor("unknown")).To(BeNil())
+		Expect(NewHypervisor("qemu").HasVMMAPI()).To(BeFalse())
+		Expect(NewHypervisor("ch").HasVMMAPI()).To(BeTrue())
+	})
+
+	It("should resolve an unset hypervisor to the default backend", func() {
+		Expect(NameOrDefault("")).To(Equal(DefaultBackend))
+		Expect(NameOrDefault("ch")).To(Equal("ch"))
+		Expect(NewHypervisor("")).To(BeAssignableToTypeOf(&QemuHypervisor{}))
+		capabilities, exists := GetCapabilities("")
+		Expect(exists).To(BeTrue())
+		Expect(capabilities).To(Equal(qemuCapabilities))
+	})
+
+	It("should create the implementation for the requested user", func() {
+		Expect(NewHypervisorWithUser("ch", true).Root()).To(BeFalse())
+		Expect(NewHypervisorWithUser("ch", false).Root()).To(BeTrue())
+	})
+
+	It("should resolve an out-of-tree backend", func() {
+		Register(Backend{
+			Name: "fake",
+			New: func(nonRoot bool) Hypervisor {
+				return &fakeHypervisor{&QemuHypervisor{user: util.RootUser}}
+			},
+			DaemonExecutables: []string{"virtfaked"},
+			Capabilities:      Capabilities{LiveMigration: true},
+		})
+		DeferCleanup(unregister, "fake")
+
+		Expect(NewHypervisor("fake")).To(BeAssignableToTypeOf(&fakeHypervisor{}))
+		capabilities, exists := GetCapabilities("fake")
+		Expect(exists).To(BeTrue())
+		Expect(capabilities).To(Equal(Capabilities{LiveMigration: true}))
+		Expect(DaemonExecutables()).To(Equal([]string{"virtqemud", "virtchd", "virtfaked"}))
+		Expect(ProcessExecutablePrefixes()).To(Equal([]string{"qemu-system", "qemu-kvm", "cloud-hypervisor", "fake-vmm"}))
+
+		unregister("fake")
+		_, exists = Lookup("fake")
+		Expect(exists).To(BeFalse())
+		Expect(RegisteredBackends()).To(Equal([]string{"qemu", "ch", "ch-kvm"}))
+	})
+
+	DescribeTable("should refuse to register", func(backend Backend) {
+		Expect(func() { Register(backend) }).To(Panic())
+	},
+		Entry("a backend without a name", Backend{New: func(bool) Hypervisor { return nil }}),
+		Entry("a backend without an implementation", Backend{Name: "incomplete"}),
+		Entry("a backend under a taken name", Backend{Name: "qemu", New: func(bool) Hypervisor { return nil }}),
+	)
+})
@@ -41,6 +41,7 @@ var (
 		cpuAffinityMetrics{},
 		migrationMetrics{},
 		filesystemMetrics{},
+		launcherMetrics{},
 	}
 
 	Collector = operatormetrics.Collector{
@@ -61,14 +62,16 @@ type collectorSettings struct {
 	nodeName            string
 	maxRequestsInFlight int
 	vmiInformer         cache.SharedIndexInformer
+	processMeter        LauncherProcessMeter
 }
 
-func SetupDomainStatsCollector(virtShareDir, nodeName string, maxRequestsInFlight int, vmiInformer cache.SharedIndexInformer) {
+func SetupDomainStatsCollector(virtShareDir, nodeName string, maxRequestsInFlight int, vmiInformer cache.SharedIndexInformer, processMeter LauncherProcessMeter) {
 	settings = &collectorSettings{
 		virtShareDir:        virtShareDir,
 		nodeName:            nodeName,
 		maxRequestsInFlight: maxRequestsInFlight,
 		vmiInformer:         vmiInformer,
+		processMeter:        processMeter,
 	}
 }
 
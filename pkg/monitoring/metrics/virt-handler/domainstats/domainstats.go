@@ -45,6 +45,8 @@ type VirtualMachineInstanceReport struct {
 type VirtualMachineInstanceStats struct {
 	DomainStats *stats.DomainStats
 	FsStats     k6tv1.VirtualMachineInstanceFileSystemList
+	// Resident set size in bytes of the virt-launcher pod processes, keyed by process
+	ProcessStats map[string]uint64
 }
 
 func newVirtualMachineInstanceReport(vmi *k6tv1.VirtualMachineInstance, vmiStats *VirtualMachineInstanceStats) *VirtualMachineInstanceReport {
@@ -0,0 +1,54 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import "github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+
+var (
+	launcherMemoryResident = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_launcher_memory_resident_bytes",
+			Help: "Resident set size of the processes in the virt-launcher pod (virt-launcher-monitor, virt-launcher, virtlogd, the hypervisor daemon and the VMM), by process. The VMM includes the guest memory it touched.",
+		},
+	)
+)
+
+type launcherMetrics struct{}
+
+func (launcherMetrics) Describe() []operatormetrics.Metric {
+	return []operatormetrics.Metric{
+		launcherMemoryResident,
+	}
+}
+
+func (launcherMetrics) Collect(vmiReport *VirtualMachineInstanceReport) []operatormetrics.CollectorResult {
+	var crs []operatormetrics.CollectorResult
+
+	for process, rss := range vmiReport.vmiStats.ProcessStats {
+		processLabels := map[string]string{
+			"process":    process,
+			"hypervisor": vmiReport.vmi.Spec.Hypervisor,
+		}
+
+		crs = append(crs, vmiReport.newCollectorResultWithLabels(launcherMemoryResident, float64(rss), processLabels))
+	}
+
+	return crs
+}
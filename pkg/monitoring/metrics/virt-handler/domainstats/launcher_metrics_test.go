@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k6tv1 "kubevirt.io/api/core/v1"
+)
+
+var _ = Describe("launcher metrics", func() {
+	Context("on Collect", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmi-1",
+				Namespace: "test-ns-1",
+			},
+			Spec: k6tv1.VirtualMachineInstanceSpec{
+				Hypervisor: "ch",
+			},
+		}
+
+		vmiStats := &VirtualMachineInstanceStats{
+			ProcessStats: map[string]uint64{
+				"virtlogd": 1,
+				"vmm":      2,
+			},
+		}
+
+		vmiReport := newVirtualMachineInstanceReport(vmi, vmiStats)
+
+		DescribeTable("should collect the resident set size", func(process string, expectedValue float64) {
+			crs := launcherMetrics{}.Collect(vmiReport)
+			Expect(crs).To(ContainElement(SatisfyAll(
+				gomegaContainsMetricMatcher(launcherMemoryResident, expectedValue),
+				HaveField("ConstLabels", SatisfyAll(
+					HaveKeyWithValue("process", process),
+					HaveKeyWithValue("hypervisor", "ch"),
+				)),
+			)))
+		},
+			Entry("of virtlogd", "virtlogd", 1.0),
+			Entry("of the VMM", "vmm", 2.0),
+		)
+
+		It("result should be empty if the processes were not measured", func() {
+			vmiStats.ProcessStats = nil
+			crs := launcherMetrics{}.Collect(vmiReport)
+			Expect(crs).To(BeEmpty())
+		})
+	})
+})
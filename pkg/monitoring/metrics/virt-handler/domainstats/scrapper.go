@@ -31,6 +31,11 @@ import (
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 )
 
+// LauncherProcessMeter measures the resident set size of the processes of the virt-launcher pod of a VMI
+type LauncherProcessMeter interface {
+	ResidentSetSize(vmi *k6tv1.VirtualMachineInstance, socketFile string) (map[string]uint64, error)
+}
+
 type domainstatsScraper struct {
 	ch chan operatormetrics.CollectorResult
 }
@@ -38,7 +43,7 @@ type domainstatsScraper struct {
 func (d domainstatsScraper) Scrape(socketFile string, vmi *k6tv1.VirtualMachineInstance) {
 	ts := time.Now()
 
-	exists, vmStats, err := gatherMetrics(socketFile)
+	exists, vmStats, err := gatherMetrics(socketFile, vmi)
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to scrape metrics from %s", socketFile)
 		return
@@ -88,7 +93,7 @@ func (d domainstatsScraper) report(vmi *k6tv1.VirtualMachineInstance, vmStats *V
 	}
 }
 
-func gatherMetrics(socketFile string) (bool, *VirtualMachineInstanceStats, error) {
+func gatherMetrics(socketFile string, vmi *k6tv1.VirtualMachineInstance) (bool, *VirtualMachineInstanceStats, error) {
 	cli, err := cmdclient.NewClient(socketFile)
 	if err != nil {
 		// Ignore failure to connect to client.
@@ -112,5 +117,13 @@ func gatherMetrics(socketFile string) (bool, *VirtualMachineInstanceStats, error
 		return false, nil, fmt.Errorf("failed to update filesystem stats from socket %s: %w", socketFile, err)
 	}
 
+	// The resident set size of the pod processes is best effort, it must not hide the domain stats
+	if settings.processMeter != nil {
+		vmStats.ProcessStats, err = settings.processMeter.ResidentSetSize(vmi, socketFile)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).V(4).Infof("failed to measure the virt-launcher processes of socket %s", socketFile)
+		}
+	}
+
 	return exists, vmStats, nil
 }
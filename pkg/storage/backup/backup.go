@@ -0,0 +1,151 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+// Package backup describes the layout of a VirtualMachineBackup in its pvc.
+// virt-launcher writes one directory per backup, holding a manifest and a
+// sparse raw image per volume. Only the extents listed in the manifest are
+// written into an image: the allocated extents of a full backup, or the
+// extents which changed since the previous backup of an incremental one.
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DirectoryExtension is the extension of the directory of a backup
+	DirectoryExtension = ".backup"
+	// ManifestFileName is the name of the manifest in the directory of a backup
+	ManifestFileName = "manifest.json"
+	// ImageExtension is the extension of the image of a volume
+	ImageExtension = ".img"
+
+	copyBufferSize = 4 * 1024 * 1024
+)
+
+// Manifest describes a backup
+type Manifest struct {
+	// Checkpoint is the checkpoint created by the backup
+	Checkpoint string `json:"checkpoint"`
+	// IncrementalFrom is the checkpoint the backup was taken on top of
+	IncrementalFrom string           `json:"incrementalFrom,omitempty"`
+	Volumes         []VolumeManifest `json:"volumes"`
+}
+
+// VolumeManifest describes the backup of a single volume
+type VolumeManifest struct {
+	// Name is the name of the volume in the vmi
+	Name string `json:"name"`
+	// Image is the file name of the image of the volume
+	Image string `json:"image"`
+	// Size is the size of the disk in bytes
+	Size int64 `json:"size"`
+	// Incremental is true if only the data changed since the checkpoint the
+	// backup was taken on top of is in the image. Disks without a persistent
+	// dirty bitmap are always backed up fully.
+	Incremental bool `json:"incremental"`
+	// Extents are the extents of the disk written into the image, the
+	// rest of the image is a hole
+	Extents []Extent `json:"extents"`
+}
+
+// Extent is a range of a disk
+type Extent struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+}
+
+// ImageFileName returns the file name of the image of a volume
+func ImageFileName(volumeName string) string {
+	return volumeName + ImageExtension
+}
+
+// WriteManifest writes the manifest into the directory of a backup
+func WriteManifest(dir string, manifest *Manifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFileName), data, 0640)
+}
+
+// ReadManifest reads the manifest from the directory of a backup
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// FindDirectory returns the directory of the completed backup in the mount
+// point of a pvc
+func FindDirectory(mountPoint string) (string, error) {
+	entries, err := os.ReadDir(mountPoint)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasSuffix(entry.Name(), DirectoryExtension) {
+			continue
+		}
+		dir := filepath.Join(mountPoint, entry.Name())
+		if _, err := os.Stat(filepath.Join(dir, ManifestFileName)); err == nil {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("no backup found in %s: %w", mountPoint, os.ErrNotExist)
+}
+
+// WriteImage writes the extents of a disk into a sparse raw image of the
+// size of the disk
+func WriteImage(image string, size int64, disk io.ReaderAt, extents []Extent) error {
+	f, err := os.OpenFile(image, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := f.Truncate(size); err != nil {
+		return err
+	}
+
+	buf := make([]byte, copyBufferSize)
+	for _, extent := range extents {
+		for offset, end := extent.Offset, extent.Offset+extent.Length; offset < end; {
+			n := min(end-offset, copyBufferSize)
+			if _, err := disk.ReadAt(buf[:n], offset); err != nil {
+				return err
+			}
+			if _, err := f.WriteAt(buf[:n], offset); err != nil {
+				return err
+			}
+			offset += n
+		}
+	}
+	return f.Close()
+}
@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backup", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+	})
+
+	It("should find the backup and read its manifest", func() {
+		Expect(os.Mkdir(filepath.Join(tempDir, "incomplete"+DirectoryExtension), 0750)).To(Succeed())
+		dir := filepath.Join(tempDir, "vm-backup"+DirectoryExtension)
+		Expect(os.Mkdir(dir, 0750)).To(Succeed())
+		manifest := &Manifest{
+			Checkpoint:      "second",
+			IncrementalFrom: "first",
+			Volumes: []VolumeManifest{
+				{Name: "disk0", Image: ImageFileName("disk0"), Size: 1024, Incremental: true, Extents: []Extent{{Offset: 512, Length: 512}}},
+			},
+		}
+		Expect(WriteManifest(dir, manifest)).To(Succeed())
+
+		found, err := FindDirectory(tempDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(Equal(dir))
+		Expect(ReadManifest(found)).To(Equal(manifest))
+	})
+
+	It("should fail to find a backup in an empty pvc", func() {
+		_, err := FindDirectory(tempDir)
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("should write the extents of a disk into a sparse image", func() {
+		const blockSize = 1024 * 1024
+		disk := make([]byte, 8*blockSize)
+		for i := range disk {
+			disk[i] = 1
+		}
+		extents := []Extent{
+			{Offset: 2 * blockSize, Length: blockSize},
+			{Offset: 5 * blockSize, Length: 2*blockSize + 512},
+		}
+		image := filepath.Join(tempDir, ImageFileName("disk0"))
+		Expect(WriteImage(image, int64(len(disk)), bytes.NewReader(disk), extents)).To(Succeed())
+
+		data, err := os.ReadFile(image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(HaveLen(len(disk)))
+		expected := make([]byte, len(disk))
+		for _, extent := range extents {
+			copy(expected[extent.Offset:extent.Offset+extent.Length], disk[extent.Offset:])
+		}
+		Expect(data).To(Equal(expected))
+	})
+})
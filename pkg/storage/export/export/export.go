@@ -25,6 +25,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strconv"
 	"time"
 
 	"github.com/openshift/library-go/pkg/build/naming"
@@ -89,6 +90,7 @@ const (
 	blockVolumeMountPath = "/dev/export-volumes"
 	fileSystemMountPath  = "/export-volumes"
 	urlBasePath          = "/volumes"
+	nbdPort              = 10809
 
 	// annContentType is an annotation on a PVC indicating the content type. This is populated by CDI.
 	annContentType = "cdi.kubevirt.io/storage.contentType"
@@ -123,6 +125,7 @@ const (
 	manifestData           = "manifest-data"
 	manifestsPath          = "/manifests/all"
 	secretManifestPath     = "/manifests/secret"
+	checksumsManifestPath  = "/manifests/sha256sums"
 	externalHostKey        = "external_host"
 	internalHostKey        = "internal_host"
 	externalCaConfigMapKey = "external_ca_cm"
@@ -158,6 +161,18 @@ func rawGzipURI(pvc *corev1.PersistentVolumeClaim) string {
 	return path.Join(fmt.Sprintf("%s/%s/disk.img.gz", urlBasePath, pvc.Name))
 }
 
+func qcow2URI(pvc *corev1.PersistentVolumeClaim) string {
+	return path.Join(fmt.Sprintf("%s/%s/disk.qcow2", urlBasePath, pvc.Name))
+}
+
+func rawZstURI(pvc *corev1.PersistentVolumeClaim) string {
+	return path.Join(fmt.Sprintf("%s/%s/disk.img.zst", urlBasePath, pvc.Name))
+}
+
+func containerDiskURI(pvc *corev1.PersistentVolumeClaim) string {
+	return path.Join(fmt.Sprintf("%s/%s/containerdisk.tar", urlBasePath, pvc.Name))
+}
+
 func archiveURI(pvc *corev1.PersistentVolumeClaim) string {
 	return path.Join(fmt.Sprintf("%s/%s/disk.tar.gz", urlBasePath, pvc.Name))
 }
@@ -166,6 +181,15 @@ func dirURI(pvc *corev1.PersistentVolumeClaim) string {
 	return path.Join(fmt.Sprintf("%s/%s/dir", urlBasePath, pvc.Name)) + "/"
 }
 
+func backupURI(pvc *corev1.PersistentVolumeClaim) string {
+	return path.Join(fmt.Sprintf("%s/%s/backup", urlBasePath, pvc.Name)) + "/"
+}
+
+// holdsBackup returns true if a VM backup was written to the PVC
+func holdsBackup(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Annotations[virtv1.PVCBackupAnnotation] != ""
+}
+
 type sourceVolumes struct {
 	volumes          []*corev1.PersistentVolumeClaim
 	inUse            bool
@@ -820,9 +844,66 @@ func (ctrl *VMExportController) createServiceManifest(vmExport *exportv1.Virtual
 			},
 		},
 	}
+	if vmExport.Spec.NBD != nil {
+		// the ports of a service with multiple ports have to be named
+		service.Spec.Ports[0].Name = "https"
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+			Name:       "nbd",
+			Protocol:   "TCP",
+			Port:       nbdPort,
+			TargetPort: intstr.FromInt32(nbdPort),
+		})
+	}
 	return service
 }
 
+// nbdReadOnly returns true unless the NBD server of the export is writable
+func nbdReadOnly(vmExport *exportv1.VirtualMachineExport) bool {
+	return vmExport.Spec.NBD == nil || vmExport.Spec.NBD.ReadOnly == nil || *vmExport.Spec.NBD.ReadOnly
+}
+
+// containerDiskImage returns the containerDisk image the PVC is packaged into, if any
+func (ctrl *VMExportController) containerDiskImage(vmExport *exportv1.VirtualMachineExport, pvc *corev1.PersistentVolumeClaim) string {
+	containerDisk := vmExport.Spec.ContainerDisk
+	if containerDisk == nil {
+		return ""
+	}
+	volumeName := getVolumeName(pvc, vmExport)
+	if ctrl.isSourceVMSnapshot(&vmExport.Spec) {
+		volumeName = getSnapshotVolumeName(pvc, vmExport)
+	}
+	if volumeName != containerDisk.Volume {
+		return ""
+	}
+	return containerDisk.Image
+}
+
+// replaceVolumeWithContainerDisk returns a copy of the VM which refers to the
+// containerDisk image of the export instead of the volume packaged into it
+func replaceVolumeWithContainerDisk(vmExport *exportv1.VirtualMachineExport, vm *virtv1.VirtualMachine) *virtv1.VirtualMachine {
+	containerDisk := vmExport.Spec.ContainerDisk
+	if containerDisk == nil {
+		return vm
+	}
+	vm = vm.DeepCopy()
+	for i, volume := range vm.Spec.Template.Spec.Volumes {
+		if (volume.DataVolume != nil && volume.DataVolume.Name == containerDisk.Volume) ||
+			(volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == containerDisk.Volume) {
+			vm.Spec.Template.Spec.Volumes[i].VolumeSource = virtv1.VolumeSource{
+				ContainerDisk: &virtv1.ContainerDiskSource{Image: containerDisk.Image},
+			}
+		}
+	}
+	templates := vm.Spec.DataVolumeTemplates[:0]
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		if template.Name != containerDisk.Volume {
+			templates = append(templates, template)
+		}
+	}
+	vm.Spec.DataVolumeTemplates = templates
+	return vm
+}
+
 func (ctrl *VMExportController) getExporterPod(vmExport *exportv1.VirtualMachineExport) (*corev1.Pod, bool, error) {
 	key := controller.NamespacedKey(vmExport.Namespace, ctrl.getExportPodName(vmExport))
 	if obj, exists, err := ctrl.PodInformer.GetStore().GetByKey(key); err != nil {
@@ -893,7 +974,7 @@ func (ctrl *VMExportController) createExporterPodManifest(vmExport *exportv1.Vir
 			mountPoint = fmt.Sprintf("%s/%s", fileSystemMountPath, pvc.Name)
 			podManifest.Spec.Containers[0].VolumeMounts = append(podManifest.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
 				Name:      pvc.Name,
-				ReadOnly:  true,
+				ReadOnly:  nbdReadOnly(vmExport),
 				MountPath: mountPoint,
 			})
 		}
@@ -906,6 +987,30 @@ func (ctrl *VMExportController) createExporterPodManifest(vmExport *exportv1.Vir
 			},
 		})
 		ctrl.addVolumeEnvironmentVariables(&podManifest.Spec.Containers[0], pvc, i, mountPoint)
+		if vmExport.Spec.NBD != nil && ctrl.isKubevirtContentType(pvc) {
+			podManifest.Spec.Containers[0].Env = append(podManifest.Spec.Containers[0].Env, corev1.EnvVar{
+				Name:  fmt.Sprintf("VOLUME%d_EXPORT_NBD_NAME", i),
+				Value: pvc.Name,
+			})
+		}
+		if image := ctrl.containerDiskImage(vmExport, pvc); image != "" && ctrl.isKubevirtContentType(pvc) {
+			podManifest.Spec.Containers[0].Env = append(podManifest.Spec.Containers[0].Env, corev1.EnvVar{
+				Name:  fmt.Sprintf("VOLUME%d_EXPORT_CONTAINERDISK_URI", i),
+				Value: containerDiskURI(pvc),
+			}, corev1.EnvVar{
+				Name:  fmt.Sprintf("VOLUME%d_EXPORT_CONTAINERDISK_IMAGE", i),
+				Value: image,
+			})
+		}
+	}
+	if vmExport.Spec.NBD != nil {
+		podManifest.Spec.Containers[0].Env = append(podManifest.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  "NBD_LISTEN_ADDR",
+			Value: fmt.Sprintf(":%d", nbdPort),
+		}, corev1.EnvVar{
+			Name:  "NBD_READ_ONLY",
+			Value: strconv.FormatBool(nbdReadOnly(vmExport)),
+		})
 	}
 
 	// Add token and certs ENV variables
@@ -927,6 +1032,9 @@ func (ctrl *VMExportController) createExporterPodManifest(vmExport *exportv1.Vir
 	}, corev1.EnvVar{
 		Name:  "EXPORT_SECRET_DEF_URI",
 		Value: secretManifestPath,
+	}, corev1.EnvVar{
+		Name:  "EXPORT_CHECKSUMS_URI",
+		Value: checksumsManifestPath,
 	})
 
 	tokenSecretRef := ""
@@ -1043,6 +1151,7 @@ func (ctrl *VMExportController) createDataManifestConfigMap(vmExport *exportv1.V
 		}
 		data[externalCaConfigMapKey] = string(caCmBytes)
 	}
+	vm = replaceVolumeWithContainerDisk(vmExport, vm)
 	vmBytes, err := ctrl.generateVMDefinitionFromVm(vm)
 	if err != nil {
 		return nil, err
@@ -1115,22 +1224,10 @@ func (ctrl *VMExportController) addVolumeEnvironmentVariables(exportContainer *c
 		Value: mountPoint,
 	})
 	if types.IsPVCBlock(pvc.Spec.VolumeMode) {
-		exportContainer.Env = append(exportContainer.Env, corev1.EnvVar{
-			Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_URI", index),
-			Value: rawURI(pvc),
-		}, corev1.EnvVar{
-			Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_GZIP_URI", index),
-			Value: rawGzipURI(pvc),
-		})
+		exportContainer.Env = append(exportContainer.Env, rawImageEnvironmentVariables(pvc, index)...)
 	} else {
 		if ctrl.isKubevirtContentType(pvc) {
-			exportContainer.Env = append(exportContainer.Env, corev1.EnvVar{
-				Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_URI", index),
-				Value: rawURI(pvc),
-			}, corev1.EnvVar{
-				Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_GZIP_URI", index),
-				Value: rawGzipURI(pvc),
-			})
+			exportContainer.Env = append(exportContainer.Env, rawImageEnvironmentVariables(pvc, index)...)
 		} else {
 			exportContainer.Env = append(exportContainer.Env, corev1.EnvVar{
 				Name:  fmt.Sprintf("VOLUME%d_EXPORT_ARCHIVE_URI", index),
@@ -1139,10 +1236,34 @@ func (ctrl *VMExportController) addVolumeEnvironmentVariables(exportContainer *c
 				Name:  fmt.Sprintf("VOLUME%d_EXPORT_DIR_URI", index),
 				Value: dirURI(pvc),
 			})
+			if holdsBackup(pvc) {
+				exportContainer.Env = append(exportContainer.Env, corev1.EnvVar{
+					Name:  fmt.Sprintf("VOLUME%d_EXPORT_BACKUP_URI", index),
+					Value: backupURI(pvc),
+				})
+			}
 		}
 	}
 }
 
+func rawImageEnvironmentVariables(pvc *corev1.PersistentVolumeClaim, index int) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_URI", index),
+			Value: rawURI(pvc),
+		}, {
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_GZIP_URI", index),
+			Value: rawGzipURI(pvc),
+		}, {
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_QCOW2_URI", index),
+			Value: qcow2URI(pvc),
+		}, {
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_ZST_URI", index),
+			Value: rawZstURI(pvc),
+		},
+	}
+}
+
 func (ctrl *VMExportController) isKubevirtContentType(pvc *corev1.PersistentVolumeClaim) bool {
 	// Block volumes are assumed always KubevirtContentType
 	if types.IsPVCBlock(pvc.Spec.VolumeMode) {
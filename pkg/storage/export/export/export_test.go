@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,6 +42,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
@@ -81,6 +83,9 @@ var (
 		{
 			Name:  "EXPORT_VM_DEF_URI",
 			Value: manifestsPath,
+		}, {
+			Name:  "EXPORT_CHECKSUMS_URI",
+			Value: checksumsManifestPath,
 		}, {
 			Name:  "CERT_FILE",
 			Value: "/cert/tls.crt",
@@ -930,6 +935,259 @@ var _ = Describe("Export controller", func() {
 		Entry("Snapshot", populateVmExportVMSnapshot, 4),
 	)
 
+	DescribeTable("should only add the backup URI to a PVC holding a backup", func(annotations map[string]string, expectBackupURI bool) {
+		pvc := &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        testPVCName,
+				Namespace:   testNamespace,
+				Annotations: annotations,
+			},
+		}
+		container := &k8sv1.Container{}
+		controller.addVolumeEnvironmentVariables(container, pvc, 0, fileSystemMountPath+"/"+testPVCName)
+		backupEnv := k8sv1.EnvVar{
+			Name:  "VOLUME0_EXPORT_BACKUP_URI",
+			Value: fmt.Sprintf("/volumes/%s/backup/", testPVCName),
+		}
+		if expectBackupURI {
+			Expect(container.Env).To(ContainElement(backupEnv))
+		} else {
+			Expect(container.Env).ToNot(ContainElement(backupEnv))
+		}
+	},
+		Entry("with a backup", map[string]string{virtv1.PVCBackupAnnotation: "testvmi-disk0-20240101-000000.backup"}, true),
+		Entry("with a memory dump", map[string]string{virtv1.PVCMemoryDumpAnnotation: "testvmi-memory-dump-20240101-000000.memory.dump"}, false),
+		Entry("without a backup", nil, false),
+	)
+
+	It("should add an NBD port to the service if NBD is enabled", func() {
+		testVMExport := createPVCVMExport()
+		testVMExport.Spec.NBD = &exportv1.VirtualMachineExportNBD{}
+		service := controller.createServiceManifest(testVMExport)
+		Expect(service.Spec.Ports).To(HaveLen(2))
+		Expect(service.Spec.Ports[0].Name).To(Equal("https"))
+		Expect(service.Spec.Ports[1]).To(Equal(k8sv1.ServicePort{
+			Name:       "nbd",
+			Protocol:   "TCP",
+			Port:       nbdPort,
+			TargetPort: intstr.FromInt32(nbdPort),
+		}))
+	})
+
+	DescribeTable("should configure the NBD server of the exporter pod", func(readOnly *bool, expectedReadOnly bool) {
+		testPVC := &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testPVCName,
+				Namespace: testNamespace,
+			},
+			Spec: k8sv1.PersistentVolumeClaimSpec{
+				VolumeMode: (*k8sv1.PersistentVolumeMode)(pointer.P(string(k8sv1.PersistentVolumeBlock))),
+			},
+		}
+		testVMExport := createPVCVMExport()
+		testVMExport.Spec.NBD = &exportv1.VirtualMachineExportNBD{ReadOnly: readOnly}
+		populateInitialVMExportStatus(testVMExport)
+		Expect(controller.handleVMExportToken(testVMExport)).To(Succeed())
+		service := controller.createServiceManifest(testVMExport)
+
+		pod, err := controller.createExporterPodManifest(testVMExport, service, []*k8sv1.PersistentVolumeClaim{testPVC})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.Containers[0].Env).To(ContainElements(
+			k8sv1.EnvVar{Name: "VOLUME0_EXPORT_NBD_NAME", Value: testPVCName},
+			k8sv1.EnvVar{Name: "NBD_LISTEN_ADDR", Value: ":10809"},
+			k8sv1.EnvVar{Name: "NBD_READ_ONLY", Value: strconv.FormatBool(expectedReadOnly)},
+		))
+	},
+		Entry("read only by default", nil, true),
+		Entry("read only", pointer.P(true), true),
+		Entry("writable", pointer.P(false), false),
+	)
+
+	It("should only add NBD links to the internal links", func() {
+		testVMExport := createPVCVMExport()
+		pvc := createPVC(testPVCName, "kubevirt")
+		exporterPod := &k8sv1.Pod{
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{{
+					Env: []k8sv1.EnvVar{
+						{Name: "VOLUME0_EXPORT_PATH", Value: blockVolumeMountPath + "/" + testPVCName},
+						{Name: "VOLUME0_EXPORT_RAW_URI", Value: "/volumes/" + testPVCName + "/disk.img"},
+						{Name: "VOLUME0_EXPORT_NBD_NAME", Value: testPVCName},
+					},
+				}},
+			},
+			Status: k8sv1.PodStatus{Phase: k8sv1.PodRunning},
+		}
+		nbdFormat := exportv1.VirtualMachineExportVolumeFormat{
+			Format: exportv1.NBD,
+			Url:    fmt.Sprintf("nbds://host.svc:10809/%s", testPVCName),
+		}
+
+		internalLink, err := controller.getLinks([]*k8sv1.PersistentVolumeClaim{pvc}, exporterPod, testVMExport, "host.svc", internal, "cert", getVolumeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(internalLink.Volumes).To(HaveLen(1))
+		Expect(internalLink.Volumes[0].Formats).To(ContainElement(nbdFormat))
+
+		externalLink, err := controller.getLinks([]*k8sv1.PersistentVolumeClaim{pvc}, exporterPod, testVMExport, "host.svc", external, "cert", getVolumeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(externalLink.Volumes).To(HaveLen(1))
+		for _, format := range externalLink.Volumes[0].Formats {
+			Expect(format.Format).ToNot(Equal(exportv1.NBD))
+		}
+	})
+
+	DescribeTable("should publish the checksums", func(nbd *exportv1.VirtualMachineExportNBD, expectChecksums bool) {
+		testVMExport := createPVCVMExport()
+		testVMExport.Spec.NBD = nbd
+		pvc := createPVC(testPVCName, "kubevirt")
+		env := []k8sv1.EnvVar{
+			{Name: "EXPORT_CHECKSUMS_URI", Value: checksumsManifestPath},
+			{Name: "VOLUME0_EXPORT_PATH", Value: blockVolumeMountPath + "/" + testPVCName},
+			{Name: "VOLUME0_EXPORT_RAW_URI", Value: "/volumes/" + testPVCName + "/disk.img"},
+		}
+		if nbd != nil {
+			env = append(env, k8sv1.EnvVar{Name: "VOLUME0_EXPORT_NBD_NAME", Value: testPVCName})
+		}
+		exporterPod := &k8sv1.Pod{
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{{Env: env}},
+			},
+			Status: k8sv1.PodStatus{Phase: k8sv1.PodRunning},
+		}
+		checksumsManifest := exportv1.VirtualMachineExportManifest{
+			Type: exportv1.Checksums,
+			Url:  "https://host.svc/manifests/sha256sums",
+		}
+		rawFormat := exportv1.VirtualMachineExportVolumeFormat{
+			Format: exportv1.KubeVirtRaw,
+			Url:    fmt.Sprintf("https://host.svc/volumes/%s/disk.img", testPVCName),
+		}
+
+		link, err := controller.getLinks([]*k8sv1.PersistentVolumeClaim{pvc}, exporterPod, testVMExport, "host.svc", internal, "cert", getVolumeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(link.Volumes).To(HaveLen(1))
+		if expectChecksums {
+			rawFormat.ChecksumUrl = rawFormat.Url + ChecksumSuffix
+			Expect(link.Manifests).To(ContainElement(checksumsManifest))
+		} else {
+			Expect(link.Manifests).ToNot(ContainElement(checksumsManifest))
+		}
+		Expect(link.Volumes[0].Formats).To(ContainElement(rawFormat))
+	},
+		Entry("without NBD", nil, true),
+		Entry("with a read only NBD export", &exportv1.VirtualMachineExportNBD{}, true),
+		Entry("not with a writable NBD export", &exportv1.VirtualMachineExportNBD{ReadOnly: pointer.P(false)}, false),
+	)
+
+	It("should not configure an NBD server if NBD is not enabled", func() {
+		testVMExport := createPVCVMExport()
+		populateInitialVMExportStatus(testVMExport)
+		Expect(controller.handleVMExportToken(testVMExport)).To(Succeed())
+		service := controller.createServiceManifest(testVMExport)
+		Expect(service.Spec.Ports).To(HaveLen(1))
+
+		pod, err := controller.createExporterPodManifest(testVMExport, service, []*k8sv1.PersistentVolumeClaim{createPVC(testPVCName, "kubevirt")})
+		Expect(err).ToNot(HaveOccurred())
+		for _, env := range pod.Spec.Containers[0].Env {
+			Expect(env.Name).ToNot(ContainSubstring("NBD"))
+		}
+	})
+
+	DescribeTable("should package the selected volume into a containerDisk image", func(volume string, expected bool) {
+		testVMExport := createPVCVMExport()
+		testVMExport.Spec.ContainerDisk = &exportv1.VirtualMachineExportContainerDisk{
+			Volume: volume,
+			Image:  "registry.example.com/vm:v1",
+		}
+		populateInitialVMExportStatus(testVMExport)
+		Expect(controller.handleVMExportToken(testVMExport)).To(Succeed())
+		service := controller.createServiceManifest(testVMExport)
+
+		pod, err := controller.createExporterPodManifest(testVMExport, service, []*k8sv1.PersistentVolumeClaim{createPVC(testPVCName, "kubevirt")})
+		Expect(err).ToNot(HaveOccurred())
+		containerDiskEnv := []k8sv1.EnvVar{
+			{Name: "VOLUME0_EXPORT_CONTAINERDISK_URI", Value: "/volumes/" + testPVCName + "/containerdisk.tar"},
+			{Name: "VOLUME0_EXPORT_CONTAINERDISK_IMAGE", Value: "registry.example.com/vm:v1"},
+		}
+		if expected {
+			Expect(pod.Spec.Containers[0].Env).To(ContainElements(containerDiskEnv))
+		} else {
+			Expect(pod.Spec.Containers[0].Env).ToNot(ContainElements(containerDiskEnv))
+		}
+	},
+		Entry("if it is the selected volume", testPVCName, true),
+		Entry("not if it is another volume", "other", false),
+	)
+
+	It("should add the containerDisk link", func() {
+		testVMExport := createPVCVMExport()
+		pvc := createPVC(testPVCName, "kubevirt")
+		exporterPod := &k8sv1.Pod{
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{{
+					Env: []k8sv1.EnvVar{
+						{Name: "VOLUME0_EXPORT_PATH", Value: blockVolumeMountPath + "/" + testPVCName},
+						{Name: "VOLUME0_EXPORT_RAW_URI", Value: "/volumes/" + testPVCName + "/disk.img"},
+						{Name: "VOLUME0_EXPORT_CONTAINERDISK_URI", Value: "/volumes/" + testPVCName + "/containerdisk.tar"},
+						{Name: "VOLUME0_EXPORT_CONTAINERDISK_IMAGE", Value: "registry.example.com/vm:v1"},
+					},
+				}},
+			},
+			Status: k8sv1.PodStatus{Phase: k8sv1.PodRunning},
+		}
+
+		link, err := controller.getLinks([]*k8sv1.PersistentVolumeClaim{pvc}, exporterPod, testVMExport, "host.svc", internal, "cert", getVolumeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(link.Volumes).To(HaveLen(1))
+		Expect(link.Volumes[0].Formats).To(ContainElement(exportv1.VirtualMachineExportVolumeFormat{
+			Format:      exportv1.ContainerDisk,
+			Url:         fmt.Sprintf("https://host.svc/volumes/%s/containerdisk.tar", testPVCName),
+			ChecksumUrl: fmt.Sprintf("https://host.svc/volumes/%s/containerdisk.tar.sha256", testPVCName),
+		}))
+	})
+
+	It("should replace the volume packaged into the containerDisk image in the VM manifest", func() {
+		testVMExport := createVMVMExport()
+		testVMExport.Spec.ContainerDisk = &exportv1.VirtualMachineExportContainerDisk{
+			Volume: "rootdisk",
+			Image:  "registry.example.com/vm:v1",
+		}
+		vm := &virtv1.VirtualMachine{
+			Spec: virtv1.VirtualMachineSpec{
+				DataVolumeTemplates: []virtv1.DataVolumeTemplateSpec{
+					{ObjectMeta: metav1.ObjectMeta{Name: "rootdisk"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "datadisk"}},
+				},
+				Template: &virtv1.VirtualMachineInstanceTemplateSpec{
+					Spec: virtv1.VirtualMachineInstanceSpec{
+						Volumes: []virtv1.Volume{{
+							Name: "root",
+							VolumeSource: virtv1.VolumeSource{
+								DataVolume: &virtv1.DataVolumeSource{Name: "rootdisk"},
+							},
+						}, {
+							Name: "data",
+							VolumeSource: virtv1.VolumeSource{
+								DataVolume: &virtv1.DataVolumeSource{Name: "datadisk"},
+							},
+						}},
+					},
+				},
+			},
+		}
+
+		result := replaceVolumeWithContainerDisk(testVMExport, vm)
+		Expect(result.Spec.DataVolumeTemplates).To(HaveLen(1))
+		Expect(result.Spec.DataVolumeTemplates[0].Name).To(Equal("datadisk"))
+		Expect(result.Spec.Template.Spec.Volumes[0].VolumeSource).To(Equal(virtv1.VolumeSource{
+			ContainerDisk: &virtv1.ContainerDiskSource{Image: "registry.example.com/vm:v1"},
+		}))
+		Expect(result.Spec.Template.Spec.Volumes[1].DataVolume.Name).To(Equal("datadisk"))
+		// the VM itself is not modified
+		Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(2))
+		Expect(vm.Spec.Template.Spec.Volumes[0].DataVolume).ToNot(BeNil())
+	})
+
 	It("Should create a secret based on the vm export", func() {
 		cp := &CertParams{Duration: 24 * time.Hour, RenewBefore: 2 * time.Hour}
 		scp, err := serializeCertParams(cp)
@@ -1364,48 +1622,49 @@ func verifyLinksInternal(vmExport *exportv1.VirtualMachineExport, expectedVolume
 	Expect(vmExport.Status.Links).ToNot(BeNil())
 	Expect(vmExport.Status.Links.Internal).NotTo(BeNil())
 	Expect(vmExport.Status.Links.Internal.Cert).NotTo(BeEmpty())
-	Expect(vmExport.Status.Links.Internal.Volumes).To(HaveLen(len(expectedVolumeFormats) / 2))
+	var formats []exportv1.VirtualMachineExportVolumeFormat
 	for _, volume := range vmExport.Status.Links.Internal.Volumes {
-		Expect(volume.Formats).To(HaveLen(2))
-		Expect(expectedVolumeFormats).To(ContainElements(volume.Formats))
+		formats = append(formats, volume.Formats...)
 	}
+	Expect(formats).To(ConsistOf(expectedVolumeFormats))
 }
 
-func verifyLinksExternal(vmExport *exportv1.VirtualMachineExport, link1Format exportv1.ExportVolumeFormat, link1Url string, link2Format exportv1.ExportVolumeFormat, link2Url string) {
+func verifyLinksExternal(vmExport *exportv1.VirtualMachineExport, expectedVolumeFormats ...exportv1.VirtualMachineExportVolumeFormat) {
 	Expect(vmExport.Status.Links.External).ToNot(BeNil())
 	Expect(vmExport.Status.Links.External.Cert).To(BeEmpty())
 	Expect(vmExport.Status.Links.External.Volumes).To(HaveLen(1))
-	Expect(vmExport.Status.Links.External.Volumes[0].Formats).To(HaveLen(2))
-	Expect(vmExport.Status.Links.External.Volumes[0].Formats).To(ContainElements(exportv1.VirtualMachineExportVolumeFormat{
-		Format: link1Format,
-		Url:    link1Url,
-	}, exportv1.VirtualMachineExportVolumeFormat{
-		Format: link2Format,
-		Url:    link2Url,
-	}))
+	Expect(vmExport.Status.Links.External.Volumes[0].Formats).To(ConsistOf(expectedVolumeFormats))
+}
+
+// kubevirtVolumeFormats returns the formats of a volume of kubevirt content type
+func kubevirtVolumeFormats(volumeURL string) []exportv1.VirtualMachineExportVolumeFormat {
+	var formats []exportv1.VirtualMachineExportVolumeFormat
+	for format, file := range map[exportv1.ExportVolumeFormat]string{
+		exportv1.KubeVirtRaw:    "disk.img",
+		exportv1.KubeVirtGz:     "disk.img.gz",
+		exportv1.KubeVirtQcow2:  "disk.qcow2",
+		exportv1.KubeVirtRawZst: "disk.img.zst",
+	} {
+		formats = append(formats, exportv1.VirtualMachineExportVolumeFormat{
+			Format:      format,
+			Url:         volumeURL + "/" + file,
+			ChecksumUrl: volumeURL + "/" + file + ".sha256",
+		})
+	}
+	return formats
 }
 
 func verifyKubevirtInternal(vmExport *exportv1.VirtualMachineExport, exportName, namespace string, volumeNames ...string) {
 	exportVolumeFormats := make([]exportv1.VirtualMachineExportVolumeFormat, 0)
 	for _, volumeName := range volumeNames {
-		exportVolumeFormats = append(exportVolumeFormats, exportv1.VirtualMachineExportVolumeFormat{
-			Format: exportv1.KubeVirtRaw,
-			Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName),
-		})
-		exportVolumeFormats = append(exportVolumeFormats, exportv1.VirtualMachineExportVolumeFormat{
-			Format: exportv1.KubeVirtGz,
-			Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img.gz", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName),
-		})
+		exportVolumeFormats = append(exportVolumeFormats, kubevirtVolumeFormats(fmt.Sprintf("https://%s.%s.svc/volumes/%s", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName))...)
 	}
 	verifyLinksInternal(vmExport, exportVolumeFormats...)
 }
 
 func verifyKubevirtExternal(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName string) {
 	verifyLinksExternal(vmExport,
-		exportv1.KubeVirtRaw,
-		fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/%s/namespaces/%s/virtualmachineexports/%s/volumes/%s/disk.img", currentVersion, namespace, exportName, volumeName),
-		exportv1.KubeVirtGz,
-		fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/%s/namespaces/%s/virtualmachineexports/%s/volumes/%s/disk.img.gz", currentVersion, namespace, exportName, volumeName))
+		kubevirtVolumeFormats(fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/%s/namespaces/%s/virtualmachineexports/%s/volumes/%s", currentVersion, namespace, exportName, volumeName))...)
 }
 
 func verifyArchiveInternal(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName string) {
@@ -1481,10 +1740,13 @@ func ingressToHost() *networkingv1.Ingress {
 
 func verifyArchiveExternal(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName string) {
 	verifyLinksExternal(vmExport,
-		exportv1.Dir,
-		fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/%s/namespaces/%s/virtualmachineexports/%s/volumes/%s/dir", currentVersion, namespace, exportName, volumeName),
-		exportv1.ArchiveGz,
-		fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/%s/namespaces/%s/virtualmachineexports/%s/volumes/%s/disk.tar.gz", currentVersion, namespace, exportName, volumeName))
+		exportv1.VirtualMachineExportVolumeFormat{
+			Format: exportv1.Dir,
+			Url:    fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/%s/namespaces/%s/virtualmachineexports/%s/volumes/%s/dir", currentVersion, namespace, exportName, volumeName),
+		}, exportv1.VirtualMachineExportVolumeFormat{
+			Format: exportv1.ArchiveGz,
+			Url:    fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/%s/namespaces/%s/virtualmachineexports/%s/volumes/%s/disk.tar.gz", currentVersion, namespace, exportName, volumeName),
+		})
 }
 
 func writeCertsToDir(dir string) {
@@ -98,6 +98,7 @@ func (ctrl *VMExportController) getLinks(pvcs []*corev1.PersistentVolumeClaim, e
 		})
 	}
 
+	hasChecksums := false
 	for _, pvc := range pvcs {
 		if pvc == nil || exporterPod.Status.Phase != corev1.PodRunning {
 			continue
@@ -113,17 +114,30 @@ func (ctrl *VMExportController) getLinks(pvcs []*corev1.PersistentVolumeClaim, e
 			Name: getVolumeName(pvc, export),
 		}
 
-		if volumeInfo.RawURI != "" {
-			ev.Formats = append(ev.Formats, exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtRaw,
-				Url:    scheme + path.Join(hostAndBase, volumeInfo.RawURI),
-			})
-		}
-		if volumeInfo.RawGzURI != "" {
-			ev.Formats = append(ev.Formats, exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtGz,
-				Url:    scheme + path.Join(hostAndBase, volumeInfo.RawGzURI),
-			})
+		// the images generated from a raw image publish their checksums, unless they are writable over NBD
+		publishChecksums := volumeInfo.NBDName == "" || nbdReadOnly(export)
+		for _, image := range []struct {
+			format exportv1.ExportVolumeFormat
+			uri    string
+		}{
+			{exportv1.KubeVirtRaw, volumeInfo.RawURI},
+			{exportv1.KubeVirtGz, volumeInfo.RawGzURI},
+			{exportv1.KubeVirtQcow2, volumeInfo.Qcow2URI},
+			{exportv1.KubeVirtRawZst, volumeInfo.RawZstURI},
+			{exportv1.ContainerDisk, volumeInfo.ContainerDiskURI},
+		} {
+			if image.uri == "" {
+				continue
+			}
+			format := exportv1.VirtualMachineExportVolumeFormat{
+				Format: image.format,
+				Url:    scheme + path.Join(hostAndBase, image.uri),
+			}
+			if publishChecksums {
+				format.ChecksumUrl = format.Url + ChecksumSuffix
+				hasChecksums = true
+			}
+			ev.Formats = append(ev.Formats, format)
 		}
 		if volumeInfo.DirURI != "" {
 			ev.Formats = append(ev.Formats, exportv1.VirtualMachineExportVolumeFormat{
@@ -137,6 +151,19 @@ func (ctrl *VMExportController) getLinks(pvcs []*corev1.PersistentVolumeClaim, e
 				Url:    scheme + path.Join(hostAndBase, volumeInfo.ArchiveURI),
 			})
 		}
+		// NBD is not proxied, it is only reachable through the service
+		if volumeInfo.NBDName != "" && linkType == internal {
+			ev.Formats = append(ev.Formats, exportv1.VirtualMachineExportVolumeFormat{
+				Format: exportv1.NBD,
+				Url:    fmt.Sprintf("nbds://%s:%d/%s", hostAndBase, nbdPort, volumeInfo.NBDName),
+			})
+		}
+		if volumeInfo.BackupURI != "" {
+			ev.Formats = append(ev.Formats, exportv1.VirtualMachineExportVolumeFormat{
+				Format: exportv1.Backup,
+				Url:    scheme + path.Join(hostAndBase, volumeInfo.BackupURI),
+			})
+		}
 
 		if len(ev.Formats) == 0 {
 			log.Log.Warningf("No formats found for volume %s", pvc.Name)
@@ -146,6 +173,13 @@ func (ctrl *VMExportController) getLinks(pvcs []*corev1.PersistentVolumeClaim, e
 		exportLink.Volumes = append(exportLink.Volumes, ev)
 	}
 
+	if paths.ChecksumsURI != "" && hasChecksums {
+		exportLink.Manifests = append(exportLink.Manifests, exportv1.VirtualMachineExportManifest{
+			Type: exportv1.Checksums,
+			Url:  scheme + path.Join(hostAndBase, paths.ChecksumsURI),
+		})
+	}
+
 	return exportLink, nil
 }
 
@@ -15,13 +15,26 @@ type VolumeInfo struct {
 	DirURI     string
 	RawURI     string
 	RawGzURI   string
+	Qcow2URI   string
+	RawZstURI  string
+	BackupURI  string
+	// NBDName is the name of the NBD export of the volume
+	NBDName string
+	// ContainerDiskURI serves the volume packaged into the containerDisk image ContainerDiskImage
+	ContainerDiskURI   string
+	ContainerDiskImage string
 }
 
+// ChecksumSuffix is appended to the URI of an image to get its SHA-256 checksum
+const ChecksumSuffix = ".sha256"
+
 // ServerPaths contains static paths and per-volume paths
 type ServerPaths struct {
 	VMURI     string
 	SecretURI string
-	Volumes   []VolumeInfo
+	// ChecksumsURI serves the SHA-256 checksums of the images of all volumes
+	ChecksumsURI string
+	Volumes      []VolumeInfo
 }
 
 // EnvironToMap converts the environment variables to a map
@@ -46,18 +59,25 @@ func ContainerEnvToMap(env []corev1.EnvVar) map[string]string {
 // CreateServerPaths creates a ServerPaths object from the environment variables
 func CreateServerPaths(env map[string]string) *ServerPaths {
 	result := &ServerPaths{
-		VMURI:     env["EXPORT_VM_DEF_URI"],
-		SecretURI: env["EXPORT_SECRET_DEF_URI"],
+		VMURI:        env["EXPORT_VM_DEF_URI"],
+		SecretURI:    env["EXPORT_SECRET_DEF_URI"],
+		ChecksumsURI: env["EXPORT_CHECKSUMS_URI"],
 	}
 	for k, v := range env {
 		if strings.HasSuffix(k, "_EXPORT_PATH") {
 			envPrefix := strings.TrimSuffix(k, "_EXPORT_PATH")
 			vi := VolumeInfo{
-				Path:       v,
-				ArchiveURI: env[envPrefix+"_EXPORT_ARCHIVE_URI"],
-				DirURI:     env[envPrefix+"_EXPORT_DIR_URI"],
-				RawURI:     env[envPrefix+"_EXPORT_RAW_URI"],
-				RawGzURI:   env[envPrefix+"_EXPORT_RAW_GZIP_URI"],
+				Path:               v,
+				ArchiveURI:         env[envPrefix+"_EXPORT_ARCHIVE_URI"],
+				DirURI:             env[envPrefix+"_EXPORT_DIR_URI"],
+				RawURI:             env[envPrefix+"_EXPORT_RAW_URI"],
+				RawGzURI:           env[envPrefix+"_EXPORT_RAW_GZIP_URI"],
+				Qcow2URI:           env[envPrefix+"_EXPORT_QCOW2_URI"],
+				RawZstURI:          env[envPrefix+"_EXPORT_RAW_ZST_URI"],
+				BackupURI:          env[envPrefix+"_EXPORT_BACKUP_URI"],
+				NBDName:            env[envPrefix+"_EXPORT_NBD_NAME"],
+				ContainerDiskURI:   env[envPrefix+"_EXPORT_CONTAINERDISK_URI"],
+				ContainerDiskImage: env[envPrefix+"_EXPORT_CONTAINERDISK_IMAGE"],
 			}
 			result.Volumes = append(result.Volumes, vi)
 		}
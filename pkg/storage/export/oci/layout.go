@@ -0,0 +1,221 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+// Package oci packages disk images into containerDisk images in the OCI image
+// layout, see https://github.com/opencontainers/image-spec/blob/main/image-layout.md
+package oci
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/sparse"
+)
+
+const (
+	MediaTypeImageIndex    = "application/vnd.oci.image.index.v1+json"
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeImageConfig   = "application/vnd.oci.image.config.v1+json"
+	MediaTypeImageLayer    = "application/vnd.oci.image.layer.v1.tar"
+
+	// AnnotationRefName is the annotation of the tag of an image in an index
+	AnnotationRefName = "org.opencontainers.image.ref.name"
+	// annotationImageName is the annotation containerd names imported images after
+	annotationImageName = "io.containerd.image.name"
+
+	LayoutFile    = "oci-layout"
+	IndexFile     = "index.json"
+	BlobsDir      = "blobs/sha256/"
+	layoutVersion = "1.0.0"
+
+	// DiskDir is the directory which holds the disk of a containerDisk image
+	DiskDir  = "disk/"
+	diskFile = DiskDir + "disk.qcow2"
+	// qemuUser owns the disk, the disk is only readable by it
+	qemuUser = 107
+)
+
+// Descriptor references a blob of an image
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Index lists the manifests of an image layout
+type Index struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Manifests     []Descriptor `json:"manifests"`
+}
+
+// Manifest references the config and the layers of an image
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+}
+
+type imageConfig struct {
+	Architecture string   `json:"architecture"`
+	OS           string   `json:"os"`
+	RootFS       rootFS   `json:"rootfs"`
+	Config       struct{} `json:"config"`
+}
+
+type rootFS struct {
+	Type    string   `json:"type"`
+	DiffIDs []string `json:"diff_ids"`
+}
+
+// Layer is the only layer of a containerDisk image, it holds the disk in QCOW2 format in /disk/
+type Layer struct {
+	*tarball
+}
+
+// NewLayer converts the raw image to the layer of a containerDisk image
+func NewLayer(f *os.File) (*Layer, error) {
+	qcow2, err := sparse.NewQcow2(f)
+	if err != nil {
+		return nil, err
+	}
+	dir := &tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     DiskDir,
+		Mode:     0755,
+	}
+	disk := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     diskFile,
+		Mode:     0440,
+		Uid:      qemuUser,
+		Gid:      qemuUser,
+	}
+	t, err := newTarball([]entry{{header: dir}}, disk, qcow2, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Layer{t}, nil
+}
+
+// Digest computes the digest of the layer, which reads the whole disk
+func (l *Layer) Digest() (string, error) {
+	hash := sha256.New()
+	if _, err := l.WriteTo(hash); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Layout is a tarball of the OCI image layout of a containerDisk image
+type Layout struct {
+	*tarball
+}
+
+// NewLayout lays out the image of the layer with the given digest, the
+// image is tagged with the tag of the reference.
+func NewLayout(layer *Layer, layerDigest string, ref *Reference) (*Layout, error) {
+	config, err := json.Marshal(imageConfig{
+		Architecture: runtime.GOARCH,
+		OS:           "linux",
+		RootFS: rootFS{
+			Type: "layers",
+			// the layer is not compressed
+			DiffIDs: []string{layerDigest},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	configDescriptor := Descriptor{MediaType: MediaTypeImageConfig, Digest: digest(config), Size: int64(len(config))}
+	layerDescriptor := Descriptor{MediaType: MediaTypeImageLayer, Digest: layerDigest, Size: layer.Size()}
+	manifest, err := json.Marshal(Manifest{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeImageManifest,
+		Config:        configDescriptor,
+		Layers:        []Descriptor{layerDescriptor},
+	})
+	if err != nil {
+		return nil, err
+	}
+	index, err := json.Marshal(Index{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeImageIndex,
+		Manifests: []Descriptor{{
+			MediaType: MediaTypeImageManifest,
+			Digest:    digest(manifest),
+			Size:      int64(len(manifest)),
+			Annotations: map[string]string{
+				AnnotationRefName:   ref.Tag,
+				annotationImageName: ref.String(),
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ociLayout, err := json.Marshal(map[string]string{"imageLayoutVersion": layoutVersion})
+	if err != nil {
+		return nil, err
+	}
+
+	// the manifest and the index follow the blobs they reference, so the layout
+	// can be pushed to a registry while it is streamed
+	before := []entry{
+		{header: fileHeader(LayoutFile), data: ociLayout},
+		{header: &tar.Header{Typeflag: tar.TypeDir, Name: "blobs/", Mode: 0755}},
+		{header: &tar.Header{Typeflag: tar.TypeDir, Name: BlobsDir, Mode: 0755}},
+		{header: fileHeader(BlobPath(configDescriptor.Digest)), data: config},
+	}
+	after := []entry{
+		{header: fileHeader(BlobPath(digest(manifest))), data: manifest},
+		{header: fileHeader(IndexFile), data: index},
+	}
+	t, err := newTarball(before, fileHeader(BlobPath(layerDigest)), layer, after)
+	if err != nil {
+		return nil, err
+	}
+	return &Layout{t}, nil
+}
+
+// BlobPath returns the path of the blob with the digest in the layout
+func BlobPath(digest string) string {
+	return path.Join(BlobsDir, strings.TrimPrefix(digest, "sha256:"))
+}
+
+func fileHeader(name string) *tar.Header {
+	return &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+	}
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,150 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	diskSize   = 8 * 1024 * 1024
+	qcow2Magic = 0x514649fb
+)
+
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// readTar returns the headers and the contents of the files of the archive in order
+func readTar(data []byte) ([]*tar.Header, map[string][]byte) {
+	var headers []*tar.Header
+	files := make(map[string][]byte)
+	tr := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return headers, files
+		}
+		Expect(err).ToNot(HaveOccurred())
+		headers = append(headers, header)
+		content, err := io.ReadAll(tr)
+		Expect(err).ToNot(HaveOccurred())
+		files[header.Name] = content
+	}
+}
+
+var _ = Describe("Layout", func() {
+	var disk *os.File
+
+	BeforeEach(func() {
+		var err error
+		disk, err = os.Create(filepath.Join(GinkgoT().TempDir(), "disk.img"))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(disk.Close)
+		Expect(disk.Truncate(diskSize)).To(Succeed())
+		_, err = disk.WriteAt(bytes.Repeat([]byte{0xab}, 4096), 1024*1024)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should put the disk in QCOW2 format into /disk/ of the layer", func() {
+		layer, err := NewLayer(disk)
+		Expect(err).ToNot(HaveOccurred())
+		var buf bytes.Buffer
+		n, err := layer.WriteTo(&buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(layer.Size()))
+		Expect(int64(buf.Len())).To(Equal(layer.Size()))
+
+		headers, files := readTar(buf.Bytes())
+		Expect(headers).To(HaveLen(2))
+		Expect(headers[0].Name).To(Equal(DiskDir))
+		Expect(headers[0].Typeflag).To(Equal(byte(tar.TypeDir)))
+		Expect(headers[1].Name).To(Equal("disk/disk.qcow2"))
+		Expect(headers[1].Uid).To(Equal(107))
+		Expect(headers[1].Gid).To(Equal(107))
+		Expect(headers[1].Mode).To(Equal(int64(0440)))
+		image := files["disk/disk.qcow2"]
+		Expect(binary.BigEndian.Uint32(image)).To(Equal(uint32(qcow2Magic)))
+		// the virtual size of the image
+		Expect(binary.BigEndian.Uint64(image[24:])).To(Equal(uint64(diskSize)))
+
+		digest, err := layer.Digest()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest).To(Equal(sha256Digest(buf.Bytes())))
+	})
+
+	It("should lay out the image", func() {
+		layer, err := NewLayer(disk)
+		Expect(err).ToNot(HaveOccurred())
+		layerDigest, err := layer.Digest()
+		Expect(err).ToNot(HaveOccurred())
+		ref, err := ParseReference("registry.example.com/vms/disk:v1")
+		Expect(err).ToNot(HaveOccurred())
+		layout, err := NewLayout(layer, layerDigest, ref)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		n, err := layout.WriteTo(&buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(layout.Size()))
+		Expect(int64(buf.Len())).To(Equal(layout.Size()))
+
+		headers, files := readTar(buf.Bytes())
+		Expect(headers[len(headers)-1].Name).To(Equal(IndexFile))
+		Expect(files[LayoutFile]).To(MatchJSON(`{"imageLayoutVersion":"1.0.0"}`))
+		for name, content := range files {
+			if name != BlobsDir && strings.HasPrefix(name, BlobsDir) {
+				Expect(BlobPath(sha256Digest(content))).To(Equal(name))
+			}
+		}
+
+		index := &Index{}
+		Expect(json.Unmarshal(files[IndexFile], index)).To(Succeed())
+		Expect(index.MediaType).To(Equal(MediaTypeImageIndex))
+		Expect(index.Manifests).To(HaveLen(1))
+		Expect(index.Manifests[0].Annotations).To(HaveKeyWithValue(AnnotationRefName, "v1"))
+		Expect(index.Manifests[0].Annotations).To(HaveKeyWithValue("io.containerd.image.name", "registry.example.com/vms/disk:v1"))
+
+		manifestData := files[BlobPath(index.Manifests[0].Digest)]
+		Expect(manifestData).To(HaveLen(int(index.Manifests[0].Size)))
+		manifest := &Manifest{}
+		Expect(json.Unmarshal(manifestData, manifest)).To(Succeed())
+		Expect(manifest.Layers).To(ConsistOf(Descriptor{MediaType: MediaTypeImageLayer, Digest: layerDigest, Size: layer.Size()}))
+		Expect(files[BlobPath(layerDigest)]).To(HaveLen(int(layer.Size())))
+
+		config := &imageConfig{}
+		Expect(json.Unmarshal(files[BlobPath(manifest.Config.Digest)], config)).To(Succeed())
+		Expect(config.OS).To(Equal("linux"))
+		Expect(config.RootFS.DiffIDs).To(ConsistOf(layerDigest))
+	})
+})
@@ -0,0 +1,90 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package oci
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultRegistry = "docker.io"
+	// dockerHubRegistry serves the registry API of docker.io
+	dockerHubRegistry = "registry-1.docker.io"
+	defaultTag        = "latest"
+)
+
+var (
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagRegexp        = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+)
+
+// Reference is a tagged image reference
+type Reference struct {
+	// Registry is the host of the registry, including the port
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+// ParseReference parses an image reference like registry:5000/repository:tag. Images
+// without a registry are on docker.io, images without a tag are tagged latest.
+// References by digest are rejected, since a new image cannot be pushed by digest.
+func ParseReference(ref string) (*Reference, error) {
+	if strings.Contains(ref, "@") {
+		return nil, fmt.Errorf("image reference %q has a digest, only tags are supported", ref)
+	}
+	result := &Reference{Registry: defaultRegistry, Tag: defaultTag}
+	name := ref
+	if registry, rest, found := strings.Cut(ref, "/"); found && (strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		result.Registry = registry
+		name = rest
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		result.Tag = name[i+1:]
+		name = name[:i]
+	}
+	if result.Registry == defaultRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	result.Repository = name
+
+	if !repositoryRegexp.MatchString(result.Repository) {
+		return nil, fmt.Errorf("image reference %q has an invalid repository", ref)
+	}
+	if !tagRegexp.MatchString(result.Tag) {
+		return nil, fmt.Errorf("image reference %q has an invalid tag", ref)
+	}
+	return result, nil
+}
+
+// String returns the fully qualified reference
+func (r *Reference) String() string {
+	return fmt.Sprintf("%s/%s:%s", r.Registry, r.Repository, r.Tag)
+}
+
+// RegistryHost returns the host which serves the registry API
+func (r *Reference) RegistryHost() string {
+	if r.Registry == defaultRegistry {
+		return dockerHubRegistry
+	}
+	return r.Registry
+}
@@ -0,0 +1,63 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package oci
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reference", func() {
+	DescribeTable("should parse", func(ref string, expected Reference, host string) {
+		result, err := ParseReference(ref)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*result).To(Equal(expected))
+		Expect(result.RegistryHost()).To(Equal(host))
+	},
+		Entry("a fully qualified reference", "registry.example.com/vms/fedora:39",
+			Reference{Registry: "registry.example.com", Repository: "vms/fedora", Tag: "39"}, "registry.example.com"),
+		Entry("a registry with a port", "localhost:5000/fedora:39",
+			Reference{Registry: "localhost:5000", Repository: "fedora", Tag: "39"}, "localhost:5000"),
+		Entry("localhost", "localhost/fedora",
+			Reference{Registry: "localhost", Repository: "fedora", Tag: "latest"}, "localhost"),
+		Entry("a reference without a tag", "registry.example.com/fedora",
+			Reference{Registry: "registry.example.com", Repository: "fedora", Tag: "latest"}, "registry.example.com"),
+		Entry("a docker.io reference", "kubevirt/fedora:39",
+			Reference{Registry: "docker.io", Repository: "kubevirt/fedora", Tag: "39"}, "registry-1.docker.io"),
+		Entry("an official docker.io image", "fedora",
+			Reference{Registry: "docker.io", Repository: "library/fedora", Tag: "latest"}, "registry-1.docker.io"),
+	)
+
+	DescribeTable("should reject", func(ref string) {
+		_, err := ParseReference(ref)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("a digest", "registry.example.com/fedora@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"),
+		Entry("an uppercase repository", "registry.example.com/Fedora:39"),
+		Entry("an empty tag", "registry.example.com/fedora:"),
+		Entry("an empty repository", "registry.example.com/:39"),
+	)
+
+	It("should print the fully qualified reference", func() {
+		ref, err := ParseReference("fedora:39")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ref.String()).To(Equal("docker.io/library/fedora:39"))
+	})
+})
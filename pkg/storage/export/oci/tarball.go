@@ -0,0 +1,111 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+const tarBlockSize = 512
+
+// content is the content of a file whose size is known before it is written
+type content interface {
+	Size() int64
+	WriteTo(w io.Writer) (int64, error)
+}
+
+type entry struct {
+	header *tar.Header
+	data   []byte
+}
+
+// tarball is a tar archive around a single large file. The archive before and
+// after the file is generated in advance, so the size of the archive is known
+// without reading the file and the file is streamed.
+type tarball struct {
+	head []byte
+	body content
+	tail []byte
+}
+
+func newTarball(before []entry, header *tar.Header, body content, after []entry) (*tarball, error) {
+	var head bytes.Buffer
+	tw := tar.NewWriter(&head)
+	if err := writeEntries(tw, before); err != nil {
+		return nil, err
+	}
+	header.Size = body.Size()
+	// the header is written right away, the body is written by WriteTo
+	if err := tw.WriteHeader(header); err != nil {
+		return nil, err
+	}
+
+	var tail bytes.Buffer
+	if rest := header.Size % tarBlockSize; rest != 0 {
+		tail.Write(make([]byte, tarBlockSize-rest))
+	}
+	tw = tar.NewWriter(&tail)
+	if err := writeEntries(tw, after); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &tarball{head: head.Bytes(), body: body, tail: tail.Bytes()}, nil
+}
+
+func writeEntries(tw *tar.Writer, entries []entry) error {
+	for _, e := range entries {
+		e.header.Size = int64(len(e.data))
+		if err := tw.WriteHeader(e.header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(e.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Size returns the size of the archive
+func (t *tarball) Size() int64 {
+	return int64(len(t.head)) + t.body.Size() + int64(len(t.tail))
+}
+
+// WriteTo writes the archive
+func (t *tarball) WriteTo(w io.Writer) (int64, error) {
+	written, err := w.Write(t.head)
+	if err != nil {
+		return int64(written), err
+	}
+	n, err := t.body.WriteTo(w)
+	total := int64(written) + n
+	if err != nil {
+		return total, err
+	}
+	if n != t.body.Size() {
+		return total, fmt.Errorf("wrote %d bytes of a file of %d bytes", n, t.body.Size())
+	}
+	written, err = w.Write(t.tail)
+	return total + int64(written), err
+}
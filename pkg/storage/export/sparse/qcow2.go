@@ -0,0 +1,253 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package sparse
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// QCOW2 version 3 without extensions, see
+// https://gitlab.com/qemu-project/qemu/-/blob/master/docs/interop/qcow2.txt
+const (
+	qcow2Magic         uint32 = 0x514649fb // "QFI\xfb"
+	qcow2Version       uint32 = 3
+	qcow2HeaderLength  uint32 = 104
+	qcow2ClusterBits          = 16
+	qcow2ClusterSize          = 1 << qcow2ClusterBits
+	qcow2RefcountOrder uint32 = 4
+	// qcow2Copied marks clusters with a refcount of exactly one
+	qcow2Copied uint64 = 1 << 63
+
+	qcow2L2Entries       = qcow2ClusterSize / 8
+	qcow2RefcountEntries = qcow2ClusterSize / 2
+)
+
+// Qcow2 converts a raw image to QCOW2 on the fly. Clusters which only contain zeroes
+// are not allocated, the conversion of the same image always yields the same output.
+type Qcow2 struct {
+	file *os.File
+	size int64
+	// allocated is a bitmap of the guest clusters which hold data
+	allocated []uint64
+
+	l1Size        int64
+	l1Clusters    int64
+	l2Tables      int64
+	dataClusters  int64
+	totalClusters int64
+	// the number of clusters of the refcount table and of the refcount blocks
+	refcountTable  int64
+	refcountBlocks int64
+}
+
+// NewQcow2 reads the data of the raw image to lay out the QCOW2 image
+func NewQcow2(f *os.File) (*Qcow2, error) {
+	size, err := Size(f)
+	if err != nil {
+		return nil, err
+	}
+	guestClusters := ceilDiv(size, qcow2ClusterSize)
+	q := &Qcow2{
+		file:      f,
+		size:      size,
+		allocated: make([]uint64, ceilDiv(guestClusters, 64)),
+		l1Size:    ceilDiv(guestClusters, qcow2L2Entries),
+	}
+	err = forEachChunk(f, size, qcow2ClusterSize, func(offset int64, data []byte) error {
+		if data != nil {
+			cluster := offset / qcow2ClusterSize
+			q.allocated[cluster/64] |= 1 << (cluster % 64)
+			q.dataClusters++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for l1Index := int64(0); l1Index < q.l1Size; l1Index++ {
+		if q.hasL2Table(l1Index) {
+			q.l2Tables++
+		}
+	}
+
+	q.l1Clusters = max(1, ceilDiv(q.l1Size*8, qcow2ClusterSize))
+	q.refcountTable, q.refcountBlocks, q.totalClusters = layoutRefcounts(1 + q.l1Clusters + q.l2Tables + q.dataClusters)
+	return q, nil
+}
+
+// layoutRefcounts returns the number of clusters of the refcount table and blocks
+// needed to count the clusters of the image, and the total number of clusters
+func layoutRefcounts(clusters int64) (table, blocks, total int64) {
+	// the refcount blocks have to count themselves and the refcount table
+	table, blocks = 1, 1
+	for {
+		total = clusters + table + blocks
+		neededBlocks := ceilDiv(total, qcow2RefcountEntries)
+		neededTable := ceilDiv(neededBlocks*8, qcow2ClusterSize)
+		if neededBlocks == blocks && neededTable == table {
+			return table, blocks, total
+		}
+		table, blocks = neededTable, neededBlocks
+	}
+}
+
+// Size returns the size of the QCOW2 image
+func (q *Qcow2) Size() int64 {
+	return q.totalClusters * qcow2ClusterSize
+}
+
+// WriteTo writes the QCOW2 image. The layout is: header, L1 table, refcount table,
+// refcount blocks, L2 tables and the data clusters in the order of the guest.
+func (q *Qcow2) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriterSize(cw, qcow2ClusterSize)
+	if err := q.writeMetadata(bw); err != nil {
+		return cw.n, err
+	}
+
+	buf := make([]byte, qcow2ClusterSize)
+	for cluster := int64(0); cluster < q.l1Size*qcow2L2Entries; cluster++ {
+		if !q.isAllocated(cluster) {
+			continue
+		}
+		if err := readChunk(q.file, cluster*qcow2ClusterSize, buf); err != nil {
+			return cw.n, err
+		}
+		if IsZero(buf) {
+			return cw.n, fmt.Errorf("image changed during the conversion")
+		}
+		if _, err := bw.Write(buf); err != nil {
+			return cw.n, err
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+func (q *Qcow2) writeMetadata(w io.Writer) error {
+	l1Offset := int64(qcow2ClusterSize)
+	refcountTableOffset := l1Offset + q.l1Clusters*qcow2ClusterSize
+	refcountBlocksOffset := refcountTableOffset + q.refcountTable*qcow2ClusterSize
+	l2Offset := refcountBlocksOffset + q.refcountBlocks*qcow2ClusterSize
+	dataOffset := l2Offset + q.l2Tables*qcow2ClusterSize
+
+	header := make([]byte, qcow2ClusterSize)
+	binary.BigEndian.PutUint32(header[0:], qcow2Magic)
+	binary.BigEndian.PutUint32(header[4:], qcow2Version)
+	binary.BigEndian.PutUint32(header[20:], qcow2ClusterBits)
+	binary.BigEndian.PutUint64(header[24:], uint64(q.size))
+	binary.BigEndian.PutUint32(header[36:], uint32(q.l1Size))
+	binary.BigEndian.PutUint64(header[40:], uint64(l1Offset))
+	binary.BigEndian.PutUint64(header[48:], uint64(refcountTableOffset))
+	binary.BigEndian.PutUint32(header[56:], uint32(q.refcountTable))
+	binary.BigEndian.PutUint32(header[96:], qcow2RefcountOrder)
+	binary.BigEndian.PutUint32(header[100:], qcow2HeaderLength)
+	// the zeroes after the header end the header extensions
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	l1Table := make([]byte, q.l1Clusters*qcow2ClusterSize)
+	next := l2Offset
+	for l1Index := int64(0); l1Index < q.l1Size; l1Index++ {
+		if q.hasL2Table(l1Index) {
+			binary.BigEndian.PutUint64(l1Table[l1Index*8:], uint64(next)|qcow2Copied)
+			next += qcow2ClusterSize
+		}
+	}
+	if _, err := w.Write(l1Table); err != nil {
+		return err
+	}
+
+	refcountTable := make([]byte, q.refcountTable*qcow2ClusterSize)
+	for i := int64(0); i < q.refcountBlocks; i++ {
+		binary.BigEndian.PutUint64(refcountTable[i*8:], uint64(refcountBlocksOffset+i*qcow2ClusterSize))
+	}
+	if _, err := w.Write(refcountTable); err != nil {
+		return err
+	}
+
+	refcountBlock := make([]byte, qcow2ClusterSize)
+	for i := int64(0); i < q.refcountBlocks; i++ {
+		clear(refcountBlock)
+		for j := int64(0); j < qcow2RefcountEntries && i*qcow2RefcountEntries+j < q.totalClusters; j++ {
+			binary.BigEndian.PutUint16(refcountBlock[j*2:], 1)
+		}
+		if _, err := w.Write(refcountBlock); err != nil {
+			return err
+		}
+	}
+
+	l2Table := make([]byte, qcow2ClusterSize)
+	next = dataOffset
+	for l1Index := int64(0); l1Index < q.l1Size; l1Index++ {
+		if !q.hasL2Table(l1Index) {
+			continue
+		}
+		clear(l2Table)
+		for j := int64(0); j < qcow2L2Entries; j++ {
+			if q.isAllocated(l1Index*qcow2L2Entries + j) {
+				binary.BigEndian.PutUint64(l2Table[j*8:], uint64(next)|qcow2Copied)
+				next += qcow2ClusterSize
+			}
+		}
+		if _, err := w.Write(l2Table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *Qcow2) isAllocated(cluster int64) bool {
+	word := cluster / 64
+	return word < int64(len(q.allocated)) && q.allocated[word]&(1<<(cluster%64)) != 0
+}
+
+// hasL2Table returns true if any cluster mapped by the L1 entry is allocated
+func (q *Qcow2) hasL2Table(l1Index int64) bool {
+	// an L2 table maps a multiple of 64 clusters
+	first := l1Index * qcow2L2Entries / 64
+	last := min((l1Index+1)*qcow2L2Entries/64, int64(len(q.allocated)))
+	for _, word := range q.allocated[min(first, last):last] {
+		if word != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+// Package sparse generates image formats which skip the zeroes of sparse raw images
+package sparse
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Extent is a range of a file which either holds data or is a hole
+type Extent struct {
+	Offset int64
+	Length int64
+	Hole   bool
+}
+
+// Size returns the size of a file or block device
+func Size(f *os.File) (int64, error) {
+	// the size of block devices is not reported by stat
+	return f.Seek(0, io.SeekEnd)
+}
+
+// Extents returns the extents of data and holes in the range [offset, end) of a file.
+// Files which cannot report their holes, like block devices, consist of data only.
+func Extents(f *os.File, offset, end int64) ([]Extent, error) {
+	fd := int(f.Fd())
+	var extents []Extent
+	for offset < end {
+		data, err := unix.Seek(fd, offset, unix.SEEK_DATA)
+		if errors.Is(err, unix.ENXIO) {
+			// no data past offset
+			data = end
+		} else if errors.Is(err, unix.EINVAL) {
+			return append(extents, Extent{Offset: offset, Length: end - offset}), nil
+		} else if err != nil {
+			return nil, err
+		}
+		if data > offset {
+			holeEnd := min(data, end)
+			extents = append(extents, Extent{Offset: offset, Length: holeEnd - offset, Hole: true})
+			offset = holeEnd
+			continue
+		}
+		hole, err := unix.Seek(fd, offset, unix.SEEK_HOLE)
+		if err != nil {
+			return nil, err
+		}
+		dataEnd := min(hole, end)
+		extents = append(extents, Extent{Offset: offset, Length: dataEnd - offset})
+		offset = dataEnd
+	}
+	return extents, nil
+}
+
+var zeroes = make([]byte, 64*1024)
+
+// IsZero returns true if the data only contains zeroes
+func IsZero(data []byte) bool {
+	for len(data) > 0 {
+		n := min(len(data), len(zeroes))
+		if !bytes.Equal(data[:n], zeroes[:n]) {
+			return false
+		}
+		data = data[n:]
+	}
+	return true
+}
+
+// forEachChunk calls fn for each chunk of the file, data is nil if the chunk
+// only contains zeroes. The last chunk is padded with zeroes.
+func forEachChunk(f *os.File, size, chunkSize int64, fn func(offset int64, data []byte) error) error {
+	extents, err := Extents(f, 0, size)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, chunkSize)
+	for offset := int64(0); offset < size; offset += chunkSize {
+		// skip the extents which end before the chunk
+		for len(extents) > 0 && extents[0].Offset+extents[0].Length <= offset {
+			extents = extents[1:]
+		}
+		if len(extents) > 0 && extents[0].Hole && extents[0].Offset+extents[0].Length >= min(offset+chunkSize, size) {
+			if err := fn(offset, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := readChunk(f, offset, buf); err != nil {
+			return err
+		}
+		data := buf
+		if IsZero(data) {
+			data = nil
+		}
+		if err := fn(offset, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readChunk reads the chunk at offset, the part past the end of the file is zeroed
+func readChunk(f *os.File, offset int64, buf []byte) error {
+	n, err := f.ReadAt(buf, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	clear(buf[n:])
+	return nil
+}
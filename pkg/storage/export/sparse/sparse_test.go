@@ -0,0 +1,212 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package sparse
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sparse", func() {
+	const imageSize = 10*1024*1024 + 123
+
+	var (
+		image *os.File
+		raw   []byte
+	)
+
+	writeAt := func(data []byte, offset int64) {
+		_, err := image.WriteAt(data, offset)
+		Expect(err).ToNot(HaveOccurred())
+		copy(raw[offset:], data)
+	}
+
+	BeforeEach(func() {
+		var err error
+		image, err = os.Create(filepath.Join(GinkgoT().TempDir(), "disk.img"))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(image.Close)
+		Expect(image.Truncate(imageSize)).To(Succeed())
+		raw = make([]byte, imageSize)
+	})
+
+	It("should report the extents of data and holes", func() {
+		writeAt(bytes.Repeat([]byte{1}, 4096), 1024*1024)
+
+		extents, err := Extents(image, 0, imageSize)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(extents).To(Equal([]Extent{
+			{Offset: 0, Length: 1024 * 1024, Hole: true},
+			{Offset: 1024 * 1024, Length: 4096},
+			{Offset: 1024*1024 + 4096, Length: imageSize - 1024*1024 - 4096, Hole: true},
+		}))
+
+		extents, err = Extents(image, 1024*1024+1024, 1024*1024+2048)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(extents).To(Equal([]Extent{{Offset: 1024*1024 + 1024, Length: 1024}}))
+	})
+
+	It("should detect zeroes", func() {
+		data := make([]byte, 200*1024)
+		Expect(IsZero(data)).To(BeTrue())
+		data[len(data)-1] = 1
+		Expect(IsZero(data)).To(BeFalse())
+		Expect(IsZero(nil)).To(BeTrue())
+	})
+
+	Context("zstd", func() {
+		It("should compress the image", func() {
+			writeAt(bytes.Repeat([]byte("hello world"), 10000), 5*1024*1024)
+			// written zeroes are no hole but are still encoded as zeroes
+			writeAt(make([]byte, zstdBlockSize), 6*1024*1024)
+			writeAt([]byte("tail"), imageSize-4)
+
+			var out bytes.Buffer
+			n, err := WriteZstd(&out, image)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(n).To(BeEquivalentTo(out.Len()))
+			Expect(out.Len()).To(BeNumerically("<", 64*1024))
+			Expect(decodeZstd(out.Bytes())).To(Equal(raw))
+		})
+
+		It("should generate the same frame every time", func() {
+			writeAt(bytes.Repeat([]byte("hello world"), 10000), 5*1024*1024)
+			var outputs [2]bytes.Buffer
+			for i := range outputs {
+				_, err := WriteZstd(&outputs[i], image)
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(outputs[0].Bytes()).To(Equal(outputs[1].Bytes()))
+		})
+
+		It("should encode an empty file", func() {
+			Expect(image.Truncate(0)).To(Succeed())
+			var out bytes.Buffer
+			_, err := WriteZstd(&out, image)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decodeZstd(out.Bytes())).To(BeEmpty())
+		})
+	})
+
+	Context("qcow2", func() {
+		It("should only allocate the clusters which hold data", func() {
+			writeAt([]byte("hello world"), 5*1024*1024)
+			writeAt(make([]byte, qcow2ClusterSize), 6*1024*1024)
+			writeAt([]byte("tail"), imageSize-4)
+
+			qcow2, err := NewQcow2(image)
+			Expect(err).ToNot(HaveOccurred())
+			var out bytes.Buffer
+			n, err := qcow2.WriteTo(&out)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(n).To(Equal(qcow2.Size()))
+			Expect(n).To(BeEquivalentTo(out.Len()))
+			// header, L1 table, refcount table, refcount block, L2 table and two data clusters
+			Expect(out.Len()).To(Equal(7 * qcow2ClusterSize))
+			Expect(decodeQcow2(out.Bytes())).To(Equal(raw))
+		})
+
+		It("should generate the same image every time", func() {
+			writeAt([]byte("hello world"), 5*1024*1024)
+			var outputs [2]bytes.Buffer
+			for i := range outputs {
+				qcow2, err := NewQcow2(image)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = qcow2.WriteTo(&outputs[i])
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(outputs[0].Bytes()).To(Equal(outputs[1].Bytes()))
+		})
+
+		DescribeTable("should count all clusters", func(clusters, expectedTable, expectedBlocks int64) {
+			table, blocks, total := layoutRefcounts(clusters)
+			Expect(table).To(Equal(expectedTable))
+			Expect(blocks).To(Equal(expectedBlocks))
+			Expect(total).To(Equal(clusters + table + blocks))
+		},
+			Entry("with a single refcount block", int64(5), int64(1), int64(1)),
+			Entry("with a full refcount block", int64(qcow2RefcountEntries-2), int64(1), int64(1)),
+			Entry("with a refcount block counting itself", int64(qcow2RefcountEntries-1), int64(1), int64(2)),
+			Entry("with several refcount table clusters", int64(qcow2RefcountEntries*qcow2ClusterSize/8), int64(2), int64(8193)),
+		)
+	})
+})
+
+// decodeZstd decodes a frame and checks that it announces the size of its content
+func decodeZstd(frame []byte) []byte {
+	var header zstd.Header
+	ExpectWithOffset(1, header.Decode(frame)).To(Succeed())
+	dec, err := zstd.NewReader(nil)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	defer dec.Close()
+	out, err := dec.DecodeAll(frame, nil)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	// the size is not announced for an empty frame
+	ExpectWithOffset(1, header.FrameContentSize).To(BeEquivalentTo(len(out)))
+	return out
+}
+
+// decodeQcow2 reads the guest data of the image and checks the refcounts
+func decodeQcow2(image []byte) []byte {
+	const offsetMask = 0x00fffffffffffe00
+	ExpectWithOffset(1, binary.BigEndian.Uint32(image)).To(Equal(qcow2Magic))
+	size := binary.BigEndian.Uint64(image[24:])
+	l1Size := binary.BigEndian.Uint32(image[36:])
+	l1Offset := binary.BigEndian.Uint64(image[40:])
+	refcountTableOffset := binary.BigEndian.Uint64(image[48:])
+	refcountTableClusters := binary.BigEndian.Uint32(image[56:])
+
+	out := make([]byte, size)
+	for i := uint64(0); i < uint64(l1Size); i++ {
+		l2Offset := binary.BigEndian.Uint64(image[l1Offset+8*i:]) & offsetMask
+		if l2Offset == 0 {
+			continue
+		}
+		for j := uint64(0); j < qcow2L2Entries; j++ {
+			dataOffset := binary.BigEndian.Uint64(image[l2Offset+8*j:]) & offsetMask
+			guestOffset := (i*qcow2L2Entries + j) * qcow2ClusterSize
+			if dataOffset != 0 {
+				copy(out[guestOffset:], image[dataOffset:dataOffset+qcow2ClusterSize])
+			}
+		}
+	}
+
+	clusters := uint64(len(image) / qcow2ClusterSize)
+	for i := uint64(0); i < uint64(refcountTableClusters)*qcow2ClusterSize/8; i++ {
+		blockOffset := binary.BigEndian.Uint64(image[refcountTableOffset+8*i:])
+		if blockOffset == 0 {
+			continue
+		}
+		for j := uint64(0); j < qcow2RefcountEntries; j++ {
+			expected := uint16(0)
+			if i*qcow2RefcountEntries+j < clusters {
+				expected = 1
+			}
+			ExpectWithOffset(1, binary.BigEndian.Uint16(image[blockOffset+2*j:])).To(Equal(expected))
+		}
+	}
+	return out
+}
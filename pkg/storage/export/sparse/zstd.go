@@ -0,0 +1,64 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package sparse
+
+import (
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdBlockSize is the size of the chunks of the file which are passed to the encoder
+const zstdBlockSize = 128 * 1024
+
+// WriteZstd writes the file as a single zstd frame, which announces the size of the file.
+// The holes of the file are not read, they are passed to the encoder as zeroes. The encoder
+// does not compress concurrently, so the same file is always encoded into the same frame.
+func WriteZstd(w io.Writer, f *os.File) (int64, error) {
+	size, err := Size(f)
+	if err != nil {
+		return 0, err
+	}
+	cw := &countingWriter{w: w}
+	enc, err := zstd.NewWriter(nil,
+		zstd.WithEncoderConcurrency(1),
+		// an empty file is encoded as an empty frame instead of an empty stream
+		zstd.WithZeroFrames(true),
+	)
+	if err != nil {
+		return 0, err
+	}
+	enc.ResetContentSize(cw, size)
+
+	zeroes := make([]byte, zstdBlockSize)
+	err = forEachChunk(f, size, zstdBlockSize, func(offset int64, data []byte) error {
+		if data == nil {
+			data = zeroes
+		}
+		_, err := enc.Write(data[:min(size-offset, zstdBlockSize)])
+		return err
+	})
+	if err != nil {
+		return cw.n, err
+	}
+	err = enc.Close()
+	return cw.n, err
+}
@@ -23,19 +23,24 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	goflag "flag"
 	"fmt"
 	"io"
 	golog "log"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	flag "github.com/spf13/pflag"
@@ -50,7 +55,11 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/service"
+	"kubevirt.io/kubevirt/pkg/storage/backup"
 	"kubevirt.io/kubevirt/pkg/storage/export/export"
+	"kubevirt.io/kubevirt/pkg/storage/export/oci"
+	"kubevirt.io/kubevirt/pkg/storage/export/sparse"
+	"kubevirt.io/kubevirt/pkg/storage/nbd"
 )
 
 const (
@@ -63,6 +72,11 @@ const (
 	externalCaConfigMapPath = manifestCmBasePath + "external_ca_cm"
 	exportNamePath          = manifestCmBasePath + "export-name"
 
+	backupExtentsExtension = ".extents"
+
+	// checksumRetryAfter is the number of seconds after which a client asks again for a checksum which is still computed
+	checksumRetryAfter = 10
+
 	external = "/external"
 	internal = "/internal"
 )
@@ -74,6 +88,10 @@ type ExportServerConfig struct {
 
 	ListenAddr string
 
+	// NBDListenAddr enables the NBD server if set
+	NBDListenAddr string
+	NBDReadOnly   bool
+
 	CertFile, KeyFile string
 
 	TokenFile string
@@ -81,12 +99,17 @@ type ExportServerConfig struct {
 	Paths *export.ServerPaths
 
 	// unit testing helpers
-	ArchiveHandler     func(string) http.Handler
-	DirHandler         func(string, string) http.Handler
-	FileHandler        func(string) http.Handler
-	GzipHandler        func(string) http.Handler
-	VmHandler          func([]export.VolumeInfo, func() (string, error), func() (*corev1.ConfigMap, error)) http.Handler
-	TokenSecretHandler func(TokenGetterFunc) http.Handler
+	ArchiveHandler       func(string) http.Handler
+	DirHandler           func(string, string) http.Handler
+	FileHandler          func(string) http.Handler
+	GzipHandler          func(string) http.Handler
+	BackupHandler        func(string, string) http.Handler
+	Qcow2Handler         func(string) http.Handler
+	ZstdHandler          func(string) http.Handler
+	ContainerDiskHandler func(string, *containerDisk) http.Handler
+	ChecksumHandler      func([]*imageChecksum, func(string) string) http.Handler
+	VmHandler            func([]export.VolumeInfo, func() (string, error), func() (*corev1.ConfigMap, error)) http.Handler
+	TokenSecretHandler   func(TokenGetterFunc) http.Handler
 
 	PermissionChecker func(string) bool
 
@@ -102,6 +125,8 @@ type execReader struct {
 type exportServer struct {
 	ExportServerConfig
 	handler http.Handler
+	// checksums are the checksums of the images of all volumes, each is computed when it's first requested
+	checksums []*imageChecksum
 }
 
 func (er *execReader) Read(p []byte) (int, error) {
@@ -122,6 +147,7 @@ func (er *execReader) Close() error {
 
 func (s *exportServer) initHandler() {
 	mux := http.NewServeMux()
+	s.checksums = nil
 	for _, vi := range s.Paths.Volumes {
 		if hasPermissions := s.PermissionChecker(vi.Path); !hasPermissions {
 			golog.Fatalf("unable to manipulate %s's contents, exiting", vi.Path)
@@ -139,6 +165,9 @@ func (s *exportServer) initHandler() {
 		mux.Handle(filepath.Join(internal, s.Paths.SecretURI), tokenChecker(s.TokenGetter, s.TokenSecretHandler(s.TokenGetter)))
 		mux.Handle(filepath.Join(external, s.Paths.SecretURI), tokenChecker(s.TokenGetter, s.TokenSecretHandler(s.TokenGetter)))
 	}
+	if s.Paths.ChecksumsURI != "" {
+		mux.Handle(s.Paths.ChecksumsURI, tokenChecker(s.TokenGetter, s.ChecksumHandler(s.checksums, checksumsManifestName)))
+	}
 	// Readiness probe
 	mux.HandleFunc(export.ReadinessPath, s.readyHandler)
 
@@ -170,11 +199,12 @@ func (s *exportServer) getHandlerMap(vi export.VolumeInfo) map[string]http.Handl
 		result[vi.DirURI] = s.DirHandler(vi.DirURI, vi.Path)
 	}
 
-	p := vi.Path
-	if fi.IsDir() {
-		p = path.Join(p, "disk.img")
+	if vi.BackupURI != "" {
+		result[vi.BackupURI] = s.BackupHandler(vi.BackupURI, vi.Path)
 	}
 
+	p := imagePath(vi.Path, fi)
+
 	if vi.RawURI != "" {
 		result[vi.RawURI] = s.FileHandler(p)
 	}
@@ -183,9 +213,100 @@ func (s *exportServer) getHandlerMap(vi export.VolumeInfo) map[string]http.Handl
 		result[vi.RawGzURI] = s.GzipHandler(p)
 	}
 
+	if vi.Qcow2URI != "" {
+		result[vi.Qcow2URI] = s.Qcow2Handler(p)
+	}
+
+	if vi.RawZstURI != "" {
+		result[vi.RawZstURI] = s.ZstdHandler(p)
+	}
+
+	// an image which is writable over NBD may change, so its checksums are not published
+	cacheChecksums := vi.NBDName == "" || s.NBDReadOnly
+	type checksummedImage struct {
+		uri   string
+		write func(string, io.Writer) error
+	}
+	images := []checksummedImage{
+		{vi.RawURI, writeRaw},
+		{vi.RawGzURI, writeGzip},
+		{vi.Qcow2URI, writeQcow2},
+		{vi.RawZstURI, writeZstd},
+	}
+
+	if vi.ContainerDiskURI != "" {
+		ref, err := oci.ParseReference(vi.ContainerDiskImage)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error parsing the containerDisk image of %s", vi.Path)
+		} else {
+			cd := &containerDisk{ref: ref, cache: cacheChecksums}
+			result[vi.ContainerDiskURI] = s.ContainerDiskHandler(p, cd)
+			images = append(images, checksummedImage{vi.ContainerDiskURI, cd.writeLayout})
+		}
+	}
+
+	for _, image := range images {
+		if image.uri != "" && cacheChecksums {
+			write := image.write
+			checksum := newImageChecksum(image.uri, func(w io.Writer) error {
+				return write(p, w)
+			})
+			s.checksums = append(s.checksums, checksum)
+			result[image.uri+export.ChecksumSuffix] = s.ChecksumHandler([]*imageChecksum{checksum}, path.Base)
+		}
+	}
+
 	return result
 }
 
+// imagePath returns the path of the disk image of a volume
+func imagePath(p string, fi os.FileInfo) string {
+	if fi.IsDir() {
+		return path.Join(p, "disk.img")
+	}
+	return p
+}
+
+// nbdExports maps the NBD export names of the volumes to their disk images
+func (s *exportServer) nbdExports() map[string]string {
+	exports := make(map[string]string)
+	for _, vi := range s.Paths.Volumes {
+		if vi.NBDName == "" {
+			continue
+		}
+		fi, err := os.Stat(vi.Path)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error statting %s", vi.Path)
+			continue
+		}
+		exports[vi.NBDName] = imagePath(vi.Path, fi)
+	}
+	return exports
+}
+
+func (s *exportServer) serveNBD() error {
+	cert, err := tls.LoadX509KeyPair(s.CertFile, s.KeyFile)
+	if err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", s.NBDListenAddr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	log.Log.Infof("Serving NBD on %s, read only: %t", s.NBDListenAddr, s.NBDReadOnly)
+	server := &nbd.Server{
+		Exports:  s.nbdExports(),
+		ReadOnly: s.NBDReadOnly,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		},
+		TokenGetter: s.TokenGetter,
+	}
+	return server.Serve(l)
+}
+
 func (s *exportServer) Run() {
 	s.initHandler()
 
@@ -204,6 +325,12 @@ func (s *exportServer) Run() {
 		ch <- err
 	}()
 
+	if s.NBDListenAddr != "" {
+		go func() {
+			ch <- s.serveNBD()
+		}()
+	}
+
 	if !s.Deadline.IsZero() {
 		log.Log.Infof("Deadline set to %s", s.Deadline)
 		select {
@@ -242,6 +369,26 @@ func NewExportServer(config ExportServerConfig) service.Service {
 		es.GzipHandler = gzipHandler
 	}
 
+	if es.BackupHandler == nil {
+		es.BackupHandler = backupHandler
+	}
+
+	if es.Qcow2Handler == nil {
+		es.Qcow2Handler = qcow2Handler
+	}
+
+	if es.ZstdHandler == nil {
+		es.ZstdHandler = zstdHandler
+	}
+
+	if es.ContainerDiskHandler == nil {
+		es.ContainerDiskHandler = containerDiskHandler
+	}
+
+	if es.ChecksumHandler == nil {
+		es.ChecksumHandler = checksumHandler
+	}
+
 	if es.VmHandler == nil {
 		es.VmHandler = vmHandler
 	}
@@ -565,6 +712,261 @@ func gzipHandler(filePath string) http.Handler {
 	})
 }
 
+// qcow2Handler serves the raw image converted to QCOW2
+func qcow2Handler(filePath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f, err := os.Open(filePath)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error opening %s", filePath)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+		qcow2, err := sparse.NewQcow2(f)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error converting %s to qcow2", filePath)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.FormatInt(qcow2.Size(), 10))
+		n, err := qcow2.WriteTo(w)
+		if err != nil {
+			log.Log.Reason(err).Error("error writing response body")
+		}
+		log.Log.Infof("Wrote %d bytes\n", n)
+	})
+}
+
+// zstdHandler serves the raw image compressed with zstd
+func zstdHandler(filePath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f, err := os.Open(filePath)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error opening %s", filePath)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+		n, err := sparse.WriteZstd(w, f)
+		if err != nil {
+			log.Log.Reason(err).Error("error writing response body")
+		}
+		log.Log.Infof("Wrote %d bytes\n", n)
+	})
+}
+
+// containerDiskHandler serves a tarball of the OCI image layout of the containerDisk image of the raw image
+func containerDiskHandler(filePath string, cd *containerDisk) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f, err := os.Open(filePath)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error opening %s", filePath)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+		layout, err := cd.layout(f)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error packaging %s into a containerDisk image", filePath)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-tar")
+		w.Header().Set("Content-Length", strconv.FormatInt(layout.Size(), 10))
+		n, err := layout.WriteTo(w)
+		if err != nil {
+			log.Log.Reason(err).Error("error writing response body")
+		}
+		log.Log.Infof("Wrote %d bytes\n", n)
+	})
+}
+
+// containerDisk packages a raw image into the containerDisk image ref. Computing the
+// digest of the layer reads the whole image, so it is only done once if it can be cached.
+type containerDisk struct {
+	ref   *oci.Reference
+	cache bool
+
+	lock        sync.Mutex
+	layerDigest string
+}
+
+func (cd *containerDisk) layout(f *os.File) (*oci.Layout, error) {
+	layer, err := oci.NewLayer(f)
+	if err != nil {
+		return nil, err
+	}
+	cd.lock.Lock()
+	defer cd.lock.Unlock()
+	layerDigest := cd.layerDigest
+	if layerDigest == "" {
+		layerDigest, err = layer.Digest()
+		if err != nil {
+			return nil, err
+		}
+		if cd.cache {
+			cd.layerDigest = layerDigest
+		}
+	}
+	return oci.NewLayout(layer, layerDigest, cd.ref)
+}
+
+func (cd *containerDisk) writeLayout(filePath string, w io.Writer) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	layout, err := cd.layout(f)
+	if err != nil {
+		return err
+	}
+	_, err = layout.WriteTo(w)
+	return err
+}
+
+// imageChecksum is the SHA-256 checksum of an image. Computing it generates the whole
+// image, so it is computed only once, when it is first requested.
+type imageChecksum struct {
+	uri        string
+	writeImage func(io.Writer) error
+
+	start       sync.Once
+	computeOnce sync.Once
+	done        chan struct{}
+	sum         string
+	err         error
+}
+
+func newImageChecksum(uri string, writeImage func(io.Writer) error) *imageChecksum {
+	return &imageChecksum{
+		uri:        uri,
+		writeImage: writeImage,
+		done:       make(chan struct{}),
+	}
+}
+
+func (c *imageChecksum) compute() {
+	c.computeOnce.Do(func() {
+		defer close(c.done)
+		hash := sha256.New()
+		if err := c.writeImage(hash); err != nil {
+			log.Log.Reason(err).Errorf("error computing the checksum of %s", c.uri)
+			c.err = err
+			return
+		}
+		c.sum = hex.EncodeToString(hash.Sum(nil))
+		log.Log.Infof("Computed the checksum of %s", c.uri)
+	})
+}
+
+// result returns the checksum once it is computed, the first call starts computing it
+func (c *imageChecksum) result() (sum string, computed bool, err error) {
+	c.start.Do(func() {
+		go c.compute()
+	})
+	select {
+	case <-c.done:
+		return c.sum, true, c.err
+	default:
+		return "", false, nil
+	}
+}
+
+// checksumsManifestName lists an image in the checksums manifest by its path relative to the export
+func checksumsManifestName(uri string) string {
+	return strings.TrimPrefix(uri, "/")
+}
+
+// checksumHandler serves the SHA-256 checksums of images in the format of sha256sum, each image
+// is listed by the name returned by name. The checksums are computed in the background on the
+// first request, it responds with Service Unavailable until all of them are computed.
+func checksumHandler(checksums []*imageChecksum, name func(string) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var out bytes.Buffer
+		for _, checksum := range checksums {
+			sum, computed, err := checksum.result()
+			if !computed {
+				w.Header().Set("Retry-After", strconv.Itoa(checksumRetryAfter))
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprintf(&out, "%s  %s\n", sum, name(checksum.uri))
+		}
+		if _, err := out.WriteTo(w); err != nil {
+			log.Log.Reason(err).Error("error writing checksums")
+		}
+	})
+}
+
+func writeRaw(filePath string, w io.Writer) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// writeGzip writes the same stream as the gzip handler
+func writeGzip(filePath string, w io.Writer) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zw := gzip.NewWriter(w)
+	if _, err := io.Copy(zw, f); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func writeQcow2(filePath string, w io.Writer) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	qcow2, err := sparse.NewQcow2(f)
+	if err != nil {
+		return err
+	}
+	_, err = qcow2.WriteTo(w)
+	return err
+}
+
+func writeZstd(filePath string, w io.Writer) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = sparse.WriteZstd(w, f)
+	return err
+}
+
 func vmHandler(vi []export.VolumeInfo, getBasePath func() (string, error), getCmFunc func() (*corev1.ConfigMap, error)) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		if req.Method != http.MethodGet {
@@ -692,6 +1094,7 @@ func dirHandler(uri, mountPoint string) http.Handler {
 	return http.StripPrefix(uri, http.FileServer(http.Dir(mountPoint)))
 }
 
+// fileHandler serves the raw image, clients can request ranges of it
 func fileHandler(file string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		f, err := os.Open(file)
@@ -701,10 +1104,83 @@ func fileHandler(file string) http.Handler {
 			return
 		}
 		defer f.Close()
+		// The ETag lets clients check with If-Range that the ranges of a resumed or
+		// parallel download belong to the same image. Block devices do not have one,
+		// so clients do not resume their downloads.
+		if fi, err := f.Stat(); err == nil && fi.Mode().IsRegular() {
+			w.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, fi.ModTime().UnixNano(), fi.Size()))
+		}
 		http.ServeContent(w, r, "disk.img", time.Time{}, f)
 	})
 }
 
+// backupHandler serves the backup stored in the mount point. Next to the
+// manifest and the sparse images of the volumes, <image>.extents lists the
+// extents of an image written by the backup, so clients can skip the holes.
+func backupHandler(uri, mountPoint string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		dir, err := backup.FindDirectory(mountPoint)
+		if errors.Is(err, os.ErrNotExist) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Log.Reason(err).Errorf("error finding backup in %s", mountPoint)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		manifest, err := backup.ReadManifest(dir)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error reading backup manifest in %s", dir)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, uri)
+		if name == backup.ManifestFileName {
+			serveFile(w, r, filepath.Join(dir, name))
+			return
+		}
+		image := strings.TrimSuffix(name, backupExtentsExtension)
+		volume := backupVolume(manifest, image)
+		if volume == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if image == name {
+			serveFile(w, r, filepath.Join(dir, image))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(volume.Extents); err != nil {
+			log.Log.Reason(err).Error("error writing data extents")
+		}
+	})
+}
+
+func backupVolume(manifest *backup.Manifest, image string) *backup.VolumeManifest {
+	for i, volume := range manifest.Volumes {
+		if volume.Image == image {
+			return &manifest.Volumes[i]
+		}
+	}
+	return nil
+}
+
+func serveFile(w http.ResponseWriter, r *http.Request, file string) {
+	f, err := os.Open(file)
+	if err != nil {
+		log.Log.Reason(err).Errorf("error opening %s", file)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+	http.ServeContent(w, r, filepath.Base(file), time.Time{}, f)
+}
+
 func getToken(tokenFile string) (string, error) {
 	content, err := os.ReadFile(tokenFile)
 	if err != nil {
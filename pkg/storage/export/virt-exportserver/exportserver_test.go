@@ -20,12 +20,20 @@
 package virtexportserver
 
 import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -38,7 +46,9 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/yaml"
 
+	"kubevirt.io/kubevirt/pkg/storage/backup"
 	"kubevirt.io/kubevirt/pkg/storage/export/export"
+	"kubevirt.io/kubevirt/pkg/storage/export/oci"
 )
 
 const (
@@ -49,6 +59,12 @@ func successHandler(w http.ResponseWriter, req *http.Request) {
 	w.Write([]byte("OK"))
 }
 
+func newTestContainerDisk() *containerDisk {
+	ref, err := oci.ParseReference("registry.example.com/vm:v1")
+	Expect(err).ToNot(HaveOccurred())
+	return &containerDisk{ref: ref, cache: true}
+}
+
 func newTestServer(token string) *exportServer {
 	config := ExportServerConfig{
 		ArchiveHandler: func(string) http.Handler {
@@ -63,6 +79,21 @@ func newTestServer(token string) *exportServer {
 		GzipHandler: func(string) http.Handler {
 			return http.HandlerFunc(successHandler)
 		},
+		BackupHandler: func(string, string) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
+		Qcow2Handler: func(string) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
+		ZstdHandler: func(string) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
+		ContainerDiskHandler: func(string, *containerDisk) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
+		ChecksumHandler: func([]*imageChecksum, func(string) string) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
 		VmHandler: func([]export.VolumeInfo, func() (string, error), func() (*v1.ConfigMap, error)) http.Handler {
 			return http.HandlerFunc(successHandler)
 		},
@@ -125,6 +156,31 @@ var _ = Describe("exportserver", func() {
 			&export.VolumeInfo{Path: "/tmp", RawGzURI: "/volume/v1/disk.img.gz"},
 			"/volume/v1/disk.img.gz",
 		),
+		Entry("qcow2 URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2",
+		),
+		Entry("raw zst URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", RawZstURI: "/volume/v1/disk.img.zst"},
+			"/volume/v1/disk.img.zst",
+		),
+		Entry("containerdisk URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", ContainerDiskURI: "/volume/v1/containerdisk.tar", ContainerDiskImage: "registry.example.com/vm:v1"},
+			"/volume/v1/containerdisk.tar",
+		),
+		Entry("checksum URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2.sha256",
+		),
+		Entry("backup URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", BackupURI: "/volume/v1/backup/"},
+			"/volume/v1/backup/manifest.json",
+		),
 		Entry("VM definition URI",
 			"/manifest",
 			nil,
@@ -180,6 +236,31 @@ var _ = Describe("exportserver", func() {
 			&export.VolumeInfo{Path: "/tmp", RawGzURI: "/volume/v1/disk.img.gz"},
 			"/volume/v1/disk.img.gz",
 		),
+		Entry("qcow2 URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2",
+		),
+		Entry("raw zst URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", RawZstURI: "/volume/v1/disk.img.zst"},
+			"/volume/v1/disk.img.zst",
+		),
+		Entry("containerdisk URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", ContainerDiskURI: "/volume/v1/containerdisk.tar", ContainerDiskImage: "registry.example.com/vm:v1"},
+			"/volume/v1/containerdisk.tar",
+		),
+		Entry("checksum URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2.sha256",
+		),
+		Entry("backup URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", BackupURI: "/volume/v1/backup/"},
+			"/volume/v1/backup/manifest.json",
+		),
 		Entry("VM definition URI",
 			"/manifest",
 			nil,
@@ -232,6 +313,31 @@ var _ = Describe("exportserver", func() {
 			&export.VolumeInfo{Path: "/tmp", RawGzURI: "/volume/v1/disk.img.gz"},
 			"/volume/v1/disk.img.gz",
 		),
+		Entry("qcow2 URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2",
+		),
+		Entry("raw zst URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", RawZstURI: "/volume/v1/disk.img.zst"},
+			"/volume/v1/disk.img.zst",
+		),
+		Entry("containerdisk URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", ContainerDiskURI: "/volume/v1/containerdisk.tar", ContainerDiskImage: "registry.example.com/vm:v1"},
+			"/volume/v1/containerdisk.tar",
+		),
+		Entry("checksum URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2.sha256",
+		),
+		Entry("backup URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", BackupURI: "/volume/v1/backup/"},
+			"/volume/v1/backup/manifest.json",
+		),
 		Entry("VM definition URI",
 			"/manifest",
 			nil,
@@ -283,6 +389,31 @@ var _ = Describe("exportserver", func() {
 			&export.VolumeInfo{Path: "/tmp", RawGzURI: "/volume/v1/disk.img.gz"},
 			"/volume/v1/disk.img.gz",
 		),
+		Entry("qcow2 URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2",
+		),
+		Entry("raw zst URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", RawZstURI: "/volume/v1/disk.img.zst"},
+			"/volume/v1/disk.img.zst",
+		),
+		Entry("containerdisk URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", ContainerDiskURI: "/volume/v1/containerdisk.tar", ContainerDiskImage: "registry.example.com/vm:v1"},
+			"/volume/v1/containerdisk.tar",
+		),
+		Entry("checksum URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2.sha256",
+		),
+		Entry("backup URI",
+			"",
+			&export.VolumeInfo{Path: "/tmp", BackupURI: "/volume/v1/backup/"},
+			"/volume/v1/backup/manifest.json",
+		),
 		Entry("VM definition URI",
 			"/manifest",
 			nil,
@@ -770,4 +901,342 @@ var _ = Describe("exportserver", func() {
 			verifySecret(string(list.Items[0].Raw))
 		})
 	})
+
+	Context("File handler", func() {
+		var image string
+
+		BeforeEach(func() {
+			image = filepath.Join(GinkgoT().TempDir(), "disk.img")
+			Expect(os.WriteFile(image, []byte("0123456789"), 0644)).To(Succeed())
+		})
+
+		get := func(headers map[string]string) *httptest.ResponseRecorder {
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/volume/v1/disk.img", nil)
+			Expect(err).ToNot(HaveOccurred())
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+			resp := httptest.NewRecorder()
+			fileHandler(image).ServeHTTP(resp, req)
+			return resp
+		}
+
+		It("should serve a range of the image", func() {
+			resp := get(map[string]string{"Range": "bytes=2-5"})
+			Expect(resp.Code).To(Equal(http.StatusPartialContent))
+			Expect(resp.Header().Get("Content-Range")).To(Equal("bytes 2-5/10"))
+			Expect(resp.Body.String()).To(Equal("2345"))
+		})
+
+		It("should serve a range if the image did not change", func() {
+			etag := get(nil).Header().Get("ETag")
+			Expect(etag).ToNot(BeEmpty())
+			resp := get(map[string]string{"Range": "bytes=8-", "If-Range": etag})
+			Expect(resp.Code).To(Equal(http.StatusPartialContent))
+			Expect(resp.Body.String()).To(Equal("89"))
+		})
+
+		It("should serve the whole image if it changed", func() {
+			etag := get(nil).Header().Get("ETag")
+			Expect(os.WriteFile(image, []byte("changed image"), 0644)).To(Succeed())
+			resp := get(map[string]string{"Range": "bytes=8-", "If-Range": etag})
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal("changed image"))
+		})
+	})
+
+	Context("Checksum handler", func() {
+		var image string
+
+		BeforeEach(func() {
+			image = filepath.Join(GinkgoT().TempDir(), "disk.img")
+			f, err := os.Create(image)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = f.WriteAt([]byte("data"), 1024*1024)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+		})
+
+		get := func(handler http.Handler) *httptest.ResponseRecorder {
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/volume/v1/disk.img.sha256", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			return resp
+		}
+
+		computed := func(uri string, write func(io.Writer) error) *imageChecksum {
+			checksum := newImageChecksum(uri, write)
+			checksum.compute()
+			return checksum
+		}
+
+		DescribeTable("should serve the checksum of the image", func(handler func(string) http.Handler, write func(string, io.Writer) error) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/volume/v1/disk", nil)
+			Expect(err).ToNot(HaveOccurred())
+			handler(image).ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			expected := sha256.Sum256(resp.Body.Bytes())
+
+			checksum := computed("/volume/v1/disk", func(w io.Writer) error {
+				return write(image, w)
+			})
+			resp = get(checksumHandler([]*imageChecksum{checksum}, path.Base))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal(hex.EncodeToString(expected[:]) + "  disk\n"))
+		},
+			Entry("raw", fileHandler, writeRaw),
+			Entry("gzip", gzipHandler, writeGzip),
+			Entry("qcow2", qcow2Handler, writeQcow2),
+			Entry("zstd", zstdHandler, writeZstd),
+			Entry("containerdisk", func(p string) http.Handler {
+				return containerDiskHandler(p, newTestContainerDisk())
+			}, func(p string, w io.Writer) error {
+				return newTestContainerDisk().writeLayout(p, w)
+			}),
+		)
+
+		It("should serve the checksums of all images in the manifest", func() {
+			raw := computed("/volume/v1/disk.img", func(w io.Writer) error {
+				return writeRaw(image, w)
+			})
+			gz := computed("/volume/v1/disk.img.gz", func(w io.Writer) error {
+				return writeGzip(image, w)
+			})
+			resp := get(checksumHandler([]*imageChecksum{raw, gz}, checksumsManifestName))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal(raw.sum + "  volume/v1/disk.img\n" + gz.sum + "  volume/v1/disk.img.gz\n"))
+		})
+
+		It("should compute the checksum on the first request and ask the client to retry until it is computed", func() {
+			var writes atomic.Int32
+			release := make(chan struct{})
+			checksum := newImageChecksum("/volume/v1/disk.img", func(w io.Writer) error {
+				writes.Add(1)
+				<-release
+				return writeRaw(image, w)
+			})
+			handler := checksumHandler([]*imageChecksum{checksum}, path.Base)
+			Expect(writes.Load()).To(BeZero())
+
+			resp := get(handler)
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Header().Get("Retry-After")).To(Equal(strconv.Itoa(checksumRetryAfter)))
+			Eventually(writes.Load).Should(Equal(int32(1)))
+
+			close(release)
+			Eventually(func() int {
+				return get(handler).Code
+			}).Should(Equal(http.StatusOK))
+			Expect(get(handler).Code).To(Equal(http.StatusOK))
+			Expect(writes.Load()).To(Equal(int32(1)))
+		})
+
+		It("should fail if the image cannot be generated", func() {
+			checksum := computed("/volume/v1/disk.img", func(w io.Writer) error {
+				return writeRaw(filepath.Join(GinkgoT().TempDir(), "missing"), w)
+			})
+			resp := get(checksumHandler([]*imageChecksum{checksum}, path.Base))
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		DescribeTable("should only compute the checksums of images which cannot change", func(nbdName string, readOnly bool, expectedChecksums int) {
+			es := newTestServer("foo")
+			es.NBDReadOnly = readOnly
+			es.Paths = &export.ServerPaths{
+				ChecksumsURI: "/manifests/sha256sums",
+				Volumes: []export.VolumeInfo{
+					{Path: image, RawURI: "/volume/v1/disk.img", RawGzURI: "/volume/v1/disk.img.gz", NBDName: nbdName},
+				},
+			}
+			es.initHandler()
+			Expect(es.checksums).To(HaveLen(expectedChecksums))
+
+			httpServer := httptest.NewServer(es.handler)
+			defer httpServer.Close()
+			req, err := http.NewRequest("GET", httpServer.URL+"/manifests/sha256sums", nil)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("x-kubevirt-export-token", "foo")
+			res, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+		},
+			Entry("without NBD", "", false, 2),
+			Entry("with a read only NBD export", "v1", true, 2),
+			Entry("with a writable NBD export", "v1", false, 0),
+		)
+	})
+
+	Context("ContainerDisk handler", func() {
+		var image string
+
+		BeforeEach(func() {
+			image = filepath.Join(GinkgoT().TempDir(), "disk.img")
+			f, err := os.Create(image)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = f.WriteAt([]byte("data"), 1024*1024)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+		})
+
+		get := func(handler http.Handler) *httptest.ResponseRecorder {
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/volume/v1/containerdisk.tar", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			return resp
+		}
+
+		It("should serve the OCI image layout of the image", func() {
+			resp := get(containerDiskHandler(image, newTestContainerDisk()))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Header().Get("Content-Length")).To(Equal(strconv.Itoa(resp.Body.Len())))
+
+			names := []string{}
+			tr := tar.NewReader(resp.Body)
+			for {
+				header, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).ToNot(HaveOccurred())
+				names = append(names, header.Name)
+			}
+			Expect(names).To(ContainElements(oci.LayoutFile, oci.IndexFile))
+		})
+
+		DescribeTable("should compute the digest of the layer", func(cache bool, expectedDigest bool) {
+			cd := newTestContainerDisk()
+			cd.cache = cache
+			first := get(containerDiskHandler(image, cd))
+			Expect(first.Code).To(Equal(http.StatusOK))
+			Expect(cd.layerDigest != "").To(Equal(expectedDigest))
+			second := get(containerDiskHandler(image, cd))
+			Expect(second.Body.Bytes()).To(Equal(first.Body.Bytes()))
+		},
+			Entry("once if it can be cached", true, true),
+			Entry("on every request if it cannot be cached", false, false),
+		)
+
+		It("should fail if the image cannot be opened", func() {
+			resp := get(containerDiskHandler(filepath.Join(GinkgoT().TempDir(), "missing"), newTestContainerDisk()))
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Context("Backup handler", func() {
+		const backupURI = "/volume/v1/backup/"
+
+		var (
+			mountPoint string
+			backupDir  string
+		)
+
+		get := func(name string, header map[string]string) *httptest.ResponseRecorder {
+			req, err := http.NewRequest("GET", "https://test.blah.invalid"+backupURI+name, nil)
+			Expect(err).ToNot(HaveOccurred())
+			for k, v := range header {
+				req.Header.Set(k, v)
+			}
+			resp := httptest.NewRecorder()
+			backupHandler(backupURI, mountPoint).ServeHTTP(resp, req)
+			return resp
+		}
+
+		BeforeEach(func() {
+			mountPoint = GinkgoT().TempDir()
+			backupDir = filepath.Join(mountPoint, "testvmi-disk0-20240101-000000.backup")
+			Expect(os.Mkdir(backupDir, 0750)).To(Succeed())
+
+			f, err := os.Create(filepath.Join(backupDir, backup.ImageFileName("disk0")))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = f.WriteAt([]byte("data"), 4*1024*1024)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+
+			Expect(backup.WriteManifest(backupDir, &backup.Manifest{
+				Checkpoint: "testvm-checkpoint",
+				Volumes: []backup.VolumeManifest{
+					{
+						Name:    "disk0",
+						Image:   backup.ImageFileName("disk0"),
+						Size:    4*1024*1024 + 4,
+						Extents: []backup.Extent{{Offset: 4 * 1024 * 1024, Length: 4}},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		It("should return the manifest", func() {
+			resp := get(backup.ManifestFileName, nil)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			manifest := &backup.Manifest{}
+			Expect(json.Unmarshal(resp.Body.Bytes(), manifest)).To(Succeed())
+			Expect(manifest.Checkpoint).To(Equal("testvm-checkpoint"))
+			Expect(manifest.Volumes).To(HaveLen(1))
+		})
+
+		It("should return a range of an image", func() {
+			resp := get("disk0.img", map[string]string{"Range": "bytes=4194304-4194307"})
+			Expect(resp.Code).To(Equal(http.StatusPartialContent))
+			Expect(resp.Body.String()).To(Equal("data"))
+		})
+
+		It("should return the extents of an image listed in the manifest", func() {
+			resp := get("disk0.img.extents", nil)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			var extents []backup.Extent
+			Expect(json.Unmarshal(resp.Body.Bytes(), &extents)).To(Succeed())
+			Expect(extents).To(Equal([]backup.Extent{{Offset: 4 * 1024 * 1024, Length: 4}}))
+		})
+
+		DescribeTable("should return 404", func(name string) {
+			Expect(get(name, nil).Code).To(Equal(http.StatusNotFound))
+		},
+			Entry("for an image which is not in the manifest", "disk1.img"),
+			Entry("for a file which is not an image", "../other"),
+		)
+
+		It("should return 404 if there is no completed backup", func() {
+			Expect(os.Remove(filepath.Join(backupDir, backup.ManifestFileName))).To(Succeed())
+			Expect(get(backup.ManifestFileName, nil).Code).To(Equal(http.StatusNotFound))
+		})
+
+		DescribeTable("should return error on", func(verb string) {
+			req, err := http.NewRequest(verb, "https://test.blah.invalid"+backupURI+backup.ManifestFileName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			backupHandler(backupURI, mountPoint).ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		},
+			Entry("POST", "POST"),
+			Entry("PUT", "PUT"),
+			Entry("DELETE", "DELETE"),
+		)
+	})
+
+	It("should export the disk images of the NBD volumes", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "disk.img"), nil, 0644)).To(Succeed())
+		file := filepath.Join(GinkgoT().TempDir(), "device")
+		Expect(os.WriteFile(file, nil, 0644)).To(Succeed())
+
+		server := &exportServer{
+			ExportServerConfig: ExportServerConfig{
+				Paths: &export.ServerPaths{
+					Volumes: []export.VolumeInfo{
+						{Path: dir, NBDName: "fs"},
+						{Path: file, NBDName: "block"},
+						{Path: dir, RawURI: "/volumes/raw/disk.img"},
+					},
+				},
+			},
+		}
+		Expect(server.nbdExports()).To(Equal(map[string]string{
+			"fs":    filepath.Join(dir, "disk.img"),
+			"block": file,
+		}))
+	})
 })
@@ -0,0 +1,377 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package nbd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+// Client is connected to a single export. Requests are sent one at a time.
+type Client struct {
+	conn     net.Conn
+	size     int64
+	contexts map[string]uint32
+	handle   uint64
+}
+
+// Dial connects to the export of the server listening on a unix socket and
+// negotiates the meta contexts
+func Dial(socket, export string, metaContexts ...string) (*Client, error) {
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn, export, metaContexts...)
+}
+
+// NewClient negotiates the export and the meta contexts on an established
+// connection
+func NewClient(conn net.Conn, export string, metaContexts ...string) (*Client, error) {
+	c := &Client{
+		conn:     conn,
+		contexts: map[string]uint32{},
+	}
+	if err := c.handshake(export, metaContexts); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to negotiate nbd export %s: %v", export, err)
+	}
+	return c, nil
+}
+
+// Size returns the size of the export in bytes
+func (c *Client) Size() int64 {
+	return c.size
+}
+
+// Close disconnects from the server
+func (c *Client) Close() error {
+	if _, err := c.request(cmdDisc, 0, 0); err != nil {
+		c.conn.Close()
+		return err
+	}
+	return c.conn.Close()
+}
+
+func (c *Client) handshake(export string, metaContexts []string) error {
+	var hello struct {
+		Magic    uint64
+		OptMagic uint64
+		Flags    uint16
+	}
+	if err := binary.Read(c.conn, binary.BigEndian, &hello); err != nil {
+		return err
+	}
+	if hello.Magic != nbdMagic || hello.OptMagic != optionMagic {
+		return fmt.Errorf("unexpected greeting")
+	}
+	if hello.Flags&flagFixedNewstyle == 0 {
+		return fmt.Errorf("the server does not support the fixed newstyle handshake")
+	}
+	clientFlags := clientFlagFixedNewstyle
+	if hello.Flags&flagNoZeroes != 0 {
+		clientFlags |= clientFlagNoZeroes
+	}
+	if err := binary.Write(c.conn, binary.BigEndian, clientFlags); err != nil {
+		return err
+	}
+
+	// the block status is only sent in structured replies
+	if err := c.sendOption(optStructuredReply, nil); err != nil {
+		return err
+	}
+	if err := c.readOptionReplies(optStructuredReply, nil); err != nil {
+		return err
+	}
+
+	if len(metaContexts) > 0 {
+		data := appendString32(nil, export)
+		data = binary.BigEndian.AppendUint32(data, uint32(len(metaContexts)))
+		for _, metaContext := range metaContexts {
+			data = appendString32(data, metaContext)
+		}
+		if err := c.sendOption(optSetMetaContext, data); err != nil {
+			return err
+		}
+		err := c.readOptionReplies(optSetMetaContext, func(replyType uint32, data []byte) error {
+			if replyType != repMetaContext || len(data) < 4 {
+				return fmt.Errorf("unexpected reply %d to set meta context", replyType)
+			}
+			c.contexts[string(data[4:])] = binary.BigEndian.Uint32(data)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, metaContext := range metaContexts {
+			if _, ok := c.contexts[metaContext]; !ok {
+				return fmt.Errorf("the server does not provide meta context %s", metaContext)
+			}
+		}
+	}
+
+	// the server always sends the size of the export, no need to request infos
+	data := appendString32(nil, export)
+	data = binary.BigEndian.AppendUint16(data, 0)
+	if err := c.sendOption(optGo, data); err != nil {
+		return err
+	}
+	gotSize := false
+	err := c.readOptionReplies(optGo, func(replyType uint32, data []byte) error {
+		if replyType != repInfo || len(data) < 2 {
+			return fmt.Errorf("unexpected reply %d to go", replyType)
+		}
+		if binary.BigEndian.Uint16(data) == infoExport && len(data) >= 10 {
+			c.size = int64(binary.BigEndian.Uint64(data[2:]))
+			gotSize = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !gotSize {
+		return fmt.Errorf("the server did not send the size of the export")
+	}
+	return nil
+}
+
+func (c *Client) sendOption(option uint32, data []byte) error {
+	message := binary.BigEndian.AppendUint64(nil, optionMagic)
+	message = binary.BigEndian.AppendUint32(message, option)
+	message = appendString32(message, string(data))
+	_, err := c.conn.Write(message)
+	return err
+}
+
+// readOptionReplies reads the replies to an option up to the final ack,
+// passing the others to reply
+func (c *Client) readOptionReplies(option uint32, reply func(replyType uint32, data []byte) error) error {
+	for {
+		var header struct {
+			Magic  uint64
+			Option uint32
+			Type   uint32
+			Length uint32
+		}
+		if err := binary.Read(c.conn, binary.BigEndian, &header); err != nil {
+			return err
+		}
+		if header.Magic != optReplyMagic || header.Option != option {
+			return fmt.Errorf("unexpected reply to option %d", option)
+		}
+		data := make([]byte, header.Length)
+		if _, err := io.ReadFull(c.conn, data); err != nil {
+			return err
+		}
+		switch {
+		case header.Type&repFlagError != 0:
+			return fmt.Errorf("option %d failed with error %#x: %s", option, header.Type, data)
+		case header.Type == repAck:
+			return nil
+		case reply == nil:
+			return fmt.Errorf("unexpected reply %d to option %d", header.Type, option)
+		}
+		if err := reply(header.Type, data); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) request(command uint16, offset int64, length uint32) (uint64, error) {
+	c.handle++
+	header := struct {
+		Magic  uint32
+		Flags  uint16
+		Type   uint16
+		Handle uint64
+		Offset uint64
+		Length uint32
+	}{requestMagic, 0, command, c.handle, uint64(offset), length}
+	return c.handle, binary.Write(c.conn, binary.BigEndian, &header)
+}
+
+// readReply reads the structured reply to a request, passing its chunks to
+// chunk
+func (c *Client) readReply(handle uint64, chunk func(replyType uint16, payload []byte) error) error {
+	for {
+		var magic uint32
+		if err := binary.Read(c.conn, binary.BigEndian, &magic); err != nil {
+			return err
+		}
+		switch magic {
+		case simpleMagic:
+			var reply struct {
+				Error  uint32
+				Handle uint64
+			}
+			if err := binary.Read(c.conn, binary.BigEndian, &reply); err != nil {
+				return err
+			}
+			if reply.Error != 0 {
+				return fmt.Errorf("request failed with error %d", reply.Error)
+			}
+			// structured replies were negotiated, the data of a successful
+			// reply can not be parsed
+			return fmt.Errorf("unexpected simple reply")
+		case structuredMagic:
+			var reply struct {
+				Flags  uint16
+				Type   uint16
+				Handle uint64
+				Length uint32
+			}
+			if err := binary.Read(c.conn, binary.BigEndian, &reply); err != nil {
+				return err
+			}
+			payload := make([]byte, reply.Length)
+			if _, err := io.ReadFull(c.conn, payload); err != nil {
+				return err
+			}
+			if reply.Handle != handle {
+				return fmt.Errorf("unexpected reply to request %d", reply.Handle)
+			}
+			if reply.Type&replyTypeErrorBit != 0 {
+				return replyError(payload)
+			}
+			if reply.Type != replyTypeNone {
+				if err := chunk(reply.Type, payload); err != nil {
+					return err
+				}
+			}
+			if reply.Flags&replyFlagDone != 0 {
+				return nil
+			}
+		default:
+			return fmt.Errorf("unexpected reply magic %#x", magic)
+		}
+	}
+}
+
+func replyError(payload []byte) error {
+	if len(payload) < 6 {
+		return fmt.Errorf("request failed")
+	}
+	code := binary.BigEndian.Uint32(payload)
+	message := payload[6:]
+	if length := int(binary.BigEndian.Uint16(payload[4:])); length < len(message) {
+		message = message[:length]
+	}
+	return fmt.Errorf("request failed with error %d: %s", code, message)
+}
+
+// ReadAt reads len(p) bytes of the export at off
+func (c *Client) ReadAt(p []byte, off int64) (int, error) {
+	for n := 0; n < len(p); {
+		length := min(len(p)-n, maxPayload)
+		if err := c.read(p[n:n+length], off+int64(n)); err != nil {
+			return n, err
+		}
+		n += length
+	}
+	return len(p), nil
+}
+
+func (c *Client) read(p []byte, off int64) error {
+	handle, err := c.request(cmdRead, off, uint32(len(p)))
+	if err != nil {
+		return err
+	}
+	return c.readReply(handle, func(replyType uint16, payload []byte) error {
+		if len(payload) < 8 {
+			return fmt.Errorf("short read chunk")
+		}
+		start := int64(binary.BigEndian.Uint64(payload)) - off
+		switch replyType {
+		case replyTypeOffsetData:
+			data := payload[8:]
+			if start < 0 || start+int64(len(data)) > int64(len(p)) {
+				return fmt.Errorf("read chunk out of the requested range")
+			}
+			copy(p[start:], data)
+		case replyTypeOffsetHole:
+			if len(payload) < 12 {
+				return fmt.Errorf("short hole chunk")
+			}
+			length := int64(binary.BigEndian.Uint32(payload[8:]))
+			if start < 0 || start+length > int64(len(p)) {
+				return fmt.Errorf("hole chunk out of the requested range")
+			}
+			clear(p[start : start+length])
+		default:
+			return fmt.Errorf("unexpected reply %d to read", replyType)
+		}
+		return nil
+	})
+}
+
+// Extents returns the status of the whole export in a negotiated meta
+// context. Adjacent extents with the same status are merged.
+func (c *Client) Extents(metaContext string) ([]Extent, error) {
+	id, ok := c.contexts[metaContext]
+	if !ok {
+		return nil, fmt.Errorf("meta context %s was not negotiated", metaContext)
+	}
+
+	var extents []Extent
+	for offset := int64(0); offset < c.size; {
+		handle, err := c.request(cmdBlockStatus, offset, uint32(min(c.size-offset, maxBlockStatusLength)))
+		if err != nil {
+			return nil, err
+		}
+		end := offset
+		err = c.readReply(handle, func(replyType uint16, payload []byte) error {
+			if replyType != replyTypeBlockStatus || len(payload) < 4 {
+				return fmt.Errorf("unexpected reply %d to block status", replyType)
+			}
+			if binary.BigEndian.Uint32(payload) != id {
+				return nil
+			}
+			for descriptors := payload[4:]; len(descriptors) >= 8; descriptors = descriptors[8:] {
+				length := min(int64(binary.BigEndian.Uint32(descriptors)), c.size-end)
+				if length <= 0 {
+					break
+				}
+				extents = appendExtent(extents, Extent{Offset: end, Length: length, Flags: binary.BigEndian.Uint32(descriptors[4:])})
+				end += length
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if end == offset {
+			return nil, fmt.Errorf("no block status of %s at offset %d", metaContext, offset)
+		}
+		offset = end
+	}
+	return extents, nil
+}
+
+func appendExtent(extents []Extent, extent Extent) []Extent {
+	if last := len(extents) - 1; last >= 0 && extents[last].Flags == extent.Flags &&
+		extents[last].Offset+extents[last].Length == extent.Offset {
+		extents[last].Length += extent.Length
+		return extents
+	}
+	return append(extents, extent)
+}
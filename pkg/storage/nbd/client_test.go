@@ -0,0 +1,262 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package nbd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const dirtyBitmap = "qemu:dirty-bitmap:backup-vda"
+
+// fakeServer serves a single export the way qemu does. It sends at most two
+// block status descriptors per reply, so clients have to ask again.
+type fakeServer struct {
+	data     []byte
+	contexts map[string][]Extent
+	// failReads makes the server reply to reads with an error
+	failReads bool
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer GinkgoRecover()
+	defer conn.Close()
+
+	write := func(v any) { Expect(binary.Write(conn, binary.BigEndian, v)).To(Succeed()) }
+	optionReply := func(option, replyType uint32, data []byte) {
+		write(struct {
+			Magic  uint64
+			Option uint32
+			Type   uint32
+			Length uint32
+		}{optReplyMagic, option, replyType, uint32(len(data))})
+		// a pipe blocks on empty writes until the other side reads
+		if len(data) > 0 {
+			_, err := conn.Write(data)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	}
+	chunk := func(flags, replyType uint16, handle uint64, payload []byte) {
+		write(struct {
+			Magic  uint32
+			Flags  uint16
+			Type   uint16
+			Handle uint64
+			Length uint32
+		}{structuredMagic, flags, replyType, handle, uint32(len(payload))})
+		if len(payload) > 0 {
+			_, err := conn.Write(payload)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	}
+
+	write(struct {
+		Magic    uint64
+		OptMagic uint64
+		Flags    uint16
+	}{nbdMagic, optionMagic, flagFixedNewstyle | flagNoZeroes})
+	var clientFlags uint32
+	Expect(binary.Read(conn, binary.BigEndian, &clientFlags)).To(Succeed())
+
+	ids := map[uint32]string{}
+	for negotiating := true; negotiating; {
+		var option struct {
+			Magic  uint64
+			Option uint32
+			Length uint32
+		}
+		if binary.Read(conn, binary.BigEndian, &option) != nil {
+			return
+		}
+		data := make([]byte, option.Length)
+		_, err := io.ReadFull(conn, data)
+		Expect(err).ToNot(HaveOccurred())
+		switch option.Option {
+		case optSetMetaContext:
+			data = data[4+binary.BigEndian.Uint32(data):]
+			queries := binary.BigEndian.Uint32(data)
+			data = data[4:]
+			for i := uint32(0); i < queries; i++ {
+				length := binary.BigEndian.Uint32(data)
+				query := string(data[4 : 4+length])
+				data = data[4+length:]
+				if _, ok := s.contexts[query]; ok {
+					id := uint32(len(ids) + 1)
+					ids[id] = query
+					optionReply(option.Option, repMetaContext, append(binary.BigEndian.AppendUint32(nil, id), query...))
+				}
+			}
+		case optGo:
+			info := binary.BigEndian.AppendUint16(nil, infoExport)
+			info = binary.BigEndian.AppendUint64(info, uint64(len(s.data)))
+			info = binary.BigEndian.AppendUint16(info, 0)
+			optionReply(option.Option, repInfo, info)
+			negotiating = false
+		}
+		optionReply(option.Option, repAck, nil)
+	}
+
+	for {
+		var request struct {
+			Magic  uint32
+			Flags  uint16
+			Type   uint16
+			Handle uint64
+			Offset uint64
+			Length uint32
+		}
+		if binary.Read(conn, binary.BigEndian, &request) != nil {
+			return
+		}
+		switch request.Type {
+		case cmdDisc:
+			return
+		case cmdRead:
+			if s.failReads {
+				payload := binary.BigEndian.AppendUint32(nil, 5)
+				payload = appendString16(payload, "I/O error")
+				chunk(replyFlagDone, replyTypeError, request.Handle, payload)
+				continue
+			}
+			// the first half is sent as data, the second one as a hole if
+			// it is zero
+			half := uint64(request.Length / 2)
+			first := request.Offset
+			second := request.Offset + half
+			end := request.Offset + uint64(request.Length)
+			chunk(0, replyTypeOffsetData, request.Handle, append(binary.BigEndian.AppendUint64(nil, first), s.data[first:second]...))
+			if bytes.Count(s.data[second:end], []byte{0}) == int(end-second) {
+				hole := binary.BigEndian.AppendUint64(nil, second)
+				chunk(0, replyTypeOffsetHole, request.Handle, binary.BigEndian.AppendUint32(hole, uint32(end-second)))
+			} else {
+				chunk(0, replyTypeOffsetData, request.Handle, append(binary.BigEndian.AppendUint64(nil, second), s.data[second:end]...))
+			}
+			chunk(replyFlagDone, replyTypeNone, request.Handle, nil)
+		case cmdBlockStatus:
+			for id := uint32(1); id <= uint32(len(ids)); id++ {
+				payload := binary.BigEndian.AppendUint32(nil, id)
+				descriptors := 0
+				for _, extent := range s.contexts[ids[id]] {
+					end := extent.Offset + extent.Length
+					if end <= int64(request.Offset) || descriptors == 2 {
+						continue
+					}
+					length := end - max(extent.Offset, int64(request.Offset))
+					payload = binary.BigEndian.AppendUint32(payload, uint32(length))
+					payload = binary.BigEndian.AppendUint32(payload, extent.Flags)
+					descriptors++
+				}
+				var flags uint16
+				if id == uint32(len(ids)) {
+					flags = replyFlagDone
+				}
+				chunk(flags, replyTypeBlockStatus, request.Handle, payload)
+			}
+		}
+	}
+}
+
+func appendString16(data []byte, s string) []byte {
+	data = binary.BigEndian.AppendUint16(data, uint16(len(s)))
+	return append(data, s...)
+}
+
+var _ = Describe("NBD client", func() {
+	const size = 8 * 1024
+
+	var server *fakeServer
+
+	BeforeEach(func() {
+		data := make([]byte, size)
+		for i := 0; i < 3*1024; i++ {
+			data[i] = byte(i)
+		}
+		server = &fakeServer{
+			data: data,
+			contexts: map[string][]Extent{
+				BaseAllocation: {
+					{Offset: 0, Length: 1024, Flags: 0},
+					{Offset: 1024, Length: 2048, Flags: 0},
+					{Offset: 3 * 1024, Length: 5 * 1024, Flags: StateHole | StateZero},
+				},
+				dirtyBitmap: {
+					{Offset: 0, Length: 1024, Flags: 0},
+					{Offset: 1024, Length: 1024, Flags: StateDirty},
+					{Offset: 2 * 1024, Length: 4 * 1024, Flags: 0},
+					{Offset: 6 * 1024, Length: 2 * 1024, Flags: StateDirty},
+				},
+			},
+		}
+	})
+
+	connect := func(metaContexts ...string) (*Client, error) {
+		clientConn, serverConn := net.Pipe()
+		go server.serve(serverConn)
+		return NewClient(clientConn, "vda", metaContexts...)
+	}
+
+	It("should negotiate the export and its meta contexts", func() {
+		client, err := connect(BaseAllocation, dirtyBitmap)
+		Expect(err).ToNot(HaveOccurred())
+		defer client.Close()
+
+		Expect(client.Size()).To(Equal(int64(size)))
+		Expect(client.Extents(BaseAllocation)).To(Equal([]Extent{
+			{Offset: 0, Length: 3 * 1024, Flags: 0},
+			{Offset: 3 * 1024, Length: 5 * 1024, Flags: StateHole | StateZero},
+		}))
+		Expect(client.Extents(dirtyBitmap)).To(Equal(server.contexts[dirtyBitmap]))
+	})
+
+	It("should read data and holes", func() {
+		client, err := connect()
+		Expect(err).ToNot(HaveOccurred())
+		defer client.Close()
+
+		p := make([]byte, 4*1024)
+		for i := range p {
+			p[i] = 0xff
+		}
+		n, err := client.ReadAt(p, 1024)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(len(p)))
+		Expect(p).To(Equal(server.data[1024 : 5*1024]))
+	})
+
+	It("should fail to read if the server replies with an error", func() {
+		server.failReads = true
+		client, err := connect()
+		Expect(err).ToNot(HaveOccurred())
+		defer client.Close()
+
+		_, err = client.ReadAt(make([]byte, 1024), 0)
+		Expect(err).To(MatchError(ContainSubstring("I/O error")))
+	})
+
+	It("should fail if the server does not provide a meta context", func() {
+		_, err := connect(DirtyBitmap("backup-vdb"))
+		Expect(err).To(MatchError(ContainSubstring("does not provide meta context qemu:dirty-bitmap:backup-vdb")))
+	})
+})
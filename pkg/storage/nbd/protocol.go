@@ -0,0 +1,179 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+// Package nbd implements the fixed newstyle NBD protocol: a server of files
+// and block devices, and a minimal client which covers what is needed to read
+// a pull mode backup of libvirt.
+// See https://github.com/NetworkBlockDevice/nbd/blob/master/doc/proto.md
+package nbd
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	nbdMagic        uint64 = 0x4e42444d41474943 // "NBDMAGIC"
+	optionMagic     uint64 = 0x49484156454f5054 // "IHAVEOPT"
+	optReplyMagic   uint64 = 0x3e889045565a9
+	requestMagic    uint32 = 0x25609513
+	simpleMagic     uint32 = 0x67446698
+	structuredMagic uint32 = 0x668e33ef
+
+	flagFixedNewstyle uint16 = 1 << 0
+	flagNoZeroes      uint16 = 1 << 1
+
+	clientFlagFixedNewstyle uint32 = 1 << 0
+	clientFlagNoZeroes      uint32 = 1 << 1
+)
+
+// options
+const (
+	optExportName      uint32 = 1
+	optAbort           uint32 = 2
+	optList            uint32 = 3
+	optStartTLS        uint32 = 5
+	optInfo            uint32 = 6
+	optGo              uint32 = 7
+	optStructuredReply uint32 = 8
+	optListMetaContext uint32 = 9
+	optSetMetaContext  uint32 = 10
+)
+
+// option replies
+const (
+	repAck         uint32 = 1
+	repInfo        uint32 = 3
+	repMetaContext uint32 = 4
+
+	repFlagError  uint32 = 1 << 31
+	repErrUnsup   uint32 = repFlagError + 1
+	repErrPolicy  uint32 = repFlagError + 2
+	repErrInvalid uint32 = repFlagError + 3
+	repErrTLSReqd uint32 = repFlagError + 5
+	repErrUnknown uint32 = repFlagError + 6
+)
+
+// information types of NBD_OPT_INFO and NBD_OPT_GO
+const (
+	infoExport    uint16 = 0
+	infoBlockSize uint16 = 3
+)
+
+// transmission flags
+const (
+	transFlagHasFlags       uint16 = 1 << 0
+	transFlagReadOnly       uint16 = 1 << 1
+	transFlagSendFlush      uint16 = 1 << 2
+	transFlagSendFUA        uint16 = 1 << 3
+	transFlagSendTrim       uint16 = 1 << 5
+	transFlagSendWriteZeros uint16 = 1 << 6
+	transFlagSendDF         uint16 = 1 << 7
+	transFlagCanMultiConn   uint16 = 1 << 8
+)
+
+// commands
+const (
+	cmdRead        uint16 = 0
+	cmdWrite       uint16 = 1
+	cmdDisc        uint16 = 2
+	cmdFlush       uint16 = 3
+	cmdTrim        uint16 = 4
+	cmdWriteZeroes uint16 = 6
+	cmdBlockStatus uint16 = 7
+
+	cmdFlagFUA    uint16 = 1 << 0
+	cmdFlagReqOne uint16 = 1 << 3
+)
+
+// structured replies
+const (
+	replyFlagDone uint16 = 1 << 0
+
+	replyTypeNone        uint16 = 0
+	replyTypeOffsetData  uint16 = 1
+	replyTypeOffsetHole  uint16 = 2
+	replyTypeBlockStatus uint16 = 5
+	replyTypeErrorBit    uint16 = 1 << 15
+	replyTypeError       uint16 = replyTypeErrorBit + 1
+)
+
+// errors
+const (
+	errPerm  uint32 = 1
+	errIO    uint32 = 5
+	errInval uint32 = 22
+	errNoSpc uint32 = 28
+)
+
+const (
+	// BaseAllocation is the meta context of the allocation status of an export
+	BaseAllocation = "base:allocation"
+	// baseAllocationID is the id of BaseAllocation on the server, it is the only meta context it provides
+	baseAllocationID = 1
+
+	// StateHole is set in base:allocation on extents which are not allocated
+	StateHole uint32 = 1 << 0
+	// StateZero is set in base:allocation on extents which read as zeroes
+	StateZero uint32 = 1 << 1
+	// StateDirty is set in a dirty bitmap context on extents which changed
+	StateDirty uint32 = 1 << 0
+)
+
+const (
+	minBlockSize       = 1
+	preferredBlockSize = 4096
+	// maxPayload is the maximum size of a read or a write, qemu serves
+	// reads of the same size in a single request
+	maxPayload = 32 * 1024 * 1024
+	// maxOptionLength is the maximum size of the data of an option
+	maxOptionLength = 4096
+	// maxBlockStatusLength is the length of the block status requests of the client
+	maxBlockStatusLength = 1 << 30
+)
+
+// DirtyBitmap returns the meta context of a dirty bitmap exported by qemu
+func DirtyBitmap(bitmap string) string {
+	return "qemu:dirty-bitmap:" + bitmap
+}
+
+// Extent is a range of an export and its status in a meta context
+type Extent struct {
+	Offset int64
+	Length int64
+	Flags  uint32
+}
+
+// appendString32 appends a string prefixed by its 32 bit length
+func appendString32(data []byte, s string) []byte {
+	data = binary.BigEndian.AppendUint32(data, uint32(len(s)))
+	return append(data, s...)
+}
+
+// parseString parses a string prefixed by its 32 bit length and returns the remaining data
+func parseString(data []byte) (string, []byte, error) {
+	if len(data) < 4 {
+		return "", nil, fmt.Errorf("string too short")
+	}
+	length := binary.BigEndian.Uint32(data)
+	if uint64(len(data)-4) < uint64(length) {
+		return "", nil, fmt.Errorf("string too short")
+	}
+	return string(data[4 : 4+length]), data[4+length:], nil
+}
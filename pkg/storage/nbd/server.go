@@ -0,0 +1,396 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package nbd
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"kubevirt.io/client-go/log"
+)
+
+var errUnknownExport = errors.New("unknown export")
+
+// Server serves files and block devices over NBD. Clients have to start TLS
+// before they can select an export. The export token authenticates the
+// client, it is passed as part of the export name: <export>/<token>.
+type Server struct {
+	// Exports maps the names of the exports to the files or block devices they serve
+	Exports map[string]string
+	// ReadOnly rejects all requests which modify the exports
+	ReadOnly    bool
+	TLSConfig   *tls.Config
+	TokenGetter func() (string, error)
+}
+
+// Serve accepts connections on the listener until it fails
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	c := newConnection(s, conn)
+	defer func() { c.conn.Close() }()
+
+	exp, err := c.negotiate()
+	if err != nil {
+		log.Log.Reason(err).Errorf("NBD negotiation with %s failed", conn.RemoteAddr())
+		return
+	}
+	if exp == nil {
+		return
+	}
+	defer exp.file.Close()
+
+	if err := c.transmit(exp); err != nil && !errors.Is(err, io.EOF) {
+		log.Log.Reason(err).Errorf("NBD transmission with %s failed", conn.RemoteAddr())
+	}
+}
+
+// open opens the export selected by the client if the token in the name is valid
+func (s *Server) open(name string) (*export, error) {
+	exportName, token, found := strings.Cut(name, "/")
+	if !found || !s.validToken(token) {
+		return nil, errUnknownExport
+	}
+	path, ok := s.Exports[exportName]
+	if !ok {
+		return nil, errUnknownExport
+	}
+
+	flag := os.O_RDWR
+	if s.ReadOnly {
+		flag = os.O_RDONLY
+	}
+	f, err := os.OpenFile(path, flag, 0)
+	if err != nil {
+		return nil, err
+	}
+	// the size of block devices is not reported by stat
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &export{file: f, size: size, readOnly: s.ReadOnly}, nil
+}
+
+func (s *Server) validToken(token string) bool {
+	expected, err := s.TokenGetter()
+	if err != nil {
+		log.Log.Reason(err).Error("error getting the export token")
+		return false
+	}
+	return expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
+
+type export struct {
+	file     *os.File
+	size     int64
+	readOnly bool
+}
+
+func (e *export) transmissionFlags(structured bool) uint16 {
+	flags := transFlagHasFlags | transFlagSendFlush | transFlagSendFUA
+	if structured {
+		// reads are never fragmented
+		flags |= transFlagSendDF
+	}
+	if e.readOnly {
+		flags |= transFlagReadOnly | transFlagCanMultiConn
+	} else {
+		flags |= transFlagSendTrim | transFlagSendWriteZeros
+	}
+	return flags
+}
+
+type connection struct {
+	server *Server
+	conn   net.Conn
+	r      *bufio.Reader
+	w      *bufio.Writer
+
+	tls         bool
+	noZeroes    bool
+	structured  bool
+	metaContext bool
+}
+
+func newConnection(server *Server, conn net.Conn) *connection {
+	c := &connection{server: server}
+	c.setConn(conn)
+	return c
+}
+
+func (c *connection) setConn(conn net.Conn) {
+	c.conn = conn
+	c.r = bufio.NewReader(conn)
+	c.w = bufio.NewWriter(conn)
+}
+
+func (c *connection) read(data ...interface{}) error {
+	for _, d := range data {
+		if err := binary.Read(c.r, binary.BigEndian, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *connection) write(data ...interface{}) error {
+	for _, d := range data {
+		if err := binary.Write(c.w, binary.BigEndian, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// negotiate runs the handshake and the option haggling of the fixed newstyle
+// negotiation. It returns the export selected by the client, or nil if the
+// client aborted.
+func (c *connection) negotiate() (*export, error) {
+	if err := c.write(nbdMagic, optionMagic, flagFixedNewstyle|flagNoZeroes); err != nil {
+		return nil, err
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+	var clientFlags uint32
+	if err := c.read(&clientFlags); err != nil {
+		return nil, err
+	}
+	if clientFlags&clientFlagFixedNewstyle == 0 {
+		return nil, fmt.Errorf("client does not support the fixed newstyle negotiation")
+	}
+	c.noZeroes = clientFlags&clientFlagNoZeroes != 0
+
+	for {
+		var (
+			magic  uint64
+			option uint32
+			length uint32
+		)
+		if err := c.read(&magic, &option, &length); err != nil {
+			return nil, err
+		}
+		if magic != optionMagic {
+			return nil, fmt.Errorf("unexpected option magic %x", magic)
+		}
+		if length > maxOptionLength {
+			return nil, fmt.Errorf("option %d is too long: %d", option, length)
+		}
+		data := make([]byte, length)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, err
+		}
+
+		if !c.tls && option != optStartTLS && option != optAbort {
+			if option == optExportName {
+				return nil, fmt.Errorf("client selected an export without TLS")
+			}
+			if err := c.optionReply(option, repErrTLSReqd, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		exp, done, err := c.handleOption(option, data)
+		if err != nil || done {
+			return exp, err
+		}
+	}
+}
+
+// handleOption handles a single option, done is true once the negotiation ended
+func (c *connection) handleOption(option uint32, data []byte) (exp *export, done bool, err error) {
+	switch option {
+	case optAbort:
+		return nil, true, c.optionReply(option, repAck, nil)
+	case optStartTLS:
+		if c.tls || len(data) != 0 {
+			return nil, false, c.optionReply(option, repErrInvalid, nil)
+		}
+		if err := c.optionReply(option, repAck, nil); err != nil {
+			return nil, false, err
+		}
+		tlsConn := tls.Server(c.conn, c.server.TLSConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, false, err
+		}
+		c.setConn(tlsConn)
+		c.tls = true
+		return nil, false, nil
+	case optExportName:
+		exp, err := c.server.open(string(data))
+		if err != nil {
+			// the only way to reject NBD_OPT_EXPORT_NAME is closing the connection
+			return nil, false, err
+		}
+		if err := c.write(uint64(exp.size), exp.transmissionFlags(c.structured)); err != nil {
+			exp.file.Close()
+			return nil, false, err
+		}
+		if !c.noZeroes {
+			if _, err := c.w.Write(make([]byte, 124)); err != nil {
+				exp.file.Close()
+				return nil, false, err
+			}
+		}
+		if err := c.w.Flush(); err != nil {
+			exp.file.Close()
+			return nil, false, err
+		}
+		return exp, true, nil
+	case optInfo, optGo:
+		return c.handleInfo(option, data)
+	case optStructuredReply:
+		if len(data) != 0 {
+			return nil, false, c.optionReply(option, repErrInvalid, nil)
+		}
+		c.structured = true
+		return nil, false, c.optionReply(option, repAck, nil)
+	case optListMetaContext, optSetMetaContext:
+		return nil, false, c.handleMetaContext(option, data)
+	case optList:
+		// listing the exports would disclose them without a token
+		return nil, false, c.optionReply(option, repErrPolicy, nil)
+	default:
+		return nil, false, c.optionReply(option, repErrUnsup, nil)
+	}
+}
+
+func (c *connection) handleInfo(option uint32, data []byte) (*export, bool, error) {
+	name, rest, err := parseString(data)
+	if err != nil || len(rest) < 2 || len(rest) != 2+2*int(binary.BigEndian.Uint16(rest)) {
+		return nil, false, c.optionReply(option, repErrInvalid, nil)
+	}
+
+	exp, err := c.server.open(name)
+	if errors.Is(err, errUnknownExport) {
+		return nil, false, c.optionReply(option, repErrUnknown, nil)
+	} else if err != nil {
+		log.Log.Reason(err).Errorf("error opening NBD export")
+		return nil, false, c.optionReply(option, repErrUnknown, nil)
+	}
+
+	exportInfo := make([]byte, 12)
+	binary.BigEndian.PutUint16(exportInfo, infoExport)
+	binary.BigEndian.PutUint64(exportInfo[2:], uint64(exp.size))
+	binary.BigEndian.PutUint16(exportInfo[10:], exp.transmissionFlags(c.structured))
+	blockSizeInfo := make([]byte, 14)
+	binary.BigEndian.PutUint16(blockSizeInfo, infoBlockSize)
+	binary.BigEndian.PutUint32(blockSizeInfo[2:], minBlockSize)
+	binary.BigEndian.PutUint32(blockSizeInfo[6:], preferredBlockSize)
+	binary.BigEndian.PutUint32(blockSizeInfo[10:], maxPayload)
+
+	for _, reply := range [][]byte{exportInfo, blockSizeInfo} {
+		if err := c.optionReply(option, repInfo, reply); err != nil {
+			exp.file.Close()
+			return nil, false, err
+		}
+	}
+	if err := c.optionReply(option, repAck, nil); err != nil {
+		exp.file.Close()
+		return nil, false, err
+	}
+	if option == optInfo {
+		exp.file.Close()
+		return nil, false, nil
+	}
+	return exp, true, nil
+}
+
+func (c *connection) handleMetaContext(option uint32, data []byte) error {
+	if !c.structured {
+		return c.optionReply(option, repErrInvalid, nil)
+	}
+	name, rest, err := parseString(data)
+	if err != nil || len(rest) < 4 {
+		return c.optionReply(option, repErrInvalid, nil)
+	}
+	queries := []string{}
+	count := binary.BigEndian.Uint32(rest)
+	rest = rest[4:]
+	for i := uint32(0); i < count; i++ {
+		var query string
+		query, rest, err = parseString(rest)
+		if err != nil {
+			return c.optionReply(option, repErrInvalid, nil)
+		}
+		queries = append(queries, query)
+	}
+	if len(rest) != 0 {
+		return c.optionReply(option, repErrInvalid, nil)
+	}
+
+	exp, err := c.server.open(name)
+	if err != nil {
+		return c.optionReply(option, repErrUnknown, nil)
+	}
+	exp.file.Close()
+
+	selected := false
+	for _, query := range queries {
+		if query == BaseAllocation || (option == optListMetaContext && query == "base:") {
+			selected = true
+		}
+	}
+	if option == optListMetaContext && len(queries) == 0 {
+		selected = true
+	}
+	if option == optSetMetaContext {
+		c.metaContext = selected
+	}
+
+	if selected {
+		reply := make([]byte, 4+len(BaseAllocation))
+		binary.BigEndian.PutUint32(reply, baseAllocationID)
+		copy(reply[4:], BaseAllocation)
+		if err := c.optionReply(option, repMetaContext, reply); err != nil {
+			return err
+		}
+	}
+	return c.optionReply(option, repAck, nil)
+}
+
+func (c *connection) optionReply(option, replyType uint32, data []byte) error {
+	if err := c.write(optReplyMagic, option, replyType, uint32(len(data))); err != nil {
+		return err
+	}
+	if _, err := c.w.Write(data); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
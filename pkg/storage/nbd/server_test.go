@@ -0,0 +1,378 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package nbd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/certificates/triple"
+	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
+)
+
+const (
+	testToken    = "token"
+	testExport   = "disk"
+	testDiskSize = 4 * 1024 * 1024
+)
+
+type optionReply struct {
+	replyType uint32
+	data      []byte
+}
+
+type structuredReply struct {
+	flags     uint16
+	replyType uint16
+	cookie    uint64
+	payload   []byte
+}
+
+// testClient is a minimal NBD client
+type testClient struct {
+	conn    net.Conn
+	rootCAs *x509.CertPool
+}
+
+func (c *testClient) write(data ...interface{}) {
+	for _, d := range data {
+		ExpectWithOffset(2, binary.Write(c.conn, binary.BigEndian, d)).To(Succeed())
+	}
+}
+
+func (c *testClient) read(data ...interface{}) {
+	for _, d := range data {
+		ExpectWithOffset(2, binary.Read(c.conn, binary.BigEndian, d)).To(Succeed())
+	}
+}
+
+func (c *testClient) handshake() {
+	var (
+		magic, opts uint64
+		flags       uint16
+	)
+	c.read(&magic, &opts, &flags)
+	Expect(magic).To(Equal(nbdMagic))
+	Expect(opts).To(Equal(optionMagic))
+	Expect(flags & flagFixedNewstyle).ToNot(BeZero())
+	c.write(clientFlagFixedNewstyle | clientFlagNoZeroes)
+}
+
+func (c *testClient) option(option uint32, data []byte) []optionReply {
+	c.write(optionMagic, option, uint32(len(data)), data)
+	var replies []optionReply
+	for {
+		var (
+			magic           uint64
+			replyOpt, rType uint32
+			length          uint32
+		)
+		c.read(&magic, &replyOpt, &rType, &length)
+		Expect(magic).To(Equal(optReplyMagic))
+		Expect(replyOpt).To(Equal(option))
+		reply := optionReply{replyType: rType, data: make([]byte, length)}
+		_, err := io.ReadFull(c.conn, reply.data)
+		Expect(err).ToNot(HaveOccurred())
+		replies = append(replies, reply)
+		if rType == repAck || rType&repFlagError != 0 {
+			return replies
+		}
+	}
+}
+
+func (c *testClient) startTLS() {
+	Expect(c.option(optStartTLS, nil)).To(Equal([]optionReply{{replyType: repAck, data: []byte{}}}))
+	tlsConn := tls.Client(c.conn, &tls.Config{RootCAs: c.rootCAs, ServerName: "nbd.test"})
+	Expect(tlsConn.Handshake()).To(Succeed())
+	c.conn = tlsConn
+}
+
+func (c *testClient) goExport(name string) []optionReply {
+	return c.option(optGo, binary.BigEndian.AppendUint16(appendString32(nil, name), 0))
+}
+
+func (c *testClient) setMetaContext(name string, queries ...string) []optionReply {
+	data := binary.BigEndian.AppendUint32(appendString32(nil, name), uint32(len(queries)))
+	for _, query := range queries {
+		data = append(data, appendString32(nil, query)...)
+	}
+	return c.option(optSetMetaContext, data)
+}
+
+func (c *testClient) request(command, flags uint16, cookie, offset uint64, length uint32, data []byte) {
+	c.write(requestMagic, flags, command, cookie, offset, length, data)
+}
+
+func (c *testClient) simpleReply(cookie uint64, length int) (uint32, []byte) {
+	var (
+		magic, nbdErr uint32
+		replyCookie   uint64
+	)
+	c.read(&magic, &nbdErr, &replyCookie)
+	Expect(magic).To(Equal(simpleMagic))
+	Expect(replyCookie).To(Equal(cookie))
+	if nbdErr != 0 {
+		return nbdErr, nil
+	}
+	data := make([]byte, length)
+	_, err := io.ReadFull(c.conn, data)
+	Expect(err).ToNot(HaveOccurred())
+	return 0, data
+}
+
+func (c *testClient) structuredReply() structuredReply {
+	var (
+		magic  uint32
+		length uint32
+		reply  structuredReply
+	)
+	c.read(&magic, &reply.flags, &reply.replyType, &reply.cookie, &length)
+	Expect(magic).To(Equal(structuredMagic))
+	reply.payload = make([]byte, length)
+	_, err := io.ReadFull(c.conn, reply.payload)
+	Expect(err).ToNot(HaveOccurred())
+	return reply
+}
+
+var _ = Describe("NBD server", func() {
+	var (
+		server   *Server
+		listener net.Listener
+		diskPath string
+		rootCAs  *x509.CertPool
+	)
+
+	newClient := func() *testClient {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(conn.Close)
+		Expect(conn.SetDeadline(time.Now().Add(10 * time.Second))).To(Succeed())
+		client := &testClient{conn: conn, rootCAs: rootCAs}
+		client.handshake()
+		return client
+	}
+
+	connect := func() *testClient {
+		client := newClient()
+		client.startTLS()
+		replies := client.goExport(testExport + "/" + testToken)
+		Expect(replies[len(replies)-1].replyType).To(Equal(repAck))
+		return client
+	}
+
+	startServer := func(readOnly bool) {
+		server.ReadOnly = readOnly
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(listener.Close)
+		go server.Serve(listener)
+	}
+
+	BeforeEach(func() {
+		ca, err := triple.NewCA("nbd-ca", time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		keyPair, err := triple.NewServerKeyPair(ca, "nbd.test", "nbd", "test", "cluster.local", nil, []string{"nbd.test"}, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		tlsCert, err := tls.X509KeyPair(cert.EncodeCertPEM(keyPair.Cert), cert.EncodePrivateKeyPEM(keyPair.Key))
+		Expect(err).ToNot(HaveOccurred())
+		rootCAs = x509.NewCertPool()
+		rootCAs.AddCert(ca.Cert)
+
+		diskPath = filepath.Join(GinkgoT().TempDir(), "disk.img")
+		f, err := os.Create(diskPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Truncate(testDiskSize)).To(Succeed())
+		_, err = f.WriteAt([]byte("data"), 1024*1024)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+
+		server = &Server{
+			Exports:   map[string]string{testExport: diskPath},
+			TLSConfig: &tls.Config{Certificates: []tls.Certificate{tlsCert}},
+			TokenGetter: func() (string, error) {
+				return testToken, nil
+			},
+		}
+	})
+
+	Context("negotiation", func() {
+		BeforeEach(func() {
+			startServer(true)
+		})
+
+		It("should require TLS", func() {
+			client := newClient()
+			replies := client.goExport(testExport + "/" + testToken)
+			Expect(replies).To(HaveLen(1))
+			Expect(replies[0].replyType).To(Equal(repErrTLSReqd))
+		})
+
+		DescribeTable("should reject", func(name string) {
+			client := newClient()
+			client.startTLS()
+			replies := client.goExport(name)
+			Expect(replies).To(HaveLen(1))
+			Expect(replies[0].replyType).To(Equal(repErrUnknown))
+		},
+			Entry("an export without token", testExport),
+			Entry("an invalid token", testExport+"/invalid"),
+			Entry("an unknown export", "unknown/"+testToken),
+		)
+
+		It("should not list the exports", func() {
+			client := newClient()
+			client.startTLS()
+			replies := client.option(optList, nil)
+			Expect(replies).To(HaveLen(1))
+			Expect(replies[0].replyType).To(Equal(repErrPolicy))
+		})
+
+		It("should report the size and flags of the export", func() {
+			client := newClient()
+			client.startTLS()
+			replies := client.goExport(testExport + "/" + testToken)
+			Expect(replies[0].replyType).To(Equal(repInfo))
+			Expect(binary.BigEndian.Uint16(replies[0].data)).To(Equal(infoExport))
+			Expect(binary.BigEndian.Uint64(replies[0].data[2:])).To(BeEquivalentTo(testDiskSize))
+			Expect(binary.BigEndian.Uint16(replies[0].data[10:]) & transFlagReadOnly).ToNot(BeZero())
+			Expect(replies[len(replies)-1].replyType).To(Equal(repAck))
+		})
+	})
+
+	Context("read only", func() {
+		BeforeEach(func() {
+			startServer(true)
+		})
+
+		It("should read the export", func() {
+			client := connect()
+			client.request(cmdRead, 0, 1, 1024*1024, 4, nil)
+			nbdErr, data := client.simpleReply(1, 4)
+			Expect(nbdErr).To(BeZero())
+			Expect(string(data)).To(Equal("data"))
+		})
+
+		It("should reject writes", func() {
+			client := connect()
+			client.request(cmdWrite, 0, 1, 0, 4, []byte("abcd"))
+			nbdErr, _ := client.simpleReply(1, 0)
+			Expect(nbdErr).To(Equal(errPerm))
+		})
+
+		It("should reject reads out of bounds", func() {
+			client := connect()
+			client.request(cmdRead, 0, 1, testDiskSize-2, 4, nil)
+			nbdErr, _ := client.simpleReply(1, 0)
+			Expect(nbdErr).To(Equal(errInval))
+		})
+
+		It("should report the allocation of the export", func() {
+			client := newClient()
+			client.startTLS()
+			Expect(client.option(optStructuredReply, nil)[0].replyType).To(Equal(repAck))
+			replies := client.setMetaContext(testExport+"/"+testToken, BaseAllocation)
+			Expect(replies).To(HaveLen(2))
+			Expect(replies[0].replyType).To(Equal(repMetaContext))
+			Expect(string(replies[0].data[4:])).To(Equal(BaseAllocation))
+			replies = client.goExport(testExport + "/" + testToken)
+			Expect(replies[len(replies)-1].replyType).To(Equal(repAck))
+
+			client.request(cmdBlockStatus, 0, 1, 0, testDiskSize, nil)
+			reply := client.structuredReply()
+			Expect(reply.replyType).To(Equal(replyTypeBlockStatus))
+			Expect(reply.flags & replyFlagDone).ToNot(BeZero())
+			Expect(binary.BigEndian.Uint32(reply.payload)).To(BeEquivalentTo(baseAllocationID))
+
+			var dataLength, holeLength uint32
+			for descriptors := reply.payload[4:]; len(descriptors) > 0; descriptors = descriptors[8:] {
+				length := binary.BigEndian.Uint32(descriptors)
+				if binary.BigEndian.Uint32(descriptors[4:])&StateHole != 0 {
+					holeLength += length
+				} else {
+					dataLength += length
+				}
+			}
+			Expect(dataLength + holeLength).To(BeEquivalentTo(testDiskSize))
+			Expect(dataLength).ToNot(BeZero())
+			Expect(holeLength).ToNot(BeZero())
+
+			client.request(cmdRead, 0, 2, 1024*1024, 4, nil)
+			reply = client.structuredReply()
+			Expect(reply.replyType).To(Equal(replyTypeOffsetData))
+			Expect(binary.BigEndian.Uint64(reply.payload)).To(BeEquivalentTo(1024 * 1024))
+			Expect(string(reply.payload[8:])).To(Equal("data"))
+		})
+
+		It("should reject block status without meta context", func() {
+			client := connect()
+			client.request(cmdBlockStatus, 0, 1, 0, testDiskSize, nil)
+			nbdErr, _ := client.simpleReply(1, 0)
+			Expect(nbdErr).To(Equal(errInval))
+		})
+	})
+
+	Context("read write", func() {
+		BeforeEach(func() {
+			startServer(false)
+		})
+
+		It("should write the export", func() {
+			client := connect()
+			client.request(cmdWrite, cmdFlagFUA, 1, 512, 4, []byte("abcd"))
+			nbdErr, _ := client.simpleReply(1, 0)
+			Expect(nbdErr).To(BeZero())
+			client.request(cmdFlush, 0, 2, 0, 0, nil)
+			nbdErr, _ = client.simpleReply(2, 0)
+			Expect(nbdErr).To(BeZero())
+
+			content, err := os.ReadFile(diskPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content[512:516])).To(Equal("abcd"))
+		})
+
+		It("should zero a range of the export", func() {
+			client := connect()
+			client.request(cmdWriteZeroes, 0, 1, 1024*1024, 4096, nil)
+			nbdErr, _ := client.simpleReply(1, 0)
+			Expect(nbdErr).To(BeZero())
+
+			content, err := os.ReadFile(diskPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(content[1024*1024 : 1024*1024+4]).To(Equal(make([]byte, 4)))
+		})
+
+		It("should reject writes out of bounds", func() {
+			client := connect()
+			client.request(cmdWrite, 0, 1, testDiskSize, 4, []byte("abcd"))
+			nbdErr, _ := client.simpleReply(1, 0)
+			Expect(nbdErr).To(Equal(errNoSpc))
+		})
+	})
+})
@@ -0,0 +1,250 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package nbd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/sys/unix"
+
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/sparse"
+)
+
+type request struct {
+	flags   uint16
+	command uint16
+	cookie  uint64
+	offset  uint64
+	length  uint32
+}
+
+// transmit serves the requests of the client until it disconnects
+func (c *connection) transmit(exp *export) error {
+	for {
+		var (
+			magic uint32
+			req   request
+		)
+		if err := c.read(&magic, &req.flags, &req.command, &req.cookie, &req.offset, &req.length); err != nil {
+			return err
+		}
+		if magic != requestMagic {
+			return fmt.Errorf("unexpected request magic %x", magic)
+		}
+
+		var err error
+		switch req.command {
+		case cmdDisc:
+			return nil
+		case cmdRead:
+			err = c.handleRead(exp, req)
+		case cmdWrite:
+			err = c.handleWrite(exp, req)
+		case cmdFlush:
+			err = c.finish(req, syncError(exp))
+		case cmdTrim, cmdWriteZeroes:
+			err = c.handleZero(exp, req)
+		case cmdBlockStatus:
+			err = c.handleBlockStatus(exp, req)
+		default:
+			err = c.finish(req, errInval)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *connection) handleRead(exp *export, req request) error {
+	if req.length > maxPayload || !inBounds(exp, req) {
+		return c.finish(req, errInval)
+	}
+	data := make([]byte, req.length)
+	if _, err := exp.file.ReadAt(data, int64(req.offset)); err != nil && !errors.Is(err, io.EOF) {
+		log.Log.Reason(err).Error("NBD read failed")
+		return c.finish(req, errIO)
+	}
+
+	if !c.structured {
+		if err := c.write(simpleMagic, uint32(0), req.cookie); err != nil {
+			return err
+		}
+		if _, err := c.w.Write(data); err != nil {
+			return err
+		}
+		return c.w.Flush()
+	}
+	if req.length == 0 {
+		return c.finish(req, 0)
+	}
+	if err := c.write(structuredMagic, replyFlagDone, replyTypeOffsetData, req.cookie, uint32(8+len(data)), req.offset); err != nil {
+		return err
+	}
+	if _, err := c.w.Write(data); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+func (c *connection) handleWrite(exp *export, req request) error {
+	if req.length > maxPayload {
+		// the payload cannot be skipped reliably
+		return fmt.Errorf("write of %d bytes exceeds the maximum payload", req.length)
+	}
+	data := make([]byte, req.length)
+	if _, err := io.ReadFull(c.r, data); err != nil {
+		return err
+	}
+	if exp.readOnly {
+		return c.finish(req, errPerm)
+	}
+	if !inBounds(exp, req) {
+		return c.finish(req, errNoSpc)
+	}
+	if _, err := exp.file.WriteAt(data, int64(req.offset)); err != nil {
+		log.Log.Reason(err).Error("NBD write failed")
+		return c.finish(req, errIO)
+	}
+	if req.flags&cmdFlagFUA != 0 {
+		return c.finish(req, syncError(exp))
+	}
+	return c.finish(req, 0)
+}
+
+// handleZero handles trim and write zeroes requests by punching a hole, write zeroes
+// falls back to writing zeroes if the export does not support holes
+func (c *connection) handleZero(exp *export, req request) error {
+	if exp.readOnly {
+		return c.finish(req, errPerm)
+	}
+	if !inBounds(exp, req) {
+		return c.finish(req, errNoSpc)
+	}
+	err := unix.Fallocate(int(exp.file.Fd()), unix.FALLOC_FL_PUNCH_HOLE|unix.FALLOC_FL_KEEP_SIZE, int64(req.offset), int64(req.length))
+	if err != nil && req.command == cmdWriteZeroes {
+		err = writeZeroes(exp, int64(req.offset), int64(req.length))
+	}
+	if err != nil && req.command == cmdWriteZeroes {
+		log.Log.Reason(err).Error("NBD write zeroes failed")
+		return c.finish(req, errIO)
+	}
+	// trimming is advisory, a failure is not reported to the client
+	if req.flags&cmdFlagFUA != 0 {
+		return c.finish(req, syncError(exp))
+	}
+	return c.finish(req, 0)
+}
+
+func writeZeroes(exp *export, offset, length int64) error {
+	zeroes := make([]byte, min(length, preferredBlockSize*256))
+	for length > 0 {
+		n := min(length, int64(len(zeroes)))
+		if _, err := exp.file.WriteAt(zeroes[:n], offset); err != nil {
+			return err
+		}
+		offset += n
+		length -= n
+	}
+	return nil
+}
+
+func (c *connection) handleBlockStatus(exp *export, req request) error {
+	if !c.metaContext || req.length == 0 || !inBounds(exp, req) {
+		return c.finish(req, errInval)
+	}
+	extents, err := allocation(exp, int64(req.offset), int64(req.offset)+int64(req.length))
+	if err != nil {
+		log.Log.Reason(err).Error("NBD block status failed")
+		return c.finish(req, errIO)
+	}
+	if req.flags&cmdFlagReqOne != 0 {
+		extents = extents[:1]
+	}
+
+	payload := make([]byte, 4+8*len(extents))
+	binary.BigEndian.PutUint32(payload, baseAllocationID)
+	for i, e := range extents {
+		binary.BigEndian.PutUint32(payload[4+8*i:], uint32(e.Length))
+		binary.BigEndian.PutUint32(payload[8+8*i:], e.Flags)
+	}
+	if err := c.write(structuredMagic, replyFlagDone, replyTypeBlockStatus, req.cookie, uint32(len(payload))); err != nil {
+		return err
+	}
+	if _, err := c.w.Write(payload); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+// allocation returns the allocation of the range of the export in extents of data and holes
+func allocation(exp *export, offset, end int64) ([]Extent, error) {
+	fileExtents, err := sparse.Extents(exp.file, offset, end)
+	if err != nil {
+		return nil, err
+	}
+	extents := make([]Extent, 0, len(fileExtents))
+	for _, e := range fileExtents {
+		var flags uint32
+		if e.Hole {
+			flags = StateHole | StateZero
+		}
+		extents = append(extents, Extent{Offset: e.Offset, Length: e.Length, Flags: flags})
+	}
+	return extents, nil
+}
+
+func inBounds(exp *export, req request) bool {
+	return req.offset <= uint64(exp.size) && uint64(req.length) <= uint64(exp.size)-req.offset
+}
+
+func syncError(exp *export) uint32 {
+	if exp.readOnly {
+		return 0
+	}
+	if err := exp.file.Sync(); err != nil {
+		log.Log.Reason(err).Error("NBD flush failed")
+		return errIO
+	}
+	return 0
+}
+
+// finish sends the reply of a request without data, errors are sent as
+// structured replies if they were negotiated
+func (c *connection) finish(req request, nbdErr uint32) error {
+	var err error
+	switch {
+	case !c.structured:
+		err = c.write(simpleMagic, nbdErr, req.cookie)
+	case nbdErr == 0:
+		err = c.write(structuredMagic, replyFlagDone, replyTypeNone, req.cookie, uint32(0))
+	default:
+		// an error without message
+		err = c.write(structuredMagic, replyFlagDone, replyTypeError, req.cookie, uint32(6), nbdErr, uint16(0))
+	}
+	if err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
@@ -0,0 +1,385 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+const (
+	vmBackupDeadlineExceededError = "backup deadline exceeded"
+
+	vmBackupVMIRestartedError = "VirtualMachineInstance was restarted during the backup"
+
+	vmBackupSourceNotRunning = "Source VirtualMachineInstance is not running"
+
+	vmBackupPVCNotFound = "PersistentVolumeClaim does not exist"
+
+	vmBackupWaitingForVolume = "Waiting for another backup of the source to finish"
+)
+
+// updateVMBackup takes the backup by hotplugging the pvc as a backup volume
+// into the running vmi. virt-launcher writes the backup into a directory of
+// the pvc and reports it back in the status of the volume.
+func (ctrl *VMSnapshotController) updateVMBackup(vmBackup *snapshotv1.VirtualMachineBackup) (time.Duration, error) {
+	log.Log.V(3).Infof("Updating VirtualMachineBackup %s/%s", vmBackup.Namespace, vmBackup.Name)
+
+	if vmBackup.DeletionTimestamp != nil || vmBackupFinished(vmBackup) {
+		return 0, nil
+	}
+
+	vmBackupCpy := vmBackup.DeepCopy()
+	if vmBackupCpy.Status == nil {
+		vmBackupCpy.Status = &snapshotv1.VirtualMachineBackupStatus{
+			Phase: snapshotv1.InProgress,
+		}
+	}
+
+	vm, vmi, err := ctrl.getVMBackupSource(vmBackupCpy)
+	if err != nil {
+		return 0, err
+	}
+
+	var retry time.Duration
+	switch {
+	case vmBackupDeadlineExceeded(vmBackupCpy):
+		err = ctrl.failVMBackup(vmBackupCpy, vmi, vmBackupDeadlineExceededError)
+	case vmBackupCpy.Status.Checkpoint == "":
+		err = ctrl.startVMBackup(vmBackupCpy, vm, vmi)
+		retry = timeUntilVMBackupDeadline(vmBackupCpy)
+	case vmi == nil || vmBackupCpy.Status.VirtualMachineInstanceUID == nil || vmi.UID != *vmBackupCpy.Status.VirtualMachineInstanceUID:
+		err = ctrl.failVMBackup(vmBackupCpy, vmi, vmBackupVMIRestartedError)
+	default:
+		err = ctrl.syncVMBackup(vmBackupCpy, vmi)
+		retry = timeUntilVMBackupDeadline(vmBackupCpy)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if !equality.Semantic.DeepEqual(vmBackup.Status, vmBackupCpy.Status) {
+		if _, err := ctrl.Client.VirtualMachineBackup(vmBackupCpy.Namespace).Update(context.Background(), vmBackupCpy, metav1.UpdateOptions{}); err != nil {
+			return 0, err
+		}
+	}
+
+	if vmBackupFinished(vmBackupCpy) {
+		return 0, nil
+	}
+	return retry, nil
+}
+
+// getVMBackupSource returns the source VM of the backup and its running vmi,
+// if any
+func (ctrl *VMSnapshotController) getVMBackupSource(vmBackup *snapshotv1.VirtualMachineBackup) (*kubevirtv1.VirtualMachine, *kubevirtv1.VirtualMachineInstance, error) {
+	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(cacheKeyFunc(vmBackup.Namespace, vmBackup.Spec.Source.Name))
+	if err != nil || !exists {
+		return nil, nil, err
+	}
+	vm, ok := obj.(*kubevirtv1.VirtualMachine)
+	if !ok {
+		return nil, nil, fmt.Errorf(unexpectedResourceFmt, obj)
+	}
+
+	vmi, exists, err := ctrl.getVMI(vm)
+	if err != nil || !exists || !vmi.IsRunning() {
+		return vm, nil, err
+	}
+	return vm, vmi, nil
+}
+
+// startVMBackup picks the checkpoint the backup is taken on top of once the
+// source is running and the pvc exists
+func (ctrl *VMSnapshotController) startVMBackup(vmBackup *snapshotv1.VirtualMachineBackup, vm *kubevirtv1.VirtualMachine, vmi *kubevirtv1.VirtualMachineInstance) error {
+	status := vmBackup.Status
+	if vm == nil || vmi == nil {
+		updateVMBackupCondition(status, newProgressingCondition(corev1.ConditionFalse, vmBackupSourceNotRunning))
+		return nil
+	}
+
+	_, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(vmBackup.Namespace, vmBackup.Spec.PersistentVolumeClaimName))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		updateVMBackupCondition(status, newProgressingCondition(corev1.ConditionFalse, vmBackupPVCNotFound))
+		return nil
+	}
+
+	if !canAddVMBackupVolume(vmBackup, vmi) {
+		updateVMBackupCondition(status, newProgressingCondition(corev1.ConditionFalse, vmBackupWaitingForVolume))
+		return nil
+	}
+
+	parent, err := ctrl.getVMBackupParent(vmBackup, vmi.UID)
+	if err != nil {
+		return err
+	}
+
+	checkpoint := fmt.Sprintf("%s-%s", vmBackup.Name, vmBackup.UID)
+	status.VirtualMachineInstanceUID = pointer.P(vmi.UID)
+	status.Checkpoint = checkpoint
+	status.StartTimestamp = currentTime()
+	if parent != nil {
+		status.Type = snapshotv1.BackupTypeIncremental
+		status.IncrementalFrom = pointer.P(parent.Status.Checkpoint)
+		status.CheckpointChain = append(append([]string{}, parent.Status.CheckpointChain...), checkpoint)
+	} else {
+		status.Type = snapshotv1.BackupTypeFull
+		status.CheckpointChain = []string{checkpoint}
+	}
+	updateVMBackupCondition(status, newProgressingCondition(corev1.ConditionTrue, "Operation in progress"))
+	updateVMBackupCondition(status, newReadyCondition(corev1.ConditionFalse, "Not ready"))
+
+	return nil
+}
+
+// getVMBackupParent returns the latest succeeded backup of the same vmi an
+// incremental backup is taken on top of. Checkpoints do not outlive the vmi.
+func (ctrl *VMSnapshotController) getVMBackupParent(vmBackup *snapshotv1.VirtualMachineBackup, vmiUID types.UID) (*snapshotv1.VirtualMachineBackup, error) {
+	if vmBackup.Spec.Type != nil && *vmBackup.Spec.Type == snapshotv1.BackupTypeFull {
+		return nil, nil
+	}
+
+	objs, err := ctrl.VMBackupInformer.GetIndexer().ByIndex("vm", cacheKeyFunc(vmBackup.Namespace, vmBackup.Spec.Source.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *snapshotv1.VirtualMachineBackup
+	for _, obj := range objs {
+		b, ok := obj.(*snapshotv1.VirtualMachineBackup)
+		if !ok {
+			return nil, fmt.Errorf(unexpectedResourceFmt, obj)
+		}
+		if b.UID == vmBackup.UID || b.DeletionTimestamp != nil || b.Status == nil ||
+			b.Status.Phase != snapshotv1.Succeeded || b.Status.EndTimestamp == nil ||
+			b.Status.VirtualMachineInstanceUID == nil || *b.Status.VirtualMachineInstanceUID != vmiUID {
+			continue
+		}
+		if parent == nil || parent.Status.EndTimestamp.Before(b.Status.EndTimestamp) {
+			parent = b
+		}
+	}
+
+	return parent, nil
+}
+
+// syncVMBackup follows the backup volume in the vmi until the backup is
+// written and the volume is removed again
+func (ctrl *VMSnapshotController) syncVMBackup(vmBackup *snapshotv1.VirtualMachineBackup, vmi *kubevirtv1.VirtualMachineInstance) error {
+	status := vmBackup.Status
+	volume := getVMBackupVolume(vmBackup, vmi)
+
+	if status.BackupDirectory != nil {
+		if volume != nil {
+			return ctrl.removeVMBackupVolume(vmi, volume.Name)
+		}
+		status.Phase = snapshotv1.Succeeded
+		updateVMBackupCondition(status, newProgressingCondition(corev1.ConditionFalse, "Operation complete"))
+		updateVMBackupCondition(status, newReadyCondition(corev1.ConditionTrue, "Ready"))
+		return nil
+	}
+
+	if volume == nil {
+		if !canAddVMBackupVolume(vmBackup, vmi) {
+			updateVMBackupCondition(status, newProgressingCondition(corev1.ConditionFalse, vmBackupWaitingForVolume))
+			return nil
+		}
+		return ctrl.addVMBackupVolume(vmBackup, vmi)
+	}
+
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.Name != volume.Name || volumeStatus.BackupVolume == nil {
+			continue
+		}
+		switch volumeStatus.Phase {
+		case kubevirtv1.BackupVolumeCompleted:
+			if err := ctrl.updatePVCBackupAnnotation(vmBackup, volumeStatus.BackupVolume.TargetDirectory); err != nil {
+				return err
+			}
+			status.BackupDirectory = pointer.P(volumeStatus.BackupVolume.TargetDirectory)
+			status.EndTimestamp = volumeStatus.BackupVolume.EndTimestamp
+			return ctrl.removeVMBackupVolume(vmi, volume.Name)
+		case kubevirtv1.BackupVolumeFailed:
+			return ctrl.failVMBackup(vmBackup, vmi, volumeStatus.Message)
+		}
+	}
+	return nil
+}
+
+func (ctrl *VMSnapshotController) failVMBackup(vmBackup *snapshotv1.VirtualMachineBackup, vmi *kubevirtv1.VirtualMachineInstance, message string) error {
+	if volume := getVMBackupVolume(vmBackup, vmi); volume != nil {
+		if err := ctrl.removeVMBackupVolume(vmi, volume.Name); err != nil {
+			return err
+		}
+	}
+
+	status := vmBackup.Status
+	status.Phase = snapshotv1.Failed
+	status.Error = &snapshotv1.Error{
+		Time:    currentTime(),
+		Message: pointer.P(message),
+	}
+	updateVMBackupCondition(status, newProgressingCondition(corev1.ConditionFalse, message))
+	updateVMBackupCondition(status, newFailureCondition(corev1.ConditionTrue, message))
+	updateVMBackupCondition(status, newReadyCondition(corev1.ConditionFalse, "Operation failed"))
+	return nil
+}
+
+func (ctrl *VMSnapshotController) addVMBackupVolume(vmBackup *snapshotv1.VirtualMachineBackup, vmi *kubevirtv1.VirtualMachineInstance) error {
+	log.Log.V(3).Infof("Backing up vmi %s/%s to pvc %s", vmi.Namespace, vmi.Name, vmBackup.Spec.PersistentVolumeClaimName)
+	volumes := append(append([]kubevirtv1.Volume{}, vmi.Spec.Volumes...), kubevirtv1.Volume{
+		Name: vmBackup.Spec.PersistentVolumeClaimName,
+		VolumeSource: kubevirtv1.VolumeSource{
+			Backup: &kubevirtv1.BackupVolumeSource{
+				PersistentVolumeClaimVolumeSource: kubevirtv1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: vmBackup.Spec.PersistentVolumeClaimName,
+					},
+					Hotpluggable: true,
+				},
+				Checkpoint:      vmBackup.Status.Checkpoint,
+				IncrementalFrom: vmBackup.Status.IncrementalFrom,
+			},
+		},
+	})
+	return ctrl.patchVMIVolumes(vmi, volumes)
+}
+
+func (ctrl *VMSnapshotController) removeVMBackupVolume(vmi *kubevirtv1.VirtualMachineInstance, name string) error {
+	log.Log.V(3).Infof("Removing backup pvc %s from vmi %s/%s", name, vmi.Namespace, vmi.Name)
+	var volumes []kubevirtv1.Volume
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name != name {
+			volumes = append(volumes, volume)
+		}
+	}
+	return ctrl.patchVMIVolumes(vmi, volumes)
+}
+
+func (ctrl *VMSnapshotController) patchVMIVolumes(vmi *kubevirtv1.VirtualMachineInstance, volumes []kubevirtv1.Volume) error {
+	patchset := patch.New(
+		patch.WithTest("/spec/volumes", vmi.Spec.Volumes),
+	)
+	if len(vmi.Spec.Volumes) > 0 {
+		patchset.AddOption(patch.WithReplace("/spec/volumes", volumes))
+	} else {
+		patchset.AddOption(patch.WithAdd("/spec/volumes", volumes))
+	}
+
+	patchBytes, err := patchset.GeneratePayload()
+	if err != nil {
+		return err
+	}
+	_, err = ctrl.Client.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
+
+// updatePVCBackupAnnotation records the directory of the backup in the pvc,
+// the export of the pvc serves it from there
+func (ctrl *VMSnapshotController) updatePVCBackupAnnotation(vmBackup *snapshotv1.VirtualMachineBackup, directory string) error {
+	obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(vmBackup.Namespace, vmBackup.Spec.PersistentVolumeClaimName))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("backup pvc %s/%s does not exist", vmBackup.Namespace, vmBackup.Spec.PersistentVolumeClaimName)
+	}
+	pvc, ok := obj.(*corev1.PersistentVolumeClaim)
+	if !ok {
+		return fmt.Errorf(unexpectedResourceFmt, obj)
+	}
+	if pvc.Annotations[kubevirtv1.PVCBackupAnnotation] == directory {
+		return nil
+	}
+
+	pvcCpy := pvc.DeepCopy()
+	if pvcCpy.Annotations == nil {
+		pvcCpy.Annotations = map[string]string{}
+	}
+	pvcCpy.Annotations[kubevirtv1.PVCBackupAnnotation] = directory
+	_, err = ctrl.Client.CoreV1().PersistentVolumeClaims(pvcCpy.Namespace).Update(context.Background(), pvcCpy, metav1.UpdateOptions{})
+	return err
+}
+
+// getVMBackupVolume returns the backup volume of the vmi which takes the
+// backup, if any
+func getVMBackupVolume(vmBackup *snapshotv1.VirtualMachineBackup, vmi *kubevirtv1.VirtualMachineInstance) *kubevirtv1.Volume {
+	if vmi == nil {
+		return nil
+	}
+	for i, volume := range vmi.Spec.Volumes {
+		if volume.Backup != nil && volume.Name == vmBackup.Spec.PersistentVolumeClaimName &&
+			volume.Backup.Checkpoint == vmBackup.Status.Checkpoint {
+			return &vmi.Spec.Volumes[i]
+		}
+	}
+	return nil
+}
+
+// canAddVMBackupVolume returns true if the vmi takes no other backup and the
+// pvc of the backup is not in use by the vmi
+func canAddVMBackupVolume(vmBackup *snapshotv1.VirtualMachineBackup, vmi *kubevirtv1.VirtualMachineInstance) bool {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Backup != nil || volume.Name == vmBackup.Spec.PersistentVolumeClaimName {
+			return false
+		}
+	}
+	return true
+}
+
+func vmBackupFinished(vmBackup *snapshotv1.VirtualMachineBackup) bool {
+	return vmBackup.Status != nil && (vmBackup.Status.Phase == snapshotv1.Succeeded || vmBackup.Status.Phase == snapshotv1.Failed)
+}
+
+func timeUntilVMBackupDeadline(vmBackup *snapshotv1.VirtualMachineBackup) time.Duration {
+	failureDeadline := snapshotv1.DefaultFailureDeadline
+	if vmBackup.Spec.FailureDeadline != nil {
+		failureDeadline = vmBackup.Spec.FailureDeadline.Duration
+	}
+	// No Deadline set by user
+	if failureDeadline == 0 {
+		return failureDeadline
+	}
+	return time.Until(vmBackup.CreationTimestamp.Add(failureDeadline))
+}
+
+func vmBackupDeadlineExceeded(vmBackup *snapshotv1.VirtualMachineBackup) bool {
+	return timeUntilVMBackupDeadline(vmBackup) < 0
+}
+
+func updateVMBackupCondition(status *snapshotv1.VirtualMachineBackupStatus, c snapshotv1.Condition) {
+	status.Conditions = updateCondition(status.Conditions, c, true)
+}
@@ -0,0 +1,457 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+const (
+	groupMemberSnapshotCreateEvent = "SuccessfulGroupVirtualMachineSnapshotCreate"
+
+	groupVolumeSnapshotsCreateEvent = "SuccessfulGroupVolumeSnapshotsCreate"
+
+	groupVolumeSnapshotsCreateErrorEvent = "FailedGroupVolumeSnapshotsCreate"
+
+	vmGroupSnapshotNoVMsError = "no VirtualMachines match the selector"
+
+	vmGroupSnapshotFreezeDeadlineError = "the volumes of the frozen VirtualMachines were not captured within the freeze deadline"
+
+	// groupFreezeDeadline bounds how long the VMs of a group stay frozen
+	// while the VolumeSnapshots of all members are created
+	groupFreezeDeadline = time.Minute
+)
+
+type groupVolumeSnapshot struct {
+	content      *snapshotv1.VirtualMachineSnapshotContent
+	volumeBackup snapshotv1.VolumeBackup
+}
+
+func (ctrl *VMSnapshotController) updateVMGroupSnapshot(vmGroupSnapshot *snapshotv1.VirtualMachineGroupSnapshot) (time.Duration, error) {
+	log.Log.V(3).Infof("Updating VirtualMachineGroupSnapshot %s/%s", vmGroupSnapshot.Namespace, vmGroupSnapshot.Name)
+
+	// the VirtualMachineSnapshots of the group are garbage collected with it
+	if vmGroupSnapshot.DeletionTimestamp != nil {
+		return 0, nil
+	}
+
+	vmGroupSnapshotCpy := vmGroupSnapshot.DeepCopy()
+	if vmGroupSnapshotCpy.Status == nil {
+		// the members of the group are fixed when the group snapshot starts
+		members, err := ctrl.getGroupSnapshotMembers(vmGroupSnapshot)
+		if err != nil {
+			return 0, err
+		}
+
+		vmGroupSnapshotCpy.Status = &snapshotv1.VirtualMachineGroupSnapshotStatus{
+			Phase:                   snapshotv1.InProgress,
+			ReadyToUse:              pointer.P(false),
+			VirtualMachineSnapshots: members,
+		}
+	}
+
+	vmSnapshots, foreign, err := ctrl.getGroupMemberSnapshots(vmGroupSnapshotCpy)
+	if err != nil {
+		return 0, err
+	}
+
+	if vmGroupSnapshotCpy.Status.Phase == snapshotv1.InProgress && len(foreign) > 0 {
+		failVMGroupSnapshot(vmGroupSnapshotCpy.Status, fmt.Sprintf("VirtualMachineSnapshots %s already exist and are not part of the group", strings.Join(foreign, ", ")))
+	}
+
+	// the freeze times are stored even if the VolumeSnapshots could not be created
+	var volumesErr error
+	if vmGroupSnapshotCpy.Status.Phase == snapshotv1.InProgress {
+		if err := ctrl.createGroupMemberSnapshots(vmGroupSnapshotCpy, vmSnapshots); err != nil {
+			return 0, err
+		}
+
+		volumesErr = ctrl.snapshotGroupVolumes(vmGroupSnapshotCpy, vmSnapshots)
+	}
+
+	updateVMGroupSnapshotStatus(vmGroupSnapshotCpy.Status, vmSnapshots)
+
+	if !equality.Semantic.DeepEqual(vmGroupSnapshot.Status, vmGroupSnapshotCpy.Status) {
+		if _, err := ctrl.Client.VirtualMachineGroupSnapshot(vmGroupSnapshotCpy.Namespace).Update(context.Background(), vmGroupSnapshotCpy, metav1.UpdateOptions{}); err != nil {
+			return 0, err
+		}
+	}
+
+	return 0, volumesErr
+}
+
+func (ctrl *VMSnapshotController) getGroupSnapshotMembers(vmGroupSnapshot *snapshotv1.VirtualMachineGroupSnapshot) ([]snapshotv1.GroupSnapshotVirtualMachineStatus, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&vmGroupSnapshot.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := ctrl.VMInformer.GetIndexer().ByIndex(cache.NamespaceIndex, vmGroupSnapshot.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []snapshotv1.GroupSnapshotVirtualMachineStatus
+	for _, obj := range objs {
+		vm, ok := obj.(*kubevirtv1.VirtualMachine)
+		if !ok {
+			return nil, fmt.Errorf(unexpectedResourceFmt, obj)
+		}
+		if vm.DeletionTimestamp != nil || !selector.Matches(labels.Set(vm.Labels)) {
+			continue
+		}
+		members = append(members, snapshotv1.GroupSnapshotVirtualMachineStatus{
+			VirtualMachineName:         vm.Name,
+			VirtualMachineSnapshotName: fmt.Sprintf("%s-%s", vmGroupSnapshot.Name, vm.Name),
+		})
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].VirtualMachineName < members[j].VirtualMachineName })
+	return members, nil
+}
+
+// getGroupMemberSnapshots returns the existing VirtualMachineSnapshots of the
+// group keyed by the name of the VirtualMachine, and the names of the members
+// which are taken by VirtualMachineSnapshots not created for the group
+func (ctrl *VMSnapshotController) getGroupMemberSnapshots(vmGroupSnapshot *snapshotv1.VirtualMachineGroupSnapshot) (map[string]*snapshotv1.VirtualMachineSnapshot, []string, error) {
+	vmSnapshots := map[string]*snapshotv1.VirtualMachineSnapshot{}
+	var foreign []string
+	for _, member := range vmGroupSnapshot.Status.VirtualMachineSnapshots {
+		obj, exists, err := ctrl.VMSnapshotInformer.GetStore().GetByKey(cacheKeyFunc(vmGroupSnapshot.Namespace, member.VirtualMachineSnapshotName))
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		vmSnapshot, ok := obj.(*snapshotv1.VirtualMachineSnapshot)
+		if !ok {
+			return nil, nil, fmt.Errorf(unexpectedResourceFmt, obj)
+		}
+		if !isGroupMemberSnapshot(vmGroupSnapshot, member, vmSnapshot) {
+			foreign = append(foreign, vmSnapshot.Name)
+			continue
+		}
+		vmSnapshots[member.VirtualMachineName] = vmSnapshot.DeepCopy()
+	}
+
+	return vmSnapshots, foreign, nil
+}
+
+// isGroupMemberSnapshot returns true if the VirtualMachineSnapshot was created by the group for the member
+func isGroupMemberSnapshot(vmGroupSnapshot *snapshotv1.VirtualMachineGroupSnapshot, member snapshotv1.GroupSnapshotVirtualMachineStatus, vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
+	source := vmSnapshot.Spec.Source
+	return metav1.IsControlledBy(vmSnapshot, vmGroupSnapshot) &&
+		vmSnapshot.Labels[snapshotv1.VirtualMachineGroupSnapshotLabel] == vmGroupSnapshot.Name &&
+		source.APIGroup != nil && *source.APIGroup == kubevirtv1.SchemeGroupVersion.Group &&
+		source.Kind == "VirtualMachine" && source.Name == member.VirtualMachineName
+}
+
+func (ctrl *VMSnapshotController) createGroupMemberSnapshots(vmGroupSnapshot *snapshotv1.VirtualMachineGroupSnapshot, vmSnapshots map[string]*snapshotv1.VirtualMachineSnapshot) error {
+	for _, member := range vmGroupSnapshot.Status.VirtualMachineSnapshots {
+		// a member which already had content and disappeared fails the group
+		if vmSnapshots[member.VirtualMachineName] != nil || member.VirtualMachineSnapshotContentName != nil {
+			continue
+		}
+
+		vmSnapshot := newGroupMemberSnapshot(vmGroupSnapshot, member)
+		_, err := ctrl.Client.VirtualMachineSnapshot(vmGroupSnapshot.Namespace).Create(context.Background(), vmSnapshot, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		ctrl.Recorder.Eventf(
+			vmGroupSnapshot,
+			corev1.EventTypeNormal,
+			groupMemberSnapshotCreateEvent,
+			"Successfully created VirtualMachineSnapshot %s",
+			vmSnapshot.Name,
+		)
+	}
+
+	return nil
+}
+
+func newGroupMemberSnapshot(vmGroupSnapshot *snapshotv1.VirtualMachineGroupSnapshot, member snapshotv1.GroupSnapshotVirtualMachineStatus) *snapshotv1.VirtualMachineSnapshot {
+	return &snapshotv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      member.VirtualMachineSnapshotName,
+			Namespace: vmGroupSnapshot.Namespace,
+			Labels: map[string]string{
+				snapshotv1.VirtualMachineGroupSnapshotLabel: vmGroupSnapshot.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vmGroupSnapshot, snapshotv1.SchemeGroupVersion.WithKind("VirtualMachineGroupSnapshot")),
+			},
+		},
+		Spec: snapshotv1.VirtualMachineSnapshotSpec{
+			Source: corev1.TypedLocalObjectReference{
+				APIGroup: pointer.P(kubevirtv1.SchemeGroupVersion.Group),
+				Kind:     "VirtualMachine",
+				Name:     member.VirtualMachineName,
+			},
+			DeletionPolicy:  vmGroupSnapshot.Spec.DeletionPolicy,
+			FailureDeadline: vmGroupSnapshot.Spec.FailureDeadline,
+		},
+	}
+}
+
+// snapshotGroupVolumes freezes all VMs of the group and creates the
+// VolumeSnapshots of all members at once. It waits until the content of every
+// member exists, at which point all VMs of the group are locked. Each VM is
+// unfrozen by the content of its own VirtualMachineSnapshot once its
+// VolumeSnapshots are created. If they are not created within the freeze
+// deadline, measured from the first VM frozen, the VMs are thawed and the
+// group fails.
+func (ctrl *VMSnapshotController) snapshotGroupVolumes(vmGroupSnapshot *snapshotv1.VirtualMachineGroupSnapshot, vmSnapshots map[string]*snapshotv1.VirtualMachineSnapshot) error {
+	var sources []snapshotSource
+	var sourceMembers []*snapshotv1.GroupSnapshotVirtualMachineStatus
+	var pending []groupVolumeSnapshot
+	var firstFreeze *metav1.Time
+	for i := range vmGroupSnapshot.Status.VirtualMachineSnapshots {
+		member := &vmGroupSnapshot.Status.VirtualMachineSnapshots[i]
+		if member.FreezeTime != nil && (firstFreeze == nil || member.FreezeTime.Before(firstFreeze)) {
+			firstFreeze = member.FreezeTime
+		}
+
+		vmSnapshot := vmSnapshots[member.VirtualMachineName]
+		if vmSnapshot == nil || !vmSnapshotProgressing(vmSnapshot) {
+			return nil
+		}
+
+		content, err := ctrl.getContent(vmSnapshot)
+		if err != nil {
+			return err
+		}
+		if content == nil {
+			log.Log.V(3).Infof("Waiting for the content of VirtualMachineSnapshot %s/%s", vmSnapshot.Namespace, vmSnapshot.Name)
+			return nil
+		}
+		if vmSnapshotContentCreated(content) {
+			continue
+		}
+
+		source, err := ctrl.getSnapshotSource(vmSnapshot)
+		if err != nil {
+			return err
+		}
+		if source == nil {
+			return fmt.Errorf("unable to get snapshot source of VirtualMachineSnapshot %s", vmSnapshot.Name)
+		}
+		sources = append(sources, source)
+		sourceMembers = append(sourceMembers, member)
+
+		for _, volumeBackup := range content.Spec.VolumeBackups {
+			if volumeBackup.VolumeSnapshotName == nil {
+				continue
+			}
+
+			volumeSnapshot, err := ctrl.GetVolumeSnapshot(content.Namespace, *volumeBackup.VolumeSnapshotName)
+			if err != nil {
+				return err
+			}
+			if volumeSnapshot == nil {
+				pending = append(pending, groupVolumeSnapshot{content: content, volumeBackup: volumeBackup})
+			}
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if firstFreeze != nil && currentTime().Time.Sub(firstFreeze.Time) > groupFreezeDeadline {
+		// the group is failed only once every VM is thawed
+		for _, source := range sources {
+			if err := source.Unfreeze(); err != nil {
+				return err
+			}
+		}
+		failVMGroupSnapshot(vmGroupSnapshot.Status, vmGroupSnapshotFreezeDeadlineError)
+		return nil
+	}
+
+	var frozen []snapshotSource
+	for i, source := range sources {
+		isFrozen, err := source.Frozen()
+		if err == nil && !isFrozen {
+			err = source.Freeze()
+		}
+		if err != nil {
+			// nothing was captured yet, so it is safe to thaw the VMs and retry
+			ctrl.unfreezeGroupSources(frozen)
+			return err
+		}
+		frozen = append(frozen, source)
+		if sourceMembers[i].FreezeTime == nil {
+			sourceMembers[i].FreezeTime = currentTime()
+		}
+	}
+
+	// The VMs stay frozen if creating a VolumeSnapshot fails, the retry has to
+	// capture the remaining volumes at the same point in time until the freeze
+	// deadline passed
+	var names []string
+	for _, p := range pending {
+		volumeSnapshot, err := ctrl.createVolumeSnapshot(p.content, p.volumeBackup)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			ctrl.Recorder.Eventf(
+				vmGroupSnapshot,
+				corev1.EventTypeWarning,
+				groupVolumeSnapshotsCreateErrorEvent,
+				"Error creating VolumeSnapshot %s: %v",
+				*p.volumeBackup.VolumeSnapshotName,
+				err,
+			)
+			return err
+		}
+		if volumeSnapshot != nil {
+			names = append(names, volumeSnapshot.Name)
+		}
+	}
+
+	ctrl.Recorder.Eventf(
+		vmGroupSnapshot,
+		corev1.EventTypeNormal,
+		groupVolumeSnapshotsCreateEvent,
+		"Successfully created VolumeSnapshots (%s) of %d frozen VirtualMachines",
+		strings.Join(names, ","),
+		len(frozen),
+	)
+
+	return nil
+}
+
+func (ctrl *VMSnapshotController) unfreezeGroupSources(sources []snapshotSource) {
+	for _, source := range sources {
+		if err := source.Unfreeze(); err != nil {
+			log.Log.Warningf("Failed to unfreeze source %s: %v", source.UID(), err)
+		}
+	}
+}
+
+func updateVMGroupSnapshotStatus(status *snapshotv1.VirtualMachineGroupSnapshotStatus, vmSnapshots map[string]*snapshotv1.VirtualMachineSnapshot) {
+	var failures []string
+	var creationTime *metav1.Time
+	succeeded, ready := true, true
+
+	for i := range status.VirtualMachineSnapshots {
+		member := &status.VirtualMachineSnapshots[i]
+		vmSnapshot := vmSnapshots[member.VirtualMachineName]
+		if vmSnapshot == nil {
+			succeeded, ready = false, false
+			member.ReadyToUse = nil
+			if member.VirtualMachineSnapshotContentName != nil {
+				failures = append(failures, fmt.Sprintf("VirtualMachineSnapshot %s no longer exists", member.VirtualMachineSnapshotName))
+			}
+			continue
+		}
+
+		if vmSnapshot.Status == nil {
+			succeeded, ready = false, false
+			continue
+		}
+
+		if vmSnapshot.Status.VirtualMachineSnapshotContentName != nil {
+			member.VirtualMachineSnapshotContentName = pointer.P(*vmSnapshot.Status.VirtualMachineSnapshotContentName)
+		}
+		member.ReadyToUse = vmSnapshot.Status.ReadyToUse
+		if !VmSnapshotReady(vmSnapshot) {
+			ready = false
+		}
+
+		switch {
+		case vmSnapshotFailed(vmSnapshot):
+			succeeded = false
+			message := "unknown error"
+			if m := vmSnapshotFailureMessage(vmSnapshot); m != nil {
+				message = *m
+			}
+			failures = append(failures, fmt.Sprintf("VirtualMachineSnapshot %s failed: %s", vmSnapshot.Name, message))
+		case vmSnapshotSucceeded(vmSnapshot):
+			if t := vmSnapshot.Status.CreationTime; t != nil && (creationTime == nil || creationTime.Before(t)) {
+				creationTime = t.DeepCopy()
+			}
+		default:
+			succeeded = false
+		}
+	}
+
+	status.ReadyToUse = pointer.P(ready && len(status.VirtualMachineSnapshots) > 0)
+
+	if status.Phase == snapshotv1.InProgress {
+		switch {
+		case len(status.VirtualMachineSnapshots) == 0:
+			failVMGroupSnapshot(status, vmGroupSnapshotNoVMsError)
+		case len(failures) > 0:
+			failVMGroupSnapshot(status, strings.Join(failures, ", "))
+		case succeeded:
+			status.Phase = snapshotv1.Succeeded
+			status.CreationTime = creationTime
+			updateVMGroupSnapshotCondition(status, newProgressingCondition(corev1.ConditionFalse, "Operation complete"))
+		default:
+			updateVMGroupSnapshotCondition(status, newProgressingCondition(corev1.ConditionTrue, "Operation in progress"))
+		}
+	}
+
+	switch {
+	case status.Phase == snapshotv1.Failed:
+		updateVMGroupSnapshotCondition(status, newReadyCondition(corev1.ConditionFalse, "Operation failed"))
+	case *status.ReadyToUse:
+		updateVMGroupSnapshotCondition(status, newReadyCondition(corev1.ConditionTrue, "Ready"))
+	default:
+		updateVMGroupSnapshotCondition(status, newReadyCondition(corev1.ConditionFalse, "Not ready"))
+	}
+}
+
+func failVMGroupSnapshot(status *snapshotv1.VirtualMachineGroupSnapshotStatus, message string) {
+	status.Phase = snapshotv1.Failed
+	status.Error = &snapshotv1.Error{
+		Time:    currentTime(),
+		Message: pointer.P(message),
+	}
+	updateVMGroupSnapshotCondition(status, newProgressingCondition(corev1.ConditionFalse, message))
+	updateVMGroupSnapshotCondition(status, newFailureCondition(corev1.ConditionTrue, message))
+}
+
+func updateVMGroupSnapshotCondition(status *snapshotv1.VirtualMachineGroupSnapshotStatus, c snapshotv1.Condition) {
+	status.Conditions = updateCondition(status.Conditions, c, false)
+}
@@ -57,6 +57,8 @@ const (
 
 	restoreSourceNamespaceLabel = "restore.kubevirt.io/source-vm-namespace"
 
+	restoreNamespaceAnnotation = "restore.kubevirt.io/namespace"
+
 	restoreCompleteEvent = "VirtualMachineRestoreComplete"
 
 	restoreErrorEvent = "VirtualMachineRestoreError"
@@ -90,6 +92,17 @@ var restoreAnnotationsToDelete = []string{
 }
 
 func restorePVCName(vmRestore *snapshotv1.VirtualMachineRestore, name string) string {
+	for _, override := range vmRestore.Spec.VolumeRestoreOverrides {
+		if override.VolumeName == name && override.RestoreName != "" {
+			return override.RestoreName
+		}
+	}
+
+	if vmRestore.Spec.VolumeRestorePolicy != nil &&
+		*vmRestore.Spec.VolumeRestorePolicy == snapshotv1.VolumeRestorePolicyPrefixTargetName {
+		return fmt.Sprintf("%s-%s", vmRestore.Spec.Target.Name, name)
+	}
+
 	return fmt.Sprintf("restore-%s-%s", vmRestore.UID, name)
 }
 
@@ -97,6 +110,31 @@ func restoreDVName(vmRestore *snapshotv1.VirtualMachineRestore, name string) str
 	return restorePVCName(vmRestore, name)
 }
 
+// RestoreTargetNamespace returns the namespace the target of the restore lives in
+func RestoreTargetNamespace(vmRestore *snapshotv1.VirtualMachineRestore) string {
+	if vmRestore.Spec.TargetNamespace != nil && *vmRestore.Spec.TargetNamespace != "" {
+		return *vmRestore.Spec.TargetNamespace
+	}
+	return vmRestore.Namespace
+}
+
+// RestoredVolumeNames returns the names of the PVCs restored from the content keyed by volume name
+func RestoredVolumeNames(vmRestore *snapshotv1.VirtualMachineRestore, content *snapshotv1.VirtualMachineSnapshotContent) map[string]string {
+	names := make(map[string]string)
+	for _, vb := range volumeBackupsForRestore(vmRestore, content) {
+		names[vb.VolumeName] = restorePVCName(vmRestore, vb.VolumeName)
+	}
+	return names
+}
+
+// GetRestoreMode returns the mode of the restore, defaulting to Full
+func GetRestoreMode(vmRestore *snapshotv1.VirtualMachineRestore) snapshotv1.RestoreMode {
+	if vmRestore.Spec.RestoreMode == nil {
+		return snapshotv1.RestoreModeFull
+	}
+	return *vmRestore.Spec.RestoreMode
+}
+
 func VmRestoreProgressing(vmRestore *snapshotv1.VirtualMachineRestore) bool {
 	return vmRestore.Status == nil || vmRestore.Status.Complete == nil || !*vmRestore.Status.Complete
 }
@@ -243,14 +281,8 @@ func (ctrl *VMRestoreController) reconcileVolumeRestores(vmRestore *snapshotv1.V
 		return false, err
 	}
 
-	noRestore := volumesNotForRestore(content)
-
 	var restores []snapshotv1.VolumeRestore
-	for _, vb := range content.Spec.VolumeBackups {
-		if noRestore.Has(vb.VolumeName) {
-			continue
-		}
-
+	for _, vb := range volumeBackupsForRestore(vmRestore, content) {
 		found := false
 		for _, vr := range vmRestore.Status.Restores {
 			if vb.VolumeName == vr.VolumeName {
@@ -286,7 +318,7 @@ func (ctrl *VMRestoreController) reconcileVolumeRestores(vmRestore *snapshotv1.V
 	createdPVC := false
 	waitingPVC := false
 	for _, restore := range restores {
-		pvc, err := ctrl.getPVC(vmRestore.Namespace, restore.PersistentVolumeClaimName)
+		pvc, err := ctrl.getPVC(RestoreTargetNamespace(vmRestore), restore.PersistentVolumeClaimName)
 		if err != nil {
 			return false, err
 		}
@@ -353,6 +385,11 @@ func (t *vmRestoreTarget) UpdateRestoreInProgress() error {
 		return nil
 	}
 
+	// hotplugging the restored volumes leaves the VM running
+	if GetRestoreMode(t.vmRestore) == snapshotv1.RestoreModeHotplug {
+		return nil
+	}
+
 	if t.vm.Status.RestoreInProgress != nil && *t.vm.Status.RestoreInProgress != t.vmRestore.Name {
 		return fmt.Errorf("vm restore %s in progress", *t.vm.Status.RestoreInProgress)
 	}
@@ -371,7 +408,7 @@ func (t *vmRestoreTarget) UpdateRestoreInProgress() error {
 }
 
 func (t *vmRestoreTarget) Ready() (bool, error) {
-	if !t.doesTargetVMExist() {
+	if !t.doesTargetVMExist() || GetRestoreMode(t.vmRestore) == snapshotv1.RestoreModeHotplug {
 		return true, nil
 	}
 
@@ -400,12 +437,71 @@ func (t *vmRestoreTarget) Ready() (bool, error) {
 }
 
 func (t *vmRestoreTarget) Reconcile() (bool, error) {
+	if GetRestoreMode(t.vmRestore) == snapshotv1.RestoreModeHotplug {
+		return t.reconcileHotplugVolumes()
+	}
 	if updated, err := t.reconcileSpec(); updated || err != nil {
 		return updated, err
 	}
 	return t.reconcileDataVolumes()
 }
 
+// reconcileHotplugVolumes attaches the restored PVCs to the target VM as
+// additional hotplug disks, named after the restored PVCs
+func (t *vmRestoreTarget) reconcileHotplugVolumes() (bool, error) {
+	if !t.doesTargetVMExist() {
+		return false, fmt.Errorf("target VM %s/%s does not exist", RestoreTargetNamespace(t.vmRestore), t.vmRestore.Spec.Target.Name)
+	}
+
+	log.Log.Object(t.vmRestore).V(3).Info("Hotplugging restored volumes")
+
+	updated := false
+	for _, vr := range t.vmRestore.Status.Restores {
+		if hasVolumeOrVolumeRequest(t.vm, vr.PersistentVolumeClaimName) {
+			continue
+		}
+
+		addVolumeOptions := &kubevirtv1.AddVolumeOptions{
+			Name: vr.PersistentVolumeClaimName,
+			Disk: &kubevirtv1.Disk{
+				DiskDevice: kubevirtv1.DiskDevice{
+					Disk: &kubevirtv1.DiskTarget{
+						Bus: kubevirtv1.DiskBusSCSI,
+					},
+				},
+			},
+			VolumeSource: &kubevirtv1.HotplugVolumeSource{
+				PersistentVolumeClaim: &kubevirtv1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: vr.PersistentVolumeClaimName,
+					},
+					Hotpluggable: true,
+				},
+			},
+		}
+		if err := t.controller.Client.VirtualMachine(t.vm.Namespace).AddVolume(context.Background(), t.vm.Name, addVolumeOptions); err != nil {
+			return false, err
+		}
+		updated = true
+	}
+
+	return updated, nil
+}
+
+func hasVolumeOrVolumeRequest(vm *kubevirtv1.VirtualMachine, name string) bool {
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	for _, request := range vm.Status.VolumeRequests {
+		if request.AddVolumeOptions != nil && request.AddVolumeOptions.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *vmRestoreTarget) UpdateTarget(obj metav1.Object) {
 	t.vm = obj.(*kubevirtv1.VirtualMachine)
 }
@@ -427,16 +523,26 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 		return false, fmt.Errorf("unexpected snapshot source")
 	}
 
-	var newTemplates = make([]kubevirtv1.DataVolumeTemplateSpec, len(snapshotVM.Spec.DataVolumeTemplates))
+	// the restored volumes replace the volumes of the spec the VM is restored with
+	volumesOnly := GetRestoreMode(t.vmRestore) == snapshotv1.RestoreModeVolumesOnly
+	sourceSpec := &snapshotVM.Spec
+	if volumesOnly {
+		if !t.doesTargetVMExist() {
+			return false, fmt.Errorf("target VM %s/%s does not exist", RestoreTargetNamespace(t.vmRestore), t.vmRestore.Spec.Target.Name)
+		}
+		sourceSpec = &t.vm.Spec
+	}
+
+	var newTemplates = make([]kubevirtv1.DataVolumeTemplateSpec, len(sourceSpec.DataVolumeTemplates))
 	var newVolumes []kubevirtv1.Volume
 	var deletedDataVolumes []string
 	updatedStatus := false
 
-	for i, t := range snapshotVM.Spec.DataVolumeTemplates {
+	for i, t := range sourceSpec.DataVolumeTemplates {
 		t.DeepCopyInto(&newTemplates[i])
 	}
 
-	for _, v := range snapshotVM.Spec.Template.Spec.Volumes {
+	for _, v := range sourceSpec.Template.Spec.Volumes {
 		nv := v.DeepCopy()
 		if nv.DataVolume != nil || nv.PersistentVolumeClaim != nil {
 			for k := range t.vmRestore.Status.Restores {
@@ -445,18 +551,18 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 					continue
 				}
 
-				pvc, err := t.controller.getPVC(t.vmRestore.Namespace, vr.PersistentVolumeClaimName)
+				pvc, err := t.controller.getPVC(RestoreTargetNamespace(t.vmRestore), vr.PersistentVolumeClaimName)
 				if err != nil {
 					return false, err
 				}
 
 				if pvc == nil {
-					return false, fmt.Errorf("pvc %s/%s does not exist and should", t.vmRestore.Namespace, vr.PersistentVolumeClaimName)
+					return false, fmt.Errorf("pvc %s/%s does not exist and should", RestoreTargetNamespace(t.vmRestore), vr.PersistentVolumeClaimName)
 				}
 
 				if nv.DataVolume != nil {
 					templateIndex := -1
-					for i, dvt := range snapshotVM.Spec.DataVolumeTemplates {
+					for i, dvt := range sourceSpec.DataVolumeTemplates {
 						if v.DataVolume.Name == dvt.Name {
 							templateIndex = i
 							break
@@ -485,7 +591,7 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 							updatedStatus = true
 						}
 
-						dv := snapshotVM.Spec.DataVolumeTemplates[templateIndex].DeepCopy()
+						dv := sourceSpec.DataVolumeTemplates[templateIndex].DeepCopy()
 						dv.Name = *vr.DataVolumeName
 						newTemplates[templateIndex] = *dv
 
@@ -507,9 +613,24 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 					nv.PersistentVolumeClaim.ClaimName = vr.PersistentVolumeClaimName
 				}
 			}
-		} else if nv.MemoryDump != nil {
-			// don't restore memory dump volume in the new spec
-			continue
+		} else if nv.MemoryDump != nil && !volumesOnly {
+			if !isMemoryStateVolume(nv) {
+				// don't restore memory dump volume in the new spec
+				continue
+			}
+			restored := false
+			for _, vr := range t.vmRestore.Status.Restores {
+				if vr.VolumeName == nv.Name {
+					// the VM resumes from the saved memory state on its next start
+					nv.MemoryDump.ClaimName = vr.PersistentVolumeClaimName
+					nv.MemoryDump.Hotpluggable = false
+					restored = true
+					break
+				}
+			}
+			if !restored {
+				continue
+			}
 		}
 		newVolumes = append(newVolumes, *nv)
 	}
@@ -538,7 +659,7 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 		newVM = &kubevirtv1.VirtualMachine{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:        t.vmRestore.Spec.Target.Name,
-				Namespace:   t.vmRestore.Namespace,
+				Namespace:   RestoreTargetNamespace(t.vmRestore),
 				Labels:      snapshotVM.Labels,
 				Annotations: snapshotVM.Annotations,
 			},
@@ -548,7 +669,7 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 
 	} else {
 		newVM = t.vm.DeepCopy()
-		newVM.Spec = *snapshotVM.Spec.DeepCopy()
+		newVM.Spec = *sourceSpec.DeepCopy()
 	}
 
 	// update Running state in case snapshot was on online VM
@@ -563,16 +684,21 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 	newVM.Spec.Template.Spec.Volumes = newVolumes
 	setLastRestoreAnnotation(t.vmRestore, newVM)
 
-	if err = t.restoreInstancetypeControllerRevisions(newVM); err != nil {
-		return false, err
+	if !volumesOnly {
+		if err = t.restoreInstancetypeControllerRevisions(newVM); err != nil {
+			return false, err
+		}
 	}
 
 	if !t.doesTargetVMExist() {
+		if isNewVM(newVM, snapshotVM) {
+			regenerateVMIdentity(newVM, t.vmRestore.Spec.NewMacAddresses, t.vmRestore.Spec.NewSMBiosSerial)
+		}
 		newVM, err = patchVM(newVM, t.vmRestore.Spec.Patches)
 		if err != nil {
 			return false, fmt.Errorf("error patching VM %s: %v", newVM.Name, err)
 		}
-		newVM, err = t.controller.Client.VirtualMachine(t.vmRestore.Namespace).Create(context.Background(), newVM, metav1.CreateOptions{})
+		newVM, err = t.controller.Client.VirtualMachine(newVM.Namespace).Create(context.Background(), newVM, metav1.CreateOptions{})
 	} else {
 		newVM, err = t.controller.Client.VirtualMachine(newVM.Namespace).Update(context.Background(), newVM, metav1.UpdateOptions{})
 	}
@@ -581,6 +707,10 @@ func (t *vmRestoreTarget) reconcileSpec() (bool, error) {
 	}
 	t.UpdateTarget(newVM)
 
+	if volumesOnly {
+		return true, nil
+	}
+
 	if err = t.claimInstancetypeControllerRevisionsOwnership(t.vm); err != nil {
 		return false, err
 	}
@@ -625,7 +755,7 @@ func (t *vmRestoreTarget) getControllerRevision(namespace, name string) (*appsv1
 }
 
 func (t *vmRestoreTarget) restoreInstancetypeControllerRevision(vmSnapshotRevisionName, vmSnapshotName string, vm *kubevirtv1.VirtualMachine) (*appsv1.ControllerRevision, error) {
-	snapshotCR, err := t.getControllerRevision(vm.Namespace, vmSnapshotRevisionName)
+	snapshotCR, err := t.getControllerRevision(t.vmRestore.Namespace, vmSnapshotRevisionName)
 	if err != nil {
 		return nil, err
 	}
@@ -743,6 +873,9 @@ func (t *vmRestoreTarget) createDataVolume(dvt kubevirtv1.DataVolumeTemplateSpec
 		newDataVolume.Annotations = make(map[string]string)
 	}
 	newDataVolume.Annotations[RestoreNameAnnotation] = t.vmRestore.Name
+	if t.vm.Namespace != t.vmRestore.Namespace {
+		newDataVolume.Annotations[restoreNamespaceAnnotation] = t.vmRestore.Namespace
+	}
 
 	if _, err = t.controller.Client.CdiClient().CdiV1beta1().DataVolumes(t.vm.Namespace).Create(context.Background(), newDataVolume, metav1.CreateOptions{}); err != nil {
 		t.controller.Recorder.Eventf(t.vm, corev1.EventTypeWarning, restoreDataVolumeCreateErrorEvent, "Error creating restore DataVolume %s: %v", newDataVolume.Name, err)
@@ -766,7 +899,8 @@ func (t *vmRestoreTarget) createDataVolume(dvt kubevirtv1.DataVolumeTemplateSpec
 }
 
 func (t *vmRestoreTarget) Own(obj metav1.Object) {
-	if !t.doesTargetVMExist() {
+	// owner references can't cross namespaces
+	if !t.doesTargetVMExist() || obj.GetNamespace() != t.vm.Namespace {
 		return
 	}
 
@@ -785,14 +919,14 @@ func (t *vmRestoreTarget) Own(obj metav1.Object) {
 
 func (t *vmRestoreTarget) Cleanup() error {
 	for _, dvName := range t.vmRestore.Status.DeletedDataVolumes {
-		objKey := cacheKeyFunc(t.vmRestore.Namespace, dvName)
+		objKey := cacheKeyFunc(RestoreTargetNamespace(t.vmRestore), dvName)
 		_, exists, err := t.controller.DataVolumeInformer.GetStore().GetByKey(objKey)
 		if err != nil {
 			return err
 		}
 
 		if exists {
-			err = t.controller.Client.CdiClient().CdiV1beta1().DataVolumes(t.vmRestore.Namespace).
+			err = t.controller.Client.CdiClient().CdiV1beta1().DataVolumes(RestoreTargetNamespace(t.vmRestore)).
 				Delete(context.Background(), dvName, metav1.DeleteOptions{})
 			if err != nil {
 				return err
@@ -855,6 +989,30 @@ func (ctrl *VMRestoreController) getVM(namespace, name string) (vm *kubevirtv1.V
 	return obj.(*kubevirtv1.VirtualMachine).DeepCopy(), nil
 }
 
+// isNewVM returns true when the restore creates a copy of the snapshotted VM
+// rather than re-creating the snapshotted VM itself
+func isNewVM(vm *kubevirtv1.VirtualMachine, snapshotVM *snapshotv1.VirtualMachine) bool {
+	return vm.Name != snapshotVM.Name || vm.Namespace != snapshotVM.Namespace
+}
+
+// regenerateVMIdentity drops the identifiers a copy of a VM must not share with
+// the original: the MAC addresses, the SMBIOS serial and the firmware UUID
+func regenerateVMIdentity(vm *kubevirtv1.VirtualMachine, newMacAddresses map[string]string, newSMBiosSerial *string) {
+	interfaces := vm.Spec.Template.Spec.Domain.Devices.Interfaces
+	for i := range interfaces {
+		// an empty MAC address gets a new one assigned
+		interfaces[i].MacAddress = newMacAddresses[interfaces[i].Name]
+	}
+
+	if firmware := vm.Spec.Template.Spec.Domain.Firmware; firmware != nil {
+		firmware.Serial = ""
+		if newSMBiosSerial != nil {
+			firmware.Serial = *newSMBiosSerial
+		}
+		firmware.UUID = ""
+	}
+}
+
 func patchVM(vm *kubevirtv1.VirtualMachine, patches []string) (*kubevirtv1.VirtualMachine, error) {
 	if len(patches) == 0 {
 		return vm, nil
@@ -922,7 +1080,7 @@ func (ctrl *VMRestoreController) getTarget(vmRestore *snapshotv1.VirtualMachineR
 	vmRestore.Spec.Target.DeepCopy()
 	switch vmRestore.Spec.Target.Kind {
 	case "VirtualMachine":
-		vm, err := ctrl.getVM(vmRestore.Namespace, vmRestore.Spec.Target.Name)
+		vm, err := ctrl.getVM(RestoreTargetNamespace(vmRestore), vmRestore.Spec.Target.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -967,9 +1125,17 @@ func (ctrl *VMRestoreController) createRestorePVC(
 	if err != nil {
 		return err
 	}
+	pvc.Namespace = RestoreTargetNamespace(vmRestore)
+	if pvc.Namespace != vmRestore.Namespace {
+		// the VolumeSnapshot lives in the namespace of the restore, which
+		// only a DataSourceRef is able to refer to
+		pvc.Spec.DataSource = nil
+		pvc.Spec.DataSourceRef.Namespace = &vmRestore.Namespace
+		pvc.Annotations[restoreNamespaceAnnotation] = vmRestore.Namespace
+	}
 	target.Own(pvc)
 
-	_, err = ctrl.Client.CoreV1().PersistentVolumeClaims(vmRestore.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	_, err = ctrl.Client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
 	if err != nil {
 		return err
 	}
@@ -1066,14 +1232,44 @@ func updateRestoreCondition(r *snapshotv1.VirtualMachineRestore, c snapshotv1.Co
 	r.Status.Conditions = updateCondition(r.Status.Conditions, c, true)
 }
 
+// volumeBackupsForRestore returns the volume backups of the content selected
+// by the volume lists and the mode of the restore
+func volumeBackupsForRestore(vmRestore *snapshotv1.VirtualMachineRestore, content *snapshotv1.VirtualMachineSnapshotContent) []snapshotv1.VolumeBackup {
+	noRestore := volumesNotForRestore(content)
+	if GetRestoreMode(vmRestore) != snapshotv1.RestoreModeFull {
+		// the memory state can only be resumed with the VM spec it was saved with
+		volumes := content.Spec.Source.VirtualMachine.Spec.Template.Spec.Volumes
+		for i := range volumes {
+			if isMemoryStateVolume(&volumes[i]) {
+				noRestore.Insert(volumes[i].Name)
+			}
+		}
+	}
+
+	included := sets.NewString(vmRestore.Spec.IncludedVolumes...)
+	excluded := sets.NewString(vmRestore.Spec.ExcludedVolumes...)
+
+	var backups []snapshotv1.VolumeBackup
+	for _, vb := range content.Spec.VolumeBackups {
+		if noRestore.Has(vb.VolumeName) || excluded.Has(vb.VolumeName) ||
+			(included.Len() > 0 && !included.Has(vb.VolumeName)) {
+			continue
+		}
+		backups = append(backups, vb)
+	}
+
+	return backups
+}
+
 // Returns a set of volumes not for restore
-// Currently only memory dump volumes should not be restored
+// Currently only memory dump volumes should not be restored, except for
+// the memory state saved by the snapshot itself
 func volumesNotForRestore(content *snapshotv1.VirtualMachineSnapshotContent) sets.String {
 	volumes := content.Spec.Source.VirtualMachine.Spec.Template.Spec.Volumes
 	noRestore := sets.NewString()
 
-	for _, volume := range volumes {
-		if volume.MemoryDump != nil {
+	for i, volume := range volumes {
+		if volume.MemoryDump != nil && !isMemoryStateVolume(&volumes[i]) {
 			noRestore.Insert(volume.Name)
 		}
 	}
@@ -1081,6 +1277,16 @@ func volumesNotForRestore(content *snapshotv1.VirtualMachineSnapshotContent) set
 	return noRestore
 }
 
+// isMemoryStateVolume returns true for a memory dump volume holding the
+// memory state saved while taking the snapshot. Memory state volumes left
+// over from an earlier restore are no longer hotpluggable and are not
+// considered part of the snapshot.
+func isMemoryStateVolume(volume *kubevirtv1.Volume) bool {
+	return volume.MemoryDump != nil &&
+		volume.MemoryDump.Type == kubevirtv1.MemoryDumpTypeState &&
+		volume.MemoryDump.Hotpluggable
+}
+
 func getRestoreVolumeBackup(volName string, content *snapshotv1.VirtualMachineSnapshotContent) (*snapshotv1.VolumeBackup, error) {
 	for _, vb := range content.Spec.VolumeBackups {
 		if vb.VolumeName == volName {
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -194,7 +195,7 @@ func (ctrl *VMRestoreController) handleDataVolume(obj interface{}) {
 			return
 		}
 
-		objName := cacheKeyFunc(dv.Namespace, restoreName)
+		objName := restoreKeyFor(dv, restoreName)
 
 		log.Log.V(3).Infof("Handling DV %s/%s, Restore %s", dv.Namespace, dv.Name, objName)
 		ctrl.vmRestoreQueue.Add(objName)
@@ -212,13 +213,23 @@ func (ctrl *VMRestoreController) handlePVC(obj interface{}) {
 			return
 		}
 
-		objName := cacheKeyFunc(pvc.Namespace, restoreName)
+		objName := restoreKeyFor(pvc, restoreName)
 
 		log.Log.V(3).Infof("Handling PVC %s/%s, Restore %s", pvc.Namespace, pvc.Name, objName)
 		ctrl.vmRestoreQueue.Add(objName)
 	}
 }
 
+// restoreKeyFor returns the key of the VirtualMachineRestore which created obj,
+// taking into account restores into another namespace
+func restoreKeyFor(obj metav1.Object, restoreName string) string {
+	namespace := obj.GetNamespace()
+	if restoreNamespace, ok := obj.GetAnnotations()[restoreNamespaceAnnotation]; ok {
+		namespace = restoreNamespace
+	}
+	return cacheKeyFunc(namespace, restoreName)
+}
+
 func (ctrl *VMRestoreController) handleVM(obj interface{}) {
 	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
 		obj = unknown.Obj
@@ -517,6 +517,41 @@ var _ = Describe("Restore controller", func() {
 				controller.processVMRestoreWorkItem()
 			})
 
+			It("should create restore PVCs in the target namespace", func() {
+				const targetNamespace = "other-ns"
+				r := createRestore()
+				r.Spec.TargetNamespace = pointer.P(targetNamespace)
+				addVolumeRestores(r)
+				pvcSize := resource.MustParse("2Gi")
+				vs := createVolumeSnapshot(r.Status.Restores[0].VolumeSnapshotName, pvcSize)
+				fakeVolumeSnapshotProvider.Add(vs)
+				Expect(vmSnapshotInformer.GetStore().Add(s)).To(Succeed())
+				Expect(vmSnapshotContentInformer.GetStore().Add(sc)).To(Succeed())
+
+				pvcCreated := false
+				k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					create := action.(testing.CreateAction)
+					Expect(create.GetNamespace()).To(Equal(targetNamespace))
+
+					pvc := create.GetObject().(*corev1.PersistentVolumeClaim)
+					Expect(pvc.Name).To(Equal(r.Status.Restores[0].PersistentVolumeClaimName))
+					Expect(pvc.OwnerReferences).To(BeEmpty())
+					Expect(pvc.Annotations).To(HaveKeyWithValue(restoreNamespaceAnnotation, testNamespace))
+					Expect(pvc.Spec.DataSource).To(BeNil())
+					Expect(pvc.Spec.DataSourceRef.Name).To(Equal(vs.Name))
+					Expect(pvc.Spec.DataSourceRef.Namespace).To(HaveValue(Equal(testNamespace)))
+					pvcCreated = true
+					return true, pvc, nil
+				})
+
+				target, err := controller.getTarget(r)
+				Expect(err).ToNot(HaveOccurred())
+				updated, err := controller.reconcileVolumeRestores(r, target)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated).To(BeTrue())
+				Expect(pvcCreated).To(BeTrue())
+			})
+
 			It("should create restore PVC with volume snapshot size if bigger then PVC size", func() {
 				r := createRestoreWithOwner()
 				vm := createModifiedVM()
@@ -682,6 +717,73 @@ var _ = Describe("Restore controller", func() {
 				controller.processVMRestoreWorkItem()
 			})
 
+			It("should only replace the restored volumes of the VM spec in VolumesOnly mode", func() {
+				r := createRestoreWithOwner()
+				r.Spec.RestoreMode = pointer.P(snapshotv1.RestoreModeVolumesOnly)
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete:           pointer.P(false),
+					DeletedDataVolumes: getDeletedDataVolumes(createModifiedVM()),
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				addVolumeRestores(r)
+				vm := createModifiedVM()
+				vm.Status.RestoreInProgress = &vmRestoreName
+				updatedVM := createModifiedVM()
+				updatedVM.Status.RestoreInProgress = &vmRestoreName
+				updatedVM.ResourceVersion = "1"
+				updatedVM.Annotations = map[string]string{"restore.kubevirt.io/lastRestoreUID": "restore-uid"}
+				updatedVM.Spec.DataVolumeTemplates[0].Name = "restore-uid-disk1"
+				updatedVM.Spec.Template.Spec.Volumes[0].DataVolume.Name = "restore-uid-disk1"
+				for i := range r.Status.Restores {
+					r.Status.Restores[i].DataVolumeName = &r.Status.Restores[i].PersistentVolumeClaimName
+				}
+				vmSource.Add(vm)
+				vmInterface.EXPECT().Update(context.Background(), updatedVM, metav1.UpdateOptions{}).Return(updatedVM, nil)
+				for _, pvc := range getRestorePVCs(r) {
+					pvc.Annotations["cdi.kubevirt.io/storage.populatedFor"] = pvc.Name
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should hotplug the restored volumes to the VM in Hotplug mode", func() {
+				r := createRestoreWithOwner()
+				r.Spec.RestoreMode = pointer.P(snapshotv1.RestoreModeHotplug)
+				addVolumeRestores(r)
+				vm := createSnapshotVM()
+				Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+
+				vmInterface.EXPECT().AddVolume(context.Background(), vmName, gomock.Any()).DoAndReturn(func(ctx context.Context, name string, options *kubevirtv1.AddVolumeOptions) error {
+					Expect(options.Name).To(Equal("restore-uid-disk1"))
+					Expect(options.Disk.Disk.Bus).To(Equal(kubevirtv1.DiskBusSCSI))
+					Expect(options.VolumeSource.PersistentVolumeClaim.ClaimName).To(Equal("restore-uid-disk1"))
+					Expect(options.VolumeSource.PersistentVolumeClaim.Hotpluggable).To(BeTrue())
+					return nil
+				}).Times(1)
+
+				target, err := controller.getTarget(r)
+				Expect(err).ToNot(HaveOccurred())
+				// the VM keeps running and is not marked as being restored
+				Expect(target.UpdateRestoreInProgress()).To(Succeed())
+				Expect(target.Ready()).To(BeTrue())
+				Expect(target.Reconcile()).To(BeTrue())
+
+				vm.Status.VolumeRequests = []kubevirtv1.VirtualMachineVolumeRequest{
+					{
+						AddVolumeOptions: &kubevirtv1.AddVolumeOptions{
+							Name: "restore-uid-disk1",
+						},
+					},
+				}
+				target.UpdateTarget(vm)
+				Expect(target.Reconcile()).To(BeFalse())
+			})
+
 			It("should cleanup and unlock vm", func() {
 				r := createRestoreWithOwner()
 				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
@@ -972,6 +1074,55 @@ var _ = Describe("Restore controller", func() {
 						Expect(err).ShouldNot(HaveOccurred())
 					})
 
+					It("with new MAC address, SMBIOS serial and firmware UUID", func() {
+						const newSerial = "new-serial"
+						snapshotVM := sc.Spec.Source.VirtualMachine
+						snapshotVM.Spec.Template.Spec.Domain.Firmware = &kubevirtv1.Firmware{
+							Serial: "serial",
+							UUID:   "uuid",
+						}
+						interfaces := snapshotVM.Spec.Template.Spec.Domain.Devices.Interfaces
+						Expect(interfaces).ToNot(BeEmpty())
+						r.Spec.NewMacAddresses = map[string]string{interfaces[0].Name: newMacAddress}
+						r.Spec.NewSMBiosSerial = pointer.P(newSerial)
+
+						vmInterface.EXPECT().Create(context.Background(), gomock.Any(), metav1.CreateOptions{}).DoAndReturn(func(ctx context.Context, newVM *kubevirtv1.VirtualMachine, options metav1.CreateOptions) (*kubevirtv1.VirtualMachine, error) {
+							Expect(newVM.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal(newMacAddress))
+							Expect(newVM.Spec.Template.Spec.Domain.Firmware.Serial).To(Equal(newSerial))
+							Expect(newVM.Spec.Template.Spec.Domain.Firmware.UUID).To(BeEmpty())
+							return newVM, nil
+						}).Times(1)
+
+						targetVM, err := controller.getTarget(r)
+						Expect(err).ShouldNot(HaveOccurred())
+						success, err := targetVM.Reconcile()
+						Expect(success).To(BeTrue())
+						Expect(err).ShouldNot(HaveOccurred())
+					})
+
+					It("in another namespace", func() {
+						const targetNamespace = "other-ns"
+						r.Spec.Target.Name = vmName
+						r.Spec.TargetNamespace = pointer.P(targetNamespace)
+						otherVMInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
+						virtClient.EXPECT().VirtualMachine(targetNamespace).Return(otherVMInterface).AnyTimes()
+
+						otherVMInterface.EXPECT().Create(context.Background(), gomock.Any(), metav1.CreateOptions{}).DoAndReturn(func(ctx context.Context, newVM *kubevirtv1.VirtualMachine, options metav1.CreateOptions) (*kubevirtv1.VirtualMachine, error) {
+							Expect(newVM.Name).To(Equal(vmName))
+							Expect(newVM.Namespace).To(Equal(targetNamespace))
+							for _, iface := range newVM.Spec.Template.Spec.Domain.Devices.Interfaces {
+								Expect(iface.MacAddress).To(BeEmpty())
+							}
+							return newVM, nil
+						}).Times(1)
+
+						targetVM, err := controller.getTarget(r)
+						Expect(err).ShouldNot(HaveOccurred())
+						success, err := targetVM.Reconcile()
+						Expect(success).To(BeTrue())
+						Expect(err).ShouldNot(HaveOccurred())
+					})
+
 					It("with changed name and MAC address", func() {
 						r.Spec.Patches = []string{changeNamePatch, changeMacAddressPatch}
 
@@ -1396,6 +1547,125 @@ var _ = Describe("Restore controller", func() {
 			})
 		})
 	})
+
+	DescribeTable("should name restored volumes", func(policy *snapshotv1.VolumeRestorePolicy, overrides []snapshotv1.VolumeRestoreOverride, expectedName string) {
+		vmRestore := &snapshotv1.VirtualMachineRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				UID: "uid",
+			},
+			Spec: snapshotv1.VirtualMachineRestoreSpec{
+				Target: corev1.TypedLocalObjectReference{
+					Name: "target",
+				},
+				VolumeRestorePolicy:    policy,
+				VolumeRestoreOverrides: overrides,
+			},
+		}
+
+		Expect(restorePVCName(vmRestore, "disk")).To(Equal(expectedName))
+		Expect(restoreDVName(vmRestore, "disk")).To(Equal(expectedName))
+	},
+		Entry("randomized by default", nil, nil, "restore-uid-disk"),
+		Entry("randomized", pointer.P(snapshotv1.VolumeRestorePolicyRandomizeNames), nil, "restore-uid-disk"),
+		Entry("prefixed with the target name", pointer.P(snapshotv1.VolumeRestorePolicyPrefixTargetName), nil, "target-disk"),
+		Entry("overridden", pointer.P(snapshotv1.VolumeRestorePolicyPrefixTargetName), []snapshotv1.VolumeRestoreOverride{
+			{VolumeName: "other", RestoreName: "other-restored"},
+			{VolumeName: "disk", RestoreName: "disk-restored"},
+		}, "disk-restored"),
+	)
+
+	DescribeTable("should select the volumes to restore", func(spec snapshotv1.VirtualMachineRestoreSpec, expectedVolumes []string) {
+		content := &snapshotv1.VirtualMachineSnapshotContent{
+			Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
+				Source: snapshotv1.SourceSpec{
+					VirtualMachine: &snapshotv1.VirtualMachine{
+						Spec: kubevirtv1.VirtualMachineSpec{
+							Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+								Spec: kubevirtv1.VirtualMachineInstanceSpec{
+									Volumes: []kubevirtv1.Volume{
+										{
+											Name: "state",
+											VolumeSource: kubevirtv1.VolumeSource{
+												MemoryDump: &kubevirtv1.MemoryDumpVolumeSource{
+													PersistentVolumeClaimVolumeSource: kubevirtv1.PersistentVolumeClaimVolumeSource{Hotpluggable: true},
+													Type:                              kubevirtv1.MemoryDumpTypeState,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				VolumeBackups: []snapshotv1.VolumeBackup{
+					{VolumeName: "disk1"},
+					{VolumeName: "disk2"},
+					{VolumeName: "state"},
+				},
+			},
+		}
+		vmRestore := &snapshotv1.VirtualMachineRestore{Spec: spec}
+
+		var volumes []string
+		for _, vb := range volumeBackupsForRestore(vmRestore, content) {
+			volumes = append(volumes, vb.VolumeName)
+		}
+		Expect(volumes).To(Equal(expectedVolumes))
+	},
+		Entry("all volumes by default", snapshotv1.VirtualMachineRestoreSpec{}, []string{"disk1", "disk2", "state"}),
+		Entry("included volumes", snapshotv1.VirtualMachineRestoreSpec{
+			IncludedVolumes: []string{"disk2"},
+		}, []string{"disk2"}),
+		Entry("all but excluded volumes", snapshotv1.VirtualMachineRestoreSpec{
+			ExcludedVolumes: []string{"disk1"},
+		}, []string{"disk2", "state"}),
+		Entry("no memory state when only restoring volumes", snapshotv1.VirtualMachineRestoreSpec{
+			RestoreMode: pointer.P(snapshotv1.RestoreModeVolumesOnly),
+		}, []string{"disk1", "disk2"}),
+		Entry("no memory state when hotplugging volumes", snapshotv1.VirtualMachineRestoreSpec{
+			RestoreMode:     pointer.P(snapshotv1.RestoreModeHotplug),
+			IncludedVolumes: []string{"disk1", "state"},
+		}, []string{"disk1"}),
+	)
+
+	DescribeTable("should only restore the memory state saved by the snapshot", func(memoryDump *kubevirtv1.MemoryDumpVolumeSource, restored bool) {
+		content := &snapshotv1.VirtualMachineSnapshotContent{
+			Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
+				Source: snapshotv1.SourceSpec{
+					VirtualMachine: &snapshotv1.VirtualMachine{
+						Spec: kubevirtv1.VirtualMachineSpec{
+							Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+								Spec: kubevirtv1.VirtualMachineInstanceSpec{
+									Volumes: []kubevirtv1.Volume{
+										{
+											Name: "memorydump",
+											VolumeSource: kubevirtv1.VolumeSource{
+												MemoryDump: memoryDump,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		Expect(volumesNotForRestore(content).Has("memorydump")).To(Equal(!restored))
+	},
+		Entry("memory dump", &kubevirtv1.MemoryDumpVolumeSource{
+			PersistentVolumeClaimVolumeSource: kubevirtv1.PersistentVolumeClaimVolumeSource{Hotpluggable: true},
+		}, false),
+		Entry("memory state saved by the snapshot", &kubevirtv1.MemoryDumpVolumeSource{
+			PersistentVolumeClaimVolumeSource: kubevirtv1.PersistentVolumeClaimVolumeSource{Hotpluggable: true},
+			Type:                              kubevirtv1.MemoryDumpTypeState,
+		}, true),
+		Entry("memory state of an earlier restore", &kubevirtv1.MemoryDumpVolumeSource{
+			Type: kubevirtv1.MemoryDumpTypeState,
+		}, false),
+	)
 })
 
 func expectPVCCreates(client *k8sfake.Clientset, vmRestore *snapshotv1.VirtualMachineRestore, expectedSize resource.Quantity) {
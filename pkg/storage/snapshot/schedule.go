@@ -0,0 +1,624 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/util/cron"
+	watchutil "kubevirt.io/kubevirt/pkg/virt-controller/watch/util"
+)
+
+const (
+	scheduledSnapshotCreateEvent = "SuccessfulScheduledVirtualMachineSnapshotCreate"
+
+	scheduledSnapshotCreateErrorEvent = "FailedScheduledVirtualMachineSnapshotCreate"
+
+	scheduledSnapshotDeleteEvent = "SuccessfulScheduledVirtualMachineSnapshotDelete"
+
+	// maxMissedScheduleWindow bounds how far back missed activations are
+	// considered, only the most recent one within the window is taken
+	maxMissedScheduleWindow = 24 * time.Hour
+
+	scheduledSnapshotTimeFormat = "200601021504"
+
+	// scheduleLabelHashLength is the number of hex digits of the hashes in shortened schedule names
+	// and in the names of scheduled snapshots
+	scheduleLabelHashLength = 10
+)
+
+// VMSnapshotScheduleController creates VirtualMachineSnapshots on a schedule and
+// prunes them according to the retention policy. The snapshots themselves are
+// taken by the VMSnapshotController.
+type VMSnapshotScheduleController struct {
+	Client kubecli.KubevirtClient
+
+	VMSnapshotScheduleInformer cache.SharedIndexInformer
+	VMSnapshotInformer         cache.SharedIndexInformer
+	VMInformer                 cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	vmSnapshotScheduleQueue workqueue.RateLimitingInterface
+}
+
+// Init initializes the snapshot schedule controller
+func (ctrl *VMSnapshotScheduleController) Init() error {
+	ctrl.vmSnapshotScheduleQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-vmsnapshotschedule")
+
+	_, err := ctrl.VMSnapshotScheduleInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMSnapshotSchedule,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMSnapshotSchedule(newObj) },
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.VMSnapshotInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleScheduledVMSnapshot,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleScheduledVMSnapshot(newObj) },
+			DeleteFunc: ctrl.handleScheduledVMSnapshot,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run the controller
+func (ctrl *VMSnapshotScheduleController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmSnapshotScheduleQueue.ShutDown()
+
+	log.Log.Info("Starting snapshot schedule controller.")
+	defer log.Log.Info("Shutting down snapshot schedule controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMSnapshotScheduleInformer.HasSynced,
+		ctrl.VMSnapshotInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmSnapshotScheduleWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMSnapshotScheduleController) vmSnapshotScheduleWorker() {
+	for ctrl.processVMSnapshotScheduleWorkItem() {
+	}
+}
+
+func (ctrl *VMSnapshotScheduleController) processVMSnapshotScheduleWorkItem() bool {
+	return watchutil.ProcessWorkItem(ctrl.vmSnapshotScheduleQueue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmSnapshotSchedule worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMSnapshotScheduleInformer.GetStore().GetByKey(key)
+		if !exists || err != nil {
+			return 0, err
+		}
+
+		schedule, ok := storeObj.(*snapshotv1.VirtualMachineSnapshotSchedule)
+		if !ok {
+			return 0, fmt.Errorf(unexpectedResourceFmt, storeObj)
+		}
+
+		return ctrl.updateVMSnapshotSchedule(schedule.DeepCopy())
+	})
+}
+
+func (ctrl *VMSnapshotScheduleController) handleVMSnapshotSchedule(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if schedule, ok := obj.(*snapshotv1.VirtualMachineSnapshotSchedule); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(schedule)
+		if err != nil {
+			log.Log.Errorf(failedKeyFromObjectFmt, err, schedule)
+			return
+		}
+
+		log.Log.V(3).Infof(enqueuedForSyncFmt, objName)
+		ctrl.vmSnapshotScheduleQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMSnapshotScheduleController) handleScheduledVMSnapshot(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmSnapshot, ok := obj.(*snapshotv1.VirtualMachineSnapshot); ok {
+		labelValue, ok := vmSnapshot.Labels[snapshotv1.VirtualMachineSnapshotScheduleLabel]
+		if !ok {
+			return
+		}
+
+		objName, ok := ctrl.scheduleKeyForLabel(vmSnapshot.Namespace, labelValue)
+		if !ok {
+			return
+		}
+
+		log.Log.V(3).Infof("Handling VMSnapshot %s/%s, Schedule %s", vmSnapshot.Namespace, vmSnapshot.Name, objName)
+		ctrl.vmSnapshotScheduleQueue.Add(objName)
+	}
+}
+
+// scheduleKeyForLabel returns the key of the schedule whose snapshots carry the given schedule label value
+func (ctrl *VMSnapshotScheduleController) scheduleKeyForLabel(namespace, labelValue string) (string, bool) {
+	if len(labelValue) < validation.LabelValueMaxLength {
+		return cacheKeyFunc(namespace, labelValue), true
+	}
+	// the name of the schedule may have been shortened
+	for _, obj := range ctrl.VMSnapshotScheduleInformer.GetStore().List() {
+		schedule, ok := obj.(*snapshotv1.VirtualMachineSnapshotSchedule)
+		if ok && schedule.Namespace == namespace && scheduleLabelValue(schedule.Name) == labelValue {
+			return cacheKeyFunc(namespace, schedule.Name), true
+		}
+	}
+	return "", false
+}
+
+func (ctrl *VMSnapshotScheduleController) updateVMSnapshotSchedule(schedule *snapshotv1.VirtualMachineSnapshotSchedule) (time.Duration, error) {
+	log.Log.V(3).Infof("Updating VirtualMachineSnapshotSchedule %s/%s", schedule.Namespace, schedule.Name)
+
+	if schedule.DeletionTimestamp != nil {
+		return 0, nil
+	}
+
+	scheduleOut := schedule.DeepCopy()
+	if scheduleOut.Status == nil {
+		scheduleOut.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{}
+	}
+
+	cronSchedule, err := cron.Parse(schedule.Spec.Schedule)
+	if err != nil {
+		scheduleOut.Status.NextScheduleTime = nil
+		updateScheduleCondition(scheduleOut, newReadyCondition(corev1.ConditionFalse, fmt.Sprintf("Invalid schedule: %v", err)))
+		return 0, ctrl.updateVMSnapshotScheduleStatus(schedule, scheduleOut)
+	}
+
+	now := currentTime().Time.UTC()
+	var retry time.Duration
+
+	if !schedule.Spec.Disabled {
+		if scheduledTime, due := lastMissedScheduleTime(cronSchedule, schedule, now); due {
+			if err := ctrl.createScheduledSnapshots(schedule, scheduledTime); err != nil {
+				updateScheduleCondition(scheduleOut, newFailureCondition(corev1.ConditionTrue, err.Error()))
+				retry = snapshotRetryInterval
+			} else {
+				scheduleOut.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+				updateScheduleCondition(scheduleOut, newFailureCondition(corev1.ConditionFalse, ""))
+			}
+		}
+	}
+
+	vmSnapshots, err := ctrl.getScheduledSnapshots(schedule)
+	if err != nil {
+		return 0, err
+	}
+	updateScheduledVirtualMachineStatuses(scheduleOut.Status, vmSnapshots)
+
+	if err := ctrl.pruneScheduledSnapshots(schedule, vmSnapshots); err != nil {
+		return 0, err
+	}
+
+	if schedule.Spec.Disabled {
+		scheduleOut.Status.NextScheduleTime = nil
+		updateScheduleCondition(scheduleOut, newReadyCondition(corev1.ConditionFalse, "Schedule disabled"))
+		return retry, ctrl.updateVMSnapshotScheduleStatus(schedule, scheduleOut)
+	}
+
+	next := cronSchedule.Next(now)
+	if next.IsZero() {
+		scheduleOut.Status.NextScheduleTime = nil
+		updateScheduleCondition(scheduleOut, newReadyCondition(corev1.ConditionFalse, "Schedule never activates"))
+		return retry, ctrl.updateVMSnapshotScheduleStatus(schedule, scheduleOut)
+	}
+	scheduleOut.Status.NextScheduleTime = &metav1.Time{Time: next}
+	updateScheduleCondition(scheduleOut, newReadyCondition(corev1.ConditionTrue, "Schedule active"))
+
+	if err := ctrl.updateVMSnapshotScheduleStatus(schedule, scheduleOut); err != nil {
+		return 0, err
+	}
+
+	if retry == 0 {
+		retry = next.Sub(now)
+	}
+	return retry, nil
+}
+
+// lastMissedScheduleTime returns the most recent activation of the schedule
+// which has not been run yet
+func lastMissedScheduleTime(cronSchedule *cron.Schedule, schedule *snapshotv1.VirtualMachineSnapshotSchedule, now time.Time) (time.Time, bool) {
+	earliest := schedule.CreationTimestamp.Time.UTC()
+	if schedule.Status != nil && schedule.Status.LastScheduleTime != nil {
+		earliest = schedule.Status.LastScheduleTime.Time.UTC()
+	}
+	if window := now.Add(-maxMissedScheduleWindow); earliest.Before(window) {
+		earliest = window
+	}
+
+	var last time.Time
+	for t := cronSchedule.Next(earliest); !t.IsZero() && !t.After(now); t = cronSchedule.Next(t) {
+		last = t
+	}
+
+	return last, !last.IsZero()
+}
+
+func (ctrl *VMSnapshotScheduleController) createScheduledSnapshots(schedule *snapshotv1.VirtualMachineSnapshotSchedule, scheduledTime time.Time) error {
+	vms, err := ctrl.getScheduledVMs(schedule)
+	if err != nil {
+		return err
+	}
+
+	// a failing VM must not keep the others from being snapshotted
+	var createErrors []error
+	for _, vm := range vms {
+		vmSnapshot := newScheduledSnapshot(schedule, vm, scheduledTime)
+		_, err := ctrl.Client.VirtualMachineSnapshot(schedule.Namespace).Create(context.Background(), vmSnapshot, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			// the run is only done if the snapshot was taken by this schedule
+			err = ctrl.checkScheduledSnapshotOwner(schedule, vmSnapshot)
+			if err == nil {
+				continue
+			}
+		}
+		if err != nil {
+			ctrl.Recorder.Eventf(
+				schedule,
+				corev1.EventTypeWarning,
+				scheduledSnapshotCreateErrorEvent,
+				"Error creating VirtualMachineSnapshot %s: %v",
+				vmSnapshot.Name,
+				err,
+			)
+			createErrors = append(createErrors, fmt.Errorf("failed to create VirtualMachineSnapshot %s: %v", vmSnapshot.Name, err))
+			continue
+		}
+
+		ctrl.Recorder.Eventf(
+			schedule,
+			corev1.EventTypeNormal,
+			scheduledSnapshotCreateEvent,
+			"Successfully created VirtualMachineSnapshot %s",
+			vmSnapshot.Name,
+		)
+	}
+
+	return utilerrors.NewAggregate(createErrors)
+}
+
+// checkScheduledSnapshotOwner returns an error unless the existing snapshot named like vmSnapshot was taken by the
+// schedule for the same VM
+func (ctrl *VMSnapshotScheduleController) checkScheduledSnapshotOwner(schedule *snapshotv1.VirtualMachineSnapshotSchedule, vmSnapshot *snapshotv1.VirtualMachineSnapshot) error {
+	existing, err := ctrl.Client.VirtualMachineSnapshot(schedule.Namespace).Get(context.Background(), vmSnapshot.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if existing.Labels[snapshotv1.VirtualMachineSnapshotScheduleLabel] != vmSnapshot.Labels[snapshotv1.VirtualMachineSnapshotScheduleLabel] ||
+		existing.Spec.Source.Name != vmSnapshot.Spec.Source.Name {
+		return fmt.Errorf("VirtualMachineSnapshot %s already exists and was not taken by the schedule", vmSnapshot.Name)
+	}
+	return nil
+}
+
+func newScheduledSnapshot(schedule *snapshotv1.VirtualMachineSnapshotSchedule, vm *kubevirtv1.VirtualMachine, scheduledTime time.Time) *snapshotv1.VirtualMachineSnapshot {
+	vmSnapshot := &snapshotv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scheduledSnapshotName(schedule.Name, vm.Name, scheduledTime),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				snapshotv1.VirtualMachineSnapshotScheduleLabel: scheduleLabelValue(schedule.Name),
+			},
+		},
+		Spec: snapshotv1.VirtualMachineSnapshotSpec{
+			Source: corev1.TypedLocalObjectReference{
+				APIGroup: pointer.P(kubevirtv1.SchemeGroupVersion.Group),
+				Kind:     "VirtualMachine",
+				Name:     vm.Name,
+			},
+		},
+	}
+
+	if template := schedule.Spec.Template; template != nil {
+		vmSnapshot.Spec.DeletionPolicy = template.DeletionPolicy
+		vmSnapshot.Spec.FailureDeadline = template.FailureDeadline
+	}
+
+	return vmSnapshot
+}
+
+// scheduledSnapshotName returns the name of the snapshot of a VM taken by the schedule at the scheduled time. The
+// names of the schedule and the VM are hashed into the name to keep it unique for every pair of names, and are
+// truncated to keep the name within the maximum length of object names.
+func scheduledSnapshotName(scheduleName, vmName string, scheduledTime time.Time) string {
+	suffix := fmt.Sprintf("%s-%s", hashSuffix(scheduleName+"/"+vmName), scheduledTime.Format(scheduledSnapshotTimeFormat))
+	prefix := fmt.Sprintf("%s-%s", scheduleName, vmName)
+	if maxLength := validation.DNS1123SubdomainMaxLength - len(suffix); len(prefix) > maxLength {
+		prefix = strings.TrimRight(prefix[:maxLength], "-.")
+	}
+	return prefix + suffix
+}
+
+// scheduleLabelValue returns the value of the schedule label of the snapshots taken by the schedule. Names exceeding
+// the maximum length of label values are truncated and suffixed with a hash of the full name to keep them unique.
+func scheduleLabelValue(scheduleName string) string {
+	if len(scheduleName) <= validation.LabelValueMaxLength {
+		return scheduleName
+	}
+	suffix := hashSuffix(scheduleName)
+	return scheduleName[:validation.LabelValueMaxLength-len(suffix)] + suffix
+}
+
+func hashSuffix(value string) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, value)
+	return fmt.Sprintf("-%x", hash.Sum(nil))[:scheduleLabelHashLength+1]
+}
+
+func (ctrl *VMSnapshotScheduleController) getScheduledVMs(schedule *snapshotv1.VirtualMachineSnapshotSchedule) ([]*kubevirtv1.VirtualMachine, error) {
+	selector := labels.Everything()
+	if schedule.Spec.Selector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(schedule.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	objs, err := ctrl.VMInformer.GetIndexer().ByIndex(cache.NamespaceIndex, schedule.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var vms []*kubevirtv1.VirtualMachine
+	for _, obj := range objs {
+		vm, ok := obj.(*kubevirtv1.VirtualMachine)
+		if !ok {
+			return nil, fmt.Errorf(unexpectedResourceFmt, obj)
+		}
+		if vm.DeletionTimestamp == nil && selector.Matches(labels.Set(vm.Labels)) {
+			vms = append(vms, vm)
+		}
+	}
+
+	sort.Slice(vms, func(i, j int) bool { return vms[i].Name < vms[j].Name })
+	return vms, nil
+}
+
+func (ctrl *VMSnapshotScheduleController) getScheduledSnapshots(schedule *snapshotv1.VirtualMachineSnapshotSchedule) ([]*snapshotv1.VirtualMachineSnapshot, error) {
+	objs, err := ctrl.VMSnapshotInformer.GetIndexer().ByIndex("schedule", cacheKeyFunc(schedule.Namespace, scheduleLabelValue(schedule.Name)))
+	if err != nil {
+		return nil, err
+	}
+
+	var vmSnapshots []*snapshotv1.VirtualMachineSnapshot
+	for _, obj := range objs {
+		vmSnapshot, ok := obj.(*snapshotv1.VirtualMachineSnapshot)
+		if !ok {
+			return nil, fmt.Errorf(unexpectedResourceFmt, obj)
+		}
+		vmSnapshots = append(vmSnapshots, vmSnapshot)
+	}
+
+	// newest first
+	sort.Slice(vmSnapshots, func(i, j int) bool {
+		ti, tj := vmSnapshots[i].CreationTimestamp, vmSnapshots[j].CreationTimestamp
+		if ti.Equal(&tj) {
+			return vmSnapshots[i].Name > vmSnapshots[j].Name
+		}
+		return tj.Before(&ti)
+	})
+	return vmSnapshots, nil
+}
+
+// updateScheduledVirtualMachineStatuses records the most recent success and
+// failure per VM. Pruned snapshots do not reset what was recorded before.
+func updateScheduledVirtualMachineStatuses(status *snapshotv1.VirtualMachineSnapshotScheduleStatus, vmSnapshots []*snapshotv1.VirtualMachineSnapshot) {
+	vmStatuses := map[string]*snapshotv1.ScheduledVirtualMachineStatus{}
+	for i := range status.VirtualMachines {
+		vmStatuses[status.VirtualMachines[i].Name] = &status.VirtualMachines[i]
+	}
+
+	getVMStatus := func(name string) *snapshotv1.ScheduledVirtualMachineStatus {
+		if vmStatus, ok := vmStatuses[name]; ok {
+			return vmStatus
+		}
+		status.VirtualMachines = append(status.VirtualMachines, snapshotv1.ScheduledVirtualMachineStatus{Name: name})
+		// the slice may have been reallocated
+		for i := range status.VirtualMachines {
+			vmStatuses[status.VirtualMachines[i].Name] = &status.VirtualMachines[i]
+		}
+		return vmStatuses[name]
+	}
+
+	for _, vmSnapshot := range vmSnapshots {
+		snapshotTime := vmSnapshot.CreationTimestamp
+		switch {
+		case vmSnapshotSucceeded(vmSnapshot):
+			vmStatus := getVMStatus(vmSnapshot.Spec.Source.Name)
+			if vmStatus.LastSuccessTime == nil || vmStatus.LastSuccessTime.Before(&snapshotTime) {
+				vmStatus.LastSuccessfulSnapshot = vmSnapshot.Name
+				vmStatus.LastSuccessTime = snapshotTime.DeepCopy()
+			}
+		case vmSnapshotFailed(vmSnapshot):
+			vmStatus := getVMStatus(vmSnapshot.Spec.Source.Name)
+			if vmStatus.LastFailureTime == nil || vmStatus.LastFailureTime.Before(&snapshotTime) {
+				vmStatus.LastFailedSnapshot = vmSnapshot.Name
+				vmStatus.LastFailureTime = snapshotTime.DeepCopy()
+				vmStatus.LastFailureMessage = vmSnapshotFailureMessage(vmSnapshot)
+			}
+		}
+	}
+
+	sort.Slice(status.VirtualMachines, func(i, j int) bool {
+		return status.VirtualMachines[i].Name < status.VirtualMachines[j].Name
+	})
+}
+
+func vmSnapshotFailureMessage(vmSnapshot *snapshotv1.VirtualMachineSnapshot) *string {
+	if e := vmSnapshotError(vmSnapshot); e != nil && e.Message != nil {
+		return pointer.P(*e.Message)
+	}
+	for _, c := range vmSnapshot.Status.Conditions {
+		if c.Type == snapshotv1.ConditionFailure && c.Status == corev1.ConditionTrue {
+			return pointer.P(c.Reason)
+		}
+	}
+	return nil
+}
+
+func (ctrl *VMSnapshotScheduleController) pruneScheduledSnapshots(schedule *snapshotv1.VirtualMachineSnapshotSchedule, vmSnapshots []*snapshotv1.VirtualMachineSnapshot) error {
+	for _, vmSnapshot := range expiredScheduledSnapshots(schedule.Spec.Retention, vmSnapshots) {
+		err := ctrl.Client.VirtualMachineSnapshot(vmSnapshot.Namespace).Delete(context.Background(), vmSnapshot.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		ctrl.Recorder.Eventf(
+			schedule,
+			corev1.EventTypeNormal,
+			scheduledSnapshotDeleteEvent,
+			"Successfully deleted VirtualMachineSnapshot %s",
+			vmSnapshot.Name,
+		)
+	}
+
+	return nil
+}
+
+// expiredScheduledSnapshots returns the snapshots the retention policy does not
+// keep. The snapshots have to be sorted newest first. Snapshots in progress are
+// always kept, failed ones are removed once a newer snapshot of the VM succeeded.
+func expiredScheduledSnapshots(retention *snapshotv1.VirtualMachineSnapshotRetentionPolicy, vmSnapshots []*snapshotv1.VirtualMachineSnapshot) []*snapshotv1.VirtualMachineSnapshot {
+	if retention == nil || (retention.KeepLast == nil && retention.KeepDaily == nil && retention.KeepWeekly == nil) {
+		return nil
+	}
+
+	type vmRetention struct {
+		succeeded  int32
+		days       map[string]struct{}
+		weeks      map[string]struct{}
+		hasSuccess bool
+	}
+	vms := map[string]*vmRetention{}
+
+	var expired []*snapshotv1.VirtualMachineSnapshot
+	for _, vmSnapshot := range vmSnapshots {
+		if vmSnapshot.DeletionTimestamp != nil {
+			continue
+		}
+
+		vm, ok := vms[vmSnapshot.Spec.Source.Name]
+		if !ok {
+			vm = &vmRetention{days: map[string]struct{}{}, weeks: map[string]struct{}{}}
+			vms[vmSnapshot.Spec.Source.Name] = vm
+		}
+
+		switch {
+		case vmSnapshotFailed(vmSnapshot):
+			if vm.hasSuccess {
+				expired = append(expired, vmSnapshot)
+			}
+			continue
+		case !vmSnapshotSucceeded(vmSnapshot):
+			continue
+		}
+		vm.hasSuccess = true
+
+		snapshotTime := vmSnapshot.CreationTimestamp.Time.UTC()
+		day := snapshotTime.Format("2006-01-02")
+		year, week := snapshotTime.ISOWeek()
+		isoWeek := fmt.Sprintf("%d-%d", year, week)
+
+		keep := false
+		if retention.KeepLast != nil && vm.succeeded < *retention.KeepLast {
+			keep = true
+		}
+		if _, seen := vm.days[day]; !seen && retention.KeepDaily != nil && int32(len(vm.days)) < *retention.KeepDaily {
+			vm.days[day] = struct{}{}
+			keep = true
+		}
+		if _, seen := vm.weeks[isoWeek]; !seen && retention.KeepWeekly != nil && int32(len(vm.weeks)) < *retention.KeepWeekly {
+			vm.weeks[isoWeek] = struct{}{}
+			keep = true
+		}
+
+		vm.succeeded++
+		if !keep {
+			expired = append(expired, vmSnapshot)
+		}
+	}
+
+	return expired
+}
+
+func (ctrl *VMSnapshotScheduleController) updateVMSnapshotScheduleStatus(schedule, scheduleOut *snapshotv1.VirtualMachineSnapshotSchedule) error {
+	if equality.Semantic.DeepEqual(schedule.Status, scheduleOut.Status) {
+		return nil
+	}
+
+	_, err := ctrl.Client.VirtualMachineSnapshotSchedule(scheduleOut.Namespace).Update(context.Background(), scheduleOut, metav1.UpdateOptions{})
+	return err
+}
+
+func updateScheduleCondition(schedule *snapshotv1.VirtualMachineSnapshotSchedule, c snapshotv1.Condition) {
+	schedule.Status.Conditions = updateCondition(schedule.Status.Conditions, c, true)
+}
@@ -0,0 +1,425 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+
+	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Snapshot schedule controller", func() {
+	const scheduleName = "nightly"
+
+	var (
+		now = time.Date(2024, time.March, 10, 2, 30, 0, 0, time.UTC)
+
+		vmSnapshotScheduleInformer cache.SharedIndexInformer
+		vmSnapshotInformer         cache.SharedIndexInformer
+		vmInformer                 cache.SharedIndexInformer
+		kvClient                   *kubevirtfake.Clientset
+		recorder                   *record.FakeRecorder
+		controller                 *VMSnapshotScheduleController
+	)
+
+	at := func(t time.Time) *metav1.Time {
+		return &metav1.Time{Time: t}
+	}
+
+	newSchedule := func() *snapshotv1.VirtualMachineSnapshotSchedule {
+		return &snapshotv1.VirtualMachineSnapshotSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              scheduleName,
+				Namespace:         testNamespace,
+				CreationTimestamp: metav1.Time{Time: now.Add(-30 * time.Hour)},
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotScheduleSpec{
+				Schedule: "0 2 * * *",
+			},
+		}
+	}
+
+	newVM := func(name string, labels map[string]string) *v1.VirtualMachine {
+		vm := createVirtualMachine(testNamespace, name)
+		vm.Labels = labels
+		return vm
+	}
+
+	newScheduledVMSnapshot := func(name, vmName string, created time.Time, phase snapshotv1.VirtualMachineSnapshotPhase) *snapshotv1.VirtualMachineSnapshot {
+		vmSnapshot := createVirtualMachineSnapshot(testNamespace, name, vmName)
+		vmSnapshot.Labels = map[string]string{snapshotv1.VirtualMachineSnapshotScheduleLabel: scheduleName}
+		vmSnapshot.CreationTimestamp = metav1.Time{Time: created}
+		vmSnapshot.Status = &snapshotv1.VirtualMachineSnapshotStatus{Phase: phase}
+		return vmSnapshot
+	}
+
+	createdSnapshots := func() []*snapshotv1.VirtualMachineSnapshot {
+		var vmSnapshots []*snapshotv1.VirtualMachineSnapshot
+		for _, action := range kvClient.Actions() {
+			if create, ok := action.(testing.CreateAction); ok && action.GetResource().Resource == "virtualmachinesnapshots" {
+				vmSnapshots = append(vmSnapshots, create.GetObject().(*snapshotv1.VirtualMachineSnapshot))
+			}
+		}
+		return vmSnapshots
+	}
+
+	deletedSnapshots := func() []string {
+		var names []string
+		for _, action := range kvClient.Actions() {
+			if del, ok := action.(testing.DeleteAction); ok && action.GetResource().Resource == "virtualmachinesnapshots" {
+				names = append(names, del.GetName())
+			}
+		}
+		return names
+	}
+
+	updatedSchedule := func() *snapshotv1.VirtualMachineSnapshotSchedule {
+		var schedule *snapshotv1.VirtualMachineSnapshotSchedule
+		for _, action := range kvClient.Actions() {
+			if update, ok := action.(testing.UpdateAction); ok && action.GetResource().Resource == "virtualmachinesnapshotschedules" {
+				schedule = update.GetObject().(*snapshotv1.VirtualMachineSnapshotSchedule)
+			}
+		}
+		Expect(schedule).ToNot(BeNil())
+		return schedule
+	}
+
+	sync := func(schedule *snapshotv1.VirtualMachineSnapshotSchedule) time.Duration {
+		Expect(vmSnapshotScheduleInformer.GetStore().Add(schedule)).To(Succeed())
+		_, err := kvClient.SnapshotV1beta1().VirtualMachineSnapshotSchedules(testNamespace).Create(context.Background(), schedule, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		kvClient.ClearActions()
+
+		retry, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		return retry
+	}
+
+	BeforeEach(func() {
+		vmSnapshotScheduleInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotSchedule{})
+		vmSnapshotInformer, _ = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshot{}, virtcontroller.GetVirtualMachineSnapshotInformerIndexers())
+		vmInformer, _ = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, virtcontroller.GetVirtualMachineInformerIndexers())
+
+		kvClient = kubevirtfake.NewSimpleClientset()
+		virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient.EXPECT().VirtualMachineSnapshot(testNamespace).
+			Return(kvClient.SnapshotV1beta1().VirtualMachineSnapshots(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineSnapshotSchedule(testNamespace).
+			Return(kvClient.SnapshotV1beta1().VirtualMachineSnapshotSchedules(testNamespace)).AnyTimes()
+
+		recorder = record.NewFakeRecorder(100)
+		controller = &VMSnapshotScheduleController{
+			Client:                     virtClient,
+			VMSnapshotScheduleInformer: vmSnapshotScheduleInformer,
+			VMSnapshotInformer:         vmSnapshotInformer,
+			VMInformer:                 vmInformer,
+			Recorder:                   recorder,
+		}
+		Expect(controller.Init()).To(Succeed())
+
+		currentTime = func() *metav1.Time {
+			return at(now)
+		}
+	})
+
+	Context("when the schedule is due", func() {
+		BeforeEach(func() {
+			Expect(vmInformer.GetStore().Add(newVM("vm-a", map[string]string{"backup": "nightly"}))).To(Succeed())
+			Expect(vmInformer.GetStore().Add(newVM("vm-b", map[string]string{"backup": "nightly"}))).To(Succeed())
+			Expect(vmInformer.GetStore().Add(newVM("vm-c", nil))).To(Succeed())
+		})
+
+		It("should snapshot the selected VMs once for the most recent missed run", func() {
+			schedule := newSchedule()
+			schedule.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"backup": "nightly"}}
+			deletionPolicy := snapshotv1.VirtualMachineSnapshotContentRetain
+			schedule.Spec.Template = &snapshotv1.VirtualMachineSnapshotScheduleTemplate{
+				DeletionPolicy:  &deletionPolicy,
+				FailureDeadline: &metav1.Duration{Duration: 10 * time.Minute},
+			}
+
+			retry := sync(schedule)
+
+			vmSnapshots := createdSnapshots()
+			Expect(vmSnapshots).To(HaveLen(2))
+			scheduledTime := time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC)
+			Expect(vmSnapshots[0].Name).To(Equal(scheduledSnapshotName(scheduleName, "vm-a", scheduledTime)))
+			Expect(vmSnapshots[0].Name).To(MatchRegexp(`^nightly-vm-a-[0-9a-f]{10}-202403100200$`))
+			Expect(vmSnapshots[0].Labels).To(HaveKeyWithValue(snapshotv1.VirtualMachineSnapshotScheduleLabel, scheduleName))
+			Expect(vmSnapshots[0].Spec.Source.Name).To(Equal("vm-a"))
+			Expect(vmSnapshots[0].Spec.DeletionPolicy).To(HaveValue(Equal(snapshotv1.VirtualMachineSnapshotContentRetain)))
+			Expect(vmSnapshots[0].Spec.FailureDeadline.Duration).To(Equal(10 * time.Minute))
+			Expect(vmSnapshots[1].Name).To(Equal(scheduledSnapshotName(scheduleName, "vm-b", scheduledTime)))
+
+			updated := updatedSchedule()
+			Expect(updated.Status.LastScheduleTime.Time).To(Equal(time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC)))
+			Expect(updated.Status.NextScheduleTime.Time).To(Equal(time.Date(2024, time.March, 11, 2, 0, 0, 0, time.UTC)))
+			Expect(retry).To(Equal(23*time.Hour + 30*time.Minute))
+			Expect(recorder.Events).To(Receive(ContainSubstring(scheduledSnapshotCreateEvent)))
+		})
+
+		It("should snapshot all VMs of the namespace without a selector", func() {
+			sync(newSchedule())
+
+			Expect(createdSnapshots()).To(HaveLen(3))
+		})
+
+		It("should tolerate snapshots which already exist", func() {
+			scheduledTime := time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC)
+			for _, vmName := range []string{"vm-a", "vm-b", "vm-c"} {
+				vmSnapshot := newScheduledVMSnapshot(scheduledSnapshotName(scheduleName, vmName, scheduledTime), vmName, now, snapshotv1.InProgress)
+				_, err := kvClient.SnapshotV1beta1().VirtualMachineSnapshots(testNamespace).Create(context.Background(), vmSnapshot, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			retry := sync(newSchedule())
+
+			Expect(retry).To(Equal(23*time.Hour + 30*time.Minute))
+			Expect(updatedSchedule().Status.LastScheduleTime).ToNot(BeNil())
+		})
+
+		It("should fail when a snapshot of another owner has the same name", func() {
+			scheduledTime := time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC)
+			foreign := createVirtualMachineSnapshot(testNamespace, scheduledSnapshotName(scheduleName, "vm-a", scheduledTime), "vm-a")
+			_, err := kvClient.SnapshotV1beta1().VirtualMachineSnapshots(testNamespace).Create(context.Background(), foreign, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			retry := sync(newSchedule())
+
+			Expect(retry).To(Equal(snapshotRetryInterval))
+			updated := updatedSchedule()
+			Expect(updated.Status.LastScheduleTime).To(BeNil())
+			Expect(updated.Status.Conditions).To(ContainElement(And(
+				HaveField("Type", snapshotv1.ConditionFailure),
+				HaveField("Status", corev1.ConditionTrue),
+				HaveField("Reason", ContainSubstring("not taken by the schedule")),
+			)))
+		})
+
+		It("should keep the names of snapshots unique and within the maximum length", func() {
+			scheduledTime := time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC)
+			Expect(scheduledSnapshotName("a-b", "c", scheduledTime)).ToNot(Equal(scheduledSnapshotName("a", "b-c", scheduledTime)))
+
+			longName := scheduledSnapshotName(strings.Repeat("s", 200), strings.Repeat("v", 200), scheduledTime)
+			Expect(longName).To(HaveLen(validation.DNS1123SubdomainMaxLength))
+			Expect(longName).To(HaveSuffix("-202403100200"))
+			Expect(validation.IsDNS1123Subdomain(longName)).To(BeEmpty())
+			Expect(longName).ToNot(Equal(scheduledSnapshotName(strings.Repeat("s", 200), strings.Repeat("v", 201), scheduledTime)))
+		})
+
+		It("should retry and report failures to create snapshots", func() {
+			kvClient.Fake.PrependReactor("create", "virtualmachinesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				return true, nil, errors.NewForbidden(snapshotv1.Resource("virtualmachinesnapshots"), "", nil)
+			})
+
+			retry := sync(newSchedule())
+
+			Expect(retry).To(Equal(snapshotRetryInterval))
+			updated := updatedSchedule()
+			Expect(updated.Status.LastScheduleTime).To(BeNil())
+			Expect(updated.Status.Conditions).To(ContainElement(And(
+				HaveField("Type", snapshotv1.ConditionFailure),
+				HaveField("Status", corev1.ConditionTrue),
+			)))
+			Expect(recorder.Events).To(Receive(ContainSubstring(scheduledSnapshotCreateErrorEvent)))
+		})
+
+		It("should snapshot the other VMs if a snapshot can not be created", func() {
+			kvClient.Fake.PrependReactor("create", "virtualmachinesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				vmSnapshot := action.(testing.CreateAction).GetObject().(*snapshotv1.VirtualMachineSnapshot)
+				if vmSnapshot.Spec.Source.Name == "vm-a" {
+					return true, nil, errors.NewForbidden(snapshotv1.Resource("virtualmachinesnapshots"), vmSnapshot.Name, nil)
+				}
+				return false, nil, nil
+			})
+
+			retry := sync(newSchedule())
+
+			Expect(retry).To(Equal(snapshotRetryInterval))
+			vmSnapshots, err := kvClient.SnapshotV1beta1().VirtualMachineSnapshots(testNamespace).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmSnapshots.Items).To(ConsistOf(
+				HaveField("Spec.Source.Name", "vm-b"),
+				HaveField("Spec.Source.Name", "vm-c"),
+			))
+			Expect(updatedSchedule().Status.Conditions).To(ContainElement(And(
+				HaveField("Type", snapshotv1.ConditionFailure),
+				HaveField("Status", corev1.ConditionTrue),
+				HaveField("Reason", ContainSubstring(scheduledSnapshotName(scheduleName, "vm-a", time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC)))),
+			)))
+		})
+
+		It("should shorten the schedule label of long schedule names", func() {
+			schedule := newSchedule()
+			schedule.Name = strings.Repeat("s", 70)
+
+			sync(schedule)
+
+			vmSnapshots := createdSnapshots()
+			Expect(vmSnapshots).To(HaveLen(3))
+			labelValue := vmSnapshots[0].Labels[snapshotv1.VirtualMachineSnapshotScheduleLabel]
+			Expect(labelValue).To(HaveLen(validation.LabelValueMaxLength))
+			Expect(labelValue).To(HavePrefix(strings.Repeat("s", 52) + "-"))
+			Expect(validation.IsValidLabelValue(labelValue)).To(BeEmpty())
+
+			Expect(vmSnapshotInformer.GetStore().Add(vmSnapshots[0])).To(Succeed())
+			Expect(controller.getScheduledSnapshots(schedule)).To(HaveLen(1))
+			controller.handleScheduledVMSnapshot(vmSnapshots[0])
+			key, _ := controller.vmSnapshotScheduleQueue.Get()
+			Expect(key).To(Equal(testNamespace + "/" + schedule.Name))
+		})
+
+		It("should not snapshot when the schedule is disabled", func() {
+			schedule := newSchedule()
+			schedule.Spec.Disabled = true
+
+			retry := sync(schedule)
+
+			Expect(retry).To(BeZero())
+			Expect(createdSnapshots()).To(BeEmpty())
+			Expect(updatedSchedule().Status.NextScheduleTime).To(BeNil())
+		})
+	})
+
+	It("should not snapshot before the next run", func() {
+		Expect(vmInformer.GetStore().Add(newVM("vm-a", nil))).To(Succeed())
+		schedule := newSchedule()
+		schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+			LastScheduleTime: at(time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC)),
+		}
+
+		retry := sync(schedule)
+
+		Expect(createdSnapshots()).To(BeEmpty())
+		Expect(retry).To(Equal(23*time.Hour + 30*time.Minute))
+	})
+
+	It("should record the last success and failure per VM", func() {
+		failed := newScheduledVMSnapshot("nightly-vm-a-202403100200", "vm-a", now.Add(-30*time.Minute), snapshotv1.Failed)
+		failed.Status.Error = &snapshotv1.Error{Message: pointer.P("snapshot deadline exceeded")}
+		for _, vmSnapshot := range []*snapshotv1.VirtualMachineSnapshot{
+			newScheduledVMSnapshot("nightly-vm-a-202403080200", "vm-a", now.Add(-48*time.Hour-30*time.Minute), snapshotv1.Succeeded),
+			newScheduledVMSnapshot("nightly-vm-a-202403090200", "vm-a", now.Add(-24*time.Hour-30*time.Minute), snapshotv1.Succeeded),
+			failed,
+			newScheduledVMSnapshot("nightly-vm-b-202403100200", "vm-b", now.Add(-30*time.Minute), snapshotv1.InProgress),
+		} {
+			Expect(vmSnapshotInformer.GetStore().Add(vmSnapshot)).To(Succeed())
+		}
+		schedule := newSchedule()
+		schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+			LastScheduleTime: at(time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC)),
+		}
+
+		sync(schedule)
+
+		Expect(updatedSchedule().Status.VirtualMachines).To(Equal([]snapshotv1.ScheduledVirtualMachineStatus{
+			{
+				Name:                   "vm-a",
+				LastSuccessfulSnapshot: "nightly-vm-a-202403090200",
+				LastSuccessTime:        at(now.Add(-24*time.Hour - 30*time.Minute)),
+				LastFailedSnapshot:     "nightly-vm-a-202403100200",
+				LastFailureTime:        at(now.Add(-30 * time.Minute)),
+				LastFailureMessage:     pointer.P("snapshot deadline exceeded"),
+			},
+		}))
+	})
+
+	It("should delete the snapshots the retention policy does not keep", func() {
+		for _, vmSnapshot := range []*snapshotv1.VirtualMachineSnapshot{
+			newScheduledVMSnapshot("nightly-vm-a-1", "vm-a", now.Add(-72*time.Hour), snapshotv1.Succeeded),
+			newScheduledVMSnapshot("nightly-vm-a-2", "vm-a", now.Add(-48*time.Hour), snapshotv1.Succeeded),
+			newScheduledVMSnapshot("nightly-vm-a-3", "vm-a", now.Add(-24*time.Hour), snapshotv1.Succeeded),
+		} {
+			Expect(vmSnapshotInformer.GetStore().Add(vmSnapshot)).To(Succeed())
+		}
+		schedule := newSchedule()
+		schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+			LastScheduleTime: at(time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC)),
+		}
+		schedule.Spec.Retention = &snapshotv1.VirtualMachineSnapshotRetentionPolicy{KeepLast: pointer.P(int32(2))}
+
+		sync(schedule)
+
+		Expect(deletedSnapshots()).To(ConsistOf("nightly-vm-a-1"))
+		Expect(recorder.Events).To(Receive(ContainSubstring(scheduledSnapshotDeleteEvent)))
+	})
+
+	DescribeTable("should expire snapshots", func(retention *snapshotv1.VirtualMachineSnapshotRetentionPolicy, expected ...string) {
+		day := func(n int) time.Time {
+			// 2024-03-01 is a Friday
+			return time.Date(2024, time.March, n, 2, 0, 0, 0, time.UTC)
+		}
+		// newest first, as returned by getScheduledSnapshots
+		vmSnapshots := []*snapshotv1.VirtualMachineSnapshot{
+			newScheduledVMSnapshot("a-18-pm", "vm-a", day(18).Add(12*time.Hour), snapshotv1.InProgress),
+			newScheduledVMSnapshot("a-18", "vm-a", day(18), snapshotv1.Succeeded),
+			newScheduledVMSnapshot("a-17-failed", "vm-a", day(17).Add(12*time.Hour), snapshotv1.Failed),
+			newScheduledVMSnapshot("a-17", "vm-a", day(17), snapshotv1.Succeeded),
+			newScheduledVMSnapshot("b-17", "vm-b", day(17), snapshotv1.Succeeded),
+			newScheduledVMSnapshot("a-16-pm", "vm-a", day(16).Add(12*time.Hour), snapshotv1.Succeeded),
+			newScheduledVMSnapshot("a-16", "vm-a", day(16), snapshotv1.Succeeded),
+			newScheduledVMSnapshot("a-10", "vm-a", day(10), snapshotv1.Succeeded),
+			newScheduledVMSnapshot("a-03", "vm-a", day(3), snapshotv1.Succeeded),
+		}
+
+		var names []string
+		for _, vmSnapshot := range expiredScheduledSnapshots(retention, vmSnapshots) {
+			names = append(names, vmSnapshot.Name)
+		}
+		Expect(names).To(ConsistOf(expected))
+	},
+		Entry("never without a retention policy", nil),
+		Entry("never with an empty retention policy", &snapshotv1.VirtualMachineSnapshotRetentionPolicy{}),
+		Entry("beyond the last N per VM",
+			&snapshotv1.VirtualMachineSnapshotRetentionPolicy{KeepLast: pointer.P(int32(2))},
+			"a-17-failed", "a-16-pm", "a-16", "a-10", "a-03"),
+		Entry("all but the newest of each day",
+			&snapshotv1.VirtualMachineSnapshotRetentionPolicy{KeepDaily: pointer.P(int32(3))},
+			"a-17-failed", "a-16", "a-10", "a-03"),
+		Entry("all but the newest of each week",
+			&snapshotv1.VirtualMachineSnapshotRetentionPolicy{KeepWeekly: pointer.P(int32(2))},
+			"a-17-failed", "a-16-pm", "a-16", "a-10", "a-03"),
+		Entry("not kept by any rule",
+			&snapshotv1.VirtualMachineSnapshotRetentionPolicy{KeepLast: pointer.P(int32(3)), KeepWeekly: pointer.P(int32(2))},
+			"a-17-failed", "a-16", "a-10", "a-03"),
+	)
+})
@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	kubevirtv1 "kubevirt.io/api/core/v1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
@@ -95,6 +96,21 @@ func vmSnapshotProgressing(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
 	return !vmSnapshotFailed(vmSnapshot) && !vmSnapshotSucceeded(vmSnapshot)
 }
 
+// vmSnapshotInGroup returns true if the snapshot is labeled with a group and
+// controlled by that VirtualMachineGroupSnapshot, the label alone is not trusted
+func vmSnapshotInGroup(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
+	group, ok := vmSnapshot.Labels[snapshotv1.VirtualMachineGroupSnapshotLabel]
+	if !ok {
+		return false
+	}
+	owner := metav1.GetControllerOf(vmSnapshot)
+	if owner == nil || owner.Kind != "VirtualMachineGroupSnapshot" || owner.Name != group {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	return err == nil && gv.Group == snapshotv1.SchemeGroupVersion.Group
+}
+
 func deleteContentPolicy(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
 	return vmSnapshot.Spec.DeletionPolicy == nil ||
 		*vmSnapshot.Spec.DeletionPolicy == snapshotv1.VirtualMachineSnapshotContentDelete
@@ -178,12 +194,21 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 				// attempt to lock source
 				// if fails will attempt again when source is updated
 				if !source.Locked() {
-					locked, err := source.Lock()
+					// the memory state is saved before locking the source
+					// as saving it has to attach a volume to the VM
+					saved, err := source.SaveMemoryState()
 					if err != nil {
 						return 0, err
 					}
 
-					log.Log.V(3).Infof("Attempt to lock source returned: %t", locked)
+					if saved {
+						locked, err := source.Lock()
+						if err != nil {
+							return 0, err
+						}
+
+						log.Log.V(3).Infof("Attempt to lock source returned: %t", locked)
+					}
 
 					retry = snapshotRetryInterval
 				} else {
@@ -195,9 +220,17 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 					}
 				}
 			} else if canUnlockSource(vmSnapshot, content) {
-				if _, err := source.Unlock(); err != nil {
+				unlocked, err := source.Unlock()
+				if err != nil {
 					return 0, err
 				}
+
+				// the memory state can be released only once the source is unlocked
+				if !unlocked {
+					if err := source.ReleaseMemoryState(); err != nil {
+						return 0, err
+					}
+				}
 			}
 		}
 	}
@@ -264,7 +297,7 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 	log.Log.V(3).Infof("Updating VirtualMachineSnapshotContent %s/%s", content.Namespace, content.Name)
 
 	var volumeSnapshotStatus []snapshotv1.VolumeSnapshotStatus
-	var deletedSnapshots, skippedSnapshots []string
+	var deletedSnapshots, skippedSnapshots, pendingSnapshots []string
 	var didFreeze bool
 
 	vmSnapshot, err := ctrl.getVMSnapshot(content)
@@ -336,6 +369,14 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 				continue
 			}
 
+			if vmSnapshotInGroup(vmSnapshot) {
+				// the VolumeSnapshots of a group member are created by the group
+				// snapshot once all VMs of the group are frozen
+				log.Log.V(3).Infof("Waiting for group snapshot to create snapshot %s", vsName)
+				pendingSnapshots = append(pendingSnapshots, vsName)
+				continue
+			}
+
 			if !didFreeze {
 				source, err := ctrl.getSnapshotSource(vmSnapshot)
 				if err != nil {
@@ -403,6 +444,8 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 		} else {
 			errorMessage = fmt.Sprintf("VolumeSnapshots (%s) skipped because in error state", strings.Join(skippedSnapshots, ","))
 		}
+	} else if len(pendingSnapshots) > 0 {
+		created, ready = false, false
 	} else {
 		for _, vss := range volumeSnapshotStatus {
 			if vss.CreationTime == nil {
@@ -716,7 +759,7 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 				updateSnapshotCondition(vmSnapshotCpy, newProgressingCondition(corev1.ConditionFalse, "Source not locked"))
 			}
 
-			indications, err := updateVMSnapshotIndications(source)
+			indications, err := updateVMSnapshotIndications(vmSnapshotCpy, source)
 			if err != nil {
 				return err
 			}
@@ -762,7 +805,7 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 	return nil
 }
 
-func updateVMSnapshotIndications(source snapshotSource) ([]snapshotv1.Indication, error) {
+func updateVMSnapshotIndications(vmSnapshot *snapshotv1.VirtualMachineSnapshot, source snapshotSource) ([]snapshotv1.Indication, error) {
 	var indications []snapshotv1.Indication
 	online, err := source.Online()
 	if err != nil {
@@ -782,6 +825,10 @@ func updateVMSnapshotIndications(source snapshotSource) ([]snapshotv1.Indication
 		} else {
 			indications = append(indications, snapshotv1.VMSnapshotNoGuestAgentIndication)
 		}
+
+		if includeMemoryState(vmSnapshot) {
+			indications = append(indications, snapshotv1.VMSnapshotMemoryStateIndication)
+		}
 	}
 	return indications, nil
 }
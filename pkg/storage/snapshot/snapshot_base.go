@@ -71,6 +71,8 @@ type VMSnapshotController struct {
 
 	VMSnapshotInformer        cache.SharedIndexInformer
 	VMSnapshotContentInformer cache.SharedIndexInformer
+	VMGroupSnapshotInformer   cache.SharedIndexInformer
+	VMBackupInformer          cache.SharedIndexInformer
 	VMInformer                cache.SharedIndexInformer
 	VMIInformer               cache.SharedIndexInformer
 	StorageClassInformer      cache.SharedIndexInformer
@@ -86,6 +88,8 @@ type VMSnapshotController struct {
 
 	vmSnapshotQueue        workqueue.RateLimitingInterface
 	vmSnapshotContentQueue workqueue.RateLimitingInterface
+	vmGroupSnapshotQueue   workqueue.RateLimitingInterface
+	vmBackupQueue          workqueue.RateLimitingInterface
 	crdQueue               workqueue.RateLimitingInterface
 	vmSnapshotStatusQueue  workqueue.RateLimitingInterface
 	vmQueue                workqueue.RateLimitingInterface
@@ -102,6 +106,8 @@ var supportedCRDVersions = []string{"v1"}
 func (ctrl *VMSnapshotController) Init() error {
 	ctrl.vmSnapshotQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-vmsnapshot")
 	ctrl.vmSnapshotContentQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-vmsnapshotcontent")
+	ctrl.vmGroupSnapshotQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-vmgroupsnapshot")
+	ctrl.vmBackupQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-vmbackup")
 	ctrl.crdQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-crd")
 	ctrl.vmSnapshotStatusQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-vmsnashotstatus")
 	ctrl.vmQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-vm")
@@ -147,6 +153,28 @@ func (ctrl *VMSnapshotController) Init() error {
 		return err
 	}
 
+	_, err = ctrl.VMGroupSnapshotInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMGroupSnapshot,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMGroupSnapshot(newObj) },
+		},
+		ctrl.ResyncPeriod,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.VMBackupInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMBackup,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMBackup(newObj) },
+		},
+		ctrl.ResyncPeriod,
+	)
+	if err != nil {
+		return err
+	}
+
 	_, err = ctrl.VMInformer.AddEventHandlerWithResyncPeriod(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    ctrl.handleVM,
@@ -216,6 +244,8 @@ func (ctrl *VMSnapshotController) Run(threadiness int, stopCh <-chan struct{}) e
 	defer utilruntime.HandleCrash()
 	defer ctrl.vmSnapshotQueue.ShutDown()
 	defer ctrl.vmSnapshotContentQueue.ShutDown()
+	defer ctrl.vmGroupSnapshotQueue.ShutDown()
+	defer ctrl.vmBackupQueue.ShutDown()
 	defer ctrl.crdQueue.ShutDown()
 	defer ctrl.vmSnapshotStatusQueue.ShutDown()
 	defer ctrl.vmQueue.ShutDown()
@@ -227,6 +257,8 @@ func (ctrl *VMSnapshotController) Run(threadiness int, stopCh <-chan struct{}) e
 		stopCh,
 		ctrl.VMSnapshotInformer.HasSynced,
 		ctrl.VMSnapshotContentInformer.HasSynced,
+		ctrl.VMGroupSnapshotInformer.HasSynced,
+		ctrl.VMBackupInformer.HasSynced,
 		ctrl.VMInformer.HasSynced,
 		ctrl.VMIInformer.HasSynced,
 		ctrl.CRDInformer.HasSynced,
@@ -252,6 +284,8 @@ func (ctrl *VMSnapshotController) Run(threadiness int, stopCh <-chan struct{}) e
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(ctrl.vmSnapshotWorker, time.Second, stopCh)
 		go wait.Until(ctrl.vmSnapshotContentWorker, time.Second, stopCh)
+		go wait.Until(ctrl.vmGroupSnapshotWorker, time.Second, stopCh)
+		go wait.Until(ctrl.vmBackupWorker, time.Second, stopCh)
 		go wait.Until(ctrl.vmSnapshotStatusWorker, time.Second, stopCh)
 		go wait.Until(ctrl.vmWorker, time.Second, stopCh)
 	}
@@ -277,6 +311,16 @@ func (ctrl *VMSnapshotController) vmSnapshotContentWorker() {
 	}
 }
 
+func (ctrl *VMSnapshotController) vmGroupSnapshotWorker() {
+	for ctrl.processVMGroupSnapshotWorkItem() {
+	}
+}
+
+func (ctrl *VMSnapshotController) vmBackupWorker() {
+	for ctrl.processVMBackupWorkItem() {
+	}
+}
+
 func (ctrl *VMSnapshotController) crdWorker() {
 	for ctrl.processCRDWorkItem() {
 	}
@@ -328,6 +372,42 @@ func (ctrl *VMSnapshotController) processVMSnapshotContentWorkItem() bool {
 	})
 }
 
+func (ctrl *VMSnapshotController) processVMGroupSnapshotWorkItem() bool {
+	return watchutil.ProcessWorkItem(ctrl.vmGroupSnapshotQueue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmGroupSnapshot worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMGroupSnapshotInformer.GetStore().GetByKey(key)
+		if !exists || err != nil {
+			return 0, err
+		}
+
+		vmGroupSnapshot, ok := storeObj.(*snapshotv1.VirtualMachineGroupSnapshot)
+		if !ok {
+			return 0, fmt.Errorf(unexpectedResourceFmt, storeObj)
+		}
+
+		return ctrl.updateVMGroupSnapshot(vmGroupSnapshot.DeepCopy())
+	})
+}
+
+func (ctrl *VMSnapshotController) processVMBackupWorkItem() bool {
+	return watchutil.ProcessWorkItem(ctrl.vmBackupQueue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmBackup worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMBackupInformer.GetStore().GetByKey(key)
+		if !exists || err != nil {
+			return 0, err
+		}
+
+		vmBackup, ok := storeObj.(*snapshotv1.VirtualMachineBackup)
+		if !ok {
+			return 0, fmt.Errorf(unexpectedResourceFmt, storeObj)
+		}
+
+		return ctrl.updateVMBackup(vmBackup.DeepCopy())
+	})
+}
+
 func (ctrl *VMSnapshotController) processCRDWorkItem() bool {
 	return watchutil.ProcessWorkItem(ctrl.crdQueue, func(key string) (time.Duration, error) {
 		log.Log.V(3).Infof("CRD worker processing key [%s]", key)
@@ -418,6 +498,44 @@ func (ctrl *VMSnapshotController) handleVMSnapshot(obj interface{}) {
 		}
 		log.Log.V(3).Infof(enqueuedForSyncFmt, objName)
 		ctrl.vmSnapshotQueue.Add(objName)
+
+		if group, ok := vmSnapshot.Labels[snapshotv1.VirtualMachineGroupSnapshotLabel]; ok {
+			k := cacheKeyFunc(vmSnapshot.Namespace, group)
+			log.Log.V(5).Infof("enqueued vmgroupsnapshot %q for sync", k)
+			ctrl.vmGroupSnapshotQueue.Add(k)
+		}
+	}
+}
+
+func (ctrl *VMSnapshotController) handleVMGroupSnapshot(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmGroupSnapshot, ok := obj.(*snapshotv1.VirtualMachineGroupSnapshot); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(vmGroupSnapshot)
+		if err != nil {
+			log.Log.Errorf(failedKeyFromObjectFmt, err, vmGroupSnapshot)
+			return
+		}
+		log.Log.V(3).Infof(enqueuedForSyncFmt, objName)
+		ctrl.vmGroupSnapshotQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMSnapshotController) handleVMBackup(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmBackup, ok := obj.(*snapshotv1.VirtualMachineBackup); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(vmBackup)
+		if err != nil {
+			log.Log.Errorf(failedKeyFromObjectFmt, err, vmBackup)
+			return
+		}
+		log.Log.V(3).Infof(enqueuedForSyncFmt, objName)
+		ctrl.vmBackupQueue.Add(objName)
 	}
 }
 
@@ -461,6 +579,8 @@ func (ctrl *VMSnapshotController) handleVM(obj interface{}) {
 			ctrl.vmSnapshotQueue.Add(k)
 		}
 
+		ctrl.enqueueVMBackups(k)
+
 		key, err := controller.KeyFunc(vm)
 		if err != nil {
 			log.Log.Error("Failed to extract vmKey from VirtualMachine.")
@@ -486,6 +606,21 @@ func (ctrl *VMSnapshotController) handleVMI(obj interface{}) {
 		for _, k := range keys {
 			ctrl.vmSnapshotQueue.Add(k)
 		}
+
+		ctrl.enqueueVMBackups(k)
+	}
+}
+
+// enqueueVMBackups enqueues the VirtualMachineBackups of a VM
+func (ctrl *VMSnapshotController) enqueueVMBackups(vmKey string) {
+	keys, err := ctrl.VMBackupInformer.GetIndexer().IndexKeys("vm", vmKey)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, k := range keys {
+		ctrl.vmBackupQueue.Add(k)
 	}
 }
 
@@ -37,6 +37,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/instancetype"
 	"kubevirt.io/kubevirt/pkg/pointer"
@@ -67,6 +68,7 @@ var _ = Describe("Snapshot controlleer", func() {
 		vmName                  = "testvm"
 		vmRevisionName          = "testvm-revision"
 		vmSnapshotName          = "test-snapshot"
+		vmGroupSnapshotName     = "test-group-snapshot"
 		retain                  = snapshotv1.VirtualMachineSnapshotContentRetain
 		volumeSnapshotClassName = "csi-rbdplugin-snapclass"
 	)
@@ -108,6 +110,40 @@ var _ = Describe("Snapshot controlleer", func() {
 		return vms
 	}
 
+	createVMGroupSnapshotInProgress := func() *snapshotv1.VirtualMachineGroupSnapshot {
+		return &snapshotv1.VirtualMachineGroupSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmGroupSnapshotName,
+				Namespace: testNamespace,
+				UID:       "group-snapshot-uid",
+			},
+			Spec: snapshotv1.VirtualMachineGroupSnapshotSpec{
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"kubevirt.io/vm": "vm-alpine-datavolume"},
+				},
+			},
+			Status: &snapshotv1.VirtualMachineGroupSnapshotStatus{
+				Phase:      snapshotv1.InProgress,
+				ReadyToUse: pointer.P(false),
+				VirtualMachineSnapshots: []snapshotv1.GroupSnapshotVirtualMachineStatus{
+					{
+						VirtualMachineName:         vmName,
+						VirtualMachineSnapshotName: vmSnapshotName,
+					},
+				},
+			},
+		}
+	}
+
+	createVMGroupMemberSnapshot := func(vmGroupSnapshot *snapshotv1.VirtualMachineGroupSnapshot) *snapshotv1.VirtualMachineSnapshot {
+		vms := createVMSnapshotInProgress()
+		vms.Labels = map[string]string{snapshotv1.VirtualMachineGroupSnapshotLabel: vmGroupSnapshot.Name}
+		vms.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(vmGroupSnapshot, snapshotv1.SchemeGroupVersion.WithKind("VirtualMachineGroupSnapshot")),
+		}
+		return vms
+	}
+
 	createVM := func() *v1.VirtualMachine {
 		return createVirtualMachine(testNamespace, vmName)
 	}
@@ -250,6 +286,68 @@ var _ = Describe("Snapshot controlleer", func() {
 		}
 	}
 
+	Context("VirtualMachineGroupSnapshot status", func() {
+		newMember := func(phase snapshotv1.VirtualMachineSnapshotPhase, ready bool) *snapshotv1.VirtualMachineSnapshot {
+			vmSnapshot := createVMSnapshotInProgress()
+			vmSnapshot.Status.Phase = phase
+			vmSnapshot.Status.ReadyToUse = pointer.P(ready)
+			vmSnapshot.Status.VirtualMachineSnapshotContentName = pointer.P("content")
+			if phase == snapshotv1.Succeeded {
+				vmSnapshot.Status.CreationTime = timeFunc()
+			}
+			if phase == snapshotv1.Failed {
+				vmSnapshot.Status.Conditions = []snapshotv1.Condition{
+					newFailureCondition(corev1.ConditionTrue, vmSnapshotDeadlineExceededError),
+				}
+			}
+			return vmSnapshot
+		}
+
+		BeforeEach(func() {
+			currentTime = timeFunc
+		})
+
+		DescribeTable("should aggregate the VirtualMachineSnapshots of the group", func(vmSnapshot *snapshotv1.VirtualMachineSnapshot, contentName *string, expectedPhase snapshotv1.VirtualMachineSnapshotPhase, expectedReady bool, expectedError string) {
+			status := createVMGroupSnapshotInProgress().Status
+			status.VirtualMachineSnapshots[0].VirtualMachineSnapshotContentName = contentName
+			vmSnapshots := map[string]*snapshotv1.VirtualMachineSnapshot{}
+			if vmSnapshot != nil {
+				vmSnapshots[vmName] = vmSnapshot
+			}
+
+			updateVMGroupSnapshotStatus(status, vmSnapshots)
+
+			Expect(status.Phase).To(Equal(expectedPhase))
+			Expect(*status.ReadyToUse).To(Equal(expectedReady))
+			if expectedError == "" {
+				Expect(status.Error).To(BeNil())
+			} else {
+				Expect(*status.Error.Message).To(ContainSubstring(expectedError))
+				Expect(status.Conditions).To(ContainElement(HaveField("Type", snapshotv1.ConditionFailure)))
+			}
+			if expectedPhase == snapshotv1.Succeeded {
+				Expect(status.CreationTime).To(Equal(timeFunc()))
+				Expect(status.VirtualMachineSnapshots[0].VirtualMachineSnapshotContentName).To(Equal(pointer.P("content")))
+			}
+		},
+			Entry("while the member is in progress", newMember(snapshotv1.InProgress, false), nil, snapshotv1.InProgress, false, ""),
+			Entry("once the member succeeded", newMember(snapshotv1.Succeeded, true), nil, snapshotv1.Succeeded, true, ""),
+			Entry("while the member is not created yet", nil, nil, snapshotv1.InProgress, false, ""),
+			Entry("when the member failed", newMember(snapshotv1.Failed, false), nil, snapshotv1.Failed, false, vmSnapshotDeadlineExceededError),
+			Entry("when the member was deleted", nil, pointer.P("content"), snapshotv1.Failed, false, "no longer exists"),
+		)
+
+		It("should fail if no VM matches the selector", func() {
+			status := createVMGroupSnapshotInProgress().Status
+			status.VirtualMachineSnapshots = nil
+
+			updateVMGroupSnapshotStatus(status, nil)
+
+			Expect(status.Phase).To(Equal(snapshotv1.Failed))
+			Expect(*status.Error.Message).To(Equal(vmGroupSnapshotNoVMsError))
+		})
+	})
+
 	Context("One valid Snapshot controller given", func() {
 
 		var ctrl *gomock.Controller
@@ -259,6 +357,10 @@ var _ = Describe("Snapshot controlleer", func() {
 		var vmSnapshotInformer cache.SharedIndexInformer
 		var vmSnapshotContentSource *framework.FakeControllerSource
 		var vmSnapshotContentInformer cache.SharedIndexInformer
+		var vmGroupSnapshotSource *framework.FakeControllerSource
+		var vmGroupSnapshotInformer cache.SharedIndexInformer
+		var vmBackupSource *framework.FakeControllerSource
+		var vmBackupInformer cache.SharedIndexInformer
 		var vmInformer cache.SharedIndexInformer
 		var vmSource *framework.FakeControllerSource
 		var vmiInformer cache.SharedIndexInformer
@@ -284,6 +386,8 @@ var _ = Describe("Snapshot controlleer", func() {
 		var recorder *record.FakeRecorder
 		var mockVMSnapshotQueue *testutils.MockWorkQueue
 		var mockVMSnapshotContentQueue *testutils.MockWorkQueue
+		var mockVMGroupSnapshotQueue *testutils.MockWorkQueue
+		var mockVMBackupQueue *testutils.MockWorkQueue
 		var mockCRDQueue *testutils.MockWorkQueue
 		var mockVMQueue *testutils.MockWorkQueue
 
@@ -295,6 +399,8 @@ var _ = Describe("Snapshot controlleer", func() {
 		syncCaches := func(stop chan struct{}) {
 			go vmSnapshotInformer.Run(stop)
 			go vmSnapshotContentInformer.Run(stop)
+			go vmGroupSnapshotInformer.Run(stop)
+			go vmBackupInformer.Run(stop)
 			go vmInformer.Run(stop)
 			go storageClassInformer.Run(stop)
 			go pvcInformer.Run(stop)
@@ -307,6 +413,8 @@ var _ = Describe("Snapshot controlleer", func() {
 				stop,
 				vmSnapshotInformer.HasSynced,
 				vmSnapshotContentInformer.HasSynced,
+				vmGroupSnapshotInformer.HasSynced,
+				vmBackupInformer.HasSynced,
 				vmInformer.HasSynced,
 				storageClassInformer.HasSynced,
 				pvcInformer.HasSynced,
@@ -327,6 +435,8 @@ var _ = Describe("Snapshot controlleer", func() {
 
 			vmSnapshotInformer, vmSnapshotSource = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshot{}, virtcontroller.GetVirtualMachineSnapshotInformerIndexers())
 			vmSnapshotContentInformer, vmSnapshotContentSource = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshotContent{}, virtcontroller.GetVirtualMachineSnapshotContentInformerIndexers())
+			vmGroupSnapshotInformer, vmGroupSnapshotSource = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineGroupSnapshot{})
+			vmBackupInformer, vmBackupSource = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineBackup{}, virtcontroller.GetVirtualMachineBackupInformerIndexers())
 			crInformer, crSource = testutils.NewFakeInformerWithIndexersFor(&appsv1.ControllerRevision{}, virtcontroller.GetControllerRevisionInformerIndexers())
 			vmInformer, vmSource = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, virtcontroller.GetVirtualMachineInformerIndexers())
 			vmiInformer, vmiSource = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, virtcontroller.GetVMIInformerIndexers())
@@ -345,6 +455,8 @@ var _ = Describe("Snapshot controlleer", func() {
 				Client:                    virtClient,
 				VMSnapshotInformer:        vmSnapshotInformer,
 				VMSnapshotContentInformer: vmSnapshotContentInformer,
+				VMGroupSnapshotInformer:   vmGroupSnapshotInformer,
+				VMBackupInformer:          vmBackupInformer,
 				VMInformer:                vmInformer,
 				VMIInformer:               vmiInformer,
 				PodInformer:               podInformer,
@@ -366,6 +478,12 @@ var _ = Describe("Snapshot controlleer", func() {
 			mockVMSnapshotContentQueue = testutils.NewMockWorkQueue(controller.vmSnapshotContentQueue)
 			controller.vmSnapshotContentQueue = mockVMSnapshotContentQueue
 
+			mockVMGroupSnapshotQueue = testutils.NewMockWorkQueue(controller.vmGroupSnapshotQueue)
+			controller.vmGroupSnapshotQueue = mockVMGroupSnapshotQueue
+
+			mockVMBackupQueue = testutils.NewMockWorkQueue(controller.vmBackupQueue)
+			controller.vmBackupQueue = mockVMBackupQueue
+
 			mockCRDQueue = testutils.NewMockWorkQueue(controller.crdQueue)
 			controller.crdQueue = mockCRDQueue
 
@@ -381,6 +499,10 @@ var _ = Describe("Snapshot controlleer", func() {
 				Return(vmSnapshotClient.SnapshotV1beta1().VirtualMachineSnapshots(testNamespace)).AnyTimes()
 			virtClient.EXPECT().VirtualMachineSnapshotContent(testNamespace).
 				Return(vmSnapshotClient.SnapshotV1beta1().VirtualMachineSnapshotContents(testNamespace)).AnyTimes()
+			virtClient.EXPECT().VirtualMachineGroupSnapshot(testNamespace).
+				Return(vmSnapshotClient.SnapshotV1beta1().VirtualMachineGroupSnapshots(testNamespace)).AnyTimes()
+			virtClient.EXPECT().VirtualMachineBackup(testNamespace).
+				Return(vmSnapshotClient.SnapshotV1beta1().VirtualMachineBackups(testNamespace)).AnyTimes()
 
 			k8sSnapshotClient = k8ssnapshotfake.NewSimpleClientset()
 			virtClient.EXPECT().KubernetesSnapshotClient().Return(k8sSnapshotClient).AnyTimes()
@@ -419,6 +541,20 @@ var _ = Describe("Snapshot controlleer", func() {
 			mockVMSnapshotContentQueue.Wait()
 		}
 
+		addVirtualMachineGroupSnapshot := func(s *snapshotv1.VirtualMachineGroupSnapshot) {
+			syncCaches(stop)
+			mockVMGroupSnapshotQueue.ExpectAdds(1)
+			vmGroupSnapshotSource.Add(s)
+			mockVMGroupSnapshotQueue.Wait()
+		}
+
+		addVirtualMachineBackup := func(b *snapshotv1.VirtualMachineBackup) {
+			syncCaches(stop)
+			mockVMBackupQueue.ExpectAdds(1)
+			vmBackupSource.Add(b)
+			mockVMBackupQueue.Wait()
+		}
+
 		addVM := func(vm *v1.VirtualMachine) {
 			syncCaches(stop)
 			mockVMSnapshotQueue.ExpectAdds(1)
@@ -449,6 +585,275 @@ var _ = Describe("Snapshot controlleer", func() {
 			}
 		}
 
+		Context("VirtualMachineBackup", func() {
+			const (
+				vmBackupName   = "test-backup"
+				vmBackupUID    = "backup-uid"
+				vmBackupPVC    = "backup-pvc"
+				vmiUID         = types.UID("vmi-uid")
+				backupDir      = "testvm-backup-pvc-20260101-000000.backup"
+				checkpointName = vmBackupName + "-" + vmBackupUID
+			)
+
+			createVMBackup := func() *snapshotv1.VirtualMachineBackup {
+				return &snapshotv1.VirtualMachineBackup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              vmBackupName,
+						Namespace:         testNamespace,
+						UID:               vmBackupUID,
+						CreationTimestamp: timeStamp,
+					},
+					Spec: snapshotv1.VirtualMachineBackupSpec{
+						Source: corev1.TypedLocalObjectReference{
+							APIGroup: pointer.P(v1.SchemeGroupVersion.Group),
+							Kind:     "VirtualMachine",
+							Name:     vmName,
+						},
+						PersistentVolumeClaimName: vmBackupPVC,
+					},
+				}
+			}
+
+			createVMBackupInProgress := func() *snapshotv1.VirtualMachineBackup {
+				vmBackup := createVMBackup()
+				vmBackup.Status = &snapshotv1.VirtualMachineBackupStatus{
+					VirtualMachineInstanceUID: pointer.P(vmiUID),
+					Phase:                     snapshotv1.InProgress,
+					Type:                      snapshotv1.BackupTypeFull,
+					Checkpoint:                checkpointName,
+					CheckpointChain:           []string{checkpointName},
+					StartTimestamp:            timeFunc(),
+				}
+				return vmBackup
+			}
+
+			createRunningVMI := func(vm *v1.VirtualMachine) *v1.VirtualMachineInstance {
+				vmi := createVMI(vm)
+				vmi.UID = vmiUID
+				vmi.Status.Phase = v1.Running
+				return vmi
+			}
+
+			addBackupPVC := func() {
+				pvcSource.Add(&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      vmBackupPVC,
+					},
+				})
+			}
+
+			expectVMBackupUpdate := func(check func(*snapshotv1.VirtualMachineBackup)) *bool {
+				updated := false
+				vmSnapshotClient.Fake.PrependReactor("update", "virtualmachinebackups", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					updateObj := action.(testing.UpdateAction).GetObject().(*snapshotv1.VirtualMachineBackup)
+					check(updateObj)
+					updated = true
+					return true, updateObj, nil
+				})
+				return &updated
+			}
+
+			It("should take a full backup if the VM has no previous backup", func() {
+				vm := createVM()
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm))
+				addBackupPVC()
+
+				updated := expectVMBackupUpdate(func(vmBackup *snapshotv1.VirtualMachineBackup) {
+					Expect(vmBackup.Status.Phase).To(Equal(snapshotv1.InProgress))
+					Expect(vmBackup.Status.Type).To(Equal(snapshotv1.BackupTypeFull))
+					Expect(vmBackup.Status.Checkpoint).To(Equal(checkpointName))
+					Expect(vmBackup.Status.IncrementalFrom).To(BeNil())
+					Expect(vmBackup.Status.CheckpointChain).To(Equal([]string{checkpointName}))
+					Expect(vmBackup.Status.VirtualMachineInstanceUID).To(HaveValue(Equal(vmiUID)))
+				})
+				addVirtualMachineBackup(createVMBackup())
+				controller.processVMBackupWorkItem()
+				Expect(*updated).To(BeTrue())
+			})
+
+			It("should take an incremental backup on top of the latest backup of the same VMI", func() {
+				vm := createVM()
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm))
+				addBackupPVC()
+
+				for i, vmi := range []types.UID{vmiUID, vmiUID, "other-vmi-uid"} {
+					previous := createVMBackupInProgress()
+					previous.Name = fmt.Sprintf("previous-%d", i)
+					previous.UID = types.UID(previous.Name)
+					previous.Status.VirtualMachineInstanceUID = pointer.P(vmi)
+					previous.Status.Phase = snapshotv1.Succeeded
+					previous.Status.Checkpoint = previous.Name
+					previous.Status.CheckpointChain = []string{"full", previous.Name}
+					previous.Status.EndTimestamp = &metav1.Time{Time: timeStamp.Add(time.Duration(i) * time.Minute)}
+					addVirtualMachineBackup(previous)
+				}
+
+				updated := expectVMBackupUpdate(func(vmBackup *snapshotv1.VirtualMachineBackup) {
+					Expect(vmBackup.Status.Type).To(Equal(snapshotv1.BackupTypeIncremental))
+					Expect(vmBackup.Status.IncrementalFrom).To(HaveValue(Equal("previous-1")))
+					Expect(vmBackup.Status.CheckpointChain).To(Equal([]string{"full", "previous-1", checkpointName}))
+				})
+				addVirtualMachineBackup(createVMBackup())
+				for controller.vmBackupQueue.Len() > 0 {
+					controller.processVMBackupWorkItem()
+				}
+				Expect(*updated).To(BeTrue())
+			})
+
+			createBackupVolume := func(checkpoint string) v1.Volume {
+				return v1.Volume{
+					Name: vmBackupPVC,
+					VolumeSource: v1.VolumeSource{
+						Backup: &v1.BackupVolumeSource{
+							PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: vmBackupPVC,
+								},
+								Hotpluggable: true,
+							},
+							Checkpoint: checkpoint,
+						},
+					},
+				}
+			}
+
+			createBackingUpVMI := func(vm *v1.VirtualMachine, phase v1.VolumePhase) *v1.VirtualMachineInstance {
+				vmi := createRunningVMI(vm)
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, createBackupVolume(checkpointName))
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+					Name:    vmBackupPVC,
+					Phase:   phase,
+					Message: "backup job failed",
+					BackupVolume: &v1.DomainBackupInfo{
+						TargetDirectory: backupDir,
+						EndTimestamp:    timeFunc(),
+					},
+				})
+				return vmi
+			}
+
+			expectVMIVolumesPatch := func(vmi *v1.VirtualMachineInstance, volumes []v1.Volume) {
+				vmiInterface.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, gomock.Any(), metav1.PatchOptions{}).
+					DoAndReturn(func(_ context.Context, _ string, _ types.PatchType, patchBytes []byte, _ metav1.PatchOptions, _ ...string) (*v1.VirtualMachineInstance, error) {
+						expectedPatch, err := patch.New(
+							patch.WithTest("/spec/volumes", vmi.Spec.Volumes),
+							patch.WithReplace("/spec/volumes", volumes),
+						).GeneratePayload()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(patchBytes).To(MatchJSON(expectedPatch))
+						return vmi, nil
+					})
+			}
+
+			It("should wait for another backup volume to be removed from the VMI", func() {
+				vm := createVM()
+				vmi := createRunningVMI(vm)
+				otherVolume := createBackupVolume("other-checkpoint")
+				otherVolume.Name = "other-pvc"
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, otherVolume)
+				vmSource.Add(vm)
+				vmiSource.Add(vmi)
+				addBackupPVC()
+
+				updated := expectVMBackupUpdate(func(vmBackup *snapshotv1.VirtualMachineBackup) {
+					Expect(vmBackup.Status.Checkpoint).To(BeEmpty())
+					Expect(vmBackup.Status.Conditions).To(ContainElement(newProgressingCondition(corev1.ConditionFalse, vmBackupWaitingForVolume)))
+				})
+				addVirtualMachineBackup(createVMBackup())
+				controller.processVMBackupWorkItem()
+				Expect(*updated).To(BeTrue())
+			})
+
+			It("should add the pvc as a backup volume to the VMI", func() {
+				vm := createVM()
+				vmi := createRunningVMI(vm)
+				vmSource.Add(vm)
+				vmiSource.Add(vmi)
+
+				expectVMIVolumesPatch(vmi, append(append([]v1.Volume{}, vmi.Spec.Volumes...), createBackupVolume(checkpointName)))
+				addVirtualMachineBackup(createVMBackupInProgress())
+				controller.processVMBackupWorkItem()
+			})
+
+			It("should record the backup directory and remove the backup volume from the VMI once the backup completed", func() {
+				vm := createVM()
+				vmi := createBackingUpVMI(vm, v1.BackupVolumeCompleted)
+				vmSource.Add(vm)
+				vmiSource.Add(vmi)
+				addBackupPVC()
+
+				virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+				annotated := false
+				k8sClient.Fake.PrependReactor("update", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					pvc := action.(testing.UpdateAction).GetObject().(*corev1.PersistentVolumeClaim)
+					Expect(pvc.Annotations).To(HaveKeyWithValue(v1.PVCBackupAnnotation, backupDir))
+					annotated = true
+					return true, pvc, nil
+				})
+				expectVMIVolumesPatch(vmi, vmi.Spec.Volumes[:len(vmi.Spec.Volumes)-1])
+				updated := expectVMBackupUpdate(func(vmBackup *snapshotv1.VirtualMachineBackup) {
+					Expect(vmBackup.Status.Phase).To(Equal(snapshotv1.InProgress))
+					Expect(vmBackup.Status.BackupDirectory).To(HaveValue(Equal(backupDir)))
+					Expect(vmBackup.Status.EndTimestamp).To(Equal(timeFunc()))
+				})
+				addVirtualMachineBackup(createVMBackupInProgress())
+				controller.processVMBackupWorkItem()
+				Expect(annotated).To(BeTrue())
+				Expect(*updated).To(BeTrue())
+			})
+
+			It("should succeed once the backup volume is removed from the VMI", func() {
+				vm := createVM()
+				vmSource.Add(vm)
+				vmiSource.Add(createRunningVMI(vm))
+
+				vmBackup := createVMBackupInProgress()
+				vmBackup.Status.BackupDirectory = pointer.P(backupDir)
+				updated := expectVMBackupUpdate(func(vmBackup *snapshotv1.VirtualMachineBackup) {
+					Expect(vmBackup.Status.Phase).To(Equal(snapshotv1.Succeeded))
+					Expect(vmBackup.Status.Conditions).To(ContainElement(newReadyCondition(corev1.ConditionTrue, "Ready")))
+				})
+				addVirtualMachineBackup(vmBackup)
+				controller.processVMBackupWorkItem()
+				Expect(*updated).To(BeTrue())
+			})
+
+			It("should fail and remove the backup volume from the VMI if the backup failed", func() {
+				vm := createVM()
+				vmi := createBackingUpVMI(vm, v1.BackupVolumeFailed)
+				vmSource.Add(vm)
+				vmiSource.Add(vmi)
+
+				expectVMIVolumesPatch(vmi, vmi.Spec.Volumes[:len(vmi.Spec.Volumes)-1])
+				updated := expectVMBackupUpdate(func(vmBackup *snapshotv1.VirtualMachineBackup) {
+					Expect(vmBackup.Status.Phase).To(Equal(snapshotv1.Failed))
+					Expect(vmBackup.Status.Error.Message).To(HaveValue(Equal("backup job failed")))
+				})
+				addVirtualMachineBackup(createVMBackupInProgress())
+				controller.processVMBackupWorkItem()
+				Expect(*updated).To(BeTrue())
+			})
+
+			It("should fail if the VMI was restarted during the backup", func() {
+				vm := createVM()
+				vmi := createRunningVMI(vm)
+				vmi.UID = "restarted-vmi-uid"
+				vmSource.Add(vm)
+				vmiSource.Add(vmi)
+
+				updated := expectVMBackupUpdate(func(vmBackup *snapshotv1.VirtualMachineBackup) {
+					Expect(vmBackup.Status.Phase).To(Equal(snapshotv1.Failed))
+					Expect(vmBackup.Status.Error.Message).To(HaveValue(Equal(vmBackupVMIRestartedError)))
+				})
+				addVirtualMachineBackup(createVMBackupInProgress())
+				controller.processVMBackupWorkItem()
+				Expect(*updated).To(BeTrue())
+			})
+		})
+
 		Context("with VolumeSnapshot and VolumeSnapshotContent informers", func() {
 
 			BeforeEach(func() {
@@ -851,6 +1256,147 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotWorkItem()
 			})
 
+			Context("with memory state", func() {
+				memoryStateIndications := []snapshotv1.Indication{
+					snapshotv1.VMSnapshotOnlineSnapshotIndication,
+					snapshotv1.VMSnapshotNoGuestAgentIndication,
+					snapshotv1.VMSnapshotMemoryStateIndication,
+				}
+
+				createMemoryStateVMSnapshot := func() *snapshotv1.VirtualMachineSnapshot {
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.IncludeMemoryState = pointer.P(true)
+					return vmSnapshot
+				}
+
+				createPausedVMI := func(vm *v1.VirtualMachine) *v1.VirtualMachineInstance {
+					vmi := createVMI(vm)
+					vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+						{
+							Type:   v1.VirtualMachineInstancePaused,
+							Status: corev1.ConditionTrue,
+						},
+					}
+					return vmi
+				}
+
+				expectSourceNotLocked := func(vmSnapshot *snapshotv1.VirtualMachineSnapshot) {
+					updatedSnapshot := vmSnapshot.DeepCopy()
+					updatedSnapshot.ResourceVersion = "1"
+					updatedSnapshot.Status.Conditions = []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionFalse, "Source not locked"),
+						newReadyCondition(corev1.ConditionFalse, "Not ready"),
+					}
+					updatedSnapshot.Status.Indications = memoryStateIndications
+					expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+				}
+
+				It("should create the memory state PVC and pause the VMI", func() {
+					vmSnapshot := createMemoryStateVMSnapshot()
+					vm := createVM()
+					vmiSource.Add(createVMI(vm))
+					vmSource.Add(vm)
+					for _, pvc := range createPVCsForVM(vm) {
+						pvcSource.Add(pvc.DeepCopy())
+					}
+
+					virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+					pvcCreated := false
+					k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+						pvc := action.(testing.CreateAction).GetObject().(*corev1.PersistentVolumeClaim)
+						Expect(pvc.Name).To(Equal(vmSnapshotName + "-memory-state"))
+						Expect(pvc.Spec.StorageClassName).To(HaveValue(Equal(storageClassName)))
+						Expect(pvc.OwnerReferences).To(HaveLen(1))
+						pvcCreated = true
+						return true, pvc, nil
+					})
+					vmiInterface.EXPECT().Pause(context.Background(), vm.Name, &v1.PauseOptions{}).Return(nil)
+					expectSourceNotLocked(vmSnapshot)
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(pvcCreated).To(BeTrue())
+				})
+
+				It("should save the memory state of the paused VMI", func() {
+					vmSnapshot := createMemoryStateVMSnapshot()
+					vm := createVM()
+					vmiSource.Add(createPausedVMI(vm))
+					vmSource.Add(vm)
+					pvcSource.Add(&corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: testNamespace,
+							Name:      vmSnapshotName + "-memory-state",
+						},
+					})
+
+					vmInterface.EXPECT().MemoryDump(context.Background(), vm.Name, &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: vmSnapshotName + "-memory-state",
+						Type:      v1.MemoryDumpTypeState,
+					}).Return(nil)
+					expectSourceNotLocked(vmSnapshot)
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+				})
+
+				It("should not lock source while the memory state is being saved", func() {
+					vmSnapshot := createMemoryStateVMSnapshot()
+					vm := createVM()
+					vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: vmSnapshotName + "-memory-state",
+						Type:      v1.MemoryDumpTypeState,
+						Phase:     v1.MemoryDumpInProgress,
+					}
+					vmiSource.Add(createPausedVMI(vm))
+					vmSource.Add(vm)
+					expectSourceNotLocked(vmSnapshot)
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+				})
+
+				It("should lock source once the memory state is saved", func() {
+					vmSnapshot := createMemoryStateVMSnapshot()
+					vm := createVM()
+					vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: vmSnapshotName + "-memory-state",
+						Type:      v1.MemoryDumpTypeState,
+						Phase:     v1.MemoryDumpCompleted,
+					}
+					vmiSource.Add(createPausedVMI(vm))
+					vmSource.Add(vm)
+
+					vmUpdate := vm.DeepCopy()
+					vmUpdate.ResourceVersion = "1"
+					vmUpdate.Status.SnapshotInProgress = &vmSnapshotName
+					vmInterface.EXPECT().UpdateStatus(context.Background(), vmUpdate, metav1.UpdateOptions{}).Return(vmUpdate, nil).Times(1)
+					expectSourceNotLocked(vmSnapshot)
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+				})
+
+				It("should resume the VMI and remove the memory state once source unlocked", func() {
+					vmSnapshot := createVMSnapshotSuccess()
+					vmSnapshot.Spec.IncludeMemoryState = pointer.P(true)
+					vm := createVM()
+					vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: vmSnapshotName + "-memory-state",
+						Type:      v1.MemoryDumpTypeState,
+						Phase:     v1.MemoryDumpCompleted,
+					}
+					vmiSource.Add(createPausedVMI(vm))
+					vmSource.Add(vm)
+
+					vmiInterface.EXPECT().Unpause(context.Background(), vm.Name, &v1.UnpauseOptions{}).Return(nil)
+					vmInterface.EXPECT().RemoveMemoryDump(context.Background(), vm.Name).Return(nil)
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+				})
+			})
+
 			It("should not lock source if pods using PVCs", func() {
 				vmSnapshot := createVMSnapshotInProgress()
 				vm := createVM()
@@ -1405,6 +1951,192 @@ var _ = Describe("Snapshot controlleer", func() {
 				testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
 			})
 
+			It("should leave the VolumeSnapshots of a VirtualMachineGroupSnapshot member to the group", func() {
+				vm := createLockedVM()
+				vmSnapshot := createVMGroupMemberSnapshot(createVMGroupSnapshotInProgress())
+				vmSnapshotContent := createVMSnapshotContent()
+
+				updatedContent := vmSnapshotContent.DeepCopy()
+				updatedContent.ResourceVersion = "1"
+				updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse: pointer.P(false),
+				}
+
+				vmSource.Add(vm)
+				vmSnapshotSource.Add(vmSnapshot)
+				expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+				addVirtualMachineSnapshotContent(vmSnapshotContent)
+				controller.processVMSnapshotContentWorkItem()
+			})
+
+			It("should not treat a VirtualMachineSnapshot only labeled with a group as a member", func() {
+				storageClass := createStorageClass()
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Labels = map[string]string{snapshotv1.VirtualMachineGroupSnapshotLabel: vmGroupSnapshotName}
+				volumeSnapshotClass := createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vm := createLockedVM()
+				vmSource.Add(vm)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+
+				updatedContent := vmSnapshotContent.DeepCopy()
+				updatedContent.ResourceVersion = "1"
+				updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse: pointer.P(false),
+				}
+
+				volumeSnapshots := createVolumeSnapshots(vmSnapshotContent)
+				for i := range volumeSnapshots {
+					vss := snapshotv1.VolumeSnapshotStatus{
+						VolumeSnapshotName: volumeSnapshots[i].Name,
+					}
+					updatedContent.Status.VolumeSnapshotStatus = append(updatedContent.Status.VolumeSnapshotStatus, vss)
+				}
+
+				storageClassSource.Add(storageClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+
+				expectVolumeSnapshotCreates(k8sSnapshotClient, volumeSnapshotClass.Name, vmSnapshotContent)
+				expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+				vmSnapshotSource.Add(vmSnapshot)
+				addVolumeSnapshotClass(volumeSnapshotClass)
+				controller.processVMSnapshotContentWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+			})
+
+			It("should freeze the VMs of a VirtualMachineGroupSnapshot and create all VolumeSnapshots", func() {
+				storageClass := createStorageClass()
+				volumeSnapshotClass := createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vm := createLockedVM()
+				vmi := createVMI(vm)
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstanceAgentConnected,
+					Status: corev1.ConditionTrue,
+				})
+				vmGroupSnapshot := createVMGroupSnapshotInProgress()
+				vmSnapshot := createVMGroupMemberSnapshot(vmGroupSnapshot)
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vmSnapshotContent.CreationTimestamp = metav1.NewTime(timeFunc().Add(-groupFreezeDeadline - time.Second))
+
+				vmSource.Add(vm)
+				vmiSource.Add(vmi)
+				vmSnapshotSource.Add(vmSnapshot)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+				storageClassSource.Add(storageClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+
+				vmiInterface.EXPECT().Freeze(context.Background(), vm.Name, 0*time.Second).Return(nil)
+				expectVolumeSnapshotCreates(k8sSnapshotClient, volumeSnapshotClass.Name, vmSnapshotContent)
+				vmSnapshotClient.Fake.PrependReactor("update", "virtualmachinegroupsnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					updateObj := action.(testing.UpdateAction).GetObject().(*snapshotv1.VirtualMachineGroupSnapshot)
+					Expect(updateObj.Status.Phase).To(Equal(snapshotv1.InProgress))
+					Expect(updateObj.Status.Conditions).To(ContainElement(newProgressingCondition(corev1.ConditionTrue, "Operation in progress")))
+					Expect(updateObj.Status.VirtualMachineSnapshots[0].FreezeTime).To(HaveValue(Equal(*timeFunc())))
+					return true, updateObj, nil
+				})
+				addVolumeSnapshotClass(volumeSnapshotClass)
+				addVirtualMachineGroupSnapshot(vmGroupSnapshot)
+				controller.processVMGroupSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+				testutils.ExpectEvent(recorder, groupVolumeSnapshotsCreateEvent)
+			})
+
+			It("should thaw the VMs and fail a VirtualMachineGroupSnapshot after the freeze deadline", func() {
+				storageClass := createStorageClass()
+				pvcs := createPersistentVolumeClaims()
+				vm := createLockedVM()
+				vmi := createVMI(vm)
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstanceAgentConnected,
+					Status: corev1.ConditionTrue,
+				})
+				vmGroupSnapshot := createVMGroupSnapshotInProgress()
+				vmGroupSnapshot.Status.VirtualMachineSnapshots[0].FreezeTime = pointer.P(metav1.NewTime(timeFunc().Add(-groupFreezeDeadline - time.Second)))
+				vmSnapshot := createVMGroupMemberSnapshot(vmGroupSnapshot)
+				vmSnapshotContent := createVMSnapshotContent()
+
+				vmSource.Add(vm)
+				vmiSource.Add(vmi)
+				vmSnapshotSource.Add(vmSnapshot)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+				storageClassSource.Add(storageClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+
+				vmiInterface.EXPECT().Unfreeze(context.Background(), vm.Name).Return(nil)
+				vmSnapshotClient.Fake.PrependReactor("update", "virtualmachinegroupsnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					updateObj := action.(testing.UpdateAction).GetObject().(*snapshotv1.VirtualMachineGroupSnapshot)
+					Expect(updateObj.Status.Phase).To(Equal(snapshotv1.Failed))
+					Expect(updateObj.Status.Error.Message).To(HaveValue(Equal(vmGroupSnapshotFreezeDeadlineError)))
+					return true, updateObj, nil
+				})
+				addVirtualMachineGroupSnapshot(vmGroupSnapshot)
+				controller.processVMGroupSnapshotWorkItem()
+			})
+
+			It("should fail a VirtualMachineGroupSnapshot when a member name is taken by another VirtualMachineSnapshot", func() {
+				vm := createLockedVM()
+				vmGroupSnapshot := createVMGroupSnapshotInProgress()
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Labels = map[string]string{snapshotv1.VirtualMachineGroupSnapshotLabel: vmGroupSnapshotName}
+
+				vmSource.Add(vm)
+				vmSnapshotSource.Add(vmSnapshot)
+
+				vmSnapshotClient.Fake.PrependReactor("update", "virtualmachinegroupsnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					updateObj := action.(testing.UpdateAction).GetObject().(*snapshotv1.VirtualMachineGroupSnapshot)
+					Expect(updateObj.Status.Phase).To(Equal(snapshotv1.Failed))
+					Expect(updateObj.Status.Error.Message).To(HaveValue(ContainSubstring(vmSnapshotName)))
+					return true, updateObj, nil
+				})
+				addVirtualMachineGroupSnapshot(vmGroupSnapshot)
+				controller.processVMGroupSnapshotWorkItem()
+			})
+
+			It("should create a VirtualMachineSnapshot of each VM selected by a VirtualMachineGroupSnapshot", func() {
+				vm := createVM()
+				otherVM := createVirtualMachine(testNamespace, "othervm")
+				otherVM.Labels = nil
+				vmGroupSnapshot := createVMGroupSnapshotInProgress()
+				vmGroupSnapshot.Status = nil
+				memberName := vmGroupSnapshotName + "-" + vmName
+
+				vmSource.Add(vm)
+				vmSource.Add(otherVM)
+
+				vmSnapshotClient.Fake.PrependReactor("create", "virtualmachinesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					createObj := action.(testing.CreateAction).GetObject().(*snapshotv1.VirtualMachineSnapshot)
+					Expect(createObj.Name).To(Equal(memberName))
+					Expect(createObj.Labels).To(HaveKeyWithValue(snapshotv1.VirtualMachineGroupSnapshotLabel, vmGroupSnapshotName))
+					Expect(createObj.OwnerReferences).To(ConsistOf(HaveField("UID", vmGroupSnapshot.UID)))
+					Expect(createObj.Spec.Source.Name).To(Equal(vmName))
+					return true, createObj, nil
+				})
+				vmSnapshotClient.Fake.PrependReactor("update", "virtualmachinegroupsnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					updateObj := action.(testing.UpdateAction).GetObject().(*snapshotv1.VirtualMachineGroupSnapshot)
+					Expect(updateObj.Status.Phase).To(Equal(snapshotv1.InProgress))
+					Expect(updateObj.Status.VirtualMachineSnapshots).To(Equal([]snapshotv1.GroupSnapshotVirtualMachineStatus{
+						{
+							VirtualMachineName:         vmName,
+							VirtualMachineSnapshotName: memberName,
+						},
+					}))
+					return true, updateObj, nil
+				})
+				addVirtualMachineGroupSnapshot(vmGroupSnapshot)
+				controller.processVMGroupSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, groupMemberSnapshotCreateEvent)
+			})
+
 			DescribeTable("should update VirtualMachineSnapshotContent", func(readyToUse bool) {
 				vmSnapshot := createVMSnapshotInProgress()
 				vmSnapshotContent := createVMSnapshotContent()
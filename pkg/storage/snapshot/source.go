@@ -26,6 +26,7 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -58,6 +59,8 @@ type snapshotSource interface {
 	Frozen() (bool, error)
 	Freeze() error
 	Unfreeze() error
+	SaveMemoryState() (bool, error)
+	ReleaseMemoryState() error
 	Spec() (snapshotv1.SourceSpec, error)
 	PersistentVolumeClaims() (map[string]string, error)
 }
@@ -318,6 +321,11 @@ func (s *vmSnapshotSource) Freeze() error {
 		return fmt.Errorf("attempting to freeze unlocked VM")
 	}
 
+	if s.memoryStateRequest() != nil {
+		// the VM is kept paused since its memory state was saved
+		return nil
+	}
+
 	exists, err := s.GuestAgent()
 	if !exists || err != nil {
 		return err
@@ -340,6 +348,10 @@ func (s *vmSnapshotSource) Unfreeze() error {
 		return nil
 	}
 
+	if s.memoryStateRequest() != nil {
+		return s.resume()
+	}
+
 	exists, err := s.GuestAgent()
 	if !exists || err != nil {
 		return err
@@ -356,6 +368,144 @@ func (s *vmSnapshotSource) Unfreeze() error {
 	return nil
 }
 
+// SaveMemoryState pauses the VM and saves its memory state to a PVC which is
+// snapshotted along with the other volumes. Returns true once the state is
+// saved or when there is no memory state to save.
+func (s *vmSnapshotSource) SaveMemoryState() (bool, error) {
+	if !includeMemoryState(s.snapshot) {
+		return true, nil
+	}
+
+	vmi, exists, err := s.controller.getVMI(s.vm)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		// an offline VM has no memory state
+		return true, nil
+	}
+
+	if request := s.memoryStateRequest(); request != nil {
+		switch request.Phase {
+		case kubevirtv1.MemoryDumpCompleted:
+			return true, nil
+		case kubevirtv1.MemoryDumpFailed:
+			return false, fmt.Errorf("failed to save memory state of vm %s: %s", s.vm.Name, request.Message)
+		}
+		return false, nil
+	}
+
+	if err := s.createMemoryStatePVC(vmi); err != nil {
+		return false, err
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasCondition(vmi, kubevirtv1.VirtualMachineInstancePaused) {
+		log.Log.V(3).Infof("Pausing vm %s before saving its memory state", s.vm.Name)
+		return false, s.controller.Client.VirtualMachineInstance(s.vm.Namespace).Pause(context.Background(), s.vm.Name, &kubevirtv1.PauseOptions{})
+	}
+
+	log.Log.V(3).Infof("Saving memory state of vm %s", s.vm.Name)
+	memoryDumpRequest := &kubevirtv1.VirtualMachineMemoryDumpRequest{
+		ClaimName: memoryStateClaimName(s.snapshot),
+		Type:      kubevirtv1.MemoryDumpTypeState,
+	}
+	return false, s.controller.Client.VirtualMachine(s.vm.Namespace).MemoryDump(context.Background(), s.vm.Name, memoryDumpRequest)
+}
+
+// ReleaseMemoryState resumes the VM if it is still paused and dissociates
+// the memory state PVC from the VM
+func (s *vmSnapshotSource) ReleaseMemoryState() error {
+	request := s.memoryStateRequest()
+	if request == nil || request.Remove {
+		return nil
+	}
+
+	if err := s.resume(); err != nil {
+		return err
+	}
+
+	if request.Phase != kubevirtv1.MemoryDumpCompleted && request.Phase != kubevirtv1.MemoryDumpFailed {
+		return nil
+	}
+
+	log.Log.V(3).Infof("Removing memory state %s from vm %s", request.ClaimName, s.vm.Name)
+	return s.controller.Client.VirtualMachine(s.vm.Namespace).RemoveMemoryDump(context.Background(), s.vm.Name)
+}
+
+func (s *vmSnapshotSource) memoryStateRequest() *kubevirtv1.VirtualMachineMemoryDumpRequest {
+	request := s.vm.Status.MemoryDumpRequest
+	if request == nil || request.ClaimName != memoryStateClaimName(s.snapshot) {
+		return nil
+	}
+	return request
+}
+
+func (s *vmSnapshotSource) resume() error {
+	vmi, exists, err := s.controller.getVMI(s.vm)
+	if err != nil || !exists {
+		return err
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasCondition(vmi, kubevirtv1.VirtualMachineInstancePaused) {
+		return nil
+	}
+
+	log.Log.V(3).Infof("Resuming vm %s after taking the snapshot", s.vm.Name)
+	return s.controller.Client.VirtualMachineInstance(s.vm.Namespace).Unpause(context.Background(), s.vm.Name, &kubevirtv1.UnpauseOptions{})
+}
+
+func (s *vmSnapshotSource) createMemoryStatePVC(vmi *kubevirtv1.VirtualMachineInstance) error {
+	claimName := memoryStateClaimName(s.snapshot)
+	_, exists, err := s.controller.PVCInformer.GetStore().GetByKey(cacheKeyFunc(s.vm.Namespace, claimName))
+	if err != nil || exists {
+		return err
+	}
+
+	// use the storage class of the VM volumes so the state can be snapshotted
+	var storageClassName *string
+	for i, volume := range s.vm.Spec.Template.Spec.Volumes {
+		if volume.MemoryDump != nil {
+			continue
+		}
+		sc, err := s.controller.getVolumeStorageClass(s.vm.Namespace, &s.vm.Spec.Template.Spec.Volumes[i])
+		if err != nil {
+			return err
+		}
+		if sc != "" {
+			storageClassName = &sc
+			break
+		}
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: s.vm.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(s.snapshot, snapshotv1.SchemeGroupVersion.WithKind("VirtualMachineSnapshot")),
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: storageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: *utils.CalcExpectedMemoryDumpSize(vmi),
+				},
+			},
+		},
+	}
+
+	log.Log.V(3).Infof("Creating memory state pvc %s/%s", pvc.Namespace, pvc.Name)
+	_, err = s.controller.Client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
 func (s *vmSnapshotSource) PersistentVolumeClaims() (map[string]string, error) {
 	return storagetypes.GetPVCsFromVolumes(s.vm.Spec.Template.Spec.Volumes), nil
 }
@@ -113,6 +113,16 @@ func timeUntilDeadline(vmSnapshot *snapshotv1.VirtualMachineSnapshot) time.Durat
 	return time.Until(deadline)
 }
 
+func includeMemoryState(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
+	return vmSnapshot.Spec.IncludeMemoryState != nil && *vmSnapshot.Spec.IncludeMemoryState
+}
+
+// memoryStateClaimName returns the name of the PVC the memory state of the
+// snapshotted VM is saved to
+func memoryStateClaimName(vmSnapshot *snapshotv1.VirtualMachineSnapshot) string {
+	return fmt.Sprintf("%s-memory-state", vmSnapshot.Name)
+}
+
 func getSimplifiedMetaObject(meta metav1.ObjectMeta) *metav1.ObjectMeta {
 	result := meta.DeepCopy()
 	result.ManagedFields = nil
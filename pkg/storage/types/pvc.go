@@ -121,6 +121,8 @@ func PVCNameFromVirtVolume(volume *virtv1.Volume) string {
 		return volume.PersistentVolumeClaim.ClaimName
 	} else if volume.MemoryDump != nil {
 		return volume.MemoryDump.ClaimName
+	} else if volume.Backup != nil {
+		return volume.Backup.ClaimName
 	}
 
 	return ""
@@ -280,6 +282,9 @@ func IsHotplugVolume(vol *virtv1.Volume) bool {
 	if volSrc.MemoryDump != nil && volSrc.MemoryDump.PersistentVolumeClaimVolumeSource.Hotpluggable {
 		return true
 	}
+	if volSrc.Backup != nil && volSrc.Backup.PersistentVolumeClaimVolumeSource.Hotpluggable {
+		return true
+	}
 
 	return false
 }
@@ -0,0 +1,74 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package cron
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	utc := func(value string) time.Time {
+		t, err := time.Parse(time.RFC3339, value)
+		Expect(err).ToNot(HaveOccurred())
+		return t.UTC()
+	}
+
+	DescribeTable("should compute the next activation", func(spec, from, expected string) {
+		schedule, err := Parse(spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(utc(from))).To(Equal(utc(expected)))
+	},
+		Entry("every minute", "* * * * *", "2024-03-10T10:15:30Z", "2024-03-10T10:16:00Z"),
+		Entry("strictly after an activation", "30 2 * * *", "2024-03-10T02:30:00Z", "2024-03-11T02:30:00Z"),
+		Entry("daily before the activation", "30 2 * * *", "2024-03-10T01:00:00Z", "2024-03-10T02:30:00Z"),
+		Entry("with a step", "*/15 * * * *", "2024-03-10T10:16:00Z", "2024-03-10T10:30:00Z"),
+		Entry("with a start and a step", "5/20 * * * *", "2024-03-10T10:46:00Z", "2024-03-10T11:05:00Z"),
+		Entry("with a list and a range", "0 8-10,22 * * *", "2024-03-10T10:01:00Z", "2024-03-10T22:00:00Z"),
+		Entry("across the end of the year", "0 0 1 1 *", "2024-12-31T23:59:00Z", "2025-01-01T00:00:00Z"),
+		Entry("on a leap day", "0 0 29 2 *", "2023-03-01T00:00:00Z", "2024-02-29T00:00:00Z"),
+		Entry("with day names", "0 12 * * sat,sun", "2024-03-11T00:00:00Z", "2024-03-16T12:00:00Z"),
+		Entry("with Sunday as 7", "0 12 * * 7", "2024-03-11T00:00:00Z", "2024-03-17T12:00:00Z"),
+		Entry("with month names", "0 0 1 jun *", "2024-03-11T00:00:00Z", "2024-06-01T00:00:00Z"),
+		Entry("with either day of month or day of week", "0 0 15 * mon", "2024-03-12T00:00:00Z", "2024-03-15T00:00:00Z"),
+		Entry("with a macro", "@weekly", "2024-03-11T00:00:00Z", "2024-03-17T00:00:00Z"),
+	)
+
+	It("should return the zero time for a schedule that never activates", func() {
+		schedule, err := Parse("0 0 31 2 *")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(utc("2024-03-10T10:15:30Z"))).To(BeZero())
+	})
+
+	DescribeTable("should reject", func(spec, message string) {
+		_, err := Parse(spec)
+		Expect(err).To(MatchError(ContainSubstring(message)))
+	},
+		Entry("too few fields", "0 0 * *", "expected 5 fields"),
+		Entry("too many fields", "0 0 0 * * *", "expected 5 fields"),
+		Entry("an out of range minute", "60 * * * *", "out of range [0-59] in minute field"),
+		Entry("an out of range day of month", "0 0 0 * *", "out of range [1-31] in day of month field"),
+		Entry("an unknown name", "0 0 * foo *", `invalid value "foo" in month field`),
+		Entry("an inverted range", "0 10-2 * * *", `invalid range "10-2" in hour field`),
+		Entry("a zero step", "*/0 * * * *", `invalid step "0" in minute field`),
+	)
+})
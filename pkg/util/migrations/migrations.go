@@ -112,3 +112,30 @@ func VMIMigratableOnEviction(clusterConfig *virtconfig.ClusterConfig, vmi *v1.Vi
 	}
 	return false
 }
+
+// priorityRanks orders the known migration priorities, migrations with a higher rank start first
+var priorityRanks = map[v1.MigrationPriority]int{
+	v1.MigrationPriorityEvacuation:     3,
+	v1.MigrationPriorityUser:           2,
+	v1.MigrationPriorityDescheduler:    1,
+	v1.MigrationPriorityWorkloadUpdate: 0,
+}
+
+// IsKnownPriority returns true if the priority is one of the known migration priorities
+func IsKnownPriority(priority v1.MigrationPriority) bool {
+	_, known := priorityRanks[priority]
+	return known
+}
+
+// Priority returns the priority of a migration. Migrations without a priority are user migrations.
+func Priority(migration *v1.VirtualMachineInstanceMigration) v1.MigrationPriority {
+	if migration.Spec.Priority == "" {
+		return v1.MigrationPriorityUser
+	}
+	return migration.Spec.Priority
+}
+
+// PriorityRank returns the rank of the priority of a migration, migrations with a higher rank start first
+func PriorityRank(migration *v1.VirtualMachineInstanceMigration) int {
+	return priorityRanks[Priority(migration)]
+}
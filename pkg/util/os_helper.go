@@ -20,9 +20,12 @@
 package util
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"kubevirt.io/client-go/log"
 )
@@ -89,3 +92,35 @@ func WriteBytes(f *os.File, c byte, n int64) error {
 
 	return nil
 }
+
+// ResidentSetSize returns the resident set size in bytes from a /proc/<pid>/status file
+func ResidentSetSize(statusPath string) (uint64, error) {
+	f, err := os.Open(statusPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return ParseResidentSetSize(f)
+}
+
+// ParseResidentSetSize reads the VmRSS field of a /proc/<pid>/status file, reported in kB, and returns it in bytes
+func ParseResidentSetSize(status io.Reader) (uint64, error) {
+	scanner := bufio.NewScanner(status)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "VmRSS:" {
+			continue
+		}
+		kib, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse VmRSS %q: %v", fields[1], err)
+		}
+		return kib * 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	// kernel threads and zombies have no VmRSS
+	return 0, nil
+}
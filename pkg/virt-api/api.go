@@ -263,6 +263,19 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("convert-hypervisor")).
+			To(subresourceApp.ConvertHypervisorVMRequestHandler).
+			Consumes(mime.MIME_ANY).
+			Reads(v1.ConvertHypervisorOptions{}).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Operation(version.Version+"ConvertHypervisor").
+			Produces(restful.MIME_JSON).
+			Doc("Switch a VirtualMachine to another hypervisor, reporting the changes of the domain.").
+			Writes(v1.HypervisorConversionReport{}).
+			Returns(http.StatusOK, "OK", v1.HypervisorConversionReport{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("start")).
 			To(subresourceApp.StartVMRequestHandler).
 			Consumes(mime.MIME_ANY).
@@ -639,6 +652,10 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/migrate",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachines/convert-hypervisor",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/expand-spec",
 						Namespaced: true,
@@ -898,6 +915,15 @@ func (app *virtAPIApp) registerValidatingWebhooks(informers *webhooks.Informers)
 	http.HandleFunc(components.VMRestoreValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMRestores(w, r, app.clusterConfig, app.virtCli, informers)
 	})
+	http.HandleFunc(components.VMSnapshotScheduleValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMSnapshotSchedules(w, r, app.clusterConfig)
+	})
+	http.HandleFunc(components.VMGroupSnapshotValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMGroupSnapshots(w, r, app.clusterConfig)
+	})
+	http.HandleFunc(components.VMBackupValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMBackups(w, r, app.clusterConfig)
+	})
 	http.HandleFunc(components.VMExportValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMExports(w, r, app.clusterConfig)
 	})
@@ -0,0 +1,357 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/emicklei/go-restful/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
+	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	"kubevirt.io/kubevirt/pkg/hypervisor"
+	kutil "kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook/admitters"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/vcpu"
+)
+
+const (
+	// the OVMF files are installed in virt-launcher, their names do not change the rendered domain
+	efiCodePlaceholder = "OVMF_CODE.fd"
+	efiVarsPlaceholder = "OVMF_VARS.fd"
+	// ephemeralDiskDataDir is the directory of the ephemeral disks in virt-launcher
+	ephemeralDiskDataDir = "/var/run/kubevirt-ephemeral-disks/disk-data"
+)
+
+func (app *SubresourceAPIApp) ConvertHypervisorVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("empty request body"), response)
+		return
+	}
+	opts := &v1.ConvertHypervisorOptions{}
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf(unmarshalRequestErrFmt, err)), response)
+		return
+	}
+	if opts.Hypervisor == "" {
+		writeError(errors.NewBadRequest("the target hypervisor must not be empty"), response)
+		return
+	}
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	expandedVM := vm.DeepCopy()
+	if err := app.instancetypeMethods.ApplyToVM(expandedVM); err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	vmi := &v1.VirtualMachineInstance{
+		ObjectMeta: *expandedVM.Spec.Template.ObjectMeta.DeepCopy(),
+		Spec:       expandedVM.Spec.Template.Spec,
+	}
+	vmi.Name = vm.Name
+	vmi.Namespace = vm.Namespace
+
+	vmi.Spec.Hypervisor = hypervisor.NameOrDefault(vmi.Spec.Hypervisor)
+	if vmi.Spec.Hypervisor == opts.Hypervisor {
+		writeError(errors.NewBadRequest(fmt.Sprintf("VM %s already uses hypervisor %s", name, opts.Hypervisor)), response)
+		return
+	}
+
+	report, causes := checkHypervisorConversion(app.clusterConfig, vmi, opts.Hypervisor)
+	if len(causes) > 0 {
+		writeError(hypervisorConversionError(name, causes), response)
+		return
+	}
+
+	path := "/spec/template/spec/hypervisor"
+	patchOps := []patch.PatchOption{patch.WithAdd(path, opts.Hypervisor)}
+	if vm.Spec.Template.Spec.Hypervisor != "" {
+		patchOps = []patch.PatchOption{
+			patch.WithTest(path, vm.Spec.Template.Spec.Hypervisor),
+			patch.WithReplace(path, opts.Hypervisor),
+		}
+	}
+	patchBytes, err := patch.New(patchOps...).GeneratePayload()
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	log.Log.Object(vm).V(4).Infof(patchingVMFmt, string(patchBytes))
+	_, err = app.virtCli.VirtualMachine(namespace).Patch(context.Background(), name, types.JSONPatchType, patchBytes, k8smetav1.PatchOptions{DryRun: opts.DryRun})
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("unable to convert VM %s to hypervisor %s: %v", name, opts.Hypervisor, err)), response)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, report)
+}
+
+func hypervisorConversionError(name string, causes []k8smetav1.StatusCause) *errors.StatusError {
+	var fieldErrs k8sfield.ErrorList
+	for _, cause := range causes {
+		fieldErrs = append(fieldErrs, k8sfield.Invalid(k8sfield.NewPath(cause.Field), nil, cause.Message))
+	}
+	return errors.NewInvalid(v1.VirtualMachineGroupVersionKind.GroupKind(), name, fieldErrs)
+}
+
+// checkHypervisorConversion validates the VMI against the capabilities of the target hypervisor. It renders the domain of
+// the VMI for both hypervisors and reports how the guest visible configuration changes, or why the VMI can not run on
+// the target hypervisor.
+func checkHypervisorConversion(clusterConfig *virtconfig.ClusterConfig, vmi *v1.VirtualMachineInstance, target string) (*v1.HypervisorConversionReport, []k8smetav1.StatusCause) {
+	field := k8sfield.NewPath("spec", "template", "spec")
+	if _, exists := hypervisor.Lookup(target); !exists {
+		return nil, []k8smetav1.StatusCause{{
+			Type:    k8smetav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("Hypervisor %s is not supported", target),
+			Field:   field.Child("hypervisor").String(),
+		}}
+	}
+
+	sourceVMI := vmi.DeepCopy()
+	targetVMI := vmi.DeepCopy()
+	targetVMI.Spec.Hypervisor = target
+	for _, defaultVMI := range []*v1.VirtualMachineInstance{sourceVMI, targetVMI} {
+		if err := webhooks.SetDefaultVirtualMachineInstance(clusterConfig, defaultVMI); err != nil {
+			return nil, []k8smetav1.StatusCause{{
+				Type:    k8smetav1.CauseTypeFieldValueInvalid,
+				Message: err.Error(),
+				Field:   field.String(),
+			}}
+		}
+	}
+
+	causes := admitters.ValidateHypervisorNamespacePolicy(field, vmi.Namespace, &targetVMI.Spec, clusterConfig)
+	causes = append(causes, admitters.ValidateHypervisorCapabilities(field, &targetVMI.Spec)...)
+	if len(causes) > 0 {
+		return nil, causes
+	}
+
+	sourceDomain, err := renderDomain(sourceVMI)
+	if err != nil {
+		return nil, []k8smetav1.StatusCause{{
+			Type:    k8smetav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("the domain can not be rendered for hypervisor %s: %v", sourceVMI.Spec.Hypervisor, err),
+			Field:   field.String(),
+		}}
+	}
+	targetDomain, err := renderDomain(targetVMI)
+	if err != nil {
+		return nil, []k8smetav1.StatusCause{{
+			Type:    k8smetav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("the domain can not be rendered for hypervisor %s: %v", target, err),
+			Field:   field.String(),
+		}}
+	}
+
+	return &v1.HypervisorConversionReport{
+		SourceHypervisor: vmi.Spec.Hypervisor,
+		TargetHypervisor: target,
+		Changes:          diffDomains(field, vmi, sourceDomain, targetDomain),
+	}, nil
+}
+
+// renderDomain converts the VMI to the domain virt-launcher would define for it. The inputs which are only known
+// on the node and do not depend on the hypervisor, like the format of container disks or the host CPUs, are
+// filled with placeholders.
+func renderDomain(vmi *v1.VirtualMachineInstance) (*api.Domain, error) {
+	hv := hypervisor.NewHypervisor(vmi.Spec.Hypervisor)
+
+	var efiConf *converter.EFIConfiguration
+	if vmi.IsBootloaderEFI() && !hypervisor.LoadsOwnFirmware(hv, vmi) {
+		secureBoot := vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot
+		efiConf = &converter.EFIConfiguration{
+			EFICode:      efiCodePlaceholder,
+			EFIVars:      efiVarsPlaceholder,
+			SecureLoader: secureBoot,
+		}
+	}
+
+	disksInfo := map[string]*cmdv1.DiskInfo{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.ContainerDisk != nil {
+			disksInfo[volume.Name] = &cmdv1.DiskInfo{Format: "raw"}
+		}
+	}
+
+	var cpuSet []int
+	if vmi.IsCPUDedicated() {
+		for i := uint32(0); i < vcpu.CalculateRequestedVCPUs(vcpu.GetCPUTopology(vmi)); i++ {
+			cpuSet = append(cpuSet, int(i))
+		}
+	}
+
+	c := &converter.ConverterContext{
+		Architecture:          vmi.Spec.Architecture,
+		VirtualMachine:        vmi,
+		AllowEmulation:        true,
+		CPUSet:                cpuSet,
+		DisksInfo:             disksInfo,
+		SMBios:                &cmdv1.SMBios{},
+		EFIConfiguration:      efiConf,
+		UseVirtioTransitional: vmi.Spec.Domain.Devices.UseVirtioTransitional != nil && *vmi.Spec.Domain.Devices.UseVirtioTransitional,
+		EphemeraldiskCreator:  ephemeraldisk.NewEphemeralDiskCreator(ephemeralDiskDataDir),
+		UseLaunchSecurity:     kutil.IsSEVVMI(vmi),
+		Hypervisor:            hv,
+	}
+
+	domain := &api.Domain{}
+	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c); err != nil {
+		return nil, err
+	}
+	// virt-api has no hardware emulation device, whether the node falls back to emulation is not known here
+	domain.Spec.Type = hv.GetDomainType()
+
+	return domain, nil
+}
+
+// diffDomains lists the differences visible to the guest between the domains rendered for the source and the target hypervisor
+func diffDomains(field *k8sfield.Path, vmi *v1.VirtualMachineInstance, source, target *api.Domain) []v1.HypervisorConversionChange {
+	var changes []v1.HypervisorConversionChange
+	changed := func(changeField *k8sfield.Path, format string, args ...interface{}) {
+		changes = append(changes, v1.HypervisorConversionChange{
+			Field:   changeField.String(),
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if source.Spec.Type != target.Spec.Type {
+		changed(field.Child("hypervisor"), "domain type changes from %s to %s", source.Spec.Type, target.Spec.Type)
+	}
+
+	sourceDisks, targetDisks := domainDisks(source), domainDisks(target)
+	disksField := field.Child("domain", "devices", "disks")
+	for i, disk := range vmi.Spec.Domain.Devices.Disks {
+		sourceDisk, targetDisk := sourceDisks[disk.Name], targetDisks[disk.Name]
+		if sourceDisk == nil || targetDisk == nil {
+			continue
+		}
+		if sourceDriver, targetDriver := diskDriver(sourceDisk), diskDriver(targetDisk); sourceDriver != targetDriver {
+			changed(disksField.Index(i), "disk driver changes from %s to %s", sourceDriver, targetDriver)
+		}
+		if sourceDisk.Target.Bus != targetDisk.Target.Bus {
+			changed(disksField.Index(i), "disk bus changes from %s to %s", sourceDisk.Target.Bus, targetDisk.Target.Bus)
+		}
+	}
+
+	firmwareField := field.Child("domain", "firmware")
+	if sourceFirmware, targetFirmware := describeFirmware(source), describeFirmware(target); sourceFirmware != targetFirmware {
+		changed(firmwareField, "the guest boots with %s instead of %s", targetFirmware, sourceFirmware)
+	}
+	if source.Spec.OS.NVRam != nil && target.Spec.OS.NVRam == nil {
+		changed(firmwareField.Child("bootloader", "efi"), "EFI variables are initialized by the firmware instead of the OVMF template")
+	}
+	if sourceBootOrder, targetBootOrder := bootOrder(source), bootOrder(target); sourceBootOrder != targetBootOrder {
+		changed(firmwareField.Child("bootloader"), "boot order changes from [%s] to [%s]", sourceBootOrder, targetBootOrder)
+	}
+
+	return changes
+}
+
+func domainDisks(domain *api.Domain) map[string]*api.Disk {
+	disks := map[string]*api.Disk{}
+	for i, disk := range domain.Spec.Devices.Disks {
+		if disk.Alias != nil {
+			disks[disk.Alias.GetName()] = &domain.Spec.Devices.Disks[i]
+		}
+	}
+	return disks
+}
+
+func diskDriver(disk *api.Disk) string {
+	if disk.Driver == nil {
+		return ""
+	}
+	return disk.Driver.Name
+}
+
+func describeFirmware(domain *api.Domain) string {
+	switch domainOS := domain.Spec.OS; {
+	case domainOS.Kernel != "":
+		return fmt.Sprintf("the firmware %s", domainOS.Kernel)
+	case domainOS.BootLoader != nil:
+		return "the OVMF loader"
+	default:
+		return "the default BIOS"
+	}
+}
+
+// bootOrder returns the boot devices of the domain OS followed by the devices with a boot order, hypervisors which
+// require neither boot by the order of the devices
+func bootOrder(domain *api.Domain) string {
+	var devices []string
+	for _, boot := range domain.Spec.OS.BootOrder {
+		devices = append(devices, boot.Dev)
+	}
+
+	type bootDevice struct {
+		name  string
+		order uint
+	}
+	var bootDevices []bootDevice
+	for _, disk := range domain.Spec.Devices.Disks {
+		if disk.BootOrder != nil && disk.Alias != nil {
+			bootDevices = append(bootDevices, bootDevice{name: "disk " + disk.Alias.GetName(), order: disk.BootOrder.Order})
+		}
+	}
+	for _, iface := range domain.Spec.Devices.Interfaces {
+		if iface.BootOrder != nil && iface.Alias != nil {
+			bootDevices = append(bootDevices, bootDevice{name: "interface " + iface.Alias.GetName(), order: iface.BootOrder.Order})
+		}
+	}
+	sort.SliceStable(bootDevices, func(i, j int) bool {
+		return bootDevices[i].order < bootDevices[j].order
+	})
+	for _, device := range bootDevices {
+		devices = append(devices, device.name)
+	}
+
+	return strings.Join(devices, ", ")
+}
@@ -0,0 +1,243 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Convert hypervisor subresource", func() {
+	var (
+		vmClient *kubecli.MockVirtualMachineInterface
+		app      *SubresourceAPIApp
+
+		request  *restful.Request
+		recorder *httptest.ResponseRecorder
+		response *restful.Response
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		vmClient = kubecli.NewMockVirtualMachineInterface(ctrl)
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		virtClient.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmClient).AnyTimes()
+
+		kv := &v1.KubeVirt{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		}
+
+		app = NewSubresourceAPIApp(virtClient, 0, nil, nil)
+		app.instancetypeMethods = testutils.NewMockInstancetypeMethods()
+		app.clusterConfig, _, _ = testutils.NewFakeClusterConfigUsingKV(kv)
+
+		request = restful.NewRequest(&http.Request{})
+		request.PathParameters()["name"] = testVMName
+		request.PathParameters()["namespace"] = k8smetav1.NamespaceDefault
+		recorder = httptest.NewRecorder()
+		response = restful.NewResponse(recorder)
+		response.SetRequestAccepts(restful.MIME_JSON)
+	})
+
+	newVM := func(opts ...libvmi.Option) *v1.VirtualMachine {
+		opts = append([]libvmi.Option{
+			libvmi.WithContainerDisk("disk0", "quay.io/kubevirt/cirros-container-disk-demo"),
+			libvmi.WithResourceMemory("128Mi"),
+			libvmi.WithAutoattachGraphicsDevice(false),
+		}, opts...)
+		vmi := libvmi.New(opts...)
+		vmi.Name = testVMName
+		vmi.Namespace = k8smetav1.NamespaceDefault
+		return libvmi.NewVirtualMachine(vmi)
+	}
+
+	convertHypervisor := func(opts *v1.ConvertHypervisorOptions) {
+		body, err := json.Marshal(opts)
+		Expect(err).ToNot(HaveOccurred())
+		request.Request.Body = io.NopCloser(bytes.NewReader(body))
+		app.ConvertHypervisorVMRequestHandler(request, response)
+	}
+
+	DescribeTable("should switch the hypervisor of the VM and report the changes", func(dryRun []string) {
+		vm := newVM()
+		vmClient.EXPECT().Get(context.Background(), testVMName, k8smetav1.GetOptions{}).Return(vm, nil)
+		vmClient.EXPECT().Patch(context.Background(), testVMName, types.JSONPatchType, gomock.Any(), k8smetav1.PatchOptions{DryRun: dryRun}).DoAndReturn(
+			func(_ context.Context, _ string, _ types.PatchType, body []byte, _ k8smetav1.PatchOptions, _ ...string) (*v1.VirtualMachine, error) {
+				Expect(string(body)).To(Equal(`[{"op":"add","path":"/spec/template/spec/hypervisor","value":"ch"}]`))
+				return vm, nil
+			})
+
+		convertHypervisor(&v1.ConvertHypervisorOptions{Hypervisor: "ch", DryRun: dryRun})
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		report := &v1.HypervisorConversionReport{}
+		Expect(json.NewDecoder(recorder.Body).Decode(report)).To(Succeed())
+		Expect(report.SourceHypervisor).To(Equal("qemu"))
+		Expect(report.TargetHypervisor).To(Equal("ch"))
+		Expect(report.Changes).To(ContainElements(
+			v1.HypervisorConversionChange{
+				Field:   "spec.template.spec.domain.devices.disks[0]",
+				Message: "disk driver changes from qemu to raw",
+			},
+			v1.HypervisorConversionChange{
+				Field:   "spec.template.spec.domain.firmware",
+				Message: "the guest boots with the firmware /usr/share/cloud-hypervisor/CLOUDHV_EFI.fd instead of the default BIOS",
+			},
+		))
+	},
+		Entry("with default", nil),
+		Entry("with dry-run option", getDryRunOption()),
+	)
+
+	It("should report the EFI variables initialized by the firmware", func() {
+		vm := newVM(libvmi.WithUefi(false))
+		vmClient.EXPECT().Get(context.Background(), testVMName, k8smetav1.GetOptions{}).Return(vm, nil)
+		vmClient.EXPECT().Patch(context.Background(), testVMName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(vm, nil)
+
+		convertHypervisor(&v1.ConvertHypervisorOptions{Hypervisor: "ch"})
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		report := &v1.HypervisorConversionReport{}
+		Expect(json.NewDecoder(recorder.Body).Decode(report)).To(Succeed())
+		Expect(report.Changes).To(ContainElements(
+			v1.HypervisorConversionChange{
+				Field:   "spec.template.spec.domain.firmware",
+				Message: "the guest boots with the firmware /usr/share/cloud-hypervisor/CLOUDHV_EFI.fd instead of the OVMF loader",
+			},
+			v1.HypervisorConversionChange{
+				Field:   "spec.template.spec.domain.firmware.bootloader.efi",
+				Message: "EFI variables are initialized by the firmware instead of the OVMF template",
+			},
+		))
+	})
+
+	It("should report the boot order of the rendered domains", func() {
+		vm := newVM()
+		vm.Spec.Template.Spec.Domain.Devices.Disks[0].BootOrder = pointer.P(uint(1))
+		vmClient.EXPECT().Get(context.Background(), testVMName, k8smetav1.GetOptions{}).Return(vm, nil)
+		vmClient.EXPECT().Patch(context.Background(), testVMName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(vm, nil)
+
+		convertHypervisor(&v1.ConvertHypervisorOptions{Hypervisor: "ch"})
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		report := &v1.HypervisorConversionReport{}
+		Expect(json.NewDecoder(recorder.Body).Decode(report)).To(Succeed())
+		Expect(report.Changes).To(ContainElement(v1.HypervisorConversionChange{
+			Field:   "spec.template.spec.domain.firmware.bootloader",
+			Message: "boot order changes from [disk disk0] to [hd, disk disk0]",
+		}))
+	})
+
+	It("should report the boot order but no firmware change with a kernel boot container", func() {
+		vm := newVM()
+		vm.Spec.Template.Spec.Domain.Firmware = &v1.Firmware{
+			KernelBoot: &v1.KernelBoot{
+				Container: &v1.KernelBootContainer{
+					Image:      "quay.io/kubevirt/alpine-ext-kernel-boot-demo",
+					KernelPath: "/boot/vmlinuz",
+				},
+			},
+		}
+		vmClient.EXPECT().Get(context.Background(), testVMName, k8smetav1.GetOptions{}).Return(vm, nil)
+		vmClient.EXPECT().Patch(context.Background(), testVMName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(vm, nil)
+
+		convertHypervisor(&v1.ConvertHypervisorOptions{Hypervisor: "ch"})
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		report := &v1.HypervisorConversionReport{}
+		Expect(json.NewDecoder(recorder.Body).Decode(report)).To(Succeed())
+		Expect(report.Changes).To(ContainElement(v1.HypervisorConversionChange{
+			Field:   "spec.template.spec.domain.firmware.bootloader",
+			Message: "boot order changes from [] to [hd]",
+		}))
+		Expect(report.Changes).ToNot(ContainElement(HaveField("Field", "spec.template.spec.domain.firmware")))
+	})
+
+	It("should replace the hypervisor set in the template", func() {
+		vm := newVM(libvmi.WithHypervisor("ch"))
+		vmClient.EXPECT().Get(context.Background(), testVMName, k8smetav1.GetOptions{}).Return(vm, nil)
+		vmClient.EXPECT().Patch(context.Background(), testVMName, types.JSONPatchType, gomock.Any(), k8smetav1.PatchOptions{}).DoAndReturn(
+			func(_ context.Context, _ string, _ types.PatchType, body []byte, _ k8smetav1.PatchOptions, _ ...string) (*v1.VirtualMachine, error) {
+				Expect(string(body)).To(Equal(`[{"op":"test","path":"/spec/template/spec/hypervisor","value":"ch"},{"op":"replace","path":"/spec/template/spec/hypervisor","value":"qemu"}]`))
+				return vm, nil
+			})
+
+		convertHypervisor(&v1.ConvertHypervisorOptions{Hypervisor: "qemu"})
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should fail if the VM already uses the hypervisor", func() {
+		vmClient.EXPECT().Get(context.Background(), testVMName, k8smetav1.GetOptions{}).Return(newVM(), nil)
+
+		convertHypervisor(&v1.ConvertHypervisorOptions{Hypervisor: "qemu"})
+
+		status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		Expect(status.Error()).To(ContainSubstring("already uses hypervisor qemu"))
+	})
+
+	It("should fail without a target hypervisor", func() {
+		convertHypervisor(&v1.ConvertHypervisorOptions{})
+
+		ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+	})
+
+	DescribeTable("should reject a VM the target hypervisor can not run", func(hypervisorName string, field string, opts ...libvmi.Option) {
+		vmClient.EXPECT().Get(context.Background(), testVMName, k8smetav1.GetOptions{}).Return(newVM(opts...), nil)
+
+		convertHypervisor(&v1.ConvertHypervisorOptions{Hypervisor: hypervisorName})
+
+		status := ExpectStatusErrorWithCode(recorder, http.StatusUnprocessableEntity)
+		Expect(status.ErrStatus.Details.Causes).To(ContainElement(HaveField("Field", field)))
+	},
+		Entry("with an unknown hypervisor", "unknown", "spec.template.spec.hypervisor"),
+		Entry("with EFI SecureBoot", "ch", "spec.template.spec.domain.firmware.bootloader.efi.secureBoot", libvmi.WithUefi(true)),
+	)
+})
@@ -53,6 +53,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	"kubevirt.io/kubevirt/pkg/instancetype"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	kutil "kubevirt.io/kubevirt/pkg/util"
@@ -1204,6 +1205,10 @@ func (app *SubresourceAPIApp) vmiVolumePatch(name, namespace string, volumeReque
 		return errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf(vmiNotRunning))
 	}
 
+	if statErr := app.verifyDiskHotplugSupported(&vmi.Spec); statErr != nil {
+		return statErr
+	}
+
 	err := verifyVolumeOption(vmi.Spec.Volumes, volumeRequest)
 	if err != nil {
 		return errors.NewConflict(v1.Resource("virtualmachineinstance"), name, err)
@@ -1234,6 +1239,12 @@ func (app *SubresourceAPIApp) vmVolumePatchStatus(name, namespace string, volume
 		return statErr
 	}
 
+	if vm.Spec.Template != nil {
+		if statErr := app.verifyDiskHotplugSupported(&vm.Spec.Template.Spec); statErr != nil {
+			return statErr
+		}
+	}
+
 	err := verifyVolumeOption(vm.Spec.Template.Spec.Volumes, volumeRequest)
 	if err != nil {
 		return errors.NewConflict(v1.Resource("virtualmachine"), name, err)
@@ -1258,6 +1269,15 @@ func (app *SubresourceAPIApp) vmVolumePatchStatus(name, namespace string, volume
 	return nil
 }
 
+// verifyDiskHotplugSupported rejects volume hotplug requests for VMIs whose hypervisor can't hotplug disks
+func (app *SubresourceAPIApp) verifyDiskHotplugSupported(spec *v1.VirtualMachineInstanceSpec) *errors.StatusError {
+	hypervisorName := hypervisor.NameOrDefault(spec.Hypervisor)
+	if capabilities, exists := hypervisor.GetCapabilities(hypervisorName); exists && !capabilities.DiskHotplug {
+		return errors.NewBadRequest(fmt.Sprintf("Disk hotplug is not supported by hypervisor %s", hypervisorName))
+	}
+	return nil
+}
+
 func (app *SubresourceAPIApp) getDryRunOption(volumeRequest *v1.VirtualMachineVolumeRequest) []string {
 	var dryRunOption []string
 	if options := volumeRequest.AddVolumeOptions; options != nil && options.DryRun != nil && options.DryRun[0] == k8smetav1.DryRunAll {
@@ -1411,6 +1431,12 @@ func (app *SubresourceAPIApp) validateMemoryDumpRequest(vm *v1.VirtualMachine, m
 		memoryDumpReq.ClaimName = vm.Status.MemoryDumpRequest.ClaimName
 	}
 
+	switch memoryDumpReq.Type {
+	case "", v1.MemoryDumpTypeCore, v1.MemoryDumpTypeState:
+	default:
+		return errors.NewBadRequest(fmt.Sprintf("Memory dump type %s is not supported", memoryDumpReq.Type))
+	}
+
 	vmi, statErr := app.FetchVirtualMachineInstance(vm.Namespace, vm.Name)
 	if statErr != nil {
 		return statErr
@@ -1420,6 +1446,12 @@ func (app *SubresourceAPIApp) validateMemoryDumpRequest(vm *v1.VirtualMachine, m
 		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vm.Name, fmt.Errorf(vmiNotRunning))
 	}
 
+	if memoryDumpReq.Type == v1.MemoryDumpTypeState {
+		if capabilities, exists := hypervisor.GetCapabilities(vmi.Spec.Hypervisor); exists && !capabilities.MemoryState {
+			return errors.NewBadRequest(fmt.Sprintf("Memory state is not supported by hypervisor %s", vmi.Spec.Hypervisor))
+		}
+	}
+
 	if statErr = app.validateMemoryDumpClaim(vmi, memoryDumpReq.ClaimName, vm.Namespace); statErr != nil {
 		return statErr
 	}
@@ -897,6 +897,57 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			}, nil, true, http.StatusBadRequest, false),
 		)
 
+		DescribeTable("Should reject volume hotplug if the hypervisor can't hotplug disks", func(addOpts *v1.AddVolumeOptions, removeOpts *v1.RemoveVolumeOptions, isVM bool) {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			if addOpts != nil {
+				request.Request.Body = newAddVolumeBody(addOpts)
+			} else {
+				request.Request.Body = newRemoveVolumeBody(removeOpts)
+			}
+
+			vmi := api.NewMinimalVMI(request.PathParameter("name"))
+			vmi.Namespace = k8smetav1.NamespaceDefault
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Hypervisor = "ch"
+
+			if isVM {
+				vm := newMinimalVM(request.PathParameter("name"))
+				vm.Namespace = k8smetav1.NamespaceDefault
+				vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				}
+				vmClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vm, nil)
+				if addOpts != nil {
+					app.VMAddVolumeRequestHandler(request, response)
+				} else {
+					app.VMRemoveVolumeRequestHandler(request, response)
+				}
+			} else {
+				vmiClient.EXPECT().Get(context.Background(), vmi.Name, k8smetav1.GetOptions{}).Return(vmi, nil)
+				if addOpts != nil {
+					app.VMIAddVolumeRequestHandler(request, response)
+				} else {
+					app.VMIRemoveVolumeRequestHandler(request, response)
+				}
+			}
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("Disk hotplug is not supported by hypervisor ch"))
+		},
+			Entry("VM add volume", &v1.AddVolumeOptions{
+				Name:         "vol1",
+				Disk:         &v1.Disk{},
+				VolumeSource: &v1.HotplugVolumeSource{},
+			}, nil, true),
+			Entry("VMI add volume", &v1.AddVolumeOptions{
+				Name:         "vol1",
+				Disk:         &v1.Disk{},
+				VolumeSource: &v1.HotplugVolumeSource{},
+			}, nil, false),
+			Entry("VM remove volume", nil, &v1.RemoveVolumeOptions{Name: "vol1"}, true),
+			Entry("VMI remove volume", nil, &v1.RemoveVolumeOptions{Name: "vol1"}, false),
+		)
+
 		DescribeTable("Should generate expected vmi patch", func(volumeRequest *v1.VirtualMachineVolumeRequest, expectedPatchSet *patch.PatchSet) {
 
 			vmi := api.NewMinimalVMI(request.PathParameter("name"))
@@ -1372,6 +1423,26 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			}, http.StatusConflict, true, true, createTestPVC("1Gi", fs, notReadOnly)),
 		)
 
+		It("should reject a memory state request if the hypervisor can not save the memory state", func() {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			request.Request.Body = newMemoryDumpBody(&v1.VirtualMachineMemoryDumpRequest{
+				ClaimName: testPVCName,
+				Type:      v1.MemoryDumpTypeState,
+			})
+
+			vm := newMinimalVM(request.PathParameter("name"))
+			vm.Namespace = k8smetav1.NamespaceDefault
+			vmi := api.NewMinimalVMI(testVMIName)
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Hypervisor = "ch"
+
+			vmClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vm, nil)
+			vmiClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vmi, nil)
+			app.MemoryDumpVMRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+
 		DescribeTable("With memory dump request", func(memDumpReq, prevMemDumpReq *v1.VirtualMachineMemoryDumpRequest, statusCode int) {
 			enableFeatureGate(virtconfig.HotplugVolumesGate)
 			request.Request.Body = newMemoryDumpBody(memDumpReq)
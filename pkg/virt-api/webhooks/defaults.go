@@ -28,8 +28,10 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	"kubevirt.io/kubevirt/pkg/liveupdate/memory"
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
+	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
@@ -38,6 +40,9 @@ func SetDefaultVirtualMachine(clusterConfig *virtconfig.ClusterConfig, vm *v1.Vi
 	if err := setDefaultVirtualMachineInstanceSpec(clusterConfig, &vm.Spec.Template.Spec); err != nil {
 		return err
 	}
+	setDefaultHypervisor(clusterConfig, vm.Namespace, &vm.Spec.Template.Spec)
+	setDefaultSecureBoot(&vm.Spec.Template.Spec)
+	setDefaultGraphics(&vm.Spec.Template.Spec)
 	setDefaultFeatures(&vm.Spec.Template.Spec)
 	v1.SetObjectDefaults_VirtualMachine(vm)
 	setDefaultHypervFeatureDependencies(&vm.Spec.Template.Spec)
@@ -49,6 +54,9 @@ func SetDefaultVirtualMachineInstance(clusterConfig *virtconfig.ClusterConfig, v
 	if err := setDefaultVirtualMachineInstanceSpec(clusterConfig, &vmi.Spec); err != nil {
 		return err
 	}
+	setDefaultHypervisor(clusterConfig, vmi.Namespace, &vmi.Spec)
+	setDefaultSecureBoot(&vmi.Spec)
+	setDefaultGraphics(&vmi.Spec)
 	setDefaultFeatures(&vmi.Spec)
 	v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 	setDefaultHypervFeatureDependencies(&vmi.Spec)
@@ -59,12 +67,43 @@ func SetDefaultVirtualMachineInstance(clusterConfig *virtconfig.ClusterConfig, v
 	return nil
 }
 
+// setDefaultSecureBoot disables Secure Boot of EFI guests by default on hypervisors which do not support it
+func setDefaultSecureBoot(spec *v1.VirtualMachineInstanceSpec) {
+	firmware := spec.Domain.Firmware
+	if firmware == nil || firmware.Bootloader == nil || firmware.Bootloader.EFI == nil || firmware.Bootloader.EFI.SecureBoot != nil {
+		return
+	}
+	if capabilities, exists := hypervisor.GetCapabilities(spec.Hypervisor); exists && !capabilities.SecureBoot {
+		firmware.Bootloader.EFI.SecureBoot = pointer.P(false)
+	}
+}
+
+// setDefaultGraphics disables the graphics device by default on hypervisors which do not support it
+func setDefaultGraphics(spec *v1.VirtualMachineInstanceSpec) {
+	if spec.Domain.Devices.AutoattachGraphicsDevice != nil {
+		return
+	}
+	if capabilities, exists := hypervisor.GetCapabilities(spec.Hypervisor); exists && !capabilities.Graphics {
+		spec.Domain.Devices.AutoattachGraphicsDevice = pointer.P(false)
+	}
+}
+
 func setupHotplug(clusterConfig *virtconfig.ClusterConfig, vmi *v1.VirtualMachineInstance) {
 	if !clusterConfig.IsVMRolloutStrategyLiveUpdate() {
 		return
 	}
-	setupCPUHotplug(clusterConfig, vmi)
-	setupMemoryHotplug(clusterConfig, vmi)
+	capabilities, exists := hypervisor.GetCapabilities(vmi.Spec.Hypervisor)
+	if !exists {
+		// unsupported hypervisors are rejected by the admitter
+		return
+	}
+	// hypervisors without hotplug support keep the topology the VMI was started with
+	if capabilities.CPUHotplug {
+		setupCPUHotplug(clusterConfig, vmi)
+	}
+	if capabilities.MemoryHotplug {
+		setupMemoryHotplug(clusterConfig, vmi)
+	}
 }
 
 func setupCPUHotplug(clusterConfig *virtconfig.ClusterConfig, vmi *v1.VirtualMachineInstance) {
@@ -323,6 +362,12 @@ func setDefaultCPUModel(clusterConfig *virtconfig.ClusterConfig, spec *v1.Virtua
 	}
 }
 
+func setDefaultHypervisor(clusterConfig *virtconfig.ClusterConfig, namespace string, spec *v1.VirtualMachineInstanceSpec) {
+	if spec.Hypervisor == "" {
+		spec.Hypervisor = clusterConfig.GetDefaultHypervisor(namespace)
+	}
+}
+
 func setDefaultArchitecture(clusterConfig *virtconfig.ClusterConfig, spec *v1.VirtualMachineInstanceSpec) {
 	if spec.Architecture == "" {
 		spec.Architecture = clusterConfig.GetDefaultArchitecture()
@@ -121,6 +121,11 @@ func (mutator *VMsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1.
 	mutator.setDefaultPreferenceKind(&vm)
 	preferenceSpec := mutator.getPreferenceSpec(&vm)
 	mutator.setDefaultArchitecture(&vm)
+	if ar.Request.Operation == admissionv1.Create {
+		// Existing VMs keep an unset hypervisor, which stands for qemu, so that
+		// updating them doesn't look like a hypervisor switch
+		mutator.setDefaultHypervisor(&vm)
+	}
 	mutator.setDefaultMachineType(&vm, preferenceSpec)
 	mutator.setPreferenceStorageClassName(&vm, preferenceSpec)
 
@@ -278,6 +283,12 @@ func (mutator *VMsMutator) setDefaultArchitecture(vm *v1.VirtualMachine) {
 	}
 }
 
+func (mutator *VMsMutator) setDefaultHypervisor(vm *v1.VirtualMachine) {
+	if vm.Spec.Template.Spec.Hypervisor == "" {
+		vm.Spec.Template.Spec.Hypervisor = mutator.ClusterConfig.GetDefaultHypervisor(vm.Namespace)
+	}
+}
+
 func validateInstancetypeMatcher(vm *v1.VirtualMachine) []metav1.StatusCause {
 	if vm.Spec.Instancetype == nil {
 		return nil
@@ -77,7 +77,8 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		By("Creating the test admissions review from the VM")
 		ar := &admissionv1.AdmissionReview{
 			Request: &admissionv1.AdmissionRequest{
-				Resource: k8smetav1.GroupVersionResource{Group: v1.VirtualMachineGroupVersionKind.Group, Version: v1.VirtualMachineGroupVersionKind.Version, Resource: "virtualmachines"},
+				Operation: admissionv1.Create,
+				Resource:  k8smetav1.GroupVersionResource{Group: v1.VirtualMachineGroupVersionKind.Group, Version: v1.VirtualMachineGroupVersionKind.Version, Resource: "virtualmachines"},
 				Object: runtime.RawExtension{
 					Raw: vmBytes,
 				},
@@ -213,6 +214,27 @@ var _ = Describe("VirtualMachine Mutator", func() {
 
 	})
 
+	It("should default the hypervisor on VM create", func() {
+		vmSpec, _ := getVMSpecMetaFromResponse(rt.GOARCH)
+		Expect(vmSpec.Template.Spec.Hypervisor).To(Equal("qemu"))
+	})
+
+	It("should not default the hypervisor on VM update", func() {
+		oldVM := vm.DeepCopy()
+		newVM := vm.DeepCopy()
+		newVM.Labels["updated"] = "true"
+
+		resp := getResponseFromVMUpdate(oldVM, newVM)
+		Expect(resp.Allowed).To(BeTrue())
+
+		vmSpec := &v1.VirtualMachineSpec{}
+		patchOps := []patch.PatchOperation{
+			{Value: vmSpec},
+		}
+		Expect(json.Unmarshal(resp.Patch, &patchOps)).To(Succeed())
+		Expect(vmSpec.Template.Spec.Hypervisor).To(BeEmpty())
+	})
+
 	It("should not override specified properties with defaults on VM create", func() {
 		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &v1.KubeVirt{
 			Spec: v1.KubeVirtSpec{
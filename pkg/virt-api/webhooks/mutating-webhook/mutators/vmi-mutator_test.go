@@ -1283,6 +1283,51 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		}),
 	)
 
+	DescribeTable("hypervisor should match the", func(vmiHypervisor, namespace, expected string) {
+		kvCR := testutils.GetFakeKubeVirtClusterConfig(kvStore)
+		kvCR.Spec.Configuration.HypervisorConfiguration = &v1.HypervisorConfiguration{
+			DefaultHypervisor: "ch",
+			NamespacePolicies: []v1.HypervisorNamespacePolicy{{
+				Namespaces:        []string{"kvm-tenant"},
+				DefaultHypervisor: "qemu",
+			}},
+		}
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvCR)
+
+		vmi.Namespace = namespace
+		vmi.Spec.Hypervisor = vmiHypervisor
+		_, vmiSpec, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
+		Expect(vmiSpec.Hypervisor).To(Equal(expected))
+	},
+		Entry("one set in the VMI", "qemu", "default", "qemu"),
+		Entry("one set cluster-wide", "", "default", "ch"),
+		Entry("one set for the namespace", "", "kvm-tenant", "qemu"),
+	)
+
+	DescribeTable("EFI SecureBoot should default to", func(hypervisor string, secureBoot *bool, expected *bool) {
+		vmi.Spec.Hypervisor = hypervisor
+		vmi.Spec.Domain.Firmware = &v1.Firmware{
+			Bootloader: &v1.Bootloader{EFI: &v1.EFI{SecureBoot: secureBoot}},
+		}
+		_, vmiSpec, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
+		Expect(vmiSpec.Domain.Firmware.Bootloader.EFI.SecureBoot).To(Equal(expected))
+	},
+		Entry("enabled by libvirt with qemu", "qemu", nil, nil),
+		Entry("disabled with ch", "ch", nil, kvpointer.P(false)),
+		Entry("the requested value with ch", "ch", kvpointer.P(true), kvpointer.P(true)),
+	)
+
+	DescribeTable("the graphics device should default to", func(hypervisor string, autoattach *bool, expected *bool) {
+		vmi.Spec.Hypervisor = hypervisor
+		vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = autoattach
+		_, vmiSpec, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
+		Expect(vmiSpec.Domain.Devices.AutoattachGraphicsDevice).To(Equal(expected))
+	},
+		Entry("attached with qemu", "qemu", nil, nil),
+		Entry("disabled with ch", "ch", nil, kvpointer.P(false)),
+		Entry("the requested value with ch", "ch", kvpointer.P(true), kvpointer.P(true)),
+	)
+
 	It("should set guest memory status on VMI creation", func() {
 		memory := resource.MustParse("128Mi")
 		vmi.Spec.Domain.Memory = &v1.Memory{
@@ -1405,6 +1450,11 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 				_, spec, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
 				Expect(spec.Domain.CPU.MaxSockets).To(Equal(uint32(4)))
 			})
+			It("to not set max sockets when the hypervisor does not support CPU hotplug", func() {
+				vmi.Spec.Hypervisor = "ch"
+				_, spec, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
+				Expect(spec.Domain.CPU.MaxSockets).To(BeZero())
+			})
 		})
 		Context("configure Memory hotplug", func() {
 			It("to keep VMI values of max guest when provided", func() {
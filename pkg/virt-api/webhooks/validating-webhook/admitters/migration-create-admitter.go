@@ -34,6 +34,7 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/util/migrations"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 )
@@ -90,6 +91,15 @@ func (admitter *MigrationCreateAdmitter) Admit(ctx context.Context, ar *admissio
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	// Evacuation migrations start before all others, users must not skip the queue with them
+	if migration.Spec.Priority == v1.MigrationPriorityEvacuation && !webhooks.IsKubeVirtServiceAccount(ar.Request.UserInfo.Username) {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("only KubeVirt may create migrations with priority %s", v1.MigrationPriorityEvacuation),
+			Field:   k8sfield.NewPath("spec", "priority").String(),
+		}})
+	}
+
 	vmi, err := admitter.VirtClient.VirtualMachineInstance(migration.Namespace).Get(ctx, migration.Spec.VMIName, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		// ensure VMI exists for the migration
@@ -159,5 +169,13 @@ func ValidateVirtualMachineInstanceMigrationSpec(field *k8sfield.Path, spec *v1.
 		})
 	}
 
+	if spec.Priority != "" && !migrations.IsKnownPriority(spec.Priority) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("priority %s is not supported", spec.Priority),
+			Field:   field.Child("priority").String(),
+		})
+	}
+
 	return causes
 }
@@ -27,6 +27,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -154,6 +155,74 @@ var _ = Describe("Validating MigrationCreate Admitter", func() {
 			Expect(resp.Allowed).To(BeTrue())
 		})
 
+		It("should reject Migration spec with an unknown priority", func() {
+			migration := v1.VirtualMachineInstanceMigration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: v1.VirtualMachineInstanceMigrationSpec{
+					VMIName:  "testvmimigrate1",
+					Priority: "Urgent",
+				},
+			}
+			migrationBytes, _ := json.Marshal(&migration)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.MigrationGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: migrationBytes,
+					},
+				},
+			}
+
+			resp := migrationCreateAdmitter.Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.priority"))
+		})
+
+		DescribeTable("with priority", func(priority v1.MigrationPriority, username string, allowed bool) {
+			vmi := api.NewMinimalVMI("testvmimigrate1")
+
+			if allowed {
+				mockVMIClient.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil)
+			}
+
+			migration := v1.VirtualMachineInstanceMigration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: vmi.Namespace,
+				},
+				Spec: v1.VirtualMachineInstanceMigrationSpec{
+					VMIName:  vmi.Name,
+					Priority: priority,
+				},
+			}
+			migrationBytes, _ := json.Marshal(&migration)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.MigrationGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: migrationBytes,
+					},
+					UserInfo: authv1.UserInfo{Username: username},
+				},
+			}
+
+			resp := migrationCreateAdmitter.Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(Equal(allowed))
+			if !allowed {
+				Expect(resp.Result.Details.Causes).To(HaveLen(1))
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.priority"))
+			}
+		},
+			Entry("should accept User from users", v1.MigrationPriorityUser, "user", true),
+			Entry("should accept WorkloadUpdate from users", v1.MigrationPriorityWorkloadUpdate, "user", true),
+			Entry("should reject Evacuation from users", v1.MigrationPriorityEvacuation, "user", false),
+			Entry("should accept Evacuation from KubeVirt", v1.MigrationPriorityEvacuation, "system:serviceaccount:kubevirt:kubevirt-controller", true),
+		)
+
 		It("should accept Migration spec on create when previous VMI migration completed", func() {
 			vmi := api.NewMinimalVMI("testmigratevmi4")
 			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
@@ -0,0 +1,149 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	"kubevirt.io/api/core"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// VMBackupAdmitter validates VirtualMachineBackups
+type VMBackupAdmitter struct {
+	Config *virtconfig.ClusterConfig
+}
+
+// NewVMBackupAdmitter creates a VMBackupAdmitter
+func NewVMBackupAdmitter(config *virtconfig.ClusterConfig) *VMBackupAdmitter {
+	return &VMBackupAdmitter{
+		Config: config,
+	}
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMBackupAdmitter) Admit(_ context.Context, ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request.Resource.Group != snapshotv1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != "virtualmachinebackups" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == admissionv1.Create && !admitter.Config.SnapshotEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("snapshot feature gate not enabled"))
+	}
+
+	// the backup is written to the pvc by hotplugging it into the VM
+	if ar.Request.Operation == admissionv1.Create && !admitter.Config.HotplugVolumesEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("hotplug volumes feature gate not enabled"))
+	}
+
+	vmBackup := &snapshotv1.VirtualMachineBackup{}
+	err := json.Unmarshal(ar.Request.Object.Raw, vmBackup)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	var causes []metav1.StatusCause
+
+	switch ar.Request.Operation {
+	case admissionv1.Create:
+		causes = validateVMBackupSpec(k8sfield.NewPath("spec"), &vmBackup.Spec)
+	case admissionv1.Update:
+		prevObj := &snapshotv1.VirtualMachineBackup{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
+		if !equality.Semantic.DeepEqual(prevObj.Spec, vmBackup.Spec) {
+			causes = []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "spec in immutable after creation",
+					Field:   k8sfield.NewPath("spec").String(),
+				},
+			}
+		}
+	default:
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected operation %s", ar.Request.Operation))
+	}
+
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	return &admissionv1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+func validateVMBackupSpec(field *k8sfield.Path, spec *snapshotv1.VirtualMachineBackupSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if spec.Source.APIGroup == nil || *spec.Source.APIGroup != core.GroupName || spec.Source.Kind != "VirtualMachine" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "source must be a VirtualMachine",
+			Field:   field.Child("source").String(),
+		})
+	} else if spec.Source.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "source name must be set",
+			Field:   field.Child("source", "name").String(),
+		})
+	}
+
+	if spec.PersistentVolumeClaimName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "persistentVolumeClaimName must be set",
+			Field:   field.Child("persistentVolumeClaimName").String(),
+		})
+	}
+
+	if spec.Type != nil && *spec.Type != snapshotv1.BackupTypeFull && *spec.Type != snapshotv1.BackupTypeIncremental {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("type must be %s or %s", snapshotv1.BackupTypeFull, snapshotv1.BackupTypeIncremental),
+			Field:   field.Child("type").String(),
+		})
+	}
+
+	if spec.FailureDeadline != nil && spec.FailureDeadline.Duration < 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "failureDeadline must not be negative",
+			Field:   field.Child("failureDeadline").String(),
+		})
+	}
+
+	return causes
+}
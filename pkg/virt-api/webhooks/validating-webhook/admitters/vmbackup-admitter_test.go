@@ -0,0 +1,189 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"kubevirt.io/api/core"
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Validating VirtualMachineBackup Admitter", func() {
+	config, _, kvStore := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+
+	newBackup := func() *snapshotv1.VirtualMachineBackup {
+		return &snapshotv1.VirtualMachineBackup{
+			Spec: snapshotv1.VirtualMachineBackupSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: pointer.P(core.GroupName),
+					Kind:     "VirtualMachine",
+					Name:     "vm",
+				},
+				PersistentVolumeClaimName: "backup",
+			},
+		}
+	}
+
+	enableFeatureGates := func(featureGates ...string) {
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &v1.KubeVirt{
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{
+						FeatureGates: featureGates,
+					},
+				},
+			},
+		})
+	}
+
+	AfterEach(func() {
+		enableFeatureGates()
+	})
+
+	DescribeTable("should reject anything without feature gate", func(featureGates []string, message string) {
+		enableFeatureGates(featureGates...)
+		ar := createBackupAdmissionReview(newBackup())
+		resp := NewVMBackupAdmitter(config).Admit(context.Background(), ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).Should(Equal(message))
+	},
+		Entry("Snapshot", []string{"HotplugVolumes"}, "snapshot feature gate not enabled"),
+		Entry("HotplugVolumes", []string{"Snapshot"}, "hotplug volumes feature gate not enabled"),
+	)
+
+	Context("With feature gates enabled", func() {
+		BeforeEach(func() {
+			enableFeatureGates("Snapshot", "HotplugVolumes")
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := NewVMBackupAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		It("should accept a valid backup", func() {
+			backup := newBackup()
+			backup.Spec.Type = pointer.P(snapshotv1.BackupTypeFull)
+			backup.Spec.FailureDeadline = &metav1.Duration{Duration: 10 * time.Minute}
+
+			ar := createBackupAdmissionReview(backup)
+			resp := NewVMBackupAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		DescribeTable("should reject", func(update func(*snapshotv1.VirtualMachineBackup), field string) {
+			backup := newBackup()
+			update(backup)
+
+			ar := createBackupAdmissionReview(backup)
+			resp := NewVMBackupAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			Entry("a source of another kind", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.Source.Kind = "VirtualMachineInstance"
+			}, "spec.source"),
+			Entry("a source without api group", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.Source.APIGroup = nil
+			}, "spec.source"),
+			Entry("a source without name", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.Source.Name = ""
+			}, "spec.source.name"),
+			Entry("a missing pvc name", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.PersistentVolumeClaimName = ""
+			}, "spec.persistentVolumeClaimName"),
+			Entry("an unknown type", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.Type = pointer.P(snapshotv1.BackupType("Differential"))
+			}, "spec.type"),
+			Entry("a negative failure deadline", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.FailureDeadline = &metav1.Duration{Duration: -time.Minute}
+			}, "spec.failureDeadline"),
+		)
+
+		It("should reject spec update", func() {
+			oldBackup := newBackup()
+			backup := newBackup()
+			backup.Spec.PersistentVolumeClaimName = "other"
+			oldBytes, _ := json.Marshal(oldBackup)
+
+			ar := createBackupAdmissionReview(backup)
+			ar.Request.Operation = admissionv1.Update
+			ar.Request.OldObject = runtime.RawExtension{Raw: oldBytes}
+			resp := NewVMBackupAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+
+		It("should allow metadata update", func() {
+			oldBackup := newBackup()
+			backup := newBackup()
+			backup.Labels = map[string]string{"tier": "gold"}
+			oldBytes, _ := json.Marshal(oldBackup)
+
+			ar := createBackupAdmissionReview(backup)
+			ar.Request.Operation = admissionv1.Update
+			ar.Request.OldObject = runtime.RawExtension{Raw: oldBytes}
+			resp := NewVMBackupAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+})
+
+func createBackupAdmissionReview(backup *snapshotv1.VirtualMachineBackup) *admissionv1.AdmissionReview {
+	bytes, _ := json.Marshal(backup)
+
+	return &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "snapshot.kubevirt.io",
+				Resource: "virtualmachinebackups",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
@@ -32,8 +32,12 @@ import (
 	"kubevirt.io/kubevirt/pkg/storage/snapshot"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	"kubevirt.io/api/clone"
@@ -48,6 +52,9 @@ import (
 const (
 	virtualMachineKind         = "VirtualMachine"
 	virtualMachineSnapshotKind = "VirtualMachineSnapshot"
+
+	// cloneSourceSubresource is the subresource users need to create in a namespace to clone from it
+	cloneSourceSubresource = "source"
 )
 
 // VirtualMachineCloneAdmitter validates VirtualMachineClones
@@ -83,7 +90,25 @@ func (admitter *VirtualMachineCloneAdmitter) Admit(ctx context.Context, ar *admi
 		return webhookutils.ToAdmissionResponseError(err)
 	}
 
-	var causes []metav1.StatusCause
+	if ar.Request.Operation == admissionv1.Update {
+		prevVMClone := &clonev1alpha1.VirtualMachineClone{}
+		if err := json.Unmarshal(ar.Request.OldObject.Raw, prevVMClone); err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+		// updates of the metadata, e.g. the removal of finalizers, must not depend on the source
+		if equality.Semantic.DeepEqual(prevVMClone.Spec, vmClone.Spec) {
+			return &admissionv1.AdmissionResponse{Allowed: true}
+		}
+	}
+
+	// the source is only looked at if the user is allowed to clone from its namespace
+	causes, err := admitter.authorizeSourceNamespace(ctx, ar.Request.UserInfo, vmClone)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
 
 	if newCauses := validateFilters(vmClone.Spec.AnnotationFilters, "spec.annotations"); newCauses != nil {
 		causes = append(causes, newCauses...)
@@ -110,7 +135,11 @@ func (admitter *VirtualMachineCloneAdmitter) Admit(ctx context.Context, ar *admi
 		causes = append(causes, newCauses...)
 	}
 
-	if newCauses := validateNewMacAddresses(vmClone); newCauses != nil {
+	if newCauses := validateNewMacAddresses(vmClone.Spec.NewMacAddresses, k8sfield.NewPath("spec").Child("newMacAddresses")); newCauses != nil {
+		causes = append(causes, newCauses...)
+	}
+
+	if newCauses := validateGuestCustomization(vmClone.Spec.GuestCustomization, k8sfield.NewPath("spec").Child("guestCustomization")); newCauses != nil {
 		causes = append(causes, newCauses...)
 	}
 
@@ -227,9 +256,9 @@ func validateSource(ctx context.Context, client kubecli.KubevirtClient, vmClone
 	if source.Kind != "" && source.Name != "" {
 		switch source.Kind {
 		case virtualMachineKind:
-			causes = append(causes, validateCloneSourceVM(ctx, client, source.Name, vmClone.Namespace, sourceField.Child("Source"))...)
+			causes = append(causes, validateCloneSourceVM(ctx, client, source.Name, cloneSourceNamespace(vmClone), sourceField.Child("Source"))...)
 		case virtualMachineSnapshotKind:
-			causes = append(causes, validateCloneSourceSnapshot(ctx, client, source.Name, vmClone.Namespace, sourceField.Child("Source"))...)
+			causes = append(causes, validateCloneSourceSnapshot(ctx, client, source.Name, cloneSourceNamespace(vmClone), sourceField.Child("Source"))...)
 		default:
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
@@ -249,6 +278,7 @@ func validateTarget(vmClone *clonev1alpha1.VirtualMachineClone) []metav1.StatusC
 
 	if source != nil &&
 		target != nil &&
+		cloneSourceNamespace(vmClone) == vmClone.Namespace &&
 		source.Kind == virtualMachineKind &&
 		target.Kind == virtualMachineKind &&
 		target.Name == source.Name {
@@ -262,16 +292,127 @@ func validateTarget(vmClone *clonev1alpha1.VirtualMachineClone) []metav1.StatusC
 	return causes
 }
 
-func validateNewMacAddresses(vmClone *clonev1alpha1.VirtualMachineClone) []metav1.StatusCause {
+func cloneSourceNamespace(vmClone *clonev1alpha1.VirtualMachineClone) string {
+	if vmClone.Spec.SourceNamespace != nil && *vmClone.Spec.SourceNamespace != "" {
+		return *vmClone.Spec.SourceNamespace
+	}
+	return vmClone.Namespace
+}
+
+// authorizeSourceNamespace checks if the user is allowed to clone from the source namespace,
+// similar to the datavolumes/source subresource of CDI
+func (admitter *VirtualMachineCloneAdmitter) authorizeSourceNamespace(ctx context.Context, userInfo authenticationv1.UserInfo, vmClone *clonev1alpha1.VirtualMachineClone) ([]metav1.StatusCause, error) {
+	sourceNamespace := cloneSourceNamespace(vmClone)
+	if sourceNamespace == vmClone.Namespace || vmClone.Spec.Source == nil {
+		return nil, nil
+	}
+
+	extra := map[string]authv1.ExtraValue{}
+	for key, value := range userInfo.Extra {
+		extra[key] = authv1.ExtraValue(value)
+	}
+	sar := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			Groups: userInfo.Groups,
+			UID:    userInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace:   sourceNamespace,
+				Verb:        "create",
+				Group:       clone.GroupName,
+				Resource:    clone.ResourceVMClonePlural,
+				Subresource: cloneSourceSubresource,
+				Name:        vmClone.Spec.Source.Name,
+			},
+		},
+	}
+
+	response, err := admitter.Client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if response.Status.Allowed {
+		return nil, nil
+	}
+
+	message := fmt.Sprintf("user %s is not allowed to create %s/%s in namespace %s", userInfo.Username, clone.ResourceVMClonePlural, cloneSourceSubresource, sourceNamespace)
+	if response.Status.Reason != "" {
+		message = fmt.Sprintf("%s: %s", message, response.Status.Reason)
+	}
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: message,
+		Field:   k8sfield.NewPath("spec", "sourceNamespace").String(),
+	}}, nil
+}
+
+func validateNewMacAddresses(newMacAddresses map[string]string, field *k8sfield.Path) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 
-	for ifaceName, ifaceMac := range vmClone.Spec.NewMacAddresses {
+	for ifaceName, ifaceMac := range newMacAddresses {
 		if ifaceMac != "" {
 			if err := link.ValidateMacAddress(ifaceMac); err != nil {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
 					Message: fmt.Sprintf("interface %s has malformed MAC address (%s).", ifaceName, ifaceMac),
-					Field:   k8sfield.NewPath("spec").Child("newMacAddresses").Child(ifaceName).String(),
+					Field:   field.Child(ifaceName).String(),
+				})
+			}
+		}
+	}
+
+	return causes
+}
+
+func validateGuestCustomization(customization *clonev1alpha1.VirtualMachineCloneGuestCustomization, field *k8sfield.Path) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if customization == nil {
+		return causes
+	}
+
+	if customization.Hostname != nil {
+		for _, msg := range validation.IsDNS1123Label(*customization.Hostname) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("hostname %q is invalid: %s", *customization.Hostname, msg),
+				Field:   field.Child("hostname").String(),
+			})
+		}
+	}
+
+	if cloudInit := customization.CloudInit; cloudInit != nil {
+		cloudInitField := field.Child("cloudInit")
+		if cloudInit.UserData == nil && cloudInit.UserDataSecretRef == nil && cloudInit.NetworkData == nil && cloudInit.NetworkDataSecretRef == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "cloud-init customization must replace the user data or the network data",
+				Field:   cloudInitField.String(),
+			})
+		}
+		if cloudInit.UserData != nil && cloudInit.UserDataSecretRef != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "userData and userDataSecretRef are mutually exclusive",
+				Field:   cloudInitField.Child("userData").String(),
+			})
+		}
+		if cloudInit.NetworkData != nil && cloudInit.NetworkDataSecretRef != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "networkData and networkDataSecretRef are mutually exclusive",
+				Field:   cloudInitField.Child("networkData").String(),
+			})
+		}
+	}
+
+	if customization.Sysprep != nil {
+		for idx, operation := range customization.Sysprep.Operations {
+			if operation == "" || strings.ContainsAny(operation, ", ") {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("sysprep operation %q is invalid", operation),
+					Field:   field.Child("sysprep", "operations").Index(idx).String(),
 				})
 			}
 		}
@@ -32,10 +32,13 @@ import (
 
 	"github.com/golang/mock/gomock"
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/rand"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 
@@ -405,6 +408,75 @@ var _ = Describe("Validating VirtualMachineClone Admitter", func() {
 		)
 	})
 
+	Context("source namespace", func() {
+		const sourceNamespace = "source-ns"
+		var k8sClient *k8sfake.Clientset
+
+		admit := func(userInfo authenticationv1.UserInfo) *admissionv1.AdmissionResponse {
+			ar := createCloneAdmissionReview(vmClone)
+			ar.Request.UserInfo = userInfo
+			return admitter.Admit(context.Background(), ar)
+		}
+
+		reviewAccess := func(allowed bool) {
+			k8sClient.Fake.PrependReactor("create", "subjectaccessreviews", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				sar := action.(testing.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+				Expect(sar.Spec.User).To(Equal("user"))
+				Expect(sar.Spec.Groups).To(ConsistOf("group"))
+				Expect(sar.Spec.ResourceAttributes).To(Equal(&authv1.ResourceAttributes{
+					Namespace:   sourceNamespace,
+					Verb:        "create",
+					Group:       clone.GroupName,
+					Resource:    clone.ResourceVMClonePlural,
+					Subresource: "source",
+					Name:        vmClone.Spec.Source.Name,
+				}))
+				sar.Status.Allowed = allowed
+				if !allowed {
+					sar.Status.Reason = "no RBAC policy matched"
+				}
+				return true, sar, nil
+			})
+		}
+
+		BeforeEach(func() {
+			k8sClient = k8sfake.NewSimpleClientset()
+			virtClient.EXPECT().AuthorizationV1().Return(k8sClient.AuthorizationV1()).AnyTimes()
+			virtClient.EXPECT().VirtualMachine(sourceNamespace).Return(vmInterface).AnyTimes()
+			vmClone.Spec.SourceNamespace = pointer.String(sourceNamespace)
+		})
+
+		It("should allow users who may create virtualmachineclones/source in the source namespace", func() {
+			reviewAccess(true)
+			resp := admit(authenticationv1.UserInfo{Username: "user", Groups: []string{"group"}})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject other users", func() {
+			reviewAccess(false)
+			resp := admit(authenticationv1.UserInfo{Username: "user", Groups: []string{"group"}})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.sourceNamespace"))
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("no RBAC policy matched"))
+		})
+
+		It("should allow the target to have the same name as the source VM", func() {
+			reviewAccess(true)
+			vmClone.Spec.Target.Name = vmClone.Spec.Source.Name
+			resp := admit(authenticationv1.UserInfo{Username: "user", Groups: []string{"group"}})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	It("should allow updates that do not change the spec", func() {
+		vmClone.Spec.Source.Name = "vm-that-doesnt-exist"
+		ar := createCloneAdmissionReview(vmClone)
+		ar.Request.Operation = admissionv1.Update
+		ar.Request.OldObject = ar.Request.Object
+		Expect(admitter.Admit(context.Background(), ar).Allowed).To(BeTrue())
+	})
+
 	DescribeTable("newMacAddresses", func(mac string, expectAllowed bool) {
 		vmClone.Spec.NewMacAddresses = map[string]string{
 			"default": mac,
@@ -415,6 +487,38 @@ var _ = Describe("Validating VirtualMachineClone Admitter", func() {
 		Entry("invalid mac address", "00:00:00:00:00", false),
 	)
 
+	DescribeTable("guestCustomization", func(customization *clonev1lpha1.VirtualMachineCloneGuestCustomization, expectAllowed bool) {
+		vmClone.Spec.GuestCustomization = customization
+		admitter.admitAndExpect(vmClone, expectAllowed)
+	},
+		Entry("valid hostname", &clonev1lpha1.VirtualMachineCloneGuestCustomization{Hostname: pointer.String("clone-host")}, true),
+		Entry("invalid hostname", &clonev1lpha1.VirtualMachineCloneGuestCustomization{Hostname: pointer.String("Clone_Host")}, false),
+		Entry("cloud-init user data", &clonev1lpha1.VirtualMachineCloneGuestCustomization{
+			CloudInit: &clonev1lpha1.VirtualMachineCloneCloudInit{UserData: pointer.String("#cloud-config")},
+		}, true),
+		Entry("cloud-init without data", &clonev1lpha1.VirtualMachineCloneGuestCustomization{
+			CloudInit: &clonev1lpha1.VirtualMachineCloneCloudInit{},
+		}, false),
+		Entry("cloud-init user data and user data secret", &clonev1lpha1.VirtualMachineCloneGuestCustomization{
+			CloudInit: &clonev1lpha1.VirtualMachineCloneCloudInit{
+				UserData:          pointer.String("#cloud-config"),
+				UserDataSecretRef: &k8sv1.LocalObjectReference{Name: "userdata"},
+			},
+		}, false),
+		Entry("cloud-init network data and network data secret", &clonev1lpha1.VirtualMachineCloneGuestCustomization{
+			CloudInit: &clonev1lpha1.VirtualMachineCloneCloudInit{
+				NetworkData:          pointer.String("version: 2"),
+				NetworkDataSecretRef: &k8sv1.LocalObjectReference{Name: "networkdata"},
+			},
+		}, false),
+		Entry("sysprep operations", &clonev1lpha1.VirtualMachineCloneGuestCustomization{
+			Sysprep: &clonev1lpha1.VirtualMachineCloneSysprep{Operations: []string{"machine-id", "-ssh-hostkeys"}},
+		}, true),
+		Entry("invalid sysprep operation", &clonev1lpha1.VirtualMachineCloneGuestCustomization{
+			Sysprep: &clonev1lpha1.VirtualMachineCloneSysprep{Operations: []string{"machine-id,ssh-hostkeys"}},
+		}, false),
+	)
+
 })
 
 func createCloneAdmissionReview(vmClone *clonev1lpha1.VirtualMachineClone) *admissionv1.AdmissionReview {
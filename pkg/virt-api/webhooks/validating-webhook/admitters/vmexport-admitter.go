@@ -33,6 +33,7 @@ import (
 	exportv1 "kubevirt.io/api/export/v1beta1"
 	"kubevirt.io/api/snapshot"
 
+	"kubevirt.io/kubevirt/pkg/storage/export/oci"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
@@ -99,6 +100,10 @@ func (admitter *VMExportAdmitter) Admit(_ context.Context, ar *admissionv1.Admis
 			}
 		}
 
+		if vmExport.Spec.ContainerDisk != nil {
+			causes = append(causes, admitter.validateContainerDisk(k8sfield.NewPath("spec", "containerDisk"), vmExport.Spec.ContainerDisk)...)
+		}
+
 	case admissionv1.Update:
 		prevObj := &exportv1.VirtualMachineExport{}
 		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
@@ -212,3 +217,22 @@ func (admitter *VMExportAdmitter) validateVMApiGroup(field *k8sfield.Path, apigr
 
 	return []metav1.StatusCause{}
 }
+
+func (admitter *VMExportAdmitter) validateContainerDisk(field *k8sfield.Path, containerDisk *exportv1.VirtualMachineExportContainerDisk) []metav1.StatusCause {
+	causes := []metav1.StatusCause{}
+	if containerDisk.Volume == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "containerDisk volume must not be empty",
+			Field:   field.Child("volume").String(),
+		})
+	}
+	if _, err := oci.ParseReference(containerDisk.Image); err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: err.Error(),
+			Field:   field.Child("image").String(),
+		})
+	}
+	return causes
+}
@@ -257,6 +257,38 @@ var _ = Describe("Validating VirtualMachineExport Admitter", func() {
 			Entry("virtual machine snapshot", "invalid", vmSnapshotKind),
 			Entry("virtual machine", "invalid", vmKind),
 		)
+
+		DescribeTable("should validate the containerDisk", func(containerDisk *exportv1.VirtualMachineExportContainerDisk, expectedField string) {
+			export := &exportv1.VirtualMachineExport{
+				Spec: exportv1.VirtualMachineExportSpec{
+					Source: corev1.TypedLocalObjectReference{
+						APIGroup: &kubevirtApiGroup,
+						Kind:     vmKind,
+						Name:     "test",
+					},
+					ContainerDisk: containerDisk,
+				},
+			}
+
+			ar := createExportAdmissionReview(export)
+			resp := createTestVMExportAdmitter(config).Admit(context.Background(), ar)
+			if expectedField == "" {
+				Expect(resp.Allowed).To(BeTrue())
+				return
+			}
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(expectedField))
+		},
+			Entry("and allow a volume and a tagged image",
+				&exportv1.VirtualMachineExportContainerDisk{Volume: "rootdisk", Image: "registry.example.com/vm:v1"}, ""),
+			Entry("and reject an empty volume",
+				&exportv1.VirtualMachineExportContainerDisk{Image: "registry.example.com/vm:v1"}, "spec.containerDisk.volume"),
+			Entry("and reject an empty image",
+				&exportv1.VirtualMachineExportContainerDisk{Volume: "rootdisk"}, "spec.containerDisk.image"),
+			Entry("and reject an image with a digest",
+				&exportv1.VirtualMachineExportContainerDisk{Volume: "rootdisk", Image: "registry.example.com/vm@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}, "spec.containerDisk.image"),
+		)
 	})
 })
 
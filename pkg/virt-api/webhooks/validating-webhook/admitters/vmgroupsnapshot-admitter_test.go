@@ -0,0 +1,177 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Validating VirtualMachineGroupSnapshot Admitter", func() {
+	config, _, kvStore := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+
+	newGroupSnapshot := func() *snapshotv1.VirtualMachineGroupSnapshot {
+		return &snapshotv1.VirtualMachineGroupSnapshot{
+			Spec: snapshotv1.VirtualMachineGroupSnapshotSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "database"}},
+			},
+		}
+	}
+
+	Context("Without feature gate enabled", func() {
+		It("should reject anything", func() {
+			ar := createGroupSnapshotAdmissionReview(newGroupSnapshot())
+			resp := NewVMGroupSnapshotAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(Equal("snapshot feature gate not enabled"))
+		})
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &v1.KubeVirt{
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						DeveloperConfiguration: &v1.DeveloperConfiguration{
+							FeatureGates: []string{"Snapshot"},
+						},
+					},
+				},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &v1.KubeVirt{
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						DeveloperConfiguration: &v1.DeveloperConfiguration{},
+					},
+				},
+			})
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := NewVMGroupSnapshotAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		It("should accept a valid group snapshot", func() {
+			groupSnapshot := newGroupSnapshot()
+			deletionPolicy := snapshotv1.VirtualMachineSnapshotContentRetain
+			groupSnapshot.Spec.DeletionPolicy = &deletionPolicy
+			groupSnapshot.Spec.FailureDeadline = &metav1.Duration{Duration: 10 * time.Minute}
+
+			ar := createGroupSnapshotAdmissionReview(groupSnapshot)
+			resp := NewVMGroupSnapshotAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		DescribeTable("should reject", func(update func(*snapshotv1.VirtualMachineGroupSnapshot), field string) {
+			groupSnapshot := newGroupSnapshot()
+			update(groupSnapshot)
+
+			ar := createGroupSnapshotAdmissionReview(groupSnapshot)
+			resp := NewVMGroupSnapshotAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			Entry("an invalid selector", func(s *snapshotv1.VirtualMachineGroupSnapshot) {
+				s.Spec.Selector = metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "app",
+					Operator: "Unknown",
+				}}}
+			}, "spec.selector"),
+			Entry("an unknown deletion policy", func(s *snapshotv1.VirtualMachineGroupSnapshot) {
+				deletionPolicy := snapshotv1.DeletionPolicy("Keep")
+				s.Spec.DeletionPolicy = &deletionPolicy
+			}, "spec.deletionPolicy"),
+			Entry("a negative failure deadline", func(s *snapshotv1.VirtualMachineGroupSnapshot) {
+				s.Spec.FailureDeadline = &metav1.Duration{Duration: -time.Minute}
+			}, "spec.failureDeadline"),
+		)
+
+		It("should reject spec update", func() {
+			oldGroupSnapshot := newGroupSnapshot()
+			groupSnapshot := newGroupSnapshot()
+			groupSnapshot.Spec.Selector.MatchLabels["app"] = "web"
+			oldBytes, _ := json.Marshal(oldGroupSnapshot)
+
+			ar := createGroupSnapshotAdmissionReview(groupSnapshot)
+			ar.Request.Operation = admissionv1.Update
+			ar.Request.OldObject = runtime.RawExtension{Raw: oldBytes}
+			resp := NewVMGroupSnapshotAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+
+		It("should allow metadata update", func() {
+			oldGroupSnapshot := newGroupSnapshot()
+			groupSnapshot := newGroupSnapshot()
+			groupSnapshot.Labels = map[string]string{"tier": "gold"}
+			oldBytes, _ := json.Marshal(oldGroupSnapshot)
+
+			ar := createGroupSnapshotAdmissionReview(groupSnapshot)
+			ar.Request.Operation = admissionv1.Update
+			ar.Request.OldObject = runtime.RawExtension{Raw: oldBytes}
+			resp := NewVMGroupSnapshotAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+})
+
+func createGroupSnapshotAdmissionReview(groupSnapshot *snapshotv1.VirtualMachineGroupSnapshot) *admissionv1.AdmissionReview {
+	bytes, _ := json.Marshal(groupSnapshot)
+
+	return &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "snapshot.kubevirt.io",
+				Resource: "virtualmachinegroupsnapshots",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
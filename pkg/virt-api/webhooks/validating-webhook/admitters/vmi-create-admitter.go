@@ -48,6 +48,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/hooks"
 	netadmitter "kubevirt.io/kubevirt/pkg/network/admitter"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
+	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -126,6 +127,7 @@ func (admitter *VMICreateAdmitter) Admit(_ context.Context, ar *admissionv1.Admi
 	causes = append(causes, validateVirtualMachineInstanceSpecVolumeDisks(k8sfield.NewPath("spec"), &vmi.Spec)...)
 	causes = append(causes, ValidateVirtualMachineInstanceMandatoryFields(k8sfield.NewPath("spec"), &vmi.Spec)...)
 	causes = append(causes, ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, admitter.ClusterConfig, accountName)...)
+	causes = append(causes, ValidateHypervisorNamespacePolicy(k8sfield.NewPath("spec"), ar.Request.Namespace, &vmi.Spec, admitter.ClusterConfig)...)
 	causes = append(causes, webhooks.ValidateVirtualMachineInstanceHyperv(k8sfield.NewPath("spec").Child("domain").Child("features").Child("hyperv"), &vmi.Spec)...)
 	if webhooks.IsARM64(&vmi.Spec) {
 		// Check if there is any unsupported setting if the arch is Arm64
@@ -159,6 +161,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	volumeNameMap := make(map[string]*v1.Volume)
 
 	causes = append(causes, validateHypervisor(field, spec)...)
+	causes = append(causes, ValidateHypervisorCapabilities(field, spec)...)
 	causes = append(causes, validateHostNameNotConformingToDNSLabelRules(field, spec)...)
 	causes = append(causes, validateSubdomainDNSSubdomainRules(field, spec)...)
 	causes = append(causes, validateMemoryRequestsNegativeOrNull(field, spec)...)
@@ -249,7 +252,7 @@ func validateVirtualMachineInstanceSpecVolumeDisks(field *k8sfield.Path, spec *v
 
 	// Validate that volumes match disks and filesystems correctly
 	for idx, volume := range spec.Volumes {
-		if volume.MemoryDump != nil {
+		if volume.MemoryDump != nil || volume.Backup != nil {
 			continue
 		}
 		if _, matchingDiskExists := diskAndFilesystemNames[volume.Name]; !matchingDiskExists {
@@ -1560,6 +1563,7 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 	serviceAccountVolumeCount := 0
 	downwardMetricVolumeCount := 0
 	memoryDumpVolumeCount := 0
+	backupVolumeCount := 0
 
 	for idx, volume := range volumes {
 		// verify name is unique
@@ -1628,7 +1632,26 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			volumeSourceSetCount++
 		}
 		if volume.MemoryDump != nil {
-			memoryDumpVolumeCount++
+			if volume.MemoryDump.Hotpluggable {
+				memoryDumpVolumeCount++
+			} else if volume.MemoryDump.Type != v1.MemoryDumpTypeState {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be hotpluggable unless it is of type %s", field.Index(idx).Child("memoryDump").String(), v1.MemoryDumpTypeState),
+					Field:   field.Index(idx).Child("memoryDump").String(),
+				})
+			}
+			volumeSourceSetCount++
+		}
+		if volume.Backup != nil {
+			if !volume.Backup.Hotpluggable {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be hotpluggable", field.Index(idx).Child("backup").String()),
+					Field:   field.Index(idx).Child("backup").String(),
+				})
+			}
+			backupVolumeCount++
 			volumeSourceSetCount++
 		}
 
@@ -1844,6 +1867,13 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			Field:   field.String(),
 		})
 	}
+	if backupVolumeCount > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must have max one backup volume set", field.String()),
+			Field:   field.String(),
+		})
+	}
 
 	return causes
 }
@@ -2335,7 +2365,7 @@ func validateCPUHotplug(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpe
 
 func validateHypervisor(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
 	var causes []metav1.StatusCause
-	if hypervisor.NewHypervisor(spec.Hypervisor) == nil {
+	if _, exists := hypervisor.Lookup(spec.Hypervisor); !exists {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
 			Message: fmt.Sprintf("Hypervisor %s is not supported", spec.Hypervisor),
@@ -2344,3 +2374,74 @@ func validateHypervisor(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpe
 	}
 	return causes
 }
+
+// ValidateHypervisorCapabilities rejects VMI features which the hypervisor of the VMI does not support
+func ValidateHypervisorCapabilities(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	capabilities, exists := hypervisor.GetCapabilities(spec.Hypervisor)
+	if !exists {
+		return nil
+	}
+
+	var causes []metav1.StatusCause
+	unsupported := func(feature string, featureField *k8sfield.Path) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is not supported by hypervisor %s", feature, spec.Hypervisor),
+			Field:   featureField.String(),
+		})
+	}
+
+	devices := &spec.Domain.Devices
+	devicesField := field.Child("domain", "devices")
+	// The mutating webhook disables the graphics device of hypervisors which don't support it, unless it is requested
+	if !capabilities.Graphics && devices.AutoattachGraphicsDevice != nil && *devices.AutoattachGraphicsDevice {
+		unsupported("Graphics device", devicesField.Child("autoattachGraphicsDevice"))
+	}
+	if !capabilities.USBRedirection && devices.ClientPassthrough != nil {
+		unsupported("USB redirection", devicesField.Child("clientPassthrough"))
+	}
+	if !capabilities.Watchdog && devices.Watchdog != nil {
+		unsupported("Watchdog device", devicesField.Child("watchdog"))
+	}
+	if !capabilities.Sound && devices.Sound != nil {
+		unsupported("Sound device", devicesField.Child("sound"))
+	}
+	if !capabilities.SEV && spec.Domain.LaunchSecurity != nil && spec.Domain.LaunchSecurity.SEV != nil {
+		unsupported("SEV", field.Child("domain", "launchSecurity", "sev"))
+	}
+	if firmware := spec.Domain.Firmware; !capabilities.SecureBoot && firmware != nil && firmware.Bootloader != nil &&
+		firmware.Bootloader.EFI != nil && (firmware.Bootloader.EFI.SecureBoot == nil || *firmware.Bootloader.EFI.SecureBoot) {
+		unsupported("EFI SecureBoot", field.Child("domain", "firmware", "bootloader", "efi", "secureBoot"))
+	}
+	if !capabilities.PersistentEFI && backendstorage.HasPersistentEFI(spec) {
+		unsupported("Persistent EFI", field.Child("domain", "firmware", "bootloader", "efi", "persistent"))
+	}
+	if cpu := spec.Domain.CPU; !capabilities.CPUHotplug && cpu != nil && cpu.MaxSockets > max(cpu.Sockets, 1) {
+		unsupported("CPU hotplug", field.Child("domain", "cpu", "maxSockets"))
+	}
+	if memory := spec.Domain.Memory; !capabilities.MemoryHotplug && memory != nil && memory.MaxGuest != nil &&
+		(memory.Guest == nil || memory.MaxGuest.Cmp(*memory.Guest) > 0) {
+		unsupported("Memory hotplug", field.Child("domain", "memory", "maxGuest"))
+	}
+	if !capabilities.DiskHotplug {
+		for i, volume := range spec.Volumes {
+			if storagetypes.IsHotplugVolume(&volume) {
+				unsupported("Disk hotplug", field.Child("volumes").Index(i))
+			}
+		}
+	}
+
+	return causes
+}
+
+// ValidateHypervisorNamespacePolicy rejects hypervisors which VMIs in the namespace are not entitled to use
+func ValidateHypervisorNamespacePolicy(field *k8sfield.Path, namespace string, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	if config.IsHypervisorAllowed(namespace, spec.Hypervisor) {
+		return nil
+	}
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Message: fmt.Sprintf("Hypervisor %s is not allowed in namespace %s", spec.Hypervisor, namespace),
+		Field:   field.Child("hypervisor").String(),
+	}}
+}
@@ -120,6 +120,87 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		Expect(resp.Result.Details.Causes).To(HaveLen(1))
 		Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.domain.devices.disks[0].name"))
 	})
+	DescribeTable("should apply the hypervisor namespace policy", func(namespace, hypervisor string, allowed bool) {
+		kvConfig := kv.DeepCopy()
+		kvConfig.Spec.Configuration.HypervisorConfiguration = &v1.HypervisorConfiguration{
+			NamespacePolicies: []v1.HypervisorNamespacePolicy{{
+				Namespaces:         []string{"mshv-tenant"},
+				AllowedHypervisors: []string{"ch"},
+			}},
+		}
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvConfig)
+
+		vmi := newBaseVmi(libvmi.WithHypervisor(hypervisor), libvmi.WithAutoattachGraphicsDevice(false))
+		vmiBytes, _ := json.Marshal(&vmi)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Namespace: namespace,
+				Resource:  webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmiBytes,
+				},
+			},
+		}
+
+		resp := vmiCreateAdmitter.Admit(context.Background(), ar)
+		Expect(resp.Allowed).To(Equal(allowed))
+		if !allowed {
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.hypervisor"))
+		}
+	},
+		Entry("accept an allowed hypervisor", "mshv-tenant", "ch", true),
+		Entry("reject a hypervisor the namespace is not entitled to", "mshv-tenant", "qemu", false),
+		Entry("accept any hypervisor in namespaces without a policy", "default", "qemu", true),
+	)
+	DescribeTable("should validate the hypervisor capabilities", func(hypervisor string, option libvmi.Option, expectedField string) {
+		vmi := libvmi.New(libvmi.WithHypervisor(hypervisor), libvmi.WithAutoattachGraphicsDevice(false), option)
+
+		causes := ValidateHypervisorCapabilities(k8sfield.NewPath("spec"), &vmi.Spec)
+		if expectedField == "" {
+			Expect(causes).To(BeEmpty())
+			return
+		}
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueNotSupported))
+		Expect(causes[0].Field).To(Equal(expectedField))
+	},
+		Entry("accept graphics with qemu", "qemu", libvmi.WithAutoattachGraphicsDevice(true), ""),
+		Entry("reject graphics with ch", "ch", libvmi.WithAutoattachGraphicsDevice(true), "spec.domain.devices.autoattachGraphicsDevice"),
+		Entry("accept disabled graphics with ch", "ch", libvmi.WithAutoattachGraphicsDevice(false), ""),
+		Entry("accept default graphics with ch", "ch", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = nil
+		}, ""),
+		Entry("accept default graphics with qemu", "qemu", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = nil
+		}, ""),
+		Entry("reject watchdog with ch", "ch", libvmi.WithWatchdog(v1.WatchdogActionPoweroff), "spec.domain.devices.watchdog"),
+		Entry("reject SEV with ch", "ch", libvmi.WithSEV(false), "spec.domain.launchSecurity.sev"),
+		Entry("reject graphics with ch-kvm", "ch-kvm", libvmi.WithAutoattachGraphicsDevice(true), "spec.domain.devices.autoattachGraphicsDevice"),
+		Entry("accept CPU hotplug with qemu", "qemu", withMaxSockets(2, 4), ""),
+		Entry("reject CPU hotplug with ch", "ch", withMaxSockets(2, 4), "spec.domain.cpu.maxSockets"),
+		Entry("accept a fixed CPU topology with ch", "ch", withMaxSockets(2, 2), ""),
+		Entry("reject memory hotplug with ch", "ch", func(vmi *v1.VirtualMachineInstance) {
+			libvmi.WithGuestMemory("1Gi")(vmi)
+			libvmi.WithMaxGuest("4Gi")(vmi)
+		}, "spec.domain.memory.maxGuest"),
+		Entry("reject disk hotplug with ch", "ch", func(vmi *v1.VirtualMachineInstance) {
+			libvmi.WithDataVolume("hotplug", "hotplug-dv")(vmi)
+			vmi.Spec.Volumes[0].DataVolume.Hotpluggable = true
+		}, "spec.volumes[0]"),
+		Entry("reject sound with ch", "ch", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Sound = &v1.SoundDevice{Name: "audio"}
+		}, "spec.domain.devices.sound"),
+		Entry("reject USB redirection with ch", "ch", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.ClientPassthrough = &v1.ClientPassthroughDevices{}
+		}, "spec.domain.devices.clientPassthrough"),
+		Entry("accept EFI SecureBoot with qemu", "qemu", libvmi.WithUefi(true), ""),
+		Entry("reject EFI SecureBoot with ch", "ch", libvmi.WithUefi(true), "spec.domain.firmware.bootloader.efi.secureBoot"),
+		Entry("accept EFI without SecureBoot with ch", "ch", libvmi.WithUefi(false), ""),
+		Entry("accept persistent EFI with qemu", "qemu", withPersistentEFI, ""),
+		Entry("reject persistent EFI with ch", "ch", withPersistentEFI, "spec.domain.firmware.bootloader.efi.persistent"),
+	)
 	It("should reject VMIs without memory after presets were applied", func() {
 		vmi := newBaseVmi()
 		vmi.Spec.Domain.Resources = v1.ResourceRequirements{}
@@ -2741,6 +2822,83 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(HaveLen(1))
 			Expect(causes[0].Message).To(ContainSubstring("fake must have max one memory dump volume set"))
 		})
+		It("should accept a memory state volume next to a memoryDump volume", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+
+			stateSource := testutils.NewFakeMemoryDumpSource("testMemoryState")
+			stateSource.Hotpluggable = false
+			stateSource.Type = v1.MemoryDumpTypeState
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes,
+				v1.Volume{
+					Name: "testMemoryDump",
+					VolumeSource: v1.VolumeSource{
+						MemoryDump: testutils.NewFakeMemoryDumpSource("testMemoryDump"),
+					},
+				},
+				v1.Volume{
+					Name: "testMemoryState",
+					VolumeSource: v1.VolumeSource{
+						MemoryDump: stateSource,
+					},
+				},
+			)
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject a memoryDump volume which is not hotpluggable and not of type State", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+
+			source := testutils.NewFakeMemoryDumpSource("testMemoryDump")
+			source.Hotpluggable = false
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testMemoryDump",
+				VolumeSource: v1.VolumeSource{
+					MemoryDump: source,
+				},
+			})
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake[0].memoryDump"))
+		})
+		newBackupVolume := func(name string, hotpluggable bool) v1.Volume {
+			return v1.Volume{
+				Name: name,
+				VolumeSource: v1.VolumeSource{
+					Backup: &v1.BackupVolumeSource{
+						PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: name,
+							},
+							Hotpluggable: hotpluggable,
+						},
+						Checkpoint: "testvm-checkpoint",
+					},
+				},
+			}
+		}
+		It("should accept a single backup volume without a matching disk", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, newBackupVolume("testBackup", true))
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject backup volumes if more than one exist", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, newBackupVolume("testBackup", true), newBackupVolume("testBackup2", true))
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring("fake must have max one backup volume set"))
+		})
+		It("should reject a backup volume which is not hotpluggable", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, newBackupVolume("testBackup", false))
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake[0].backup"))
+		})
 
 	})
 
@@ -4393,3 +4551,14 @@ func newBaseVmi(opts ...libvmi.Option) *v1.VirtualMachineInstance {
 	opts = append(opts, libvmi.WithResourceMemory("512Mi"))
 	return libvmi.New(opts...)
 }
+
+func withMaxSockets(sockets, maxSockets uint32) libvmi.Option {
+	return func(vmi *v1.VirtualMachineInstance) {
+		vmi.Spec.Domain.CPU = &v1.CPU{Sockets: sockets, MaxSockets: maxSockets}
+	}
+}
+
+func withPersistentEFI(vmi *v1.VirtualMachineInstance) {
+	libvmi.WithUefi(false)(vmi)
+	vmi.Spec.Domain.Firmware.Bootloader.EFI.Persistent = kubevirtpointer.P(true)
+}
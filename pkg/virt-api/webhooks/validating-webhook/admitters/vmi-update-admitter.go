@@ -131,7 +131,7 @@ func (admitter *VMIUpdateAdmitter) Admit(_ context.Context, ar *admissionv1.Admi
 func getExpectedDisksAndFilesystems(newVolumes []v1.Volume) int {
 	numMemoryDumpVolumes := 0
 	for _, volume := range newVolumes {
-		if volume.MemoryDump != nil {
+		if volume.MemoryDump != nil || volume.Backup != nil {
 			numMemoryDumpVolumes = numMemoryDumpVolumes + 1
 		}
 	}
@@ -189,7 +189,7 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 					},
 				})
 			}
-			if v.MemoryDump == nil {
+			if v.MemoryDump == nil && v.Backup == nil {
 				if _, ok := newDisks[k]; !ok {
 					return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 						{
@@ -208,8 +208,8 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 				}
 			}
 		} else {
-			// This is a new volume, ensure that the volume is either DV, PVC or memoryDumpVolume
-			if v.DataVolume == nil && v.PersistentVolumeClaim == nil && v.MemoryDump == nil {
+			// This is a new volume, ensure that the volume is either DV, PVC, memoryDumpVolume or backupVolume
+			if v.DataVolume == nil && v.PersistentVolumeClaim == nil && v.MemoryDump == nil && v.Backup == nil {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 					{
 						Type:    metav1.CauseTypeFieldValueInvalid,
@@ -217,7 +217,7 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 					},
 				})
 			}
-			if v.MemoryDump == nil {
+			if v.MemoryDump == nil && v.Backup == nil {
 				// Also ensure the matching new disk exists and is of type scsi
 				if _, ok := newDisks[k]; !ok {
 					return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
@@ -23,13 +23,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/cache"
 
@@ -38,7 +42,9 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
+	"kubevirt.io/kubevirt/pkg/storage/snapshot"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
@@ -85,6 +91,15 @@ func (admitter *VMRestoreAdmitter) Admit(ctx context.Context, ar *admissionv1.Ad
 		var targetUID *types.UID
 		targetField := k8sfield.NewPath("spec", "target")
 
+		// the target is only looked at if the user is allowed to restore into its namespace
+		causes, err = admitter.authorizeTargetNamespace(ctx, ar.Request.UserInfo, ar.Request.Namespace, vmRestore)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+		if len(causes) > 0 {
+			return webhookutils.ToAdmissionResponse(causes)
+		}
+
 		if vmRestore.Spec.Target.APIGroup == nil {
 			causes = []metav1.StatusCause{
 				{
@@ -134,14 +149,30 @@ func (admitter *VMRestoreAdmitter) Admit(ctx context.Context, ar *admissionv1.Ad
 			return webhookutils.ToAdmissionResponseError(err)
 		}
 
+		volumeCauses, err := admitter.validateVolumeRestores(ctx, k8sfield.NewPath("spec"), vmRestore)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+		causes = append(causes, volumeCauses...)
+		causes = append(causes, validateNewMacAddresses(vmRestore.Spec.NewMacAddresses, k8sfield.NewPath("spec", "newMacAddresses"))...)
+
+		targetNamespace := snapshot.RestoreTargetNamespace(vmRestore)
 		objects, err := admitter.VMRestoreInformer.GetIndexer().ByIndex(cache.NamespaceIndex, ar.Request.Namespace)
 		if err != nil {
 			return webhookutils.ToAdmissionResponseError(err)
 		}
+		if targetNamespace != ar.Request.Namespace {
+			targetNamespaceObjects, err := admitter.VMRestoreInformer.GetIndexer().ByIndex(cache.NamespaceIndex, targetNamespace)
+			if err != nil {
+				return webhookutils.ToAdmissionResponseError(err)
+			}
+			objects = append(objects, targetNamespaceObjects...)
+		}
 
 		for _, obj := range objects {
 			r := obj.(*snapshotv1.VirtualMachineRestore)
 			if equality.Semantic.DeepEqual(r.Spec.Target, vmRestore.Spec.Target) &&
+				snapshot.RestoreTargetNamespace(r) == targetNamespace &&
 				(r.Status == nil || r.Status.Complete == nil || !*r.Status.Complete) {
 				cause := metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
@@ -184,14 +215,102 @@ func (admitter *VMRestoreAdmitter) Admit(ctx context.Context, ar *admissionv1.Ad
 	return &reviewResponse
 }
 
+// authorizeTargetNamespace checks if the user is allowed to create the restored VM and its PVCs in the
+// target namespace, since the restore controller creates them on behalf of the user. Restoring over an
+// existing VM also updates it and replaces its DataVolumes and PVCs, so that access is checked as well.
+func (admitter *VMRestoreAdmitter) authorizeTargetNamespace(ctx context.Context, userInfo authenticationv1.UserInfo, namespace string, vmRestore *snapshotv1.VirtualMachineRestore) ([]metav1.StatusCause, error) {
+	targetNamespace := snapshot.RestoreTargetNamespace(vmRestore)
+	if vmRestore.Spec.TargetNamespace == nil || targetNamespace == namespace {
+		return nil, nil
+	}
+
+	type resourceAccess struct {
+		verb     string
+		group    string
+		resource string
+	}
+	accesses := []resourceAccess{
+		{verb: "create", group: core.GroupName, resource: "virtualmachines"},
+		{verb: "create", group: "", resource: "persistentvolumeclaims"},
+	}
+
+	if vmRestore.Spec.Target.Kind == "VirtualMachine" {
+		_, err := admitter.Client.VirtualMachine(targetNamespace).Get(ctx, vmRestore.Spec.Target.Name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			accesses = append(accesses,
+				resourceAccess{verb: "update", group: core.GroupName, resource: "virtualmachines"},
+				resourceAccess{verb: "delete", group: cdiv1.SchemeGroupVersion.Group, resource: "datavolumes"},
+				resourceAccess{verb: "delete", group: "", resource: "persistentvolumeclaims"},
+			)
+		case !errors.IsNotFound(err):
+			return nil, err
+		}
+	}
+
+	extra := map[string]authv1.ExtraValue{}
+	for key, value := range userInfo.Extra {
+		extra[key] = authv1.ExtraValue(value)
+	}
+
+	var causes []metav1.StatusCause
+	for _, a := range accesses {
+		sar := &authv1.SubjectAccessReview{
+			Spec: authv1.SubjectAccessReviewSpec{
+				User:   userInfo.Username,
+				Groups: userInfo.Groups,
+				UID:    userInfo.UID,
+				Extra:  extra,
+				ResourceAttributes: &authv1.ResourceAttributes{
+					Namespace: targetNamespace,
+					Verb:      a.verb,
+					Group:     a.group,
+					Resource:  a.resource,
+				},
+			},
+		}
+
+		response, err := admitter.Client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if response.Status.Allowed {
+			continue
+		}
+
+		message := fmt.Sprintf("user %s is not allowed to %s %s in namespace %s", userInfo.Username, a.verb, a.resource, targetNamespace)
+		if response.Status.Reason != "" {
+			message = fmt.Sprintf("%s: %s", message, response.Status.Reason)
+		}
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   k8sfield.NewPath("spec", "targetNamespace").String(),
+		})
+	}
+
+	return causes, nil
+}
+
 func (admitter *VMRestoreAdmitter) validateCreateVM(ctx context.Context, field *k8sfield.Path, vmRestore *snapshotv1.VirtualMachineRestore) (causes []metav1.StatusCause, uid *types.UID, targetVMExists bool, err error) {
 	vmName := vmRestore.Spec.Target.Name
-	namespace := vmRestore.Namespace
+	namespace := snapshot.RestoreTargetNamespace(vmRestore)
 
 	causes = admitter.validatePatches(vmRestore.Spec.Patches, field.Child("patches"))
 
+	restoreMode := snapshot.GetRestoreMode(vmRestore)
+
 	vm, err := admitter.Client.VirtualMachine(namespace).Get(ctx, vmName, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
+		if restoreMode == snapshotv1.RestoreModeVolumesOnly || restoreMode == snapshotv1.RestoreModeHotplug {
+			return []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("restore mode %s requires VirtualMachine %q to exist", restoreMode, vmName),
+					Field:   field.Child("restoreMode").String(),
+				},
+			}, nil, false, nil
+		}
 		// If the target VM does not exist it would be automatically created by the restore controller
 		return nil, nil, false, nil
 	}
@@ -200,6 +319,19 @@ func (admitter *VMRestoreAdmitter) validateCreateVM(ctx context.Context, field *
 		return nil, nil, false, err
 	}
 
+	if namespace != vmRestore.Namespace {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("VirtualMachine %q already exists in namespace %q, restoring into another namespace requires a new VM", vmName, namespace),
+			Field:   field.Child("targetNamespace").String(),
+		})
+	}
+
+	// restored volumes are hotplugged to the target VM while it keeps running
+	if restoreMode == snapshotv1.RestoreModeHotplug {
+		return causes, &vm.UID, true, nil
+	}
+
 	rs, err := vm.RunStrategy()
 	if err != nil {
 		return nil, nil, true, err
@@ -227,6 +359,176 @@ func (admitter *VMRestoreAdmitter) validateCreateVM(ctx context.Context, field *
 	return causes, &vm.UID, true, nil
 }
 
+func (admitter *VMRestoreAdmitter) validateVolumeRestores(ctx context.Context, field *k8sfield.Path, vmRestore *snapshotv1.VirtualMachineRestore) ([]metav1.StatusCause, error) {
+	var causes []metav1.StatusCause
+
+	policy := vmRestore.Spec.VolumeRestorePolicy
+	if policy != nil &&
+		*policy != snapshotv1.VolumeRestorePolicyRandomizeNames &&
+		*policy != snapshotv1.VolumeRestorePolicyPrefixTargetName {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("volume restore policy %q is not supported", *policy),
+			Field:   field.Child("volumeRestorePolicy").String(),
+		})
+	}
+
+	switch restoreMode := snapshot.GetRestoreMode(vmRestore); restoreMode {
+	case snapshotv1.RestoreModeFull, snapshotv1.RestoreModeVolumesOnly:
+	case snapshotv1.RestoreModeHotplug:
+		if !admitter.Config.HotplugVolumesEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("restore mode %s requires the HotplugVolumes feature gate", restoreMode),
+				Field:   field.Child("restoreMode").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("restore mode %q is not supported", restoreMode),
+			Field:   field.Child("restoreMode").String(),
+		})
+	}
+
+	if len(vmRestore.Spec.IncludedVolumes) > 0 && len(vmRestore.Spec.ExcludedVolumes) > 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "includedVolumes and excludedVolumes are mutually exclusive",
+			Field:   field.Child("excludedVolumes").String(),
+		})
+	}
+
+	overridesField := field.Child("volumeRestoreOverrides")
+	volumeNames := make(map[string]bool)
+	restoreNames := make(map[string]bool)
+	for i, override := range vmRestore.Spec.VolumeRestoreOverrides {
+		if volumeNames[override.VolumeName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("volume %q is overridden more than once", override.VolumeName),
+				Field:   overridesField.Index(i).Child("volumeName").String(),
+			})
+		}
+		volumeNames[override.VolumeName] = true
+
+		for _, msg := range validation.IsDNS1123Subdomain(override.RestoreName) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("restore name %q is invalid: %s", override.RestoreName, msg),
+				Field:   overridesField.Index(i).Child("restoreName").String(),
+			})
+		}
+		if restoreNames[override.RestoreName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("restore name %q is used more than once", override.RestoreName),
+				Field:   overridesField.Index(i).Child("restoreName").String(),
+			})
+		}
+		restoreNames[override.RestoreName] = true
+	}
+
+	prefixTargetName := policy != nil && *policy == snapshotv1.VolumeRestorePolicyPrefixTargetName
+	deterministicNames := prefixTargetName || len(vmRestore.Spec.VolumeRestoreOverrides) > 0
+	selectsVolumes := len(vmRestore.Spec.IncludedVolumes) > 0 || len(vmRestore.Spec.ExcludedVolumes) > 0 ||
+		snapshot.GetRestoreMode(vmRestore) != snapshotv1.RestoreModeFull
+	if len(causes) > 0 || (!deterministicNames && !selectsVolumes) {
+		return causes, nil
+	}
+
+	vmSnapshot, err := admitter.Client.VirtualMachineSnapshot(vmRestore.Namespace).Get(ctx, vmRestore.Spec.VirtualMachineSnapshotName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		// reported by the snapshot validation
+		return causes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vmSnapshot.Status == nil || vmSnapshot.Status.VirtualMachineSnapshotContentName == nil {
+		return causes, nil
+	}
+
+	content, err := snapshot.GetSnapshotContents(vmSnapshot, admitter.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	causes = append(causes, validateVolumeSelection(field, vmRestore, content)...)
+
+	restoredNames := snapshot.RestoredVolumeNames(vmRestore, content)
+	for i, override := range vmRestore.Spec.VolumeRestoreOverrides {
+		if _, ok := restoredNames[override.VolumeName]; !ok {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %q is not restored from VirtualMachineSnapshot %q", override.VolumeName, vmSnapshot.Name),
+				Field:   overridesField.Index(i).Child("volumeName").String(),
+			})
+		}
+	}
+
+	// randomized names can't collide with existing PVCs
+	if !deterministicNames {
+		return causes, nil
+	}
+
+	namespace := snapshot.RestoreTargetNamespace(vmRestore)
+	volumes := make([]string, 0, len(restoredNames))
+	for volumeName := range restoredNames {
+		volumes = append(volumes, volumeName)
+	}
+	sort.Strings(volumes)
+	for _, volumeName := range volumes {
+		pvcName := restoredNames[volumeName]
+		_, err := admitter.Client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, pvcName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("restored PersistentVolumeClaim %q of volume %q already exists in namespace %q", pvcName, volumeName, namespace),
+			Field:   field.Child("volumeRestorePolicy").String(),
+		})
+	}
+
+	return causes, nil
+}
+
+func validateVolumeSelection(field *k8sfield.Path, vmRestore *snapshotv1.VirtualMachineRestore, content *snapshotv1.VirtualMachineSnapshotContent) (causes []metav1.StatusCause) {
+	backups := make(map[string]bool)
+	for _, vb := range content.Spec.VolumeBackups {
+		backups[vb.VolumeName] = true
+	}
+
+	validateVolumes := func(volumes []string, field *k8sfield.Path) {
+		for i, volumeName := range volumes {
+			if !backups[volumeName] {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("volume %q is not part of VirtualMachineSnapshot %q", volumeName, vmRestore.Spec.VirtualMachineSnapshotName),
+					Field:   field.Index(i).String(),
+				})
+			}
+		}
+	}
+	validateVolumes(vmRestore.Spec.IncludedVolumes, field.Child("includedVolumes"))
+	validateVolumes(vmRestore.Spec.ExcludedVolumes, field.Child("excludedVolumes"))
+
+	if restoreMode := snapshot.GetRestoreMode(vmRestore); restoreMode != snapshotv1.RestoreModeFull &&
+		len(snapshot.RestoredVolumeNames(vmRestore, content)) == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("restore mode %s requires at least one volume to restore", restoreMode),
+			Field:   field.Child("restoreMode").String(),
+		})
+	}
+
+	return causes
+}
+
 func (admitter *VMRestoreAdmitter) validatePatches(patches []string, field *k8sfield.Path) (causes []metav1.StatusCause) {
 	// Validate patches are either on labels/annotations or on elements under "/spec/" path only
 	for _, patch := range patches {
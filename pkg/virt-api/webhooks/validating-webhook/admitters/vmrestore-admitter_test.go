@@ -29,12 +29,16 @@ import (
 	. "github.com/onsi/gomega"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
 
 	v1 "kubevirt.io/api/core/v1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
@@ -88,12 +92,12 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 	})
 
 	Context("With feature gate enabled", func() {
-		enableFeatureGate := func(featureGate string) {
+		enableFeatureGates := func(featureGates ...string) {
 			testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &v1.KubeVirt{
 				Spec: v1.KubeVirtSpec{
 					Configuration: v1.KubeVirtConfiguration{
 						DeveloperConfiguration: &v1.DeveloperConfiguration{
-							FeatureGates: []string{featureGate},
+							FeatureGates: featureGates,
 						},
 					},
 				},
@@ -112,7 +116,7 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 		}
 
 		BeforeEach(func() {
-			enableFeatureGate("Snapshot")
+			enableFeatureGates("Snapshot")
 		})
 
 		AfterEach(func() {
@@ -606,6 +610,288 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 				})
 			})
 
+			Context("when restoring volumes", func() {
+				const targetVMName = "new-vm"
+
+				var restore *snapshotv1.VirtualMachineRestore
+				var snapshotWithContent *snapshotv1.VirtualMachineSnapshot
+				var content *snapshotv1.VirtualMachineSnapshotContent
+
+				BeforeEach(func() {
+					restore = &snapshotv1.VirtualMachineRestore{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "restore",
+							Namespace: "default",
+						},
+						Spec: snapshotv1.VirtualMachineRestoreSpec{
+							Target: corev1.TypedLocalObjectReference{
+								APIGroup: &apiGroup,
+								Kind:     "VirtualMachine",
+								Name:     targetVMName,
+							},
+							VirtualMachineSnapshotName: vmSnapshotName,
+						},
+					}
+
+					snapshotWithContent = snapshot.DeepCopy()
+					snapshotWithContent.Status.VirtualMachineSnapshotContentName = pointer.P("content")
+					content = &snapshotv1.VirtualMachineSnapshotContent{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "content",
+							Namespace: "default",
+						},
+						Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
+							Source: snapshotv1.SourceSpec{
+								VirtualMachine: &snapshotv1.VirtualMachine{
+									Spec: v1.VirtualMachineSpec{
+										Template: &v1.VirtualMachineInstanceTemplateSpec{},
+									},
+								},
+							},
+							VolumeBackups: []snapshotv1.VolumeBackup{
+								{VolumeName: "disk1"},
+							},
+						},
+					}
+				})
+
+				It("should reject an unsupported volume restore policy", func() {
+					restore.Spec.VolumeRestorePolicy = pointer.P(snapshotv1.VolumeRestorePolicy("Unknown"))
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshot).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.volumeRestorePolicy"))
+				})
+
+				It("should reject invalid volume restore overrides", func() {
+					restore.Spec.VolumeRestoreOverrides = []snapshotv1.VolumeRestoreOverride{
+						{VolumeName: "disk1", RestoreName: "Invalid_Name"},
+						{VolumeName: "disk1", RestoreName: "restored"},
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshot).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(2))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.volumeRestoreOverrides[0].restoreName"))
+					Expect(resp.Result.Details.Causes[1].Field).To(Equal("spec.volumeRestoreOverrides[1].volumeName"))
+				})
+
+				It("should reject overrides of volumes missing from the snapshot", func() {
+					restore.Spec.VolumeRestoreOverrides = []snapshotv1.VolumeRestoreOverride{
+						{VolumeName: "disk2", RestoreName: "restored"},
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshotWithContent, content).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.volumeRestoreOverrides[0].volumeName"))
+				})
+
+				DescribeTable("should reject when the restored PVC already exists", func(namespace string) {
+					restore.Spec.VolumeRestorePolicy = pointer.P(snapshotv1.VolumeRestorePolicyPrefixTargetName)
+					if namespace != restore.Namespace {
+						restore.Spec.TargetNamespace = pointer.P(namespace)
+					}
+					pvc := &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      targetVMName + "-disk1",
+							Namespace: namespace,
+						},
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshotWithContent, content, pvc).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("already exists"))
+				},
+					Entry("in the namespace of the restore", "default"),
+					Entry("in the target namespace", "other"),
+				)
+
+				It("should accept restored PVC names which are free", func() {
+					restore.Spec.VolumeRestorePolicy = pointer.P(snapshotv1.VolumeRestorePolicyPrefixTargetName)
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshotWithContent, content).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+
+				It("should reject restoring into another namespace over an existing VM", func() {
+					restore.Spec.Target.Name = vmName
+					restore.Spec.TargetNamespace = pointer.P("other")
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshot).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.targetNamespace"))
+				})
+
+				DescribeTable("should review access to the target namespace", func(deniedAccess string, allowed bool) {
+					restore.Spec.TargetNamespace = pointer.P("other")
+					var reviewed []string
+					reviewAccess := func(sar *authv1.SubjectAccessReview) bool {
+						Expect(sar.Spec.User).To(Equal("user"))
+						Expect(sar.Spec.ResourceAttributes.Namespace).To(Equal("other"))
+						access := sar.Spec.ResourceAttributes.Verb + " " + sar.Spec.ResourceAttributes.Resource
+						reviewed = append(reviewed, access)
+						return access != deniedAccess
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					ar.Request.UserInfo = authenticationv1.UserInfo{Username: "user"}
+					resp := createTestVMRestoreAdmitterWithAccess(config, nil, reviewAccess, snapshot).Admit(context.Background(), ar)
+					Expect(reviewed).To(ConsistOf("create virtualmachines", "create persistentvolumeclaims"))
+					Expect(resp.Allowed).To(Equal(allowed))
+					if !allowed {
+						Expect(resp.Result.Details.Causes).To(HaveLen(1))
+						Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.targetNamespace"))
+						Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring(deniedAccess))
+					}
+				},
+					Entry("allow users who may create VMs and PVCs", "", true),
+					Entry("reject users who may not create VMs", "create virtualmachines", false),
+					Entry("reject users who may not create PVCs", "create persistentvolumeclaims", false),
+				)
+
+				DescribeTable("should review access to replace an existing VM in the target namespace", func(deniedAccess string) {
+					restore.Spec.Target.Name = vmName
+					restore.Spec.TargetNamespace = pointer.P("other")
+					var reviewed []string
+					reviewAccess := func(sar *authv1.SubjectAccessReview) bool {
+						Expect(sar.Spec.ResourceAttributes.Namespace).To(Equal("other"))
+						access := sar.Spec.ResourceAttributes.Verb + " " + sar.Spec.ResourceAttributes.Resource
+						reviewed = append(reviewed, access)
+						return access != deniedAccess
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					ar.Request.UserInfo = authenticationv1.UserInfo{Username: "user"}
+					resp := createTestVMRestoreAdmitterWithAccess(config, vm, reviewAccess, snapshot).Admit(context.Background(), ar)
+					Expect(reviewed).To(ConsistOf(
+						"create virtualmachines",
+						"create persistentvolumeclaims",
+						"update virtualmachines",
+						"delete datavolumes",
+						"delete persistentvolumeclaims",
+					))
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.targetNamespace"))
+					Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring(deniedAccess))
+				},
+					Entry("reject users who may not update VMs", "update virtualmachines"),
+					Entry("reject users who may not delete DataVolumes", "delete datavolumes"),
+					Entry("reject users who may not delete PVCs", "delete persistentvolumeclaims"),
+				)
+
+				It("should reject a malformed new MAC address", func() {
+					restore.Spec.NewMacAddresses = map[string]string{"default": "not-a-mac"}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshot).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.newMacAddresses.default"))
+				})
+
+				It("should reject both included and excluded volumes", func() {
+					restore.Spec.IncludedVolumes = []string{"disk1"}
+					restore.Spec.ExcludedVolumes = []string{"disk2"}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshot).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.excludedVolumes"))
+				})
+
+				DescribeTable("should reject selected volumes missing from the snapshot", func(included, excluded []string, field string) {
+					restore.Spec.IncludedVolumes = included
+					restore.Spec.ExcludedVolumes = excluded
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshotWithContent, content).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+				},
+					Entry("included", []string{"disk1", "disk2"}, nil, "spec.includedVolumes[1]"),
+					Entry("excluded", nil, []string{"disk2"}, "spec.excludedVolumes[0]"),
+				)
+
+				It("should accept restoring selected volumes", func() {
+					restore.Spec.IncludedVolumes = []string{"disk1"}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshotWithContent, content).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+
+				It("should reject an unsupported restore mode", func() {
+					restore.Spec.RestoreMode = pointer.P(snapshotv1.RestoreMode("Unknown"))
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshot).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.restoreMode"))
+				})
+
+				DescribeTable("should reject restoring volumes of a VM which does not exist", func(restoreMode snapshotv1.RestoreMode) {
+					enableFeatureGates("Snapshot", "HotplugVolumes")
+					restore.Spec.RestoreMode = pointer.P(restoreMode)
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshotWithContent, content).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.restoreMode"))
+				},
+					Entry("only", snapshotv1.RestoreModeVolumesOnly),
+					Entry("as hotplug disks", snapshotv1.RestoreModeHotplug),
+				)
+
+				It("should reject restoring volumes only when no volume is selected", func() {
+					restore.Spec.Target.Name = vmName
+					restore.Spec.RestoreMode = pointer.P(snapshotv1.RestoreModeVolumesOnly)
+					restore.Spec.ExcludedVolumes = []string{"disk1"}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshotWithContent, content).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.restoreMode"))
+				})
+
+				It("should accept hotplugging restored volumes to a running VM", func() {
+					enableFeatureGates("Snapshot", "HotplugVolumes")
+					restore.Spec.Target.Name = vmName
+					restore.Spec.RestoreMode = pointer.P(snapshotv1.RestoreModeHotplug)
+					vm.Spec.RunStrategy = pointer.P(v1.RunStrategyAlways)
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshotWithContent, content).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+
+				It("should reject hotplugging restored volumes without the HotplugVolumes feature gate", func() {
+					restore.Spec.Target.Name = vmName
+					restore.Spec.RestoreMode = pointer.P(snapshotv1.RestoreModeHotplug)
+					vm.Spec.RunStrategy = pointer.P(v1.RunStrategyAlways)
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshotWithContent, content).Admit(context.Background(), ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.restoreMode"))
+				})
+			})
 		})
 	})
 })
@@ -658,14 +944,46 @@ func createTestVMRestoreAdmitter(
 	config *virtconfig.ClusterConfig,
 	vm *v1.VirtualMachine,
 	objs ...runtime.Object,
+) *VMRestoreAdmitter {
+	allowAll := func(*authv1.SubjectAccessReview) bool { return true }
+	return createTestVMRestoreAdmitterWithAccess(config, vm, allowAll, objs...)
+}
+
+func createTestVMRestoreAdmitterWithAccess(
+	config *virtconfig.ClusterConfig,
+	vm *v1.VirtualMachine,
+	reviewAccess func(*authv1.SubjectAccessReview) bool,
+	objs ...runtime.Object,
 ) *VMRestoreAdmitter {
 	ctrl := gomock.NewController(GinkgoT())
 	virtClient := kubecli.NewMockKubevirtClient(ctrl)
 	vmInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
-	kubevirtClient := kubevirtfake.NewSimpleClientset(objs...)
+
+	var kubevirtObjs, k8sObjs []runtime.Object
+	for _, obj := range objs {
+		if _, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+			k8sObjs = append(k8sObjs, obj)
+		} else {
+			kubevirtObjs = append(kubevirtObjs, obj)
+		}
+	}
+	kubevirtClient := kubevirtfake.NewSimpleClientset(kubevirtObjs...)
+	k8sClient := k8sfake.NewSimpleClientset(k8sObjs...)
+	k8sClient.Fake.PrependReactor("create", "subjectaccessreviews", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+		sar := action.(testing.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+		sar.Status.Allowed = reviewAccess(sar)
+		if !sar.Status.Allowed {
+			sar.Status.Reason = "no RBAC policy matched"
+		}
+		return true, sar, nil
+	})
 
 	virtClient.EXPECT().VirtualMachineSnapshot("default").
 		Return(kubevirtClient.SnapshotV1beta1().VirtualMachineSnapshots("default")).AnyTimes()
+	virtClient.EXPECT().VirtualMachineSnapshotContent("default").
+		Return(kubevirtClient.SnapshotV1beta1().VirtualMachineSnapshotContents("default")).AnyTimes()
+	virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+	virtClient.EXPECT().AuthorizationV1().Return(k8sClient.AuthorizationV1()).AnyTimes()
 	virtClient.EXPECT().VirtualMachine(gomock.Any()).Return(vmInterface).AnyTimes()
 
 	restoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
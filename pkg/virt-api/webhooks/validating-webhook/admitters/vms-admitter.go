@@ -182,6 +182,9 @@ func (admitter *VMsAdmitter) Admit(ctx context.Context, ar *admissionv1.Admissio
 		}
 	}
 	causes = ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vmCopy.Spec, admitter.ClusterConfig, accountName)
+	if vmCopy.Spec.Template != nil {
+		causes = append(causes, ValidateHypervisorNamespacePolicy(k8sfield.NewPath("spec", "template", "spec"), ar.Request.Namespace, &vmCopy.Spec.Template.Spec, admitter.ClusterConfig)...)
+	}
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
 	}
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -94,7 +95,7 @@ func (admitter *VMSnapshotAdmitter) Admit(ctx context.Context, ar *admissionv1.A
 		case core.GroupName:
 			switch vmSnapshot.Spec.Source.Kind {
 			case "VirtualMachine":
-				causes, err = admitter.validateCreateVM(ctx, sourceField.Child("name"), ar.Request.Namespace, vmSnapshot.Spec.Source.Name)
+				causes, err = admitter.validateCreateVM(ctx, sourceField.Child("name"), ar.Request.Namespace, vmSnapshot)
 				if err != nil {
 					return webhookutils.ToAdmissionResponseError(err)
 				}
@@ -147,7 +148,8 @@ func (admitter *VMSnapshotAdmitter) Admit(ctx context.Context, ar *admissionv1.A
 	return &reviewResponse
 }
 
-func (admitter *VMSnapshotAdmitter) validateCreateVM(ctx context.Context, field *k8sfield.Path, namespace, name string) ([]metav1.StatusCause, error) {
+func (admitter *VMSnapshotAdmitter) validateCreateVM(ctx context.Context, field *k8sfield.Path, namespace string, vmSnapshot *snapshotv1.VirtualMachineSnapshot) ([]metav1.StatusCause, error) {
+	name := vmSnapshot.Spec.Source.Name
 	vm, err := admitter.Client.VirtualMachine(namespace).Get(ctx, name, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		return []metav1.StatusCause{
@@ -173,5 +175,21 @@ func (admitter *VMSnapshotAdmitter) validateCreateVM(ctx context.Context, field
 		}, nil
 	}
 
+	if vmSnapshot.Spec.IncludeMemoryState != nil && *vmSnapshot.Spec.IncludeMemoryState {
+		hypervisorName := hypervisor.DefaultBackend
+		if vm.Spec.Template != nil {
+			hypervisorName = hypervisor.NameOrDefault(vm.Spec.Template.Spec.Hypervisor)
+		}
+		if capabilities, exists := hypervisor.GetCapabilities(hypervisorName); exists && !capabilities.MemoryState {
+			return []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("Memory state is not supported by hypervisor %s", hypervisorName),
+					Field:   k8sfield.NewPath("spec", "includeMemoryState").String(),
+				},
+			}, nil
+		}
+	}
+
 	return []metav1.StatusCause{}, nil
 }
@@ -294,6 +294,48 @@ var _ = Describe("Validating VirtualMachineSnapshot Admitter", func() {
 				Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("needs backend storage"))
 			})
 
+			DescribeTable("should validate the memory state against the hypervisor", func(defaultHypervisor, hypervisorName string, allowed bool) {
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &v1.KubeVirt{
+					Spec: v1.KubeVirtSpec{
+						Configuration: v1.KubeVirtConfiguration{
+							DeveloperConfiguration: &v1.DeveloperConfiguration{
+								FeatureGates: []string{"Snapshot"},
+							},
+							HypervisorConfiguration: &v1.HypervisorConfiguration{
+								DefaultHypervisor: defaultHypervisor,
+							},
+						},
+					},
+				})
+				vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Hypervisor: hypervisorName,
+					},
+				}
+				snapshot := &snapshotv1.VirtualMachineSnapshot{
+					Spec: snapshotv1.VirtualMachineSnapshotSpec{
+						Source: corev1.TypedLocalObjectReference{
+							APIGroup: &apiGroup,
+							Kind:     "VirtualMachine",
+							Name:     vmName,
+						},
+						IncludeMemoryState: pointer.P(true),
+					},
+				}
+
+				ar := createSnapshotAdmissionReview(snapshot)
+				resp := createTestVMSnapshotAdmitter(config, vm).Admit(context.Background(), ar)
+				Expect(resp.Allowed).To(Equal(allowed))
+				if !allowed {
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.includeMemoryState"))
+				}
+			},
+				Entry("accept with qemu", "qemu", "qemu", true),
+				Entry("reject with ch", "qemu", "ch", false),
+				Entry("accept an unset hypervisor, which stands for qemu, whatever the default", "ch", "", true),
+			)
+
 			It("should accept when VM is not running", func() {
 				snapshot := &snapshotv1.VirtualMachineSnapshot{
 					Spec: snapshotv1.VirtualMachineSnapshotSpec{
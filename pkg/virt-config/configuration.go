@@ -267,6 +267,9 @@ func defaultClusterConfig(cpuArch string) *v1.KubeVirtConfiguration {
 			MaxHotplugRatio: DefaultMaxHotplugRatio,
 		},
 		VMRolloutStrategy: pointer.P(DefaultVMRolloutStrategy),
+		HypervisorConfiguration: &v1.HypervisorConfiguration{
+			DefaultHypervisor: DefaultHypervisor,
+		},
 	}
 }
 
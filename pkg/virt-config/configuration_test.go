@@ -354,6 +354,60 @@ var _ = Describe("test configuration", func() {
 		Entry("is unset, GetMaxHotplugRatio should return the default", 0, virtconfig.DefaultMaxHotplugRatio),
 	)
 
+	Context("when hypervisorConfiguration", func() {
+		hypervisorConfiguration := &v1.HypervisorConfiguration{
+			DefaultHypervisor: "ch",
+			NamespacePolicies: []v1.HypervisorNamespacePolicy{
+				{
+					Namespaces:         []string{"kvm-tenant"},
+					DefaultHypervisor:  "qemu",
+					AllowedHypervisors: []string{"qemu"},
+				},
+				{
+					Namespaces:         []string{"mixed-tenant"},
+					AllowedHypervisors: []string{"qemu", "ch"},
+				},
+			},
+		}
+
+		DescribeTable("GetDefaultHypervisor", func(value *v1.HypervisorConfiguration, namespace, expected string) {
+			clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				HypervisorConfiguration: value,
+			})
+			Expect(clusterConfig.GetDefaultHypervisor(namespace)).To(Equal(expected))
+		},
+			Entry("is unset, should return the default", nil, "default", virtconfig.DefaultHypervisor),
+			Entry("is set, should return the cluster-wide default", hypervisorConfiguration, "default", "ch"),
+			Entry("has a policy for the namespace, should return the namespace default", hypervisorConfiguration, "kvm-tenant", "qemu"),
+			Entry("has a policy without default for the namespace, should return the cluster-wide default", hypervisorConfiguration, "mixed-tenant", "ch"),
+		)
+
+		DescribeTable("IsHypervisorAllowed", func(value *v1.HypervisorConfiguration, namespace, hypervisor string, expected bool) {
+			clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				HypervisorConfiguration: value,
+			})
+			Expect(clusterConfig.IsHypervisorAllowed(namespace, hypervisor)).To(Equal(expected))
+		},
+			Entry("is unset, should allow any hypervisor", nil, "kvm-tenant", "ch", true),
+			Entry("has no policy for the namespace, should allow any hypervisor", hypervisorConfiguration, "default", "ch", true),
+			Entry("has a policy for the namespace, should allow listed hypervisors", hypervisorConfiguration, "mixed-tenant", "ch", true),
+			Entry("has a policy for the namespace, should reject other hypervisors", hypervisorConfiguration, "kvm-tenant", "ch", false),
+		)
+
+		It("should return the overheads of the requested hypervisor", func() {
+			vmmOverhead := resource.MustParse("64Mi")
+			clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				HypervisorConfiguration: &v1.HypervisorConfiguration{
+					Overheads: []v1.HypervisorOverhead{
+						{Hypervisor: "ch", VMM: &vmmOverhead},
+					},
+				},
+			})
+			Expect(clusterConfig.GetHypervisorOverhead("ch")).To(Equal(&v1.HypervisorOverhead{Hypervisor: "ch", VMM: &vmmOverhead}))
+			Expect(clusterConfig.GetHypervisorOverhead("qemu")).To(BeNil())
+		})
+	})
+
 	// deprecated
 	DescribeTable(" when supportedGuestAgentVersions", func(value []string, result []string) {
 		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
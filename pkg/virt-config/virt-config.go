@@ -32,6 +32,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 )
 
 const (
@@ -89,6 +91,8 @@ const (
 
 	DefaultMaxHotplugRatio   = 4
 	DefaultVMRolloutStrategy = v1.VMRolloutStrategyStage
+
+	DefaultHypervisor = hypervisor.DefaultBackend
 )
 
 func IsAMD64(arch string) bool {
@@ -468,3 +472,64 @@ func (c *ClusterConfig) GetNetworkBindings() map[string]v1.InterfaceBindingPlugi
 	}
 	return nil
 }
+
+// GetDefaultHypervisor returns the hypervisor assigned to VMIs in the given namespace which don't specify one
+func (c *ClusterConfig) GetDefaultHypervisor(namespace string) string {
+	hypervisorConfig := c.GetConfig().HypervisorConfiguration
+	if hypervisorConfig == nil {
+		return DefaultHypervisor
+	}
+
+	if policy := hypervisorNamespacePolicy(hypervisorConfig, namespace); policy != nil && policy.DefaultHypervisor != "" {
+		return policy.DefaultHypervisor
+	}
+	if hypervisorConfig.DefaultHypervisor != "" {
+		return hypervisorConfig.DefaultHypervisor
+	}
+	return DefaultHypervisor
+}
+
+// IsHypervisorAllowed returns true if VMIs in the given namespace are entitled to use the hypervisor
+func (c *ClusterConfig) IsHypervisorAllowed(namespace, hypervisor string) bool {
+	hypervisorConfig := c.GetConfig().HypervisorConfiguration
+	if hypervisorConfig == nil {
+		return true
+	}
+
+	policy := hypervisorNamespacePolicy(hypervisorConfig, namespace)
+	if policy == nil || len(policy.AllowedHypervisors) == 0 {
+		return true
+	}
+	for _, allowed := range policy.AllowedHypervisors {
+		if allowed == hypervisor {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHypervisorOverhead returns the configured overheads of the hypervisor, or nil if the built-in defaults apply
+func (c *ClusterConfig) GetHypervisorOverhead(name string) *v1.HypervisorOverhead {
+	hypervisorConfig := c.GetConfig().HypervisorConfiguration
+	if hypervisorConfig == nil {
+		return nil
+	}
+
+	for i, overhead := range hypervisorConfig.Overheads {
+		if hypervisor.NameOrDefault(overhead.Hypervisor) == hypervisor.NameOrDefault(name) {
+			return &hypervisorConfig.Overheads[i]
+		}
+	}
+	return nil
+}
+
+func hypervisorNamespacePolicy(hypervisorConfig *v1.HypervisorConfiguration, namespace string) *v1.HypervisorNamespacePolicy {
+	for i, policy := range hypervisorConfig.NamespacePolicies {
+		for _, ns := range policy.Namespaces {
+			if ns == namespace {
+				return &hypervisorConfig.NamespacePolicies[i]
+			}
+		}
+	}
+	return nil
+}
@@ -301,7 +301,7 @@ func copyResources(srcResources, dstResources k8sv1.ResourceList) {
 // Note: This is the best estimation we were able to come up with
 //
 //	and is still not 100% accurate
-func GetMemoryOverhead(vmi *v1.VirtualMachineInstance, cpuArch string, additionalOverheadRatio *string) resource.Quantity {
+func GetMemoryOverhead(vmi *v1.VirtualMachineInstance, cpuArch string, additionalOverheadRatio *string, hypervisorOverhead *v1.HypervisorOverhead) resource.Quantity {
 	domain := vmi.Spec.Domain
 	vmiMemoryReq := domain.Resources.Requests.Memory()
 
@@ -317,11 +317,15 @@ func GetMemoryOverhead(vmi *v1.VirtualMachineInstance, cpuArch string, additiona
 	// Add fixed overhead for KubeVirt components, as seen in a random run, rounded up to the nearest MiB
 	// Note: shared libraries are included in the size, so every library is counted (wrongly) as many times as there are
 	//   processes using it. However, the extra memory is only in the order of 10MiB and makes for a nice safety margin.
-	overhead.Add(resource.MustParse(hypervisor.GetVirtLauncherMonitorOverhead()))
-	overhead.Add(resource.MustParse(hypervisor.GetVirtLauncherOverhead()))
-	overhead.Add(resource.MustParse(hypervisor.GetVirtlogdOverhead()))
-	overhead.Add(resource.MustParse(hypervisor.GetHypervisorDaemonOverhead()))
-	overhead.Add(resource.MustParse(hypervisor.GetHypervisorOverhead()))
+	// The defaults can be overridden per hypervisor in the KubeVirt CR.
+	if hypervisorOverhead == nil {
+		hypervisorOverhead = &v1.HypervisorOverhead{}
+	}
+	overhead.Add(overheadOrDefault(hypervisorOverhead.VirtLauncherMonitor, hypervisor.GetVirtLauncherMonitorOverhead()))
+	overhead.Add(overheadOrDefault(hypervisorOverhead.VirtLauncher, hypervisor.GetVirtLauncherOverhead()))
+	overhead.Add(overheadOrDefault(hypervisorOverhead.Virtlogd, hypervisor.GetVirtlogdOverhead()))
+	overhead.Add(overheadOrDefault(hypervisorOverhead.HypervisorDaemon, hypervisor.GetHypervisorDaemonOverhead()))
+	overhead.Add(overheadOrDefault(hypervisorOverhead.VMM, hypervisor.GetHypervisorOverhead()))
 
 	// Add CPU table overhead (8 MiB per vCPU and 8 MiB per IO thread)
 	// overhead per vcpu in MiB
@@ -410,6 +414,14 @@ func GetMemoryOverhead(vmi *v1.VirtualMachineInstance, cpuArch string, additiona
 	return overhead
 }
 
+// overheadOrDefault returns the configured overhead of a process, or the hypervisor default if it is unset
+func overheadOrDefault(configured *resource.Quantity, defaultOverhead string) resource.Quantity {
+	if configured != nil {
+		return *configured
+	}
+	return resource.MustParse(defaultOverhead)
+}
+
 // Request a resource by name. This function bumps the number of resources,
 // both its limits and requests attributes.
 //
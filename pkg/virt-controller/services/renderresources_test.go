@@ -497,7 +497,27 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*videoRAMOverhead)
 			// 8Mi*1core(default)
 			expected.Add(*coresOverhead)
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
+			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
+		})
+	})
+
+	When("the overheads of the hypervisor are configured", func() {
+		It("should replace the defaults of the configured processes only", func() {
+			vmi.Spec.Hypervisor = "qemu"
+			hypervisorOverhead := &v1.HypervisorOverhead{
+				Hypervisor: "qemu",
+				Virtlogd:   pointer.P(resource.MustParse("10Mi")),
+				VMM:        pointer.P(resource.MustParse("80Mi")),
+			}
+			expected := resource.NewScaledQuantity(0, resource.Kilo)
+			expected.Add(*baseOverhead)
+			expected.Add(*staticOverhead)
+			expected.Add(*videoRAMOverhead)
+			expected.Add(*coresOverhead)
+			// virtlogd 20Mi -> 10Mi, vmm 30Mi -> 80Mi
+			expected.Add(resource.MustParse("40Mi"))
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, hypervisorOverhead)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		})
 	})
@@ -519,7 +539,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			// (2cores* 2threads *2sockets)
 			value := coresOverhead.Value() * 8
 			expected.Add(*resource.NewQuantity(value, coresOverhead.Format))
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		})
 	})
@@ -537,7 +557,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*videoRAMOverhead)
 			value := coresOverhead.Value() * int64(coresMultiplier)
 			expected.Add(*resource.NewQuantity(value, coresOverhead.Format))
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		},
 			Entry("based on the limits if both requests and limits are provided", "3", "5", 5),
@@ -556,7 +576,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*baseOverhead)
 			expected.Add(*staticOverhead)
 			expected.Add(*coresOverhead)
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		})
 	})
@@ -569,7 +589,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*videoRAMOverhead)
 			expected.Add(*coresOverhead)
 			expected.Add(*cpuArchOverhead)
-			overhead := GetMemoryOverhead(vmi, "arm64", nil)
+			overhead := GetMemoryOverhead(vmi, "arm64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		})
 	})
@@ -583,7 +603,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*videoRAMOverhead)
 			expected.Add(*coresOverhead)
 			expected.Add(*vfioOverhead)
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		},
 			Entry("with hostDEV", v1.Devices{HostDevices: []v1.HostDevice{{Name: "test"}}}),
@@ -603,7 +623,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*videoRAMOverhead)
 			expected.Add(*coresOverhead)
 			expected.Add(*downwardmetricsOverhead)
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		})
 	})
@@ -619,7 +639,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*coresOverhead)
 			expected.Add(probeOverhead)
 
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		},
 			Entry("with livenessProbe only", &v1.Probe{Handler: v1.Handler{Exec: &kubev1.ExecAction{}}}, nil, resource.MustParse("110Mi")),
@@ -642,7 +662,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*videoRAMOverhead)
 			expected.Add(*coresOverhead)
 			expected.Add(*sevOverhead)
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		})
 	})
@@ -661,7 +681,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*videoRAMOverhead)
 			expected.Add(*coresOverhead)
 			expected.Add(*tpmOverhead)
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		})
 	})
@@ -681,7 +701,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 				expected = multiplyMemory(*base, ratio)
 			}
 
-			overhead := GetMemoryOverhead(vmi, "amd64", pointer.P(additionalOverheadRatio))
+			overhead := GetMemoryOverhead(vmi, "amd64", pointer.P(additionalOverheadRatio), nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		},
 			Entry("with the given value if the given value is a float", "3.2", false),
@@ -708,7 +728,7 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 			expected.Add(*coresOverhead)
 			expected.Add(resource.MustParse("100Mi"))
 
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
+			overhead := GetMemoryOverhead(vmi, "amd64", nil, nil)
 			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
 		},
 			Entry("with DedicatedCPU", true, false),
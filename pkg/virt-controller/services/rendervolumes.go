@@ -167,6 +167,12 @@ func withVMIVolumes(pvcStore cache.Store, vmiSpecVolumes []v1.Volume, vmiVolumeS
 			if volume.CloudInitConfigDrive != nil {
 				renderer.handleCloudInitConfigDrive(volume)
 			}
+
+			if volume.MemoryDump != nil && !volume.MemoryDump.Hotpluggable {
+				if err := renderer.handleMemoryStateVolume(volume, pvcStore); err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	}
@@ -567,6 +573,23 @@ func (vr *VolumeRenderer) handlePVCVolume(volume v1.Volume, pvcStore cache.Store
 	return nil
 }
 
+// handleMemoryStateVolume mounts the pvc holding the saved state the vmi is resumed from
+func (vr *VolumeRenderer) handleMemoryStateVolume(volume v1.Volume, pvcStore cache.Store) error {
+	claimName := volume.MemoryDump.ClaimName
+	if err := vr.addPVCToLaunchManifest(pvcStore, volume, claimName); err != nil {
+		return err
+	}
+	vr.podVolumes = append(vr.podVolumes, k8sv1.Volume{
+		Name: volume.Name,
+		VolumeSource: k8sv1.VolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	})
+	return nil
+}
+
 func (vr *VolumeRenderer) handleEphemeralVolume(volume v1.Volume, pvcStore cache.Store) error {
 	claimName := volume.Ephemeral.PersistentVolumeClaim.ClaimName
 	if err := vr.addPVCToLaunchManifest(pvcStore, volume, claimName); err != nil {
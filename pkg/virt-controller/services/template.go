@@ -44,6 +44,7 @@ import (
 
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/hooks"
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	metrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-controller"
 	"kubevirt.io/kubevirt/pkg/network/downwardapi"
 	"kubevirt.io/kubevirt/pkg/network/istio"
@@ -160,6 +161,17 @@ func isFeatureStateEnabled(fs *v1.FeatureState) bool {
 func setNodeAffinityForPod(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) {
 	setNodeAffinityForHostModelCpuModel(vmi, pod)
 	setNodeAffinityForbiddenFeaturePolicy(vmi, pod)
+	setNodeAffinityForHypervisor(vmi, pod)
+}
+
+func setNodeAffinityForHypervisor(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) {
+	// QEMU VMIs must keep scheduling on nodes which are not labelled yet, e.g. during an upgrade.
+	// The KVM device request, or emulation, already decides which nodes are able to run them.
+	hypervisorName := hypervisor.NameOrDefault(vmi.Spec.Hypervisor)
+	if hypervisorName == hypervisor.DefaultBackend {
+		return
+	}
+	pod.Spec.Affinity = modifyNodeAffinityToRequireLabel(pod.Spec.Affinity, v1.HypervisorLabel+hypervisorName)
 }
 
 func setNodeAffinityForHostModelCpuModel(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) {
@@ -181,11 +193,21 @@ func setNodeAffinityForbiddenFeaturePolicy(vmi *v1.VirtualMachineInstance, pod *
 }
 
 func modifyNodeAffintyToRejectLabel(origAffinity *k8sv1.Affinity, labelToReject string) *k8sv1.Affinity {
-	affinity := origAffinity.DeepCopy()
-	requirement := k8sv1.NodeSelectorRequirement{
+	return addNodeAffinityRequirement(origAffinity, k8sv1.NodeSelectorRequirement{
 		Key:      labelToReject,
 		Operator: k8sv1.NodeSelectorOpDoesNotExist,
-	}
+	})
+}
+
+func modifyNodeAffinityToRequireLabel(origAffinity *k8sv1.Affinity, labelToRequire string) *k8sv1.Affinity {
+	return addNodeAffinityRequirement(origAffinity, k8sv1.NodeSelectorRequirement{
+		Key:      labelToRequire,
+		Operator: k8sv1.NodeSelectorOpExists,
+	})
+}
+
+func addNodeAffinityRequirement(origAffinity *k8sv1.Affinity, requirement k8sv1.NodeSelectorRequirement) *k8sv1.Affinity {
+	affinity := origAffinity.DeepCopy()
 	term := k8sv1.NodeSelectorTerm{
 		MatchExpressions: []k8sv1.NodeSelectorRequirement{requirement}}
 
@@ -197,7 +219,7 @@ func modifyNodeAffintyToRejectLabel(origAffinity *k8sv1.Affinity, labelToReject
 	if affinity != nil && affinity.NodeAffinity != nil {
 		if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
 			terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
-			// Since NodeSelectorTerms are ORed , the anti affinity requirement will be added to each term.
+			// Since NodeSelectorTerms are ORed , the requirement will be added to each term.
 			for i, selectorTerm := range terms {
 				affinity.NodeAffinity.
 					RequiredDuringSchedulingIgnoredDuringExecution.
@@ -367,7 +389,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 	} else {
 		command = []string{"/usr/bin/virt-launcher-monitor",
 			"--qemu-timeout", generateQemuTimeoutWithJitter(t.launcherQemuTimeout),
-			"--hypervisor", vmi.Spec.Hypervisor,
+			"--hypervisor", hypervisor.NameOrDefault(vmi.Spec.Hypervisor),
 			"--name", domain,
 			"--uid", string(vmi.UID),
 			"--namespace", namespace,
@@ -565,6 +587,13 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 		}
 
 	}
+	if podSeccompProfile == nil {
+		if h := hypervisor.NewHypervisorWithUser(vmi.Spec.Hypervisor, util.IsNonRootVMI(vmi)); h != nil && h.ShouldUseRuntimeDefaultSeccompProfile() {
+			podSeccompProfile = &k8sv1.SeccompProfile{
+				Type: k8sv1.SeccompProfileTypeRuntimeDefault,
+			}
+		}
+	}
 	pod := k8sv1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "virt-launcher-" + domain + "-",
@@ -731,7 +760,8 @@ func (t *templateService) newContainerSpecRenderer(vmi *v1.VirtualMachineInstanc
 		computeContainerOpts = append(computeContainerOpts, WithDropALLCapabilities())
 	}
 
-	if t.IsPPC64() {
+	h := hypervisor.NewHypervisorWithUser(vmi.Spec.Hypervisor, util.IsNonRootVMI(vmi))
+	if t.IsPPC64() || (h != nil && h.ShouldRunPrivileged()) {
 		computeContainerOpts = append(computeContainerOpts, WithPrivileged())
 	}
 
@@ -1423,7 +1453,7 @@ func (t *templateService) VMIResourcePredicates(vmi *v1.VirtualMachineInstance,
 	if vmiCPUArch == "" {
 		vmiCPUArch = t.clusterConfig.GetClusterCPUArch()
 	}
-	memoryOverhead := GetMemoryOverhead(vmi, vmiCPUArch, t.clusterConfig.GetConfig().AdditionalGuestMemoryOverheadRatio)
+	memoryOverhead := GetMemoryOverhead(vmi, vmiCPUArch, t.clusterConfig.GetConfig().AdditionalGuestMemoryOverheadRatio, t.clusterConfig.GetHypervisorOverhead(vmi.Spec.Hypervisor))
 
 	if t.netBindingPluginMemoryCalculator != nil {
 		memoryOverhead.Add(
@@ -241,6 +241,58 @@ var _ = Describe("Template", func() {
 
 		})
 
+		Context("with Cloud Hypervisor", func() {
+			It("should run non-root VMIs unprivileged with the runtime default seccomp profile", func() {
+				_, kvStore, svc = configFactory(defaultArch)
+				vmi := newMinimalWithContainerDisk("random")
+				vmi.Spec.Hypervisor = "ch"
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pod.Spec.SecurityContext.SeccompProfile).To(Equal(&k8sv1.SeccompProfile{
+					Type: k8sv1.SeccompProfileTypeRuntimeDefault,
+				}))
+				Expect(*pod.Spec.SecurityContext.RunAsUser).To(Equal(int64(util.NonRootUID)))
+				Expect(*pod.Spec.Containers[0].SecurityContext.Privileged).To(BeFalse())
+			})
+
+			It("should run root VMIs unprivileged with the hypervisor device and the networking capability", func() {
+				_, kvStore, svc = configFactory(defaultArch)
+				vmi := newMinimalWithContainerDisk("random")
+				vmi.Annotations = nil
+				vmi.Spec.Hypervisor = "ch"
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pod.Spec.SecurityContext.SeccompProfile).To(BeNil())
+				Expect(*pod.Spec.Containers[0].SecurityContext.Privileged).To(BeFalse())
+				Expect(pod.Spec.Containers[0].SecurityContext.Capabilities.Add).To(ContainElement(k8sv1.Capability("NET_ADMIN")))
+				Expect(pod.Spec.Containers[0].Resources.Limits).To(HaveKey(k8sv1.ResourceName("devices.kubevirt.io/mshv")))
+			})
+
+			It("should prefer the configured seccomp profile", func() {
+				_, kvStore, svc = configFactory(defaultArch)
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.SeccompConfiguration = &v1.SeccompConfiguration{
+					VirtualMachineInstanceProfile: &v1.VirtualMachineInstanceProfile{
+						CustomProfile: &v1.CustomProfile{
+							LocalhostProfile: pointer.String("kubevirt/kubevirt.json"),
+						},
+					},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvConfig)
+				vmi := newMinimalWithContainerDisk("random")
+				vmi.Spec.Hypervisor = "ch"
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pod.Spec.SecurityContext.SeccompProfile.Type).To(Equal(k8sv1.SeccompProfileTypeLocalhost))
+			})
+		})
+
 		Context("with NonRoot feature-gate", func() {
 			var vmi *v1.VirtualMachineInstance
 			BeforeEach(func() {
@@ -1748,6 +1800,56 @@ var _ = Describe("Template", func() {
 				Entry("empty string should be treated as host-model", ""),
 				Entry("nil should be treated as host-model", nil),
 			)
+			DescribeTable("should add affinity to nodes providing the hypervisor", func(hypervisor string, useEmulation bool, expectAffinity bool) {
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.DeveloperConfiguration.UseEmulation = useEmulation
+				config, kvStore, svc = configFactory(defaultArch)
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvConfig)
+
+				vmi := libvmi.New(
+					libvmi.WithNamespace("default"),
+					libvmi.WithHypervisor(hypervisor),
+				)
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				requirement := k8sv1.NodeSelectorRequirement{
+					Key:      v1.HypervisorLabel + hypervisor,
+					Operator: k8sv1.NodeSelectorOpExists,
+				}
+				terms := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				Expect(terms).ToNot(BeEmpty())
+				for _, term := range terms {
+					if expectAffinity {
+						Expect(term.MatchExpressions).To(ContainElement(requirement))
+					} else {
+						Expect(term.MatchExpressions).ToNot(ContainElement(requirement))
+					}
+				}
+			},
+				Entry("with qemu", "qemu", false, false),
+				Entry("with qemu when emulation is allowed", "qemu", true, false),
+				Entry("with ch", "ch", false, true),
+				Entry("with ch when emulation is allowed", "ch", true, true),
+				Entry("with ch-kvm", "ch-kvm", false, true),
+			)
+
+			DescribeTable("should request the device of the hypervisor", func(hypervisor string, expectedDevice k8sv1.ResourceName) {
+				config, kvStore, svc = configFactory(defaultArch)
+
+				vmi := libvmi.New(
+					libvmi.WithNamespace("default"),
+					libvmi.WithHypervisor(hypervisor),
+				)
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Resources.Limits).To(HaveKey(expectedDevice))
+			},
+				Entry("with qemu", "qemu", k8sv1.ResourceName("devices.kubevirt.io/kvm")),
+				Entry("with ch", "ch", k8sv1.ResourceName("devices.kubevirt.io/mshv")),
+				Entry("with ch-kvm", "ch-kvm", k8sv1.ResourceName("devices.kubevirt.io/kvm")),
+			)
 		})
 		Context("with cpu and memory constraints", func() {
 			DescribeTable("should add cpu and memory constraints to a template", func(arch string, requestMemory string, limitMemory string) {
@@ -2550,7 +2652,7 @@ var _ = Describe("Template", func() {
 				arch := config.GetClusterCPUArch()
 				Expect(err).ToNot(HaveOccurred())
 				expectedMemory := resource.NewScaledQuantity(0, resource.Kilo)
-				expectedMemory.Add(GetMemoryOverhead(vmi, arch, config.GetConfig().AdditionalGuestMemoryOverheadRatio))
+				expectedMemory.Add(GetMemoryOverhead(vmi, arch, config.GetConfig().AdditionalGuestMemoryOverheadRatio, config.GetHypervisorOverhead(vmi.Spec.Hypervisor)))
 				expectedMemory.Add(*vmi.Spec.Domain.Resources.Requests.Memory())
 				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().Value()).To(Equal(expectedMemory.Value()))
 			})
@@ -2578,7 +2680,7 @@ var _ = Describe("Template", func() {
 				arch := config.GetClusterCPUArch()
 				Expect(err).ToNot(HaveOccurred())
 				expectedMemory := resource.NewScaledQuantity(0, resource.Kilo)
-				expectedMemory.Add(GetMemoryOverhead(vmi1, arch, config.GetConfig().AdditionalGuestMemoryOverheadRatio))
+				expectedMemory.Add(GetMemoryOverhead(vmi1, arch, config.GetConfig().AdditionalGuestMemoryOverheadRatio, config.GetHypervisorOverhead(vmi1.Spec.Hypervisor)))
 				expectedMemory.Add(*vmi.Spec.Domain.Resources.Requests.Memory())
 				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().Value()).To(Equal(expectedMemory.Value()))
 				Expect(pod1.Spec.Containers[0].Resources.Requests.Memory().Value()).To(Equal(expectedMemory.Value()))
@@ -3952,7 +4054,7 @@ var _ = Describe("Template", func() {
 				arch := config.GetClusterCPUArch()
 				Expect(err).ToNot(HaveOccurred())
 				expectedMemory := resource.NewScaledQuantity(0, resource.Kilo)
-				expectedMemory.Add(GetMemoryOverhead(vmi, arch, config.GetConfig().AdditionalGuestMemoryOverheadRatio))
+				expectedMemory.Add(GetMemoryOverhead(vmi, arch, config.GetConfig().AdditionalGuestMemoryOverheadRatio, config.GetHypervisorOverhead(vmi.Spec.Hypervisor)))
 				expectedMemory.Add(*vmi.Spec.Domain.Resources.Requests.Memory())
 				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().Value()).To(Equal(expectedMemory.Value()))
 			})
@@ -4048,8 +4150,8 @@ var _ = Describe("Template", func() {
 				ratio, err := strconv.ParseFloat(ratioStr, 64)
 				Expect(err).ToNot(HaveOccurred())
 
-				originalOverhead := GetMemoryOverhead(vmi, config.GetClusterCPUArch(), nil)
-				actualOverheadWithHeadroom := GetMemoryOverhead(vmi, config.GetClusterCPUArch(), pointer.String(ratioStr))
+				originalOverhead := GetMemoryOverhead(vmi, config.GetClusterCPUArch(), nil, nil)
+				actualOverheadWithHeadroom := GetMemoryOverhead(vmi, config.GetClusterCPUArch(), pointer.String(ratioStr), nil)
 				expectedOverheadWithHeadroom := multiplyMemory(originalOverhead, ratio)
 
 				const errFmt = "overhead without headroom: %s, ratio: %s, actual overhead with headroom: %s, expected overhead with headroom: %s"
@@ -4601,7 +4703,7 @@ var _ = Describe("Template", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(pod.Spec.Containers[0].Name).To(Equal("compute"))
 					expectedMemory := resource.NewScaledQuantity(0, resource.Kilo)
-					expectedMemory.Add(GetMemoryOverhead(&vmi, defaultArch, config.GetConfig().AdditionalGuestMemoryOverheadRatio))
+					expectedMemory.Add(GetMemoryOverhead(&vmi, defaultArch, config.GetConfig().AdditionalGuestMemoryOverheadRatio, config.GetHypervisorOverhead(vmi.Spec.Hypervisor)))
 					expectedMemory.Add(guestMemory)
 					Expect(pod.Spec.Containers[0].Resources.Limits.Memory().Value()).To(BeEquivalentTo(expectedMemory.Value()))
 				},
@@ -39,6 +39,8 @@ import (
 	k6tv1 "kubevirt.io/api/core/v1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
 )
 
 type cloneSourceType string
@@ -64,6 +66,7 @@ type syncInfoType struct {
 	targetVMName    string
 	targetVMCreated bool
 	pvcBound        bool
+	sysprepDone     bool
 
 	isCloneFailing bool
 	failEvent      Event
@@ -96,6 +99,18 @@ func (ctrl *VMCloneController) execute(key string) error {
 		return nil
 	}
 
+	if vmClone.DeletionTimestamp != nil && controller.HasFinalizer(vmClone, cloneFinalizer) {
+		return ctrl.cleanupSourceNamespace(vmClone)
+	}
+
+	if vmClone.DeletionTimestamp == nil && isCrossNamespace(vmClone) && !controller.HasFinalizer(vmClone, cloneFinalizer) {
+		// objects in the source namespace cannot be owned by the clone, they are cleaned up before it is deleted
+		vmCloneCpy := vmClone.DeepCopy()
+		controller.AddFinalizer(vmCloneCpy, cloneFinalizer)
+		_, err := ctrl.client.VirtualMachineClone(vmCloneCpy.Namespace).Update(context.Background(), vmCloneCpy, v1.UpdateOptions{})
+		return err
+	}
+
 	if vmClone.Status.Phase == clonev1alpha1.Succeeded {
 		_, vmExists, err := ctrl.vmStore.GetByKey(fmt.Sprintf("%s/%s", vmClone.Namespace, *vmClone.Status.TargetName))
 		if err != nil {
@@ -151,7 +166,7 @@ func (ctrl *VMCloneController) retrieveCloneInfo(vmClone *clonev1alpha1.VirtualM
 
 	switch cloneSourceType(sourceInfo.Kind) {
 	case sourceTypeVM:
-		sourceVMObj, err := ctrl.getSource(vmClone, sourceInfo.Name, vmClone.Namespace, string(sourceTypeVM), ctrl.vmStore)
+		sourceVMObj, err := ctrl.getSource(vmClone, sourceInfo.Name, getSourceNamespace(vmClone), string(sourceTypeVM), ctrl.vmStore)
 		if err != nil {
 			return nil, err
 		}
@@ -160,7 +175,7 @@ func (ctrl *VMCloneController) retrieveCloneInfo(vmClone *clonev1alpha1.VirtualM
 		cloneInfo.sourceVm = sourceVM
 
 	case sourceTypeSnapshot:
-		sourceSnapshotObj, err := ctrl.getSource(vmClone, sourceInfo.Name, vmClone.Namespace, string(sourceTypeSnapshot), ctrl.snapshotStore)
+		sourceSnapshotObj, err := ctrl.getSource(vmClone, sourceInfo.Name, getSourceNamespace(vmClone), string(sourceTypeSnapshot), ctrl.snapshotStore)
 		if err != nil {
 			return nil, err
 		}
@@ -194,7 +209,7 @@ func (ctrl *VMCloneController) syncTargetVM(vmCloneInfo *vmCloneInfo) syncInfoTy
 			}
 		}
 
-		vmCloneInfo.snapshot, syncInfo = ctrl.verifySnapshotReady(vmClone, vmCloneInfo.snapshotName, vmCloneInfo.vmClone.Namespace, syncInfo)
+		vmCloneInfo.snapshot, syncInfo = ctrl.verifySnapshotReady(vmClone, vmCloneInfo.snapshotName, getSourceNamespace(vmClone), syncInfo)
 		if syncInfo.isFailingOrError() || !syncInfo.snapshotReady {
 			return syncInfo
 		}
@@ -204,7 +219,7 @@ func (ctrl *VMCloneController) syncTargetVM(vmCloneInfo *vmCloneInfo) syncInfoTy
 	case clonev1alpha1.RestoreInProgress:
 		// Here we have to know the snapshot name
 		if vmCloneInfo.snapshot == nil {
-			vmCloneInfo.snapshot, syncInfo = ctrl.getSnapshot(vmCloneInfo.snapshotName, vmCloneInfo.vmClone.Namespace, syncInfo)
+			vmCloneInfo.snapshot, syncInfo = ctrl.getSnapshot(vmCloneInfo.snapshotName, getSourceNamespace(vmClone), syncInfo)
 			if syncInfo.isFailingOrError() {
 				return syncInfo
 			}
@@ -221,7 +236,7 @@ func (ctrl *VMCloneController) syncTargetVM(vmCloneInfo *vmCloneInfo) syncInfoTy
 			return syncInfo
 		}
 
-		syncInfo = ctrl.verifyRestoreReady(vmClone, vmCloneInfo.vmClone.Namespace, syncInfo)
+		syncInfo = ctrl.verifyRestoreReady(vmClone, getSourceNamespace(vmClone), syncInfo)
 		if syncInfo.isFailingOrError() || !syncInfo.restoreReady {
 			return syncInfo
 		}
@@ -237,8 +252,26 @@ func (ctrl *VMCloneController) syncTargetVM(vmCloneInfo *vmCloneInfo) syncInfoTy
 
 		fallthrough
 
+	case clonev1alpha1.SysprepInProgress:
+
+		if needsSysprep(vmClone) {
+			syncInfo = ctrl.syncSysprep(vmCloneInfo, syncInfo)
+			if syncInfo.isFailingOrError() || !syncInfo.sysprepDone {
+				return syncInfo
+			}
+		}
+
+		fallthrough
+
 	case clonev1alpha1.Succeeded:
 
+		if isInPhase(vmClone, clonev1alpha1.Succeeded) && needsSysprep(vmClone) {
+			syncInfo = ctrl.cleanupSysprepPod(vmClone, syncInfo)
+			if syncInfo.isFailingOrError() {
+				return syncInfo
+			}
+		}
+
 		if vmClone.Status.RestoreName != nil {
 			syncInfo = ctrl.verifyPVCBound(vmClone, syncInfo)
 			if syncInfo.isFailingOrError() || !syncInfo.pvcBound {
@@ -258,6 +291,12 @@ func (ctrl *VMCloneController) syncTargetVM(vmCloneInfo *vmCloneInfo) syncInfoTy
 			}
 		}
 
+	case clonev1alpha1.Failed:
+
+		if needsSysprep(vmClone) {
+			syncInfo = ctrl.cleanupSysprepPod(vmClone, syncInfo)
+		}
+
 	default:
 		log.Log.Object(vmClone).Infof("clone %s is in phase %s - nothing to do", vmClone.Name, string(vmClone.Status.Phase))
 	}
@@ -315,8 +354,16 @@ func (ctrl *VMCloneController) updateStatus(origClone *clonev1alpha1.VirtualMach
 		}
 
 		if syncInfo.targetVMCreated {
+			if needsSysprep(vmClone) {
+				assignPhase(clonev1alpha1.SysprepInProgress)
+			} else {
+				assignPhase(clonev1alpha1.Succeeded)
+			}
+		}
+	}
+	if isInPhase(vmClone, clonev1alpha1.SysprepInProgress) {
+		if syncInfo.sysprepDone {
 			assignPhase(clonev1alpha1.Succeeded)
-
 		}
 	}
 	if isInPhase(vmClone, clonev1alpha1.Succeeded) {
@@ -417,7 +464,7 @@ func (ctrl *VMCloneController) createRestoreFromVm(vmClone *clonev1alpha1.Virtua
 		syncInfo.setError(retErr)
 		return syncInfo
 	}
-	restore := generateRestore(vmClone.Spec.Target, vm.Name, vmClone.Namespace, vmClone.Name, snapshotName, vmClone.UID, patches)
+	restore := generateRestore(vmClone, vm.Name, snapshotName, patches)
 	log.Log.Object(vmClone).Infof("creating restore %s for clone %s", restore.Name, vmClone.Name)
 	createdRestore, err := ctrl.client.VirtualMachineRestore(restore.Namespace).Create(context.Background(), restore, v1.CreateOptions{})
 	if err != nil {
@@ -482,7 +529,7 @@ func (ctrl *VMCloneController) verifyVmReady(vmClone *clonev1alpha1.VirtualMachi
 }
 
 func (ctrl *VMCloneController) verifyPVCBound(vmClone *clonev1alpha1.VirtualMachineClone, syncInfo syncInfoType) syncInfoType {
-	obj, exists, err := ctrl.restoreStore.GetByKey(getKey(*vmClone.Status.RestoreName, vmClone.Namespace))
+	obj, exists, err := ctrl.restoreStore.GetByKey(getKey(*vmClone.Status.RestoreName, getSourceNamespace(vmClone)))
 	if !exists {
 		syncInfo.setError(fmt.Errorf("restore %s is not created yet for clone %s", *vmClone.Status.RestoreName, vmClone.Name))
 		return syncInfo
@@ -517,7 +564,7 @@ func (ctrl *VMCloneController) verifyPVCBound(vmClone *clonev1alpha1.VirtualMach
 }
 
 func (ctrl *VMCloneController) cleanupSnapshot(vmClone *clonev1alpha1.VirtualMachineClone, syncInfo syncInfoType) syncInfoType {
-	err := ctrl.client.VirtualMachineSnapshot(vmClone.Namespace).Delete(context.Background(), *vmClone.Status.SnapshotName, v1.DeleteOptions{})
+	err := ctrl.client.VirtualMachineSnapshot(getSourceNamespace(vmClone)).Delete(context.Background(), *vmClone.Status.SnapshotName, v1.DeleteOptions{})
 	if err != nil && !errors.IsNotFound(err) {
 		syncInfo.setError(fmt.Errorf("cannot clean up snapshot %s for clone %s", *vmClone.Status.SnapshotName, vmClone.Name))
 		return syncInfo
@@ -527,7 +574,7 @@ func (ctrl *VMCloneController) cleanupSnapshot(vmClone *clonev1alpha1.VirtualMac
 }
 
 func (ctrl *VMCloneController) cleanupRestore(vmClone *clonev1alpha1.VirtualMachineClone, syncInfo syncInfoType) syncInfoType {
-	err := ctrl.client.VirtualMachineRestore(vmClone.Namespace).Delete(context.Background(), *vmClone.Status.RestoreName, v1.DeleteOptions{})
+	err := ctrl.client.VirtualMachineRestore(getSourceNamespace(vmClone)).Delete(context.Background(), *vmClone.Status.RestoreName, v1.DeleteOptions{})
 	if err != nil && !errors.IsNotFound(err) {
 		syncInfo.setError(fmt.Errorf("cannot clean up restore %s for clone %s", *vmClone.Status.RestoreName, vmClone.Name))
 		return syncInfo
@@ -536,6 +583,28 @@ func (ctrl *VMCloneController) cleanupRestore(vmClone *clonev1alpha1.VirtualMach
 	return syncInfo
 }
 
+// cleanupSourceNamespace deletes the snapshot and the restore the clone created in the source
+// namespace, and releases the clone afterwards
+func (ctrl *VMCloneController) cleanupSourceNamespace(vmClone *clonev1alpha1.VirtualMachineClone) error {
+	namespace := getSourceNamespace(vmClone)
+
+	err := ctrl.client.VirtualMachineRestore(namespace).Delete(context.Background(), generateRestoreName(vmClone.UID), v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("cannot clean up restore for clone %s: %v", vmClone.Name, err)
+	}
+
+	// a snapshot used as source of the clone has another name and is kept
+	err = ctrl.client.VirtualMachineSnapshot(namespace).Delete(context.Background(), generateSnapshotName(vmClone.UID), v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("cannot clean up snapshot for clone %s: %v", vmClone.Name, err)
+	}
+
+	vmCloneCpy := vmClone.DeepCopy()
+	controller.RemoveFinalizer(vmCloneCpy, cloneFinalizer)
+	_, err = ctrl.client.VirtualMachineClone(vmCloneCpy.Namespace).Update(context.Background(), vmCloneCpy, v1.UpdateOptions{})
+	return err
+}
+
 func (ctrl *VMCloneController) logAndRecord(vmClone *clonev1alpha1.VirtualMachineClone, event Event, msg string) {
 	ctrl.recorder.Eventf(vmClone, corev1.EventTypeNormal, string(event), msg)
 	log.Log.Object(vmClone).Infof(msg)
@@ -20,6 +20,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/storage/snapshot"
 	"kubevirt.io/kubevirt/pkg/util/status"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 type Event string
@@ -35,9 +36,12 @@ const (
 	RestoreReady          Event = "RestoreReady"
 	TargetVMCreated       Event = "TargetVMCreated"
 	PVCBound              Event = "PVCBound"
+	SysprepStarted        Event = "SysprepStarted"
+	SysprepCompleted      Event = "SysprepCompleted"
 
 	SnapshotDeleted    Event = "SnapshotDeleted"
 	SourceDoesNotExist Event = "SourceDoesNotExist"
+	SysprepFailed      Event = "SysprepFailed"
 )
 
 type VMCloneController struct {
@@ -48,6 +52,8 @@ type VMCloneController struct {
 	vmStore              cache.Store
 	snapshotContentStore cache.Store
 	pvcStore             cache.Store
+	podStore             cache.Store
+	clusterConfig        *virtconfig.ClusterConfig
 	recorder             record.EventRecorder
 
 	vmCloneQueue       workqueue.RateLimitingInterface
@@ -56,7 +62,7 @@ type VMCloneController struct {
 	hasSynced          func() bool
 }
 
-func NewVmCloneController(client kubecli.KubevirtClient, vmCloneInformer, snapshotInformer, restoreInformer, vmInformer, snapshotContentInformer, pvcInformer cache.SharedIndexInformer, recorder record.EventRecorder) (*VMCloneController, error) {
+func NewVmCloneController(client kubecli.KubevirtClient, vmCloneInformer, snapshotInformer, restoreInformer, vmInformer, snapshotContentInformer, pvcInformer, podInformer cache.SharedIndexInformer, clusterConfig *virtconfig.ClusterConfig, recorder record.EventRecorder) (*VMCloneController, error) {
 	ctrl := VMCloneController{
 		client:               client,
 		vmCloneIndexer:       vmCloneInformer.GetIndexer(),
@@ -65,6 +71,8 @@ func NewVmCloneController(client kubecli.KubevirtClient, vmCloneInformer, snapsh
 		vmStore:              vmInformer.GetStore(),
 		snapshotContentStore: snapshotContentInformer.GetStore(),
 		pvcStore:             pvcInformer.GetStore(),
+		podStore:             podInformer.GetStore(),
+		clusterConfig:        clusterConfig,
 		recorder:             recorder,
 		vmCloneQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-vmclone"),
 		vmStatusUpdater:      status.NewVMStatusUpdater(client),
@@ -73,7 +81,7 @@ func NewVmCloneController(client kubecli.KubevirtClient, vmCloneInformer, snapsh
 
 	ctrl.hasSynced = func() bool {
 		return vmCloneInformer.HasSynced() && snapshotInformer.HasSynced() && restoreInformer.HasSynced() &&
-			vmInformer.HasSynced() && snapshotInformer.HasSynced() && pvcInformer.HasSynced()
+			vmInformer.HasSynced() && snapshotInformer.HasSynced() && pvcInformer.HasSynced() && podInformer.HasSynced()
 	}
 
 	_, err := vmCloneInformer.AddEventHandler(
@@ -124,6 +132,18 @@ func NewVmCloneController(client kubecli.KubevirtClient, vmCloneInformer, snapsh
 		return nil, err
 	}
 
+	_, err = podInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handlePod,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handlePod(newObj) },
+			DeleteFunc: ctrl.handlePod,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = vmInformer.AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			DeleteFunc: ctrl.handleDeleteVM,
@@ -264,6 +284,23 @@ func (ctrl *VMCloneController) handlePVC(obj interface{}) {
 	}
 }
 
+func (ctrl *VMCloneController) handlePod(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	pod, ok := obj.(*k8scorev1.Pod)
+	if !ok {
+		log.Log.Errorf(unknownTypeErrFmt, "pod")
+		return
+	}
+
+	// only sysprep pods are owned by clones
+	if ownedByClone, key := isOwnedByClone(pod); ownedByClone {
+		ctrl.vmCloneQueue.AddRateLimited(key)
+	}
+}
+
 func (ctrl *VMCloneController) handleDeleteVM(obj interface{}) {
 	vm, ok := obj.(*virtv1.VirtualMachine)
 	// When a delete is dropped, the relist will notice a vm in the store not
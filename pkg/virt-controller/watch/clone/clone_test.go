@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
@@ -65,10 +66,12 @@ var _ = Describe("Clone", func() {
 		recorder   *record.FakeRecorder
 		mockQueue  *testutils.MockWorkQueue
 
-		client    *kubevirtfake.Clientset
-		k8sClient *k8sfake.Clientset
-		sourceVM  *virtv1.VirtualMachine
-		vmClone   *clonev1alpha1.VirtualMachineClone
+		virtClient *kubecli.MockKubevirtClient
+		client     *kubevirtfake.Clientset
+		k8sClient  *k8sfake.Clientset
+		podClient  *k8sfake.Clientset
+		sourceVM   *virtv1.VirtualMachine
+		vmClone    *clonev1alpha1.VirtualMachineClone
 	)
 
 	addVM := func(vm *virtv1.VirtualMachine) {
@@ -212,10 +215,19 @@ var _ = Describe("Clone", func() {
 		cloneInformer, _ := testutils.NewFakeInformerFor(&clonev1alpha1.VirtualMachineClone{})
 		snapshotContentInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotContent{})
 		pvcInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+		podInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Pod{})
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKV(&virtv1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+			Status: virtv1.KubeVirtStatus{
+				ObservedKubeVirtRegistry: "registry",
+				ObservedKubeVirtVersion:  "v1.0.0",
+				ObservedDeploymentConfig: "{}",
+			},
+		})
 
 		recorder = record.NewFakeRecorder(100)
 		recorder.IncludeObject = true
-		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
 		controller, _ = NewVmCloneController(
 			virtClient,
 			cloneInformer,
@@ -224,6 +236,8 @@ var _ = Describe("Clone", func() {
 			vmInformer,
 			snapshotContentInformer,
 			pvcInformer,
+			podInformer,
+			clusterConfig,
 			recorder)
 		mockQueue = testutils.NewMockWorkQueue(controller.vmCloneQueue)
 		controller.vmCloneQueue = mockQueue
@@ -236,6 +250,7 @@ var _ = Describe("Clone", func() {
 		virtClient.EXPECT().VirtualMachineSnapshot(metav1.NamespaceDefault).Return(client.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault)).AnyTimes()
 		virtClient.EXPECT().VirtualMachineRestore(metav1.NamespaceDefault).Return(client.SnapshotV1beta1().VirtualMachineRestores(metav1.NamespaceDefault)).AnyTimes()
 		virtClient.EXPECT().VirtualMachineSnapshotContent(metav1.NamespaceDefault).Return(client.SnapshotV1beta1().VirtualMachineSnapshotContents(metav1.NamespaceDefault)).AnyTimes()
+		virtClient.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(client.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
 
 		k8sClient = k8sfake.NewSimpleClientset()
 		k8sClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
@@ -243,6 +258,9 @@ var _ = Describe("Clone", func() {
 			return true, nil, nil
 		})
 		virtClient.EXPECT().AppsV1().Return(k8sClient.AppsV1()).AnyTimes()
+
+		podClient = k8sfake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(podClient.CoreV1()).AnyTimes()
 	})
 
 	Context("basic controller operations", func() {
@@ -542,6 +560,176 @@ var _ = Describe("Clone", func() {
 				})
 			})
 
+			When("sysprep is requested", func() {
+				var targetVM *virtv1.VirtualMachine
+
+				getSysprepPod := func() *k8sv1.Pod {
+					pod, err := podClient.CoreV1().Pods(metav1.NamespaceDefault).Get(context.TODO(), generateSysprepPodName(vmClone.UID), metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					return pod
+				}
+
+				addSysprepPod := func(phase k8sv1.PodPhase) {
+					pod := generateSysprepPod(vmClone, "libguestfs-tools", []sysprepDisk{{claimName: "restore-pvc"}})
+					pod.Status.Phase = phase
+					pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+						State: k8sv1.ContainerState{Terminated: &k8sv1.ContainerStateTerminated{Message: "virt-sysprep: error: no operating systems were found"}},
+					}}
+					_, err := podClient.CoreV1().Pods(metav1.NamespaceDefault).Create(context.TODO(), pod, metav1.CreateOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(controller.podStore.Add(pod)).To(Succeed())
+				}
+
+				addTargetVM := func() {
+					_, err := client.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.TODO(), targetVM, metav1.CreateOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					addVM(targetVM)
+				}
+
+				expectSysprepPodDeleted := func() {
+					pods, err := podClient.CoreV1().Pods(metav1.NamespaceDefault).List(context.TODO(), metav1.ListOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(pods.Items).To(BeEmpty())
+				}
+
+				BeforeEach(func() {
+					snapshot := createVirtualMachineSnapshot(sourceVM)
+					snapshot.Status.ReadyToUse = pointer.P(true)
+					restore := createVirtualMachineRestore(sourceVM, snapshot.Name)
+					restore.Status.Complete = pointer.P(true)
+
+					vmClone.Spec.GuestCustomization = &clonev1alpha1.VirtualMachineCloneGuestCustomization{
+						Hostname: pointer.P("clone-host"),
+						Sysprep: &clonev1alpha1.VirtualMachineCloneSysprep{
+							Operations: []string{"machine-id", "ssh-hostkeys"},
+						},
+					}
+					vmClone.Status.SnapshotName = pointer.P(snapshot.Name)
+					vmClone.Status.RestoreName = pointer.P(restore.Name)
+
+					sourceVM.Spec.RunStrategy = pointer.P(virtv1.RunStrategyAlways)
+					targetVM = sourceVM.DeepCopy()
+					targetVM.Name = vmClone.Spec.Target.Name
+					targetVM.Spec.RunStrategy = pointer.P(virtv1.RunStrategyHalted)
+					targetVM.Spec.Template.Spec.Volumes = []virtv1.Volume{{
+						Name: "disk0",
+						VolumeSource: virtv1.VolumeSource{
+							PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "restore-pvc"},
+							},
+						},
+					}}
+
+					addVM(sourceVM)
+					addSnapshot(snapshot)
+					addSnapshotContent(createVirtualMachineSnapshotContent(sourceVM))
+					addRestore(restore)
+					addPVC(createPVC(metav1.NamespaceDefault, k8sv1.ClaimBound))
+				})
+
+				It("should run virt-sysprep on the disks of the target VM before succeeding", func() {
+					vmClone.Status.Phase = clonev1alpha1.CreatingTargetVM
+					addTargetVM()
+					addClone(vmClone)
+
+					controller.Execute()
+					expectEvent(TargetVMCreated)
+					expectEvent(SysprepStarted)
+					expectCloneBeInPhase(clonev1alpha1.SysprepInProgress)
+
+					pod := getSysprepPod()
+					validateOwnerReference(pod.OwnerReferences[0], vmClone)
+					Expect(pod.Spec.Containers).To(HaveLen(1))
+					Expect(pod.Spec.Containers[0].Image).To(Equal("registry/libguestfs-tools:v1.0.0"))
+					Expect(pod.Spec.Containers[0].Args).To(Equal([]string{
+						"--format", "raw",
+						"-a", "/disks/0/disk.img",
+						"--operations", "machine-id,ssh-hostkeys",
+						"--hostname", "clone-host",
+					}))
+					Expect(pod.Spec.Volumes).To(ContainElement(HaveField("PersistentVolumeClaim.ClaimName", "restore-pvc")))
+					// the snapshot and the restore are kept until sysprep completed
+					_, err := client.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault).Get(context.TODO(), testSnapshotName, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					_, err = client.SnapshotV1beta1().VirtualMachineRestores(metav1.NamespaceDefault).Get(context.TODO(), testRestoreName, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("should restore the run strategy of the source and succeed when virt-sysprep completed", func() {
+					vmClone.Status.Phase = clonev1alpha1.SysprepInProgress
+					addTargetVM()
+					addClone(vmClone)
+					addSysprepPod(k8sv1.PodSucceeded)
+
+					controller.Execute()
+					expectEvent(SysprepCompleted)
+					expectCloneBeInPhase(clonev1alpha1.Succeeded)
+					vm, err := client.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.TODO(), targetVM.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Spec.RunStrategy).To(HaveValue(Equal(virtv1.RunStrategyAlways)))
+					// the pod is deleted once the phase is stored
+					Expect(getSysprepPod()).ToNot(BeNil())
+				})
+
+				It("should restore running of the source when virt-sysprep completed", func() {
+					sourceVM.Spec.RunStrategy = nil
+					sourceVM.Spec.Running = pointer.P(true)
+					addSnapshotContent(createVirtualMachineSnapshotContent(sourceVM))
+					vmClone.Status.Phase = clonev1alpha1.SysprepInProgress
+					addTargetVM()
+					addClone(vmClone)
+					addSysprepPod(k8sv1.PodSucceeded)
+
+					controller.Execute()
+					expectEvent(SysprepCompleted)
+					vm, err := client.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.TODO(), targetVM.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Spec.RunStrategy).To(BeNil())
+					Expect(vm.Spec.Running).To(HaveValue(BeTrue()))
+				})
+
+				It("should fail and keep the target VM halted when virt-sysprep failed", func() {
+					vmClone.Status.Phase = clonev1alpha1.SysprepInProgress
+					addTargetVM()
+					addClone(vmClone)
+					addSysprepPod(k8sv1.PodFailed)
+
+					controller.Execute()
+					testutils.ExpectEvent(recorder, "no operating systems were found")
+					expectCloneBeInPhase(clonev1alpha1.Failed)
+					vm, err := client.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.TODO(), targetVM.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Spec.RunStrategy).To(HaveValue(Equal(virtv1.RunStrategyHalted)))
+				})
+
+				DescribeTable("should delete the sysprep pod once the clone", func(phase clonev1alpha1.VirtualMachineClonePhase, podPhase k8sv1.PodPhase) {
+					vmClone.Status.Phase = phase
+					vmClone.Status.TargetName = pointer.P(targetVM.Name)
+					targetVM.Spec.RunStrategy = pointer.P(virtv1.RunStrategyAlways)
+					addTargetVM()
+					addClone(vmClone)
+					addSysprepPod(podPhase)
+
+					controller.Execute()
+					expectSysprepPodDeleted()
+				},
+					Entry("succeeded", clonev1alpha1.Succeeded, k8sv1.PodSucceeded),
+					Entry("failed", clonev1alpha1.Failed, k8sv1.PodFailed),
+				)
+
+				It("should fail when the target VM was started before sysprep", func() {
+					vmClone.Status.Phase = clonev1alpha1.SysprepInProgress
+					targetVM.Status.Created = true
+					addTargetVM()
+					addClone(vmClone)
+
+					controller.Execute()
+					expectEvent(SysprepFailed)
+					expectCloneBeInPhase(clonev1alpha1.Failed)
+					expectSysprepPodDeleted()
+				})
+			})
+
 		})
 
 		Context("with source snapshot", func() {
@@ -599,6 +787,145 @@ var _ = Describe("Clone", func() {
 				expectCloneBeInPhase(clonev1alpha1.RestoreInProgress)
 			})
 		})
+
+		Context("with source in another namespace", func() {
+			const sourceNamespace = "source-ns"
+
+			getClone := func() *clonev1alpha1.VirtualMachineClone {
+				clone, err := client.CloneV1alpha1().VirtualMachineClones(metav1.NamespaceDefault).Get(context.TODO(), vmClone.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				return clone
+			}
+
+			addSourceSnapshot := func(snapshot *snapshotv1.VirtualMachineSnapshot) {
+				snapshot, err := client.SnapshotV1beta1().VirtualMachineSnapshots(sourceNamespace).Create(context.TODO(), snapshot, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(controller.snapshotStore.Add(snapshot)).To(Succeed())
+			}
+
+			addSourceRestore := func(restore *snapshotv1.VirtualMachineRestore) {
+				restore, err := client.SnapshotV1beta1().VirtualMachineRestores(sourceNamespace).Create(context.TODO(), restore, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(controller.restoreStore.Add(restore)).To(Succeed())
+			}
+
+			expectCloneAnnotation := func(obj metav1.Object) {
+				Expect(obj.GetOwnerReferences()).To(BeEmpty())
+				Expect(obj.GetAnnotations()).To(HaveKeyWithValue(cloneAnnotation, metav1.NamespaceDefault+"/"+vmClone.Name))
+			}
+
+			BeforeEach(func() {
+				virtClient.EXPECT().VirtualMachineSnapshot(sourceNamespace).Return(client.SnapshotV1beta1().VirtualMachineSnapshots(sourceNamespace)).AnyTimes()
+				virtClient.EXPECT().VirtualMachineRestore(sourceNamespace).Return(client.SnapshotV1beta1().VirtualMachineRestores(sourceNamespace)).AnyTimes()
+
+				sourceVM.Namespace = sourceNamespace
+				vmClone.Spec.SourceNamespace = pointer.P(sourceNamespace)
+				vmClone.Finalizers = []string{cloneFinalizer}
+			})
+
+			It("should add a finalizer before creating the snapshot", func() {
+				vmClone.Finalizers = nil
+
+				addVM(sourceVM)
+				addClone(vmClone)
+
+				controller.Execute()
+				Expect(getClone().Finalizers).To(ConsistOf(cloneFinalizer))
+				_, err := client.SnapshotV1beta1().VirtualMachineSnapshots(sourceNamespace).Get(context.TODO(), testSnapshotName, metav1.GetOptions{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should create the snapshot in the source namespace", func() {
+				addVM(sourceVM)
+				addClone(vmClone)
+
+				controller.Execute()
+				expectEvent(SnapshotCreated)
+				snapshot, err := client.SnapshotV1beta1().VirtualMachineSnapshots(sourceNamespace).Get(context.TODO(), testSnapshotName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(snapshot.Spec.Source.Name).To(Equal(sourceVM.Name))
+				expectCloneAnnotation(snapshot)
+			})
+
+			It("should restore the snapshot into the namespace of the clone", func() {
+				snapshot := createVirtualMachineSnapshot(sourceVM)
+				snapshot.Status.ReadyToUse = pointer.P(true)
+
+				vmClone.Status.SnapshotName = pointer.P(snapshot.Name)
+				vmClone.Status.Phase = clonev1alpha1.SnapshotInProgress
+
+				addVM(sourceVM)
+				addClone(vmClone)
+				addSourceSnapshot(snapshot)
+				addSnapshotContent(createVirtualMachineSnapshotContent(sourceVM))
+
+				controller.Execute()
+				expectEvent(SnapshotReady)
+				expectEvent(RestoreCreated)
+				expectCloneBeInPhase(clonev1alpha1.RestoreInProgress)
+				restore, err := client.SnapshotV1beta1().VirtualMachineRestores(sourceNamespace).Get(context.TODO(), testRestoreName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(restore.Spec.VirtualMachineSnapshotName).To(Equal(testSnapshotName))
+				Expect(restore.Spec.TargetNamespace).To(HaveValue(Equal(metav1.NamespaceDefault)))
+				expectCloneAnnotation(restore)
+			})
+
+			It("should wait for the PVCs restored into the namespace of the clone", func() {
+				snapshot := createVirtualMachineSnapshot(sourceVM)
+				snapshot.Status.ReadyToUse = pointer.P(true)
+				pvc := createPVC(metav1.NamespaceDefault, k8sv1.ClaimBound)
+				restore := createVirtualMachineRestore(sourceVM, snapshot.Name)
+				restore.Status.Complete = pointer.P(true)
+				restore.Status.Restores = []snapshotv1.VolumeRestore{
+					{PersistentVolumeClaimName: pvc.Name},
+				}
+
+				vmClone.Status.SnapshotName = pointer.P(snapshot.Name)
+				vmClone.Status.RestoreName = pointer.P(restore.Name)
+				vmClone.Status.Phase = clonev1alpha1.Succeeded
+				vmClone.Status.TargetName = pointer.P(vmClone.Spec.Target.Name)
+
+				targetVM := sourceVM.DeepCopy()
+				targetVM.Namespace = metav1.NamespaceDefault
+				targetVM.Name = vmClone.Spec.Target.Name
+
+				addVM(sourceVM)
+				addVM(targetVM)
+				addClone(vmClone)
+				addSourceSnapshot(snapshot)
+				addSourceRestore(restore)
+				addPVC(pvc)
+
+				controller.Execute()
+				expectEvent(PVCBound)
+				_, err := client.SnapshotV1beta1().VirtualMachineSnapshots(sourceNamespace).Get(context.TODO(), testSnapshotName, metav1.GetOptions{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				_, err = client.SnapshotV1beta1().VirtualMachineRestores(sourceNamespace).Get(context.TODO(), testRestoreName, metav1.GetOptions{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should clean up the source namespace when the clone is deleted", func() {
+				snapshot := createVirtualMachineSnapshot(sourceVM)
+				restore := createVirtualMachineRestore(sourceVM, snapshot.Name)
+
+				vmClone.DeletionTimestamp = pointer.P(metav1.Now())
+				vmClone.Status.SnapshotName = pointer.P(snapshot.Name)
+				vmClone.Status.RestoreName = pointer.P(restore.Name)
+				vmClone.Status.Phase = clonev1alpha1.RestoreInProgress
+
+				addVM(sourceVM)
+				addClone(vmClone)
+				addSourceSnapshot(snapshot)
+				addSourceRestore(restore)
+
+				controller.Execute()
+				Expect(getClone().Finalizers).To(BeEmpty())
+				_, err := client.SnapshotV1beta1().VirtualMachineSnapshots(sourceNamespace).Get(context.TODO(), testSnapshotName, metav1.GetOptions{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				_, err = client.SnapshotV1beta1().VirtualMachineRestores(sourceNamespace).Get(context.TODO(), testRestoreName, metav1.GetOptions{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+		})
 	})
 
 	Context("generation of target VM", func() {
@@ -898,9 +1225,139 @@ var _ = Describe("Clone", func() {
 			})
 		})
 
+		Context("Guest customization", func() {
+			getPatchedVM := func() virtv1.VirtualMachine {
+				restore, err := client.SnapshotV1beta1().VirtualMachineRestores(metav1.NamespaceDefault).Get(context.TODO(), testRestoreName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				patchedVM, err := offlinePatchVM(sourceVM, restore.Spec.Patches)
+				Expect(err).ToNot(HaveOccurred())
+				return patchedVM
+			}
+
+			It("should set the hostname", func() {
+				vmClone.Spec.GuestCustomization = &clonev1alpha1.VirtualMachineCloneGuestCustomization{
+					Hostname: pointer.P("clone-host"),
+				}
+				addClone(vmClone)
+
+				expectedVM := sourceVM.DeepCopy()
+				expectedVM.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress = ""
+				expectedVM.Spec.Template.Spec.Hostname = "clone-host"
+
+				controller.Execute()
+				expectVMCreationFromPatches(expectedVM)
+			})
+
+			It("should replace the access credentials", func() {
+				sourceVM.Spec.Template.Spec.AccessCredentials = []virtv1.AccessCredential{
+					newSSHAccessCredential("source-keys"),
+				}
+				vmClone.Spec.GuestCustomization = &clonev1alpha1.VirtualMachineCloneGuestCustomization{
+					AccessCredentials: []virtv1.AccessCredential{newSSHAccessCredential("target-keys")},
+				}
+				addClone(vmClone)
+
+				controller.Execute()
+				Expect(getPatchedVM().Spec.Template.Spec.AccessCredentials).To(Equal(vmClone.Spec.GuestCustomization.AccessCredentials))
+			})
+
+			DescribeTable("should replace the cloud-init user data and keep the network data", func(volumeSource virtv1.VolumeSource) {
+				sourceVM.Spec.Template.Spec.Volumes = append(sourceVM.Spec.Template.Spec.Volumes, virtv1.Volume{
+					Name:         "cloudinit",
+					VolumeSource: volumeSource,
+				})
+				vmClone.Spec.GuestCustomization = &clonev1alpha1.VirtualMachineCloneGuestCustomization{
+					CloudInit: &clonev1alpha1.VirtualMachineCloneCloudInit{
+						UserDataSecretRef: &k8sv1.LocalObjectReference{Name: "target-userdata"},
+					},
+				}
+				addClone(vmClone)
+
+				controller.Execute()
+				volumes := getPatchedVM().Spec.Template.Spec.Volumes
+				Expect(volumes).To(HaveLen(1))
+				var userData, userDataBase64, networkData string
+				var userDataSecretRef *k8sv1.LocalObjectReference
+				if noCloud := volumes[0].CloudInitNoCloud; noCloud != nil {
+					userData, userDataBase64, userDataSecretRef, networkData = noCloud.UserData, noCloud.UserDataBase64, noCloud.UserDataSecretRef, noCloud.NetworkData
+				} else {
+					configDrive := volumes[0].CloudInitConfigDrive
+					Expect(configDrive).ToNot(BeNil())
+					userData, userDataBase64, userDataSecretRef, networkData = configDrive.UserData, configDrive.UserDataBase64, configDrive.UserDataSecretRef, configDrive.NetworkData
+				}
+				Expect(userData).To(BeEmpty())
+				Expect(userDataBase64).To(BeEmpty())
+				Expect(userDataSecretRef).To(HaveValue(Equal(k8sv1.LocalObjectReference{Name: "target-userdata"})))
+				Expect(networkData).To(Equal("source-networkdata"))
+			},
+				Entry("with NoCloud", virtv1.VolumeSource{
+					CloudInitNoCloud: &virtv1.CloudInitNoCloudSource{UserData: "source-userdata", NetworkData: "source-networkdata"},
+				}),
+				Entry("with ConfigDrive", virtv1.VolumeSource{
+					CloudInitConfigDrive: &virtv1.CloudInitConfigDriveSource{UserDataBase64: "c291cmNl", NetworkData: "source-networkdata"},
+				}),
+			)
+
+			DescribeTable("should halt the target VM when sysprep is requested", func(running *bool, runStrategy *virtv1.VirtualMachineRunStrategy) {
+				sourceVM.Spec.Running = running
+				sourceVM.Spec.RunStrategy = runStrategy
+				addSnapshotContent(createVirtualMachineSnapshotContent(sourceVM))
+				vmClone.Spec.GuestCustomization = &clonev1alpha1.VirtualMachineCloneGuestCustomization{
+					Sysprep: &clonev1alpha1.VirtualMachineCloneSysprep{},
+				}
+				addClone(vmClone)
+
+				controller.Execute()
+				patchedVM := getPatchedVM()
+				Expect(patchedVM.Spec.Running).To(BeNil())
+				Expect(patchedVM.Spec.RunStrategy).To(HaveValue(Equal(virtv1.RunStrategyHalted)))
+			},
+				Entry("with running", pointer.P(true), nil),
+				Entry("with run strategy", nil, pointer.P(virtv1.RunStrategyAlways)),
+			)
+
+			DescribeTable("should set a new instance ID", func(firmware *virtv1.Firmware) {
+				sourceVM.Spec.Template.Spec.Domain.Firmware = firmware
+				vmClone.Spec.GuestCustomization = &clonev1alpha1.VirtualMachineCloneGuestCustomization{
+					NewInstanceID: true,
+				}
+				addClone(vmClone)
+
+				controller.Execute()
+				patchedFirmware := getPatchedVM().Spec.Template.Spec.Domain.Firmware
+				Expect(patchedFirmware).ToNot(BeNil())
+				Expect(patchedFirmware.UUID).ToNot(BeEmpty())
+				Expect(patchedFirmware.UUID).ToNot(Equal(types.UID("source-fake-uuid")))
+			},
+				Entry("without firmware", nil),
+				Entry("with firmware UUID", &virtv1.Firmware{UUID: "source-fake-uuid"}),
+			)
+		})
+
+	})
+})
+
+var _ = Describe("Clone cloud-init patches", func() {
+	It("should fail when the source has no cloud-init volume", func() {
+		cloudInit := &clonev1alpha1.VirtualMachineCloneCloudInit{UserData: pointer.P("#cloud-config")}
+		err := addCloudInitPatches(patch.New(), []virtv1.Volume{{Name: "disk0"}}, cloudInit)
+		Expect(err).To(MatchError(ContainSubstring("the source has no cloud-init volume")))
 	})
 })
 
+func newSSHAccessCredential(secretName string) virtv1.AccessCredential {
+	return virtv1.AccessCredential{
+		SSHPublicKey: &virtv1.SSHPublicKeyAccessCredential{
+			Source: virtv1.SSHPublicKeyAccessCredentialSource{
+				Secret: &virtv1.AccessCredentialSecretSource{SecretName: secretName},
+			},
+			PropagationMethod: virtv1.SSHPublicKeyAccessCredentialPropagationMethod{
+				NoCloud: &virtv1.NoCloudSSHPublicKeyAccessCredentialPropagation{},
+			},
+		},
+	}
+}
+
 func createVirtualMachineSnapshot(vm *virtv1.VirtualMachine, owner ...metav1.OwnerReference) *snapshotv1.VirtualMachineSnapshot {
 	return &snapshotv1.VirtualMachineSnapshot{
 		ObjectMeta: metav1.ObjectMeta{
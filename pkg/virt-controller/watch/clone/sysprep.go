@@ -0,0 +1,420 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package clone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	clonev1alpha1 "kubevirt.io/api/clone/v1alpha1"
+	k6tv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/util"
+	virtoperatorutils "kubevirt.io/kubevirt/pkg/virt-operator/util"
+)
+
+const (
+	libguestfsImageName = "libguestfs-tools"
+	// kvmDevice is the resource virt-handler exposes for /dev/kvm
+	kvmDevice = "devices.kubevirt.io/kvm"
+
+	sysprepContainerName = "virt-sysprep"
+	sysprepDiskDir       = "/disks"
+	sysprepTmpDirPath    = "/tmp/guestfs"
+	sysprepHomePath      = "/home/guestfs"
+	sysprepAppliancePath = "/usr/local/lib/guestfs/appliance"
+)
+
+type sysprepDisk struct {
+	claimName string
+	isBlock   bool
+}
+
+func generateSysprepPodName(vmCloneUID types.UID) string {
+	return fmt.Sprintf("tmp-sysprep-%s", string(vmCloneUID))
+}
+
+func needsSysprep(vmClone *clonev1alpha1.VirtualMachineClone) bool {
+	return vmClone.Spec.GuestCustomization != nil && vmClone.Spec.GuestCustomization.Sysprep != nil
+}
+
+// syncSysprep runs virt-sysprep on the disks of the target VM, which is halted by the restore,
+// and reports whether it is done
+func (ctrl *VMCloneController) syncSysprep(vmCloneInfo *vmCloneInfo, syncInfo syncInfoType) syncInfoType {
+	vmClone := vmCloneInfo.vmClone
+	podName := generateSysprepPodName(vmClone.UID)
+	obj, exists, err := ctrl.podStore.GetByKey(getKey(podName, vmClone.Namespace))
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("error getting sysprep pod %s from cache for clone %s: %v", podName, vmClone.Name, err))
+		return syncInfo
+	}
+
+	if !exists {
+		return ctrl.createSysprepPod(vmClone, syncInfo)
+	}
+
+	pod := obj.(*corev1.Pod)
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		syncInfo = ctrl.restoreRunStrategy(vmCloneInfo, syncInfo)
+		if syncInfo.isFailingOrError() {
+			return syncInfo
+		}
+		ctrl.logAndRecord(vmClone, SysprepCompleted, fmt.Sprintf("sysprep of target VM %s for clone %s completed", vmClone.Spec.Target.Name, vmClone.Name))
+		syncInfo.sysprepDone = true
+	case corev1.PodFailed:
+		syncInfo.isCloneFailing = true
+		syncInfo.failEvent = SysprepFailed
+		syncInfo.failReason = fmt.Sprintf("sysprep pod %s failed: %s", pod.Name, getTerminationMessage(pod))
+	default:
+		log.Log.Object(vmClone).V(defaultVerbosityLevel).Infof("sysprep pod %s for clone %s is not completed yet", pod.Name, vmClone.Name)
+	}
+
+	return syncInfo
+}
+
+func (ctrl *VMCloneController) createSysprepPod(vmClone *clonev1alpha1.VirtualMachineClone, syncInfo syncInfoType) syncInfoType {
+	targetVMName := vmClone.Spec.Target.Name
+	obj, exists, err := ctrl.vmStore.GetByKey(getKey(targetVMName, vmClone.Namespace))
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("error getting VM %s from cache for clone %s: %v", targetVMName, vmClone.Name, err))
+		return syncInfo
+	} else if !exists {
+		syncInfo.setError(fmt.Errorf("target VM %s is not created yet for clone %s", targetVMName, vmClone.Name))
+		return syncInfo
+	}
+
+	vm := obj.(*k6tv1.VirtualMachine)
+	if vm.Status.Created {
+		// virt-sysprep must never touch the disks of a running guest
+		syncInfo.isCloneFailing = true
+		syncInfo.failEvent = SysprepFailed
+		syncInfo.failReason = fmt.Sprintf("target VM %s was started before sysprep", targetVMName)
+		return syncInfo
+	}
+
+	disks, err := ctrl.getSysprepDisks(vm)
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("cannot get disks of target VM %s for clone %s: %v", targetVMName, vmClone.Name, err))
+		return syncInfo
+	}
+	if len(disks) == 0 {
+		log.Log.Object(vmClone).Infof("target VM %s of clone %s has no persistent disks, skipping sysprep", targetVMName, vmClone.Name)
+		syncInfo.sysprepDone = true
+		return syncInfo
+	}
+
+	image, err := libguestfsImage(ctrl.clusterConfig.GetConfigFromKubeVirtCR())
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("cannot get libguestfs image for clone %s: %v", vmClone.Name, err))
+		return syncInfo
+	}
+
+	pod := generateSysprepPod(vmClone, image, disks)
+	_, err = ctrl.client.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, v1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		syncInfo.setError(fmt.Errorf("failed creating sysprep pod %s for clone %s: %v", pod.Name, vmClone.Name, err))
+		return syncInfo
+	}
+
+	ctrl.logAndRecord(vmClone, SysprepStarted, fmt.Sprintf("started sysprep of target VM %s for clone %s", targetVMName, vmClone.Name))
+	return syncInfo
+}
+
+// restoreRunStrategy gives the target VM the run strategy of the source back, the target VM is
+// halted by the restore until sysprep completed
+func (ctrl *VMCloneController) restoreRunStrategy(vmCloneInfo *vmCloneInfo, syncInfo syncInfoType) syncInfoType {
+	vmClone := vmCloneInfo.vmClone
+	targetVMName := vmClone.Spec.Target.Name
+	obj, exists, err := ctrl.vmStore.GetByKey(getKey(targetVMName, vmClone.Namespace))
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("error getting VM %s from cache for clone %s: %v", targetVMName, vmClone.Name, err))
+		return syncInfo
+	} else if !exists {
+		syncInfo.setError(fmt.Errorf("target VM %s does not exist anymore for clone %s", targetVMName, vmClone.Name))
+		return syncInfo
+	}
+
+	vm := obj.(*k6tv1.VirtualMachine)
+	if vm.Spec.Running != nil || vm.Spec.RunStrategy == nil || *vm.Spec.RunStrategy != k6tv1.RunStrategyHalted {
+		// the run strategy was already restored
+		return syncInfo
+	}
+
+	snapshot := vmCloneInfo.snapshot
+	if snapshot == nil {
+		snapshot, syncInfo = ctrl.getSnapshot(vmCloneInfo.snapshotName, getSourceNamespace(vmClone), syncInfo)
+		if syncInfo.isFailingOrError() {
+			return syncInfo
+		}
+	}
+
+	sourceVM, err := ctrl.getVmFromSnapshot(snapshot)
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("cannot get VM manifest from snapshot: %v", err))
+		return syncInfo
+	}
+
+	patchSet := generateRunStrategyRestorePatch(&sourceVM.Spec)
+	if patchSet.IsEmpty() {
+		return syncInfo
+	}
+
+	payload, err := patchSet.GeneratePayload()
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("cannot generate run strategy patch for clone %s: %v", vmClone.Name, err))
+		return syncInfo
+	}
+
+	_, err = ctrl.client.VirtualMachine(vmClone.Namespace).Patch(context.Background(), targetVMName, types.JSONPatchType, payload, v1.PatchOptions{})
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("failed restoring the run strategy of target VM %s for clone %s: %v", targetVMName, vmClone.Name, err))
+		return syncInfo
+	}
+
+	return syncInfo
+}
+
+func generateRunStrategyRestorePatch(sourceSpec *k6tv1.VirtualMachineSpec) *patch.PatchSet {
+	switch {
+	case sourceSpec.Running != nil:
+		return patch.New(
+			patch.WithTest("/spec/runStrategy", k6tv1.RunStrategyHalted),
+			patch.WithRemove("/spec/runStrategy"),
+			patch.WithAdd("/spec/running", *sourceSpec.Running),
+		)
+	case sourceSpec.RunStrategy != nil && *sourceSpec.RunStrategy != k6tv1.RunStrategyHalted:
+		return patch.New(
+			patch.WithTest("/spec/runStrategy", k6tv1.RunStrategyHalted),
+			patch.WithReplace("/spec/runStrategy", *sourceSpec.RunStrategy),
+		)
+	}
+	return patch.New()
+}
+
+// cleanupSysprepPod deletes the sysprep pod once the clone left the SysprepInProgress phase, it's
+// kept until then so that sysprep never runs twice
+func (ctrl *VMCloneController) cleanupSysprepPod(vmClone *clonev1alpha1.VirtualMachineClone, syncInfo syncInfoType) syncInfoType {
+	podName := generateSysprepPodName(vmClone.UID)
+	_, exists, err := ctrl.podStore.GetByKey(getKey(podName, vmClone.Namespace))
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("error getting sysprep pod %s from cache for clone %s: %v", podName, vmClone.Name, err))
+		return syncInfo
+	} else if !exists {
+		return syncInfo
+	}
+
+	err = ctrl.client.CoreV1().Pods(vmClone.Namespace).Delete(context.Background(), podName, v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		syncInfo.setError(fmt.Errorf("cannot clean up sysprep pod %s for clone %s: %v", podName, vmClone.Name, err))
+		return syncInfo
+	}
+
+	return syncInfo
+}
+
+func (ctrl *VMCloneController) getSysprepDisks(vm *k6tv1.VirtualMachine) ([]sysprepDisk, error) {
+	var disks []sysprepDisk
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		var claimName string
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.DataVolume != nil:
+			claimName = volume.DataVolume.Name
+		default:
+			continue
+		}
+
+		obj, exists, err := ctrl.pvcStore.GetByKey(getKey(claimName, vm.Namespace))
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, fmt.Errorf("PVC %s does not exist", claimName)
+		}
+
+		pvc := obj.(*corev1.PersistentVolumeClaim)
+		disks = append(disks, sysprepDisk{
+			claimName: claimName,
+			isBlock:   pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock,
+		})
+	}
+
+	return disks, nil
+}
+
+// libguestfsImage returns the libguestfs-tools image of the KubeVirt installation, which is also
+// used by virtctl guestfs
+func libguestfsImage(kv *k6tv1.KubeVirt) (string, error) {
+	if kv == nil {
+		return "", fmt.Errorf("failed getting KubeVirt config")
+	}
+
+	var kvConfig virtoperatorutils.KubeVirtDeploymentConfig
+	if err := json.Unmarshal([]byte(kv.Status.ObservedDeploymentConfig), &kvConfig); err != nil {
+		return "", err
+	}
+	if kvConfig.GsImage != "" {
+		return kvConfig.GsImage, nil
+	}
+
+	imageName := kvConfig.GetImagePrefix() + libguestfsImageName
+	switch {
+	case kvConfig.GsSha != "":
+		imageName = fmt.Sprintf("%s@%s", imageName, kvConfig.GsSha)
+	case kv.Status.ObservedKubeVirtVersion != "":
+		imageName = fmt.Sprintf("%s:%s", imageName, kv.Status.ObservedKubeVirtVersion)
+	default:
+		return "", fmt.Errorf("neither the digest nor the tag of the libguestfs image is known")
+	}
+
+	if registry := kv.Status.ObservedKubeVirtRegistry; registry != "" {
+		return fmt.Sprintf("%s/%s", registry, imageName), nil
+	}
+	return imageName, nil
+}
+
+func generateSysprepArgs(customization *clonev1alpha1.VirtualMachineCloneGuestCustomization, disks []sysprepDisk) []string {
+	// Restored disks are always raw images
+	args := []string{"--format", "raw"}
+	for idx, disk := range disks {
+		args = append(args, "-a", sysprepDiskPath(idx, disk))
+	}
+
+	if operations := customization.Sysprep.Operations; len(operations) > 0 {
+		args = append(args, "--operations", strings.Join(operations, ","))
+	}
+	if customization.Hostname != nil {
+		args = append(args, "--hostname", *customization.Hostname)
+	}
+
+	return args
+}
+
+func sysprepDiskPath(idx int, disk sysprepDisk) string {
+	if disk.isBlock {
+		return fmt.Sprintf("/dev/disk%d", idx)
+	}
+	return fmt.Sprintf("%s/%d/disk.img", sysprepDiskDir, idx)
+}
+
+func generateSysprepPod(vmClone *clonev1alpha1.VirtualMachineClone, image string, disks []sysprepDisk) *corev1.Pod {
+	const (
+		tmpDirVolumeName = "libguestfs-tmp-dir"
+		homeVolumeName   = "libguestfs-home"
+	)
+
+	container := corev1.Container{
+		Name:    sysprepContainerName,
+		Image:   image,
+		Command: []string{"virt-sysprep"},
+		Args:    generateSysprepArgs(vmClone.Spec.GuestCustomization, disks),
+		Env: []corev1.EnvVar{
+			{Name: "LIBGUESTFS_BACKEND", Value: "direct"},
+			{Name: "LIBGUESTFS_PATH", Value: sysprepAppliancePath},
+			{Name: "LIBGUESTFS_TMPDIR", Value: sysprepTmpDirPath},
+			{Name: "HOME", Value: sysprepHomePath},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: tmpDirVolumeName, MountPath: sysprepTmpDirPath},
+			{Name: homeVolumeName, MountPath: sysprepHomePath},
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				kvmDevice: resource.MustParse("1"),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: pointer.Bool(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+
+	volumes := []corev1.Volume{
+		{Name: tmpDirVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: homeVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+
+	for idx, disk := range disks {
+		volumeName := fmt.Sprintf("disk%d", idx)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: disk.claimName,
+				},
+			},
+		})
+
+		if disk.isBlock {
+			container.VolumeDevices = append(container.VolumeDevices, corev1.VolumeDevice{
+				Name:       volumeName,
+				DevicePath: sysprepDiskPath(idx, disk),
+			})
+		} else {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: fmt.Sprintf("%s/%d", sysprepDiskDir, idx),
+			})
+		}
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: generateCloneObjectMeta(vmClone, generateSysprepPodName(vmClone.UID), vmClone.Namespace),
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: pointer.Bool(true),
+				RunAsUser:    pointer.Int64(util.NonRootUID),
+				RunAsGroup:   pointer.Int64(util.NonRootUID),
+				FSGroup:      pointer.Int64(util.NonRootUID),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Containers:    []corev1.Container{container},
+			Volumes:       volumes,
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+}
+
+func getTerminationMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+			return strings.TrimSpace(status.State.Terminated.Message)
+		}
+	}
+	return "unknown error"
+}
@@ -22,6 +22,12 @@ import (
 const (
 	vmKind           = "VirtualMachine"
 	kubevirtApiGroup = "kubevirt.io"
+
+	// cloneAnnotation refers to the clone of a snapshot or a restore in another namespace,
+	// since owner references cannot cross namespaces
+	cloneAnnotation = "clone.kubevirt.io/clone"
+	// cloneFinalizer protects clones from another namespace until their snapshot and restore are deleted
+	cloneFinalizer = "clone.kubevirt.io/source-namespace-protection"
 )
 
 // variable so can be overridden in tests
@@ -72,19 +78,26 @@ func generateVMName(oldVMName string) string {
 	return generateNameWithRandomSuffix(oldVMName, "clone")
 }
 
+// getSourceNamespace returns the namespace of the source of the clone, which is also
+// the namespace of its snapshot and restore
+func getSourceNamespace(vmClone *clonev1alpha1.VirtualMachineClone) string {
+	if vmClone.Spec.SourceNamespace != nil && *vmClone.Spec.SourceNamespace != "" {
+		return *vmClone.Spec.SourceNamespace
+	}
+	return vmClone.Namespace
+}
+
+func isCrossNamespace(vmClone *clonev1alpha1.VirtualMachineClone) bool {
+	return getSourceNamespace(vmClone) != vmClone.Namespace
+}
+
 func isInPhase(vmClone *clonev1alpha1.VirtualMachineClone, phase clonev1alpha1.VirtualMachineClonePhase) bool {
 	return vmClone.Status.Phase == phase
 }
 
 func generateSnapshot(vmClone *clonev1alpha1.VirtualMachineClone, sourceVM *v1.VirtualMachine) *snapshotv1.VirtualMachineSnapshot {
 	return &snapshotv1.VirtualMachineSnapshot{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      generateSnapshotName(vmClone.UID),
-			Namespace: sourceVM.Namespace,
-			OwnerReferences: []metav1.OwnerReference{
-				getCloneOwnerReference(vmClone.Name, vmClone.UID),
-			},
-		},
+		ObjectMeta: generateCloneObjectMeta(vmClone, generateSnapshotName(vmClone.UID), sourceVM.Namespace),
 		Spec: snapshotv1.VirtualMachineSnapshotSpec{
 			Source: corev1.TypedLocalObjectReference{
 				Kind:     vmKind,
@@ -95,26 +108,46 @@ func generateSnapshot(vmClone *clonev1alpha1.VirtualMachineClone, sourceVM *v1.V
 	}
 }
 
-func generateRestore(targetInfo *corev1.TypedLocalObjectReference, sourceVMName, namespace, cloneName, snapshotName string, cloneUID types.UID, patches []string) *snapshotv1.VirtualMachineRestore {
-	targetInfo = targetInfo.DeepCopy()
+func generateRestore(vmClone *clonev1alpha1.VirtualMachineClone, sourceVMName, snapshotName string, patches []string) *snapshotv1.VirtualMachineRestore {
+	targetInfo := vmClone.Spec.Target.DeepCopy()
 	if targetInfo.Name == "" {
 		targetInfo.Name = generateVMName(sourceVMName)
 	}
 
-	return &snapshotv1.VirtualMachineRestore{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      generateRestoreName(cloneUID),
-			Namespace: namespace,
-			OwnerReferences: []metav1.OwnerReference{
-				getCloneOwnerReference(cloneName, cloneUID),
-			},
-		},
+	// the restore has to live next to the snapshot, the target is restored into the namespace of the clone
+	restore := &snapshotv1.VirtualMachineRestore{
+		ObjectMeta: generateCloneObjectMeta(vmClone, generateRestoreName(vmClone.UID), getSourceNamespace(vmClone)),
 		Spec: snapshotv1.VirtualMachineRestoreSpec{
 			Target:                     *targetInfo,
 			VirtualMachineSnapshotName: snapshotName,
 			Patches:                    patches,
 		},
 	}
+	if isCrossNamespace(vmClone) {
+		restore.Spec.TargetNamespace = pointer.String(vmClone.Namespace)
+	}
+
+	return restore
+}
+
+// generateCloneObjectMeta returns the metadata of an object created for the clone. Objects in the
+// namespace of the clone are owned by it, objects in another namespace refer to it by annotation.
+func generateCloneObjectMeta(vmClone *clonev1alpha1.VirtualMachineClone, name, namespace string) metav1.ObjectMeta {
+	objectMeta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+	}
+	if namespace == vmClone.Namespace {
+		objectMeta.OwnerReferences = []metav1.OwnerReference{
+			getCloneOwnerReference(vmClone.Name, vmClone.UID),
+		}
+	} else {
+		objectMeta.Annotations = map[string]string{
+			cloneAnnotation: getKey(vmClone.Name, vmClone.Namespace),
+		}
+	}
+
+	return objectMeta
 }
 
 func getCloneOwnerReference(cloneName string, cloneUID types.UID) metav1.OwnerReference {
@@ -145,6 +178,10 @@ func isOwnedByClone(obj metav1.Object) (isOwned bool, key string) {
 		return true, key
 	}
 
+	if key, exists := obj.GetAnnotations()[cloneAnnotation]; exists {
+		return true, key
+	}
+
 	return false, ""
 	// TODO: Unit test this?
 }
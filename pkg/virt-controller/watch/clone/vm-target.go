@@ -25,6 +25,10 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
 	"kubevirt.io/client-go/log"
 
 	clonev1alpha1 "kubevirt.io/api/clone/v1alpha1"
@@ -41,7 +45,11 @@ func generatePatches(source *k6tv1.VirtualMachine, cloneSpec *clonev1alpha1.Virt
 	addAnnotationPatches(patchSet, source.Annotations, cloneSpec.AnnotationFilters)
 	addRemovePatchesFromFilter(patchSet, source.Spec.Template.ObjectMeta.Labels, cloneSpec.Template.LabelFilters, "/spec/template/metadata/labels")
 	addRemovePatchesFromFilter(patchSet, source.Spec.Template.ObjectMeta.Annotations, cloneSpec.Template.AnnotationFilters, "/spec/template/metadata/annotations")
-	addFirmwareUUIDPatches(patchSet, source.Spec.Template.Spec.Domain.Firmware)
+	addFirmwareUUIDPatches(patchSet, source.Spec.Template.Spec.Domain.Firmware, cloneSpec.GuestCustomization)
+	if err := addGuestCustomizationPatches(patchSet, &source.Spec.Template.Spec, cloneSpec.GuestCustomization); err != nil {
+		return nil, err
+	}
+	addSysprepRunStrategyPatches(patchSet, &source.Spec, cloneSpec.GuestCustomization)
 
 	patches, err := generateStringPatchOperations(patchSet)
 	if err != nil {
@@ -149,10 +157,103 @@ func addRemovePatchesFromFilter(patchSet *patch.PatchSet, m map[string]string, f
 	}
 }
 
-func addFirmwareUUIDPatches(patchSet *patch.PatchSet, firmware *k6tv1.Firmware) {
+func addFirmwareUUIDPatches(patchSet *patch.PatchSet, firmware *k6tv1.Firmware, customization *clonev1alpha1.VirtualMachineCloneGuestCustomization) {
+	if customization != nil && customization.NewInstanceID {
+		// The cloud-init NoCloud instance-id is the firmware UUID
+		newUUID := types.UID(uuid.NewString())
+		if firmware == nil {
+			patchSet.AddOption(patch.WithAdd("/spec/template/spec/domain/firmware", k6tv1.Firmware{UUID: newUUID}))
+		} else {
+			patchSet.AddOption(patch.WithAdd("/spec/template/spec/domain/firmware/uuid", newUUID))
+		}
+		return
+	}
+
 	if firmware == nil {
 		return
 	}
 
 	patchSet.AddOption(patch.WithReplace("/spec/template/spec/domain/firmware/uuid", ""))
 }
+
+func addGuestCustomizationPatches(patchSet *patch.PatchSet, vmiSpec *k6tv1.VirtualMachineInstanceSpec, customization *clonev1alpha1.VirtualMachineCloneGuestCustomization) error {
+	if customization == nil {
+		return nil
+	}
+
+	if customization.Hostname != nil {
+		patchSet.AddOption(patch.WithAdd("/spec/template/spec/hostname", *customization.Hostname))
+	}
+
+	if customization.AccessCredentials != nil {
+		patchSet.AddOption(patch.WithAdd("/spec/template/spec/accessCredentials", customization.AccessCredentials))
+	}
+
+	if customization.CloudInit != nil {
+		return addCloudInitPatches(patchSet, vmiSpec.Volumes, customization.CloudInit)
+	}
+
+	return nil
+}
+
+// addSysprepRunStrategyPatches keeps the target VM halted until virt-sysprep is done with its disks,
+// the run strategy of the source is restored afterwards
+func addSysprepRunStrategyPatches(patchSet *patch.PatchSet, vmSpec *k6tv1.VirtualMachineSpec, customization *clonev1alpha1.VirtualMachineCloneGuestCustomization) {
+	if customization == nil || customization.Sysprep == nil {
+		return
+	}
+
+	if vmSpec.Running != nil {
+		patchSet.AddOption(patch.WithRemove("/spec/running"))
+	}
+	patchSet.AddOption(patch.WithAdd("/spec/runStrategy", k6tv1.RunStrategyHalted))
+}
+
+func addCloudInitPatches(patchSet *patch.PatchSet, volumes []k6tv1.Volume, cloudInit *clonev1alpha1.VirtualMachineCloneCloudInit) error {
+	for idx, volume := range volumes {
+		switch {
+		case volume.CloudInitNoCloud != nil:
+			noCloud := volume.CloudInitNoCloud.DeepCopy()
+			replaceCloudInitData(cloudInit,
+				&noCloud.UserData, &noCloud.UserDataBase64, &noCloud.UserDataSecretRef,
+				&noCloud.NetworkData, &noCloud.NetworkDataBase64, &noCloud.NetworkDataSecretRef)
+			patchSet.AddOption(patch.WithReplace(fmt.Sprintf("/spec/template/spec/volumes/%d/cloudInitNoCloud", idx), noCloud))
+			return nil
+		case volume.CloudInitConfigDrive != nil:
+			configDrive := volume.CloudInitConfigDrive.DeepCopy()
+			replaceCloudInitData(cloudInit,
+				&configDrive.UserData, &configDrive.UserDataBase64, &configDrive.UserDataSecretRef,
+				&configDrive.NetworkData, &configDrive.NetworkDataBase64, &configDrive.NetworkDataSecretRef)
+			patchSet.AddOption(patch.WithReplace(fmt.Sprintf("/spec/template/spec/volumes/%d/cloudInitConfigDrive", idx), configDrive))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cloud-init data cannot be replaced, the source has no cloud-init volume")
+}
+
+// replaceCloudInitData replaces the user data and the network data of a cloud-init source, the
+// data which is not set in the clone is kept
+func replaceCloudInitData(cloudInit *clonev1alpha1.VirtualMachineCloneCloudInit,
+	userData, userDataBase64 *string, userDataSecretRef **corev1.LocalObjectReference,
+	networkData, networkDataBase64 *string, networkDataSecretRef **corev1.LocalObjectReference) {
+	if cloudInit.UserData != nil || cloudInit.UserDataSecretRef != nil {
+		*userData, *userDataBase64, *userDataSecretRef = "", "", nil
+		if cloudInit.UserData != nil {
+			*userData = *cloudInit.UserData
+		}
+		if cloudInit.UserDataSecretRef != nil {
+			*userDataSecretRef = cloudInit.UserDataSecretRef.DeepCopy()
+		}
+	}
+
+	if cloudInit.NetworkData != nil || cloudInit.NetworkDataSecretRef != nil {
+		*networkData, *networkDataBase64, *networkDataSecretRef = "", "", nil
+		if cloudInit.NetworkData != nil {
+			*networkData = *cloudInit.NetworkData
+		}
+		if cloudInit.NetworkDataSecretRef != nil {
+			*networkDataSecretRef = cloudInit.NetworkDataSecretRef.DeepCopy()
+		}
+	}
+}
@@ -363,7 +363,7 @@ func getMarkedForEvictionVMIs(vmis []*virtv1.VirtualMachineInstance) []*virtv1.V
 	return evictionCandidates
 }
 
-func GenerateNewMigration(vmiName string, key string) *virtv1.VirtualMachineInstanceMigration {
+func GenerateNewMigration(vmiName string, key string, priority virtv1.MigrationPriority) *virtv1.VirtualMachineInstanceMigration {
 
 	annotations := map[string]string{
 		virtv1.EvacuationMigrationAnnotation: key,
@@ -374,7 +374,8 @@ func GenerateNewMigration(vmiName string, key string) *virtv1.VirtualMachineInst
 			GenerateName: "kubevirt-evacuation-",
 		},
 		Spec: virtv1.VirtualMachineInstanceMigrationSpec{
-			VMIName: vmiName,
+			VMIName:  vmiName,
+			Priority: priority,
 		},
 	}
 }
@@ -437,6 +438,12 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 
 	log.DefaultLogger().Infof("node: %v, migrations: %v, candidates: %v, selected: %v", node.Name, len(activeMigrations), len(migrationCandidates), len(selectedCandidates))
 
+	// Migrations draining a node start before the migrations of VMIs evicted by the descheduler
+	priority := virtv1.MigrationPriorityDescheduler
+	if node.Spec.Unschedulable || nodeHasTaint(taint, node) {
+		priority = virtv1.MigrationPriorityEvacuation
+	}
+
 	wg := &sync.WaitGroup{}
 	wg.Add(diff)
 
@@ -446,7 +453,7 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 	for _, vmi := range selectedCandidates {
 		go func(vmi *virtv1.VirtualMachineInstance) {
 			defer wg.Done()
-			createdMigration, err := c.clientset.VirtualMachineInstanceMigration(vmi.Namespace).Create(context.Background(), GenerateNewMigration(vmi.Name, node.Name), v1.CreateOptions{})
+			createdMigration, err := c.clientset.VirtualMachineInstanceMigration(vmi.Namespace).Create(context.Background(), GenerateNewMigration(vmi.Name, node.Name, priority), v1.CreateOptions{})
 			if err != nil {
 				c.migrationExpectations.CreationObserved(node.Name)
 				c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedCreateVirtualMachineInstanceMigrationReason, "Error creating a Migration: %v", err)
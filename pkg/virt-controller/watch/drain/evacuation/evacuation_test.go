@@ -76,6 +76,13 @@ var _ = Describe("Evacuation", func() {
 		ExpectWithOffset(1, migrationList.Items).To(HaveLen(1))
 	}
 
+	expectMigrationPriority := func(priority v1.MigrationPriority) {
+		migrationList, err := fakeVirtClient.KubevirtV1().VirtualMachineInstanceMigrations(k8sv1.NamespaceDefault).List(context.TODO(), metav1.ListOptions{})
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		ExpectWithOffset(1, migrationList.Items).To(HaveLen(1))
+		ExpectWithOffset(1, migrationList.Items[0].Spec.Priority).To(Equal(priority))
+	}
+
 	BeforeEach(func() {
 		stop = make(chan struct{})
 		ctrl = gomock.NewController(GinkgoT())
@@ -117,8 +124,9 @@ var _ = Describe("Evacuation", func() {
 
 	Context("migration object creation", func() {
 		It("should have expected values and annotations", func() {
-			migration := evacuation.GenerateNewMigration("my-vmi", "somenode")
+			migration := evacuation.GenerateNewMigration("my-vmi", "somenode", v1.MigrationPriorityEvacuation)
 			Expect(migration.Spec.VMIName).To(Equal("my-vmi"))
+			Expect(migration.Spec.Priority).To(Equal(v1.MigrationPriorityEvacuation))
 			Expect(migration.Annotations[v1.EvacuationMigrationAnnotation]).To(Equal("somenode"))
 		})
 
@@ -165,6 +173,7 @@ var _ = Describe("Evacuation", func() {
 			controller.Execute()
 			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
 			expectMigrationCreation()
+			expectMigrationPriority(v1.MigrationPriorityEvacuation)
 		})
 
 		It("should ignore VMIs which are not migratable", func() {
@@ -254,6 +263,20 @@ var _ = Describe("Evacuation", func() {
 			controller.Execute()
 			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
 			expectMigrationCreation()
+			expectMigrationPriority(v1.MigrationPriorityDescheduler)
+		})
+
+		It("Should evict the VMI with evacuation priority if the node is cordoned", func() {
+			node := newNode("foo")
+			node.Spec.Unschedulable = true
+			addNode(node)
+			vmi := newVirtualMachine("testvm", node.Name)
+			vmi.Spec.EvictionStrategy = newEvictionStrategyLiveMigrate()
+			vmi.Status.EvacuationNodeName = node.Name
+			vmiFeeder.Add(vmi)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+			expectMigrationPriority(v1.MigrationPriorityEvacuation)
 		})
 
 		It("Should record a warning on a not migratable VMI", func() {
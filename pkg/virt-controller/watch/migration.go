@@ -47,6 +47,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/pdbs"
 	"kubevirt.io/kubevirt/pkg/util/status"
@@ -115,6 +116,10 @@ type MigrationController struct {
 	handOffLock sync.Mutex
 	handOffMap  map[string]struct{}
 
+	// the time of the last failed creation of a target pod, by migration key
+	targetPodFailureLock sync.Mutex
+	targetPodFailureMap  map[string]time.Time
+
 	unschedulablePendingTimeoutSeconds int64
 	catchAllPendingTimeoutSeconds      int64
 }
@@ -151,6 +156,7 @@ func NewMigrationController(templateService services.TemplateService,
 		clusterConfig:        clusterConfig,
 		statusUpdater:        status.NewMigrationStatusUpdater(clientset),
 		handOffMap:           make(map[string]struct{}),
+		targetPodFailureMap:  make(map[string]time.Time),
 
 		unschedulablePendingTimeoutSeconds: defaultUnschedulablePendingTimeoutSeconds,
 		catchAllPendingTimeoutSeconds:      defaultCatchAllPendingTimeoutSeconds,
@@ -297,6 +303,7 @@ func (c *MigrationController) execute(key string) error {
 	if !exists {
 		c.podExpectations.DeleteExpectations(key)
 		c.removeHandOffKey(key)
+		c.removeTargetPodFailure(key)
 		return nil
 	}
 	migration := obj.(*virtv1.VirtualMachineInstanceMigration)
@@ -340,12 +347,13 @@ func (c *MigrationController) execute(key string) error {
 	logger.V(4).Infof("processing migration: needsSync %t, hasVMI %t, targetPod len %d", needsSync, vmiExists, len(targetPods))
 
 	var syncErr error
+	queue := c.newMigrationQueue()
 
 	if needsSync {
-		syncErr = c.sync(key, migration, vmi, targetPods)
+		syncErr = c.sync(key, migration, vmi, targetPods, queue)
 	}
 
-	err = c.updateStatus(migration, vmi, targetPods, syncErr)
+	err = c.updateStatus(migration, vmi, targetPods, queue, syncErr)
 	if err != nil {
 		return err
 	}
@@ -399,7 +407,7 @@ func (c *MigrationController) canMigrateVMI(migration *virtv1.VirtualMachineInst
 
 }
 
-func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, pods []*k8sv1.Pod, syncError error) error {
+func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, pods []*k8sv1.Pod, queue *migrationQueue, syncError error) error {
 
 	var pod *k8sv1.Pod = nil
 	var attachmentPod *k8sv1.Pod = nil
@@ -427,6 +435,7 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 
 		// remove the migration finalizaer
 		controller.RemoveFinalizer(migrationCopy, virtv1.VirtualMachineInstanceMigrationFinalizer)
+		clearMigrationQueueStatus(migrationCopy)
 
 		// Status checking of active Migration job.
 		//
@@ -492,7 +501,7 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, controller.FailedMigrationReason, "Migration failed because target attachment pod shutdown during migration")
 		log.Log.Object(migration).Errorf("target attachment pod %s/%s shutdown during migration", attachmentPod.Namespace, attachmentPod.Name)
 	} else {
-		err := c.processMigrationPhase(migration, migrationCopy, pod, attachmentPod, vmi, syncError)
+		err := c.processMigrationPhase(migration, migrationCopy, pod, attachmentPod, vmi, queue, syncError)
 		if err != nil {
 			return err
 		}
@@ -526,6 +535,7 @@ func (c *MigrationController) processMigrationPhase(
 	migration, migrationCopy *virtv1.VirtualMachineInstanceMigration,
 	pod, attachmentPod *k8sv1.Pod,
 	vmi *virtv1.VirtualMachineInstance,
+	queue *migrationQueue,
 	syncError error,
 ) error {
 	conditionManager := controller.NewVirtualMachineInstanceMigrationConditionManager()
@@ -537,7 +547,17 @@ func (c *MigrationController) processMigrationPhase(
 			return err
 		}
 
-		if canMigrate {
+		unsupportedOptions, err := c.unsupportedMigrationPolicyOptions(vmi)
+		if err != nil {
+			return err
+		}
+
+		if canMigrate && len(unsupportedOptions) > 0 {
+			// the matched migration policy asks for options the hypervisor can't honour
+			migrationCopy.Status.Phase = virtv1.MigrationFailed
+			c.recorder.Eventf(migration, k8sv1.EventTypeWarning, controller.FailedMigrationReason, "The migration policy requests %s, which is not supported by hypervisor %s.", strings.Join(unsupportedOptions, ", "), vmi.Spec.Hypervisor)
+			log.Log.Object(migration).Errorf("Migration policy options %v are not supported by hypervisor %s", unsupportedOptions, vmi.Spec.Hypervisor)
+		} else if canMigrate {
 			migrationCopy.Status.Phase = virtv1.MigrationPending
 		} else {
 			// can not migrate because there is an active migration already
@@ -548,6 +568,7 @@ func (c *MigrationController) processMigrationPhase(
 		}
 	case virtv1.MigrationPending:
 		if pod != nil {
+			clearMigrationQueueStatus(migrationCopy)
 			if controller.VMIHasHotplugVolumes(vmi) {
 				if attachmentPod != nil {
 					migrationCopy.Status.Phase = virtv1.MigrationScheduling
@@ -555,7 +576,12 @@ func (c *MigrationController) processMigrationPhase(
 			} else {
 				migrationCopy.Status.Phase = virtv1.MigrationScheduling
 			}
-		} else if syncError != nil && strings.Contains(syncError.Error(), "exceeded quota") && !conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationRejectedByResourceQuota) {
+			break
+		}
+		if err := updateMigrationQueueStatus(queue, migration, migrationCopy); err != nil {
+			return err
+		}
+		if syncError != nil && strings.Contains(syncError.Error(), "exceeded quota") && !conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationRejectedByResourceQuota) {
 			condition := virtv1.VirtualMachineInstanceMigrationCondition{
 				Type:          virtv1.VirtualMachineInstanceMigrationRejectedByResourceQuota,
 				Status:        k8sv1.ConditionTrue,
@@ -726,8 +752,10 @@ func (c *MigrationController) createTargetPod(migration *virtv1.VirtualMachineIn
 			err = fmt.Errorf("failed to create vmi migration target pod: %v", err)
 		}
 		c.podExpectations.CreationObserved(key)
+		c.addTargetPodFailure(key)
 		return err
 	}
+	c.removeTargetPodFailure(key)
 	log.Log.Object(vmi).Infof("Created migration target pod %s/%s with uuid %s for migration %s with uuid %s", pod.Namespace, pod.Name, string(pod.UID), migration.Name, string(migration.UID))
 	c.recorder.Eventf(migration, k8sv1.EventTypeNormal, controller.SuccessfulCreatePodReason, "Created migration target pod %s", pod.Name)
 	return nil
@@ -756,27 +784,43 @@ func (c *MigrationController) expandPDB(pdb *policyv1.PodDisruptionBudget, vmi *
 // handleMigrationBackoff introduce a backoff (when needed) only for migrations
 // created by the evacuation controller.
 func (c *MigrationController) handleMigrationBackoff(key string, vmi *virtv1.VirtualMachineInstance, migration *virtv1.VirtualMachineInstanceMigration) error {
+	backoff, err := c.migrationBackoff(migration)
+	if err != nil {
+		return err
+	}
+
+	if backoff > 0 {
+		log.Log.Object(vmi).Errorf("vmi in migration backoff, re-enqueueing after %v", backoff)
+		c.Queue.AddAfter(key, backoff)
+		return migrationBackoffError
+	}
+	return nil
+}
+
+// migrationBackoff returns how long an evacuation or workload update migration has to wait
+// before it may start, because previous migrations of the same vmi failed.
+func (c *MigrationController) migrationBackoff(migration *virtv1.VirtualMachineInstanceMigration) (time.Duration, error) {
 	if _, exists := migration.Annotations[virtv1.FuncTestForceIgnoreMigrationBackoffAnnotation]; exists {
-		return nil
+		return 0, nil
 	}
 	_, existsEvacMig := migration.Annotations[virtv1.EvacuationMigrationAnnotation]
 	_, existsWorkUpdMig := migration.Annotations[virtv1.WorkloadUpdateMigrationAnnotation]
 	if !existsEvacMig && !existsWorkUpdMig {
-		return nil
+		return 0, nil
 	}
 
-	migrations, err := c.listBackoffEligibleMigrations(vmi.Namespace, vmi.Name)
+	migrations, err := c.listBackoffEligibleMigrations(migration.Namespace, migration.Spec.VMIName)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if len(migrations) < 2 {
-		return nil
+		return 0, nil
 	}
 
 	// Newest first
 	sort.Sort(sort.Reverse(vmimCollection(migrations)))
 	if migrations[0].UID != migration.UID {
-		return nil
+		return 0, nil
 	}
 
 	backoff := time.Second * 0
@@ -797,7 +841,7 @@ func (c *MigrationController) handleMigrationBackoff(key string, vmi *virtv1.Vir
 		}
 	}
 	if backoff == 0 {
-		return nil
+		return 0, nil
 	}
 
 	getFailedTS := func(migration *virtv1.VirtualMachineInstanceMigration) metav1.Time {
@@ -810,14 +854,7 @@ func (c *MigrationController) handleMigrationBackoff(key string, vmi *virtv1.Vir
 	}
 
 	outOffBackoffTS := getFailedTS(migrations[1]).Add(backoff)
-	backoff = outOffBackoffTS.Sub(time.Now())
-
-	if backoff > 0 {
-		log.Log.Object(vmi).Errorf("vmi in migration backoff, re-enqueueing after %v", backoff)
-		c.Queue.AddAfter(key, backoff)
-		return migrationBackoffError
-	}
-	return nil
+	return outOffBackoffTS.Sub(time.Now()), nil
 }
 
 func (c *MigrationController) handleMarkMigrationFailedOnVMI(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
@@ -991,7 +1028,7 @@ func filterOutOldPDBs(pdbList []*policyv1.PodDisruptionBudget) []*policyv1.PodDi
 	return filteredPdbs
 }
 
-func (c *MigrationController) handleTargetPodCreation(key string, migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, sourcePod *k8sv1.Pod) error {
+func (c *MigrationController) handleTargetPodCreation(key string, migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, sourcePod *k8sv1.Pod, queue *migrationQueue) error {
 
 	c.migrationStartLock.Lock()
 	defer c.migrationStartLock.Unlock()
@@ -1007,34 +1044,19 @@ func (c *MigrationController) handleTargetPodCreation(key string, migration *vir
 
 	}
 
-	// Don't start new migrations if we wait for migration object updates because of new target pods
-	runningMigrations, err := c.findRunningMigrations()
+	// Don't start new migrations if the parallel migration limits leave no slot
+	// for this migration after the migrations ahead of it in the queue
+	queueStatus, err := queue.status(migration)
 	if err != nil {
-		return fmt.Errorf("failed to determin the number of running migrations: %v", err)
+		return fmt.Errorf("failed to determine the position of the migration in the queue: %v", err)
 	}
-
-	// XXX: Make this configurable, think about limit per node, bandwidth per migration, and so on.
-	if len(runningMigrations) >= int(*c.clusterConfig.GetMigrationConfiguration().ParallelMigrationsPerCluster) {
-		log.Log.Object(migration).Infof("Waiting to schedule target pod for vmi [%s/%s] migration because total running parallel migration count [%d] is currently at the global cluster limit.", vmi.Namespace, vmi.Name, len(runningMigrations))
+	if !queueStatus.admitted {
+		log.Log.Object(migration).Infof("Waiting to schedule target pod for vmi [%s/%s] migration at queue position %d: %s", vmi.Namespace, vmi.Name, queueStatus.position, queueStatus.message)
 		// Let's wait until some migrations are done
 		c.Queue.AddAfter(key, time.Second*5)
 		return nil
 	}
 
-	outboundMigrations, err := c.outboundMigrationsOnNode(vmi.Status.NodeName, runningMigrations)
-
-	if err != nil {
-		return err
-	}
-
-	if outboundMigrations >= int(*c.clusterConfig.GetMigrationConfiguration().ParallelOutboundMigrationsPerNode) {
-		// Let's ensure that we only have two outbound migrations per node
-		// XXX: Make this configurable, thinkg about inbound migration limit, bandwidh per migration, and so on.
-		log.Log.Object(migration).Infof("Waiting to schedule target pod for vmi [%s/%s] migration because total running parallel outbound migrations on target node [%d] has hit outbound migrations per node limit.", vmi.Namespace, vmi.Name, outboundMigrations)
-		c.Queue.AddAfter(key, time.Second*5)
-		return nil
-	}
-
 	// migration was accepted into the system, now see if we
 	// should create the target pod
 	if vmi.IsRunning() {
@@ -1235,7 +1257,7 @@ func (c *MigrationController) handlePendingPodTimeout(migration *virtv1.VirtualM
 	return nil
 }
 
-func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, pods []*k8sv1.Pod) error {
+func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, pods []*k8sv1.Pod, queue *migrationQueue) error {
 
 	var pod *k8sv1.Pod = nil
 	targetPodExists := len(pods) > 0
@@ -1305,7 +1327,7 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 				}
 			}
 
-			return c.handleTargetPodCreation(key, migration, vmi, sourcePod)
+			return c.handleTargetPodCreation(key, migration, vmi, sourcePod, queue)
 		} else if controller.IsPodReady(pod) {
 			if controller.VMIHasHotplugVolumes(vmi) {
 				attachmentPods, err := controller.AttachmentPods(pod, c.podIndexer)
@@ -1829,49 +1851,6 @@ func (c *MigrationController) deleteVMI(obj interface{}) {
 	}
 }
 
-func (c *MigrationController) outboundMigrationsOnNode(node string, runningMigrations []*virtv1.VirtualMachineInstanceMigration) (int, error) {
-	sum := 0
-	for _, migration := range runningMigrations {
-		if vmi, exists, _ := c.vmiStore.GetByKey(migration.Namespace + "/" + migration.Spec.VMIName); exists {
-			if vmi.(*virtv1.VirtualMachineInstance).Status.NodeName == node {
-				sum = sum + 1
-			}
-		}
-	}
-	return sum, nil
-}
-
-// findRunningMigrations calcules how many migrations are running or in flight to be triggered to running
-// Migrations which are in running phase are added alongside with migrations which are still pending but
-// where we already see a target pod.
-func (c *MigrationController) findRunningMigrations() ([]*virtv1.VirtualMachineInstanceMigration, error) {
-
-	// Don't start new migrations if we wait for migration object updates because of new target pods
-	notFinishedMigrations := migrations.ListUnfinishedMigrations(c.migrationIndexer)
-	var runningMigrations []*virtv1.VirtualMachineInstanceMigration
-	for _, migration := range notFinishedMigrations {
-		if migration.IsRunning() {
-			runningMigrations = append(runningMigrations, migration)
-			continue
-		}
-		vmi, exists, err := c.vmiStore.GetByKey(migration.Namespace + "/" + migration.Spec.VMIName)
-		if err != nil {
-			return nil, err
-		}
-		if !exists {
-			continue
-		}
-		pods, err := c.listMatchingTargetPods(migration, vmi.(*virtv1.VirtualMachineInstance))
-		if err != nil {
-			return nil, err
-		}
-		if len(pods) > 0 {
-			runningMigrations = append(runningMigrations, migration)
-		}
-	}
-	return runningMigrations, nil
-}
-
 func (c *MigrationController) getNodeForVMI(vmi *virtv1.VirtualMachineInstance) (*k8sv1.Node, error) {
 	obj, exists, err := c.nodeStore.GetByKey(vmi.Status.NodeName)
 
@@ -1971,10 +1950,10 @@ func isNodeSuitableForHostModelMigration(node *k8sv1.Node, requiredNodeLabels ma
 	return true
 }
 
-func (c *MigrationController) matchMigrationPolicy(vmi *virtv1.VirtualMachineInstance, clusterMigrationConfiguration *virtv1.MigrationConfiguration) error {
+func (c *MigrationController) getMatchedMigrationPolicy(vmi *virtv1.VirtualMachineInstance) (*v1alpha1.MigrationPolicy, error) {
 	vmiNamespace, err := c.clientset.CoreV1().Namespaces().Get(context.Background(), vmi.Namespace, v1.GetOptions{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Fetch cluster policies
@@ -1986,8 +1965,40 @@ func (c *MigrationController) matchMigrationPolicy(vmi *virtv1.VirtualMachineIns
 	}
 	policiesListObj := v1alpha1.MigrationPolicyList{Items: policies}
 
+	return MatchPolicy(&policiesListObj, vmi, vmiNamespace), nil
+}
+
+// unsupportedMigrationPolicyOptions returns the options requested by the migration policy
+// matching the VMI which the hypervisor of the VMI can't honour. Options coming from the
+// cluster-wide migration configuration are not reported, they are dropped by virt-launcher
+// for hypervisors which don't support them.
+func (c *MigrationController) unsupportedMigrationPolicyOptions(vmi *virtv1.VirtualMachineInstance) ([]string, error) {
+	capabilities, exists := hypervisor.GetCapabilities(vmi.Spec.Hypervisor)
+	if !exists || capabilities.PostCopyMigration && capabilities.AutoConvergeMigration {
+		return nil, nil
+	}
+
+	matchedPolicy, err := c.getMatchedMigrationPolicy(vmi)
+	if err != nil || matchedPolicy == nil {
+		return nil, err
+	}
+
+	var unsupported []string
+	if matchedPolicy.Spec.AllowPostCopy != nil && *matchedPolicy.Spec.AllowPostCopy && !capabilities.PostCopyMigration {
+		unsupported = append(unsupported, "post-copy")
+	}
+	if matchedPolicy.Spec.AllowAutoConverge != nil && *matchedPolicy.Spec.AllowAutoConverge && !capabilities.AutoConvergeMigration {
+		unsupported = append(unsupported, "auto-converge")
+	}
+	return unsupported, nil
+}
+
+func (c *MigrationController) matchMigrationPolicy(vmi *virtv1.VirtualMachineInstance, clusterMigrationConfiguration *virtv1.MigrationConfiguration) error {
 	// Override cluster-wide migration configuration if migration policy is matched
-	matchedPolicy := MatchPolicy(&policiesListObj, vmi, vmiNamespace)
+	matchedPolicy, err := c.getMatchedMigrationPolicy(vmi)
+	if err != nil {
+		return err
+	}
 
 	if matchedPolicy == nil {
 		log.Log.Object(vmi).Reason(err).Infof("no migration policy matched for VMI %s", vmi.Name)
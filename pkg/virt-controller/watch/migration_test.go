@@ -957,6 +957,168 @@ var _ = Describe("Migration watcher", func() {
 		)
 	})
 
+	Context("Migration queue", func() {
+		var created time.Time
+
+		BeforeEach(func() {
+			created = time.Now().Add(-time.Hour)
+		})
+
+		addRunningMigrations := func(prefix, namespace string, count int, nodeName func(i int) string) {
+			for i := 0; i < count; i++ {
+				vmi := newVirtualMachine(fmt.Sprintf("%svmi%d", prefix, i), virtv1.Running)
+				vmi.Namespace = namespace
+				addNodeNameToVMI(vmi, nodeName(i))
+				migration := newMigration(fmt.Sprintf("%smigration%d", prefix, i), vmi.Name, virtv1.MigrationScheduling)
+				migration.Namespace = namespace
+
+				addMigration(migration)
+				addVirtualMachineInstance(vmi)
+			}
+		}
+
+		onDifferentNodes := func(i int) string {
+			return fmt.Sprintf("node%d", i)
+		}
+
+		newPendingMigration := func(name, namespace, nodeName string, priority virtv1.MigrationPriority, age time.Duration) *virtv1.VirtualMachineInstanceMigration {
+			vmi := newVirtualMachine(name+"vmi", virtv1.Running)
+			vmi.Namespace = namespace
+			addNodeNameToVMI(vmi, nodeName)
+			migration := newMigration(name, vmi.Name, virtv1.MigrationPending)
+			migration.Namespace = namespace
+			migration.Spec.Priority = priority
+			migration.CreationTimestamp = metav1.NewTime(created.Add(age))
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+			return migration
+		}
+
+		expectMigrationQueued := func(migration *virtv1.VirtualMachineInstanceMigration, position int32, reason string) {
+			updatedVMIM, err := virtClientset.KubevirtV1().VirtualMachineInstanceMigrations(migration.Namespace).Get(context.Background(), migration.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updatedVMIM.Status.Phase).To(Equal(virtv1.MigrationPending))
+			Expect(updatedVMIM.Status.QueuePosition).To(HaveValue(Equal(position)))
+			Expect(updatedVMIM.Status.Conditions).To(ContainElement(
+				MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(virtv1.VirtualMachineInstanceMigrationQueued),
+					"Status": Equal(k8sv1.ConditionTrue),
+					"Reason": Equal(reason),
+				}),
+			))
+		}
+
+		It("should queue the migration when the cluster limit is reached", func() {
+			migration := newPendingMigration("testmigration", k8sv1.NamespaceDefault, "tefwegwrerg", "", 0)
+			addRunningMigrations("running", k8sv1.NamespaceDefault, 5, onDifferentNodes)
+
+			controller.Execute()
+
+			expectPodDoesNotExist(migration.Namespace, migration.Spec.VMIName, migration.Name)
+			expectMigrationQueued(migration, 1, migrationQueuedClusterLimitReason)
+		})
+
+		It("should start migrations with a higher priority first", func() {
+			migration := newPendingMigration("testmigration", k8sv1.NamespaceDefault, "tefwegwrerg", virtv1.MigrationPriorityWorkloadUpdate, 0)
+			newPendingMigration("evacuation", k8sv1.NamespaceDefault, "drained", virtv1.MigrationPriorityEvacuation, time.Minute)
+			addRunningMigrations("running", k8sv1.NamespaceDefault, 4, onDifferentNodes)
+
+			controller.Execute()
+
+			expectPodDoesNotExist(migration.Namespace, migration.Spec.VMIName, migration.Name)
+			expectMigrationQueued(migration, 1, migrationQueuedClusterLimitReason)
+		})
+
+		It("should let namespaces take turns within a priority", func() {
+			migration := newPendingMigration("testmigration", k8sv1.NamespaceDefault, "tefwegwrerg", "", 0)
+			newPendingMigration("othermigration", "other", "othernode", "", time.Minute)
+			addRunningMigrations("running", k8sv1.NamespaceDefault, 4, onDifferentNodes)
+
+			controller.Execute()
+
+			expectPodDoesNotExist(migration.Namespace, migration.Spec.VMIName, migration.Name)
+			expectMigrationQueued(migration, 1, migrationQueuedClusterLimitReason)
+		})
+
+		It("should not let a migration waiting for its source node block migrations from other nodes", func() {
+			evacuation := newPendingMigration("evacuation", k8sv1.NamespaceDefault, "busy", virtv1.MigrationPriorityEvacuation, 0)
+			workloadUpdate := newPendingMigration("workloadupdate", k8sv1.NamespaceDefault, "idle", virtv1.MigrationPriorityWorkloadUpdate, time.Minute)
+			addRunningMigrations("running", k8sv1.NamespaceDefault, 2, func(int) string { return "busy" })
+
+			queue := controller.newMigrationQueue()
+			queueStatus, err := queue.status(evacuation)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queueStatus.admitted).To(BeFalse())
+			Expect(queueStatus.position).To(Equal(int32(1)))
+			Expect(queueStatus.reason).To(Equal(migrationQueuedNodeLimitReason))
+
+			queueStatus, err = queue.status(workloadUpdate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queueStatus.admitted).To(BeTrue())
+		})
+
+		DescribeTable("should not let migrations which can't start block other migrations", func(block func(migration *virtv1.VirtualMachineInstanceMigration)) {
+			for i := 0; i < 5; i++ {
+				stuck := newPendingMigration(fmt.Sprintf("stuck%d", i), k8sv1.NamespaceDefault, fmt.Sprintf("drained%d", i), virtv1.MigrationPriorityEvacuation, time.Duration(i)*time.Second)
+				block(stuck)
+			}
+			workloadUpdate := newPendingMigration("workloadupdate", k8sv1.NamespaceDefault, "idle", virtv1.MigrationPriorityWorkloadUpdate, time.Minute)
+
+			queue := controller.newMigrationQueue()
+			queueStatus, err := queue.status(workloadUpdate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queueStatus.admitted).To(BeTrue())
+		},
+			Entry("when they are rejected by the resource quota", func(migration *virtv1.VirtualMachineInstanceMigration) {
+				migration.Status.Conditions = append(migration.Status.Conditions, virtv1.VirtualMachineInstanceMigrationCondition{
+					Type:   virtv1.VirtualMachineInstanceMigrationRejectedByResourceQuota,
+					Status: k8sv1.ConditionTrue,
+				})
+			}),
+			Entry("when their target pod recently failed to be created", func(migration *virtv1.VirtualMachineInstanceMigration) {
+				controller.addTargetPodFailure(virtcontroller.MigrationKey(migration))
+			}),
+		)
+
+		It("should queue a migration again in order once its target pod failure is no longer recent", func() {
+			migration := newPendingMigration("testmigration", k8sv1.NamespaceDefault, "tefwegwrerg", virtv1.MigrationPriorityEvacuation, 0)
+			newPendingMigration("othermigration", k8sv1.NamespaceDefault, "othernode", virtv1.MigrationPriorityEvacuation, time.Minute)
+			addRunningMigrations("running", k8sv1.NamespaceDefault, 4, onDifferentNodes)
+			controller.targetPodFailureMap[virtcontroller.MigrationKey(migration)] = time.Now().Add(-recentTargetPodFailure)
+
+			queueStatus, err := controller.newMigrationQueue().status(migration)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queueStatus.admitted).To(BeTrue())
+		})
+
+		It("should remove the queue position once the target pod exists", func() {
+			vmi := newVirtualMachine("testvmi", virtv1.Running)
+			migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPending)
+			migration.Status.QueuePosition = pointer.P(int32(1))
+			migration.Status.Conditions = []virtv1.VirtualMachineInstanceMigrationCondition{{
+				Type:   virtv1.VirtualMachineInstanceMigrationQueued,
+				Status: k8sv1.ConditionTrue,
+				Reason: migrationQueuedClusterLimitReason,
+			}}
+			targetPod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodPending)
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+			addPod(targetPod)
+
+			controller.Execute()
+
+			updatedVMIM, err := virtClientset.KubevirtV1().VirtualMachineInstanceMigrations(migration.Namespace).Get(context.Background(), migration.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updatedVMIM.Status.Phase).To(Equal(virtv1.MigrationScheduling))
+			Expect(updatedVMIM.Status.QueuePosition).To(BeNil())
+			Expect(updatedVMIM.Status.Conditions).To(BeEmpty())
+		})
+	})
+
 	Context("Migration garbage collection", func() {
 		DescribeTable("should garbage old finalized migration objects", func(phase virtv1.VirtualMachineInstanceMigrationPhase) {
 			vmi := newVirtualMachine("testvmi", virtv1.Running)
@@ -1864,6 +2026,38 @@ var _ = Describe("Migration watcher", func() {
 				false,
 			),
 		)
+
+		DescribeTable("should reject options the hypervisor can't honour", func(hypervisorName string, defineMigrationPolicy func(*migrationsv1.MigrationPolicySpec), expectFailure bool) {
+			vmi = newVirtualMachine("testvmi", virtv1.Running)
+			vmi.Spec.Hypervisor = hypervisorName
+			migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPhaseUnset)
+
+			migrationPolicy := generatePolicyAndAlignVMI(vmi)
+			defineMigrationPolicy(&migrationPolicy.Spec)
+
+			addMigrationPolicies(*migrationPolicy)
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+
+			controller.Execute()
+
+			if expectFailure {
+				testutils.ExpectEvent(recorder, virtcontroller.FailedMigrationReason)
+				expectMigrationFailedState(migration.Namespace, migration.Name)
+			} else {
+				expectMigrationPendingState(migration.Namespace, migration.Name)
+			}
+		},
+			Entry("post copy with QEMU", "qemu",
+				func(p *migrationsv1.MigrationPolicySpec) { p.AllowPostCopy = pointer.P(true) }, false),
+			Entry("post copy with Cloud Hypervisor", "ch",
+				func(p *migrationsv1.MigrationPolicySpec) { p.AllowPostCopy = pointer.P(true) }, true),
+			Entry("auto converge with Cloud Hypervisor", "ch",
+				func(p *migrationsv1.MigrationPolicySpec) { p.AllowAutoConverge = pointer.P(true) }, true),
+			Entry("denied post copy with Cloud Hypervisor", "ch",
+				func(p *migrationsv1.MigrationPolicySpec) { p.AllowPostCopy = pointer.P(false) }, false),
+		)
 	})
 
 	Context("Migration of host-model VMI", func() {
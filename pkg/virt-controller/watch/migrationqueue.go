@@ -0,0 +1,329 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	virtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
+	"kubevirt.io/kubevirt/pkg/util/pdbs"
+)
+
+const (
+	// migrationQueuedClusterLimitReason is set when a migration waits because of the ParallelMigrationsPerCluster limit
+	migrationQueuedClusterLimitReason = "ParallelMigrationsPerClusterLimitReached"
+	// migrationQueuedNodeLimitReason is set when a migration waits because of the ParallelOutboundMigrationsPerNode limit
+	migrationQueuedNodeLimitReason = "ParallelOutboundMigrationsPerNodeLimitReached"
+)
+
+// recentTargetPodFailure is how long a failed creation of a target pod keeps a migration at the end of the queue
+const recentTargetPodFailure = 5 * time.Minute
+
+// migrationQueueEntry is a migration which occupies or waits for a migration slot
+type migrationQueueEntry struct {
+	migration  *virtv1.VirtualMachineInstanceMigration
+	sourceNode string
+	// round is the turn of the namespace of the migration among the pending migrations of the same priority
+	round int
+	// blocked is set if the migration may not start even with a free slot, so it must not hold up other migrations
+	blocked bool
+}
+
+// migrationQueueStatus describes whether a pending migration may start, or where it waits in the queue
+type migrationQueueStatus struct {
+	admitted bool
+	position int32
+	reason   string
+	message  string
+}
+
+// migrationQueue holds the migrations which occupy a migration slot, because they are running or
+// their target pod is already created, and the pending migrations which wait for a slot in the order
+// in which they get one. It is computed at most once per sync of a migration.
+type migrationQueue struct {
+	controller *MigrationController
+	computed   bool
+	running    []migrationQueueEntry
+	pending    []migrationQueueEntry
+}
+
+func (c *MigrationController) newMigrationQueue() *migrationQueue {
+	return &migrationQueue{controller: c}
+}
+
+func (q *migrationQueue) compute() error {
+	if q.computed {
+		return nil
+	}
+	c := q.controller
+
+	// a single pass over the pods finds the target pods of all migrations
+	migrationsWithTargetPod := map[types.UID]bool{}
+	activePodsPerVMI := map[types.UID]int{}
+	for _, obj := range c.podIndexer.List() {
+		pod := obj.(*k8sv1.Pod)
+		if migrationUID, ok := pod.Labels[virtv1.MigrationJobLabel]; ok && pod.Labels[virtv1.AppLabel] == "virt-launcher" {
+			migrationsWithTargetPod[types.UID(migrationUID)] = true
+		}
+		if pod.Status.Phase == k8sv1.PodSucceeded || pod.Status.Phase == k8sv1.PodFailed {
+			continue
+		}
+		if owner := v1.GetControllerOf(pod); owner != nil {
+			activePodsPerVMI[owner.UID]++
+		}
+	}
+
+	for _, migration := range migrations.ListUnfinishedMigrations(c.migrationIndexer) {
+		obj, exists, err := c.vmiStore.GetByKey(migration.Namespace + "/" + migration.Spec.VMIName)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// Migrations of deleted vmis keep their slot until they are finalized
+			if migration.IsRunning() {
+				q.running = append(q.running, migrationQueueEntry{migration: migration})
+			}
+			continue
+		}
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		entry := migrationQueueEntry{migration: migration, sourceNode: vmi.Status.NodeName}
+
+		if migration.IsRunning() || migrationsWithTargetPod[migration.UID] {
+			q.running = append(q.running, entry)
+			continue
+		}
+
+		if migration.Status.Phase != virtv1.MigrationPending || migration.DeletionTimestamp != nil ||
+			!vmi.IsRunning() || vmi.DeletionTimestamp != nil {
+			continue
+		}
+		// Migrations in backoff don't compete for a slot before the backoff expired
+		backoff, err := c.migrationBackoff(migration)
+		if err != nil {
+			return err
+		}
+		if backoff > 0 {
+			continue
+		}
+		entry.blocked, err = c.isMigrationBlocked(migration, vmi, activePodsPerVMI[vmi.UID])
+		if err != nil {
+			return err
+		}
+		q.pending = append(q.pending, entry)
+	}
+
+	sortMigrationQueue(q.pending, q.running)
+	q.computed = true
+	return nil
+}
+
+// isMigrationBlocked returns true if the target pod of a pending migration can't be created, or the
+// migration waits for something else than a migration slot before creating it
+func (c *MigrationController) isMigrationBlocked(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, activePods int) (bool, error) {
+	if controller.NewVirtualMachineInstanceMigrationConditionManager().HasCondition(migration, virtv1.VirtualMachineInstanceMigrationRejectedByResourceQuota) {
+		return true, nil
+	}
+	if c.hasRecentTargetPodFailure(controller.MigrationKey(migration)) {
+		return true, nil
+	}
+	// other pods of the vmi have to go away before the target pod is created
+	if activePods > 1 {
+		return true, nil
+	}
+	if !migrations.VMIMigratableOnEviction(c.clusterConfig, vmi) {
+		return false, nil
+	}
+	vmiPDBs, err := pdbs.PDBsForVMI(vmi, c.pdbIndexer)
+	if err != nil {
+		return false, err
+	}
+	for _, pdb := range vmiPDBs {
+		// the pdb was expanded for the migration, but doesn't protect the migration pods yet
+		if pdb.Labels[virtv1.MigrationNameLabel] == migration.Name && !isMigrationProtected(pdb) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// sortMigrationQueue orders pending migrations by priority, after all migrations which are not blocked.
+// Within a priority the namespaces take turns, so that a namespace with many migrations doesn't starve
+// the others: the n-th pending migration of a namespace waits for the n-th turn, after the turns taken
+// by its running migrations. Migrations with the same turn start in creation order.
+func sortMigrationQueue(queue []migrationQueueEntry, running []migrationQueueEntry) {
+	sort.SliceStable(queue, func(i, j int) bool {
+		return migrationCreatedBefore(queue[i].migration, queue[j].migration)
+	})
+
+	runningPerNamespace := map[string]int{}
+	for _, entry := range running {
+		runningPerNamespace[entry.migration.Namespace]++
+	}
+	turns := map[string]int{}
+	for i := range queue {
+		migration := queue[i].migration
+		key := fmt.Sprintf("%s/%s", migrations.Priority(migration), migration.Namespace)
+		queue[i].round = runningPerNamespace[migration.Namespace] + turns[key]
+		turns[key]++
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		if queue[i].blocked != queue[j].blocked {
+			return !queue[i].blocked
+		}
+		rankI, rankJ := migrations.PriorityRank(queue[i].migration), migrations.PriorityRank(queue[j].migration)
+		if rankI != rankJ {
+			return rankI > rankJ
+		}
+		return queue[i].round < queue[j].round
+	})
+}
+
+func migrationCreatedBefore(a, b *virtv1.VirtualMachineInstanceMigration) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// status hands out the free migration slots to the pending migrations in the order of the queue,
+// and reports whether the given migration gets one of them.
+// A migration which doesn't fit the outbound limit of its source node doesn't block the migrations
+// behind it from other source nodes.
+func (q *migrationQueue) status(migration *virtv1.VirtualMachineInstanceMigration) (*migrationQueueStatus, error) {
+	if err := q.compute(); err != nil {
+		return nil, err
+	}
+	running, queue := q.running, q.pending
+
+	migrationConfig := q.controller.clusterConfig.GetMigrationConfiguration()
+	clusterLimit := int(*migrationConfig.ParallelMigrationsPerCluster)
+	nodeLimit := int(*migrationConfig.ParallelOutboundMigrationsPerNode)
+
+	usedSlots := len(running)
+	outboundMigrations := map[string]int{}
+	for _, entry := range running {
+		outboundMigrations[entry.sourceNode]++
+	}
+
+	position := int32(0)
+	for _, entry := range queue {
+		isMigration := entry.migration.Namespace == migration.Namespace && entry.migration.Name == migration.Name
+		clusterFull := usedSlots >= clusterLimit
+		nodeFull := outboundMigrations[entry.sourceNode] >= nodeLimit
+
+		if !clusterFull && !nodeFull {
+			if isMigration {
+				return &migrationQueueStatus{admitted: true}, nil
+			}
+			usedSlots++
+			outboundMigrations[entry.sourceNode]++
+			continue
+		}
+
+		position++
+		if !isMigration {
+			continue
+		}
+		if clusterFull {
+			return &migrationQueueStatus{
+				position: position,
+				reason:   migrationQueuedClusterLimitReason,
+				message:  fmt.Sprintf("the cluster reached its limit of %d parallel migrations", clusterLimit),
+			}, nil
+		}
+		return &migrationQueueStatus{
+			position: position,
+			reason:   migrationQueuedNodeLimitReason,
+			message:  fmt.Sprintf("source node %s reached its limit of %d parallel outbound migrations", entry.sourceNode, nodeLimit),
+		}, nil
+	}
+
+	// The migration doesn't wait for a slot, e.g. because it's in backoff
+	return &migrationQueueStatus{admitted: true}, nil
+}
+
+// updateMigrationQueueStatus reports the queue position of a pending migration which waits for a migration slot
+func updateMigrationQueueStatus(queue *migrationQueue, migration, migrationCopy *virtv1.VirtualMachineInstanceMigration) error {
+	queueStatus, err := queue.status(migration)
+	if err != nil {
+		return err
+	}
+	if queueStatus.admitted {
+		clearMigrationQueueStatus(migrationCopy)
+		return nil
+	}
+
+	migrationCopy.Status.QueuePosition = pointer.P(queueStatus.position)
+	now := v1.Now()
+	controller.NewVirtualMachineInstanceMigrationConditionManager().UpdateCondition(migrationCopy, &virtv1.VirtualMachineInstanceMigrationCondition{
+		Type:               virtv1.VirtualMachineInstanceMigrationQueued,
+		Status:             k8sv1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             queueStatus.reason,
+		Message:            fmt.Sprintf("Waiting for a free migration slot: %s", queueStatus.message),
+	})
+	return nil
+}
+
+// clearMigrationQueueStatus removes the queue position of a migration which no longer waits for a migration slot
+func clearMigrationQueueStatus(migrationCopy *virtv1.VirtualMachineInstanceMigration) {
+	migrationCopy.Status.QueuePosition = nil
+	conditionManager := controller.NewVirtualMachineInstanceMigrationConditionManager()
+	if conditionManager.HasCondition(migrationCopy, virtv1.VirtualMachineInstanceMigrationQueued) {
+		conditionManager.RemoveCondition(migrationCopy, virtv1.VirtualMachineInstanceMigrationQueued)
+	}
+}
+
+func (c *MigrationController) addTargetPodFailure(migrationKey string) {
+	c.targetPodFailureLock.Lock()
+	defer c.targetPodFailureLock.Unlock()
+
+	c.targetPodFailureMap[migrationKey] = time.Now()
+}
+
+func (c *MigrationController) removeTargetPodFailure(migrationKey string) {
+	c.targetPodFailureLock.Lock()
+	defer c.targetPodFailureLock.Unlock()
+
+	delete(c.targetPodFailureMap, migrationKey)
+}
+
+func (c *MigrationController) hasRecentTargetPodFailure(migrationKey string) bool {
+	c.targetPodFailureLock.Lock()
+	defer c.targetPodFailureLock.Unlock()
+
+	failed, exists := c.targetPodFailureMap[migrationKey]
+	return exists && time.Since(failed) < recentTargetPodFailure
+}
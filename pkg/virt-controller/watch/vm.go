@@ -63,6 +63,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	"kubevirt.io/kubevirt/pkg/instancetype"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
@@ -535,7 +536,7 @@ func removeMemoryDumpVolumeFromVMISpec(vmiSpec *virtv1.VirtualMachineInstanceSpe
 	return vmiSpec
 }
 
-func applyMemoryDumpVolumeRequestOnVMISpec(vmiSpec *virtv1.VirtualMachineInstanceSpec, claimName string) *virtv1.VirtualMachineInstanceSpec {
+func applyMemoryDumpVolumeRequestOnVMISpec(vmiSpec *virtv1.VirtualMachineInstanceSpec, claimName string, dumpType virtv1.MemoryDumpType) *virtv1.VirtualMachineInstanceSpec {
 	for _, volume := range vmiSpec.Volumes {
 		if volume.Name == claimName {
 			return vmiSpec
@@ -549,6 +550,7 @@ func applyMemoryDumpVolumeRequestOnVMISpec(vmiSpec *virtv1.VirtualMachineInstanc
 			},
 			Hotpluggable: true,
 		},
+		Type: dumpType,
 	}
 
 	newVolume := virtv1.Volume{
@@ -579,7 +581,7 @@ func (c *VMController) generateVMIMemoryDumpVolumePatch(vmi *virtv1.VirtualMachi
 
 	vmiCopy := vmi.DeepCopy()
 	if addVolume {
-		vmiCopy.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vmiCopy.Spec, request.ClaimName)
+		vmiCopy.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vmiCopy.Spec, request.ClaimName, request.Type)
 	} else {
 		vmiCopy.Spec = *removeMemoryDumpVolumeFromVMISpec(&vmiCopy.Spec, request.ClaimName)
 	}
@@ -839,7 +841,7 @@ func (c *VMController) handleMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *v
 		// When in state associating we want to add the memory dump pvc
 		// as a volume in the vm and in the vmi to trigger the mount
 		// to virt launcher and the memory dump
-		vm.Spec.Template.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vm.Spec.Template.Spec, vm.Status.MemoryDumpRequest.ClaimName)
+		vm.Spec.Template.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vm.Spec.Template.Spec, vm.Status.MemoryDumpRequest.ClaimName, vm.Status.MemoryDumpRequest.Type)
 		if _, exists := vmiVolumeMap[vm.Status.MemoryDumpRequest.ClaimName]; exists {
 			return nil
 		}
@@ -943,7 +945,14 @@ func (c *VMController) handleVolumeUpdateRequest(vm *virtv1.VirtualMachine, vmi
 		}
 		vmCopy.Spec.Template.Spec.Volumes[i].ContainerDisk.ImagePullPolicy = vmiVol.ContainerDisk.ImagePullPolicy
 	}
-	if equality.Semantic.DeepEqual(vmi.Spec.Volumes, vmCopy.Spec.Template.Spec.Volumes) {
+	// Backup volumes are only added to the vmi, for the duration of a backup
+	vmiVolumes := []virtv1.Volume{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Backup == nil {
+			vmiVolumes = append(vmiVolumes, volume)
+		}
+	}
+	if equality.Semantic.DeepEqual(vmiVolumes, vmCopy.Spec.Template.Spec.Volumes) {
 		return nil
 	}
 	vmConditions := controller.NewVirtualMachineConditionManager()
@@ -1845,6 +1854,8 @@ func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.Virtual
 	vmi.ObjectMeta.GenerateName = ""
 	vmi.ObjectMeta.Namespace = vm.ObjectMeta.Namespace
 	vmi.Spec = *vm.Spec.Template.Spec.DeepCopy()
+	// Pin the hypervisor of VMs which don't set one, so that the VMI isn't defaulted to the namespace's hypervisor
+	vmi.Spec.Hypervisor = hypervisor.NameOrDefault(vmi.Spec.Hypervisor)
 
 	if hasStartPausedRequest(vm) {
 		strategy := virtv1.StartStrategyPaused
@@ -3053,6 +3064,14 @@ func (c *VMController) addRestartRequiredIfNeeded(lastSeenVMSpec *virtv1.Virtual
 		lastSeenVM.Spec.Template.Spec.Affinity = currentVM.Spec.Template.Spec.Affinity
 	}
 
+	// A hypervisor switch is applied by restarting the VM, e.g. after virtctl convert-hypervisor
+	if hypervisor.NameOrDefault(lastSeenVM.Spec.Template.Spec.Hypervisor) != hypervisor.NameOrDefault(currentVM.Spec.Template.Spec.Hypervisor) {
+		setRestartRequired(vm, fmt.Sprintf("the hypervisor was changed from %q to %q", lastSeenVM.Spec.Template.Spec.Hypervisor, currentVM.Spec.Template.Spec.Hypervisor))
+		return true
+	}
+
+	lastSeenVM.Spec.Template.Spec.Hypervisor = currentVM.Spec.Template.Spec.Hypervisor
+
 	if !equality.Semantic.DeepEqual(lastSeenVM.Spec.Template.Spec, currentVM.Spec.Template.Spec) {
 		setRestartRequired(vm, "a non-live-updatable field was changed in the template spec")
 		return true
@@ -2583,6 +2583,17 @@ var _ = Describe("VirtualMachine", func() {
 			Expect(string(vmi1.Spec.Domain.Firmware.UUID)).To(Equal(uid))
 		})
 
+		DescribeTable("should pin the hypervisor of the VirtualMachineInstance", func(hypervisorName, expected string) {
+			vm, _ := DefaultVirtualMachine(true)
+			vm.Spec.Template.Spec.Hypervisor = hypervisorName
+
+			vmi := controller.setupVMIFromVM(vm)
+			Expect(vmi.Spec.Hypervisor).To(Equal(expected))
+		},
+			Entry("to qemu if the VirtualMachine doesn't set one", "", "qemu"),
+			Entry("to the hypervisor of the VirtualMachine", "ch", "ch"),
+		)
+
 		It("should delete VirtualMachineInstance when stopped", func() {
 			vm, vmi := DefaultVirtualMachine(false)
 
@@ -6622,6 +6633,58 @@ var _ = Describe("VirtualMachine", func() {
 				Expect(vm.Status.Conditions).To(restartRequiredMatcher(k8sv1.ConditionTrue), "restart required")
 			})
 
+			It("should appear with the hypervisors when changing the hypervisor", func() {
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kv)
+
+				By("Creating a VMI with the hypervisor 'qemu'")
+				vm.Spec.Template.Spec.Hypervisor = "qemu"
+				vmi = controller.setupVMIFromVM(vm)
+				controller.vmiIndexer.Add(vmi)
+
+				By("Creating a Controller Revision with the hypervisor 'qemu'")
+				controller.crIndexer.Add(createVMRevision(vm))
+
+				By("Changing the hypervisor to 'ch'")
+				vm.Spec.Template.Spec.Hypervisor = "ch"
+				vm, err := virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+				addVirtualMachine(vm)
+
+				By("Executing the controller expecting the RestartRequired condition to name the hypervisors")
+				sanityExecute(vm)
+				vm, err = virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(vm.Status.Conditions).To(ContainElement(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Type":    Equal(v1.VirtualMachineRestartRequired),
+					"Status":  Equal(k8sv1.ConditionTrue),
+					"Message": Equal(`the hypervisor was changed from "qemu" to "ch"`),
+				})))
+			})
+
+			It("should not appear when an unset hypervisor is set to qemu", func() {
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kv)
+
+				By("Creating a VMI without a hypervisor")
+				vm.Spec.Template.Spec.Hypervisor = ""
+				vmi = controller.setupVMIFromVM(vm)
+				controller.vmiIndexer.Add(vmi)
+
+				By("Creating a Controller Revision without a hypervisor")
+				controller.crIndexer.Add(createVMRevision(vm))
+
+				By("Setting the hypervisor to 'qemu'")
+				vm.Spec.Template.Spec.Hypervisor = "qemu"
+				vm, err := virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+				addVirtualMachine(vm)
+
+				By("Executing the controller expecting no RestartRequired condition")
+				sanityExecute(vm)
+				vm, err = virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(vm.Status.Conditions).ToNot(restartRequiredMatcher(k8sv1.ConditionTrue))
+			})
+
 			It("should appear when VM doesn't specify maxSockets and sockets go above cluster-wide maxSockets", func() {
 				var maxSockets uint32 = 8
 
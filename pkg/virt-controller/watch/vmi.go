@@ -55,6 +55,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	netadmitter "kubevirt.io/kubevirt/pkg/network/admitter"
 	"kubevirt.io/kubevirt/pkg/network/multus"
 	"kubevirt.io/kubevirt/pkg/network/namescheme"
@@ -625,8 +626,8 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 					vmiCopy.Status.MigrationTransport = virtv1.MigrationTransportUnix
 				}
 
-				// Allocate the CID if VSOCK is enabled.
-				if util.IsAutoAttachVSOCK(vmiCopy) {
+				// Allocate the CID if VSOCK is enabled or the guest agent is reached over vsock.
+				if util.IsAutoAttachVSOCK(vmiCopy) || hasGuestAgentOverVSOCK(vmiCopy) {
 					if err := c.cidsMap.Allocate(vmiCopy); err != nil {
 						return err
 					}
@@ -2019,6 +2020,9 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 					ClaimName: volume.Name,
 				}
 			}
+			if volume.Backup != nil && status.BackupVolume == nil {
+				status.BackupVolume = &virtv1.DomainBackupInfo{}
+			}
 			if attachmentPod == nil {
 				if !c.volumeReady(status.Phase) {
 					status.HotplugVolume.AttachPodUID = ""
@@ -2045,7 +2049,7 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 			}
 		}
 
-		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil || volume.VolumeSource.MemoryDump != nil {
+		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil || volume.VolumeSource.MemoryDump != nil || volume.VolumeSource.Backup != nil {
 
 			pvcName := storagetypes.PVCNameFromVirtVolume(&volume)
 
@@ -2337,3 +2341,10 @@ func statusOfReadyCondition(conditions []cdiv1.DataVolumeCondition) k8sv1.Condit
 	}
 	return k8sv1.ConditionUnknown
 }
+
+// hasGuestAgentOverVSOCK returns true if the hypervisor of the VMI does not provide a guest agent channel,
+// its hybrid vsock device is served by the VMM and does not need the VSOCK feature gate or /dev/vhost-vsock
+func hasGuestAgentOverVSOCK(vmi *virtv1.VirtualMachineInstance) bool {
+	capabilities, exists := hypervisor.GetCapabilities(vmi.Spec.Hypervisor)
+	return exists && !capabilities.GuestAgentChannel
+}
@@ -3335,11 +3335,12 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 	})
 
 	Context("auto attach VSOCK", func() {
-		It("should allocate CID when VirtualMachineInstance is scheduled", func() {
+		DescribeTable("should allocate CID when VirtualMachineInstance is scheduled", func(hypervisor string, autoattachVSOCK *bool, expectCID bool) {
 			vmi := NewPendingVirtualMachine("testvmi")
 			setReadyCondition(vmi, k8sv1.ConditionFalse, virtv1.GuestNotRunningReason)
 			vmi.Status.Phase = virtv1.Scheduling
-			vmi.Spec.Domain.Devices.AutoattachVSOCK = pointer.P(true)
+			vmi.Spec.Hypervisor = hypervisor
+			vmi.Spec.Domain.Devices.AutoattachVSOCK = autoattachVSOCK
 			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
 
 			addVirtualMachine(vmi)
@@ -3349,8 +3350,16 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			expectVMIScheduledState(vmi)
 			updatedVmi, err := virtClientset.KubevirtV1().VirtualMachineInstances(vmi.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
 			Expect(err).ToNot(HaveOccurred())
-			Expect(updatedVmi.Status.VSOCKCID).NotTo(BeNil())
-		})
+			if expectCID {
+				Expect(updatedVmi.Status.VSOCKCID).NotTo(BeNil())
+			} else {
+				Expect(updatedVmi.Status.VSOCKCID).To(BeNil())
+			}
+		},
+			Entry("with VSOCK enabled", "qemu", pointer.P(true), true),
+			Entry("not without VSOCK", "qemu", nil, false),
+			Entry("for the guest agent of hypervisors without an agent channel", "ch", nil, true),
+		)
 
 		It("should recycle the CID when the pods are deleted", func() {
 			alc := &fakeAllocator{}
@@ -556,9 +556,12 @@ func (c *WorkloadUpdateController) sync(kv *virtv1.KubeVirt) error {
 	for _, vmi := range migrationCandidates {
 		go func(vmi *virtv1.VirtualMachineInstance) {
 			var labels map[string]string
+			// Volume updates are requested by users, launcher updates are not
+			priority := virtv1.MigrationPriorityWorkloadUpdate
 			if isVolumesUpdateInProgress(vmi) {
 				labels = make(map[string]string)
 				labels[virtv1.VolumesUpdateMigration] = vmi.Name
+				priority = virtv1.MigrationPriorityUser
 			}
 			defer wg.Done()
 			createdMigration, err := c.clientset.VirtualMachineInstanceMigration(vmi.Namespace).Create(context.Background(), &virtv1.VirtualMachineInstanceMigration{
@@ -570,7 +573,8 @@ func (c *WorkloadUpdateController) sync(kv *virtv1.KubeVirt) error {
 					GenerateName: "kubevirt-workload-update-",
 				},
 				Spec: virtv1.VirtualMachineInstanceMigrationSpec{
-					VMIName: vmi.Name,
+					VMIName:  vmi.Name,
+					Priority: priority,
 				},
 			}, metav1.CreateOptions{})
 			if err != nil {
@@ -126,6 +126,7 @@ var _ = Describe("Workload Updater", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(migrations.Items).To(HaveLen(1))
 			Expect(migrations.Items[0].Spec.VMIName).To(Equal("testvm"))
+			Expect(migrations.Items[0].Spec.Priority).To(Equal(v1.MigrationPriorityWorkloadUpdate))
 		})
 
 		It("should do nothing if deployment is updating", func() {
@@ -105,7 +105,8 @@ type LauncherClient interface {
 	Ping() error
 	GuestPing(string, int32) error
 	Close()
-	VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error
+	VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string, dumpType v1.MemoryDumpType) error
+	VirtualMachineBackup(vmi *v1.VirtualMachineInstance, backupPath string, volume *v1.BackupVolumeSource) error
 	GetQemuVersion() (string, error)
 	SyncVirtualMachineCPUs(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
 	GetSEVInfo() (*v1.SEVPlatformInfo, error)
@@ -411,7 +412,7 @@ func (c *VirtLauncherClient) UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstan
 	return c.genericSendVMICmd("Unfreeze", c.v1client.UnfreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
 
-func (c *VirtLauncherClient) VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error {
+func (c *VirtLauncherClient) VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string, dumpType v1.MemoryDumpType) error {
 	vmiJson, err := json.Marshal(vmi)
 	if err != nil {
 		return err
@@ -422,6 +423,7 @@ func (c *VirtLauncherClient) VirtualMachineMemoryDump(vmi *v1.VirtualMachineInst
 			VmiJson: vmiJson,
 		},
 		DumpPath: dumpPath,
+		Type:     string(dumpType),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
@@ -431,6 +433,31 @@ func (c *VirtLauncherClient) VirtualMachineMemoryDump(vmi *v1.VirtualMachineInst
 	return err
 }
 
+func (c *VirtLauncherClient) VirtualMachineBackup(vmi *v1.VirtualMachineInstance, backupPath string, volume *v1.BackupVolumeSource) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	optionsJson, err := json.Marshal(volume)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.BackupRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		BackupPath: backupPath,
+		Options:    optionsJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+	response, err := c.v1client.VirtualMachineBackup(ctx, request)
+	return handleError(err, "VirtualMachineBackup", response)
+}
+
 func (c *VirtLauncherClient) SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance) error {
 	return c.genericSendVMICmd("SoftReboot", c.v1client.SoftRebootVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
@@ -51,12 +51,12 @@ func (_mr *_MockPodIsolationDetectorRecorder) DetectForSocket(arg0, arg1 interfa
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DetectForSocket", arg0, arg1)
 }
 
-func (_m *MockPodIsolationDetector) AdjustResources(vm *v1.VirtualMachineInstance, additionalOverheadRatio *string) error {
-	ret := _m.ctrl.Call(_m, "AdjustResources", vm, additionalOverheadRatio)
+func (_m *MockPodIsolationDetector) AdjustResources(vm *v1.VirtualMachineInstance, additionalOverheadRatio *string, hypervisorOverhead *v1.HypervisorOverhead) error {
+	ret := _m.ctrl.Call(_m, "AdjustResources", vm, additionalOverheadRatio, hypervisorOverhead)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockPodIsolationDetectorRecorder) AdjustResources(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "AdjustResources", arg0, arg1)
+func (_mr *_MockPodIsolationDetectorRecorder) AdjustResources(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AdjustResources", arg0, arg1, arg2)
 }
@@ -20,11 +20,34 @@
 package isolation
 
 import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mitchellh/go-ps"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/hypervisor"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+// Names of the processes of a virt-launcher pod, as reported by LauncherProcessMeter
+const (
+	VirtLauncherMonitorProcess = "virt-launcher-monitor"
+	VirtLauncherProcess        = "virt-launcher"
+	VirtlogdProcess            = "virtlogd"
+	HypervisorDaemonProcess    = "hypervisor-daemon"
+	VMMProcess                 = "vmm"
 )
 
+const procPath = "/proc"
+
 // childProcesses given a list of processes, it returns the ones that are children
 // of the given PID.
 func childProcesses(processes []ps.Process, pid int) []ps.Process {
@@ -52,3 +75,119 @@ func lookupProcessByExecutablePrefix(processes []ps.Process, execPrefix string)
 
 	return nil
 }
+
+// launcherProcesses given a list of processes, it returns the pids of the processes of the
+// virt-launcher pod whose virt-launcher and virt-launcher-monitor have the given PIDs, keyed by process name.
+func launcherProcesses(processes []ps.Process, launcherPid, monitorPid int) map[string]int {
+	pids := map[string]int{
+		VirtLauncherMonitorProcess: monitorPid,
+		VirtLauncherProcess:        launcherPid,
+	}
+
+	// virtlogd and the hypervisor daemon are started by virt-launcher
+	daemonExecutables := hypervisor.DaemonExecutables()
+	for _, process := range childProcesses(processes, launcherPid) {
+		switch {
+		case process.Executable() == VirtlogdProcess:
+			pids[VirtlogdProcess] = process.Pid()
+		case slices.Contains(daemonExecutables, process.Executable()):
+			pids[HypervisorDaemonProcess] = process.Pid()
+		}
+	}
+
+	// the VMM is daemonized and reparented to virt-launcher-monitor
+	if vmm, err := findIsolatedQemuProcess(processes, monitorPid); err == nil {
+		pids[VMMProcess] = vmm.Pid()
+	}
+
+	return pids
+}
+
+// launcherProcessesTTL is how long the processes of a VMI which is no longer measured are remembered
+const launcherProcessesTTL = 10 * time.Minute
+
+type launcherProcessesEntry struct {
+	pids     map[string]int
+	lastUsed time.Time
+}
+
+// LauncherProcessMeter measures the resident set size of the processes of virt-launcher pods.
+// The processes of a VMI are resolved once and cached, so that measuring them only reads their status.
+type LauncherProcessMeter struct {
+	detector        PodIsolationDetector
+	listProcesses   func() ([]ps.Process, error)
+	residentSetSize func(pid int) (uint64, error)
+
+	lock      sync.Mutex
+	processes map[types.UID]*launcherProcessesEntry
+}
+
+func NewLauncherProcessMeter(detector PodIsolationDetector) *LauncherProcessMeter {
+	return &LauncherProcessMeter{
+		detector:        detector,
+		listProcesses:   ps.Processes,
+		residentSetSize: processResidentSetSize,
+		processes:       map[types.UID]*launcherProcessesEntry{},
+	}
+}
+
+// ResidentSetSize returns the resident set size in bytes of the processes of the virt-launcher pod
+// serving the given socket, keyed by process name. Processes which are not running are omitted.
+func (m *LauncherProcessMeter) ResidentSetSize(vmi *v1.VirtualMachineInstance, socketFile string) (map[string]uint64, error) {
+	pids, err := m.launcherPids(vmi, socketFile)
+	if err != nil {
+		return nil, err
+	}
+
+	rss := map[string]uint64{}
+	for name, pid := range pids {
+		processRSS, err := m.residentSetSize(pid)
+		if err != nil {
+			// a process exited or was restarted, resolve the processes again on the next call
+			m.forget(vmi.UID)
+			return nil, err
+		}
+		rss[name] = processRSS
+	}
+	return rss, nil
+}
+
+func (m *LauncherProcessMeter) launcherPids(vmi *v1.VirtualMachineInstance, socketFile string) (map[string]int, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	for uid, entry := range m.processes {
+		if now.Sub(entry.lastUsed) > launcherProcessesTTL {
+			delete(m.processes, uid)
+		}
+	}
+
+	// the VMM is started after the other processes, keep looking for it until it is found
+	if entry, exists := m.processes[vmi.UID]; exists && entry.pids[VMMProcess] != 0 {
+		entry.lastUsed = now
+		return entry.pids, nil
+	}
+
+	res, err := m.detector.DetectForSocket(vmi, socketFile)
+	if err != nil {
+		return nil, err
+	}
+	processes, err := m.listProcesses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all processes: %v", err)
+	}
+	pids := launcherProcesses(processes, res.Pid(), res.PPid())
+	m.processes[vmi.UID] = &launcherProcessesEntry{pids: pids, lastUsed: now}
+	return pids, nil
+}
+
+func (m *LauncherProcessMeter) forget(uid types.UID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.processes, uid)
+}
+
+func processResidentSetSize(pid int) (uint64, error) {
+	return util.ResidentSetSize(filepath.Join(procPath, strconv.Itoa(pid), "status"))
+}
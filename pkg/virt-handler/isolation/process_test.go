@@ -20,7 +20,16 @@
 package isolation
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/mock/gomock"
 	"github.com/mitchellh/go-ps"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/util"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -88,6 +97,134 @@ var _ = Describe("process", func() {
 			),
 		)
 	})
+	Context("virt-launcher processes", func() {
+		const (
+			monitorPid  = 1
+			launcherPid = 10
+		)
+
+		It("should find the processes of the pod", func() {
+			processes := []ps.Process{
+				ProcessStub{ppid: 0, pid: monitorPid, binary: "virt-launcher-monitor"},
+				ProcessStub{ppid: monitorPid, pid: launcherPid, binary: "virt-launcher"},
+				ProcessStub{ppid: launcherPid, pid: 11, binary: "virtlogd"},
+				ProcessStub{ppid: launcherPid, pid: 12, binary: "virtqemud"},
+				ProcessStub{ppid: monitorPid, pid: 13, binary: "qemu-kvm"},
+				ProcessStub{ppid: 999, pid: 14, binary: "virtlogd"},
+			}
+			Expect(launcherProcesses(processes, launcherPid, monitorPid)).To(Equal(map[string]int{
+				VirtLauncherMonitorProcess: monitorPid,
+				VirtLauncherProcess:        launcherPid,
+				VirtlogdProcess:            11,
+				HypervisorDaemonProcess:    12,
+				VMMProcess:                 13,
+			}))
+		})
+
+		It("should omit processes which are not running", func() {
+			processes := []ps.Process{
+				ProcessStub{ppid: monitorPid, pid: launcherPid, binary: "virt-launcher"},
+				ProcessStub{ppid: launcherPid, pid: 11, binary: "virtchd"},
+			}
+			Expect(launcherProcesses(processes, launcherPid, monitorPid)).To(Equal(map[string]int{
+				VirtLauncherMonitorProcess: monitorPid,
+				VirtLauncherProcess:        launcherPid,
+				HypervisorDaemonProcess:    11,
+			}))
+		})
+
+		DescribeTable("should parse the resident set size", func(status string, expected uint64) {
+			Expect(util.ParseResidentSetSize(strings.NewReader(status))).To(Equal(expected))
+		},
+			Entry("of a process", "Name:\tvirtlogd\nVmHWM:\t   20480 kB\nVmRSS:\t   18432 kB\nRssAnon:\t    2048 kB\n", uint64(18432*1024)),
+			Entry("of a process without memory", "Name:\tkthreadd\nState:\tS (sleeping)\n", uint64(0)),
+		)
+	})
+
+	Context("launcher process meter", func() {
+		const (
+			monitorPid  = 1
+			launcherPid = 10
+			vmmPid      = 13
+			socketFile  = "/var/run/kubevirt/sockets/launcher-sock"
+		)
+
+		var (
+			detector    *MockPodIsolationDetector
+			vmi         *v1.VirtualMachineInstance
+			meter       *LauncherProcessMeter
+			processes   []ps.Process
+			listed      int
+			exitedPids  map[int]bool
+			launcherPod []ps.Process
+		)
+
+		BeforeEach(func() {
+			ctrl := gomock.NewController(GinkgoT())
+			detector = NewMockPodIsolationDetector(ctrl)
+			res := NewMockIsolationResult(ctrl)
+			res.EXPECT().Pid().Return(launcherPid).AnyTimes()
+			res.EXPECT().PPid().Return(monitorPid).AnyTimes()
+			detector.EXPECT().DetectForSocket(gomock.Any(), socketFile).Return(res, nil).AnyTimes()
+
+			vmi = &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{UID: "uid"}}
+			launcherPod = []ps.Process{
+				ProcessStub{ppid: 0, pid: monitorPid, binary: "virt-launcher-monitor"},
+				ProcessStub{ppid: monitorPid, pid: launcherPid, binary: "virt-launcher"},
+				ProcessStub{ppid: launcherPid, pid: 11, binary: "virtlogd"},
+			}
+			processes = append(launcherPod, ProcessStub{ppid: monitorPid, pid: vmmPid, binary: "cloud-hypervisor"})
+			listed = 0
+			exitedPids = map[int]bool{}
+
+			meter = NewLauncherProcessMeter(detector)
+			meter.listProcesses = func() ([]ps.Process, error) {
+				listed++
+				return processes, nil
+			}
+			meter.residentSetSize = func(pid int) (uint64, error) {
+				if exitedPids[pid] {
+					return 0, fmt.Errorf("process %d exited", pid)
+				}
+				return uint64(pid * 1024), nil
+			}
+		})
+
+		It("should resolve the processes of a VMI once", func() {
+			for i := 0; i < 3; i++ {
+				Expect(meter.ResidentSetSize(vmi, socketFile)).To(Equal(map[string]uint64{
+					VirtLauncherMonitorProcess: monitorPid * 1024,
+					VirtLauncherProcess:        launcherPid * 1024,
+					VirtlogdProcess:            11 * 1024,
+					VMMProcess:                 vmmPid * 1024,
+				}))
+			}
+			Expect(listed).To(Equal(1))
+		})
+
+		It("should resolve the processes again until the VMM is running", func() {
+			processes = launcherPod
+			Expect(meter.ResidentSetSize(vmi, socketFile)).ToNot(HaveKey(VMMProcess))
+			processes = append(launcherPod, ProcessStub{ppid: monitorPid, pid: vmmPid, binary: "cloud-hypervisor"})
+			Expect(meter.ResidentSetSize(vmi, socketFile)).To(HaveKey(VMMProcess))
+			Expect(meter.ResidentSetSize(vmi, socketFile)).To(HaveKey(VMMProcess))
+			Expect(listed).To(Equal(2))
+		})
+
+		It("should resolve the processes again after one of them exited", func() {
+			_, err := meter.ResidentSetSize(vmi, socketFile)
+			Expect(err).ToNot(HaveOccurred())
+
+			exitedPids[vmmPid] = true
+			_, err = meter.ResidentSetSize(vmi, socketFile)
+			Expect(err).To(HaveOccurred())
+
+			delete(exitedPids, vmmPid)
+			_, err = meter.ResidentSetSize(vmi, socketFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(listed).To(Equal(2))
+		})
+	})
 })
 
 type ProcessStub struct {
@@ -0,0 +1,70 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package nodelabeller
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"kubevirt.io/kubevirt/pkg/hypervisor"
+)
+
+// hypervisorsFile is written by node-labeller.sh and lists one available hypervisor per line
+// in the format "<name> <version>", e.g. "ch 41.0"
+const hypervisorsFile = "hypervisors"
+
+// loadHypervisors reads the hypervisors which are usable on the node, i.e. whose device,
+// daemon and VMM binary were found, together with their version
+func (n *NodeLabeller) loadHypervisors() error {
+	n.hypervisors = make(map[string]string)
+
+	f, err := os.Open(filepath.Join(n.volumePath, hypervisorsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		n.logger.Warning("node-labeller could not find the available hypervisors, no hypervisor labels will be set")
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		name := fields[0]
+		if _, exists := hypervisor.Lookup(name); !exists {
+			n.logger.Warningf("node-labeller ignores unknown hypervisor %s", name)
+			continue
+		}
+		version := ""
+		if len(fields) > 1 && len(validation.IsValidLabelValue(fields[1])) == 0 {
+			version = fields[1]
+		}
+		n.hypervisors[name] = version
+	}
+	return scanner.Err()
+}
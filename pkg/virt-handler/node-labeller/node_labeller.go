@@ -57,6 +57,7 @@ var nodeLabellerLabels = []string{
 	kubevirtv1.HostModelCPULabel,
 	kubevirtv1.HostModelRequiredFeaturesLabel,
 	kubevirtv1.NodeHostModelIsObsoleteLabel,
+	kubevirtv1.HypervisorLabel,
 }
 
 // NodeLabeller struct holds information needed to run node-labeller
@@ -76,6 +77,7 @@ type NodeLabeller struct {
 	capabilities            *api.Capabilities
 	hostCPUModel            hostCPUModel
 	SEV                     SEVConfiguration
+	hypervisors             map[string]string
 	arch                    string
 }
 
@@ -169,6 +171,12 @@ func (n *NodeLabeller) loadAll() error {
 		return err
 	}
 
+	err = n.loadHypervisors()
+	if err != nil {
+		n.logger.Errorf("node-labeller could not load available hypervisors: " + err.Error())
+		return err
+	}
+
 	n.loadHypervFeatures()
 
 	return nil
@@ -286,6 +294,10 @@ func (n *NodeLabeller) prepareLabels(node *v1.Node, cpuModels []string, cpuFeatu
 		newLabels[kubevirtv1.SEVESLabel] = ""
 	}
 
+	for name, version := range n.hypervisors {
+		newLabels[kubevirtv1.HypervisorLabel+name] = version
+	}
+
 	return newLabels
 }
 
@@ -131,6 +131,28 @@ var _ = Describe("Node-labeller ", func() {
 		Expect(node.Labels).To(HaveKey(v1.SEVLabel))
 	})
 
+	It("should add hypervisor labels with versions", func() {
+		res := nlController.execute()
+		Expect(res).To(BeTrue())
+
+		node := retrieveNode(kubeClient)
+		Expect(node.Labels).To(HaveKeyWithValue(v1.HypervisorLabel+"qemu", "8.2.0"))
+		Expect(node.Labels).To(HaveKeyWithValue(v1.HypervisorLabel+"ch", "41.0"))
+		Expect(node.Labels).To(HaveKeyWithValue(v1.HypervisorLabel+"ch-kvm", "41.0"))
+	})
+
+	It("should remove hypervisor labels of hypervisors which are no longer available", func() {
+		Expect(nlController.run()).To(Succeed())
+		Expect(retrieveNode(kubeClient).Labels).To(HaveKey(v1.HypervisorLabel + "ch"))
+
+		delete(nlController.hypervisors, "ch")
+		Expect(nlController.run()).To(Succeed())
+
+		node := retrieveNode(kubeClient)
+		Expect(node.Labels).To(HaveKey(v1.HypervisorLabel + "qemu"))
+		Expect(node.Labels).ToNot(HaveKey(v1.HypervisorLabel + "ch"))
+	})
+
 	It("should add SEVES label", func() {
 		res := nlController.execute()
 		Expect(res).To(BeTrue())
@@ -38,6 +38,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/hypervisor"
 	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
+	"kubevirt.io/kubevirt/pkg/storage/backup"
 
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	pvctypes "kubevirt.io/kubevirt/pkg/storage/types"
@@ -795,7 +796,7 @@ func (d *VirtualMachineController) migrationTargetUpdateVMIStatus(vmi *v1.Virtua
 
 		// adjust QEMU process memlock limits in order to enable old virt-launcher pod's to
 		// perform hotplug host-devices on post migration.
-		if err := isolation.AdjustQemuProcessMemoryLimits(d.podIsolationDetector, vmi, d.clusterConfig.GetConfig().AdditionalGuestMemoryOverheadRatio); err != nil {
+		if err := isolation.AdjustQemuProcessMemoryLimits(d.podIsolationDetector, vmi, d.clusterConfig.GetConfig().AdditionalGuestMemoryOverheadRatio, d.clusterConfig.GetHypervisorOverhead(vmi.Spec.Hypervisor)); err != nil {
 			d.recorder.Event(vmi, k8sv1.EventTypeWarning, err.Error(), "Failed to update target node qemu memory limits during live migration")
 		}
 
@@ -1053,6 +1054,10 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 				volumeStatus, tmpNeedsRefresh = d.updateMemoryDumpInfo(vmi, volumeStatus, domain)
 				needsRefresh = needsRefresh || tmpNeedsRefresh
 			}
+			if volumeStatus.BackupVolume != nil {
+				volumeStatus, tmpNeedsRefresh = d.updateBackupInfo(vmi, volumeStatus, domain)
+				needsRefresh = needsRefresh || tmpNeedsRefresh
+			}
 			newStatuses = append(newStatuses, volumeStatus)
 			newStatusMap[volumeStatus.Name] = volumeStatus
 		}
@@ -1245,6 +1250,32 @@ func dumpTargetFile(vmiName, volName string) string {
 	return targetFileName
 }
 
+func stateTargetFile(vmiName, volName string) string {
+	return fmt.Sprintf("%s-%s-%s%s", vmiName, volName, time.Now().Format("20060102-150405"), api.MemoryStateFileExtension)
+}
+
+func backupTargetDir(vmiName, volName string) string {
+	return fmt.Sprintf("%s-%s-%s%s", vmiName, volName, time.Now().Format("20060102-150405"), backup.DirectoryExtension)
+}
+
+func memoryDumpType(vmi *v1.VirtualMachineInstance, volName string) v1.MemoryDumpType {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == volName && volume.MemoryDump != nil {
+			return volume.MemoryDump.Type
+		}
+	}
+	return v1.MemoryDumpTypeCore
+}
+
+func backupVolumeSource(vmi *v1.VirtualMachineInstance, volName string) *v1.BackupVolumeSource {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == volName && volume.Backup != nil {
+			return volume.Backup
+		}
+	}
+	return nil
+}
+
 func (d *VirtualMachineController) updateMemoryDumpInfo(vmi *v1.VirtualMachineInstance, volumeStatus v1.VolumeStatus, domain *api.Domain) (v1.VolumeStatus, bool) {
 	needsRefresh := false
 	switch volumeStatus.Phase {
@@ -1254,7 +1285,12 @@ func (d *VirtualMachineController) updateMemoryDumpInfo(vmi *v1.VirtualMachineIn
 		volumeStatus.Phase = v1.MemoryDumpVolumeInProgress
 		volumeStatus.Message = fmt.Sprintf("Memory dump Volume %s is attached, getting memory dump", volumeStatus.Name)
 		volumeStatus.Reason = VolumeMountedToPodReason
-		volumeStatus.MemoryDumpVolume.TargetFileName = dumpTargetFile(vmi.Name, volumeStatus.Name)
+		switch memoryDumpType(vmi, volumeStatus.Name) {
+		case v1.MemoryDumpTypeState:
+			volumeStatus.MemoryDumpVolume.TargetFileName = stateTargetFile(vmi.Name, volumeStatus.Name)
+		default:
+			volumeStatus.MemoryDumpVolume.TargetFileName = dumpTargetFile(vmi.Name, volumeStatus.Name)
+		}
 	case v1.MemoryDumpVolumeInProgress:
 		memoryDumpMetadata := domain.Spec.Metadata.KubeVirt.MemoryDump
 		if memoryDumpMetadata == nil || memoryDumpMetadata.FileName != volumeStatus.MemoryDumpVolume.TargetFileName {
@@ -1282,6 +1318,43 @@ func (d *VirtualMachineController) updateMemoryDumpInfo(vmi *v1.VirtualMachineIn
 	return volumeStatus, needsRefresh
 }
 
+func (d *VirtualMachineController) updateBackupInfo(vmi *v1.VirtualMachineInstance, volumeStatus v1.VolumeStatus, domain *api.Domain) (v1.VolumeStatus, bool) {
+	needsRefresh := false
+	switch volumeStatus.Phase {
+	case v1.HotplugVolumeMounted:
+		needsRefresh = true
+		log.Log.Object(vmi).V(3).Infof("Backup volume %s attached, marking it in progress", volumeStatus.Name)
+		volumeStatus.Phase = v1.BackupVolumeInProgress
+		volumeStatus.Message = fmt.Sprintf("Backup Volume %s is attached, backing up", volumeStatus.Name)
+		volumeStatus.Reason = VolumeMountedToPodReason
+		volumeStatus.BackupVolume.TargetDirectory = backupTargetDir(vmi.Name, volumeStatus.Name)
+	case v1.BackupVolumeInProgress:
+		backupMetadata := domain.Spec.Metadata.KubeVirt.Backup
+		if backupMetadata == nil || backupMetadata.Directory != volumeStatus.BackupVolume.TargetDirectory {
+			// backup wasnt triggered yet
+			return volumeStatus, needsRefresh
+		}
+		needsRefresh = true
+		if backupMetadata.StartTimestamp != nil {
+			volumeStatus.BackupVolume.StartTimestamp = backupMetadata.StartTimestamp
+		}
+		if backupMetadata.EndTimestamp != nil && backupMetadata.Failed {
+			log.Log.Object(vmi).Errorf("Backup to pvc %s failed: %v", volumeStatus.Name, backupMetadata.FailureReason)
+			volumeStatus.Message = fmt.Sprintf("Backup to pvc %s failed: %v", volumeStatus.Name, backupMetadata.FailureReason)
+			volumeStatus.Phase = v1.BackupVolumeFailed
+			volumeStatus.BackupVolume.EndTimestamp = backupMetadata.EndTimestamp
+		} else if backupMetadata.Completed {
+			log.Log.Object(vmi).V(3).Infof("Marking backup to volume %s has completed", volumeStatus.Name)
+			volumeStatus.Phase = v1.BackupVolumeCompleted
+			volumeStatus.Message = fmt.Sprintf("Backup to Volume %s has completed successfully", volumeStatus.Name)
+			volumeStatus.Reason = VolumeReadyReason
+			volumeStatus.BackupVolume.EndTimestamp = backupMetadata.EndTimestamp
+		}
+	}
+
+	return volumeStatus, needsRefresh
+}
+
 func (d *VirtualMachineController) updateFSFreezeStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
 
 	if domain == nil || domain.Status.FSFreezeStatus.Status == "" {
@@ -1660,6 +1733,15 @@ func (d *VirtualMachineController) calculateLiveMigrationCondition(vmi *v1.Virtu
 		return newNonMigratableCondition("VMI uses hyperv passthrough", v1.VirtualMachineInstanceReasonHypervPassthroughNotMigratable), isBlockMigration
 	}
 
+	if capabilities, exists := hypervisor.GetCapabilities(vmi.Spec.Hypervisor); exists {
+		if !capabilities.LiveMigration {
+			return newNonMigratableCondition(fmt.Sprintf("hypervisor %s does not support live migration", vmi.Spec.Hypervisor), v1.VirtualMachineInstanceReasonHypervisorNotMigratable), isBlockMigration
+		}
+		if isBlockMigration && !capabilities.BlockMigration {
+			return newNonMigratableCondition(fmt.Sprintf("hypervisor %s does not support live migration of local disks", vmi.Spec.Hypervisor), v1.VirtualMachineInstanceReasonHypervisorNotMigratable), isBlockMigration
+		}
+	}
+
 	return &v1.VirtualMachineInstanceCondition{
 		Type:   v1.VirtualMachineInstanceIsMigratable,
 		Status: k8sv1.ConditionTrue,
@@ -3197,7 +3279,7 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 		}
 
 		// set runtime limits as needed
-		err = d.podIsolationDetector.AdjustResources(vmi, d.clusterConfig.GetConfig().AdditionalGuestMemoryOverheadRatio)
+		err = d.podIsolationDetector.AdjustResources(vmi, d.clusterConfig.GetConfig().AdditionalGuestMemoryOverheadRatio, d.clusterConfig.GetHypervisorOverhead(vmi.Spec.Hypervisor))
 		if err != nil {
 			return fmt.Errorf("failed to adjust resources: %v", err)
 		}
@@ -3222,6 +3304,10 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 			return err
 		}
 
+		if err := d.getBackup(vmi); err != nil {
+			return err
+		}
+
 		isolationRes, err := d.podIsolationDetector.Detect(vmi)
 		if err != nil {
 			return fmt.Errorf(failedDetectIsolationFmt, err)
@@ -3332,7 +3418,7 @@ func (d *VirtualMachineController) hotplugSriovInterfacesCommand(vmi *v1.Virtual
 		return fmt.Errorf("%s: %v", errMsgPrefix, err)
 	}
 
-	if err := isolation.AdjustQemuProcessMemoryLimits(d.podIsolationDetector, vmi, d.clusterConfig.GetConfig().AdditionalGuestMemoryOverheadRatio); err != nil {
+	if err := isolation.AdjustQemuProcessMemoryLimits(d.podIsolationDetector, vmi, d.clusterConfig.GetConfig().AdditionalGuestMemoryOverheadRatio, d.clusterConfig.GetHypervisorOverhead(vmi.Spec.Hypervisor)); err != nil {
 		d.recorder.Event(vmi, k8sv1.EventTypeWarning, err.Error(), err.Error())
 		return fmt.Errorf("%s: %v", errMsgPrefix, err)
 	}
@@ -3364,7 +3450,7 @@ func (d *VirtualMachineController) getMemoryDump(vmi *v1.VirtualMachineInstance)
 		}
 
 		log.Log.V(3).Object(vmi).Info("sending memory dump command")
-		err = client.VirtualMachineMemoryDump(vmi, memoryDumpPath(volumeStatus))
+		err = client.VirtualMachineMemoryDump(vmi, memoryDumpPath(volumeStatus), memoryDumpType(vmi, volumeStatus.Name))
 		if err != nil {
 			return fmt.Errorf("%s: %v", errMsgPrefix, err)
 		}
@@ -3373,6 +3459,36 @@ func (d *VirtualMachineController) getMemoryDump(vmi *v1.VirtualMachineInstance)
 	return nil
 }
 
+func backupPath(volumeStatus v1.VolumeStatus) string {
+	target := hotplugdisk.GetVolumeMountDir(volumeStatus.Name)
+	return filepath.Join(target, volumeStatus.BackupVolume.TargetDirectory)
+}
+
+func (d *VirtualMachineController) getBackup(vmi *v1.VirtualMachineInstance) error {
+	const errMsgPrefix = "failed to back up"
+
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.BackupVolume == nil || volumeStatus.Phase != v1.BackupVolumeInProgress {
+			continue
+		}
+		volume := backupVolumeSource(vmi, volumeStatus.Name)
+		if volume == nil {
+			continue
+		}
+		client, err := d.getVerifiedLauncherClient(vmi)
+		if err != nil {
+			return fmt.Errorf("%s: %v", errMsgPrefix, err)
+		}
+
+		log.Log.V(3).Object(vmi).Info("sending backup command")
+		if err := client.VirtualMachineBackup(vmi, backupPath(volumeStatus), volume); err != nil {
+			return fmt.Errorf("%s: %v", errMsgPrefix, err)
+		}
+	}
+
+	return nil
+}
+
 func (d *VirtualMachineController) processVmUpdate(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
 
 	isUnresponsive, isInitialized, err := d.isLauncherClientUnresponsive(vmi)
@@ -3732,7 +3848,7 @@ func (d *VirtualMachineController) hotplugMemory(vmi *v1.VirtualMachineInstance,
 	}
 
 	overheadRatio := vmi.Labels[v1.MemoryHotplugOverheadRatioLabel]
-	requiredMemory := services.GetMemoryOverhead(vmi, runtime.GOARCH, &overheadRatio)
+	requiredMemory := services.GetMemoryOverhead(vmi, runtime.GOARCH, &overheadRatio, d.clusterConfig.GetHypervisorOverhead(vmi.Spec.Hypervisor))
 	requiredMemory.Add(
 		d.netBindingPluginMemoryCalculator.Calculate(vmi, d.clusterConfig.GetNetworkBindings()),
 	)
@@ -189,7 +189,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 		mockIsolationDetector = isolation.NewMockPodIsolationDetector(ctrl)
 		mockIsolationDetector.EXPECT().Detect(gomock.Any()).Return(mockIsolationResult, nil).AnyTimes()
-		mockIsolationDetector.EXPECT().AdjustResources(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		mockIsolationDetector.EXPECT().AdjustResources(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 		mockContainerDiskMounter = containerdisk.NewMockMounter(ctrl)
 		mockHotplugVolumeMounter = hotplugvolume.NewMockVolumeMounter(ctrl)
@@ -1482,6 +1482,85 @@ var _ = Describe("VirtualMachineInstance", func() {
 				controller.updateVolumeStatusesFromDomain(vmi, domain)
 			})
 
+			It("Should mark a backup volume in progress and send the backup command once mounted", func() {
+				vmi := api2.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.Status.Phase = v1.Running
+				backupVolume := &v1.BackupVolumeSource{Checkpoint: "testvm-checkpoint"}
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "test",
+					VolumeSource: v1.VolumeSource{
+						Backup: backupVolume,
+					},
+				})
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+					Name:  "test",
+					Phase: v1.HotplugVolumeMounted,
+					HotplugVolume: &v1.HotplugVolumeStatus{
+						AttachPodName: "testpod",
+						AttachPodUID:  "1234",
+					},
+					BackupVolume: &v1.DomainBackupInfo{},
+				})
+				domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+
+				mockHotplugVolumeMounter.EXPECT().IsMounted(vmi, "test", gomock.Any()).Return(true, nil)
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+
+				Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.BackupVolumeInProgress))
+				targetDir := vmi.Status.VolumeStatus[0].BackupVolume.TargetDirectory
+				Expect(targetDir).To(HavePrefix("testvmi-test-"))
+				Expect(targetDir).To(HaveSuffix(".backup"))
+				testutils.ExpectEvent(recorder, "Backup Volume test is attached, backing up")
+
+				By("Sending the backup command with the backup volume")
+				client.EXPECT().Ping()
+				client.EXPECT().VirtualMachineBackup(vmi, backupPath(vmi.Status.VolumeStatus[0]), backupVolume).Return(nil)
+				Expect(controller.getBackup(vmi)).To(Succeed())
+			})
+
+			It("Should mark a backup volume completed once the backup completed", func() {
+				vmi := api2.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.Status.Phase = v1.Running
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "test",
+					VolumeSource: v1.VolumeSource{
+						Backup: &v1.BackupVolumeSource{Checkpoint: "testvm-checkpoint"},
+					},
+				})
+				targetDir := backupTargetDir(vmi.Name, "test")
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+					Name:  "test",
+					Phase: v1.BackupVolumeInProgress,
+					HotplugVolume: &v1.HotplugVolumeStatus{
+						AttachPodName: "testpod",
+						AttachPodUID:  "1234",
+					},
+					BackupVolume: &v1.DomainBackupInfo{
+						TargetDirectory: targetDir,
+					},
+				})
+				domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				now := metav1.Now()
+				domain.Spec.Metadata.KubeVirt.Backup = &api.BackupMetadata{
+					Directory:      targetDir,
+					StartTimestamp: &now,
+					EndTimestamp:   &now,
+					Completed:      true,
+				}
+				domain.Status.Status = api.Running
+
+				mockHotplugVolumeMounter.EXPECT().IsMounted(vmi, "test", gomock.Any()).Return(true, nil)
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+
+				Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.BackupVolumeCompleted))
+				Expect(vmi.Status.VolumeStatus[0].BackupVolume.StartTimestamp).To(Equal(&now))
+				Expect(vmi.Status.VolumeStatus[0].BackupVolume.EndTimestamp).To(Equal(&now))
+				testutils.ExpectEvent(recorder, "Backup to Volume test has completed successfully")
+			})
+
 			It("Should generate memory dump completed event once memory dump completed", func() {
 				vmi := api2.NewMinimalVMI("testvmi")
 				vmi.UID = vmiTestUUID
@@ -2048,7 +2127,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 				GuestRequested: &initialMemory,
 			}
 
-			targetPodMemory := services.GetMemoryOverhead(vmi, runtime.GOARCH, nil)
+			targetPodMemory := services.GetMemoryOverhead(vmi, runtime.GOARCH, nil, nil)
 			targetPodMemory.Add(requestedMemory)
 			vmi.Labels = map[string]string{
 				v1.VirtualMachinePodMemoryRequestsLabel: targetPodMemory.String(),
@@ -2122,7 +2201,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			}
 			vmi.Spec.Architecture = "amd64"
 
-			targetPodMemory := services.GetMemoryOverhead(vmi, runtime.GOARCH, nil)
+			targetPodMemory := services.GetMemoryOverhead(vmi, runtime.GOARCH, nil, nil)
 			targetPodMemory.Add(requestedMemory)
 			vmi.Labels = map[string]string{
 				v1.VirtualMachinePodMemoryRequestsLabel: targetPodMemory.String(),
@@ -2696,6 +2775,32 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonPRNotMigratable))
 		})
 
+		DescribeTable("should take the migration capabilities of the hypervisor into account", func(hypervisorName string, withLocalDisk bool, expectedStatus k8sv1.ConditionStatus) {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Spec.Hypervisor = hypervisorName
+			if withLocalDisk {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "containerdisk",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{},
+					},
+				})
+			}
+
+			condition, isBlockMigration := controller.calculateLiveMigrationCondition(vmi)
+			Expect(isBlockMigration).To(Equal(withLocalDisk))
+			Expect(condition.Type).To(Equal(v1.VirtualMachineInstanceIsMigratable))
+			Expect(condition.Status).To(Equal(expectedStatus))
+			if expectedStatus == k8sv1.ConditionFalse {
+				Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonHypervisorNotMigratable))
+			}
+		},
+			Entry("QEMU with shared disks", "qemu", false, k8sv1.ConditionTrue),
+			Entry("QEMU with local disks", "qemu", true, k8sv1.ConditionTrue),
+			Entry("Cloud Hypervisor with shared disks", "ch", false, k8sv1.ConditionTrue),
+			Entry("Cloud Hypervisor with local disks", "ch", true, k8sv1.ConditionFalse),
+		)
+
 		Context("with network configuration", func() {
 			It("should block migration for bridge binding assigned to the pod network", func() {
 				vmi := api2.NewMinimalVMI("testvmi")
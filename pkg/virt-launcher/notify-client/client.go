@@ -39,6 +39,8 @@ import (
 const (
 	cantDetermineLibvirtDomainName = "Could not determine name of libvirt domain in event callback."
 	libvirtEventChannelFull        = "Libvirt event channel is full, dropping event."
+
+	agentProbeInterval = 10 * time.Second
 )
 
 var (
@@ -241,7 +243,7 @@ func newWatchEventError(err error) watch.Event {
 
 func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEvent, client *Notifier, events chan watch.Event,
 	interfaceStatus []api.InterfaceStatus, osInfo *api.GuestOSInfo, vmi *v1.VirtualMachineInstance, fsFreezeStatus *api.FSFreeze,
-	metadataCache *metadata.Cache) {
+	probedAgentConnected *bool, metadataCache *metadata.Cache) {
 
 	d, err := c.LookupDomainByName(util.DomainFromNamespaceName(domain.ObjectMeta.Namespace, domain.ObjectMeta.Name))
 	if err != nil {
@@ -288,6 +290,9 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 		if spec != nil {
 			spec.Metadata.KubeVirt = kubevirtMetadata
 			domain.Spec = *spec
+			if probedAgentConnected != nil {
+				setAgentChannelState(&domain.Spec, *probedAgentConnected)
+			}
 		}
 
 		if domain.Status.Status == prevStatus && domain.Status.Reason == prevReason {
@@ -359,6 +364,48 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 	}
 }
 
+// setAgentChannelState reports the state of a guest agent which is not reached through a libvirt channel
+// as the state of the guest agent channel
+func setAgentChannelState(spec *api.DomainSpec, connected bool) {
+	state := "disconnected"
+	if connected {
+		state = "connected"
+	}
+	agentChannel := converter.Add_Agent_To_api_Channel()
+	for i, channel := range spec.Devices.Channels {
+		if channel.Target != nil && channel.Target.Name == agentChannel.Target.Name {
+			spec.Devices.Channels[i].Target.State = state
+			return
+		}
+	}
+	agentChannel.Target.State = state
+	spec.Devices.Channels = append(spec.Devices.Channels, agentChannel)
+}
+
+// probeAgent raises agent lifecycle events for a guest agent which is not reached through a libvirt channel
+func probeAgent(prober cli.AgentProber, domainName string, interval time.Duration, eventChan chan<- libvirtEvent) {
+	connected := false
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if reachable := prober.PingAgent(domainName) == nil; reachable == connected {
+			continue
+		}
+		state := libvirt.CONNECT_DOMAIN_EVENT_AGENT_LIFECYCLE_STATE_CONNECTED
+		if connected {
+			state = libvirt.CONNECT_DOMAIN_EVENT_AGENT_LIFECYCLE_STATE_DISCONNECTED
+		}
+		log.Log.Infof("GuestAgentLifecycle probe of domain %s changed the state to %d", domainName, state)
+		select {
+		case eventChan <- libvirtEvent{AgentEvent: &libvirt.DomainEventAgentLifecycle{State: state}, Domain: domainName}:
+			connected = !connected
+		default:
+			// the state change is picked up again by the next probe
+			log.Log.Infof(libvirtEventChannelFull)
+		}
+	}
+}
+
 var updateEvents = updateEventsClosure()
 
 func updateEventsClosure() func(event watch.Event, domain *api.Domain, events chan watch.Event) {
@@ -415,12 +462,17 @@ func (n *Notifier) StartDomainNotifier(
 		var interfaceStatuses []api.InterfaceStatus
 		var guestOsInfo *api.GuestOSInfo
 		var fsFreezeStatus *api.FSFreeze
+		var probedAgentConnected *bool
 		for {
 			select {
 			case event := <-eventChan:
 				metadataCache.ResetNotification()
+				if _, isProber := domainConn.(cli.AgentProber); isProber && event.AgentEvent != nil {
+					connected := event.AgentEvent.State == libvirt.CONNECT_DOMAIN_EVENT_AGENT_LIFECYCLE_STATE_CONNECTED
+					probedAgentConnected = &connected
+				}
 				domainCache = util.NewDomainFromName(event.Domain, vmi.UID)
-				eventCallback(domainConn, domainCache, event, n, deleteNotificationSent, interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, metadataCache)
+				eventCallback(domainConn, domainCache, event, n, deleteNotificationSent, interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, probedAgentConnected, metadataCache)
 				log.Log.Infof("Domain name event: %v", domainCache.Spec.Name)
 				if event.AgentEvent != nil {
 					if event.AgentEvent.State == libvirt.CONNECT_DOMAIN_EVENT_AGENT_LIFECYCLE_STATE_CONNECTED {
@@ -436,7 +488,7 @@ func (n *Notifier) StartDomainNotifier(
 				fsFreezeStatus = agentUpdate.DomainInfo.FSFreezeStatus
 
 				eventCallback(domainConn, domainCache, libvirtEvent{}, n, deleteNotificationSent,
-					interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, metadataCache)
+					interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, probedAgentConnected, metadataCache)
 			case <-reconnectChan:
 				n.SendDomainEvent(newWatchEventError(fmt.Errorf("Libvirt reconnect, domain %s", domainName)))
 
@@ -458,6 +510,7 @@ func (n *Notifier) StartDomainNotifier(
 						guestOsInfo,
 						vmi,
 						fsFreezeStatus,
+						probedAgentConnected,
 						metadataCache,
 					)
 				}
@@ -560,6 +613,10 @@ func (n *Notifier) StartDomainNotifier(
 		return err
 	}
 
+	if prober, isProber := domainConn.(cli.AgentProber); isProber {
+		go probeAgent(prober, domainName, agentProbeInterval, eventChan)
+	}
+
 	log.Log.Infof("Registered libvirt event notify callback")
 	return nil
 }
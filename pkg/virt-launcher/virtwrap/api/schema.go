@@ -389,6 +389,7 @@ type KubeVirtMetadata struct {
 	Migration        *MigrationMetadata        `xml:"migration,omitempty"`
 	AccessCredential *AccessCredentialMetadata `xml:"accessCredential,omitempty"`
 	MemoryDump       *MemoryDumpMetadata       `xml:"memoryDump,omitempty"`
+	Backup           *BackupMetadata           `xml:"backup,omitempty"`
 }
 
 type AccessCredentialMetadata struct {
@@ -396,6 +397,10 @@ type AccessCredentialMetadata struct {
 	Message   string `xml:"message,omitempty"`
 }
 
+// MemoryStateFileExtension is the extension of the file a memory dump of type
+// State is written to, the vmi is resumed from it when it starts
+const MemoryStateFileExtension = ".memory.state"
+
 type MemoryDumpMetadata struct {
 	FileName       string       `xml:"fileName,omitempty"`
 	StartTimestamp *metav1.Time `xml:"startTimestamp,omitempty"`
@@ -405,6 +410,15 @@ type MemoryDumpMetadata struct {
 	FailureReason  string       `xml:"failureReason,omitempty"`
 }
 
+type BackupMetadata struct {
+	Directory      string       `xml:"directory,omitempty"`
+	StartTimestamp *metav1.Time `xml:"startTimestamp,omitempty"`
+	EndTimestamp   *metav1.Time `xml:"endTimestamp,omitempty"`
+	Completed      bool         `xml:"completed,omitempty"`
+	Failed         bool         `xml:"failed,omitempty"`
+	FailureReason  string       `xml:"failureReason,omitempty"`
+}
+
 type MigrationMetadata struct {
 	UID            types.UID        `xml:"uid,omitempty"`
 	StartTimestamp *metav1.Time     `xml:"startTimestamp,omitempty"`
@@ -0,0 +1,530 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"libvirt.org/go/libvirt"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/storage/backup"
+	"kubevirt.io/kubevirt/pkg/storage/nbd"
+	kutil "kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	failedDomainBackup = "Domain backup failed"
+
+	// checkpointFileExtension is the extension of the file the checkpoint of the
+	// latest backup is stored in, next to each disk image holding its bitmap
+	checkpointFileExtension = ".checkpoint.xml"
+	scratchFileExtension    = ".scratch.qcow2"
+)
+
+// backupExport is a disk exported by a pull mode backup job
+type backupExport interface {
+	io.ReaderAt
+	Size() int64
+	Extents(metaContext string) ([]nbd.Extent, error)
+	Close() error
+}
+
+var dialBackupExport = func(socket, export, metaContext string) (backupExport, error) {
+	client, err := nbd.Dial(socket, export, metaContext)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+type domainBackup struct {
+	XMLName xml.Name            `xml:"domainbackup"`
+	Mode    string              `xml:"mode,attr"`
+	Server  *domainBackupServer `xml:"server,omitempty"`
+	Disks   []domainBackupDisk  `xml:"disks>disk"`
+}
+
+type domainBackupServer struct {
+	Transport string `xml:"transport,attr"`
+	Socket    string `xml:"socket,attr"`
+}
+
+type domainBackupDisk struct {
+	Name         string                   `xml:"name,attr"`
+	Backup       string                   `xml:"backup,attr"`
+	Type         string                   `xml:"type,attr,omitempty"`
+	BackupMode   string                   `xml:"backupmode,attr,omitempty"`
+	Incremental  string                   `xml:"incremental,attr,omitempty"`
+	ExportName   string                   `xml:"exportname,attr,omitempty"`
+	ExportBitmap string                   `xml:"exportbitmap,attr,omitempty"`
+	Driver       *domainBackupDiskDriver  `xml:"driver,omitempty"`
+	Scratch      *domainBackupDiskScratch `xml:"scratch,omitempty"`
+}
+
+type domainBackupDiskDriver struct {
+	Type string `xml:"type,attr"`
+}
+
+type domainBackupDiskScratch struct {
+	File string `xml:"file,attr"`
+}
+
+type domainCheckpoint struct {
+	XMLName xml.Name               `xml:"domaincheckpoint"`
+	Name    string                 `xml:"name"`
+	Disks   []domainCheckpointDisk `xml:"disks>disk"`
+}
+
+type domainCheckpointDisk struct {
+	Name       string `xml:"name,attr"`
+	Checkpoint string `xml:"checkpoint,attr"`
+}
+
+// backupDisk is a disk of the domain which is backed up
+type backupDisk struct {
+	volume string
+	target string
+	// source is the image file of the disk, empty for block devices
+	source string
+	// bitmap is true if the disk can hold the persistent dirty bitmap of a checkpoint
+	bitmap      bool
+	incremental bool
+}
+
+func (l *LibvirtDomainManager) BackupVirtualMachine(vmi *v1.VirtualMachineInstance, backupPath string, volume *v1.BackupVolumeSource) error {
+	select {
+	case l.backupInProgress <- struct{}{}:
+	default:
+		log.Log.Object(vmi).Infof("backup is in progress")
+		return nil
+	}
+
+	go func() {
+		defer func() { <-l.backupInProgress }()
+		if err := l.backup(vmi, backupPath, volume); err != nil {
+			log.Log.Object(vmi).Reason(err).Error(failedDomainBackup)
+		}
+	}()
+	return nil
+}
+
+// backup takes a pull mode backup of the persistent disks of the domain into a sparse raw image per disk.
+// Disks holding the dirty bitmap of the checkpoint the backup is taken on top of are backed up incrementally,
+// the others fully. Bitmaps are only kept in qcow2 images, disks in other formats are always backed up fully.
+func (l *LibvirtDomainManager) backup(vmi *v1.VirtualMachineInstance, backupPath string, volume *v1.BackupVolumeSource) error {
+	logger := log.Log.Object(vmi)
+
+	if l.shouldSkipBackup(backupPath) {
+		return nil
+	}
+	l.initializeBackupMetadata(backupPath)
+
+	logger.Infof("Starting backup")
+	manifest, disks, err := l.runBackup(vmi, backupPath, volume)
+	if err != nil {
+		l.setBackupResult(true, fmt.Sprintf("%s: %s", failedDomainBackup, err))
+		return err
+	}
+	if err := backup.WriteManifest(backupPath, manifest); err != nil {
+		l.setBackupResult(true, fmt.Sprintf("%s: %s", failedDomainBackup, err))
+		return err
+	}
+
+	// the backup is usable without them, a missing checkpoint only makes the next backup a full one
+	if err := l.storeCheckpoint(vmi, volume.Checkpoint, disks); err != nil {
+		logger.Reason(err).Warningf("Failed to store checkpoint %s", volume.Checkpoint)
+	}
+	if err := l.pruneCheckpoints(vmi, volume.Checkpoint); err != nil {
+		logger.Reason(err).Warning("Failed to remove the checkpoints of previous backups")
+	}
+	logger.Infof("Completed backup successfully")
+	l.setBackupResult(false, "")
+	return nil
+}
+
+func (l *LibvirtDomainManager) runBackup(vmi *v1.VirtualMachineInstance, backupPath string, volume *v1.BackupVolumeSource) (*backup.Manifest, []backupDisk, error) {
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if dom == nil || err != nil {
+		return nil, nil, fmt.Errorf("failed to look up the domain: %v", err)
+	}
+	defer dom.Free()
+
+	domSpec, err := getDomainSpec(dom)
+	if err != nil {
+		return nil, nil, err
+	}
+	disks := backupDisks(vmi, domSpec)
+	if len(disks) == 0 {
+		return nil, nil, fmt.Errorf("the vmi has no persistent volumes to back up")
+	}
+
+	incrementalFrom := ""
+	if volume.IncrementalFrom != nil {
+		incrementalFrom, err = l.markIncrementalDisks(vmi, domName, *volume.IncrementalFrom, disks)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// keep trying to back up even if removing the previous backup failed
+	removePreviousBackup(filepath.Dir(backupPath))
+	if err := os.MkdirAll(backupPath, 0750); err != nil {
+		return nil, nil, err
+	}
+
+	socket := backupSocket(vmi)
+	backupXML, checkpointXML, err := backupJobXML(disks, backupPath, socket, volume.Checkpoint, incrementalFrom)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := l.virConn.BeginDomainBackup(domName, backupXML, checkpointXML); err != nil {
+		return nil, nil, err
+	}
+	// the disks are exported until the pull mode backup job is ended
+	defer func() {
+		if err := dom.AbortJob(); err != nil {
+			log.Log.Object(vmi).Reason(err).Warning("Failed to end the backup job")
+		}
+		for _, disk := range disks {
+			if err := os.Remove(scratchFile(backupPath, disk)); err != nil && !os.IsNotExist(err) {
+				log.Log.Object(vmi).Reason(err).Warningf("Failed to remove the scratch file of %s", disk.volume)
+			}
+		}
+	}()
+
+	manifest := &backup.Manifest{
+		Checkpoint:      volume.Checkpoint,
+		IncrementalFrom: incrementalFrom,
+	}
+	for _, disk := range disks {
+		volumeManifest, err := copyBackupExport(socket, backupPath, disk)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to back up %s: %v", disk.volume, err)
+		}
+		manifest.Volumes = append(manifest.Volumes, *volumeManifest)
+	}
+	return manifest, disks, nil
+}
+
+// copyBackupExport writes the extents of the disk which are part of the backup into its image. Those are the
+// extents marked dirty in the bitmap of an incremental backup, and the extents which do not read as zeroes
+// of a full one.
+func copyBackupExport(socket, backupPath string, disk backupDisk) (*backup.VolumeManifest, error) {
+	metaContext := nbd.BaseAllocation
+	inBackup := func(flags uint32) bool { return flags&nbd.StateZero == 0 }
+	if disk.incremental {
+		metaContext = nbd.DirtyBitmap(backupBitmap(disk))
+		inBackup = func(flags uint32) bool { return flags&nbd.StateDirty != 0 }
+	}
+
+	export, err := dialBackupExport(socket, disk.target, metaContext)
+	if err != nil {
+		return nil, err
+	}
+	defer export.Close()
+
+	diskExtents, err := export.Extents(metaContext)
+	if err != nil {
+		return nil, err
+	}
+	extents := []backup.Extent{}
+	for _, extent := range diskExtents {
+		if !inBackup(extent.Flags) {
+			continue
+		}
+		if last := len(extents) - 1; last >= 0 && extents[last].Offset+extents[last].Length == extent.Offset {
+			extents[last].Length += extent.Length
+			continue
+		}
+		extents = append(extents, backup.Extent{Offset: extent.Offset, Length: extent.Length})
+	}
+
+	image := backup.ImageFileName(disk.volume)
+	if err := backup.WriteImage(filepath.Join(backupPath, image), export.Size(), export, extents); err != nil {
+		return nil, err
+	}
+	return &backup.VolumeManifest{
+		Name:        disk.volume,
+		Image:       image,
+		Size:        export.Size(),
+		Incremental: disk.incremental,
+		Extents:     extents,
+	}, nil
+}
+
+// markIncrementalDisks marks the disks holding a bitmap of the checkpoint as incremental. If the checkpoint is
+// unknown, e.g. because its bitmaps were lost, the backup falls back to a full backup.
+func (l *LibvirtDomainManager) markIncrementalDisks(vmi *v1.VirtualMachineInstance, domName, checkpointName string, disks []backupDisk) (string, error) {
+	checkpointXML, err := l.virConn.GetDomainCheckpointXML(domName, checkpointName)
+	if lvErr, ok := err.(libvirt.Error); ok && lvErr.Code == libvirt.ERR_NO_DOMAIN_CHECKPOINT {
+		log.Log.Object(vmi).Warningf("Checkpoint %s does not exist, taking a full backup", checkpointName)
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	checkpoint := &domainCheckpoint{}
+	if err := xml.Unmarshal([]byte(checkpointXML), checkpoint); err != nil {
+		return "", err
+	}
+	withBitmap := map[string]bool{}
+	for _, disk := range checkpoint.Disks {
+		withBitmap[disk.Name] = disk.Checkpoint == "bitmap"
+	}
+	for i := range disks {
+		disks[i].incremental = withBitmap[disks[i].target]
+	}
+	return checkpointName, nil
+}
+
+// storeCheckpoint stores the checkpoint next to the images holding its bitmaps, libvirt forgets about it
+// when the domain goes away
+func (l *LibvirtDomainManager) storeCheckpoint(vmi *v1.VirtualMachineInstance, checkpointName string, disks []backupDisk) error {
+	checkpointXML, err := l.virConn.GetDomainCheckpointXML(api.VMINamespaceKeyFunc(vmi), checkpointName)
+	if err != nil {
+		return err
+	}
+	for _, disk := range disks {
+		if !disk.bitmap || disk.source == "" {
+			continue
+		}
+		if err := os.WriteFile(disk.source+checkpointFileExtension, []byte(checkpointXML), 0640); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneCheckpoints deletes the checkpoints of the domain older than the checkpoint of the last completed
+// backup. Later backups are only ever taken on top of it, the bitmaps of older ones only slow down writes.
+func (l *LibvirtDomainManager) pruneCheckpoints(vmi *v1.VirtualMachineInstance, latest string) error {
+	domName := api.VMINamespaceKeyFunc(vmi)
+	names, err := l.virConn.ListDomainCheckpointNames(domName)
+	if err != nil {
+		return err
+	}
+	// the names are listed parents first, deleting a root checkpoint drops its bitmap
+	for _, name := range names {
+		if name == latest {
+			continue
+		}
+		if err := l.virConn.DeleteDomainCheckpoint(domName, name); err != nil {
+			return err
+		}
+		log.Log.Object(vmi).Infof("Deleted checkpoint %s", name)
+	}
+	return nil
+}
+
+// redefineCheckpoints makes the checkpoints stored next to the images of the domain known to libvirt again,
+// e.g. after the vmi was restarted or migrated. Stored checkpoints whose bitmaps are gone are removed, the
+// next backup is a full one then.
+func (l *LibvirtDomainManager) redefineCheckpoints(vmi *v1.VirtualMachineInstance, domSpec *api.DomainSpec) {
+	logger := log.Log.Object(vmi)
+	domName := api.VMINamespaceKeyFunc(vmi)
+
+	var known map[string]bool
+	for _, disk := range domSpec.Devices.Disks {
+		if disk.Source.File == "" {
+			continue
+		}
+		file := disk.Source.File + checkpointFileExtension
+		checkpointXML, err := os.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			logger.Reason(err).Warningf("Failed to read stored checkpoint %s", file)
+			continue
+		}
+		checkpoint := &domainCheckpoint{}
+		if err := xml.Unmarshal(checkpointXML, checkpoint); err != nil {
+			logger.Reason(err).Warningf("Removing invalid stored checkpoint %s", file)
+			removeStoredCheckpoint(file)
+			continue
+		}
+
+		if known == nil {
+			names, err := l.virConn.ListDomainCheckpointNames(domName)
+			if err != nil {
+				logger.Reason(err).Warning("Failed to list the checkpoints of the domain")
+				return
+			}
+			known = map[string]bool{}
+			for _, name := range names {
+				known[name] = true
+			}
+		}
+		if known[checkpoint.Name] {
+			continue
+		}
+		if err := l.virConn.RedefineDomainCheckpoint(domName, string(checkpointXML)); err != nil {
+			logger.Reason(err).Warningf("Removing stored checkpoint %s which cannot be redefined", checkpoint.Name)
+			removeStoredCheckpoint(file)
+			continue
+		}
+		known[checkpoint.Name] = true
+		logger.Infof("Redefined checkpoint %s", checkpoint.Name)
+	}
+}
+
+func removeStoredCheckpoint(file string) {
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		log.Log.Reason(err).Errorf("failed to remove stored checkpoint %s", file)
+	}
+}
+
+// removePreviousBackup removes the directories of older backups from the pvc the backup is written to
+func removePreviousBackup(dir string) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to remove older backups")
+		return
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), backup.DirectoryExtension) {
+			if err := os.RemoveAll(filepath.Join(dir, file.Name())); err != nil {
+				log.Log.Reason(err).Errorf("failed to remove older backups")
+			}
+		}
+	}
+}
+
+// backupDisks returns the disks of the domain backing persistent volumes of the vmi
+func backupDisks(vmi *v1.VirtualMachineInstance, domSpec *api.DomainSpec) []backupDisk {
+	persistent := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil || volume.DataVolume != nil || volume.HostDisk != nil {
+			persistent[volume.Name] = true
+		}
+	}
+
+	var disks []backupDisk
+	for _, disk := range domSpec.Devices.Disks {
+		if disk.Device != "disk" || disk.ReadOnly != nil || disk.Alias == nil || !persistent[disk.Alias.GetName()] {
+			continue
+		}
+		disks = append(disks, backupDisk{
+			volume: disk.Alias.GetName(),
+			target: disk.Target.Device,
+			source: disk.Source.File,
+			bitmap: disk.Driver != nil && disk.Driver.Type == "qcow2",
+		})
+	}
+	return disks
+}
+
+// backupSocket returns the unix socket the disks of a pull mode backup are exported on, next to the serial
+// console sockets qemu creates
+func backupSocket(vmi *v1.VirtualMachineInstance) string {
+	return filepath.Join(kutil.VirtPrivateDir, string(vmi.UID), "backup.sock")
+}
+
+func scratchFile(backupPath string, disk backupDisk) string {
+	return filepath.Join(backupPath, disk.volume+scratchFileExtension)
+}
+
+// backupBitmap returns the name of the bitmap holding the extents changed since the
+// checkpoint an incremental backup of the disk is taken on top of
+func backupBitmap(disk backupDisk) string {
+	return "backup-" + disk.target
+}
+
+func backupJobXML(disks []backupDisk, backupPath, socket, checkpointName, incrementalFrom string) (string, string, error) {
+	domBackup := domainBackup{
+		Mode:   "pull",
+		Server: &domainBackupServer{Transport: "unix", Socket: socket},
+	}
+	checkpoint := domainCheckpoint{Name: checkpointName}
+	for _, disk := range disks {
+		backupDisk := domainBackupDisk{
+			Name:       disk.target,
+			Backup:     "yes",
+			Type:       "file",
+			BackupMode: "full",
+			ExportName: disk.target,
+			Driver:     &domainBackupDiskDriver{Type: "qcow2"},
+			Scratch:    &domainBackupDiskScratch{File: scratchFile(backupPath, disk)},
+		}
+		if disk.incremental {
+			backupDisk.BackupMode = "incremental"
+			backupDisk.Incremental = incrementalFrom
+			backupDisk.ExportBitmap = backupBitmap(disk)
+		}
+		domBackup.Disks = append(domBackup.Disks, backupDisk)
+
+		checkpointDisk := domainCheckpointDisk{Name: disk.target, Checkpoint: "no"}
+		if disk.bitmap {
+			checkpointDisk.Checkpoint = "bitmap"
+		}
+		checkpoint.Disks = append(checkpoint.Disks, checkpointDisk)
+	}
+
+	backupXML, err := xml.Marshal(domBackup)
+	if err != nil {
+		return "", "", err
+	}
+	checkpointXML, err := xml.Marshal(checkpoint)
+	if err != nil {
+		return "", "", err
+	}
+	return string(backupXML), string(checkpointXML), nil
+}
+
+func (l *LibvirtDomainManager) shouldSkipBackup(backupPath string) bool {
+	backupMetadata, _ := l.metadataCache.Backup.Load()
+	// the backup is still in progress or has just completed
+	return backupMetadata.Directory == filepath.Base(backupPath)
+}
+
+func (l *LibvirtDomainManager) initializeBackupMetadata(backupPath string) {
+	l.metadataCache.Backup.WithSafeBlock(func(backupMetadata *api.BackupMetadata, initialized bool) {
+		now := metav1.Now()
+		*backupMetadata = api.BackupMetadata{
+			Directory:      filepath.Base(backupPath),
+			StartTimestamp: &now,
+		}
+	})
+	log.Log.V(4).Infof("initialize backup metadata: %s", l.metadataCache.Backup.String())
+}
+
+func (l *LibvirtDomainManager) setBackupResult(failed bool, reason string) {
+	l.metadataCache.Backup.WithSafeBlock(func(backupMetadata *api.BackupMetadata, initialized bool) {
+		if !initialized {
+			return
+		}
+		now := metav1.Now()
+		backupMetadata.Completed = true
+		backupMetadata.EndTimestamp = &now
+		backupMetadata.Failed = failed
+		backupMetadata.FailureReason = reason
+	})
+	log.Log.V(4).Infof("set backup results in metadata: %s", l.metadataCache.Backup.String())
+}
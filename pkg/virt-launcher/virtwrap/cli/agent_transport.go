@@ -0,0 +1,144 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"kubevirt.io/kubevirt/pkg/vsock"
+)
+
+const (
+	// GuestAgentVSOCKPort is the port the guest agent listens on when it is reached over vsock,
+	// e.g. qemu-ga started with --method=vsock-listen --path=-1:1025
+	GuestAgentVSOCKPort uint32 = 1025
+
+	agentTransportTimeout = 10 * time.Second
+	agentPingCommand      = `{"execute":"guest-ping"}`
+)
+
+// AgentTransport carries guest agent commands of the qemu-ga protocol to the guest of a domain
+type AgentTransport interface {
+	// Command sends the command to the guest agent and returns its reply, the same way virDomainQemuAgentCommand does
+	Command(command string, domainName string) (string, error)
+}
+
+// AgentProber is implemented by connections whose guest agent does not raise libvirt agent lifecycle events,
+// the agent has to be probed to find out whether it is connected
+type AgentProber interface {
+	// PingAgent returns nil if the guest agent of the domain responds
+	PingAgent(domainName string) error
+}
+
+// VSOCKSocketLocator is implemented by connections which know the unix socket of the hybrid vsock device of a domain
+type VSOCKSocketLocator interface {
+	// VSOCKSocketPath returns the path of the unix socket, or an error if the domain has no vsock device
+	VSOCKSocketPath(domainName string) (string, error)
+}
+
+type agentTransportConnection struct {
+	Connection
+	transport AgentTransport
+}
+
+// NewAgentTransportConnection returns a connection sending the guest agent commands through the given transport
+// instead of the guest agent channel of libvirt
+func NewAgentTransportConnection(conn Connection, transport AgentTransport) Connection {
+	return &agentTransportConnection{
+		Connection: conn,
+		transport:  transport,
+	}
+}
+
+func (c *agentTransportConnection) QemuAgentCommand(command string, domainName string) (string, error) {
+	return c.transport.Command(command, domainName)
+}
+
+func (c *agentTransportConnection) PingAgent(domainName string) error {
+	_, err := c.transport.Command(agentPingCommand, domainName)
+	return err
+}
+
+type agentReply struct {
+	Error *agentError `json:"error,omitempty"`
+}
+
+type agentError struct {
+	Class string `json:"class"`
+	Desc  string `json:"desc"`
+}
+
+// VSOCKAgentTransport reaches the guest agent through the hybrid vsock device of the VMM, where the host side
+// of the vsock device is a unix socket and connections are forwarded to a guest port with a CONNECT handshake.
+type VSOCKAgentTransport struct {
+	socketPath func(domainName string) (string, error)
+	port       uint32
+	timeout    time.Duration
+
+	// the guest agent serves a single client at a time
+	lock sync.Mutex
+}
+
+// NewVSOCKAgentTransport returns a transport to the guest agent listening on the given vsock port. socketPath returns
+// the unix socket of the vsock device of the given domain.
+func NewVSOCKAgentTransport(socketPath func(domainName string) (string, error), port uint32) *VSOCKAgentTransport {
+	return &VSOCKAgentTransport{
+		socketPath: socketPath,
+		port:       port,
+		timeout:    agentTransportTimeout,
+	}
+}
+
+func (t *VSOCKAgentTransport) Command(command string, domainName string) (string, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	socketPath, err := t.socketPath(domainName)
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the vsock socket: %v", err)
+	}
+	conn, err := vsock.DialHybrid(socketPath, t.port, t.timeout)
+	if err != nil {
+		return "", fmt.Errorf("guest agent is not connected: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := fmt.Fprintf(conn, "%s\n", command); err != nil {
+		return "", fmt.Errorf("failed to send the guest agent command: %v", err)
+	}
+
+	var reply json.RawMessage
+	if err := json.NewDecoder(conn).Decode(&reply); err != nil {
+		return "", fmt.Errorf("failed to read the guest agent reply: %v", err)
+	}
+
+	var parsed agentReply
+	if err := json.Unmarshal(reply, &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse the guest agent reply: %v", err)
+	}
+	if parsed.Error != nil {
+		return "", fmt.Errorf("guest agent command failed: %s: %s", parsed.Error.Class, parsed.Error.Desc)
+	}
+
+	return string(reply), nil
+}
@@ -0,0 +1,126 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Guest agent over vsock", func() {
+	var socketPath string
+	var listener net.Listener
+	var conn Connection
+
+	// serve emulates the hybrid vsock socket of the VMM with a guest agent listening on the given port
+	serve := func(agentPort uint32, replies map[string]string) {
+		for {
+			c, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				reader := bufio.NewReader(c)
+				handshake, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if handshake != fmt.Sprintf("CONNECT %d\n", agentPort) {
+					_, _ = c.Write([]byte("ERROR\n"))
+					return
+				}
+				_, _ = c.Write([]byte("OK 1073741824\n"))
+				command, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				_, _ = c.Write([]byte(replies[command]))
+			}()
+		}
+	}
+
+	BeforeEach(func() {
+		// unix socket paths are limited in length, the ginkgo temp dir may exceed it
+		socketDir, err := os.MkdirTemp("", "vsock")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, socketDir)
+		socketPath = filepath.Join(socketDir, "vsock.sock")
+
+		listener, err = net.Listen("unix", socketPath)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(listener.Close)
+
+		transport := NewVSOCKAgentTransport(func(domainName string) (string, error) {
+			Expect(domainName).To(Equal("default_testvmi"))
+			return socketPath, nil
+		}, GuestAgentVSOCKPort)
+		conn = NewAgentTransportConnection(NewMockConnection(gomock.NewController(GinkgoT())), transport)
+	})
+
+	It("should return the reply of the guest agent", func() {
+		go serve(GuestAgentVSOCKPort, map[string]string{
+			`{"execute":"guest-get-host-name"}` + "\n": `{"return":{"host-name":"testvmi"}}` + "\n",
+		})
+
+		reply, err := conn.QemuAgentCommand(`{"execute":"guest-get-host-name"}`, "default_testvmi")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reply).To(Equal(`{"return":{"host-name":"testvmi"}}`))
+	})
+
+	It("should fail if the guest agent returns an error", func() {
+		go serve(GuestAgentVSOCKPort, map[string]string{
+			`{"execute":"guest-fsfreeze-freeze"}` + "\n": `{"error":{"class":"GenericError","desc":"freeze failed"}}` + "\n",
+		})
+
+		_, err := conn.QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, "default_testvmi")
+		Expect(err).To(MatchError(ContainSubstring("GenericError: freeze failed")))
+	})
+
+	It("should report the agent as disconnected if nothing listens on the port", func() {
+		go serve(GuestAgentVSOCKPort+1, nil)
+
+		Expect(conn.(AgentProber).PingAgent("default_testvmi")).To(MatchError(ContainSubstring("guest agent is not connected")))
+	})
+
+	It("should report the agent as disconnected if the domain has no vsock device", func() {
+		transport := NewVSOCKAgentTransport(func(domainName string) (string, error) {
+			return "", fmt.Errorf("domain %s has no vsock device", domainName)
+		}, GuestAgentVSOCKPort)
+		conn = NewAgentTransportConnection(NewMockConnection(gomock.NewController(GinkgoT())), transport)
+
+		Expect(conn.(AgentProber).PingAgent("default_testvmi")).To(MatchError(ContainSubstring("has no vsock device")))
+	})
+
+	It("should report the agent as connected if it responds to the ping", func() {
+		go serve(GuestAgentVSOCKPort, map[string]string{
+			agentPingCommand + "\n": `{"return":{}}` + "\n",
+		})
+
+		Expect(conn.(AgentProber).PingAgent("default_testvmi")).To(Succeed())
+	})
+})
@@ -0,0 +1,426 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"libvirt.org/go/libvirt"
+
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+const (
+	cloudHypervisorAPITimeout = 5 * time.Second
+
+	// /proc/<pid>/stat reports the CPU time in clock ticks, which are fixed to 100 per second for userspace
+	clockTicksPerSecond = 100
+	nanosecondsPerTick  = uint64(time.Second / clockTicksPerSecond)
+)
+
+// CloudHypervisorVMInfo is the subset of the vm.info response of the Cloud Hypervisor HTTP API used for the domain stats
+// and for locating the vsock device
+type CloudHypervisorVMInfo struct {
+	Config           CloudHypervisorVMConfig `json:"config"`
+	State            string                  `json:"state"`
+	MemoryActualSize uint64                  `json:"memory_actual_size,omitempty"`
+}
+
+type CloudHypervisorVMConfig struct {
+	CPUs   CloudHypervisorCPUsConfig   `json:"cpus"`
+	Memory CloudHypervisorMemoryConfig `json:"memory"`
+	Disks  []CloudHypervisorDiskConfig `json:"disks,omitempty"`
+	Net    []CloudHypervisorNetConfig  `json:"net,omitempty"`
+	Vsock  *CloudHypervisorVsockConfig `json:"vsock,omitempty"`
+}
+
+type CloudHypervisorCPUsConfig struct {
+	BootVCPUs uint `json:"boot_vcpus"`
+	MaxVCPUs  uint `json:"max_vcpus"`
+}
+
+type CloudHypervisorMemoryConfig struct {
+	Size uint64 `json:"size"`
+}
+
+type CloudHypervisorDiskConfig struct {
+	ID   string `json:"id"`
+	Path string `json:"path"`
+}
+
+type CloudHypervisorNetConfig struct {
+	ID  string `json:"id"`
+	Tap string `json:"tap"`
+	MAC string `json:"mac"`
+}
+
+type CloudHypervisorVsockConfig struct {
+	CID    uint32 `json:"cid"`
+	Socket string `json:"socket"`
+}
+
+// CloudHypervisorVMCounters is the vm.counters response of the Cloud Hypervisor HTTP API,
+// the counters of every device keyed by the device id
+type CloudHypervisorVMCounters map[string]map[string]uint64
+
+type cloudHypervisorVmmPing struct {
+	Version string `json:"version"`
+	Pid     int    `json:"pid"`
+}
+
+// cloudHypervisorConnection collects the domain stats from the HTTP API of Cloud Hypervisor, since the libvirt
+// ch driver implements only a small part of the bulk stats API. Everything else is served by libvirt.
+type cloudHypervisorConnection struct {
+	Connection
+	apiSocketPath func(domainName string) string
+	vcpuRegex     *regexp.Regexp
+	procPath      string
+}
+
+// NewCloudHypervisorConnection wraps a connection to the libvirt ch driver. apiSocketPath returns the path of the
+// HTTP API socket of the Cloud Hypervisor process running the given domain and vcpuRegex matches the comm value
+// of its vCPU threads.
+func NewCloudHypervisorConnection(conn Connection, apiSocketPath func(domainName string) string, vcpuRegex *regexp.Regexp) Connection {
+	return &cloudHypervisorConnection{
+		Connection:    conn,
+		apiSocketPath: apiSocketPath,
+		vcpuRegex:     vcpuRegex,
+		procPath:      "/proc",
+	}
+}
+
+func (c *cloudHypervisorConnection) GetDomainStats(_ libvirt.DomainStatsTypes, migrateJobInfo *stats.DomainJobInfo, _ libvirt.ConnectGetAllDomainStatsFlags) ([]*stats.DomainStats, error) {
+	doms, err := c.ListAllDomains(libvirt.CONNECT_LIST_DOMAINS_ACTIVE)
+	if err != nil {
+		return nil, err
+	}
+	// Free memory allocated for domains
+	defer func() {
+		for _, dom := range doms {
+			if err := dom.Free(); err != nil {
+				log.Log.Reason(err).Warning("Error freeing a domain.")
+			}
+		}
+	}()
+
+	var list []*stats.DomainStats
+	for _, dom := range doms {
+		stat, err := c.getDomainStats(dom)
+		if err != nil {
+			return list, err
+		}
+		stat.MigrateDomainJobInfo = migrateJobInfo
+		list = append(list, stat)
+	}
+
+	return list, nil
+}
+
+// VSOCKSocketPath returns the unix socket of the hybrid vsock device the ch driver configured for the domain
+func (c *cloudHypervisorConnection) VSOCKSocketPath(domainName string) (string, error) {
+	client := newCloudHypervisorAPIClient(c.apiSocketPath(domainName))
+	defer client.close()
+	info := &CloudHypervisorVMInfo{}
+	if err := client.get("vm.info", info); err != nil {
+		return "", err
+	}
+	if info.Config.Vsock == nil || info.Config.Vsock.Socket == "" {
+		return "", fmt.Errorf("domain %s has no vsock device", domainName)
+	}
+	return info.Config.Vsock.Socket, nil
+}
+
+func (c *cloudHypervisorConnection) getDomainStats(dom VirDomain) (*stats.DomainStats, error) {
+	stat := &stats.DomainStats{}
+
+	var err error
+	if stat.Name, err = dom.GetName(); err != nil {
+		return nil, err
+	}
+	if stat.UUID, err = dom.GetUUIDString(); err != nil {
+		return nil, err
+	}
+
+	domSpec := &api.DomainSpec{}
+	domxml, err := dom.GetXMLDesc(0)
+	if err != nil {
+		return nil, err
+	}
+	if err := xml.Unmarshal([]byte(domxml), domSpec); err != nil {
+		return nil, err
+	}
+
+	client := newCloudHypervisorAPIClient(c.apiSocketPath(stat.Name))
+	defer client.close()
+	info := &CloudHypervisorVMInfo{}
+	if err := client.get("vm.info", info); err != nil {
+		return nil, err
+	}
+	counters := CloudHypervisorVMCounters{}
+	if err := client.get("vm.counters", &counters); err != nil {
+		return nil, err
+	}
+	ping := &cloudHypervisorVmmPing{}
+	if err := client.get("vmm.ping", ping); err != nil {
+		return nil, err
+	}
+
+	convertCloudHypervisorVMInfo(info, counters, domSpec, stat)
+
+	// CPU and memory usage are not part of the API, they are taken from the VMM process
+	if err := c.addProcessStats(ping.Pid, stat); err != nil {
+		log.Log.Reason(err).Warningf("failed to read the process stats of Cloud Hypervisor process %d", ping.Pid)
+	}
+
+	return stat, nil
+}
+
+// convertCloudHypervisorVMInfo maps the configuration and device counters of a Cloud Hypervisor VM to the domain stats.
+// Devices are matched to the domain devices by their path and MAC address, since the ch driver does not name them.
+func convertCloudHypervisorVMInfo(info *CloudHypervisorVMInfo, counters CloudHypervisorVMCounters, domSpec *api.DomainSpec, out *stats.DomainStats) {
+	out.NrVirtCpu = info.Config.CPUs.BootVCPUs
+
+	out.Memory = &stats.DomainStatsMemory{
+		TotalSet: true,
+		Total:    info.Config.Memory.Size / 1024,
+	}
+	if info.MemoryActualSize > 0 {
+		out.Memory.ActualBalloonSet = true
+		out.Memory.ActualBalloon = info.MemoryActualSize / 1024
+	}
+
+	for _, disk := range info.Config.Disks {
+		blkStat := stats.DomainStatsBlock{
+			PathSet: true,
+			Path:    disk.Path,
+		}
+		for _, domDisk := range domSpec.Devices.Disks {
+			if domDisk.Source.File == disk.Path || domDisk.Source.Dev == disk.Path {
+				blkStat.NameSet = true
+				blkStat.Name = domDisk.Target.Device
+				if domDisk.Alias != nil {
+					blkStat.Alias = domDisk.Alias.GetName()
+				}
+				break
+			}
+		}
+		if diskCounters, ok := counters[disk.ID]; ok {
+			blkStat.RdBytes, blkStat.RdBytesSet = diskCounters["read_bytes"]
+			blkStat.RdReqs, blkStat.RdReqsSet = diskCounters["read_ops"]
+			blkStat.WrBytes, blkStat.WrBytesSet = diskCounters["write_bytes"]
+			blkStat.WrReqs, blkStat.WrReqsSet = diskCounters["write_ops"]
+		}
+		out.Block = append(out.Block, blkStat)
+	}
+
+	for _, iface := range info.Config.Net {
+		netStat := stats.DomainStatsNet{
+			NameSet: iface.Tap != "",
+			Name:    iface.Tap,
+		}
+		for _, domIface := range domSpec.Devices.Interfaces {
+			if domIface.MAC != nil && strings.EqualFold(domIface.MAC.MAC, iface.MAC) {
+				if domIface.Alias != nil {
+					netStat.AliasSet = true
+					netStat.Alias = domIface.Alias.GetName()
+				}
+				if !netStat.NameSet && domIface.Target != nil {
+					netStat.NameSet = true
+					netStat.Name = domIface.Target.Device
+				}
+				break
+			}
+		}
+		if netCounters, ok := counters[iface.ID]; ok {
+			netStat.RxBytes, netStat.RxBytesSet = netCounters["rx_bytes"]
+			netStat.RxPkts, netStat.RxPktsSet = netCounters["rx_frames"]
+			netStat.TxBytes, netStat.TxBytesSet = netCounters["tx_bytes"]
+			netStat.TxPkts, netStat.TxPktsSet = netCounters["tx_frames"]
+		}
+		out.Net = append(out.Net, netStat)
+	}
+}
+
+// addProcessStats adds the CPU time of the VMM and its vCPU threads and the resident set size of the VMM
+func (c *cloudHypervisorConnection) addProcessStats(pid int, out *stats.DomainStats) error {
+	processPath := filepath.Join(c.procPath, strconv.Itoa(pid))
+
+	user, system, err := readProcessCPUTime(filepath.Join(processPath, "stat"))
+	if err != nil {
+		return err
+	}
+	out.Cpu = &stats.DomainStatsCPU{
+		TimeSet:   true,
+		Time:      user + system,
+		UserSet:   true,
+		User:      user,
+		SystemSet: true,
+		System:    system,
+	}
+
+	rss, err := util.ResidentSetSize(filepath.Join(processPath, "status"))
+	if err != nil {
+		return err
+	}
+	if out.Memory == nil {
+		out.Memory = &stats.DomainStatsMemory{}
+	}
+	// like libvirt, the domain stats report the resident set size in KiB
+	out.Memory.RSSSet = true
+	out.Memory.RSS = rss / 1024
+
+	tasks, err := os.ReadDir(filepath.Join(processPath, "task"))
+	if err != nil {
+		return err
+	}
+	vcpus := make([]stats.DomainStatsVcpu, out.NrVirtCpu)
+	for _, task := range tasks {
+		taskPath := filepath.Join(processPath, "task", task.Name())
+		comm, err := os.ReadFile(filepath.Join(taskPath, "comm"))
+		if err != nil {
+			continue
+		}
+		match := c.vcpuRegex.FindStringSubmatch(string(comm))
+		if len(match) < 2 {
+			continue
+		}
+		vcpu, err := strconv.Atoi(match[1])
+		if err != nil || vcpu >= len(vcpus) {
+			continue
+		}
+		user, system, err := readProcessCPUTime(filepath.Join(taskPath, "stat"))
+		if err != nil {
+			continue
+		}
+		vcpus[vcpu] = stats.DomainStatsVcpu{
+			StateSet: true,
+			State:    stats.VCPURunning,
+			TimeSet:  true,
+			Time:     user + system,
+		}
+	}
+	out.Vcpu = vcpus
+
+	return nil
+}
+
+// readProcessCPUTime returns the user and system time in nanoseconds from a /proc/<pid>/stat file
+func readProcessCPUTime(statPath string) (uint64, uint64, error) {
+	content, err := os.ReadFile(statPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	// The command name may contain spaces, the fields are counted after its closing parenthesis
+	stat := string(content)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	// utime and stime are the 14th and 15th field, the 12th and 13th after the command name
+	if len(fields) < 13 {
+		return 0, 0, fmt.Errorf("unexpected format of %s", statPath)
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return utime * nanosecondsPerTick, stime * nanosecondsPerTick, nil
+}
+
+type cloudHypervisorAPIClient struct {
+	client *http.Client
+}
+
+// newCloudHypervisorAPIClient returns a client which opens a new connection to the API socket for every request,
+// the clients are created per call and must not leave idle connections to the VMM behind.
+func newCloudHypervisorAPIClient(socketPath string) *cloudHypervisorAPIClient {
+	return &cloudHypervisorAPIClient{
+		client: &http.Client{
+			Timeout: cloudHypervisorAPITimeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", socketPath)
+				},
+				DisableKeepAlives: true,
+			},
+		},
+	}
+}
+
+func (c *cloudHypervisorAPIClient) close() {
+	c.client.CloseIdleConnections()
+}
+
+func (c *cloudHypervisorAPIClient) put(endpoint string, in interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, "http://localhost/api/v1/"+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Cloud Hypervisor API %s: %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("Cloud Hypervisor API %s returned %s", endpoint, resp.Status)
+	}
+	return nil
+}
+
+func (c *cloudHypervisorAPIClient) get(endpoint string, out interface{}) error {
+	resp, err := c.client.Get("http://localhost/api/v1/" + endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to call Cloud Hypervisor API %s: %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Cloud Hypervisor API %s returned %s", endpoint, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response of Cloud Hypervisor API %s: %v", endpoint, err)
+	}
+	return nil
+}
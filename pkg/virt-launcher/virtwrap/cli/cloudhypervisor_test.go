@@ -0,0 +1,234 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package cli
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"libvirt.org/go/libvirt"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+const (
+	cloudHypervisorDomainXML = `<domain type="hyperv">
+  <name>default_testvmi</name>
+  <devices>
+    <disk type="file" device="disk">
+      <source file="/var/run/kubevirt-private/vmi-disks/rootdisk/disk.img"/>
+      <target dev="vda" bus="virtio"/>
+      <alias name="ua-rootdisk"/>
+    </disk>
+    <interface type="ethernet">
+      <mac address="02:00:00:00:00:01"/>
+      <target dev="tap0"/>
+      <alias name="ua-default"/>
+    </interface>
+  </devices>
+</domain>`
+
+	cloudHypervisorVMInfo = `{
+  "config": {
+    "cpus": {"boot_vcpus": 2, "max_vcpus": 2},
+    "memory": {"size": 1073741824},
+    "disks": [{"id": "_disk0", "path": "/var/run/kubevirt-private/vmi-disks/rootdisk/disk.img"}],
+    "net": [{"id": "_net1", "tap": "tap0", "mac": "02:00:00:00:00:01"}]
+  },
+  "state": "Running",
+  "memory_actual_size": 1073741824
+}`
+
+	cloudHypervisorVMCounters = `{
+  "_disk0": {"read_bytes": 4096, "read_ops": 1, "write_bytes": 8192, "write_ops": 2},
+  "_net1": {"rx_bytes": 100, "rx_frames": 3, "tx_bytes": 200, "tx_frames": 4}
+}`
+
+	cloudHypervisorPid = 42
+)
+
+var _ = Describe("Cloud Hypervisor domain stats", func() {
+	var conn *cloudHypervisorConnection
+	var mockConn *MockConnection
+	var mockDomain *MockVirDomain
+
+	writeProcFile := func(procPath string, content string, elems ...string) {
+		path := filepath.Join(append([]string{procPath}, elems...)...)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockConn = NewMockConnection(ctrl)
+		mockDomain = NewMockVirDomain(ctrl)
+
+		// unix socket paths are limited in length, the ginkgo temp dir may exceed it
+		socketDir, err := os.MkdirTemp("", "ch")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, socketDir)
+		socketPath := filepath.Join(socketDir, "api.sock")
+
+		mux := http.NewServeMux()
+		for endpoint, response := range map[string]string{
+			"vm.info":     cloudHypervisorVMInfo,
+			"vm.counters": cloudHypervisorVMCounters,
+			"vmm.ping":    `{"version": "v41.0", "pid": 42}`,
+		} {
+			response := response
+			mux.HandleFunc("/api/v1/"+endpoint, func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(response))
+			})
+		}
+		listener, err := net.Listen("unix", socketPath)
+		Expect(err).ToNot(HaveOccurred())
+		server := &http.Server{Handler: mux}
+		go func() { _ = server.Serve(listener) }()
+		DeferCleanup(server.Close)
+
+		procPath := GinkgoT().TempDir()
+		writeProcFile(procPath, "42 (cloud-hypervisor) S 1 42 42 0 -1 4194560 0 0 0 0 150 50 0 0 20 0 8 0 100 0 0\n", "42", "stat")
+		writeProcFile(procPath, "Name:\tcloud-hypervisor\nVmRSS:\t  204800 kB\n", "42", "status")
+		writeProcFile(procPath, "vcpu0\n", "42", "task", "43", "comm")
+		writeProcFile(procPath, "43 (vcpu0) S 1 42 42 0 -1 4194560 0 0 0 0 100 10 0 0 20 0 8 0 100 0 0\n", "42", "task", "43", "stat")
+		writeProcFile(procPath, "vcpu1\n", "42", "task", "44", "comm")
+		writeProcFile(procPath, "44 (vcpu1) S 1 42 42 0 -1 4194560 0 0 0 0 20 5 0 0 20 0 8 0 100 0 0\n", "42", "task", "44", "stat")
+		writeProcFile(procPath, "http-server\n", "42", "task", "45", "comm")
+
+		conn = NewCloudHypervisorConnection(mockConn, func(domainName string) string {
+			Expect(domainName).To(Equal("default_testvmi"))
+			return socketPath
+		}, regexp.MustCompile(`^vcpu(\d+)\n$`)).(*cloudHypervisorConnection)
+		conn.procPath = procPath
+
+		mockConn.EXPECT().ListAllDomains(libvirt.CONNECT_LIST_DOMAINS_ACTIVE).Return([]VirDomain{mockDomain}, nil)
+		mockDomain.EXPECT().GetName().Return("default_testvmi", nil)
+		mockDomain.EXPECT().GetUUIDString().Return("a7ba5a5e-f0a0-4b2a-a5b5-bb6d3c3a5d5e", nil)
+		mockDomain.EXPECT().GetXMLDesc(gomock.Any()).Return(cloudHypervisorDomainXML, nil)
+		mockDomain.EXPECT().Free()
+	})
+
+	It("should map the API and process stats to the domain stats", func() {
+		jobInfo := &stats.DomainJobInfo{}
+		domStats, err := conn.GetDomainStats(0, jobInfo, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(domStats).To(HaveLen(1))
+
+		stat := domStats[0]
+		Expect(stat.Name).To(Equal("default_testvmi"))
+		Expect(stat.UUID).To(Equal("a7ba5a5e-f0a0-4b2a-a5b5-bb6d3c3a5d5e"))
+		Expect(stat.NrVirtCpu).To(Equal(uint(2)))
+		Expect(stat.MigrateDomainJobInfo).To(BeIdenticalTo(jobInfo))
+
+		Expect(stat.Cpu).To(Equal(&stats.DomainStatsCPU{
+			TimeSet: true, Time: 2000000000,
+			UserSet: true, User: 1500000000,
+			SystemSet: true, System: 500000000,
+		}))
+		Expect(stat.Vcpu).To(Equal([]stats.DomainStatsVcpu{
+			{StateSet: true, State: stats.VCPURunning, TimeSet: true, Time: 1100000000},
+			{StateSet: true, State: stats.VCPURunning, TimeSet: true, Time: 250000000},
+		}))
+		Expect(stat.Memory).To(Equal(&stats.DomainStatsMemory{
+			TotalSet: true, Total: 1048576,
+			ActualBalloonSet: true, ActualBalloon: 1048576,
+			RSSSet: true, RSS: 204800,
+		}))
+
+		Expect(stat.Block).To(Equal([]stats.DomainStatsBlock{{
+			NameSet:    true,
+			Name:       "vda",
+			Alias:      "rootdisk",
+			PathSet:    true,
+			Path:       "/var/run/kubevirt-private/vmi-disks/rootdisk/disk.img",
+			RdBytesSet: true, RdBytes: 4096,
+			RdReqsSet: true, RdReqs: 1,
+			WrBytesSet: true, WrBytes: 8192,
+			WrReqsSet: true, WrReqs: 2,
+		}}))
+		Expect(stat.Net).To(Equal([]stats.DomainStatsNet{{
+			NameSet:    true,
+			Name:       "tap0",
+			AliasSet:   true,
+			Alias:      "default",
+			RxBytesSet: true, RxBytes: 100,
+			RxPktsSet: true, RxPkts: 3,
+			TxBytesSet: true, TxBytes: 200,
+			TxPktsSet: true, TxPkts: 4,
+		}}))
+	})
+
+	It("should report the API stats if the VMM process can not be read", func() {
+		conn.procPath = GinkgoT().TempDir()
+
+		domStats, err := conn.GetDomainStats(0, nil, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(domStats).To(HaveLen(1))
+		Expect(domStats[0].Cpu).To(BeNil())
+		Expect(domStats[0].Memory.RSSSet).To(BeFalse())
+		Expect(domStats[0].Block).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("Cloud Hypervisor vsock device", func() {
+	var conn *cloudHypervisorConnection
+	var vmInfo string
+
+	BeforeEach(func() {
+		// unix socket paths are limited in length, the ginkgo temp dir may exceed it
+		socketDir, err := os.MkdirTemp("", "ch")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, socketDir)
+		socketPath := filepath.Join(socketDir, "api.sock")
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/v1/vm.info", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(vmInfo))
+		})
+		listener, err := net.Listen("unix", socketPath)
+		Expect(err).ToNot(HaveOccurred())
+		server := &http.Server{Handler: mux}
+		go func() { _ = server.Serve(listener) }()
+		DeferCleanup(server.Close)
+
+		conn = NewCloudHypervisorConnection(nil, func(string) string {
+			return socketPath
+		}, nil).(*cloudHypervisorConnection)
+	})
+
+	It("should locate the socket of the vsock device", func() {
+		vmInfo = `{"config": {"vsock": {"cid": 3, "socket": "/run/libvirt/ch/default_testvmi-vsock"}}, "state": "Running"}`
+
+		Expect(conn.VSOCKSocketPath("default_testvmi")).To(Equal("/run/libvirt/ch/default_testvmi-vsock"))
+	})
+
+	It("should fail if the domain has no vsock device", func() {
+		vmInfo = cloudHypervisorVMInfo
+
+		_, err := conn.VSOCKSocketPath("default_testvmi")
+		Expect(err).To(MatchError("domain default_testvmi has no vsock device"))
+	})
+})
@@ -210,6 +210,78 @@ func (_mr *_MockConnectionRecorder) GetSEVInfo() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSEVInfo")
 }
 
+func (_m *MockConnection) SaveDomainState(domainName string, path string) error {
+	ret := _m.ctrl.Call(_m, "SaveDomainState", domainName, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) SaveDomainState(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SaveDomainState", arg0, arg1)
+}
+
+func (_m *MockConnection) RestoreDomainState(path string, domXML string) error {
+	ret := _m.ctrl.Call(_m, "RestoreDomainState", path, domXML)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) RestoreDomainState(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RestoreDomainState", arg0, arg1)
+}
+
+func (_m *MockConnection) BeginDomainBackup(domainName string, backupXML string, checkpointXML string) error {
+	ret := _m.ctrl.Call(_m, "BeginDomainBackup", domainName, backupXML, checkpointXML)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) BeginDomainBackup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BeginDomainBackup", arg0, arg1, arg2)
+}
+
+func (_m *MockConnection) GetDomainCheckpointXML(domainName string, checkpointName string) (string, error) {
+	ret := _m.ctrl.Call(_m, "GetDomainCheckpointXML", domainName, checkpointName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockConnectionRecorder) GetDomainCheckpointXML(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDomainCheckpointXML", arg0, arg1)
+}
+
+func (_m *MockConnection) ListDomainCheckpointNames(domainName string) ([]string, error) {
+	ret := _m.ctrl.Call(_m, "ListDomainCheckpointNames", domainName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockConnectionRecorder) ListDomainCheckpointNames(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ListDomainCheckpointNames", arg0)
+}
+
+func (_m *MockConnection) DeleteDomainCheckpoint(domainName string, checkpointName string) error {
+	ret := _m.ctrl.Call(_m, "DeleteDomainCheckpoint", domainName, checkpointName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) DeleteDomainCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteDomainCheckpoint", arg0, arg1)
+}
+
+func (_m *MockConnection) RedefineDomainCheckpoint(domainName string, checkpointXML string) error {
+	ret := _m.ctrl.Call(_m, "RedefineDomainCheckpoint", domainName, checkpointXML)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) RedefineDomainCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RedefineDomainCheckpoint", arg0, arg1)
+}
+
 // Mock of Stream interface
 type MockStream struct {
 	ctrl     *gomock.Controller
@@ -65,6 +65,21 @@ type Connection interface {
 	GetDomainStats(statsTypes libvirt.DomainStatsTypes, l *stats.DomainJobInfo, flags libvirt.ConnectGetAllDomainStatsFlags) ([]*stats.DomainStats, error)
 	GetQemuVersion() (string, error)
 	GetSEVInfo() (*api.SEVNodeParameters, error)
+	// SaveDomainState saves the memory and device state of the paused domain to path, the domain keeps existing
+	SaveDomainState(domainName string, path string) error
+	// RestoreDomainState starts the defined domain from the state saved by SaveDomainState at path
+	RestoreDomainState(path string, domXML string) error
+	// BeginDomainBackup starts the backup job described by backupXML and creates the checkpoint described by
+	// checkpointXML, if any, at the point in time the backup is taken
+	BeginDomainBackup(domainName string, backupXML string, checkpointXML string) error
+	// GetDomainCheckpointXML returns the description of a checkpoint of the domain, without the domain definition
+	GetDomainCheckpointXML(domainName string, checkpointName string) (string, error)
+	// ListDomainCheckpointNames returns the names of the checkpoints of the domain, parents before their children
+	ListDomainCheckpointNames(domainName string) ([]string, error)
+	// DeleteDomainCheckpoint deletes a checkpoint of the domain, its dirty bitmaps are merged into its parent
+	DeleteDomainCheckpoint(domainName string, checkpointName string) error
+	// RedefineDomainCheckpoint restores the metadata of a checkpoint whose dirty bitmaps are kept in the disks
+	RedefineDomainCheckpoint(domainName string, checkpointXML string) error
 }
 
 type Stream interface {
@@ -342,6 +357,192 @@ func (l *LibvirtConnection) GetDomainStats(statsTypes libvirt.DomainStatsTypes,
 	return list, nil
 }
 
+type memoryStateSnapshot struct {
+	XMLName xml.Name                  `xml:"domainsnapshot"`
+	Memory  memoryStateSnapshotMemory `xml:"memory"`
+	Disks   []memoryStateSnapshotDisk `xml:"disks>disk"`
+}
+
+type memoryStateSnapshotMemory struct {
+	Snapshot string `xml:"snapshot,attr"`
+	File     string `xml:"file,attr"`
+}
+
+type memoryStateSnapshotDisk struct {
+	Name     string `xml:"name,attr"`
+	Snapshot string `xml:"snapshot,attr"`
+}
+
+// SaveDomainState takes an external memory only snapshot of the domain, which writes the same image as
+// virDomainSave without stopping the domain. The disks are excluded, they are snapshotted by the storage.
+func (l *LibvirtConnection) SaveDomainState(domainName string, path string) error {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return err
+	}
+
+	dom, err := l.Connect.LookupDomainByName(domainName)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return err
+	}
+	defer dom.Free()
+
+	domXML, err := dom.GetXMLDesc(0)
+	if err != nil {
+		return err
+	}
+	domSpec := &api.DomainSpec{}
+	if err := xml.Unmarshal([]byte(domXML), domSpec); err != nil {
+		return err
+	}
+
+	snapshot := memoryStateSnapshot{
+		Memory: memoryStateSnapshotMemory{Snapshot: "external", File: path},
+	}
+	for _, disk := range domSpec.Devices.Disks {
+		snapshot.Disks = append(snapshot.Disks, memoryStateSnapshotDisk{Name: disk.Target.Device, Snapshot: "no"})
+	}
+	snapshotXML, err := xml.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	domSnapshot, err := dom.CreateSnapshotXML(string(snapshotXML), libvirt.DOMAIN_SNAPSHOT_CREATE_NO_METADATA)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return err
+	}
+	return domSnapshot.Free()
+}
+
+func (l *LibvirtConnection) RestoreDomainState(path string, domXML string) error {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return err
+	}
+
+	err := l.Connect.DomainRestoreFlags(path, domXML, 0)
+	l.checkConnectionLost(err)
+	return err
+}
+
+func (l *LibvirtConnection) BeginDomainBackup(domainName string, backupXML string, checkpointXML string) error {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return err
+	}
+
+	dom, err := l.Connect.LookupDomainByName(domainName)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return err
+	}
+	defer dom.Free()
+
+	err = dom.BackupBegin(backupXML, checkpointXML, 0)
+	l.checkConnectionLost(err)
+	return err
+}
+
+func (l *LibvirtConnection) GetDomainCheckpointXML(domainName string, checkpointName string) (string, error) {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return "", err
+	}
+
+	dom, err := l.Connect.LookupDomainByName(domainName)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return "", err
+	}
+	defer dom.Free()
+
+	checkpoint, err := dom.CheckpointLookupByName(checkpointName, 0)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return "", err
+	}
+	defer checkpoint.Free()
+
+	return checkpoint.GetXMLDesc(libvirt.DOMAIN_CHECKPOINT_XML_NO_DOMAIN)
+}
+
+func (l *LibvirtConnection) ListDomainCheckpointNames(domainName string) ([]string, error) {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return nil, err
+	}
+
+	dom, err := l.Connect.LookupDomainByName(domainName)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return nil, err
+	}
+	defer dom.Free()
+
+	checkpoints, err := dom.ListAllCheckpoints(libvirt.DOMAIN_CHECKPOINT_LIST_TOPOLOGICAL)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return nil, err
+	}
+	defer func() {
+		for i := range checkpoints {
+			checkpoints[i].Free()
+		}
+	}()
+
+	names := make([]string, 0, len(checkpoints))
+	for i := range checkpoints {
+		name, err := checkpoints[i].GetName()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (l *LibvirtConnection) DeleteDomainCheckpoint(domainName string, checkpointName string) error {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return err
+	}
+
+	dom, err := l.Connect.LookupDomainByName(domainName)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return err
+	}
+	defer dom.Free()
+
+	checkpoint, err := dom.CheckpointLookupByName(checkpointName, 0)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return err
+	}
+	defer checkpoint.Free()
+
+	err = checkpoint.Delete(0)
+	l.checkConnectionLost(err)
+	return err
+}
+
+func (l *LibvirtConnection) RedefineDomainCheckpoint(domainName string, checkpointXML string) error {
+	if err := l.reconnectIfNecessary(); err != nil {
+		return err
+	}
+
+	dom, err := l.Connect.LookupDomainByName(domainName)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return err
+	}
+	defer dom.Free()
+
+	// validating makes sure the bitmaps of the checkpoint are still in the disks
+	checkpoint, err := dom.CreateCheckpointXML(checkpointXML, libvirt.DOMAIN_CHECKPOINT_CREATE_REDEFINE|libvirt.DOMAIN_CHECKPOINT_CREATE_REDEFINE_VALIDATE)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return err
+	}
+	return checkpoint.Free()
+}
+
 func (l *LibvirtConnection) GetSEVInfo() (*api.SEVNodeParameters, error) {
 	const flags = uint32(0)
 	params, err := l.Connect.GetSEVInfo(flags)
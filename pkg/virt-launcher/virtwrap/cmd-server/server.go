@@ -249,7 +249,7 @@ func (l *Launcher) VirtualMachineMemoryDump(_ context.Context, request *cmdv1.Me
 		return response, nil
 	}
 
-	if err := l.domainManager.MemoryDump(vmi, request.DumpPath); err != nil {
+	if err := l.domainManager.MemoryDump(vmi, request.DumpPath, v1.MemoryDumpType(request.Type)); err != nil {
 		log.Log.Object(vmi).Reason(err).Errorf("Failed to Dump vmi memory")
 		response.Success = false
 		response.Message = getErrorMessage(err)
@@ -259,6 +259,29 @@ func (l *Launcher) VirtualMachineMemoryDump(_ context.Context, request *cmdv1.Me
 	return response, nil
 }
 
+func (l *Launcher) VirtualMachineBackup(_ context.Context, request *cmdv1.BackupRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	var volume v1.BackupVolumeSource
+	if err := json.Unmarshal(request.Options, &volume); err != nil {
+		response.Success = false
+		response.Message = "No valid backup volume present in command server request"
+		return response, nil
+	}
+
+	if err := l.domainManager.BackupVirtualMachine(vmi, request.BackupPath, &volume); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to back up vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	return response, nil
+}
+
 func (l *Launcher) FreezeVirtualMachine(_ context.Context, request *cmdv1.FreezeRequest) (*cmdv1.Response, error) {
 	vmi, response := getVMIFromRequest(request.Vmi)
 	if !response.Success {
@@ -123,8 +123,16 @@ var _ = Describe("Virt remote commands", func() {
 		It("should call memory dump", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			dumpPath := "path/to/dump/volMem"
-			domainManager.EXPECT().MemoryDump(vmi, dumpPath)
-			err := client.VirtualMachineMemoryDump(vmi, dumpPath)
+			domainManager.EXPECT().MemoryDump(vmi, dumpPath, v1.MemoryDumpType(""))
+			err := client.VirtualMachineMemoryDump(vmi, dumpPath, "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should call memory dump saving the vmi state", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			dumpPath := "path/to/dump/volMem"
+			domainManager.EXPECT().MemoryDump(vmi, dumpPath, v1.MemoryDumpTypeState)
+			err := client.VirtualMachineMemoryDump(vmi, dumpPath, v1.MemoryDumpTypeState)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1211,7 +1211,9 @@ func Convert_v1_Firmware_To_related_apis(vmi *v1.VirtualMachineInstance, domain
 		},
 	}
 
-	if util.IsEFIVMI(vmi) {
+	// Hypervisors loading their own firmware as kernel have no pflash variable store, persistent EFI is
+	// rejected for them at admission
+	if util.IsEFIVMI(vmi) && c.EFIConfiguration != nil {
 		domain.Spec.OS.BootLoader = &api.Loader{
 			Path:     c.EFIConfiguration.EFICode,
 			ReadOnly: "yes",
@@ -1255,7 +1257,7 @@ func Convert_v1_Firmware_To_related_apis(vmi *v1.VirtualMachineInstance, domain
 			log.Log.Object(vmi).Infof("setting initrd path for kernel boot: " + initrdPath)
 			domain.Spec.OS.Initrd = initrdPath
 		}
-	} else if defaultKernelPath, defaultInitrdPath := c.Hypervisor.GetDefaultKernelPath(); defaultKernelPath != "" {
+	} else if defaultKernelPath, defaultInitrdPath := c.Hypervisor.GetDefaultKernelPath(firmware.Bootloader); defaultKernelPath != "" {
 		domain.Spec.OS.Kernel = defaultKernelPath
 		if defaultInitrdPath != "" {
 			domain.Spec.OS.Initrd = defaultInitrdPath
@@ -55,6 +55,7 @@ import (
 	kvapi "kubevirt.io/client-go/api"
 
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	kubevirtpointer "kubevirt.io/kubevirt/pkg/pointer"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	sev "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/launchsecurity"
@@ -2576,6 +2577,35 @@ var _ = Describe("Converter", func() {
 			Entry("VGA with EFI and BochsDisplayForEFIGuests unset", v1.Bootloader{EFI: &v1.EFI{}}, false, "vga"),
 			Entry("Bochs with EFI and BochsDisplayForEFIGuests set", v1.Bootloader{EFI: &v1.EFI{}}, true, "bochs"),
 		)
+
+		DescribeTable("with Cloud Hypervisor should load the firmware as kernel", func(bootloader *v1.Bootloader, expectedKernel string) {
+			vmi.Spec.Hypervisor = "ch"
+			vmi.Spec.Domain.Firmware = &v1.Firmware{Bootloader: bootloader}
+			c.Hypervisor = hypervisor.NewHypervisor("ch")
+
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.OS.Kernel).To(Equal(expectedKernel))
+			Expect(domainSpec.OS.BootLoader).To(BeNil())
+			Expect(domainSpec.OS.NVRam).To(BeNil())
+		},
+			Entry("OVMF without bootloader", nil, "/usr/share/cloud-hypervisor/CLOUDHV_EFI.fd"),
+			Entry("OVMF with EFI", &v1.Bootloader{EFI: &v1.EFI{SecureBoot: False()}}, "/usr/share/cloud-hypervisor/CLOUDHV_EFI.fd"),
+			Entry("rust-hypervisor-firmware with BIOS", &v1.Bootloader{BIOS: &v1.BIOS{}}, "/usr/share/cloud-hypervisor/hypervisor-fw"),
+		)
+
+		It("with Cloud Hypervisor should prefer the kernel boot container over the firmware", func() {
+			vmi.Spec.Hypervisor = "ch"
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{BIOS: &v1.BIOS{}},
+				KernelBoot: &v1.KernelBoot{
+					Container: &v1.KernelBootContainer{Image: "kernel-image", KernelPath: "/boot/vmlinuz"},
+				},
+			}
+			c.Hypervisor = hypervisor.NewHypervisor("ch")
+
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.OS.Kernel).To(Equal("/var/run/kubevirt/container-disks/kernel-boot/vmlinuz"))
+		})
 	})
 
 	Context("Kernel Boot", func() {
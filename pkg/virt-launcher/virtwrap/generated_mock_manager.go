@@ -276,14 +276,24 @@ func (_mr *_MockDomainManagerRecorder) GuestPing(arg0 interface{}) *gomock.Call
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestPing", arg0)
 }
 
-func (_m *MockDomainManager) MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error {
-	ret := _m.ctrl.Call(_m, "MemoryDump", vmi, dumpPath)
+func (_m *MockDomainManager) MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string, dumpType v1.MemoryDumpType) error {
+	ret := _m.ctrl.Call(_m, "MemoryDump", vmi, dumpPath, dumpType)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockDomainManagerRecorder) MemoryDump(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "MemoryDump", arg0, arg1)
+func (_mr *_MockDomainManagerRecorder) MemoryDump(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MemoryDump", arg0, arg1, arg2)
+}
+
+func (_m *MockDomainManager) BackupVirtualMachine(vmi *v1.VirtualMachineInstance, backupPath string, volume *v1.BackupVolumeSource) error {
+	ret := _m.ctrl.Call(_m, "BackupVirtualMachine", vmi, backupPath, volume)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) BackupVirtualMachine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BackupVirtualMachine", arg0, arg1, arg2)
 }
 
 func (_m *MockDomainManager) GetQemuVersion() (string, error) {
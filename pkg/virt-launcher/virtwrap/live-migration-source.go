@@ -30,6 +30,7 @@ import (
 	"libvirt.org/go/libvirtxml"
 
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	"kubevirt.io/kubevirt/pkg/util/migrations"
 
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
@@ -85,6 +86,10 @@ type migrationMonitor struct {
 	lastProgressUpdate int64
 	progressWatermark  uint64
 	remainingData      uint64
+	processedData      uint64
+
+	// hypervisors which don't report the remaining data are considered progressing while data is transferred
+	reportsRemainingData bool
 
 	progressTimeout          int64
 	acceptableCompletionTime int64
@@ -122,6 +127,43 @@ func generateMigrationFlags(isBlockMigration, migratePaused bool, options *cmdcl
 
 }
 
+// getMigrationBackend returns the name of the hypervisor backend running the VMI. VMIs which
+// were created before the hypervisor was defaulted are run by QEMU.
+func getMigrationBackend(vmi *v1.VirtualMachineInstance) string {
+	if _, exists := hypervisor.Lookup(vmi.Spec.Hypervisor); exists {
+		return vmi.Spec.Hypervisor
+	}
+	return "qemu"
+}
+
+// getMigrationHypervisor returns the hypervisor running the VMI
+func getMigrationHypervisor(vmi *v1.VirtualMachineInstance) hypervisor.Hypervisor {
+	return hypervisor.NewHypervisorWithUser(getMigrationBackend(vmi), virtutil.IsNonRootVMI(vmi))
+}
+
+// filterMigrationOptions drops the migration options which are not supported by the
+// hypervisor, so that libvirt does not reject the whole migration because of them.
+func filterMigrationOptions(vmi *v1.VirtualMachineInstance, options *cmdclient.MigrationOptions) *cmdclient.MigrationOptions {
+	logger := log.Log.Object(vmi)
+	capabilities, _ := hypervisor.GetCapabilities(getMigrationBackend(vmi))
+	filtered := *options
+
+	if filtered.AllowPostCopy && !capabilities.PostCopyMigration {
+		logger.Warningf("Post-copy migration is not supported by hypervisor %s, ignoring it", vmi.Spec.Hypervisor)
+		filtered.AllowPostCopy = false
+	}
+	if filtered.AllowAutoConverge && !capabilities.AutoConvergeMigration {
+		logger.Warningf("Auto-converge migration is not supported by hypervisor %s, ignoring it", vmi.Spec.Hypervisor)
+		filtered.AllowAutoConverge = false
+	}
+	if filtered.ParallelMigrationThreads != nil && !capabilities.ParallelMigration {
+		logger.Warningf("Parallel migration is not supported by hypervisor %s, ignoring it", vmi.Spec.Hypervisor)
+		filtered.ParallelMigrationThreads = nil
+	}
+
+	return &filtered
+}
+
 func hotUnplugHostDevices(virConn cli.Connection, dom cli.VirDomain) error {
 	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
 	if err != nil {
@@ -431,6 +473,9 @@ func newMigrationMonitor(vmi *v1.VirtualMachineInstance, l *LibvirtDomainManager
 		progressTimeout:          options.ProgressTimeout,
 		acceptableCompletionTime: options.CompletionTimeoutPerGiB * getVMIMigrationDataSize(vmi, l.ephemeralDiskDir),
 	}
+	if capabilities, exists := hypervisor.GetCapabilities(getMigrationBackend(vmi)); !exists || capabilities.MigrationProgress {
+		monitor.reportsRemainingData = true
+	}
 
 	return monitor
 }
@@ -516,10 +561,17 @@ func (m *migrationMonitor) processInflightMigration(dom cli.VirDomain, stats *li
 	elapsed := now - m.start
 
 	m.l.migrateInfoStats = statsconv.Convert_libvirt_DomainJobInfo_To_stats_DomainJobInfo(stats)
-	if (m.progressWatermark == 0) || (m.remainingData < m.progressWatermark) {
-		m.lastProgressUpdate = now
+	if m.reportsRemainingData {
+		if (m.progressWatermark == 0) || (m.remainingData < m.progressWatermark) {
+			m.lastProgressUpdate = now
+		}
+		m.progressWatermark = m.remainingData
+	} else {
+		if m.processedData > m.progressWatermark {
+			m.lastProgressUpdate = now
+		}
+		m.progressWatermark = m.processedData
 	}
-	m.progressWatermark = m.remainingData
 
 	switch {
 	case m.isMigrationPostCopy():
@@ -641,6 +693,9 @@ func (m *migrationMonitor) startMonitor() {
 		if stats.DataRemainingSet {
 			m.remainingData = stats.DataRemaining
 		}
+		if stats.DataProcessedSet {
+			m.processedData = stats.DataProcessed
+		}
 
 		switch stats.Type {
 		case libvirt.DOMAIN_JOB_UNBOUNDED:
@@ -965,12 +1020,7 @@ func (l *LibvirtDomainManager) migrateHelper(vmi *v1.VirtualMachineInstance, opt
 	}
 
 	// initiate the live migration
-	var dstURI string
-	if virtutil.IsNonRootVMI(vmi) {
-		dstURI = fmt.Sprintf("qemu+unix:///session?socket=%s", migrationproxy.SourceUnixFile(l.virtShareDir, string(vmi.UID)))
-	} else {
-		dstURI = fmt.Sprintf("qemu+unix:///system?socket=%s", migrationproxy.SourceUnixFile(l.virtShareDir, string(vmi.UID)))
-	}
+	dstURI := getMigrationHypervisor(vmi).GetMigrationUri(migrationproxy.SourceUnixFile(l.virtShareDir, string(vmi.UID)))
 
 	err = dom.MigrateToURI3(dstURI, params, migrateFlags)
 	if err != nil {
@@ -1009,6 +1059,7 @@ func (l *LibvirtDomainManager) migrate(vmi *v1.VirtualMachineInstance, options *
 	if options.UnsafeMigration {
 		log.Log.Object(vmi).Info("UNSAFE_MIGRATION flag is set, libvirt's migration checks will be disabled!")
 	}
+	options = filterMigrationOptions(vmi, options)
 
 	// From here on out, any error encountered must be sent to the
 	// migrationError channel which is processed by the liveMigrationMonitor
@@ -53,6 +53,18 @@ func (l *LibvirtDomainManager) finalizeMigrationTarget(vmi *v1.VirtualMachineIns
 		return err
 	}
 
+	// libvirt does not migrate checkpoints, only the bitmaps in the images
+	dom, err := l.virConn.LookupDomainByName(api.VMINamespaceKeyFunc(vmi))
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+	domSpec, err := getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+	l.redefineCheckpoints(vmi, domSpec)
+
 	return nil
 }
 
@@ -45,6 +45,7 @@ import (
 
 	"k8s.io/utils/pointer"
 
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/hypervisor"
 	"kubevirt.io/kubevirt/pkg/liveupdate/memory"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/generic"
@@ -107,6 +108,10 @@ const (
 
 const maxConcurrentHotplugHostDevices = 1
 const maxConcurrentMemoryDumps = 1
+const maxConcurrentBackups = 1
+
+// memoryStateRestoredSuffix is appended to a saved state once the domain was restored from it
+const memoryStateRestoredSuffix = ".restored"
 
 type contextStore struct {
 	ctx    context.Context
@@ -138,7 +143,8 @@ type DomainManager interface {
 	GetGuestOSInfo() *api.GuestOSInfo
 	Exec(string, string, []string, int32) (string, error)
 	GuestPing(string) error
-	MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error
+	MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string, dumpType v1.MemoryDumpType) error
+	BackupVirtualMachine(vmi *v1.VirtualMachineInstance, backupPath string, volume *v1.BackupVolumeSource) error
 	GetQemuVersion() (string, error)
 	UpdateVCPUs(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
 	GetSEVInfo() (*v1.SEVPlatformInfo, error)
@@ -159,6 +165,7 @@ type LibvirtDomainManager struct {
 
 	hotplugHostDevicesInProgress chan struct{}
 	memoryDumpInProgress         chan struct{}
+	backupInProgress             chan struct{}
 
 	virtShareDir             string
 	ephemeralDiskDir         string
@@ -226,6 +233,7 @@ func newLibvirtDomainManager(connection cli.Connection, virtShareDir, ephemeralD
 
 	manager.hotplugHostDevicesInProgress = make(chan struct{}, maxConcurrentHotplugHostDevices)
 	manager.memoryDumpInProgress = make(chan struct{}, maxConcurrentMemoryDumps)
+	manager.backupInProgress = make(chan struct{}, maxConcurrentBackups)
 	manager.credManager = accesscredentials.NewManager(connection, &manager.domainModifyLock, metadataCache)
 
 	reCalcDomainStats := func() (*stats.DomainStats, error) {
@@ -971,8 +979,9 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 		}
 	}
 
+	hv := hypervisor.NewHypervisor(vmi.Spec.Hypervisor)
 	var efiConf *converter.EFIConfiguration
-	if vmi.IsBootloaderEFI() {
+	if vmi.IsBootloaderEFI() && !hypervisor.LoadsOwnFirmware(hv, vmi) {
 		secureBoot := vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot
 		sev := kutil.IsSEVVMI(vmi)
 
@@ -1003,7 +1012,7 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 		UseLaunchSecurity:     kutil.IsSEVVMI(vmi),
 		FreePageReporting:     isFreePageReportingEnabled(false, vmi),
 		SerialConsoleLog:      isSerialConsoleLogEnabled(false, vmi),
-		Hypervisor:            hypervisor.NewHypervisor(vmi.Spec.Hypervisor),
+		Hypervisor:            hv,
 	}
 
 	if options != nil {
@@ -1114,6 +1123,7 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		if err := l.startDomain(vmi, dom); err != nil {
 			return nil, err
 		}
+		l.redefineCheckpoints(vmi, &domain.Spec)
 	case cli.IsPaused(domState) && !l.paused.contains(vmi.UID):
 		// TODO: if state change reason indicates a system error, we could try something smarter
 		if err := dom.Resume(); err != nil {
@@ -1248,6 +1258,14 @@ func (l *LibvirtDomainManager) startDomain(
 		return err
 	}
 
+	statePath, err := memoryStateToRestore(vmi)
+	if err != nil {
+		return err
+	}
+	if statePath != "" {
+		return l.restoreDomain(vmi, dom, statePath)
+	}
+
 	createFlags := getDomainCreateFlags(vmi)
 	if err := dom.CreateWithFlags(createFlags); err != nil {
 		logger.Reason(err).
@@ -1471,8 +1489,9 @@ func removePreviousMemoryDump(dir string) {
 		return
 	}
 	for _, file := range files {
-		if strings.Contains(file.Name(), "memory.dump") {
-			err = os.Remove(filepath.Join(dir, file.Name()))
+		if strings.Contains(file.Name(), "memory.dump") || strings.HasSuffix(file.Name(), api.MemoryStateFileExtension) {
+			// the saved state of some hypervisors is a directory
+			err = os.RemoveAll(filepath.Join(dir, file.Name()))
 			if err != nil {
 				log.Log.Reason(err).Errorf("failed to remove older memory dumps")
 			}
@@ -1480,7 +1499,7 @@ func removePreviousMemoryDump(dir string) {
 	}
 }
 
-func (l *LibvirtDomainManager) MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error {
+func (l *LibvirtDomainManager) MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string, dumpType v1.MemoryDumpType) error {
 	select {
 	case l.memoryDumpInProgress <- struct{}{}:
 	default:
@@ -1490,14 +1509,14 @@ func (l *LibvirtDomainManager) MemoryDump(vmi *v1.VirtualMachineInstance, dumpPa
 
 	go func() {
 		defer func() { <-l.memoryDumpInProgress }()
-		if err := l.memoryDump(vmi, dumpPath); err != nil {
+		if err := l.memoryDump(vmi, dumpPath, dumpType); err != nil {
 			log.Log.Object(vmi).Reason(err).Error(failedDomainMemoryDump)
 		}
 	}()
 	return nil
 }
 
-func (l *LibvirtDomainManager) memoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error {
+func (l *LibvirtDomainManager) memoryDump(vmi *v1.VirtualMachineInstance, dumpPath string, dumpType v1.MemoryDumpType) error {
 	logger := log.Log.Object(vmi)
 
 	if l.shouldSkipMemoryDump(dumpPath) {
@@ -1517,7 +1536,11 @@ func (l *LibvirtDomainManager) memoryDump(vmi *v1.VirtualMachineInstance, dumpPa
 	logger.Infof("Starting memory dump")
 	failed := false
 	reason := ""
-	err = dom.CoreDumpWithFormat(dumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY)
+	if dumpType == v1.MemoryDumpTypeState {
+		err = l.saveDomainState(dom, domName, dumpPath)
+	} else {
+		err = dom.CoreDumpWithFormat(dumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY)
+	}
 	if err != nil {
 		failed = true
 		reason = fmt.Sprintf("%s: %s", failedDomainMemoryDump, err)
@@ -1529,6 +1552,65 @@ func (l *LibvirtDomainManager) memoryDump(vmi *v1.VirtualMachineInstance, dumpPa
 	return err
 }
 
+// saveDomainState saves the memory and device state of the domain, which has to be paused
+// so that the state matches the volumes snapshotted next to it
+func (l *LibvirtDomainManager) saveDomainState(dom cli.VirDomain, domName string, statePath string) error {
+	domState, _, err := dom.GetState()
+	if err != nil {
+		return err
+	}
+	if !cli.IsPaused(domState) {
+		return fmt.Errorf("the domain has to be paused to save its state")
+	}
+	return l.virConn.SaveDomainState(domName, statePath)
+}
+
+// memoryStateToRestore returns the path of the saved state the vmi is started from, if any
+func memoryStateToRestore(vmi *v1.VirtualMachineInstance) (string, error) {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.MemoryDump == nil || volume.MemoryDump.Hotpluggable || volume.MemoryDump.Type != v1.MemoryDumpTypeState {
+			continue
+		}
+		dir := hostdisk.GetMountedHostDiskDir(volume.Name)
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file.Name(), api.MemoryStateFileExtension) {
+				return filepath.Join(dir, file.Name()), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// restoreDomain starts the domain from its saved state. The state is renamed afterwards,
+// further starts of the vmi boot the guest from its disks.
+func (l *LibvirtDomainManager) restoreDomain(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, statePath string) error {
+	logger := log.Log.Object(vmi)
+
+	domXML, err := dom.GetXMLDesc(0)
+	if err != nil {
+		return err
+	}
+	if err := l.virConn.RestoreDomainState(statePath, domXML); err != nil {
+		logger.Reason(err).Errorf("Failed to restore VirtualMachineInstance from %s.", statePath)
+		return err
+	}
+	if err := os.Rename(statePath, statePath+memoryStateRestoredSuffix); err != nil {
+		logger.Reason(err).Warningf("Failed to mark %s as restored", statePath)
+	}
+	logger.Infof("Domain restored from %s.", statePath)
+
+	if vmi.ShouldStartPaused() {
+		l.paused.add(vmi.UID)
+		return nil
+	}
+	// the state was saved while the domain was paused
+	return dom.Resume()
+}
+
 func (l *LibvirtDomainManager) shouldSkipMemoryDump(dumpPath string) bool {
 	memoryDumpMetadata, _ := l.metadataCache.MemoryDump.Load()
 	if memoryDumpMetadata.FileName == filepath.Base(dumpPath) {
@@ -20,6 +20,7 @@
 package virtwrap
 
 import (
+	"bytes"
 	"crypto/sha256"
 	_ "embed"
 	"encoding/base64"
@@ -60,6 +61,8 @@ import (
 	"kubevirt.io/kubevirt/pkg/ephemeral-disk/fake"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	virtpointer "kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/storage/backup"
+	"kubevirt.io/kubevirt/pkg/storage/nbd"
 	"kubevirt.io/kubevirt/pkg/util/net/ip"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/metadata"
@@ -649,9 +652,9 @@ var _ = Describe("Manager", func() {
 			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
 
 			vmi := newVMI(testNamespace, testVmName)
-			Expect(manager.MemoryDump(vmi, testDumpPath)).To(Succeed())
+			Expect(manager.MemoryDump(vmi, testDumpPath, v1.MemoryDumpTypeCore)).To(Succeed())
 			// Expect extra call to memory dump not to impact
-			Expect(manager.MemoryDump(vmi, testDumpPath)).To(Succeed())
+			Expect(manager.MemoryDump(vmi, testDumpPath, v1.MemoryDumpTypeCore)).To(Succeed())
 
 			Eventually(func() bool {
 				memoryDump, _ := metadataCache.MemoryDump.Load()
@@ -665,9 +668,9 @@ var _ = Describe("Manager", func() {
 			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
 
 			vmi := newVMI(testNamespace, testVmName)
-			Expect(manager.MemoryDump(vmi, testDumpPath)).To(Succeed())
+			Expect(manager.MemoryDump(vmi, testDumpPath, v1.MemoryDumpTypeCore)).To(Succeed())
 			// Expect extra call to memory dump not to impact
-			Expect(manager.MemoryDump(vmi, testDumpPath)).To(Succeed())
+			Expect(manager.MemoryDump(vmi, testDumpPath, v1.MemoryDumpTypeCore)).To(Succeed())
 
 			Eventually(func() bool {
 				memoryDump, _ := metadataCache.MemoryDump.Load()
@@ -675,7 +678,7 @@ var _ = Describe("Manager", func() {
 			}, 5*time.Second, 2).Should(BeTrue())
 			// Expect extra call to memory dump after completion
 			// not to call core dump command again
-			Expect(manager.MemoryDump(vmi, testDumpPath)).To(Succeed())
+			Expect(manager.MemoryDump(vmi, testDumpPath, v1.MemoryDumpTypeCore)).To(Succeed())
 		})
 		It("should update domain with memory dump info if memory dump failed", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
@@ -685,13 +688,252 @@ var _ = Describe("Manager", func() {
 			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
 
 			vmi := newVMI(testNamespace, testVmName)
-			err := manager.MemoryDump(vmi, testDumpPath)
+			err := manager.MemoryDump(vmi, testDumpPath, v1.MemoryDumpTypeCore)
 			Expect(err).ToNot(HaveOccurred())
 			Eventually(func() bool {
 				memoryDump, _ := metadataCache.MemoryDump.Load()
 				return memoryDump.Failed
 			}, 5*time.Second).Should(BeTrue(), "failed memory dump result wasn't set")
 		})
+		It("should save the state of a paused VirtualMachineInstance", func() {
+			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
+			mockConn.EXPECT().SaveDomainState(testDomainName, testDumpPath).Return(nil)
+
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+			vmi := newVMI(testNamespace, testVmName)
+			Expect(manager.MemoryDump(vmi, testDumpPath, v1.MemoryDumpTypeState)).To(Succeed())
+			Eventually(func() bool {
+				memoryDump, _ := metadataCache.MemoryDump.Load()
+				return memoryDump.Completed && !memoryDump.Failed
+			}, 5*time.Second).Should(BeTrue())
+		})
+		It("should fail to save the state of a running VirtualMachineInstance", func() {
+			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+			vmi := newVMI(testNamespace, testVmName)
+			Expect(manager.MemoryDump(vmi, testDumpPath, v1.MemoryDumpTypeState)).To(Succeed())
+			Eventually(func() bool {
+				memoryDump, _ := metadataCache.MemoryDump.Load()
+				return memoryDump.Failed
+			}, 5*time.Second).Should(BeTrue(), "failed state save result wasn't set")
+		})
+		Context("backup", func() {
+			const checkpointXML = `<domaincheckpoint><name>testvm-checkpoint</name><disks><disk name="vda" checkpoint="bitmap"/></disks></domaincheckpoint>`
+			var backupPath string
+			var diskImage string
+			var disk []byte
+			var exportExtents map[string][]nbd.Extent
+
+			newBackupVMI := func() *v1.VirtualMachineInstance {
+				vmi := newVMI(testNamespace, testVmName)
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc0"},
+						},
+					},
+				})
+				return vmi
+			}
+
+			newBackupDomainSpec := func() *api.DomainSpec {
+				domSpec := &api.DomainSpec{}
+				domSpec.Devices.Disks = []api.Disk{{
+					Device: "disk",
+					Driver: &api.DiskDriver{Type: "qcow2"},
+					Source: api.DiskSource{File: diskImage},
+					Target: api.DiskTarget{Device: "vda"},
+					Alias:  api.NewUserDefinedAlias("disk0"),
+				}}
+				return domSpec
+			}
+
+			expectBackupJob := func(expectedBackupXML, expectedCheckpointXML string) {
+				domXML, err := xml.Marshal(newBackupDomainSpec())
+				Expect(err).ToNot(HaveOccurred())
+
+				mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(domXML), nil)
+				mockConn.EXPECT().BeginDomainBackup(testDomainName, expectedBackupXML, expectedCheckpointXML).Return(nil)
+				mockDomain.EXPECT().AbortJob().Return(nil)
+			}
+
+			expectCheckpointsPruned := func() {
+				mockConn.EXPECT().GetDomainCheckpointXML(testDomainName, "testvm-checkpoint").Return(checkpointXML, nil)
+				mockConn.EXPECT().ListDomainCheckpointNames(testDomainName).Return([]string{"testvm-parent", "testvm-checkpoint"}, nil)
+				mockConn.EXPECT().DeleteDomainCheckpoint(testDomainName, "testvm-parent").Return(nil)
+			}
+
+			waitForBackup := func() {
+				Eventually(func() bool {
+					backupMetadata, _ := metadataCache.Backup.Load()
+					return backupMetadata.Completed
+				}, 5*time.Second).Should(BeTrue())
+				backupMetadata, _ := metadataCache.Backup.Load()
+				Expect(backupMetadata.Failed).To(BeFalse(), backupMetadata.FailureReason)
+			}
+
+			BeforeEach(func() {
+				backupPath = filepath.Join(GinkgoT().TempDir(), "testvmi-disk0.backup")
+				diskImage = filepath.Join(GinkgoT().TempDir(), "disk.img")
+				disk = bytes.Repeat([]byte{0xab}, 4096)
+				exportExtents = map[string][]nbd.Extent{
+					nbd.BaseAllocation: {
+						{Offset: 0, Length: 1024},
+						{Offset: 1024, Length: 2048, Flags: nbd.StateHole | nbd.StateZero},
+						{Offset: 3072, Length: 1024},
+					},
+					nbd.DirtyBitmap("backup-vda"): {
+						{Offset: 0, Length: 2048},
+						{Offset: 2048, Length: 1024, Flags: nbd.StateDirty},
+						{Offset: 3072, Length: 1024},
+					},
+				}
+
+				origDialBackupExport := dialBackupExport
+				DeferCleanup(func() { dialBackupExport = origDialBackupExport })
+				dialBackupExport = func(socket, export, metaContext string) (backupExport, error) {
+					Expect(socket).To(Equal(backupSocket(newBackupVMI())))
+					Expect(export).To(Equal("vda"))
+					if _, ok := exportExtents[metaContext]; !ok {
+						return nil, fmt.Errorf("unknown meta context %s", metaContext)
+					}
+					return &fakeBackupExport{Reader: bytes.NewReader(disk), extents: exportExtents}, nil
+				}
+			})
+
+			It("should take a full backup of the allocated extents and write its manifest", func() {
+				expectBackupJob(
+					`<domainbackup mode="pull"><server transport="unix" socket="`+backupSocket(newBackupVMI())+`"></server><disks><disk name="vda" backup="yes" type="file" backupmode="full" exportname="vda"><driver type="qcow2"></driver><scratch file="`+filepath.Join(backupPath, "disk0.scratch.qcow2")+`"></scratch></disk></disks></domainbackup>`,
+					`<domaincheckpoint><name>testvm-checkpoint</name><disks><disk name="vda" checkpoint="bitmap"></disk></disks></domaincheckpoint>`,
+				)
+				expectCheckpointsPruned()
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				Expect(manager.BackupVirtualMachine(newBackupVMI(), backupPath, &v1.BackupVolumeSource{Checkpoint: "testvm-checkpoint"})).To(Succeed())
+				waitForBackup()
+
+				manifest, err := backup.ReadManifest(backupPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(manifest.Checkpoint).To(Equal("testvm-checkpoint"))
+				Expect(manifest.IncrementalFrom).To(BeEmpty())
+				Expect(manifest.Volumes).To(Equal([]backup.VolumeManifest{{
+					Name:    "disk0",
+					Image:   "disk0.img",
+					Size:    4096,
+					Extents: []backup.Extent{{Offset: 0, Length: 1024}, {Offset: 3072, Length: 1024}},
+				}}))
+
+				image, err := os.ReadFile(filepath.Join(backupPath, "disk0.img"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(image[:1024]).To(Equal(disk[:1024]))
+				Expect(image[1024:3072]).To(Equal(make([]byte, 2048)))
+				Expect(image[3072:]).To(Equal(disk[3072:]))
+
+				storedCheckpoint, err := os.ReadFile(diskImage + checkpointFileExtension)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(storedCheckpoint)).To(Equal(checkpointXML))
+			})
+
+			It("should back up the dirty extents of disks holding the bitmap of the checkpoint", func() {
+				mockConn.EXPECT().GetDomainCheckpointXML(testDomainName, "testvm-parent").Return(
+					`<domaincheckpoint><name>testvm-parent</name><disks><disk name="vda" checkpoint="bitmap"/></disks></domaincheckpoint>`, nil)
+				expectBackupJob(
+					`<domainbackup mode="pull"><server transport="unix" socket="`+backupSocket(newBackupVMI())+`"></server><disks><disk name="vda" backup="yes" type="file" backupmode="incremental" incremental="testvm-parent" exportname="vda" exportbitmap="backup-vda"><driver type="qcow2"></driver><scratch file="`+filepath.Join(backupPath, "disk0.scratch.qcow2")+`"></scratch></disk></disks></domainbackup>`,
+					`<domaincheckpoint><name>testvm-checkpoint</name><disks><disk name="vda" checkpoint="bitmap"></disk></disks></domaincheckpoint>`,
+				)
+				expectCheckpointsPruned()
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				volume := &v1.BackupVolumeSource{Checkpoint: "testvm-checkpoint", IncrementalFrom: virtpointer.P("testvm-parent")}
+				Expect(manager.BackupVirtualMachine(newBackupVMI(), backupPath, volume)).To(Succeed())
+				waitForBackup()
+
+				manifest, err := backup.ReadManifest(backupPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(manifest.IncrementalFrom).To(Equal("testvm-parent"))
+				Expect(manifest.Volumes).To(HaveLen(1))
+				Expect(manifest.Volumes[0].Incremental).To(BeTrue())
+				Expect(manifest.Volumes[0].Extents).To(Equal([]backup.Extent{{Offset: 2048, Length: 1024}}))
+			})
+
+			It("should fall back to a full backup if the checkpoint does not exist", func() {
+				mockConn.EXPECT().GetDomainCheckpointXML(testDomainName, "testvm-parent").Return("", libvirt.Error{Code: libvirt.ERR_NO_DOMAIN_CHECKPOINT})
+				expectBackupJob(
+					`<domainbackup mode="pull"><server transport="unix" socket="`+backupSocket(newBackupVMI())+`"></server><disks><disk name="vda" backup="yes" type="file" backupmode="full" exportname="vda"><driver type="qcow2"></driver><scratch file="`+filepath.Join(backupPath, "disk0.scratch.qcow2")+`"></scratch></disk></disks></domainbackup>`,
+					`<domaincheckpoint><name>testvm-checkpoint</name><disks><disk name="vda" checkpoint="bitmap"></disk></disks></domaincheckpoint>`,
+				)
+				expectCheckpointsPruned()
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				volume := &v1.BackupVolumeSource{Checkpoint: "testvm-checkpoint", IncrementalFrom: virtpointer.P("testvm-parent")}
+				Expect(manager.BackupVirtualMachine(newBackupVMI(), backupPath, volume)).To(Succeed())
+				waitForBackup()
+
+				manifest, err := backup.ReadManifest(backupPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(manifest.IncrementalFrom).To(BeEmpty())
+				Expect(manifest.Volumes[0].Incremental).To(BeFalse())
+			})
+
+			It("should report a failed backup and end the backup job", func() {
+				delete(exportExtents, nbd.BaseAllocation)
+				domXML, err := xml.Marshal(newBackupDomainSpec())
+				Expect(err).ToNot(HaveOccurred())
+				mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(domXML), nil)
+				mockConn.EXPECT().BeginDomainBackup(testDomainName, gomock.Any(), gomock.Any()).Return(nil)
+				mockDomain.EXPECT().AbortJob().Return(nil)
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				Expect(manager.BackupVirtualMachine(newBackupVMI(), backupPath, &v1.BackupVolumeSource{Checkpoint: "testvm-checkpoint"})).To(Succeed())
+				Eventually(func() bool {
+					backupMetadata, _ := metadataCache.Backup.Load()
+					return backupMetadata.Failed
+				}, 5*time.Second).Should(BeTrue(), "failed backup result wasn't set")
+				_, err = backup.ReadManifest(backupPath)
+				Expect(err).To(HaveOccurred())
+			})
+
+			Context("checkpoint redefinition", func() {
+				const storedCheckpointXML = `<domaincheckpoint><name>testvm-checkpoint</name></domaincheckpoint>`
+
+				BeforeEach(func() {
+					Expect(os.WriteFile(diskImage+checkpointFileExtension, []byte(storedCheckpointXML), 0640)).To(Succeed())
+				})
+
+				It("should redefine a stored checkpoint unknown to libvirt", func() {
+					mockConn.EXPECT().ListDomainCheckpointNames(testDomainName).Return(nil, nil)
+					mockConn.EXPECT().RedefineDomainCheckpoint(testDomainName, storedCheckpointXML).Return(nil)
+					manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+					manager.(*LibvirtDomainManager).redefineCheckpoints(newBackupVMI(), newBackupDomainSpec())
+					Expect(diskImage + checkpointFileExtension).To(BeAnExistingFile())
+				})
+
+				It("should not redefine a checkpoint known to libvirt", func() {
+					mockConn.EXPECT().ListDomainCheckpointNames(testDomainName).Return([]string{"testvm-checkpoint"}, nil)
+					manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+					manager.(*LibvirtDomainManager).redefineCheckpoints(newBackupVMI(), newBackupDomainSpec())
+				})
+
+				It("should remove a stored checkpoint which cannot be redefined", func() {
+					mockConn.EXPECT().ListDomainCheckpointNames(testDomainName).Return(nil, nil)
+					mockConn.EXPECT().RedefineDomainCheckpoint(testDomainName, storedCheckpointXML).Return(fmt.Errorf("bitmap not found"))
+					manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+					manager.(*LibvirtDomainManager).redefineCheckpoints(newBackupVMI(), newBackupDomainSpec())
+					Expect(diskImage + checkpointFileExtension).ToNot(BeAnExistingFile())
+				})
+			})
+		})
 		It("should pause a VirtualMachineInstance", func() {
 			vmi := newVMI(testNamespace, testVmName)
 
@@ -1520,6 +1762,41 @@ var _ = Describe("Manager", func() {
 			monitor := newMigrationMonitor(vmi, manager, options, migrationErrorChan)
 			monitor.startMonitor()
 		})
+		It("migration should be canceled if no data is transferred by a hypervisor which does not report the remaining data", func() {
+			migrationErrorChan := make(chan error)
+			defer close(migrationErrorChan)
+			fake_jobinfo := &libvirt.DomainJobInfo{
+				Type:             libvirt.DOMAIN_JOB_UNBOUNDED,
+				DataProcessed:    1024,
+				DataProcessedSet: true,
+			}
+
+			options := &cmdclient.MigrationOptions{
+				Bandwidth:               resource.MustParse("64Mi"),
+				ProgressTimeout:         2,
+				CompletionTimeoutPerGiB: 300,
+			}
+
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Hypervisor = "ch"
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: "111222333",
+			}
+
+			manager := &LibvirtDomainManager{
+				virConn:       mockConn,
+				virtShareDir:  testVirtShareDir,
+				metadataCache: metadataCache,
+			}
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+			mockDomain.EXPECT().GetState().AnyTimes().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().GetJobStats(libvirt.DomainGetJobStatsFlags(0)).AnyTimes().Return(fake_jobinfo, nil)
+			mockDomain.EXPECT().AbortJob()
+
+			monitor := newMigrationMonitor(vmi, manager, options, migrationErrorChan)
+			monitor.startMonitor()
+		})
 		It("migration should be canceled if timeout has been reached", func() {
 			migrationErrorChan := make(chan error)
 			defer close(migrationErrorChan)
@@ -2043,6 +2320,42 @@ var _ = Describe("Manager", func() {
 		Entry("migration with parallel threads", "parallel"),
 	)
 
+	DescribeTable("should filter the migration options not supported by the hypervisor",
+		func(hypervisorName string, expectSupported bool) {
+			var parallelMigrationThreads uint = 8
+			options := &cmdclient.MigrationOptions{
+				ProgressTimeout:          150,
+				UnsafeMigration:          true,
+				AllowAutoConverge:        true,
+				AllowPostCopy:            true,
+				ParallelMigrationThreads: &parallelMigrationThreads,
+			}
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Hypervisor = hypervisorName
+
+			filtered := filterMigrationOptions(vmi, options)
+			Expect(filtered.UnsafeMigration).To(BeTrue())
+			Expect(filtered.AllowAutoConverge).To(Equal(expectSupported))
+			Expect(filtered.AllowPostCopy).To(Equal(expectSupported))
+			Expect(filtered.ParallelMigrationThreads != nil).To(Equal(expectSupported))
+			Expect(filtered.ProgressTimeout).To(BeEquivalentTo(150), "stuck migrations should be detected on every hypervisor")
+			Expect(options.AllowPostCopy).To(BeTrue(), "the original options should not be modified")
+		},
+		Entry("keep all options for QEMU", "qemu", true),
+		Entry("keep all options when the hypervisor is not set", "", true),
+		Entry("drop unsupported options for Cloud Hypervisor", "ch", false),
+	)
+
+	DescribeTable("should use the migration URI of the hypervisor",
+		func(hypervisorName string, expectedURI string) {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Hypervisor = hypervisorName
+			Expect(getMigrationHypervisor(vmi).GetMigrationUri("/migration.sock")).To(Equal(expectedURI))
+		},
+		Entry("for QEMU", "qemu", "qemu+unix:///system?socket=/migration.sock"),
+		Entry("for Cloud Hypervisor", "ch", "ch+unix:///system?socket=/migration.sock"),
+	)
+
 	DescribeTable("on successful list all domains",
 		func(state libvirt.DomainState, kubevirtState api.LifeCycle, libvirtReason int, kubevirtReason api.StateChangeReason) {
 
@@ -3099,3 +3412,17 @@ func isoCreationFunc(isoOutFile, volumeID string, inDir string) error {
 	_, err := os.Create(isoOutFile)
 	return err
 }
+
+// fakeBackupExport serves a disk exported by a pull mode backup job from memory
+type fakeBackupExport struct {
+	*bytes.Reader
+	extents map[string][]nbd.Extent
+}
+
+func (e *fakeBackupExport) Extents(metaContext string) ([]nbd.Extent, error) {
+	return e.extents[metaContext], nil
+}
+
+func (e *fakeBackupExport) Close() error {
+	return nil
+}
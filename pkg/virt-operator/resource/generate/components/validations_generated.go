@@ -1030,6 +1030,106 @@ var CRDsValidation map[string]string = map[string]string{
                       type: object
                   type: object
               type: object
+            hypervisorConfiguration:
+              description: HypervisorConfiguration holds the cluster-wide default
+                hypervisor and the per-namespace hypervisor policies
+              properties:
+                defaultHypervisor:
+                  description: |-
+                    DefaultHypervisor is the hypervisor assigned to VirtualMachineInstances which don't set spec.hypervisor.
+                    Defaults to "qemu".
+                  type: string
+                namespacePolicies:
+                  description: |-
+                    NamespacePolicies restrict and default the hypervisors available in specific namespaces.
+                    When several policies select the same namespace, the first one wins.
+                  items:
+                    description: HypervisorNamespacePolicy defines the hypervisors
+                      available to VirtualMachineInstances in a set of namespaces.
+                    properties:
+                      allowedHypervisors:
+                        description: |-
+                          AllowedHypervisors lists the hypervisors VirtualMachineInstances in the selected namespaces may use.
+                          If empty, every hypervisor is allowed.
+                        items:
+                          type: string
+                        type: array
+                        x-kubernetes-list-type: set
+                      defaultHypervisor:
+                        description: DefaultHypervisor overrides the cluster-wide
+                          default hypervisor in the selected namespaces.
+                        type: string
+                      namespaces:
+                        description: Namespaces the policy applies to.
+                        items:
+                          type: string
+                        type: array
+                        x-kubernetes-list-type: set
+                    required:
+                    - namespaces
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                overheads:
+                  description: |-
+                    Overheads override the memory overhead KubeVirt reserves in virt-launcher pods for the processes
+                    of a hypervisor. Fields which are not set keep the built-in default of the hypervisor.
+                  items:
+                    description: HypervisorOverhead defines the memory reserved for
+                      each process of a virt-launcher pod running a hypervisor.
+                    properties:
+                      hypervisor:
+                        description: Hypervisor the overheads apply to, e.g. "qemu"
+                          or "ch".
+                        type: string
+                      hypervisorDaemon:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: HypervisorDaemon is the memory reserved for the
+                          libvirt daemon of the hypervisor, e.g. virtqemud.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      virtLauncher:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: VirtLauncher is the memory reserved for the virt-launcher
+                          process.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      virtLauncherMonitor:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: VirtLauncherMonitor is the memory reserved for
+                          the virt-launcher-monitor process.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      virtlogd:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Virtlogd is the memory reserved for the virtlogd
+                          process.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      vmm:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: VMM is the memory reserved for the VMM process,
+                          on top of the guest memory and page tables.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                    required:
+                    - hypervisor
+                    type: object
+                  type: array
+                  x-kubernetes-list-map-keys:
+                  - hypervisor
+                  x-kubernetes-list-type: map
+              type: object
             imagePullPolicy:
               description: PullPolicy describes a policy for if/when to pull a container
                 image
@@ -6828,6 +6928,7 @@ var CRDsValidation map[string]string = map[string]string{
                                 persistent:
                                   description: |-
                                     If set to true, Persistent will persist the EFI NVRAM across reboots.
+                                    Not supported by the ch hypervisor, whose firmware keeps the EFI variables in guest memory.
                                     Defaults to false
                                   type: boolean
                                 secureBoot:
@@ -7015,8 +7116,9 @@ var CRDsValidation map[string]string = map[string]string{
                     If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
                   type: string
                 hypervisor:
-                  description: The Hypervisor to use for the VMI. Possible values
-                    are "qemu" for QEMU and "ch" for Cloud Hypervisor.
+                  description: |-
+                    The Hypervisor to use for the VMI. Possible values are "qemu" for QEMU, "ch" for Cloud Hypervisor on MSHV
+                    and "ch-kvm" for Cloud Hypervisor on KVM.
                   type: string
                 livenessProbe:
                   description: |-
@@ -7605,6 +7707,39 @@ var CRDsValidation map[string]string = map[string]string{
                   items:
                     description: Volume represents a named volume in a vmi.
                     properties:
+                      backup:
+                        description: Backup is attached to the virt launcher and is
+                          populated with a backup of the disks of the vmi
+                        properties:
+                          checkpoint:
+                            description: |-
+                              Checkpoint is the name of the checkpoint created when the backup starts,
+                              later backups can be taken incrementally on top of it
+                            type: string
+                          claimName:
+                            description: |-
+                              claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
+                              More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
+                            type: string
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          incrementalFrom:
+                            description: |-
+                              IncrementalFrom is the name of the checkpoint of a previous backup,
+                              only the data changed since that checkpoint is backed up.
+                              A full backup is taken if it is not set.
+                            type: string
+                          readOnly:
+                            description: |-
+                              readOnly Will force the ReadOnly setting in VolumeMounts.
+                              Default false.
+                            type: boolean
+                        required:
+                        - checkpoint
+                        - claimName
+                        type: object
                       cloudInitConfigDrive:
                         description: |-
                           CloudInitConfigDrive represents a cloud-init Config Drive user-data source.
@@ -7938,6 +8073,12 @@ var CRDsValidation map[string]string = map[string]string{
                               readOnly Will force the ReadOnly setting in VolumeMounts.
                               Default false.
                             type: boolean
+                          type:
+                            description: |-
+                              Type is the kind of memory dump the pvc holds, defaults to Core.
+                              A non hotpluggable volume of type State is used to resume the vmi
+                              from the saved state when it starts.
+                            type: string
                         required:
                         - claimName
                         type: object
@@ -8124,6 +8265,9 @@ var CRDsValidation map[string]string = map[string]string{
               description: StartTimestamp represents the time the memory dump started
               format: date-time
               type: string
+            type:
+              description: Type is the kind of memory dump to take, defaults to Core
+              type: string
           required:
           - claimName
           - phase
@@ -8456,6 +8600,159 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachinebackup": `openAPIV3Schema:
+  description: |-
+    VirtualMachineBackup defines the operation of backing up the disks of a
+    running VM to a PersistentVolumeClaim
+  properties:
+    apiVersion:
+      description: |-
+        APIVersion defines the versioned schema of this representation of an object.
+        Servers should convert recognized schemas to the latest internal value, and
+        may reject unrecognized values.
+        More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+      type: string
+    kind:
+      description: |-
+        Kind is a string value representing the REST resource this object represents.
+        Servers may infer this from the endpoint the client submits requests to.
+        Cannot be updated.
+        In CamelCase.
+        More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineBackupSpec is the spec for a VirtualMachineBackup
+        resource
+      properties:
+        failureDeadline:
+          description: |-
+            This time represents the number of seconds we permit the backup
+            to take. In case we pass this deadline we mark this backup
+            as failed.
+            Defaults to DefaultFailureDeadline - 5min
+          type: string
+        persistentVolumeClaimName:
+          description: |-
+            PersistentVolumeClaimName is the filesystem pvc the backup is written
+            to, a previous backup in the pvc is replaced
+          type: string
+        source:
+          description: Source is the running VirtualMachine whose disks are backed
+            up
+          properties:
+            apiGroup:
+              description: |-
+                APIGroup is the group for the resource being referenced.
+                If APIGroup is not specified, the specified Kind must be in the core API group.
+                For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+          x-kubernetes-map-type: atomic
+        type:
+          description: Type defaults to Incremental
+          type: string
+      required:
+      - persistentVolumeClaimName
+      - source
+      type: object
+    status:
+      description: VirtualMachineBackupStatus is the status for a VirtualMachineBackup
+        resource
+      properties:
+        backupDirectory:
+          description: BackupDirectory is the directory of the backup in the pvc
+          type: string
+        checkpoint:
+          description: Checkpoint is the name of the checkpoint created by the backup
+          type: string
+        checkpointChain:
+          description: |-
+            CheckpointChain lists the checkpoints from the latest full backup up
+            to the checkpoint of this backup. The backups of all the checkpoints
+            are needed to rebuild the disks.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for Conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        endTimestamp:
+          format: date-time
+          nullable: true
+          type: string
+        error:
+          description: Error is the last error encountered during the snapshot/restore
+          properties:
+            message:
+              type: string
+            time:
+              format: date-time
+              type: string
+          type: object
+        incrementalFrom:
+          description: |-
+            IncrementalFrom is the checkpoint of the backup an incremental
+            backup is taken on top of
+          type: string
+        phase:
+          description: VirtualMachineSnapshotPhase is the current phase of the VirtualMachineSnapshot
+          type: string
+        startTimestamp:
+          format: date-time
+          nullable: true
+          type: string
+        type:
+          description: |-
+            Type is the type of the backup which is taken, disks without a
+            persistent dirty bitmap are backed up fully in an incremental backup
+          type: string
+        virtualMachineInstanceUID:
+          description: |-
+            VirtualMachineInstanceUID is the uid of the vmi the backup was taken
+            from. Checkpoints do not outlive the vmi, incremental backups are
+            only taken on top of backups of the same vmi.
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
 `,
 	"virtualmachineclone": `openAPIV3Schema:
   description: VirtualMachineClone is a CRD that clones one VM into another.
@@ -8487,6 +8784,182 @@ var CRDsValidation map[string]string = map[string]string{
             type: string
           type: array
           x-kubernetes-list-type: atomic
+        guestCustomization:
+          description: |-
+            GuestCustomization changes the identity the guest operating system of the target boots with.
+            If this field is not specified, the target boots with the hostname, the cloud-init data and
+            the credentials of the source.
+          properties:
+            accessCredentials:
+              description: |-
+                AccessCredentials replace the access credentials of the target.
+                Secrets are looked up in the namespace of the VirtualMachineClone.
+              items:
+                description: |-
+                  AccessCredential represents a credential source that can be used to
+                  authorize remote access to the vm guest
+                  Only one of its members may be specified.
+                properties:
+                  sshPublicKey:
+                    description: |-
+                      SSHPublicKey represents the source and method of applying a ssh public
+                      key into a guest virtual machine.
+                    properties:
+                      propagationMethod:
+                        description: PropagationMethod represents how the public key
+                          is injected into the vm guest.
+                        properties:
+                          configDrive:
+                            description: |-
+                              ConfigDrivePropagation means that the ssh public keys are injected
+                              into the VM using metadata using the configDrive cloud-init provider
+                            type: object
+                          noCloud:
+                            description: |-
+                              NoCloudPropagation means that the ssh public keys are injected
+                              into the VM using metadata using the noCloud cloud-init provider
+                            type: object
+                          qemuGuestAgent:
+                            description: |-
+                              QemuGuestAgentAccessCredentailPropagation means ssh public keys are
+                              dynamically injected into the vm at runtime via the qemu guest agent.
+                              This feature requires the qemu guest agent to be running within the guest.
+                            properties:
+                              users:
+                                description: |-
+                                  Users represents a list of guest users that should have the ssh public keys
+                                  added to their authorized_keys file.
+                                items:
+                                  type: string
+                                type: array
+                                x-kubernetes-list-type: set
+                            required:
+                            - users
+                            type: object
+                        type: object
+                      source:
+                        description: Source represents where the public keys are pulled
+                          from
+                        properties:
+                          secret:
+                            description: Secret means that the access credential is
+                              pulled from a kubernetes secret
+                            properties:
+                              secretName:
+                                description: SecretName represents the name of the
+                                  secret in the VMI's namespace
+                                type: string
+                            required:
+                            - secretName
+                            type: object
+                        type: object
+                    required:
+                    - propagationMethod
+                    - source
+                    type: object
+                  userPassword:
+                    description: |-
+                      UserPassword represents the source and method for applying a guest user's
+                      password
+                    properties:
+                      propagationMethod:
+                        description: propagationMethod represents how the user passwords
+                          are injected into the vm guest.
+                        properties:
+                          qemuGuestAgent:
+                            description: |-
+                              QemuGuestAgentAccessCredentailPropagation means passwords are
+                              dynamically injected into the vm at runtime via the qemu guest agent.
+                              This feature requires the qemu guest agent to be running within the guest.
+                            type: object
+                        type: object
+                      source:
+                        description: Source represents where the user passwords are
+                          pulled from
+                        properties:
+                          secret:
+                            description: Secret means that the access credential is
+                              pulled from a kubernetes secret
+                            properties:
+                              secretName:
+                                description: SecretName represents the name of the
+                                  secret in the VMI's namespace
+                                type: string
+                            required:
+                            - secretName
+                            type: object
+                        type: object
+                    required:
+                    - propagationMethod
+                    - source
+                    type: object
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            cloudInit:
+              description: |-
+                CloudInit replaces the user data and the network data of the cloud-init NoCloud or
+                ConfigDrive volume of the target. The source must have a cloud-init volume.
+              properties:
+                networkData:
+                  description: NetworkData replaces the network data with inline network
+                    data.
+                  type: string
+                networkDataSecretRef:
+                  description: |-
+                    NetworkDataSecretRef replaces the network data with the network data of a secret
+                    in the namespace of the VirtualMachineClone.
+                  properties:
+                    name:
+                      description: |-
+                        Name of the referent.
+                        More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                        TODO: Add other useful fields. apiVersion, kind, uid?
+                      type: string
+                  type: object
+                  x-kubernetes-map-type: atomic
+                userData:
+                  description: UserData replaces the user data with inline user data.
+                  type: string
+                userDataSecretRef:
+                  description: |-
+                    UserDataSecretRef replaces the user data with the user data of a secret
+                    in the namespace of the VirtualMachineClone.
+                  properties:
+                    name:
+                      description: |-
+                        Name of the referent.
+                        More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                        TODO: Add other useful fields. apiVersion, kind, uid?
+                      type: string
+                  type: object
+                  x-kubernetes-map-type: atomic
+              type: object
+            hostname:
+              description: Hostname sets the hostname of the target, which is also
+                passed to cloud-init.
+              type: string
+            newInstanceID:
+              description: |-
+                NewInstanceID sets a random firmware UUID on the target. The cloud-init NoCloud instance-id is
+                derived from it, so cloud-init configures the target as a new instance even if the target has
+                the same name as the source. Otherwise the firmware UUID is derived from the target name.
+              type: boolean
+            sysprep:
+              description: |-
+                Sysprep resets the guest operating system on the disks of the target with virt-sysprep
+                before the clone succeeds. The target must not be started before the clone succeeded.
+              properties:
+                operations:
+                  description: |-
+                    Operations are the virt-sysprep operations to run, e.g. "machine-id" or "ssh-hostkeys".
+                    Defaults to the default operations of virt-sysprep.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+              type: object
+          type: object
         labelFilters:
           description: |-
             Example use: "!some/key*".
@@ -8531,6 +9004,14 @@ var CRDsValidation map[string]string = map[string]string{
           - name
           type: object
           x-kubernetes-map-type: atomic
+        sourceNamespace:
+          description: |-
+            SourceNamespace is the namespace of the source. Defaults to the namespace of the
+            VirtualMachineClone, which is always the namespace of the target. Cloning from another
+            namespace requires permission to create virtualmachineclones/source in the source namespace,
+            the CrossNamespaceVolumeDataSource feature of the cluster, and a ReferenceGrant allowing
+            PVCs of the target namespace to use the VolumeSnapshots of the source namespace.
+          type: string
         target:
           description: |-
             Target is the outcome of the cloning process.
@@ -9551,6 +10032,31 @@ var CRDsValidation map[string]string = map[string]string{
       description: VirtualMachineExportSpec is the spec for a VirtualMachineExport
         resource
       properties:
+        containerDisk:
+          description: ContainerDisk packages a volume into a containerDisk image,
+            the VirtualMachine manifest refers to the image instead of the volume
+          properties:
+            image:
+              description: Image is the reference of the image, the VirtualMachine
+                manifest refers to it
+              type: string
+            volume:
+              description: Volume is the name of the exported volume which is packaged
+                into the image
+              type: string
+          required:
+          - image
+          - volume
+          type: object
+        nbd:
+          description: NBD enables an NBD server in the export pod, which serves the
+            volumes on the internal link
+          properties:
+            readOnly:
+              description: ReadOnly rejects writes to the volumes through the NBD
+                server, defaults to true
+              type: boolean
+          type: object
         source:
           description: |-
             TypedLocalObjectReference contains enough information to let you locate the
@@ -9662,6 +10168,11 @@ var CRDsValidation map[string]string = map[string]string{
                           description: VirtualMachineExportVolumeFormat contains the
                             format type and URL to get the volume in that format
                           properties:
+                            checksumUrl:
+                              description: |-
+                                ChecksumUrl is the url of the SHA-256 checksum of the volume in the format specified,
+                                in the format of sha256sum
+                              type: string
                             format:
                               description: Format is the format of the image at the
                                 specified URL
@@ -9731,6 +10242,11 @@ var CRDsValidation map[string]string = map[string]string{
                           description: VirtualMachineExportVolumeFormat contains the
                             format type and URL to get the volume in that format
                           properties:
+                            checksumUrl:
+                              description: |-
+                                ChecksumUrl is the url of the SHA-256 checksum of the volume in the format specified,
+                                in the format of sha256sum
+                              type: string
                             format:
                               description: Format is the format of the image at the
                                 specified URL
@@ -9789,6 +10305,179 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachinegroupsnapshot": `openAPIV3Schema:
+  description: |-
+    VirtualMachineGroupSnapshot defines the operation of snapshotting several VMs
+    at a consistent point in time
+  properties:
+    apiVersion:
+      description: |-
+        APIVersion defines the versioned schema of this representation of an object.
+        Servers should convert recognized schemas to the latest internal value, and
+        may reject unrecognized values.
+        More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+      type: string
+    kind:
+      description: |-
+        Kind is a string value representing the REST resource this object represents.
+        Servers may infer this from the endpoint the client submits requests to.
+        Cannot be updated.
+        In CamelCase.
+        More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineGroupSnapshotSpec is the spec for a VirtualMachineGroupSnapshot
+        resource
+      properties:
+        deletionPolicy:
+          description: DeletionPolicy is applied to the VirtualMachineSnapshot of
+            each VirtualMachine
+          type: string
+        failureDeadline:
+          description: |-
+            This time represents the number of seconds we permit the group snapshot
+            to take. In case we pass this deadline we mark this snapshot
+            as failed.
+            Defaults to DefaultFailureDeadline - 5min
+          type: string
+        selector:
+          description: |-
+            Selector selects the VirtualMachines in the namespace of the group
+            snapshot which are snapshotted together. The selected VirtualMachines
+            are fixed once the group snapshot started.
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements.
+                The requirements are ANDed.
+              items:
+                description: |-
+                  A label selector requirement is a selector that contains values, a key, and an operator that
+                  relates the key and values.
+                properties:
+                  key:
+                    description: key is the label key that the selector applies to.
+                    type: string
+                  operator:
+                    description: |-
+                      operator represents a key's relationship to a set of values.
+                      Valid operators are In, NotIn, Exists and DoesNotExist.
+                    type: string
+                  values:
+                    description: |-
+                      values is an array of string values. If the operator is In or NotIn,
+                      the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                      the values array must be empty. This array is replaced during a strategic
+                      merge patch.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: |-
+                matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                map is equivalent to an element of matchExpressions, whose key field is "key", the
+                operator is "In", and the values array contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+          x-kubernetes-map-type: atomic
+      required:
+      - selector
+      type: object
+    status:
+      description: VirtualMachineGroupSnapshotStatus is the status for a VirtualMachineGroupSnapshot
+        resource
+      properties:
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for Conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        creationTime:
+          format: date-time
+          nullable: true
+          type: string
+        error:
+          description: Error is the last error encountered during the snapshot/restore
+          properties:
+            message:
+              type: string
+            time:
+              format: date-time
+              type: string
+          type: object
+        phase:
+          description: VirtualMachineSnapshotPhase is the current phase of the VirtualMachineSnapshot
+          type: string
+        readyToUse:
+          type: boolean
+        virtualMachineSnapshots:
+          description: VirtualMachineSnapshots lists the snapshot taken of each VirtualMachine
+            of the group
+          items:
+            description: |-
+              GroupSnapshotVirtualMachineStatus is the status of the snapshot of a single
+              VirtualMachine of a group snapshot
+            properties:
+              freezeTime:
+                description: |-
+                  FreezeTime is the time the VirtualMachine was frozen for the group snapshot,
+                  the freeze deadline of the group is measured from the first frozen VirtualMachine
+                format: date-time
+                nullable: true
+                type: string
+              readyToUse:
+                type: boolean
+              virtualMachineName:
+                type: string
+              virtualMachineSnapshotContentName:
+                type: string
+              virtualMachineSnapshotName:
+                type: string
+            required:
+            - virtualMachineName
+            - virtualMachineSnapshotName
+            type: object
+          type: array
+          x-kubernetes-list-map-keys:
+          - virtualMachineName
+          x-kubernetes-list-type: map
+      type: object
+  required:
+  - spec
+  type: object
 `,
 	"virtualmachineinstance": `openAPIV3Schema:
   description: VirtualMachineInstance is *the* VirtualMachineInstance Definition.
@@ -11885,6 +12574,7 @@ var CRDsValidation map[string]string = map[string]string{
                         persistent:
                           description: |-
                             If set to true, Persistent will persist the EFI NVRAM across reboots.
+                            Not supported by the ch hypervisor, whose firmware keeps the EFI variables in guest memory.
                             Defaults to false
                           type: boolean
                         secureBoot:
@@ -12068,8 +12758,9 @@ var CRDsValidation map[string]string = map[string]string{
             If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
           type: string
         hypervisor:
-          description: The Hypervisor to use for the VMI. Possible values are "qemu"
-            for QEMU and "ch" for Cloud Hypervisor.
+          description: |-
+            The Hypervisor to use for the VMI. Possible values are "qemu" for QEMU, "ch" for Cloud Hypervisor on MSHV
+            and "ch-kvm" for Cloud Hypervisor on KVM.
           type: string
         livenessProbe:
           description: |-
@@ -12656,6 +13347,39 @@ var CRDsValidation map[string]string = map[string]string{
           items:
             description: Volume represents a named volume in a vmi.
             properties:
+              backup:
+                description: Backup is attached to the virt launcher and is populated
+                  with a backup of the disks of the vmi
+                properties:
+                  checkpoint:
+                    description: |-
+                      Checkpoint is the name of the checkpoint created when the backup starts,
+                      later backups can be taken incrementally on top of it
+                    type: string
+                  claimName:
+                    description: |-
+                      claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
+                      More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
+                    type: string
+                  hotpluggable:
+                    description: Hotpluggable indicates whether the volume can be
+                      hotplugged and hotunplugged.
+                    type: boolean
+                  incrementalFrom:
+                    description: |-
+                      IncrementalFrom is the name of the checkpoint of a previous backup,
+                      only the data changed since that checkpoint is backed up.
+                      A full backup is taken if it is not set.
+                    type: string
+                  readOnly:
+                    description: |-
+                      readOnly Will force the ReadOnly setting in VolumeMounts.
+                      Default false.
+                    type: boolean
+                required:
+                - checkpoint
+                - claimName
+                type: object
               cloudInitConfigDrive:
                 description: |-
                   CloudInitConfigDrive represents a cloud-init Config Drive user-data source.
@@ -12980,6 +13704,12 @@ var CRDsValidation map[string]string = map[string]string{
                       readOnly Will force the ReadOnly setting in VolumeMounts.
                       Default false.
                     type: boolean
+                  type:
+                    description: |-
+                      Type is the kind of memory dump the pvc holds, defaults to Core.
+                      A non hotpluggable volume of type State is used to resume the vmi
+                      from the saved state when it starts.
+                    type: string
                 required:
                 - claimName
                 type: object
@@ -13632,6 +14362,23 @@ var CRDsValidation map[string]string = map[string]string{
             description: VolumeStatus represents information about the status of volumes
               attached to the VirtualMachineInstance.
             properties:
+              backupVolume:
+                description: If the volume is a backup volume, this will contain the
+                  backup info.
+                properties:
+                  endTimestamp:
+                    description: EndTimestamp is the time when the backup completed
+                    format: date-time
+                    type: string
+                  startTimestamp:
+                    description: StartTimestamp is the time when the backup started
+                    format: date-time
+                    type: string
+                  targetDirectory:
+                    description: TargetDirectory is the name of the directory the
+                      backup is written to
+                    type: string
+                type: object
               containerDiskVolume:
                 description: ContainerDiskVolume shows info about the containerdisk,
                   if the volume is a containerdisk
@@ -13782,6 +14529,13 @@ var CRDsValidation map[string]string = map[string]string{
       type: object
     spec:
       properties:
+        priority:
+          description: |-
+            Priority orders pending migrations when the parallel migration limits are reached.
+            Evacuation migrations start first, followed by User, Descheduler and WorkloadUpdate migrations.
+            Within a priority, migrations of different namespaces take turns.
+            Defaults to User. Only KubeVirt may create Evacuation migrations.
+          type: string
         vmiName:
           description: The name of the VMI to perform the migration on. VMI must exist
             in the migration objects namespace
@@ -14002,6 +14756,12 @@ var CRDsValidation map[string]string = map[string]string{
             type: object
           type: array
           x-kubernetes-list-type: atomic
+        queuePosition:
+          description: |-
+            QueuePosition is the position of a pending migration in the queue of migrations waiting for a free
+            migration slot, starting at 1. It is removed once the target pod of the migration is created.
+          format: int32
+          type: integer
       type: object
   required:
   - spec
@@ -15030,6 +15790,7 @@ var CRDsValidation map[string]string = map[string]string{
                         persistent:
                           description: |-
                             If set to true, Persistent will persist the EFI NVRAM across reboots.
+                            Not supported by the ch hypervisor, whose firmware keeps the EFI variables in guest memory.
                             Defaults to false
                           type: boolean
                         secureBoot:
@@ -17441,6 +18202,7 @@ var CRDsValidation map[string]string = map[string]string{
                                 persistent:
                                   description: |-
                                     If set to true, Persistent will persist the EFI NVRAM across reboots.
+                                    Not supported by the ch hypervisor, whose firmware keeps the EFI variables in guest memory.
                                     Defaults to false
                                   type: boolean
                                 secureBoot:
@@ -17628,8 +18390,9 @@ var CRDsValidation map[string]string = map[string]string{
                     If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
                   type: string
                 hypervisor:
-                  description: The Hypervisor to use for the VMI. Possible values
-                    are "qemu" for QEMU and "ch" for Cloud Hypervisor.
+                  description: |-
+                    The Hypervisor to use for the VMI. Possible values are "qemu" for QEMU, "ch" for Cloud Hypervisor on MSHV
+                    and "ch-kvm" for Cloud Hypervisor on KVM.
                   type: string
                 livenessProbe:
                   description: |-
@@ -18218,6 +18981,39 @@ var CRDsValidation map[string]string = map[string]string{
                   items:
                     description: Volume represents a named volume in a vmi.
                     properties:
+                      backup:
+                        description: Backup is attached to the virt launcher and is
+                          populated with a backup of the disks of the vmi
+                        properties:
+                          checkpoint:
+                            description: |-
+                              Checkpoint is the name of the checkpoint created when the backup starts,
+                              later backups can be taken incrementally on top of it
+                            type: string
+                          claimName:
+                            description: |-
+                              claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
+                              More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
+                            type: string
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          incrementalFrom:
+                            description: |-
+                              IncrementalFrom is the name of the checkpoint of a previous backup,
+                              only the data changed since that checkpoint is backed up.
+                              A full backup is taken if it is not set.
+                            type: string
+                          readOnly:
+                            description: |-
+                              readOnly Will force the ReadOnly setting in VolumeMounts.
+                              Default false.
+                            type: boolean
+                        required:
+                        - checkpoint
+                        - claimName
+                        type: object
                       cloudInitConfigDrive:
                         description: |-
                           CloudInitConfigDrive represents a cloud-init Config Drive user-data source.
@@ -18551,6 +19347,12 @@ var CRDsValidation map[string]string = map[string]string{
                               readOnly Will force the ReadOnly setting in VolumeMounts.
                               Default false.
                             type: boolean
+                          type:
+                            description: |-
+                              Type is the kind of memory dump the pvc holds, defaults to Core.
+                              A non hotpluggable volume of type State is used to resume the vmi
+                              from the saved state when it starts.
+                            type: string
                         required:
                         - claimName
                         type: object
@@ -21944,6 +22746,7 @@ var CRDsValidation map[string]string = map[string]string{
                                         persistent:
                                           description: |-
                                             If set to true, Persistent will persist the EFI NVRAM across reboots.
+                                            Not supported by the ch hypervisor, whose firmware keeps the EFI variables in guest memory.
                                             Defaults to false
                                           type: boolean
                                         secureBoot:
@@ -22136,8 +22939,9 @@ var CRDsValidation map[string]string = map[string]string{
                             If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
                           type: string
                         hypervisor:
-                          description: The Hypervisor to use for the VMI. Possible
-                            values are "qemu" for QEMU and "ch" for Cloud Hypervisor.
+                          description: |-
+                            The Hypervisor to use for the VMI. Possible values are "qemu" for QEMU, "ch" for Cloud Hypervisor on MSHV
+                            and "ch-kvm" for Cloud Hypervisor on KVM.
                           type: string
                         livenessProbe:
                           description: |-
@@ -22728,6 +23532,40 @@ var CRDsValidation map[string]string = map[string]string{
                           items:
                             description: Volume represents a named volume in a vmi.
                             properties:
+                              backup:
+                                description: Backup is attached to the virt launcher
+                                  and is populated with a backup of the disks of the
+                                  vmi
+                                properties:
+                                  checkpoint:
+                                    description: |-
+                                      Checkpoint is the name of the checkpoint created when the backup starts,
+                                      later backups can be taken incrementally on top of it
+                                    type: string
+                                  claimName:
+                                    description: |-
+                                      claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
+                                      More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
+                                    type: string
+                                  hotpluggable:
+                                    description: Hotpluggable indicates whether the
+                                      volume can be hotplugged and hotunplugged.
+                                    type: boolean
+                                  incrementalFrom:
+                                    description: |-
+                                      IncrementalFrom is the name of the checkpoint of a previous backup,
+                                      only the data changed since that checkpoint is backed up.
+                                      A full backup is taken if it is not set.
+                                    type: string
+                                  readOnly:
+                                    description: |-
+                                      readOnly Will force the ReadOnly setting in VolumeMounts.
+                                      Default false.
+                                    type: boolean
+                                required:
+                                - checkpoint
+                                - claimName
+                                type: object
                               cloudInitConfigDrive:
                                 description: |-
                                   CloudInitConfigDrive represents a cloud-init Config Drive user-data source.
@@ -23068,6 +23906,12 @@ var CRDsValidation map[string]string = map[string]string{
                                       readOnly Will force the ReadOnly setting in VolumeMounts.
                                       Default false.
                                     type: boolean
+                                  type:
+                                    description: |-
+                                      Type is the kind of memory dump the pvc holds, defaults to Core.
+                                      A non hotpluggable volume of type State is used to resume the vmi
+                                      from the saved state when it starts.
+                                    type: string
                                 required:
                                 - claimName
                                 type: object
@@ -23899,6 +24743,35 @@ var CRDsValidation map[string]string = map[string]string{
     spec:
       description: VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource
       properties:
+        excludedVolumes:
+          description: |-
+            ExcludedVolumes lists the volumes of the snapshot that are not restored.
+            Mutually exclusive with IncludedVolumes.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        includedVolumes:
+          description: |-
+            IncludedVolumes restricts the restore to the listed volumes of the snapshot.
+            Mutually exclusive with ExcludedVolumes.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        newMacAddresses:
+          additionalProperties:
+            type: string
+          description: |-
+            NewMacAddresses sets the MAC addresses of the interfaces of a VM created by the restore
+            as a copy of the snapshotted VM. The key is the interface name and the value is the new
+            MAC address. Interfaces that are not listed get a new MAC address assigned.
+          type: object
+        newSMBiosSerial:
+          description: |-
+            NewSMBiosSerial sets the SMBIOS serial of a VM created by the restore as a copy of the
+            snapshotted VM. If not set, the serial is cleared.
+          type: string
         patches:
           description: |-
             If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be
@@ -23910,6 +24783,11 @@ var CRDsValidation map[string]string = map[string]string{
             type: string
           type: array
           x-kubernetes-list-type: atomic
+        restoreMode:
+          description: |-
+            RestoreMode defines how the restored volumes are applied to the target.
+            Defaults to Full.
+          type: string
         target:
           description: initially only VirtualMachine type supported
           properties:
@@ -23930,8 +24808,39 @@ var CRDsValidation map[string]string = map[string]string{
           - name
           type: object
           x-kubernetes-map-type: atomic
+        targetNamespace:
+          description: |-
+            TargetNamespace is the namespace the target is restored into. Defaults to the namespace
+            of the VirtualMachineRestore. Restoring into another namespace always creates a new VM
+            and requires the CrossNamespaceVolumeDataSource feature of the cluster, along with a
+            ReferenceGrant allowing PVCs of the target namespace to use the VolumeSnapshots of the
+            snapshot namespace.
+          type: string
         virtualMachineSnapshotName:
           type: string
+        volumeRestoreOverrides:
+          description: |-
+            VolumeRestoreOverrides names the restored PVCs and DataVolumes of specific volumes,
+            taking precedence over the VolumeRestorePolicy.
+          items:
+            description: VolumeRestoreOverride sets the name of the restored PVC and
+              DataVolume of a volume
+            properties:
+              restoreName:
+                type: string
+              volumeName:
+                type: string
+            required:
+            - restoreName
+            - volumeName
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        volumeRestorePolicy:
+          description: |-
+            VolumeRestorePolicy defines how the restored PVCs and DataVolumes are named.
+            Defaults to RandomizeNames.
+          type: string
       required:
       - target
       - virtualMachineSnapshotName
@@ -24036,6 +24945,15 @@ var CRDsValidation map[string]string = map[string]string{
             as failed.
             Defaults to DefaultFailureDeadline - 5min
           type: string
+        includeMemoryState:
+          description: |-
+            IncludeMemoryState saves the guest memory and device state of a
+            running vm next to the volume snapshots. The vm is paused while
+            the state and the volumes are captured, and a restore of the
+            snapshot resumes the vm from that state.
+            Only vms running on the qemu hypervisor can save their memory
+            state, snapshots of Cloud Hypervisor (ch) vms are rejected.
+          type: boolean
         source:
           description: |-
             TypedLocalObjectReference contains enough information to let you locate the
@@ -27118,6 +28036,7 @@ var CRDsValidation map[string]string = map[string]string{
                                             persistent:
                                               description: |-
                                                 If set to true, Persistent will persist the EFI NVRAM across reboots.
+                                                Not supported by the ch hypervisor, whose firmware keeps the EFI variables in guest memory.
                                                 Defaults to false
                                               type: boolean
                                             secureBoot:
@@ -27311,8 +28230,9 @@ var CRDsValidation map[string]string = map[string]string{
                                 If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
                               type: string
                             hypervisor:
-                              description: The Hypervisor to use for the VMI. Possible
-                                values are "qemu" for QEMU and "ch" for Cloud Hypervisor.
+                              description: |-
+                                The Hypervisor to use for the VMI. Possible values are "qemu" for QEMU, "ch" for Cloud Hypervisor on MSHV
+                                and "ch-kvm" for Cloud Hypervisor on KVM.
                               type: string
                             livenessProbe:
                               description: |-
@@ -27907,6 +28827,40 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Volume represents a named volume in a
                                   vmi.
                                 properties:
+                                  backup:
+                                    description: Backup is attached to the virt launcher
+                                      and is populated with a backup of the disks
+                                      of the vmi
+                                    properties:
+                                      checkpoint:
+                                        description: |-
+                                          Checkpoint is the name of the checkpoint created when the backup starts,
+                                          later backups can be taken incrementally on top of it
+                                        type: string
+                                      claimName:
+                                        description: |-
+                                          claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
+                                          More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
+                                        type: string
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      incrementalFrom:
+                                        description: |-
+                                          IncrementalFrom is the name of the checkpoint of a previous backup,
+                                          only the data changed since that checkpoint is backed up.
+                                          A full backup is taken if it is not set.
+                                        type: string
+                                      readOnly:
+                                        description: |-
+                                          readOnly Will force the ReadOnly setting in VolumeMounts.
+                                          Default false.
+                                        type: boolean
+                                    required:
+                                    - checkpoint
+                                    - claimName
+                                    type: object
                                   cloudInitConfigDrive:
                                     description: |-
                                       CloudInitConfigDrive represents a cloud-init Config Drive user-data source.
@@ -28254,6 +29208,12 @@ var CRDsValidation map[string]string = map[string]string{
                                           readOnly Will force the ReadOnly setting in VolumeMounts.
                                           Default false.
                                         type: boolean
+                                      type:
+                                        description: |-
+                                          Type is the kind of memory dump the pvc holds, defaults to Core.
+                                          A non hotpluggable volume of type State is used to resume the vmi
+                                          from the saved state when it starts.
+                                        type: string
                                     required:
                                     - claimName
                                     type: object
@@ -28448,6 +29408,10 @@ var CRDsValidation map[string]string = map[string]string{
                             dump started
                           format: date-time
                           type: string
+                        type:
+                          description: Type is the kind of memory dump to take, defaults
+                            to Core
+                          type: string
                       required:
                       - claimName
                       - phase
@@ -29066,5 +30030,190 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachinesnapshotschedule": `openAPIV3Schema:
+  description: VirtualMachineSnapshotSchedule defines recurring snapshots of a set
+    of VMs
+  properties:
+    apiVersion:
+      description: |-
+        APIVersion defines the versioned schema of this representation of an object.
+        Servers should convert recognized schemas to the latest internal value, and
+        may reject unrecognized values.
+        More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+      type: string
+    kind:
+      description: |-
+        Kind is a string value representing the REST resource this object represents.
+        Servers may infer this from the endpoint the client submits requests to.
+        Cannot be updated.
+        In CamelCase.
+        More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule
+        resource
+      properties:
+        disabled:
+          description: Disabled suspends the creation of new snapshots
+          type: boolean
+        retention:
+          description: Retention defines which of the created snapshots are kept
+          properties:
+            keepDaily:
+              description: KeepDaily keeps the most recent snapshot of each of the
+                given number of days
+              format: int32
+              type: integer
+            keepLast:
+              description: KeepLast keeps the given number of most recent snapshots
+              format: int32
+              type: integer
+            keepWeekly:
+              description: KeepWeekly keeps the most recent snapshot of each of the
+                given number of weeks
+              format: int32
+              type: integer
+          type: object
+        schedule:
+          description: |-
+            Schedule in cron format (minute hour day-of-month month day-of-week),
+            evaluated in UTC
+          type: string
+        selector:
+          description: |-
+            Selector selects the VirtualMachines in the namespace of the schedule
+            which are snapshotted. All VirtualMachines in the namespace are selected
+            if the selector is omitted.
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements.
+                The requirements are ANDed.
+              items:
+                description: |-
+                  A label selector requirement is a selector that contains values, a key, and an operator that
+                  relates the key and values.
+                properties:
+                  key:
+                    description: key is the label key that the selector applies to.
+                    type: string
+                  operator:
+                    description: |-
+                      operator represents a key's relationship to a set of values.
+                      Valid operators are In, NotIn, Exists and DoesNotExist.
+                    type: string
+                  values:
+                    description: |-
+                      values is an array of string values. If the operator is In or NotIn,
+                      the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                      the values array must be empty. This array is replaced during a strategic
+                      merge patch.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: |-
+                matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                map is equivalent to an element of matchExpressions, whose key field is "key", the
+                operator is "In", and the values array contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+          x-kubernetes-map-type: atomic
+        template:
+          description: Template holds the defaults for the created VirtualMachineSnapshots
+          properties:
+            deletionPolicy:
+              description: |-
+                DeletionPolicy defines that to do with VirtualMachineSnapshot
+                when VirtualMachineSnapshot is deleted
+              type: string
+            failureDeadline:
+              type: string
+          type: object
+      required:
+      - schedule
+      type: object
+    status:
+      description: VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule
+        resource
+      properties:
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for Conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        lastScheduleTime:
+          format: date-time
+          nullable: true
+          type: string
+        nextScheduleTime:
+          format: date-time
+          nullable: true
+          type: string
+        virtualMachines:
+          items:
+            description: ScheduledVirtualMachineStatus records the outcome of the
+              scheduled snapshots of a VirtualMachine
+            properties:
+              lastFailedSnapshot:
+                type: string
+              lastFailureMessage:
+                type: string
+              lastFailureTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastSuccessTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastSuccessfulSnapshot:
+                type: string
+              name:
+                type: string
+            required:
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-map-keys:
+          - name
+          x-kubernetes-list-type: map
+      type: object
+  required:
+  - spec
+  type: object
 `,
 }
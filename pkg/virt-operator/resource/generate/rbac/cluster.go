@@ -39,31 +39,37 @@ const (
 	defaultClusterRoleName          = "kubevirt.io:default"
 	instancetypeViewClusterRoleName = "instancetype.kubevirt.io:view"
 
-	apiVersion            = "version"
-	apiGuestFs            = "guestfs"
-	apiExpandVmSpec       = "expand-vm-spec"
-	apiKubevirts          = "kubevirts"
-	apiVM                 = "virtualmachines"
-	apiVMInstances        = "virtualmachineinstances"
-	apiVMIPresets         = "virtualmachineinstancepresets"
-	apiVMIReplicasets     = "virtualmachineinstancereplicasets"
-	apiVMIMigrations      = "virtualmachineinstancemigrations"
-	apiVMSnapshots        = "virtualmachinesnapshots"
-	apiVMSnapshotContents = "virtualmachinesnapshotcontents"
-	apiVMRestores         = "virtualmachinerestores"
-	apiVMExports          = "virtualmachineexports"
-	apiVMClones           = "virtualmachineclones"
-	apiVMPools            = "virtualmachinepools"
+	apiVersion             = "version"
+	apiGuestFs             = "guestfs"
+	apiExpandVmSpec        = "expand-vm-spec"
+	apiKubevirts           = "kubevirts"
+	apiVM                  = "virtualmachines"
+	apiVMInstances         = "virtualmachineinstances"
+	apiVMIPresets          = "virtualmachineinstancepresets"
+	apiVMIReplicasets      = "virtualmachineinstancereplicasets"
+	apiVMIMigrations       = "virtualmachineinstancemigrations"
+	apiVMSnapshots         = "virtualmachinesnapshots"
+	apiVMSnapshotContents  = "virtualmachinesnapshotcontents"
+	apiVMRestores          = "virtualmachinerestores"
+	apiVMSnapshotSchedules = "virtualmachinesnapshotschedules"
+	apiVMGroupSnapshots    = "virtualmachinegroupsnapshots"
+	apiVMBackups           = "virtualmachinebackups"
+	apiVMExports           = "virtualmachineexports"
+	apiVMClones            = "virtualmachineclones"
+	apiVMPools             = "virtualmachinepools"
 
-	apiVMExpandSpec   = "virtualmachines/expand-spec"
-	apiVMPortForward  = "virtualmachines/portforward"
-	apiVMStart        = "virtualmachines/start"
-	apiVMStop         = "virtualmachines/stop"
-	apiVMRestart      = "virtualmachines/restart"
-	apiVMAddVolume    = "virtualmachines/addvolume"
-	apiVMRemoveVolume = "virtualmachines/removevolume"
-	apiVMMigrate      = "virtualmachines/migrate"
-	apiVMMemoryDump   = "virtualmachines/memorydump"
+	apiVMExpandSpec        = "virtualmachines/expand-spec"
+	apiVMPortForward       = "virtualmachines/portforward"
+	apiVMStart             = "virtualmachines/start"
+	apiVMStop              = "virtualmachines/stop"
+	apiVMRestart           = "virtualmachines/restart"
+	apiVMAddVolume         = "virtualmachines/addvolume"
+	apiVMRemoveVolume      = "virtualmachines/removevolume"
+	apiVMMigrate           = "virtualmachines/migrate"
+	apiVMMemoryDump        = "virtualmachines/memorydump"
+	apiVMConvertHypervisor = "virtualmachines/convert-hypervisor"
+
+	apiVMClonesSource = "virtualmachineclones/source"
 
 	apiVMInstancesConsole                   = "virtualmachineinstances/console"
 	apiVMInstancesVNC                       = "virtualmachineinstances/vnc"
@@ -249,6 +255,7 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					apiVMRemoveVolume,
 					apiVMMigrate,
 					apiVMMemoryDump,
+					apiVMConvertHypervisor,
 				},
 				Verbs: []string{
 					"update",
@@ -288,6 +295,9 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					apiVMSnapshots,
 					apiVMSnapshotContents,
 					apiVMRestores,
+					apiVMSnapshotSchedules,
+					apiVMGroupSnapshots,
+					apiVMBackups,
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
@@ -315,6 +325,17 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
 				},
 			},
+			{
+				APIGroups: []string{
+					clone.GroupName,
+				},
+				Resources: []string{
+					apiVMClonesSource,
+				},
+				Verbs: []string{
+					"create",
+				},
+			},
 			{
 				APIGroups: []string{
 					instancetype.GroupName,
@@ -432,6 +453,7 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					apiVMRemoveVolume,
 					apiVMMigrate,
 					apiVMMemoryDump,
+					apiVMConvertHypervisor,
 				},
 				Verbs: []string{
 					"update",
@@ -471,6 +493,9 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					apiVMSnapshots,
 					apiVMSnapshotContents,
 					apiVMRestores,
+					apiVMSnapshotSchedules,
+					apiVMGroupSnapshots,
+					apiVMBackups,
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch",
@@ -498,6 +523,17 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					clone.GroupName,
+				},
+				Resources: []string{
+					apiVMClonesSource,
+				},
+				Verbs: []string{
+					"create",
+				},
+			},
 			{
 				APIGroups: []string{
 					instancetype.GroupName,
@@ -624,6 +660,9 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					apiVMSnapshots,
 					apiVMSnapshotContents,
 					apiVMRestores,
+					apiVMSnapshotSchedules,
+					apiVMGroupSnapshots,
+					apiVMBackups,
 				},
 				Verbs: []string{
 					"get", "list", "watch",
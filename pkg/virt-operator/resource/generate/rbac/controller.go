@@ -373,9 +373,14 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstances/removevolume",
 					"virtualmachineinstances/freeze",
 					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/pause",
+					"virtualmachineinstances/unpause",
 					"virtualmachineinstances/softreboot",
 					"virtualmachineinstances/sev/setupsession",
 					"virtualmachineinstances/sev/injectlaunchsecret",
+					"virtualmachines/addvolume",
+					"virtualmachines/memorydump",
+					"virtualmachines/removememorydump",
 				},
 				Verbs: []string{
 					"update",
@@ -21,6 +21,7 @@ package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"kubevirt.io/client-go/log"
@@ -28,6 +29,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
@@ -58,6 +60,14 @@ func (k *kubeVirtCreateAdmitter) Admit(ctx context.Context, review *admissionv1.
 	}
 	//TODO: Do we want semantic validation
 
+	newKV := &v1.KubeVirt{}
+	if err := json.Unmarshal(review.Request.Object.Raw, newKV); err != nil {
+		return webhooks.ToAdmissionResponseError(err)
+	}
+	if causes := validateHypervisorConfiguration(field.NewPath("spec").Child("configuration", "hypervisorConfiguration"), newKV.Spec.Configuration.HypervisorConfiguration); len(causes) > 0 {
+		return webhooks.ToAdmissionResponse(causes)
+	}
+
 	// Best effort
 	list, err := k.client.KubeVirt(k8sv1.NamespaceAll).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -84,4 +84,39 @@ var _ = Describe("Validating KubeVirtCreate Admitter", func() {
 		response := admitter.Admit(context.Background(), review)
 		Expect(response.Allowed).To(BeTrue(), "Create Kubevirt should be allowed")
 	})
+
+	It("should reject a new Kubevirt resource with an invalid hypervisor configuration", func() {
+		kvInterface.EXPECT().List(gomock.Any(), gomock.Any()).
+			Return(&v1.KubeVirtList{Items: []v1.KubeVirt{}}, nil).AnyTimes()
+
+		newKv := v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "New",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					HypervisorConfiguration: &v1.HypervisorConfiguration{
+						DefaultHypervisor: "unknown",
+					},
+				},
+			},
+		}
+
+		b, err := json.Marshal(newKv)
+		Expect(err).ToNot(HaveOccurred())
+		review := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Namespace: "test",
+				Name:      "kubevirt",
+				Object: runtime.RawExtension{
+					Raw: b,
+				},
+			},
+		}
+
+		response := admitter.Admit(context.Background(), review)
+		Expect(response.Allowed).To(BeFalse())
+		Expect(response.Result.Details.Causes).To(HaveLen(1))
+		Expect(response.Result.Details.Causes[0].Field).To(Equal("spec.configuration.hypervisorConfiguration.defaultHypervisor"))
+	})
 })
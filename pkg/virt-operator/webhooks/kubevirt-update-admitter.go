@@ -23,8 +23,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 
+	"kubevirt.io/kubevirt/pkg/hypervisor"
 	kvtls "kubevirt.io/kubevirt/pkg/util/tls"
 
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -38,6 +40,7 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -107,6 +110,11 @@ func (admitter *KubeVirtUpdateAdmitter) Admit(ctx context.Context, ar *admission
 
 	}
 
+	if !equality.Semantic.DeepEqual(currKV.Spec.Configuration.HypervisorConfiguration, newKV.Spec.Configuration.HypervisorConfiguration) {
+		results = append(results,
+			validateHypervisorConfiguration(field.NewPath("spec").Child("configuration", "hypervisorConfiguration"), newKV.Spec.Configuration.HypervisorConfiguration)...)
+	}
+
 	if newKV.Spec.Infra != nil {
 		results = append(results, validateInfraReplicas(newKV.Spec.Infra.Replicas)...)
 	}
@@ -309,6 +317,78 @@ func validateSeccompConfiguration(field *field.Path, seccompConf *v1.SeccompConf
 
 }
 
+func validateHypervisorConfiguration(field *field.Path, hypervisorConf *v1.HypervisorConfiguration) []metav1.StatusCause {
+	statuses := []metav1.StatusCause{}
+	if hypervisorConf == nil {
+		return statuses
+	}
+
+	if hypervisorConf.DefaultHypervisor != "" {
+		statuses = append(statuses, validateHypervisorName(field.Child("defaultHypervisor"), hypervisorConf.DefaultHypervisor)...)
+	}
+
+	for i, policy := range hypervisorConf.NamespacePolicies {
+		policyField := field.Child("namespacePolicies").Index(i)
+		if len(policy.Namespaces) == 0 {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Field:   policyField.Child("namespaces").String(),
+				Message: fmt.Sprintf("%s must select at least one namespace", policyField.String()),
+			})
+		}
+		for j, name := range policy.AllowedHypervisors {
+			statuses = append(statuses, validateHypervisorName(policyField.Child("allowedHypervisors").Index(j), name)...)
+		}
+		if policy.DefaultHypervisor == "" {
+			continue
+		}
+		statuses = append(statuses, validateHypervisorName(policyField.Child("defaultHypervisor"), policy.DefaultHypervisor)...)
+		if len(policy.AllowedHypervisors) > 0 && !slices.Contains(policy.AllowedHypervisors, policy.DefaultHypervisor) {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   policyField.Child("defaultHypervisor").String(),
+				Message: fmt.Sprintf("default hypervisor %q is not part of the allowed hypervisors", policy.DefaultHypervisor),
+			})
+		}
+	}
+
+	for i, overhead := range hypervisorConf.Overheads {
+		overheadField := field.Child("overheads").Index(i)
+		statuses = append(statuses, validateHypervisorName(overheadField.Child("hypervisor"), overhead.Hypervisor)...)
+		for _, process := range []struct {
+			name     string
+			quantity *resource.Quantity
+		}{
+			{"virtLauncherMonitor", overhead.VirtLauncherMonitor},
+			{"virtLauncher", overhead.VirtLauncher},
+			{"virtlogd", overhead.Virtlogd},
+			{"hypervisorDaemon", overhead.HypervisorDaemon},
+			{"vmm", overhead.VMM},
+		} {
+			if process.quantity != nil && process.quantity.Sign() < 0 {
+				statuses = append(statuses, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Field:   overheadField.Child(process.name).String(),
+					Message: fmt.Sprintf("%s must not be negative", overheadField.Child(process.name).String()),
+				})
+			}
+		}
+	}
+
+	return statuses
+}
+
+func validateHypervisorName(field *field.Path, name string) []metav1.StatusCause {
+	if _, exists := hypervisor.Lookup(name); exists {
+		return nil
+	}
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Field:   field.String(),
+		Message: fmt.Sprintf("hypervisor %q is not supported", name),
+	}}
+}
+
 func validateWorkloadPlacement(ctx context.Context, namespace string, placementConfig *v1.NodePlacement, client kubecli.KubevirtClient) []metav1.StatusCause {
 	statuses := []metav1.StatusCause{}
 
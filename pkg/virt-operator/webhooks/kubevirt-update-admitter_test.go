@@ -30,6 +30,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -69,6 +70,51 @@ var _ = Describe("Validating KubeVirtUpdate Admitter", func() {
 		}, []string{vmProfileField.Child("customProfile", "runtimeDefaultProfile").String(), vmProfileField.Child("customProfile", "localhostProfile").String()}),
 	)
 
+	DescribeTable("validateHypervisorConfiguration", func(hypervisorConfiguration *v1.HypervisorConfiguration, expectedFields []string) {
+		causes := validateHypervisorConfiguration(test, hypervisorConfiguration)
+		Expect(causes).To(HaveLen(len(expectedFields)))
+		for _, cause := range causes {
+			Expect(cause.Field).To(BeElementOf(expectedFields))
+		}
+	},
+		Entry("accept a nil configuration", nil, nil),
+		Entry("accept a supported default hypervisor", &v1.HypervisorConfiguration{DefaultHypervisor: "ch"}, nil),
+		Entry("reject an unsupported default hypervisor", &v1.HypervisorConfiguration{DefaultHypervisor: "xen"},
+			[]string{test.Child("defaultHypervisor").String()}),
+		Entry("accept a valid namespace policy", &v1.HypervisorConfiguration{
+			NamespacePolicies: []v1.HypervisorNamespacePolicy{{
+				Namespaces:         []string{"mshv-tenant"},
+				DefaultHypervisor:  "ch",
+				AllowedHypervisors: []string{"ch"},
+			}},
+		}, nil),
+		Entry("reject a namespace policy without namespaces", &v1.HypervisorConfiguration{
+			NamespacePolicies: []v1.HypervisorNamespacePolicy{{AllowedHypervisors: []string{"qemu"}}},
+		}, []string{test.Child("namespacePolicies").Index(0).Child("namespaces").String()}),
+		Entry("reject unsupported allowed hypervisors", &v1.HypervisorConfiguration{
+			NamespacePolicies: []v1.HypervisorNamespacePolicy{{
+				Namespaces:         []string{"mshv-tenant"},
+				AllowedHypervisors: []string{"ch", "xen"},
+			}},
+		}, []string{test.Child("namespacePolicies").Index(0).Child("allowedHypervisors").Index(1).String()}),
+		Entry("reject a namespace default which is not allowed", &v1.HypervisorConfiguration{
+			NamespacePolicies: []v1.HypervisorNamespacePolicy{{
+				Namespaces:         []string{"mshv-tenant"},
+				DefaultHypervisor:  "qemu",
+				AllowedHypervisors: []string{"ch"},
+			}},
+		}, []string{test.Child("namespacePolicies").Index(0).Child("defaultHypervisor").String()}),
+		Entry("accept overheads of a supported hypervisor", &v1.HypervisorConfiguration{
+			Overheads: []v1.HypervisorOverhead{{Hypervisor: "ch", VMM: resource.NewQuantity(64*1024*1024, resource.BinarySI)}},
+		}, nil),
+		Entry("reject overheads of an unsupported hypervisor", &v1.HypervisorConfiguration{
+			Overheads: []v1.HypervisorOverhead{{Hypervisor: "xen"}},
+		}, []string{test.Child("overheads").Index(0).Child("hypervisor").String()}),
+		Entry("reject negative overheads", &v1.HypervisorConfiguration{
+			Overheads: []v1.HypervisorOverhead{{Hypervisor: "qemu", Virtlogd: resource.NewQuantity(-1024*1024, resource.BinarySI)}},
+		}, []string{test.Child("overheads").Index(0).Child("virtlogd").String()}),
+	)
+
 	DescribeTable("test validateCustomizeComponents", func(cc v1.CustomizeComponents, expectedCauses int) {
 		causes := validateCustomizeComponents(cc)
 		Expect(causes).To(HaveLen(expectedCauses))
@@ -0,0 +1,94 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_CONVERT_HYPERVISOR = "convert-hypervisor"
+
+	hypervisorArg = "hypervisor"
+)
+
+var hypervisorName string
+
+func NewConvertHypervisorCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert-hypervisor (VM)",
+		Short: "Switch a virtual machine to another hypervisor.",
+		Long: `Switch a virtual machine to another hypervisor.
+The domain of the VM is rendered for the current and the target hypervisor and the differences visible to the guest are reported.
+The VM is rejected if it uses features the target hypervisor does not support. A running VM switches on its next restart.`,
+		Example: convertHypervisorUsage(),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_CONVERT_HYPERVISOR, clientConfig: clientConfig}
+			return c.convertHypervisorRun(args)
+		},
+	}
+	cmd.Flags().StringVar(&hypervisorName, hypervisorArg, "", "The hypervisor the VM switches to.")
+	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	cmd.MarkFlagRequired(hypervisorArg)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func convertHypervisorUsage() string {
+	return `  # Switch a virtual machine called 'myvm' to Cloud Hypervisor:
+  {{ProgramName}} convert-hypervisor myvm --hypervisor=ch
+
+  # Report the changes of switching a virtual machine called 'myvm' to QEMU without switching it:
+  {{ProgramName}} convert-hypervisor myvm --hypervisor=qemu --dry-run`
+}
+
+func (o *Command) convertHypervisorRun(args []string) error {
+	vmName := args[0]
+
+	virtClient, namespace, err := GetNamespaceAndClient(o.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	dryRunOption := setDryRunOption(dryRun)
+
+	report, err := virtClient.VirtualMachine(namespace).ConvertHypervisor(context.Background(), vmName, &v1.ConvertHypervisorOptions{
+		Hypervisor: hypervisorName,
+		DryRun:     dryRunOption,
+	})
+	if err != nil {
+		return fmt.Errorf("Error converting VirtualMachine to hypervisor %s: %v", hypervisorName, err)
+	}
+
+	for _, change := range report.Changes {
+		fmt.Printf("%s: %s\n", change.Field, change.Message)
+	}
+	fmt.Printf("VM %s was converted from hypervisor %s to %s\n", vmName, report.SourceHypervisor, report.TargetHypervisor)
+
+	return nil
+}
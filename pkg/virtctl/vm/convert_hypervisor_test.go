@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Convert hypervisor command", func() {
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var ctrl *gomock.Controller
+	const vmName = "testvm"
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+	})
+
+	It("should fail with missing input parameters", func() {
+		cmd := clientcmd.NewRepeatableVirtctlCommand("convert-hypervisor")
+		Expect(cmd()).To(MatchError("accepts 1 arg(s), received 0"))
+	})
+
+	It("should fail without the target hypervisor", func() {
+		cmd := clientcmd.NewRepeatableVirtctlCommand("convert-hypervisor", vmName)
+		Expect(cmd()).To(MatchError(ContainSubstring(`required flag(s) "hypervisor" not set`)))
+	})
+
+	DescribeTable("should convert a vm according to options", func(convertOptions *v1.ConvertHypervisorOptions) {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().ConvertHypervisor(context.Background(), vmName, convertOptions).Return(&v1.HypervisorConversionReport{
+			SourceHypervisor: "qemu",
+			TargetHypervisor: "ch",
+		}, nil).Times(1)
+
+		args := []string{"convert-hypervisor", vmName, "--hypervisor", convertOptions.Hypervisor}
+		if len(convertOptions.DryRun) > 0 {
+			args = append(args, "--dry-run")
+		}
+
+		Expect(clientcmd.NewRepeatableVirtctlCommand(args...)()).To(Succeed())
+	},
+		Entry("with default", &v1.ConvertHypervisorOptions{Hypervisor: "ch"}),
+		Entry("with dry-run option", &v1.ConvertHypervisorOptions{Hypervisor: "ch", DryRun: []string{k8smetav1.DryRunAll}}),
+	)
+
+	It("should return the error of the conversion", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().ConvertHypervisor(context.Background(), vmName, gomock.Any()).Return(nil, fmt.Errorf("SecureBoot is not supported")).Times(1)
+
+		cmd := clientcmd.NewRepeatableVirtctlCommand("convert-hypervisor", vmName, "--hypervisor", "ch")
+		Expect(cmd()).To(MatchError(ContainSubstring("SecureBoot is not supported")))
+	})
+})
@@ -0,0 +1,301 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package vmexport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	pb "github.com/cheggaaa/pb/v3"
+	"golang.org/x/sync/errgroup"
+
+	exportv1 "kubevirt.io/api/export/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+const (
+	defaultRangeChunkSize = 64 * 1024 * 1024
+	defaultRetryInterval  = 2 * time.Second
+	// maxRetryInterval caps the exponential backoff between the attempts to download a range
+	maxRetryInterval = 30 * time.Second
+	// progressFileSuffix is appended to the output file to get the file storing the progress of a ranged download
+	progressFileSuffix = ".progress"
+)
+
+var (
+	// rangeChunkSize is the size of the ranges of a ranged download
+	rangeChunkSize int64 = defaultRangeChunkSize
+	// retryInterval is the initial interval between the attempts to download a range
+	retryInterval = defaultRetryInterval
+)
+
+// SetRangedDownloadParams allows overriding the size of the ranges and the interval between
+// the attempts to download them (useful for unit testing)
+func SetRangedDownloadParams(chunkSize int64, interval time.Duration) {
+	rangeChunkSize = chunkSize
+	retryInterval = interval
+}
+
+// SetDefaultRangedDownloadParams sets the size of the ranges and the retry interval back to default
+func SetDefaultRangedDownloadParams() {
+	SetRangedDownloadParams(defaultRangeChunkSize, defaultRetryInterval)
+}
+
+var errVolumeChanged = errors.New("the volume changed during the download")
+
+// rangedDownload tracks the ranges of the volume which were downloaded, it is stored
+// next to the output file so an interrupted download can be resumed
+type rangedDownload struct {
+	Size      int64   `json:"size"`
+	ETag      string  `json:"etag,omitempty"`
+	ChunkSize int64   `json:"chunkSize"`
+	Completed []int64 `json:"completed"`
+
+	lock sync.Mutex
+	path string
+}
+
+// downloadRanges downloads a raw volume into the output file in ranges, which are downloaded in
+// parallel and retried with backoff. Ranges downloaded by a previous attempt are skipped when resuming.
+func downloadRanges(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, volumeFormat *exportv1.VirtualMachineExportVolumeFormat, downloadUrl string) error {
+	output, ok := vmeInfo.OutputWriter.(*os.File)
+	if !ok {
+		return fmt.Errorf("ranged downloads need an output file")
+	}
+	fetch := func(headers map[string]string) (*http.Response, error) {
+		return HandleHTTPRequest(client, vmexport, downloadUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, headers)
+	}
+
+	// Request the first byte to learn the size of the volume and whether the server supports ranges
+	resp, err := fetch(map[string]string{"Range": "bytes=0-0"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var size int64
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if _, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes 0-0/%d", &size); err != nil {
+			return fmt.Errorf("invalid Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the volume is empty
+	case http.StatusOK:
+		printToOutput("The export server does not support ranged downloads, downloading the whole volume\n")
+		if err := output.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := output.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if err := copyFileWithProgressBar(output, resp.Body, false); err != nil {
+			return err
+		}
+		return verifyOutputChecksum(client, vmexport, vmeInfo, volumeFormat, output)
+	default:
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	download := loadRangedDownload(vmeInfo, output, size, resp.Header.Get("ETag"))
+	if err := output.Truncate(size); err != nil {
+		return err
+	}
+	if err := download.save(); err != nil {
+		return err
+	}
+	if err := download.run(fetch, output, vmeInfo.Parallel, vmeInfo.DownloadRetries); err != nil {
+		return err
+	}
+	// The progress is removed even if the checksum does not match, so a resumed download starts over
+	verifyErr := verifyOutputChecksum(client, vmexport, vmeInfo, volumeFormat, output)
+	if err := os.Remove(download.path); err != nil {
+		return err
+	}
+	return verifyErr
+}
+
+// loadRangedDownload resumes the previous download of the same volume into the output file, if requested.
+// Only the progress of the previous download tells which ranges of the output file are valid, and only the
+// ETag of the volume tells that they still belong to it, without either the download starts over.
+func loadRangedDownload(vmeInfo *VMExportInfo, output *os.File, size int64, etag string) *rangedDownload {
+	download := &rangedDownload{
+		Size:      size,
+		ETag:      etag,
+		ChunkSize: rangeChunkSize,
+		path:      output.Name() + progressFileSuffix,
+	}
+	if !vmeInfo.Resume {
+		return download
+	}
+
+	data, err := os.ReadFile(download.path)
+	switch {
+	case err != nil:
+		printToOutput("No progress of a previous download found, restarting it\n")
+	case etag == "":
+		printToOutput("The export server does not identify the volume, restarting the download\n")
+	default:
+		previous := &rangedDownload{}
+		if err := json.Unmarshal(data, previous); err == nil && previous.Size == size && previous.ETag == etag && previous.ChunkSize == rangeChunkSize {
+			download.Completed = previous.Completed
+		} else {
+			printToOutput("The volume changed since the previous download, restarting it\n")
+		}
+	}
+	if len(download.Completed) > 0 {
+		printToOutput("Resuming the download, %d of %d ranges were already downloaded\n", len(download.Completed), download.chunks())
+	}
+	return download
+}
+
+func (d *rangedDownload) chunks() int64 {
+	return (d.Size + d.ChunkSize - 1) / d.ChunkSize
+}
+
+// run downloads the missing ranges
+func (d *rangedDownload) run(fetch func(map[string]string) (*http.Response, error), output *os.File, parallel, retries int) error {
+	completed := make(map[int64]bool, len(d.Completed))
+	for _, index := range d.Completed {
+		completed[index] = true
+	}
+
+	bar := downloadBarTemplate().New(0).SetTotal(d.Size)
+	for index := range completed {
+		start, end := d.chunkRange(index)
+		bar.Add64(end - start + 1)
+	}
+	bar.Start()
+	defer bar.Finish()
+
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(parallel)
+	for index := int64(0); index < d.chunks(); index++ {
+		if completed[index] {
+			continue
+		}
+		group.Go(func() error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return d.downloadChunk(fetch, output, index, bar, retries)
+		})
+	}
+	return group.Wait()
+}
+
+func (d *rangedDownload) chunkRange(index int64) (int64, int64) {
+	start := index * d.ChunkSize
+	return start, min(start+d.ChunkSize, d.Size) - 1
+}
+
+// downloadChunk downloads a range, retrying with exponential backoff
+func (d *rangedDownload) downloadChunk(fetch func(map[string]string) (*http.Response, error), output *os.File, index int64, bar *pb.ProgressBar, retries int) error {
+	start, end := d.chunkRange(index)
+	interval := retryInterval
+	for attempt := 0; ; attempt++ {
+		written, err := d.fetchChunk(fetch, output, start, end, bar)
+		if err == nil {
+			return d.complete(index)
+		}
+		bar.Add64(-written)
+		if attempt >= retries || errors.Is(err, errVolumeChanged) {
+			return fmt.Errorf("failed to download bytes %d-%d: %w", start, end, err)
+		}
+		printToOutput("Failed to download bytes %d-%d: %v, retrying in %s\n", start, end, err, interval)
+		time.Sleep(interval)
+		interval = min(2*interval, maxRetryInterval)
+	}
+}
+
+func (d *rangedDownload) fetchChunk(fetch func(map[string]string) (*http.Response, error), output *os.File, start, end int64, bar *pb.ProgressBar) (int64, error) {
+	headers := map[string]string{"Range": fmt.Sprintf("bytes=%d-%d", start, end)}
+	if d.ETag != "" {
+		headers["If-Range"] = d.ETag
+	}
+	resp, err := fetch(headers)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// the server ignores the range if the ETag does not match anymore
+		return 0, errVolumeChanged
+	default:
+		return 0, fmt.Errorf("bad status: %s", resp.Status)
+	}
+	if contentRange := fmt.Sprintf("bytes %d-%d/%d", start, end, d.Size); resp.Header.Get("Content-Range") != contentRange {
+		return 0, fmt.Errorf("unexpected Content-Range %q instead of %q", resp.Header.Get("Content-Range"), contentRange)
+	}
+
+	length := end - start + 1
+	written, err := io.Copy(io.NewOffsetWriter(output, start), bar.NewProxyReader(io.LimitReader(resp.Body, length)))
+	if err == nil && written != length {
+		err = io.ErrUnexpectedEOF
+	}
+	return written, err
+}
+
+// complete records that a range was downloaded
+func (d *rangedDownload) complete(index int64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.Completed = append(d.Completed, index)
+	return d.save()
+}
+
+// save stores the progress of the download, replacing the previous one atomically
+func (d *rangedDownload) save() error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	tmp := d.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, d.path)
+}
+
+// verifyOutputChecksum compares the checksum of the output file with the one published by the export server
+func verifyOutputChecksum(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, volumeFormat *exportv1.VirtualMachineExportVolumeFormat, output *os.File) error {
+	if volumeFormat.ChecksumUrl == "" {
+		return nil
+	}
+	printToOutput("Computing the checksum of the downloaded volume\n")
+	if _, err := output.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, output); err != nil {
+		return err
+	}
+	return verifyChecksum(client, vmexport, vmeInfo, volumeFormat.ChecksumUrl, hex.EncodeToString(hash.Sum(nil)))
+}
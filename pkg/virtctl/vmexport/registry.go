@@ -0,0 +1,402 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package vmexport
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/oci"
+)
+
+// maxBufferedBlobSize is the maximum size of the blobs which are kept in memory until the
+// index of the image layout is read, bigger blobs are pushed while they are downloaded
+const maxBufferedBlobSize = 1024 * 1024
+
+// registry is a minimal client of the OCI distribution API used to push containerdisk images
+type registry struct {
+	client        *http.Client
+	ref           *oci.Reference
+	username      string
+	password      string
+	authorization string
+}
+
+func newRegistry(ref *oci.Reference, username, password string) *registry {
+	return &registry{
+		client:   &http.Client{},
+		ref:      ref,
+		username: username,
+		password: password,
+	}
+}
+
+// url builds the URL of an endpoint of the repository
+func (r *registry) url(endpoint string) string {
+	scheme := "https"
+	host := r.ref.RegistryHost()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	// local registries are usually not served over TLS
+	if host == "localhost" || host == "127.0.0.1" || host == "::1" {
+		scheme = "http"
+	}
+	if endpoint == "" {
+		return fmt.Sprintf("%s://%s/v2/", scheme, r.ref.RegistryHost())
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s", scheme, r.ref.RegistryHost(), r.ref.Repository, endpoint)
+}
+
+func (r *registry) do(req *http.Request) (*http.Response, error) {
+	if r.authorization != "" {
+		req.Header.Set("Authorization", r.authorization)
+	}
+	return r.client.Do(req)
+}
+
+// authenticate answers the challenge of the registry, if any
+func (r *registry) authenticate() error {
+	resp, err := r.client.Get(r.url(""))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return registryError(resp, "access registry "+r.ref.Registry)
+	}
+
+	scheme, params := parseChallenge(resp.Header.Get("WWW-Authenticate"))
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if r.username == "" {
+			return fmt.Errorf("registry %s requires credentials, specify them with %s", r.ref.Registry, PULL_SECRET_FLAG)
+		}
+		r.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(r.username+":"+r.password))
+	case "bearer":
+		token, err := r.token(params)
+		if err != nil {
+			return err
+		}
+		r.authorization = "Bearer " + token
+	default:
+		return fmt.Errorf("registry %s requested the unsupported authentication scheme %q", r.ref.Registry, scheme)
+	}
+	return nil
+}
+
+// token requests a token to push to the repository from the authorization server of the registry
+func (r *registry) token(params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("registry %s requested a token from an invalid realm %q", r.ref.Registry, params["realm"])
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull,push", r.ref.Repository))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", registryError(resp, "get a token for "+r.ref.Repository)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("registry %s returned an empty token", r.ref.Registry)
+}
+
+func (r *registry) blobExists(digest string) (bool, error) {
+	req, err := http.NewRequest(http.MethodHead, r.url("blobs/"+digest), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := r.do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, registryError(resp, "check blob "+digest)
+	}
+}
+
+// pushBlob uploads a blob in a single request, unless the registry already has it
+func (r *registry) pushBlob(digest string, size int64, data io.Reader) error {
+	exists, err := r.blobExists(digest)
+	if err != nil || exists {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.url("blobs/uploads/"), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return registryError(resp, "start the upload of blob "+digest)
+	}
+	location, err := resp.Location()
+	if err != nil {
+		return err
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	req, err = http.NewRequest(http.MethodPut, location.String(), io.NopCloser(data))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err = r.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return registryError(resp, "upload blob "+digest)
+	}
+	return nil
+}
+
+// pushManifest uploads a manifest tagged with the tag of the reference
+func (r *registry) pushManifest(mediaType string, manifest []byte) error {
+	req, err := http.NewRequest(http.MethodPut, r.url("manifests/"+r.ref.Tag), bytes.NewReader(manifest))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", mediaType)
+	resp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return registryError(resp, "upload manifest of "+r.ref.String())
+	}
+	return nil
+}
+
+// pushImageLayout reads an OCI image layout tarball and pushes its image to the registry.
+// The index comes last in the layouts of the export server, so the blobs are pushed
+// while the tarball is read.
+func (r *registry) pushImageLayout(layout io.Reader) error {
+	if err := r.authenticate(); err != nil {
+		return err
+	}
+
+	blobs := map[string][]byte{}
+	tr := tar.NewReader(layout)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("the image layout has no %s", oci.IndexFile)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case header.Name == oci.IndexFile:
+			index, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			return r.pushIndex(index, blobs)
+		case header.Typeflag == tar.TypeReg && strings.HasPrefix(header.Name, oci.BlobsDir):
+			digest := "sha256:" + strings.TrimPrefix(header.Name, oci.BlobsDir)
+			if header.Size > maxBufferedBlobSize {
+				if err := r.pushBlob(digest, header.Size, tr); err != nil {
+					return err
+				}
+				continue
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			blobs[digest] = data
+		}
+	}
+}
+
+// pushIndex pushes the manifests of the index, and the buffered blobs they reference
+func (r *registry) pushIndex(data []byte, blobs map[string][]byte) error {
+	index := &oci.Index{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return fmt.Errorf("invalid index of the image layout: %v", err)
+	}
+	if len(index.Manifests) == 0 {
+		return fmt.Errorf("the index of the image layout has no manifests")
+	}
+	for _, desc := range index.Manifests {
+		data, ok := blobs[desc.Digest]
+		if !ok {
+			return fmt.Errorf("the image layout has no manifest %s", desc.Digest)
+		}
+		manifest := &oci.Manifest{}
+		if err := json.Unmarshal(data, manifest); err != nil {
+			return fmt.Errorf("invalid manifest %s: %v", desc.Digest, err)
+		}
+		for _, blob := range append([]oci.Descriptor{manifest.Config}, manifest.Layers...) {
+			if blobData, ok := blobs[blob.Digest]; ok {
+				if err := r.pushBlob(blob.Digest, int64(len(blobData)), bytes.NewReader(blobData)); err != nil {
+					return err
+				}
+			}
+		}
+		if err := r.pushManifest(desc.MediaType, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseChallenge parses the scheme and the parameters of a WWW-Authenticate header
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimLeft(rest, ", ") {
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !strings.HasPrefix(value, `"`) {
+			params[key], rest, _ = strings.Cut(value, ",")
+			continue
+		}
+		end := strings.Index(value[1:], `"`)
+		if end < 0 {
+			break
+		}
+		params[key] = value[1 : end+1]
+		rest = value[end+2:]
+	}
+	return scheme, params
+}
+
+func registryError(resp *http.Response, action string) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if message := strings.TrimSpace(string(body)); message != "" {
+		return fmt.Errorf("failed to %s: %s: %s", action, resp.Status, message)
+	}
+	return fmt.Errorf("failed to %s: %s", action, resp.Status)
+}
+
+// getRegistryCredentials gets the credentials of the registry from a pull secret
+func getRegistryCredentials(client kubecli.KubevirtClient, namespace, secretName string, ref *oci.Reference) (string, string, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	data, ok := secret.Data[k8sv1.DockerConfigJsonKey]
+	if !ok {
+		return "", "", fmt.Errorf("secret '%s/%s' is not of type %s", namespace, secretName, k8sv1.SecretTypeDockerConfigJson)
+	}
+	config := struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", "", fmt.Errorf("invalid pull secret '%s/%s': %v", namespace, secretName, err)
+	}
+	for server, auth := range config.Auths {
+		if registryHost(server) != ref.Registry {
+			continue
+		}
+		if auth.Auth == "" {
+			return auth.Username, auth.Password, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid credentials of %s in pull secret '%s/%s': %v", server, namespace, secretName, err)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return username, password, nil
+	}
+	return "", "", fmt.Errorf("pull secret '%s/%s' has no credentials for %s", namespace, secretName, ref.Registry)
+}
+
+// registryHost returns the registry of a server in a docker config, which may be a URL
+func registryHost(server string) string {
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		server = u.Host
+	}
+	server, _, _ = strings.Cut(server, "/")
+	if server == "index.docker.io" || server == "registry-1.docker.io" {
+		return "docker.io"
+	}
+	return server
+}
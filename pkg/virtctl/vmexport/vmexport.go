@@ -22,8 +22,10 @@ package vmexport
 import (
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log"
@@ -54,6 +56,7 @@ import (
 
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 
+	"kubevirt.io/kubevirt/pkg/storage/export/oci"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
@@ -82,14 +85,23 @@ const (
 	PORT_FORWARD_FLAG   = "--port-forward"
 	LOCAL_PORT_FLAG     = "--local-port"
 	RETRY_FLAG          = "--retry"
+	CONTAINERDISK_FLAG  = "--containerdisk-image"
+	PUSH_FLAG           = "--push"
+	PULL_SECRET_FLAG    = "--pull-secret"
+	PARALLEL_FLAG       = "--parallel"
+	RESUME_FLAG         = "--resume"
 
 	// Possible output format for manifests
 	OUTPUT_FORMAT_JSON = "json"
 	OUTPUT_FORMAT_YAML = "yaml"
 
 	// Possible output format for volumes
-	GZIP_FORMAT = "gzip"
-	RAW_FORMAT  = "raw"
+	GZIP_FORMAT    = "gzip"
+	RAW_FORMAT     = "raw"
+	QCOW2_FORMAT   = "qcow2"
+	RAW_ZST_FORMAT = "raw.zst"
+	// CONTAINERDISK_FORMAT is an OCI image layout tarball of a containerdisk image
+	CONTAINERDISK_FORMAT = "containerdisk"
 
 	ACCEPT           = "Accept"
 	APPLICATION_YAML = "application/yaml"
@@ -99,6 +111,10 @@ const (
 	processingWaitInterval = 2 * time.Second
 	// processingWaitTotal is the maximum time used to wait for a virtualMachineExport to be ready
 	processingWaitTotal = 2 * time.Minute
+	// checksumWaitInterval is the time interval used to wait for a checksum if the server does not specify one
+	checksumWaitInterval = 10 * time.Second
+	// checksumWaitTotal is the maximum time used to wait for the export server to compute a checksum
+	checksumWaitTotal = time.Hour
 
 	// exportTokenHeader is the http header used to download the exported volume using the secret token
 	exportTokenHeader = "x-kubevirt-export-token"
@@ -119,6 +135,8 @@ const (
 	ErrIncompatibleExportTypeManifest = "cannot get manifest for PVC export"
 	// ErrInvalidValue ensures that the value provided in a flag is one of the acceptable values
 	ErrInvalidValue = "%s is not a valid value, acceptable values are %s"
+	// ErrRequiredFormat serves as error message when a flag is used with an incompatible format
+	ErrRequiredFormat = "the '%s' flag can only be used with '%s=%s'"
 
 	// progressBarCycle is a const used to store the cycle displayed in the progress bar when downloading the exported volume
 	progressBarCycle = `"[___________________]" "[==>________________]" "[====>______________]" "[======>____________]" "[========>__________]" "[==========>________]" "[============>______]" "[==============>____]" "[================>__]" "[==================>]"`
@@ -144,6 +162,11 @@ var (
 	ttl                  string
 	manifestOutputFormat string
 	downloadRetries      int
+	containerDiskImage   string
+	push                 bool
+	pullSecret           string
+	parallel             int
+	resume               bool
 )
 
 type VMExportInfo struct {
@@ -154,6 +177,7 @@ type VMExportInfo struct {
 	IncludeSecret   bool
 	ExportManifest  bool
 	Decompress      bool
+	Format          string
 	PortForward     bool
 	LocalPort       string
 	OutputFile      string
@@ -166,6 +190,15 @@ type VMExportInfo struct {
 	ExportSource    k8sv1.TypedLocalObjectReference
 	TTL             metav1.Duration
 	DownloadRetries int
+	// ContainerDiskImage is the image the volume is exported as
+	ContainerDiskImage string
+	// Push pushes the containerdisk image to its registry instead of downloading it
+	Push       bool
+	PullSecret string
+	// Parallel is the number of ranges of a raw volume which are downloaded in parallel
+	Parallel int
+	// Resume skips the ranges of a raw volume which were downloaded by a previous attempt
+	Resume bool
 }
 
 type command struct {
@@ -258,7 +291,19 @@ func usage() string {
 	{{ProgramName}} vmexport download vm1-export --vm=vm1 --manifest
 
 	# Get the VirtualMachine manifest in Yaml format from an existing VirtualMachineExport including CDI header secret
-	{{ProgramName}} vmexport download existing-export --include-secret --manifest`
+	{{ProgramName}} vmexport download existing-export --include-secret --manifest
+
+	# Create a VirtualMachineExport and download a volume as an OCI image layout of a containerdisk image
+	{{ProgramName}} vmexport download vm1-export --vm=vm1 --volume=volume1 --containerdisk-image=registry.example.com/vm1:v1 --format=containerdisk --output=vm1.tar
+
+	# Push a volume of an existing VirtualMachineExport as a containerdisk image, using the credentials of a pull secret
+	{{ProgramName}} vmexport download vm1-export --volume=volume1 --format=containerdisk --push --pull-secret=registry-secret
+
+	# Download a raw volume in 4 parallel ranges, resuming a previous download and retrying failed ranges up to 5 times
+	{{ProgramName}} vmexport download vm1-export --volume=volume1 --format=raw --output=disk.img --parallel=4 --resume --retry=5
+
+	# Get the VirtualMachine manifest with the volume replaced by a containerdisk image
+	{{ProgramName}} vmexport download vm1-export --vm=vm1 --volume=volume1 --containerdisk-image=registry.example.com/vm1:v1 --manifest`
 	return usage
 }
 
@@ -283,7 +328,7 @@ func NewVirtualMachineExportCommand(clientConfig clientcmd.ClientConfig) *cobra.
 	cmd.MarkFlagsMutuallyExclusive("vm", "snapshot", "pvc")
 	cmd.Flags().StringVar(&outputFile, "output", "", "Specifies the output path of the volume to be downloaded.")
 	cmd.Flags().StringVar(&volumeName, "volume", "", "Specifies the volume to be downloaded.")
-	cmd.Flags().StringVar(&format, "format", "", "Used to specify the format of the downloaded image. There's two options: gzip (default) and raw.")
+	cmd.Flags().StringVar(&format, "format", "", "Used to specify the format of the downloaded image. There's five options: gzip (default), raw, qcow2, raw.zst and containerdisk.")
 	cmd.Flags().BoolVar(&insecure, "insecure", false, "When used with the 'download' option, specifies that the http request should be insecure.")
 	cmd.Flags().BoolVar(&keepVme, "keep-vme", false, "When used with the 'download' option, specifies that the vmexport object should always be retained after the download finishes.")
 	cmd.Flags().BoolVar(&deleteVme, "delete-vme", false, "When used with the 'download' option, specifies that the vmexport object should always be deleted after the download finishes.")
@@ -294,8 +339,13 @@ func NewVirtualMachineExportCommand(clientConfig clientcmd.ClientConfig) *cobra.
 	cmd.Flags().BoolVar(&portForward, "port-forward", false, "Configures port-forwarding on a random port. Useful to download without proper ingress/route configuration")
 	cmd.Flags().StringVar(&localPort, "local-port", "0", "Defines the specific port to be used in port-forward.")
 	cmd.Flags().IntVar(&downloadRetries, "retry", 0, "When export server returns a transient error, we retry this number of times before giving up")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "When used with the 'raw' format, downloads this number of ranges of the volume in parallel.")
+	cmd.Flags().BoolVar(&resume, "resume", false, "When used with the 'raw' format, resumes a previous download into the output file instead of starting over. Downloads of block volumes always start over.")
 	cmd.Flags().BoolVar(&includeSecret, "include-secret", false, "When used with manifest and set to true include a secret that contains proper headers for CDI to import using the manifest")
 	cmd.Flags().BoolVar(&exportManifest, "manifest", false, "Instead of downloading a volume, retrieve the VM manifest")
+	cmd.Flags().StringVar(&containerDiskImage, "containerdisk-image", "", "Exports the volume as a containerdisk image with this reference, the VM manifest references the image instead of the volume.")
+	cmd.Flags().BoolVar(&push, "push", false, "When used with the 'containerdisk' format, pushes the image to its registry instead of downloading it.")
+	cmd.Flags().StringVar(&pullSecret, "pull-secret", "", "The pull secret with the credentials of the registry the containerdisk image is pushed to.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
 	return cmd
@@ -374,7 +424,12 @@ func (c *command) initVMExportInfo(vmeInfo *VMExportInfo) error {
 	// User wants the output in a file, create
 	if outputFile != "" && outputFile != "-" {
 		vmeInfo.OutputFile = outputFile
-		output, err := os.Create(vmeInfo.OutputFile)
+		flag := os.O_RDWR | os.O_CREATE | os.O_TRUNC
+		if resume {
+			// Keep what was downloaded already
+			flag &^= os.O_TRUNC
+		}
+		output, err := os.OpenFile(vmeInfo.OutputFile, flag, 0666)
 		if err != nil {
 			return err
 		}
@@ -392,6 +447,7 @@ func (c *command) initVMExportInfo(vmeInfo *VMExportInfo) error {
 	if format == RAW_FORMAT {
 		vmeInfo.Decompress = true
 	}
+	vmeInfo.Format = format
 	vmeInfo.DownloadRetries = downloadRetries
 	vmeInfo.ShouldCreate = shouldCreate
 	vmeInfo.Insecure = insecure
@@ -402,6 +458,11 @@ func (c *command) initVMExportInfo(vmeInfo *VMExportInfo) error {
 	vmeInfo.OutputFormat = manifestOutputFormat
 	vmeInfo.IncludeSecret = includeSecret
 	vmeInfo.ExportManifest = exportManifest
+	vmeInfo.ContainerDiskImage = containerDiskImage
+	vmeInfo.Push = push
+	vmeInfo.PullSecret = pullSecret
+	vmeInfo.Parallel = parallel
+	vmeInfo.Resume = resume
 	if portForward {
 		vmeInfo.PortForward = portForward
 		vmeInfo.Insecure = true
@@ -459,6 +520,12 @@ func CreateVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExport
 	if vmeInfo.TTL.Duration > 0 {
 		vmexport.Spec.TTLDuration = &vmeInfo.TTL
 	}
+	if vmeInfo.ContainerDiskImage != "" {
+		vmexport.Spec.ContainerDisk = &exportv1.VirtualMachineExportContainerDisk{
+			Volume: vmeInfo.VolumeName,
+			Image:  vmeInfo.ContainerDiskImage,
+		}
+	}
 
 	vmexport, err = client.VirtualMachineExport(vmeInfo.Namespace).Create(context.TODO(), vmexport, metav1.CreateOptions{})
 	if err != nil {
@@ -596,10 +663,22 @@ func getVirtualMachineManifest(client kubecli.KubevirtClient, vmexport *exportv1
 // downloadVolume handles the process of downloading the requested volume from a VirtualMachineExport
 func downloadVolume(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) (bool, error) {
 	// Extract the URL from the vmexport
-	downloadUrl, err := GetUrlFromVirtualMachineExport(vmexport, vmeInfo)
+	volumeFormat, err := getVolumeFormatFromVirtualMachineExport(vmexport, vmeInfo)
 	if err != nil {
 		return false, err
 	}
+	downloadUrl, err := replaceUrlWithServiceUrl(volumeFormat.Url, vmeInfo)
+	if err != nil {
+		return false, err
+	}
+
+	if vmeInfo.rangedDownload() {
+		if err := downloadRanges(client, vmexport, vmeInfo, volumeFormat, downloadUrl); err != nil {
+			return false, err
+		}
+		printToOutput("Download finished succesfully\n")
+		return true, nil
+	}
 
 	resp, err := HandleHTTPRequest(client, vmexport, downloadUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, nil)
 	if err != nil {
@@ -614,15 +693,117 @@ func downloadVolume(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMac
 	}
 
 	// Lastly, copy the file to the expected output
-	if err := copyFileWithProgressBar(vmeInfo.OutputWriter, resp, vmeInfo.Decompress); err != nil {
+	hash := sha256.New()
+	if vmeInfo.Push {
+		if err := pushContainerDisk(client, vmexport, vmeInfo, io.TeeReader(resp.Body, hash)); err != nil {
+			return false, err
+		}
+	} else if err := copyFileWithProgressBar(vmeInfo.OutputWriter, io.TeeReader(resp.Body, hash), vmeInfo.Decompress); err != nil {
 		return false, err
 	}
 
+	if volumeFormat.ChecksumUrl != "" {
+		if err := verifyChecksum(client, vmexport, vmeInfo, volumeFormat.ChecksumUrl, hex.EncodeToString(hash.Sum(nil))); err != nil {
+			return false, err
+		}
+	}
+
 	printToOutput("Download finished succesfully\n")
 
 	return true, nil
 }
 
+// pushContainerDisk pushes the containerdisk image in the downloaded image layout to its registry
+func pushContainerDisk(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, layout io.Reader) error {
+	if vmexport.Spec.ContainerDisk == nil {
+		return fmt.Errorf("VirtualMachineExport '%s/%s' does not export a containerdisk image", vmexport.Namespace, vmexport.Name)
+	}
+	ref, err := oci.ParseReference(vmexport.Spec.ContainerDisk.Image)
+	if err != nil {
+		return err
+	}
+	var username, password string
+	if vmeInfo.PullSecret != "" {
+		if username, password, err = getRegistryCredentials(client, vmexport.Namespace, vmeInfo.PullSecret, ref); err != nil {
+			return err
+		}
+	}
+
+	pr, pw := io.Pipe()
+	pushed := make(chan error, 1)
+	go func() {
+		err := newRegistry(ref, username, password).pushImageLayout(pr)
+		if err == nil {
+			// The rest of the tarball is still needed to verify the checksum
+			_, err = io.Copy(io.Discard, pr)
+		}
+		pr.CloseWithError(err)
+		pushed <- err
+	}()
+	printToOutput("Pushing %s\n", ref)
+	err = copyFileWithProgressBar(pw, layout, false)
+	pw.CloseWithError(err)
+	if pushErr := <-pushed; pushErr != nil {
+		return pushErr
+	}
+	return err
+}
+
+// verifyChecksum compares the checksum of the downloaded volume with the one published by the export server
+func verifyChecksum(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, checksumUrl, checksum string) error {
+	checksumUrl, err := replaceUrlWithServiceUrl(checksumUrl, vmeInfo)
+	if err != nil {
+		return err
+	}
+	// the export server computes the checksums when it starts and is unavailable until they are done
+	var resp *http.Response
+	deadline := time.Now().Add(checksumWaitTotal)
+	for {
+		resp, err = HandleHTTPRequest(client, vmexport, checksumUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, nil)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusServiceUnavailable || time.Now().After(deadline) {
+			break
+		}
+		resp.Body.Close()
+		printToOutput("Waiting for the checksum of the volume to be computed...\n")
+		time.Sleep(retryAfter(resp))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to get the checksum of the volume: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// the checksum is in the format of sha256sum
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return fmt.Errorf("invalid checksum of the volume: %q", body)
+	}
+	if fields[0] != checksum {
+		return fmt.Errorf("checksum mismatch, the downloaded volume has the SHA-256 checksum %s instead of %s", checksum, fields[0])
+	}
+	printToOutput("Checksum verified\n")
+	return nil
+}
+
+// retryAfter returns the time to wait before retrying a request, as requested by the server
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return checksumWaitInterval
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// rangedDownload decides wether the volume is downloaded in ranges, which is only possible with the raw image
+func (vmeInfo *VMExportInfo) rangedDownload() bool {
+	return vmeInfo.Parallel > 1 || vmeInfo.Resume
+}
+
 // shouldDeleteVMExport decides wether we should retain or delete a VMExport after a download. If delete/retain are not explicitly specified,
 // the vmexport will be deleted when is created in the same instance as the download, retained otherwise.
 func shouldDeleteVMExport(vmeInfo *VMExportInfo) bool {
@@ -643,11 +824,18 @@ func replaceUrlWithServiceUrl(manifestUrl string, vmeInfo *VMExportInfo) (string
 
 // GetUrlFromVirtualMachineExport inspects the VirtualMachineExport status to fetch the extected URL
 func GetUrlFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) (string, error) {
+	volumeFormat, err := getVolumeFormatFromVirtualMachineExport(vmexport, vmeInfo)
+	if err != nil {
+		return "", err
+	}
+	return replaceUrlWithServiceUrl(volumeFormat.Url, vmeInfo)
+}
+
+// getVolumeFormatFromVirtualMachineExport inspects the VirtualMachineExport status to fetch the format of the volume to download
+func getVolumeFormatFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) (*exportv1.VirtualMachineExportVolumeFormat, error) {
 	var (
-		downloadUrl string
-		err         error
-		format      exportv1.VirtualMachineExportVolumeFormat
-		links       *exportv1.VirtualMachineExportLink
+		volumeFormat *exportv1.VirtualMachineExportVolumeFormat
+		links        *exportv1.VirtualMachineExportLink
 	)
 
 	if vmeInfo.ServiceURL == "" && vmexport.Status.Links != nil && vmexport.Status.Links.External != nil {
@@ -656,21 +844,34 @@ func GetUrlFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vme
 		links = vmexport.Status.Links.Internal
 	}
 	if links == nil || len(links.Volumes) <= 0 {
-		return "", fmt.Errorf("unable to access the volume info from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
+		return nil, fmt.Errorf("unable to access the volume info from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
 	}
 	volumeNumber := len(links.Volumes)
 	if volumeNumber > 1 && vmeInfo.VolumeName == "" {
-		return "", fmt.Errorf("detected more than one downloadable volume in '%s/%s' VirtualMachineExport: Select the expected volume using the --volume flag", vmexport.Namespace, vmexport.Name)
+		return nil, fmt.Errorf("detected more than one downloadable volume in '%s/%s' VirtualMachineExport: Select the expected volume using the --volume flag", vmexport.Namespace, vmexport.Name)
 	}
 	for _, exportVolume := range links.Volumes {
 		// Access the requested volume
 		if volumeNumber == 1 || exportVolume.Name == vmeInfo.VolumeName {
-			for _, format = range exportVolume.Formats {
-				if format.Format == exportv1.KubeVirtGz || format.Format == exportv1.ArchiveGz || format.Format == exportv1.KubeVirtRaw {
-					downloadUrl, err = replaceUrlWithServiceUrl(format.Url, vmeInfo)
-					if err != nil {
-						return "", err
+			for i, format := range exportVolume.Formats {
+				// Ranged downloads need the raw image
+				if vmeInfo.rangedDownload() {
+					if format.Format == exportv1.KubeVirtRaw {
+						volumeFormat = &exportVolume.Formats[i]
+						break
 					}
+					continue
+				}
+				// qcow2, raw.zst and containerdisk are only downloaded if they were requested explicitly
+				if vmeInfo.Format == QCOW2_FORMAT || vmeInfo.Format == RAW_ZST_FORMAT || vmeInfo.Format == CONTAINERDISK_FORMAT {
+					if string(format.Format) == vmeInfo.Format {
+						volumeFormat = &exportVolume.Formats[i]
+						break
+					}
+					continue
+				}
+				if format.Format == exportv1.KubeVirtGz || format.Format == exportv1.ArchiveGz || format.Format == exportv1.KubeVirtRaw {
+					volumeFormat = &exportVolume.Formats[i]
 				}
 				// By default, we always attempt to find and get the compressed file URL,
 				// so we only break the loop when one is found.
@@ -681,16 +882,16 @@ func GetUrlFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vme
 		}
 	}
 
-	// No need to decompress file if format is not gzip
-	if format.Format == exportv1.KubeVirtRaw {
-		vmeInfo.Decompress = false
+	if volumeFormat == nil {
+		return nil, fmt.Errorf("unable to get a valid URL from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
 	}
 
-	if downloadUrl == "" {
-		return "", fmt.Errorf("unable to get a valid URL from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
+	// No need to decompress file if format is not gzip
+	if volumeFormat.Format != exportv1.KubeVirtGz && volumeFormat.Format != exportv1.ArchiveGz {
+		vmeInfo.Decompress = false
 	}
 
-	return downloadUrl, nil
+	return volumeFormat, nil
 }
 
 // GetManifestUrlsFromVirtualMachineExport retrieves the manifest URLs from VirtualMachineExport status
@@ -812,15 +1013,19 @@ func getHTTPClient(transport *http.Transport, insecure bool) *http.Client {
 	return client
 }
 
+// downloadBarTemplate returns the template of the progress bar displayed when downloading the exported volume
+func downloadBarTemplate() pb.ProgressBarTemplate {
+	return pb.ProgressBarTemplate(fmt.Sprintf(`{{ "Downloading file:" }} {{counters . }} {{ cycle . %s }} {{speed . }}`, progressBarCycle))
+}
+
 // copyFileWithProgressBar serves as a wrapper to copy the file with a progress bar
-func copyFileWithProgressBar(output io.Writer, resp *http.Response, decompress bool) error {
+func copyFileWithProgressBar(output io.Writer, body io.Reader, decompress bool) error {
 	var rd io.Reader
-	barTemplate := fmt.Sprintf(`{{ "Downloading file:" }} {{counters . }} {{ cycle . %s }} {{speed . }}`, progressBarCycle)
 
 	// start bar based on our template
-	bar := pb.ProgressBarTemplate(barTemplate).Start(0)
+	bar := downloadBarTemplate().Start(0)
 	defer bar.Finish()
-	barRd := bar.NewProxyReader(resp.Body)
+	barRd := bar.NewProxyReader(body)
 	rd = barRd
 	bar.Start()
 
@@ -926,9 +1131,12 @@ func handleCreateFlags() error {
 	if outputFile != "" {
 		return fmt.Errorf(ErrIncompatibleFlag, OUTPUT_FLAG, CREATE)
 	}
-	if volumeName != "" {
+	if volumeName != "" && containerDiskImage == "" {
 		return fmt.Errorf(ErrIncompatibleFlag, VOLUME_FLAG, CREATE)
 	}
+	if containerDiskImage != "" && volumeName == "" {
+		return fmt.Errorf(ErrRequiredFlag, VOLUME_FLAG, CONTAINERDISK_FLAG)
+	}
 	if insecure {
 		return fmt.Errorf(ErrIncompatibleFlag, INSECURE_FLAG, CREATE)
 	}
@@ -953,6 +1161,18 @@ func handleCreateFlags() error {
 	if downloadRetries != 0 {
 		return fmt.Errorf(ErrIncompatibleFlag, RETRY_FLAG, CREATE)
 	}
+	if push {
+		return fmt.Errorf(ErrIncompatibleFlag, PUSH_FLAG, CREATE)
+	}
+	if pullSecret != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, PULL_SECRET_FLAG, CREATE)
+	}
+	if parallel != 1 {
+		return fmt.Errorf(ErrIncompatibleFlag, PARALLEL_FLAG, CREATE)
+	}
+	if resume {
+		return fmt.Errorf(ErrIncompatibleFlag, RESUME_FLAG, CREATE)
+	}
 
 	return nil
 }
@@ -993,6 +1213,21 @@ func handleDeleteFlags() error {
 	if downloadRetries != 0 {
 		return fmt.Errorf(ErrIncompatibleFlag, RETRY_FLAG, DELETE)
 	}
+	if containerDiskImage != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, CONTAINERDISK_FLAG, DELETE)
+	}
+	if push {
+		return fmt.Errorf(ErrIncompatibleFlag, PUSH_FLAG, DELETE)
+	}
+	if pullSecret != "" {
+		return fmt.Errorf(ErrIncompatibleFlag, PULL_SECRET_FLAG, DELETE)
+	}
+	if parallel != 1 {
+		return fmt.Errorf(ErrIncompatibleFlag, PARALLEL_FLAG, DELETE)
+	}
+	if resume {
+		return fmt.Errorf(ErrIncompatibleFlag, RESUME_FLAG, DELETE)
+	}
 
 	return nil
 }
@@ -1011,8 +1246,47 @@ func handleDownloadFlags() error {
 		}
 	}
 
-	if format != "" && format != GZIP_FORMAT && format != RAW_FORMAT {
-		return fmt.Errorf(ErrInvalidValue, FORMAT_FLAG, "gzip/raw")
+	if format != "" && format != GZIP_FORMAT && format != RAW_FORMAT && format != QCOW2_FORMAT && format != RAW_ZST_FORMAT && format != CONTAINERDISK_FORMAT {
+		return fmt.Errorf(ErrInvalidValue, FORMAT_FLAG, "gzip/raw/qcow2/raw.zst/containerdisk")
+	}
+
+	if containerDiskImage != "" {
+		if !shouldCreate {
+			return fmt.Errorf("the '%s' flag can only be used when creating a VirtualMachineExport [--vm|--snapshot|--pvc]", CONTAINERDISK_FLAG)
+		}
+		if volumeName == "" {
+			return fmt.Errorf(ErrRequiredFlag, VOLUME_FLAG, CONTAINERDISK_FLAG)
+		}
+	}
+	if push {
+		if format != CONTAINERDISK_FORMAT {
+			return fmt.Errorf(ErrRequiredFormat, PUSH_FLAG, FORMAT_FLAG, CONTAINERDISK_FORMAT)
+		}
+		if outputFile != "" {
+			return fmt.Errorf(ErrIncompatibleFlag, OUTPUT_FLAG, PUSH_FLAG)
+		}
+	}
+	if pullSecret != "" && !push {
+		return fmt.Errorf(ErrRequiredFlag, PUSH_FLAG, PULL_SECRET_FLAG)
+	}
+
+	if parallel < 1 {
+		return fmt.Errorf(ErrInvalidValue, PARALLEL_FLAG, "positive integers")
+	}
+	if parallel > 1 || resume {
+		rangedFlag := RESUME_FLAG
+		if parallel > 1 {
+			rangedFlag = PARALLEL_FLAG
+		}
+		if format != RAW_FORMAT {
+			return fmt.Errorf(ErrRequiredFormat, rangedFlag, FORMAT_FLAG, RAW_FORMAT)
+		}
+		if outputFile == "" || outputFile == "-" {
+			return fmt.Errorf(ErrRequiredFlag, OUTPUT_FLAG+" <FILE>", rangedFlag)
+		}
+		if exportManifest {
+			return fmt.Errorf(ErrIncompatibleFlag, rangedFlag, MANIFEST_FLAG)
+		}
 	}
 
 	if downloadRetries < 0 {
@@ -1020,9 +1294,12 @@ func handleDownloadFlags() error {
 	}
 
 	if exportManifest {
-		if volumeName != "" {
+		if volumeName != "" && containerDiskImage == "" {
 			return fmt.Errorf(ErrIncompatibleFlag, VOLUME_FLAG, MANIFEST_FLAG)
 		}
+		if push {
+			return fmt.Errorf(ErrIncompatibleFlag, PUSH_FLAG, MANIFEST_FLAG)
+		}
 
 		manifestOutputFormat = strings.ToLower(manifestOutputFormat)
 		if manifestOutputFormat != OUTPUT_FORMAT_JSON && manifestOutputFormat != OUTPUT_FORMAT_YAML && manifestOutputFormat != "" {
@@ -1033,7 +1310,7 @@ func handleDownloadFlags() error {
 			return fmt.Errorf(ErrIncompatibleFlag, PVC_FLAG, MANIFEST_FLAG)
 		}
 	}
-	if !exportManifest && outputFile == "" {
+	if !exportManifest && !push && outputFile == "" {
 		return fmt.Errorf("warning: Binary output can mess up your terminal. Use '%s -' to output into stdout anyway or consider '%s <FILE>' to save to a file", OUTPUT_FLAG, OUTPUT_FLAG)
 	}
 
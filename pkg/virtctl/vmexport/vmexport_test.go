@@ -3,11 +3,18 @@ package vmexport_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -24,6 +31,7 @@ import (
 
 	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/storage/export/oci"
 	"kubevirt.io/kubevirt/pkg/virtctl/utils"
 	virtctlvmexport "kubevirt.io/kubevirt/pkg/virtctl/vmexport"
 	"kubevirt.io/kubevirt/tests/clientcmd"
@@ -323,7 +331,22 @@ var _ = Describe("vmexport", func() {
 			Entry("Using 'manifest' with volume type", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.VOLUME_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.VOLUME_FLAG, "volume")),
 			Entry("Using 'manifest' with invalid output_format_flag", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.OUTPUT_FORMAT_FLAG, "json/yaml"), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.OUTPUT_FORMAT_FLAG, "invalid")),
 			Entry("Using 'port-forward' with invalid port", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.LOCAL_PORT_FLAG, "valid port numbers"), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.PORT_FORWARD_FLAG, setflag(virtctlvmexport.LOCAL_PORT_FLAG, "test")),
-			Entry("Using 'format' with invalid download format", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.FORMAT_FLAG, "gzip/raw"), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, "test")),
+			Entry("Using 'format' with invalid download format", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.FORMAT_FLAG, "gzip/raw/qcow2/raw.zst/containerdisk"), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, "test")),
+			Entry("Using 'create' with containerdisk image without volume", fmt.Sprintf(virtctlvmexport.ErrRequiredFlag, virtctlvmexport.VOLUME_FLAG, virtctlvmexport.CONTAINERDISK_FLAG), virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.CONTAINERDISK_FLAG, "registry:5000/vm:v1")),
+			Entry("Using 'create' with push", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PUSH_FLAG, virtctlvmexport.CREATE), virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test"), virtctlvmexport.PUSH_FLAG),
+			Entry("Using 'delete' with containerdisk image", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.CONTAINERDISK_FLAG, virtctlvmexport.DELETE), virtctlvmexport.DELETE, vmexportName, setflag(virtctlvmexport.CONTAINERDISK_FLAG, "registry:5000/vm:v1")),
+			Entry("Using containerdisk image without export type", fmt.Sprintf("the '%s' flag can only be used when creating a VirtualMachineExport [--vm|--snapshot|--pvc]", virtctlvmexport.CONTAINERDISK_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.VOLUME_FLAG, "volume"), setflag(virtctlvmexport.CONTAINERDISK_FLAG, "registry:5000/vm:v1"), setflag(virtctlvmexport.OUTPUT_FLAG, "disk.tar")),
+			Entry("Using containerdisk image without volume", fmt.Sprintf(virtctlvmexport.ErrRequiredFlag, virtctlvmexport.VOLUME_FLAG, virtctlvmexport.CONTAINERDISK_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.CONTAINERDISK_FLAG, "registry:5000/vm:v1"), setflag(virtctlvmexport.OUTPUT_FLAG, "disk.tar")),
+			Entry("Using push without containerdisk format", fmt.Sprintf("the '%s' flag can only be used with '%s=%s'", virtctlvmexport.PUSH_FLAG, virtctlvmexport.FORMAT_FLAG, virtctlvmexport.CONTAINERDISK_FORMAT), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.PUSH_FLAG),
+			Entry("Using push with output", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.OUTPUT_FLAG, virtctlvmexport.PUSH_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.PUSH_FLAG, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.CONTAINERDISK_FORMAT), setflag(virtctlvmexport.OUTPUT_FLAG, "disk.tar")),
+			Entry("Using pull secret without push", fmt.Sprintf(virtctlvmexport.ErrRequiredFlag, virtctlvmexport.PUSH_FLAG, virtctlvmexport.PULL_SECRET_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PULL_SECRET_FLAG, "secret"), setflag(virtctlvmexport.OUTPUT_FLAG, "disk.tar")),
+			Entry("Using push with 'manifest'", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PUSH_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, virtctlvmexport.PUSH_FLAG, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.CONTAINERDISK_FORMAT)),
+			Entry("Using 'create' with parallel", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PARALLEL_FLAG, virtctlvmexport.CREATE), virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.PVC_FLAG, "test"), setflag(virtctlvmexport.PARALLEL_FLAG, "2")),
+			Entry("Using 'delete' with resume", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.RESUME_FLAG, virtctlvmexport.DELETE), virtctlvmexport.DELETE, vmexportName, virtctlvmexport.RESUME_FLAG),
+			Entry("Using parallel with invalid value", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.PARALLEL_FLAG, "positive integers"), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PARALLEL_FLAG, "0"), setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img")),
+			Entry("Using parallel without raw format", fmt.Sprintf(virtctlvmexport.ErrRequiredFormat, virtctlvmexport.PARALLEL_FLAG, virtctlvmexport.FORMAT_FLAG, virtctlvmexport.RAW_FORMAT), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PARALLEL_FLAG, "2"), setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img")),
+			Entry("Using resume without raw format", fmt.Sprintf(virtctlvmexport.ErrRequiredFormat, virtctlvmexport.RESUME_FLAG, virtctlvmexport.FORMAT_FLAG, virtctlvmexport.RAW_FORMAT), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.RESUME_FLAG, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.QCOW2_FORMAT), setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img")),
+			Entry("Using resume with output into stdout", fmt.Sprintf(virtctlvmexport.ErrRequiredFlag, virtctlvmexport.OUTPUT_FLAG+" <FILE>", virtctlvmexport.RESUME_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.RESUME_FLAG, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.RAW_FORMAT), setflag(virtctlvmexport.OUTPUT_FLAG, "-")),
 			Entry("Downloading volume without specifying output", fmt.Sprintf("warning: Binary output can mess up your terminal. Use '%s -' to output into stdout anyway or consider '%s <FILE>' to save to a file", virtctlvmexport.OUTPUT_FLAG, virtctlvmexport.OUTPUT_FLAG), virtctlvmexport.DOWNLOAD, vmexportName),
 		)
 
@@ -464,6 +487,82 @@ var _ = Describe("vmexport", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		DescribeTable("should verify the checksum of the downloaded volume", func(checksum string, expectSuccess bool) {
+			const data = "qcow2 data"
+			virtctlvmexport.HandleHTTPRequest = func(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, exportURL string, headers map[string]string) (*http.Response, error) {
+				body := data
+				if strings.HasSuffix(downloadUrl, ".sha256") {
+					body = checksum + "  disk.qcow2\n"
+				}
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}
+				return &resp, nil
+			}
+			vmexport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
+			vmexport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name: volumeName,
+					Formats: []exportv1.VirtualMachineExportVolumeFormat{{
+						Format:      exportv1.KubeVirtQcow2,
+						Url:         server.URL + "/disk.qcow2",
+						ChecksumUrl: server.URL + "/disk.qcow2.sha256",
+					}},
+				},
+			}, secretName)
+			utils.HandleSecretGet(kubeClient, secretName)
+			utils.HandleVMExportCreate(vmExportClient, vmexport)
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.QCOW2_FORMAT), setflag(virtctlvmexport.PVC_FLAG, "test-pvc"), setflag(virtctlvmexport.VOLUME_FLAG, volumeName), setflag(virtctlvmexport.OUTPUT_FLAG, "test-pvc"), virtctlvmexport.INSECURE_FLAG)
+			err := cmd()
+			if expectSuccess {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring("checksum mismatch")))
+			}
+		},
+			Entry("with a matching checksum", fmt.Sprintf("%x", sha256.Sum256([]byte("qcow2 data"))), true),
+			Entry("with a different checksum", fmt.Sprintf("%x", sha256.Sum256([]byte("other data"))), false),
+		)
+
+		It("should wait until the checksum of the downloaded volume is computed", func() {
+			const data = "qcow2 data"
+			checksumRequests := 0
+			virtctlvmexport.HandleHTTPRequest = func(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, exportURL string, headers map[string]string) (*http.Response, error) {
+				if !strings.HasSuffix(downloadUrl, ".sha256") {
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(data))}, nil
+				}
+				checksumRequests++
+				if checksumRequests < 3 {
+					return &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+						Header:     http.Header{"Retry-After": []string{"0"}},
+						Body:       io.NopCloser(strings.NewReader("")),
+					}, nil
+				}
+				body := fmt.Sprintf("%x  disk.qcow2\n", sha256.Sum256([]byte(data)))
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+			}
+			vmexport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
+			vmexport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name: volumeName,
+					Formats: []exportv1.VirtualMachineExportVolumeFormat{{
+						Format:      exportv1.KubeVirtQcow2,
+						Url:         server.URL + "/disk.qcow2",
+						ChecksumUrl: server.URL + "/disk.qcow2.sha256",
+					}},
+				},
+			}, secretName)
+			utils.HandleSecretGet(kubeClient, secretName)
+			utils.HandleVMExportCreate(vmExportClient, vmexport)
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.QCOW2_FORMAT), setflag(virtctlvmexport.PVC_FLAG, "test-pvc"), setflag(virtctlvmexport.VOLUME_FLAG, volumeName), setflag(virtctlvmexport.OUTPUT_FLAG, "test-pvc"), virtctlvmexport.INSECURE_FLAG)
+			Expect(cmd()).To(Succeed())
+			Expect(checksumRequests).To(Equal(3))
+		})
+
 		It("Succesfully download a VirtualMachineExport without decompressing is url is already raw", func() {
 			vmexport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
 			vmexport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
@@ -554,9 +653,400 @@ var _ = Describe("vmexport", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("Succesfully create VirtualMachineExport with a containerdisk image", func() {
+			vmExportClient.Fake.PrependReactor("create", "virtualmachineexports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				create, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+				vme, ok := create.GetObject().(*exportv1.VirtualMachineExport)
+				Expect(ok).To(BeTrue())
+				Expect(vme.Spec.ContainerDisk).To(Equal(&exportv1.VirtualMachineExportContainerDisk{
+					Volume: volumeName,
+					Image:  "registry:5000/vm:v1",
+				}))
+
+				return true, vme, nil
+			})
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.VM_FLAG, "test-vm"), setflag(virtctlvmexport.VOLUME_FLAG, volumeName), setflag(virtctlvmexport.CONTAINERDISK_FLAG, "registry:5000/vm:v1"))
+			err := cmd()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			testDone()
+		})
+	})
+
+	Context("ContainerDisk push", func() {
+		const pullSecretName = "registry-secret"
+
+		var (
+			orgHttpFunc virtctlvmexport.HandleHTTPRequestFunc
+			registry    *httptest.Server
+			blobs       map[string][]byte
+			manifests   map[string][]byte
+			layout      []byte
+			image       string
+		)
+
+		// registryHandler serves a registry which requires basic authentication
+		registryHandler := func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "pass" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch {
+			case r.URL.Path == "/v2/":
+				w.WriteHeader(http.StatusOK)
+			case r.Method == http.MethodHead && strings.HasPrefix(r.URL.Path, "/v2/vm/disk/blobs/sha256:"):
+				if _, ok := blobs[path.Base(r.URL.Path)]; !ok {
+					w.WriteHeader(http.StatusNotFound)
+				}
+			case r.Method == http.MethodPost && r.URL.Path == "/v2/vm/disk/blobs/uploads/":
+				w.Header().Set("Location", "/v2/vm/disk/blobs/uploads/upload?state=test")
+				w.WriteHeader(http.StatusAccepted)
+			case r.Method == http.MethodPut && r.URL.Path == "/v2/vm/disk/blobs/uploads/upload" && r.URL.Query().Get("state") == "test":
+				data, err := io.ReadAll(r.Body)
+				digest := r.URL.Query().Get("digest")
+				if err != nil || digest != fmt.Sprintf("sha256:%x", sha256.Sum256(data)) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				blobs[digest] = data
+				w.WriteHeader(http.StatusCreated)
+			case r.Method == http.MethodPut && r.URL.Path == "/v2/vm/disk/manifests/v1" && r.Header.Get("Content-Type") == oci.MediaTypeImageManifest:
+				data, err := io.ReadAll(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				manifests["v1"] = data
+				w.WriteHeader(http.StatusCreated)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+
+		BeforeEach(func() {
+			orgHttpFunc = virtctlvmexport.HandleHTTPRequest
+			testInit(defaultHandler)
+			blobs = map[string][]byte{}
+			manifests = map[string][]byte{}
+			registry = httptest.NewServer(http.HandlerFunc(registryHandler))
+			image = registry.Listener.Addr().String() + "/vm/disk:v1"
+
+			// the disk is big enough to be pushed while it is downloaded
+			disk, err := os.Create(filepath.Join(GinkgoT().TempDir(), "disk.img"))
+			Expect(err).ToNot(HaveOccurred())
+			defer disk.Close()
+			_, err = disk.Write(bytes.Repeat([]byte{1}, 2*1024*1024))
+			Expect(err).ToNot(HaveOccurred())
+			layer, err := oci.NewLayer(disk)
+			Expect(err).ToNot(HaveOccurred())
+			layerDigest, err := layer.Digest()
+			Expect(err).ToNot(HaveOccurred())
+			ref, err := oci.ParseReference(image)
+			Expect(err).ToNot(HaveOccurred())
+			l, err := oci.NewLayout(layer, layerDigest, ref)
+			Expect(err).ToNot(HaveOccurred())
+			buf := &bytes.Buffer{}
+			_, err = l.WriteTo(buf)
+			Expect(err).ToNot(HaveOccurred())
+			layout = buf.Bytes()
+
+			virtctlvmexport.HandleHTTPRequest = func(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, exportURL string, headers map[string]string) (*http.Response, error) {
+				body := io.NopCloser(bytes.NewReader(layout))
+				if strings.HasSuffix(downloadUrl, ".sha256") {
+					body = io.NopCloser(strings.NewReader(fmt.Sprintf("%x  containerdisk.tar\n", sha256.Sum256(layout))))
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: body}, nil
+			}
+
+			vmexport := utils.VMExportSpecVM(vmexportName, metav1.NamespaceDefault, "test-vm", secretName)
+			vmexport.Spec.ContainerDisk = &exportv1.VirtualMachineExportContainerDisk{Volume: volumeName, Image: image}
+			vmexport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name: volumeName,
+					Formats: []exportv1.VirtualMachineExportVolumeFormat{{
+						Format:      exportv1.ContainerDisk,
+						Url:         server.URL + "/volumes/test-volume/containerdisk.tar",
+						ChecksumUrl: server.URL + "/volumes/test-volume/containerdisk.tar.sha256",
+					}},
+				},
+			}, secretName)
+			utils.HandleVMExportGet(vmExportClient, vmexport, vmexportName)
+
+			kubeClient.Fake.PrependReactor("get", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				get, ok := action.(testing.GetAction)
+				Expect(ok).To(BeTrue())
+				Expect(get.GetName()).To(Equal(pullSecretName))
+				auth := base64.StdEncoding.EncodeToString([]byte("user:pass"))
+				return true, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: pullSecretName, Namespace: metav1.NamespaceDefault},
+					Type:       v1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{
+						v1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths":{"http://%s":{"auth":%q}}}`, registry.Listener.Addr().String(), auth)),
+					},
+				}, nil
+			})
+		})
+
 		AfterEach(func() {
+			virtctlvmexport.HandleHTTPRequest = orgHttpFunc
+			registry.Close()
 			testDone()
 		})
+
+		It("should push the image to the registry", func() {
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.CONTAINERDISK_FORMAT), virtctlvmexport.PUSH_FLAG, setflag(virtctlvmexport.PULL_SECRET_FLAG, pullSecretName))
+			Expect(cmd()).To(Succeed())
+
+			Expect(manifests).To(HaveKey("v1"))
+			manifest := &oci.Manifest{}
+			Expect(json.Unmarshal(manifests["v1"], manifest)).To(Succeed())
+			Expect(manifest.Layers).To(HaveLen(1))
+			Expect(blobs).To(HaveKey(manifest.Config.Digest))
+			Expect(blobs).To(HaveKey(manifest.Layers[0].Digest))
+			Expect(blobs[manifest.Layers[0].Digest]).To(HaveLen(int(manifest.Layers[0].Size)))
+		})
+
+		It("should fail without the credentials of the registry", func() {
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.CONTAINERDISK_FORMAT), virtctlvmexport.PUSH_FLAG)
+			Expect(cmd()).To(MatchError(ContainSubstring("requires credentials")))
+			Expect(manifests).To(BeEmpty())
+		})
+
+		It("should fail if the registry rejects the image", func() {
+			registry.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/") {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, "invalid manifest")
+					return
+				}
+				registryHandler(w, r)
+			})
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.CONTAINERDISK_FORMAT), virtctlvmexport.PUSH_FLAG, setflag(virtctlvmexport.PULL_SECRET_FLAG, pullSecretName))
+			Expect(cmd()).To(MatchError(ContainSubstring("invalid manifest")))
+		})
+	})
+
+	Context("Ranged download", func() {
+		const (
+			etag      = `"v1"`
+			chunkSize = 16
+		)
+
+		var (
+			orgHttpFunc virtctlvmexport.HandleHTTPRequestFunc
+			data        []byte
+			output      string
+			checksum    string
+			lock        sync.Mutex
+			ranges      []string
+			serverETag  string
+		)
+
+		// serveRaw serves the raw image like the export server, unless failing returns a response for the range
+		serveRaw := func(failing func(string) *http.Response) {
+			virtctlvmexport.HandleHTTPRequest = func(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, exportURL string, headers map[string]string) (*http.Response, error) {
+				if strings.HasSuffix(downloadUrl, ".sha256") {
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(checksum + "  disk.img\n"))}, nil
+				}
+				if !strings.HasSuffix(downloadUrl, "/disk.img") {
+					return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader(""))}, nil
+				}
+				lock.Lock()
+				ranges = append(ranges, headers["Range"])
+				resp := failing(headers["Range"])
+				lock.Unlock()
+				if resp != nil {
+					return resp, nil
+				}
+				req := httptest.NewRequest(http.MethodGet, downloadUrl, nil)
+				for k, v := range headers {
+					req.Header.Set(k, v)
+				}
+				rec := httptest.NewRecorder()
+				if serverETag != "" {
+					rec.Header().Set("ETag", serverETag)
+				}
+				http.ServeContent(rec, req, "disk.img", time.Time{}, bytes.NewReader(data))
+				return rec.Result(), nil
+			}
+		}
+
+		noFailures := func(string) *http.Response {
+			return nil
+		}
+
+		download := func(args ...string) error {
+			args = append([]string{commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.RAW_FORMAT), setflag(virtctlvmexport.OUTPUT_FLAG, output)}, args...)
+			return clientcmd.NewRepeatableVirtctlCommand(args...)()
+		}
+
+		writeProgress := func(etag string, completed ...int64) {
+			progress := fmt.Sprintf(`{"size":%d,"etag":%q,"chunkSize":%d,"completed":[`, len(data), etag, chunkSize)
+			for i, index := range completed {
+				if i > 0 {
+					progress += ","
+				}
+				progress += fmt.Sprint(index)
+			}
+			Expect(os.WriteFile(output+".progress", []byte(progress+"]}"), 0644)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			orgHttpFunc = virtctlvmexport.HandleHTTPRequest
+			testInit(defaultHandler)
+			virtctlvmexport.SetRangedDownloadParams(chunkSize, time.Millisecond)
+
+			data = make([]byte, 100)
+			for i := range data {
+				data[i] = byte(i)
+			}
+			checksum = fmt.Sprintf("%x", sha256.Sum256(data))
+			output = filepath.Join(GinkgoT().TempDir(), "disk.img")
+			ranges = nil
+			serverETag = etag
+
+			vmexport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
+			vmexport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name: volumeName,
+					Formats: []exportv1.VirtualMachineExportVolumeFormat{
+						{
+							Format: exportv1.KubeVirtGz,
+							Url:    server.URL + "/volumes/test-volume/disk.img.gz",
+						},
+						{
+							Format:      exportv1.KubeVirtRaw,
+							Url:         server.URL + "/volumes/test-volume/disk.img",
+							ChecksumUrl: server.URL + "/volumes/test-volume/disk.img.sha256",
+						},
+					},
+				},
+			}, secretName)
+			utils.HandleVMExportGet(vmExportClient, vmexport, vmexportName)
+		})
+
+		AfterEach(func() {
+			virtctlvmexport.HandleHTTPRequest = orgHttpFunc
+			virtctlvmexport.SetDefaultRangedDownloadParams()
+			testDone()
+		})
+
+		It("should download the raw volume in parallel ranges", func() {
+			serveRaw(noFailures)
+			Expect(download(setflag(virtctlvmexport.PARALLEL_FLAG, "4"))).To(Succeed())
+			Expect(os.ReadFile(output)).To(Equal(data))
+			Expect(output + ".progress").ToNot(BeAnExistingFile())
+			Expect(ranges).To(ConsistOf("bytes=0-0", "bytes=0-15", "bytes=16-31", "bytes=32-47", "bytes=48-63", "bytes=64-79", "bytes=80-95", "bytes=96-99"))
+		})
+
+		It("should resume a download from its progress", func() {
+			serveRaw(noFailures)
+			partial := make([]byte, len(data))
+			copy(partial, data[:chunkSize])
+			copy(partial[3*chunkSize:], data[3*chunkSize:4*chunkSize])
+			Expect(os.WriteFile(output, partial, 0644)).To(Succeed())
+			writeProgress(etag, 0, 3)
+
+			Expect(download(virtctlvmexport.RESUME_FLAG)).To(Succeed())
+			Expect(os.ReadFile(output)).To(Equal(data))
+			Expect(ranges).To(ConsistOf("bytes=0-0", "bytes=16-31", "bytes=32-47", "bytes=64-79", "bytes=80-95", "bytes=96-99"))
+		})
+
+		It("should restart a download without its progress", func() {
+			serveRaw(noFailures)
+			Expect(os.WriteFile(output, make([]byte, 40), 0644)).To(Succeed())
+
+			Expect(download(virtctlvmexport.RESUME_FLAG)).To(Succeed())
+			Expect(os.ReadFile(output)).To(Equal(data))
+			Expect(ranges).To(HaveLen(8))
+		})
+
+		It("should restart a download if the server sends no ETag", func() {
+			serverETag = ""
+			serveRaw(noFailures)
+			Expect(os.WriteFile(output, make([]byte, len(data)), 0644)).To(Succeed())
+			writeProgress("", 0, 1, 2)
+
+			Expect(download(virtctlvmexport.RESUME_FLAG)).To(Succeed())
+			Expect(os.ReadFile(output)).To(Equal(data))
+			Expect(ranges).To(HaveLen(8))
+		})
+
+		It("should restart a download if the volume changed", func() {
+			serveRaw(noFailures)
+			Expect(os.WriteFile(output, make([]byte, len(data)), 0644)).To(Succeed())
+			writeProgress(`"v0"`, 0, 1, 2)
+
+			Expect(download(virtctlvmexport.RESUME_FLAG)).To(Succeed())
+			Expect(os.ReadFile(output)).To(Equal(data))
+			Expect(ranges).To(HaveLen(8))
+		})
+
+		It("should not resume a download without --resume", func() {
+			serveRaw(noFailures)
+			Expect(os.WriteFile(output, data[:40], 0644)).To(Succeed())
+
+			Expect(download(setflag(virtctlvmexport.PARALLEL_FLAG, "2"))).To(Succeed())
+			Expect(os.ReadFile(output)).To(Equal(data))
+			Expect(ranges).To(HaveLen(8))
+		})
+
+		DescribeTable("should retry a failed range", func(retries string, expectSuccess bool) {
+			failures := 0
+			serveRaw(func(r string) *http.Response {
+				if r == "bytes=32-47" && failures == 0 {
+					failures++
+					return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", Body: io.NopCloser(strings.NewReader(""))}
+				}
+				return nil
+			})
+			err := download(virtctlvmexport.RESUME_FLAG, setflag(virtctlvmexport.RETRY_FLAG, retries))
+			if expectSuccess {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(os.ReadFile(output)).To(Equal(data))
+				return
+			}
+			Expect(err).To(MatchError(ContainSubstring("failed to download bytes 32-47: bad status: 503 Service Unavailable")))
+			Expect(output + ".progress").To(BeAnExistingFile())
+		},
+			Entry("until it succeeds", "1", true),
+			Entry("until the retries run out", "0", false),
+		)
+
+		It("should fail if the volume changes during the download", func() {
+			serveRaw(func(r string) *http.Response {
+				if r == "bytes=48-63" {
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(data))}
+				}
+				return nil
+			})
+			Expect(download(virtctlvmexport.RESUME_FLAG, setflag(virtctlvmexport.RETRY_FLAG, "3"))).To(MatchError(ContainSubstring("the volume changed during the download")))
+		})
+
+		It("should fail if the checksum does not match", func() {
+			checksum = fmt.Sprintf("%x", sha256.Sum256([]byte("other data")))
+			serveRaw(noFailures)
+			Expect(download(virtctlvmexport.RESUME_FLAG)).To(MatchError(ContainSubstring("checksum mismatch")))
+			Expect(output + ".progress").ToNot(BeAnExistingFile())
+		})
+
+		It("should download the whole volume if the server does not support ranges", func() {
+			virtctlvmexport.HandleHTTPRequest = func(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, exportURL string, headers map[string]string) (*http.Response, error) {
+				body := io.NopCloser(bytes.NewReader(data))
+				if strings.HasSuffix(downloadUrl, ".sha256") {
+					body = io.NopCloser(strings.NewReader(checksum + "  disk.img\n"))
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: body}, nil
+			}
+			Expect(os.WriteFile(output, []byte("partial download which is longer than the volume"), 0644)).To(Succeed())
+			Expect(download(virtctlvmexport.RESUME_FLAG)).To(Succeed())
+			Expect(os.ReadFile(output)).To(Equal(data))
+		})
 	})
 
 	Context("getUrlFromVirtualMachineExport", func() {
@@ -612,6 +1102,42 @@ var _ = Describe("vmexport", func() {
 			Expect(url).Should(Equal("raw"))
 		})
 
+		DescribeTable("Should only get the URL of the requested format", func(format string, expectedUrl string) {
+			vmExport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
+			vmExport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name: volumeName,
+					Formats: []exportv1.VirtualMachineExportVolumeFormat{
+						{Format: exportv1.KubeVirtRaw, Url: "raw"},
+						{Format: exportv1.KubeVirtQcow2, Url: "qcow2"},
+						{Format: exportv1.KubeVirtRawZst, Url: "zst"},
+						{Format: exportv1.KubeVirtGz, Url: "compressed"},
+					},
+				},
+			}, secretName)
+			vmeinfo.Format = format
+			url, err := virtctlvmexport.GetUrlFromVirtualMachineExport(vmExport, vmeinfo)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).Should(Equal(expectedUrl))
+		},
+			Entry("default", "", "compressed"),
+			Entry("qcow2", virtctlvmexport.QCOW2_FORMAT, "qcow2"),
+			Entry("raw.zst", virtctlvmexport.RAW_ZST_FORMAT, "zst"),
+		)
+
+		It("Should not get any URL when the requested format is not available", func() {
+			vmExport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
+			vmExport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name:    volumeName,
+					Formats: utils.GetExportVolumeFormat("compressed", exportv1.KubeVirtGz),
+				},
+			}, secretName)
+			vmeinfo.Format = virtctlvmexport.QCOW2_FORMAT
+			_, err := virtctlvmexport.GetUrlFromVirtualMachineExport(vmExport, vmeinfo)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Should not get any URL when there's no valid options", func() {
 			vmExport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
 			vmExport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
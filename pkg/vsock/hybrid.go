@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package vsock
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// DialHybrid connects to a guest port through a hybrid vsock device, whose host side is a unix socket of the VMM
+// forwarding connections to the guest after a CONNECT handshake. The deadline of the connection is set to the timeout.
+func DialHybrid(socketPath string, port uint32, timeout time.Duration) (net.Conn, error) {
+	conn, err := net.DialTimeout("unix", socketPath, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if _, err := fmt.Fprintf(conn, "CONNECT %d\n", port); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	response, err := readHandshakeLine(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !strings.HasPrefix(response, "OK ") {
+		conn.Close()
+		return nil, fmt.Errorf("vsock port %d refused the connection: %q", port, strings.TrimSpace(response))
+	}
+
+	return conn, nil
+}
+
+// readHandshakeLine reads the handshake reply byte by byte, buffering it would swallow the beginning of the guest reply
+func readHandshakeLine(conn net.Conn) (string, error) {
+	var line strings.Builder
+	b := make([]byte, 1)
+	for {
+		if _, err := conn.Read(b); err != nil {
+			return "", err
+		}
+		line.WriteByte(b[0])
+		if b[0] == '\n' {
+			return line.String(), nil
+		}
+	}
+}
@@ -24,6 +24,7 @@ package v1alpha1
 import (
 	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	corev1 "kubevirt.io/api/core/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -72,6 +73,80 @@ func (in *VirtualMachineClone) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCloneCloudInit) DeepCopyInto(out *VirtualMachineCloneCloudInit) {
+	*out = *in
+	if in.UserData != nil {
+		in, out := &in.UserData, &out.UserData
+		*out = new(string)
+		**out = **in
+	}
+	if in.UserDataSecretRef != nil {
+		in, out := &in.UserDataSecretRef, &out.UserDataSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.NetworkData != nil {
+		in, out := &in.NetworkData, &out.NetworkData
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkDataSecretRef != nil {
+		in, out := &in.NetworkDataSecretRef, &out.NetworkDataSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCloneCloudInit.
+func (in *VirtualMachineCloneCloudInit) DeepCopy() *VirtualMachineCloneCloudInit {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCloneCloudInit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCloneGuestCustomization) DeepCopyInto(out *VirtualMachineCloneGuestCustomization) {
+	*out = *in
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(string)
+		**out = **in
+	}
+	if in.CloudInit != nil {
+		in, out := &in.CloudInit, &out.CloudInit
+		*out = new(VirtualMachineCloneCloudInit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessCredentials != nil {
+		in, out := &in.AccessCredentials, &out.AccessCredentials
+		*out = make([]corev1.AccessCredential, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sysprep != nil {
+		in, out := &in.Sysprep, &out.Sysprep
+		*out = new(VirtualMachineCloneSysprep)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCloneGuestCustomization.
+func (in *VirtualMachineCloneGuestCustomization) DeepCopy() *VirtualMachineCloneGuestCustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCloneGuestCustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineCloneList) DeepCopyInto(out *VirtualMachineCloneList) {
 	*out = *in
@@ -113,6 +188,11 @@ func (in *VirtualMachineCloneSpec) DeepCopyInto(out *VirtualMachineCloneSpec) {
 		*out = new(v1.TypedLocalObjectReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SourceNamespace != nil {
+		in, out := &in.SourceNamespace, &out.SourceNamespace
+		*out = new(string)
+		**out = **in
+	}
 	if in.Target != nil {
 		in, out := &in.Target, &out.Target
 		*out = new(v1.TypedLocalObjectReference)
@@ -141,6 +221,11 @@ func (in *VirtualMachineCloneSpec) DeepCopyInto(out *VirtualMachineCloneSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.GuestCustomization != nil {
+		in, out := &in.GuestCustomization, &out.GuestCustomization
+		*out = new(VirtualMachineCloneGuestCustomization)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -196,6 +281,27 @@ func (in *VirtualMachineCloneStatus) DeepCopy() *VirtualMachineCloneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCloneSysprep) DeepCopyInto(out *VirtualMachineCloneSysprep) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCloneSysprep.
+func (in *VirtualMachineCloneSysprep) DeepCopy() *VirtualMachineCloneSysprep {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCloneSysprep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineCloneTemplateFilters) DeepCopyInto(out *VirtualMachineCloneTemplateFilters) {
 	*out = *in
@@ -22,6 +22,8 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
 )
 
 // VirtualMachineClone is a CRD that clones one VM into another.
@@ -56,6 +58,14 @@ type VirtualMachineCloneSpec struct {
 	// VirtualMachineSnapshot of snapshot.kubevirt.io API group
 	Source *corev1.TypedLocalObjectReference `json:"source"`
 
+	// SourceNamespace is the namespace of the source. Defaults to the namespace of the
+	// VirtualMachineClone, which is always the namespace of the target. Cloning from another
+	// namespace requires permission to create virtualmachineclones/source in the source namespace,
+	// the CrossNamespaceVolumeDataSource feature of the cluster, and a ReferenceGrant allowing
+	// PVCs of the target namespace to use the VolumeSnapshots of the source namespace.
+	// +optional
+	SourceNamespace *string `json:"sourceNamespace,omitempty"`
+
 	// Target is the outcome of the cloning process.
 	// Currently supported source types are:
 	// - VirtualMachine of kubevirt.io API group
@@ -88,6 +98,60 @@ type VirtualMachineCloneSpec struct {
 	// be generated automatically.
 	// +optional
 	NewSMBiosSerial *string `json:"newSMBiosSerial,omitempty"`
+	// GuestCustomization changes the identity the guest operating system of the target boots with.
+	// If this field is not specified, the target boots with the hostname, the cloud-init data and
+	// the credentials of the source.
+	// +optional
+	GuestCustomization *VirtualMachineCloneGuestCustomization `json:"guestCustomization,omitempty"`
+}
+
+type VirtualMachineCloneGuestCustomization struct {
+	// Hostname sets the hostname of the target, which is also passed to cloud-init.
+	// +optional
+	Hostname *string `json:"hostname,omitempty"`
+	// NewInstanceID sets a random firmware UUID on the target. The cloud-init NoCloud instance-id is
+	// derived from it, so cloud-init configures the target as a new instance even if the target has
+	// the same name as the source. Otherwise the firmware UUID is derived from the target name.
+	// +optional
+	NewInstanceID bool `json:"newInstanceID,omitempty"`
+	// CloudInit replaces the user data and the network data of the cloud-init NoCloud or
+	// ConfigDrive volume of the target. The source must have a cloud-init volume.
+	// +optional
+	CloudInit *VirtualMachineCloneCloudInit `json:"cloudInit,omitempty"`
+	// AccessCredentials replace the access credentials of the target.
+	// Secrets are looked up in the namespace of the VirtualMachineClone.
+	// +optional
+	// +listType=atomic
+	AccessCredentials []v1.AccessCredential `json:"accessCredentials,omitempty"`
+	// Sysprep resets the guest operating system on the disks of the target with virt-sysprep
+	// before the clone succeeds. The target must not be started before the clone succeeded.
+	// +optional
+	Sysprep *VirtualMachineCloneSysprep `json:"sysprep,omitempty"`
+}
+
+type VirtualMachineCloneCloudInit struct {
+	// UserData replaces the user data with inline user data.
+	// +optional
+	UserData *string `json:"userData,omitempty"`
+	// UserDataSecretRef replaces the user data with the user data of a secret
+	// in the namespace of the VirtualMachineClone.
+	// +optional
+	UserDataSecretRef *corev1.LocalObjectReference `json:"userDataSecretRef,omitempty"`
+	// NetworkData replaces the network data with inline network data.
+	// +optional
+	NetworkData *string `json:"networkData,omitempty"`
+	// NetworkDataSecretRef replaces the network data with the network data of a secret
+	// in the namespace of the VirtualMachineClone.
+	// +optional
+	NetworkDataSecretRef *corev1.LocalObjectReference `json:"networkDataSecretRef,omitempty"`
+}
+
+type VirtualMachineCloneSysprep struct {
+	// Operations are the virt-sysprep operations to run, e.g. "machine-id" or "ssh-hostkeys".
+	// Defaults to the default operations of virt-sysprep.
+	// +optional
+	// +listType=atomic
+	Operations []string `json:"operations,omitempty"`
 }
 
 type VirtualMachineClonePhase string
@@ -97,6 +161,7 @@ const (
 	SnapshotInProgress VirtualMachineClonePhase = "SnapshotInProgress"
 	CreatingTargetVM   VirtualMachineClonePhase = "CreatingTargetVM"
 	RestoreInProgress  VirtualMachineClonePhase = "RestoreInProgress"
+	SysprepInProgress  VirtualMachineClonePhase = "SysprepInProgress"
 	Succeeded          VirtualMachineClonePhase = "Succeeded"
 	Failed             VirtualMachineClonePhase = "Failed"
 	Unknown            VirtualMachineClonePhase = "Unknown"
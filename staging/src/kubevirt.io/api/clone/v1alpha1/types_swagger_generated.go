@@ -17,13 +17,40 @@ func (VirtualMachineCloneTemplateFilters) SwaggerDoc() map[string]string {
 
 func (VirtualMachineCloneSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"source":            "Source is the object that would be cloned. Currently supported source types are:\nVirtualMachine of kubevirt.io API group,\nVirtualMachineSnapshot of snapshot.kubevirt.io API group",
-		"target":            "Target is the outcome of the cloning process.\nCurrently supported source types are:\n- VirtualMachine of kubevirt.io API group\n- Empty (nil).\nIf the target is not provided, the target type would default to VirtualMachine and a random\nname would be generated for the target. The target's name can be viewed by\ninspecting status \"TargetName\" field below.\n+optional",
-		"annotationFilters": "Example use: \"!some/key*\".\nFor a detailed description, please refer to https://kubevirt.io/user-guide/operations/clone_api/#label-annotation-filters.\n+optional\n+listType=atomic",
-		"labelFilters":      "Example use: \"!some/key*\".\nFor a detailed description, please refer to https://kubevirt.io/user-guide/operations/clone_api/#label-annotation-filters.\n+optional\n+listType=atomic",
-		"template":          "For a detailed description, please refer to https://kubevirt.io/user-guide/operations/clone_api/#label-annotation-filters.\n+optional",
-		"newMacAddresses":   "NewMacAddresses manually sets that target interfaces' mac addresses. The key is the interface name and the\nvalue is the new mac address. If this field is not specified, a new MAC address will\nbe generated automatically, as for any interface that is not included in this map.\n+optional",
-		"newSMBiosSerial":   "NewSMBiosSerial manually sets that target's SMbios serial. If this field is not specified, a new serial will\nbe generated automatically.\n+optional",
+		"source":             "Source is the object that would be cloned. Currently supported source types are:\nVirtualMachine of kubevirt.io API group,\nVirtualMachineSnapshot of snapshot.kubevirt.io API group",
+		"sourceNamespace":    "SourceNamespace is the namespace of the source. Defaults to the namespace of the\nVirtualMachineClone, which is always the namespace of the target. Cloning from another\nnamespace requires permission to create virtualmachineclones/source in the source namespace,\nthe CrossNamespaceVolumeDataSource feature of the cluster, and a ReferenceGrant allowing\nPVCs of the target namespace to use the VolumeSnapshots of the source namespace.\n+optional",
+		"target":             "Target is the outcome of the cloning process.\nCurrently supported source types are:\n- VirtualMachine of kubevirt.io API group\n- Empty (nil).\nIf the target is not provided, the target type would default to VirtualMachine and a random\nname would be generated for the target. The target's name can be viewed by\ninspecting status \"TargetName\" field below.\n+optional",
+		"annotationFilters":  "Example use: \"!some/key*\".\nFor a detailed description, please refer to https://kubevirt.io/user-guide/operations/clone_api/#label-annotation-filters.\n+optional\n+listType=atomic",
+		"labelFilters":       "Example use: \"!some/key*\".\nFor a detailed description, please refer to https://kubevirt.io/user-guide/operations/clone_api/#label-annotation-filters.\n+optional\n+listType=atomic",
+		"template":           "For a detailed description, please refer to https://kubevirt.io/user-guide/operations/clone_api/#label-annotation-filters.\n+optional",
+		"newMacAddresses":    "NewMacAddresses manually sets that target interfaces' mac addresses. The key is the interface name and the\nvalue is the new mac address. If this field is not specified, a new MAC address will\nbe generated automatically, as for any interface that is not included in this map.\n+optional",
+		"newSMBiosSerial":    "NewSMBiosSerial manually sets that target's SMbios serial. If this field is not specified, a new serial will\nbe generated automatically.\n+optional",
+		"guestCustomization": "GuestCustomization changes the identity the guest operating system of the target boots with.\nIf this field is not specified, the target boots with the hostname, the cloud-init data and\nthe credentials of the source.\n+optional",
+	}
+}
+
+func (VirtualMachineCloneGuestCustomization) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"hostname":          "Hostname sets the hostname of the target, which is also passed to cloud-init.\n+optional",
+		"newInstanceID":     "NewInstanceID sets a random firmware UUID on the target. The cloud-init NoCloud instance-id is\nderived from it, so cloud-init configures the target as a new instance even if the target has\nthe same name as the source. Otherwise the firmware UUID is derived from the target name.\n+optional",
+		"cloudInit":         "CloudInit replaces the user data and the network data of the cloud-init NoCloud or\nConfigDrive volume of the target. The source must have a cloud-init volume.\n+optional",
+		"accessCredentials": "AccessCredentials replace the access credentials of the target.\nSecrets are looked up in the namespace of the VirtualMachineClone.\n+optional\n+listType=atomic",
+		"sysprep":           "Sysprep resets the guest operating system on the disks of the target with virt-sysprep\nbefore the clone succeeds. The target must not be started before the clone succeeded.\n+optional",
+	}
+}
+
+func (VirtualMachineCloneCloudInit) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"userData":             "UserData replaces the user data with inline user data.\n+optional",
+		"userDataSecretRef":    "UserDataSecretRef replaces the user data with the user data of a secret\nin the namespace of the VirtualMachineClone.\n+optional",
+		"networkData":          "NetworkData replaces the network data with inline network data.\n+optional",
+		"networkDataSecretRef": "NetworkDataSecretRef replaces the network data with the network data of a secret\nin the namespace of the VirtualMachineClone.\n+optional",
+	}
+}
+
+func (VirtualMachineCloneSysprep) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"operations": "Operations are the virt-sysprep operations to run, e.g. \"machine-id\" or \"ssh-hostkeys\".\nDefaults to the default operations of virt-sysprep.\n+optional\n+listType=atomic",
 	}
 }
 
@@ -206,6 +206,28 @@ func (in *BIOS) DeepCopy() *BIOS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVolumeSource) DeepCopyInto(out *BackupVolumeSource) {
+	*out = *in
+	out.PersistentVolumeClaimVolumeSource = in.PersistentVolumeClaimVolumeSource
+	if in.IncrementalFrom != nil {
+		in, out := &in.IncrementalFrom, &out.IncrementalFrom
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVolumeSource.
+func (in *BackupVolumeSource) DeepCopy() *BackupVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BlockSize) DeepCopyInto(out *BlockSize) {
 	*out = *in
@@ -656,6 +678,28 @@ func (in *ContainerDiskSource) DeepCopy() *ContainerDiskSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConvertHypervisorOptions) DeepCopyInto(out *ConvertHypervisorOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConvertHypervisorOptions.
+func (in *ConvertHypervisorOptions) DeepCopy() *ConvertHypervisorOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ConvertHypervisorOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomBlockSize) DeepCopyInto(out *CustomBlockSize) {
 	*out = *in
@@ -1200,6 +1244,30 @@ func (in *DiskVerification) DeepCopy() *DiskVerification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainBackupInfo) DeepCopyInto(out *DomainBackupInfo) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainBackupInfo.
+func (in *DomainBackupInfo) DeepCopy() *DomainBackupInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainBackupInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainMemoryDumpInfo) DeepCopyInto(out *DomainMemoryDumpInfo) {
 	*out = *in
@@ -2034,6 +2102,149 @@ func (in *HypervTimer) DeepCopy() *HypervTimer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypervisorConfiguration) DeepCopyInto(out *HypervisorConfiguration) {
+	*out = *in
+	if in.NamespacePolicies != nil {
+		in, out := &in.NamespacePolicies, &out.NamespacePolicies
+		*out = make([]HypervisorNamespacePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Overheads != nil {
+		in, out := &in.Overheads, &out.Overheads
+		*out = make([]HypervisorOverhead, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypervisorConfiguration.
+func (in *HypervisorConfiguration) DeepCopy() *HypervisorConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(HypervisorConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypervisorConversionChange) DeepCopyInto(out *HypervisorConversionChange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypervisorConversionChange.
+func (in *HypervisorConversionChange) DeepCopy() *HypervisorConversionChange {
+	if in == nil {
+		return nil
+	}
+	out := new(HypervisorConversionChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypervisorConversionReport) DeepCopyInto(out *HypervisorConversionReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]HypervisorConversionChange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypervisorConversionReport.
+func (in *HypervisorConversionReport) DeepCopy() *HypervisorConversionReport {
+	if in == nil {
+		return nil
+	}
+	out := new(HypervisorConversionReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HypervisorConversionReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypervisorNamespacePolicy) DeepCopyInto(out *HypervisorNamespacePolicy) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedHypervisors != nil {
+		in, out := &in.AllowedHypervisors, &out.AllowedHypervisors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypervisorNamespacePolicy.
+func (in *HypervisorNamespacePolicy) DeepCopy() *HypervisorNamespacePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HypervisorNamespacePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypervisorOverhead) DeepCopyInto(out *HypervisorOverhead) {
+	*out = *in
+	if in.VirtLauncherMonitor != nil {
+		in, out := &in.VirtLauncherMonitor, &out.VirtLauncherMonitor
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.VirtLauncher != nil {
+		in, out := &in.VirtLauncher, &out.VirtLauncher
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Virtlogd != nil {
+		in, out := &in.Virtlogd, &out.Virtlogd
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.HypervisorDaemon != nil {
+		in, out := &in.HypervisorDaemon, &out.HypervisorDaemon
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.VMM != nil {
+		in, out := &in.VMM, &out.VMM
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypervisorOverhead.
+func (in *HypervisorOverhead) DeepCopy() *HypervisorOverhead {
+	if in == nil {
+		return nil
+	}
+	out := new(HypervisorOverhead)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *I6300ESBWatchdog) DeepCopyInto(out *I6300ESBWatchdog) {
 	*out = *in
@@ -2606,6 +2817,11 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(VMRolloutStrategy)
 		**out = **in
 	}
+	if in.HypervisorConfiguration != nil {
+		in, out := &in.HypervisorConfiguration, &out.HypervisorConfiguration
+		*out = new(HypervisorConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5130,6 +5346,11 @@ func (in *VirtualMachineInstanceMigrationStatus) DeepCopyInto(out *VirtualMachin
 		*out = new(VirtualMachineInstanceMigrationState)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.QueuePosition != nil {
+		in, out := &in.QueuePosition, &out.QueuePosition
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -6016,6 +6237,11 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 		*out = new(MemoryDumpVolumeSource)
 		**out = **in
 	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -6047,6 +6273,11 @@ func (in *VolumeStatus) DeepCopyInto(out *VolumeStatus) {
 		*out = new(DomainMemoryDumpInfo)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BackupVolume != nil {
+		in, out := &in.BackupVolume, &out.BackupVolume
+		*out = new(DomainBackupInfo)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ContainerDiskVolume != nil {
 		in, out := &in.ContainerDiskVolume, &out.ContainerDiskVolume
 		*out = new(ContainerDiskInfo)
@@ -247,6 +247,7 @@ type EFI struct {
 	// +optional
 	SecureBoot *bool `json:"secureBoot,omitempty"`
 	// If set to true, Persistent will persist the EFI NVRAM across reboots.
+	// Not supported by the ch hypervisor, whose firmware keeps the EFI variables in guest memory.
 	// Defaults to false
 	// +optional
 	Persistent *bool `json:"persistent,omitempty"`
@@ -839,6 +840,8 @@ type VolumeSource struct {
 	DownwardMetrics *DownwardMetricsVolumeSource `json:"downwardMetrics,omitempty"`
 	// MemoryDump is attached to the virt launcher and is populated with a memory dump of the vmi
 	MemoryDump *MemoryDumpVolumeSource `json:"memoryDump,omitempty"`
+	// Backup is attached to the virt launcher and is populated with a backup of the disks of the vmi
+	Backup *BackupVolumeSource `json:"backup,omitempty"`
 }
 
 // HotplugVolumeSource Represents the source of a volume to mount which are capable
@@ -880,6 +883,26 @@ type MemoryDumpVolumeSource struct {
 	// Directly attached to the virt launcher
 	// +optional
 	PersistentVolumeClaimVolumeSource `json:",inline"`
+	// Type is the kind of memory dump the pvc holds, defaults to Core.
+	// A non hotpluggable volume of type State is used to resume the vmi
+	// from the saved state when it starts.
+	// +optional
+	Type MemoryDumpType `json:"type,omitempty"`
+}
+
+type BackupVolumeSource struct {
+	// PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
+	// Directly attached to the virt launcher
+	// +optional
+	PersistentVolumeClaimVolumeSource `json:",inline"`
+	// Checkpoint is the name of the checkpoint created when the backup starts,
+	// later backups can be taken incrementally on top of it
+	Checkpoint string `json:"checkpoint"`
+	// IncrementalFrom is the name of the checkpoint of a previous backup,
+	// only the data changed since that checkpoint is backed up.
+	// A full backup is taken if it is not set.
+	// +optional
+	IncrementalFrom *string `json:"incrementalFrom,omitempty"`
 }
 
 type EphemeralVolumeSource struct {
@@ -123,7 +123,7 @@ func (EFI) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":           "If set, EFI will be used instead of BIOS.",
 		"secureBoot": "If set, SecureBoot will be enabled and the OVMF roms will be swapped for\nSecureBoot-enabled ones.\nRequires SMM to be enabled.\nDefaults to true\n+optional",
-		"persistent": "If set to true, Persistent will persist the EFI NVRAM across reboots.\nDefaults to false\n+optional",
+		"persistent": "If set to true, Persistent will persist the EFI NVRAM across reboots.\nNot supported by the ch hypervisor, whose firmware keeps the EFI variables in guest memory.\nDefaults to false\n+optional",
 	}
 }
 
@@ -449,6 +449,7 @@ func (VolumeSource) SwaggerDoc() map[string]string {
 		"serviceAccount":        "ServiceAccountVolumeSource represents a reference to a service account.\nThere can only be one volume of this type!\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/\n+optional",
 		"downwardMetrics":       "DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest\nmetrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.",
 		"memoryDump":            "MemoryDump is attached to the virt launcher and is populated with a memory dump of the vmi",
+		"backup":                "Backup is attached to the virt launcher and is populated with a backup of the disks of the vmi",
 	}
 }
 
@@ -475,7 +476,16 @@ func (PersistentVolumeClaimVolumeSource) SwaggerDoc() map[string]string {
 }
 
 func (MemoryDumpVolumeSource) SwaggerDoc() map[string]string {
-	return map[string]string{}
+	return map[string]string{
+		"type": "Type is the kind of memory dump the pvc holds, defaults to Core.\nA non hotpluggable volume of type State is used to resume the vmi\nfrom the saved state when it starts.\n+optional",
+	}
+}
+
+func (BackupVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"checkpoint":      "Checkpoint is the name of the checkpoint created when the backup starts,\nlater backups can be taken incrementally on top of it",
+		"incrementalFrom": "IncrementalFrom is the name of the checkpoint of a previous backup,\nonly the data changed since that checkpoint is backed up.\nA full backup is taken if it is not set.\n+optional",
+	}
 }
 
 func (EphemeralVolumeSource) SwaggerDoc() map[string]string {
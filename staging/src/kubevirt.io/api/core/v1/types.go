@@ -79,7 +79,8 @@ const (
 // VirtualMachineInstanceSpec is a description of a VirtualMachineInstance.
 type VirtualMachineInstanceSpec struct {
 
-	// The Hypervisor to use for the VMI. Possible values are "qemu" for QEMU and "ch" for Cloud Hypervisor.
+	// The Hypervisor to use for the VMI. Possible values are "qemu" for QEMU, "ch" for Cloud Hypervisor on MSHV
+	// and "ch-kvm" for Cloud Hypervisor on KVM.
 	Hypervisor string `json:"hypervisor,omitempty"`
 
 	// If specified, indicates the pod's priority.
@@ -370,6 +371,8 @@ type VolumeStatus struct {
 	Size int64 `json:"size,omitempty"`
 	// If the volume is memorydump volume, this will contain the memorydump info.
 	MemoryDumpVolume *DomainMemoryDumpInfo `json:"memoryDumpVolume,omitempty"`
+	// If the volume is a backup volume, this will contain the backup info.
+	BackupVolume *DomainBackupInfo `json:"backupVolume,omitempty"`
 	// ContainerDiskVolume shows info about the containerdisk, if the volume is a containerdisk
 	ContainerDiskVolume *ContainerDiskInfo `json:"containerDiskVolume,omitempty"`
 }
@@ -406,6 +409,16 @@ type DomainMemoryDumpInfo struct {
 	TargetFileName string `json:"targetFileName,omitempty"`
 }
 
+// DomainBackupInfo represents the backup information
+type DomainBackupInfo struct {
+	// StartTimestamp is the time when the backup started
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// EndTimestamp is the time when the backup completed
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+	// TargetDirectory is the name of the directory the backup is written to
+	TargetDirectory string `json:"targetDirectory,omitempty"`
+}
+
 // HotplugVolumeStatus represents the hotplug status of the volume
 type HotplugVolumeStatus struct {
 	// AttachPodName is the name of the pod used to attach the volume to the node.
@@ -444,6 +457,12 @@ const (
 	MemoryDumpVolumeInProgress VolumePhase = "MemoryDumpInProgress"
 	// MemoryDumpVolumeInProgress means that the volume for the memory dump was attached, and now the command is being triggered
 	MemoryDumpVolumeFailed VolumePhase = "MemoryDumpFailed"
+	// BackupVolumeCompleted means that the requested backup was completed and the backup is ready in the volume
+	BackupVolumeCompleted VolumePhase = "BackupCompleted"
+	// BackupVolumeInProgress means that the volume for the backup was attached, and now the backup is being taken
+	BackupVolumeInProgress VolumePhase = "BackupInProgress"
+	// BackupVolumeFailed means that the requested backup failed
+	BackupVolumeFailed VolumePhase = "BackupFailed"
 )
 
 func (v *VirtualMachineInstance) IsScheduling() bool {
@@ -602,6 +621,8 @@ const (
 	VirtualMachineInstanceReasonHypervPassthroughNotMigratable = "HypervPassthroughNotLiveMigratable"
 	// Reason means that VMI is not live migratable because it requested SCSI persitent reservation
 	VirtualMachineInstanceReasonPRNotMigratable = "PersistentReservationNotLiveMigratable"
+	// Reason means that VMI is not live migratable because its hypervisor does not support the required kind of live migration
+	VirtualMachineInstanceReasonHypervisorNotMigratable = "HypervisorNotLiveMigratable"
 	// Reason means that not all of the VMI's DVs are ready
 	VirtualMachineInstanceReasonNotAllDVsReady = "NotAllDVsReady"
 	// Reason means that all of the VMI's DVs are bound and not running
@@ -632,6 +653,8 @@ const (
 	// VirtualMachineInstanceMigrationAbortRequested indicates that live migration abort has been requested
 	VirtualMachineInstanceMigrationAbortRequested          VirtualMachineInstanceMigrationConditionType = "migrationAbortRequested"
 	VirtualMachineInstanceMigrationRejectedByResourceQuota VirtualMachineInstanceMigrationConditionType = "migrationRejectedByResourceQuota"
+	// VirtualMachineInstanceMigrationQueued indicates that a pending migration waits for a free migration slot
+	VirtualMachineInstanceMigrationQueued VirtualMachineInstanceMigrationConditionType = "migrationQueued"
 )
 
 type VirtualMachineInstanceCondition struct {
@@ -1020,6 +1043,10 @@ const (
 	// SEVESLabel marks the node as capable of running workloads with SEV-ES
 	SEVESLabel string = "kubevirt.io/sev-es"
 
+	// HypervisorLabel marks the node as capable of running workloads with the hypervisor named by the label suffix,
+	// e.g. hypervisor.kubevirt.io/ch. The label value holds the version of the hypervisor on the node.
+	HypervisorLabel string = "hypervisor.kubevirt.io/"
+
 	// KSMEnabledLabel marks the node as KSM-handling enabled
 	KSMEnabledLabel string = "kubevirt.io/ksm-enabled"
 
@@ -1058,6 +1085,10 @@ const (
 	// pvc name and the timestamp the memory dump was collected
 	PVCMemoryDumpAnnotation string = "kubevirt.io/memory-dump"
 
+	// PVCBackupAnnotation is the name of the directory of the latest backup
+	// written to the pvc
+	PVCBackupAnnotation string = "kubevirt.io/backup"
+
 	// AllowPodBridgeNetworkLiveMigrationAnnotation allow to run live migration when the
 	// vm has the pod networking bind with a bridge
 	AllowPodBridgeNetworkLiveMigrationAnnotation string = "kubevirt.io/allow-pod-bridge-network-live-migration"
@@ -1364,8 +1395,29 @@ type VirtualMachineInstanceMigrationList struct {
 type VirtualMachineInstanceMigrationSpec struct {
 	// The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace
 	VMIName string `json:"vmiName,omitempty" valid:"required"`
+
+	// Priority orders pending migrations when the parallel migration limits are reached.
+	// Evacuation migrations start first, followed by User, Descheduler and WorkloadUpdate migrations.
+	// Within a priority, migrations of different namespaces take turns.
+	// Defaults to User. Only KubeVirt may create Evacuation migrations.
+	// +optional
+	Priority MigrationPriority `json:"priority,omitempty"`
 }
 
+// MigrationPriority is the priority of a migration in the queue of pending migrations
+type MigrationPriority string
+
+const (
+	// MigrationPriorityEvacuation is the priority of migrations evacuating a node which is drained
+	MigrationPriorityEvacuation MigrationPriority = "Evacuation"
+	// MigrationPriorityUser is the priority of migrations requested by users
+	MigrationPriorityUser MigrationPriority = "User"
+	// MigrationPriorityDescheduler is the priority of migrations evicting a VMI from a node which is not drained
+	MigrationPriorityDescheduler MigrationPriority = "Descheduler"
+	// MigrationPriorityWorkloadUpdate is the priority of migrations moving a VMI to an updated virt-launcher
+	MigrationPriorityWorkloadUpdate MigrationPriority = "WorkloadUpdate"
+)
+
 // VirtualMachineInstanceMigrationPhaseTransitionTimestamp gives a timestamp in relation to when a phase is set on a vmi
 type VirtualMachineInstanceMigrationPhaseTransitionTimestamp struct {
 	// Phase is the status of the VirtualMachineInstanceMigrationPhase in kubernetes world. It is not the VirtualMachineInstanceMigrationPhase status, but partially correlates to it.
@@ -1384,6 +1436,10 @@ type VirtualMachineInstanceMigrationStatus struct {
 	PhaseTransitionTimestamps []VirtualMachineInstanceMigrationPhaseTransitionTimestamp `json:"phaseTransitionTimestamps,omitempty"`
 	// Represents the status of a live migration
 	MigrationState *VirtualMachineInstanceMigrationState `json:"migrationState,omitempty"`
+	// QueuePosition is the position of a pending migration in the queue of migrations waiting for a free
+	// migration slot, starting at 1. It is removed once the target pod of the migration is created.
+	// +optional
+	QueuePosition *int32 `json:"queuePosition,omitempty"`
 }
 
 // VirtualMachineInstanceMigrationPhase is a label for the condition of a VirtualMachineInstanceMigration at the current time.
@@ -2241,6 +2297,44 @@ type MigrateOptions struct {
 	DryRun []string `json:"dryRun,omitempty" protobuf:"bytes,1,rep,name=dryRun"`
 }
 
+// ConvertHypervisorOptions may be provided on convert-hypervisor request.
+type ConvertHypervisorOptions struct {
+	metav1.TypeMeta `json:",inline"`
+	// Hypervisor is the hypervisor backend the VirtualMachine is moved to
+	Hypervisor string `json:"hypervisor"`
+	// When present, indicates that modifications should not be
+	// persisted. An invalid or unrecognized dryRun directive will
+	// result in an error response and no further processing of the
+	// request. Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
+// HypervisorConversionReport lists how the domain of a VirtualMachine changes when it is moved to another hypervisor
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HypervisorConversionReport struct {
+	metav1.TypeMeta `json:",inline"`
+	// SourceHypervisor is the hypervisor the VirtualMachine currently uses
+	SourceHypervisor string `json:"sourceHypervisor"`
+	// TargetHypervisor is the hypervisor the VirtualMachine is moved to
+	TargetHypervisor string `json:"targetHypervisor"`
+	// Changes the guest observes after the VirtualMachine was restarted on the target hypervisor
+	// +optional
+	// +listType=atomic
+	Changes []HypervisorConversionChange `json:"changes,omitempty"`
+}
+
+// HypervisorConversionChange describes a difference between the domains rendered for the source and the target hypervisor
+type HypervisorConversionChange struct {
+	// Field is the path of the VirtualMachine field the change originates from
+	Field string `json:"field"`
+	// Message describes the change
+	Message string `json:"message"`
+}
+
 // VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -2344,8 +2438,21 @@ type VirtualMachineMemoryDumpRequest struct {
 	// Message is a detailed message about failure of the memory dump
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Type is the kind of memory dump to take, defaults to Core
+	// +optional
+	Type MemoryDumpType `json:"type,omitempty"`
 }
 
+// MemoryDumpType is the kind of memory dump written to the memory dump pvc
+type MemoryDumpType string
+
+const (
+	// A core dump of the guest memory, meant for debugging
+	MemoryDumpTypeCore MemoryDumpType = "Core"
+	// The guest memory and device state, from which the vm can be resumed
+	MemoryDumpTypeState MemoryDumpType = "State"
+)
+
 type MemoryDumpPhase string
 
 const (
@@ -2505,6 +2612,74 @@ type KubeVirtConfiguration struct {
 	// +nullable
 	// +kubebuilder:validation:Enum=Stage;LiveUpdate
 	VMRolloutStrategy *VMRolloutStrategy `json:"vmRolloutStrategy,omitempty"`
+
+	// HypervisorConfiguration holds the cluster-wide default hypervisor and the per-namespace hypervisor policies
+	HypervisorConfiguration *HypervisorConfiguration `json:"hypervisorConfiguration,omitempty"`
+}
+
+// HypervisorConfiguration defines which hypervisor VirtualMachineInstances get when they don't specify one,
+// and which hypervisors they are allowed to use.
+type HypervisorConfiguration struct {
+	// DefaultHypervisor is the hypervisor assigned to VirtualMachineInstances which don't set spec.hypervisor.
+	// Defaults to "qemu".
+	// +optional
+	DefaultHypervisor string `json:"defaultHypervisor,omitempty"`
+
+	// NamespacePolicies restrict and default the hypervisors available in specific namespaces.
+	// When several policies select the same namespace, the first one wins.
+	// +optional
+	// +listType=atomic
+	NamespacePolicies []HypervisorNamespacePolicy `json:"namespacePolicies,omitempty"`
+
+	// Overheads override the memory overhead KubeVirt reserves in virt-launcher pods for the processes
+	// of a hypervisor. Fields which are not set keep the built-in default of the hypervisor.
+	// +optional
+	// +listType=map
+	// +listMapKey=hypervisor
+	Overheads []HypervisorOverhead `json:"overheads,omitempty"`
+}
+
+// HypervisorOverhead defines the memory reserved for each process of a virt-launcher pod running a hypervisor.
+type HypervisorOverhead struct {
+	// Hypervisor the overheads apply to, e.g. "qemu" or "ch".
+	Hypervisor string `json:"hypervisor"`
+
+	// VirtLauncherMonitor is the memory reserved for the virt-launcher-monitor process.
+	// +optional
+	VirtLauncherMonitor *resource.Quantity `json:"virtLauncherMonitor,omitempty"`
+
+	// VirtLauncher is the memory reserved for the virt-launcher process.
+	// +optional
+	VirtLauncher *resource.Quantity `json:"virtLauncher,omitempty"`
+
+	// Virtlogd is the memory reserved for the virtlogd process.
+	// +optional
+	Virtlogd *resource.Quantity `json:"virtlogd,omitempty"`
+
+	// HypervisorDaemon is the memory reserved for the libvirt daemon of the hypervisor, e.g. virtqemud.
+	// +optional
+	HypervisorDaemon *resource.Quantity `json:"hypervisorDaemon,omitempty"`
+
+	// VMM is the memory reserved for the VMM process, on top of the guest memory and page tables.
+	// +optional
+	VMM *resource.Quantity `json:"vmm,omitempty"`
+}
+
+// HypervisorNamespacePolicy defines the hypervisors available to VirtualMachineInstances in a set of namespaces.
+type HypervisorNamespacePolicy struct {
+	// Namespaces the policy applies to.
+	// +listType=set
+	Namespaces []string `json:"namespaces"`
+
+	// DefaultHypervisor overrides the cluster-wide default hypervisor in the selected namespaces.
+	// +optional
+	DefaultHypervisor string `json:"defaultHypervisor,omitempty"`
+
+	// AllowedHypervisors lists the hypervisors VirtualMachineInstances in the selected namespaces may use.
+	// If empty, every hypervisor is allowed.
+	// +optional
+	// +listType=set
+	AllowedHypervisors []string `json:"allowedHypervisors,omitempty"`
 }
 
 type VMRolloutStrategy string
@@ -19,7 +19,7 @@ func (VirtualMachineInstanceList) SwaggerDoc() map[string]string {
 func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                              "VirtualMachineInstanceSpec is a description of a VirtualMachineInstance.",
-		"hypervisor":                    "The Hypervisor to use for the VMI. Possible values are \"qemu\" for QEMU and \"ch\" for Cloud Hypervisor.",
+		"hypervisor":                    "The Hypervisor to use for the VMI. Possible values are \"qemu\" for QEMU, \"ch\" for Cloud Hypervisor on MSHV\nand \"ch-kvm\" for Cloud Hypervisor on KVM.",
 		"priorityClassName":             "If specified, indicates the pod's priority.\nIf not specified, the pod priority will be default or zero if there is no\ndefault.\n+optional",
 		"domain":                        "Specification of the desired behavior of the VirtualMachineInstance on the host.",
 		"nodeSelector":                  "NodeSelector is a selector which must be true for the vmi to fit on a node.\nSelector which must match a node's labels for the vmi to be scheduled on that node.\nMore info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/\n+optional",
@@ -121,6 +121,7 @@ func (VolumeStatus) SwaggerDoc() map[string]string {
 		"hotplugVolume":             "If the volume is hotplug, this will contain the hotplug status.",
 		"size":                      "Represents the size of the volume",
 		"memoryDumpVolume":          "If the volume is memorydump volume, this will contain the memorydump info.",
+		"backupVolume":              "If the volume is a backup volume, this will contain the backup info.",
 		"containerDiskVolume":       "ContainerDiskVolume shows info about the containerdisk, if the volume is a containerdisk",
 	}
 }
@@ -157,6 +158,15 @@ func (DomainMemoryDumpInfo) SwaggerDoc() map[string]string {
 	}
 }
 
+func (DomainBackupInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "DomainBackupInfo represents the backup information",
+		"startTimestamp":  "StartTimestamp is the time when the backup started",
+		"endTimestamp":    "EndTimestamp is the time when the backup completed",
+		"targetDirectory": "TargetDirectory is the name of the directory the backup is written to",
+	}
+}
+
 func (HotplugVolumeStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":              "HotplugVolumeStatus represents the hotplug status of the volume",
@@ -314,7 +324,8 @@ func (VirtualMachineInstanceMigrationList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceMigrationSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"vmiName": "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"vmiName":  "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"priority": "Priority orders pending migrations when the parallel migration limits are reached.\nEvacuation migrations start first, followed by User, Descheduler and WorkloadUpdate migrations.\nWithin a priority, migrations of different namespaces take turns.\nDefaults to User. Only KubeVirt may create Evacuation migrations.\n+optional",
 	}
 }
 
@@ -331,6 +342,7 @@ func (VirtualMachineInstanceMigrationStatus) SwaggerDoc() map[string]string {
 		"":                          "VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.",
 		"phaseTransitionTimestamps": "PhaseTransitionTimestamp is the timestamp of when the last phase change occurred\n+listType=atomic\n+optional",
 		"migrationState":            "Represents the status of a live migration",
+		"queuePosition":             "QueuePosition is the position of a pending migration in the queue of migrations waiting for a free\nmigration slot, starting at 1. It is removed once the target pod of the migration is created.\n+optional",
 	}
 }
 
@@ -614,6 +626,31 @@ func (MigrateOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (ConvertHypervisorOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "ConvertHypervisorOptions may be provided on convert-hypervisor request.",
+		"hypervisor": "Hypervisor is the hypervisor backend the VirtualMachine is moved to",
+		"dryRun":     "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
+func (HypervisorConversionReport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "HypervisorConversionReport lists how the domain of a VirtualMachine changes when it is moved to another hypervisor\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"sourceHypervisor": "SourceHypervisor is the hypervisor the VirtualMachine currently uses",
+		"targetHypervisor": "TargetHypervisor is the hypervisor the VirtualMachine is moved to",
+		"changes":          "Changes the guest observes after the VirtualMachine was restarted on the target hypervisor\n+optional\n+listType=atomic",
+	}
+}
+
+func (HypervisorConversionChange) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "HypervisorConversionChange describes a difference between the domains rendered for the source and the target hypervisor",
+		"field":   "Field is the path of the VirtualMachine field the change originates from",
+		"message": "Message describes the change",
+	}
+}
+
 func (VirtualMachineInstanceGuestAgentInfo) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                  "VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
@@ -686,6 +723,7 @@ func (VirtualMachineMemoryDumpRequest) SwaggerDoc() map[string]string {
 		"endTimestamp":   "EndTimestamp represents the time the memory dump was completed\n+optional",
 		"fileName":       "FileName represents the name of the output file\n+optional",
 		"message":        "Message is a detailed message about failure of the memory dump\n+optional",
+		"type":           "Type is the kind of memory dump to take, defaults to Core\n+optional",
 	}
 }
 
@@ -755,6 +793,37 @@ func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 		"autoCPULimitNamespaceLabelSelector": "When set, AutoCPULimitNamespaceLabelSelector will set a CPU limit on virt-launcher for VMIs running inside\nnamespaces that match the label selector.\nThe CPU limit will equal the number of requested vCPUs.\nThis setting does not apply to VMIs with dedicated CPUs.",
 		"liveUpdateConfiguration":            "LiveUpdateConfiguration holds defaults for live update features",
 		"vmRolloutStrategy":                  "VMRolloutStrategy defines how changes to a VM object propagate to its VMI\n+nullable\n+kubebuilder:validation:Enum=Stage;LiveUpdate",
+		"hypervisorConfiguration":            "HypervisorConfiguration holds the cluster-wide default hypervisor and the per-namespace hypervisor policies",
+	}
+}
+
+func (HypervisorConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "HypervisorConfiguration defines which hypervisor VirtualMachineInstances get when they don't specify one,\nand which hypervisors they are allowed to use.",
+		"defaultHypervisor": "DefaultHypervisor is the hypervisor assigned to VirtualMachineInstances which don't set spec.hypervisor.\nDefaults to \"qemu\".\n+optional",
+		"namespacePolicies": "NamespacePolicies restrict and default the hypervisors available in specific namespaces.\nWhen several policies select the same namespace, the first one wins.\n+optional\n+listType=atomic",
+		"overheads":         "Overheads override the memory overhead KubeVirt reserves in virt-launcher pods for the processes\nof a hypervisor. Fields which are not set keep the built-in default of the hypervisor.\n+optional\n+listType=map\n+listMapKey=hypervisor",
+	}
+}
+
+func (HypervisorOverhead) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "HypervisorOverhead defines the memory reserved for each process of a virt-launcher pod running a hypervisor.",
+		"hypervisor":          "Hypervisor the overheads apply to, e.g. \"qemu\" or \"ch\".",
+		"virtLauncherMonitor": "VirtLauncherMonitor is the memory reserved for the virt-launcher-monitor process.\n+optional",
+		"virtLauncher":        "VirtLauncher is the memory reserved for the virt-launcher process.\n+optional",
+		"virtlogd":            "Virtlogd is the memory reserved for the virtlogd process.\n+optional",
+		"hypervisorDaemon":    "HypervisorDaemon is the memory reserved for the libvirt daemon of the hypervisor, e.g. virtqemud.\n+optional",
+		"vmm":                 "VMM is the memory reserved for the VMM process, on top of the guest memory and page tables.\n+optional",
+	}
+}
+
+func (HypervisorNamespacePolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "HypervisorNamespacePolicy defines the hypervisors available to VirtualMachineInstances in a set of namespaces.",
+		"namespaces":         "Namespaces the policy applies to.\n+listType=set",
+		"defaultHypervisor":  "DefaultHypervisor overrides the cluster-wide default hypervisor in the selected namespaces.\n+optional",
+		"allowedHypervisors": "AllowedHypervisors lists the hypervisors VirtualMachineInstances in the selected namespaces may use.\nIf empty, every hypervisor is allowed.\n+optional\n+listType=set",
 	}
 }
 
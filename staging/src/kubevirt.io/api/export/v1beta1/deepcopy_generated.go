@@ -76,6 +76,22 @@ func (in *VirtualMachineExport) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportContainerDisk) DeepCopyInto(out *VirtualMachineExportContainerDisk) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportContainerDisk.
+func (in *VirtualMachineExportContainerDisk) DeepCopy() *VirtualMachineExportContainerDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportContainerDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineExportLink) DeepCopyInto(out *VirtualMachineExportLink) {
 	*out = *in
@@ -179,6 +195,27 @@ func (in *VirtualMachineExportManifest) DeepCopy() *VirtualMachineExportManifest
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportNBD) DeepCopyInto(out *VirtualMachineExportNBD) {
+	*out = *in
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportNBD.
+func (in *VirtualMachineExportNBD) DeepCopy() *VirtualMachineExportNBD {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportNBD)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineExportSpec) DeepCopyInto(out *VirtualMachineExportSpec) {
 	*out = *in
@@ -193,6 +230,16 @@ func (in *VirtualMachineExportSpec) DeepCopyInto(out *VirtualMachineExportSpec)
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.NBD != nil {
+		in, out := &in.NBD, &out.NBD
+		*out = new(VirtualMachineExportNBD)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerDisk != nil {
+		in, out := &in.ContainerDisk, &out.ContainerDisk
+		*out = new(VirtualMachineExportContainerDisk)
+		**out = **in
+	}
 	return
 }
 
@@ -67,6 +67,29 @@ type VirtualMachineExportSpec struct {
 	// If this field is omitted, a reasonable default is applied.
 	// +optional
 	TTLDuration *metav1.Duration `json:"ttlDuration,omitempty"`
+
+	// NBD enables an NBD server in the export pod, which serves the volumes on the internal link
+	// +optional
+	NBD *VirtualMachineExportNBD `json:"nbd,omitempty"`
+
+	// ContainerDisk packages a volume into a containerDisk image, the VirtualMachine manifest refers to the image instead of the volume
+	// +optional
+	ContainerDisk *VirtualMachineExportContainerDisk `json:"containerDisk,omitempty"`
+}
+
+// VirtualMachineExportNBD configures the NBD server of a VirtualMachineExport
+type VirtualMachineExportNBD struct {
+	// ReadOnly rejects writes to the volumes through the NBD server, defaults to true
+	// +optional
+	ReadOnly *bool `json:"readOnly,omitempty"`
+}
+
+// VirtualMachineExportContainerDisk configures the containerDisk image of a VirtualMachineExport
+type VirtualMachineExportContainerDisk struct {
+	// Volume is the name of the exported volume which is packaged into the image
+	Volume string `json:"volume"`
+	// Image is the reference of the image, the VirtualMachine manifest refers to it
+	Image string `json:"image"`
 }
 
 // VirtualMachineExportPhase is the current phase of the VirtualMachineExport
@@ -157,6 +180,8 @@ const (
 	AllManifests ExportManifestType = "all"
 	// AuthHeader returns a CDI compatible secret containing the token as an Auth header
 	AuthHeader ExportManifestType = "auth-header-secret"
+	// Checksums returns the SHA-256 checksums of the volumes in all formats which publish one, in the format of sha256sum
+	Checksums ExportManifestType = "sha256sums"
 )
 
 // VirtualMachineExportVolume contains the name and available formats for the exported volume
@@ -180,6 +205,18 @@ const (
 	Dir ExportVolumeFormat = "dir"
 	// ArchiveGz is a tarred and gzipped version of the root of a PersistentVolumeClaim
 	ArchiveGz ExportVolumeFormat = "tar.gz"
+	// Backup is the latest VirtualMachineBackup stored on a PersistentVolumeClaim, consisting of a manifest,
+	// sparse raw images and their data extents
+	Backup ExportVolumeFormat = "backup"
+	// NBD is the volume served by an NBD server which requires TLS, the export name is the name of the volume
+	// followed by a slash and the export token
+	NBD ExportVolumeFormat = "nbd"
+	// KubeVirtQcow2 is the volume in QCOW2 format, clusters which only contain zeroes are not allocated
+	KubeVirtQcow2 ExportVolumeFormat = "qcow2"
+	// KubeVirtRawZst is the volume in zstd compressed RAW format
+	KubeVirtRawZst ExportVolumeFormat = "raw.zst"
+	// ContainerDisk is a tarball of the OCI image layout of a containerDisk image, which contains the volume in QCOW2 format
+	ContainerDisk ExportVolumeFormat = "containerdisk"
 )
 
 // VirtualMachineExportVolumeFormat contains the format type and URL to get the volume in that format
@@ -188,6 +225,10 @@ type VirtualMachineExportVolumeFormat struct {
 	Format ExportVolumeFormat `json:"format"`
 	// Url is the url that contains the volume in the format specified
 	Url string `json:"url"`
+	// ChecksumUrl is the url of the SHA-256 checksum of the volume in the format specified,
+	// in the format of sha256sum
+	// +optional
+	ChecksumUrl string `json:"checksumUrl,omitempty"`
 }
 
 // ConditionType is the const type for Conditions
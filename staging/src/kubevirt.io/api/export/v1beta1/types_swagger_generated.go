@@ -21,6 +21,23 @@ func (VirtualMachineExportSpec) SwaggerDoc() map[string]string {
 		"":               "VirtualMachineExportSpec is the spec for a VirtualMachineExport resource",
 		"tokenSecretRef": "+optional\nTokenSecretRef is the name of the custom-defined secret that contains the token used by the export server pod",
 		"ttlDuration":    "ttlDuration limits the lifetime of an export\nIf this field is set, after this duration has passed from counting from CreationTimestamp,\nthe export is eligible to be automatically deleted.\nIf this field is omitted, a reasonable default is applied.\n+optional",
+		"nbd":            "NBD enables an NBD server in the export pod, which serves the volumes on the internal link\n+optional",
+		"containerDisk":  "ContainerDisk packages a volume into a containerDisk image, the VirtualMachine manifest refers to the image instead of the volume\n+optional",
+	}
+}
+
+func (VirtualMachineExportNBD) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "VirtualMachineExportNBD configures the NBD server of a VirtualMachineExport",
+		"readOnly": "ReadOnly rejects writes to the volumes through the NBD server, defaults to true\n+optional",
+	}
+}
+
+func (VirtualMachineExportContainerDisk) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineExportContainerDisk configures the containerDisk image of a VirtualMachineExport",
+		"volume": "Volume is the name of the exported volume which is packaged into the image",
+		"image":  "Image is the reference of the image, the VirtualMachine manifest refers to it",
 	}
 }
 
@@ -72,9 +89,10 @@ func (VirtualMachineExportVolume) SwaggerDoc() map[string]string {
 
 func (VirtualMachineExportVolumeFormat) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":       "VirtualMachineExportVolumeFormat contains the format type and URL to get the volume in that format",
-		"format": "Format is the format of the image at the specified URL",
-		"url":    "Url is the url that contains the volume in the format specified",
+		"":            "VirtualMachineExportVolumeFormat contains the format type and URL to get the volume in that format",
+		"format":      "Format is the format of the image at the specified URL",
+		"url":         "Url is the url that contains the volume in the format specified",
+		"checksumUrl": "ChecksumUrl is the url of the SHA-256 checksum of the volume in the format specified,\nin the format of sha256sum\n+optional",
 	}
 }
 
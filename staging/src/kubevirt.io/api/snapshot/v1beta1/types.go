@@ -71,6 +71,15 @@ type VirtualMachineSnapshotSpec struct {
 	// Defaults to DefaultFailureDeadline - 5min
 	// +optional
 	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+
+	// IncludeMemoryState saves the guest memory and device state of a
+	// running vm next to the volume snapshots. The vm is paused while
+	// the state and the volumes are captured, and a restore of the
+	// snapshot resumes the vm from that state.
+	// Only vms running on the qemu hypervisor can save their memory
+	// state, snapshots of Cloud Hypervisor (ch) vms are rejected.
+	// +optional
+	IncludeMemoryState *bool `json:"includeMemoryState,omitempty"`
 }
 
 // Indication is a way to indicate the state of the vm when taking the snapshot
@@ -80,6 +89,7 @@ const (
 	VMSnapshotOnlineSnapshotIndication Indication = "Online"
 	VMSnapshotNoGuestAgentIndication   Indication = "NoGuestAgent"
 	VMSnapshotGuestAgentIndication     Indication = "GuestAgent"
+	VMSnapshotMemoryStateIndication    Indication = "MemoryState"
 )
 
 // VirtualMachineSnapshotPhase is the current phase of the VirtualMachineSnapshot
@@ -324,6 +334,88 @@ type VirtualMachineRestoreSpec struct {
 	// +optional
 	// +listType=atomic
 	Patches []string `json:"patches,omitempty"`
+
+	// TargetNamespace is the namespace the target is restored into. Defaults to the namespace
+	// of the VirtualMachineRestore. Restoring into another namespace always creates a new VM
+	// and requires the CrossNamespaceVolumeDataSource feature of the cluster, along with a
+	// ReferenceGrant allowing PVCs of the target namespace to use the VolumeSnapshots of the
+	// snapshot namespace.
+	// +optional
+	TargetNamespace *string `json:"targetNamespace,omitempty"`
+
+	// VolumeRestorePolicy defines how the restored PVCs and DataVolumes are named.
+	// Defaults to RandomizeNames.
+	// +optional
+	VolumeRestorePolicy *VolumeRestorePolicy `json:"volumeRestorePolicy,omitempty"`
+
+	// VolumeRestoreOverrides names the restored PVCs and DataVolumes of specific volumes,
+	// taking precedence over the VolumeRestorePolicy.
+	// +optional
+	// +listType=atomic
+	VolumeRestoreOverrides []VolumeRestoreOverride `json:"volumeRestoreOverrides,omitempty"`
+
+	// NewMacAddresses sets the MAC addresses of the interfaces of a VM created by the restore
+	// as a copy of the snapshotted VM. The key is the interface name and the value is the new
+	// MAC address. Interfaces that are not listed get a new MAC address assigned.
+	// +optional
+	NewMacAddresses map[string]string `json:"newMacAddresses,omitempty"`
+
+	// NewSMBiosSerial sets the SMBIOS serial of a VM created by the restore as a copy of the
+	// snapshotted VM. If not set, the serial is cleared.
+	// +optional
+	NewSMBiosSerial *string `json:"newSMBiosSerial,omitempty"`
+
+	// IncludedVolumes restricts the restore to the listed volumes of the snapshot.
+	// Mutually exclusive with ExcludedVolumes.
+	// +optional
+	// +listType=set
+	IncludedVolumes []string `json:"includedVolumes,omitempty"`
+
+	// ExcludedVolumes lists the volumes of the snapshot that are not restored.
+	// Mutually exclusive with IncludedVolumes.
+	// +optional
+	// +listType=set
+	ExcludedVolumes []string `json:"excludedVolumes,omitempty"`
+
+	// RestoreMode defines how the restored volumes are applied to the target.
+	// Defaults to Full.
+	// +optional
+	RestoreMode *RestoreMode `json:"restoreMode,omitempty"`
+}
+
+// RestoreMode defines how the restored volumes are applied to the target
+type RestoreMode string
+
+const (
+	// RestoreModeFull restores the VM spec of the snapshot along with the restored volumes.
+	// Volumes that are not restored keep the source they had in the snapshot.
+	RestoreModeFull RestoreMode = "Full"
+
+	// RestoreModeVolumesOnly keeps the current spec of the target VM and only replaces the
+	// restored volumes. The target VM must exist and be stopped.
+	RestoreModeVolumesOnly RestoreMode = "VolumesOnly"
+
+	// RestoreModeHotplug leaves the target VM untouched and attaches the restored volumes
+	// as additional hotplug disks, so data can be recovered from a running VM.
+	// The target VM must exist and the HotplugVolumes feature gate must be enabled.
+	RestoreModeHotplug RestoreMode = "Hotplug"
+)
+
+// VolumeRestorePolicy defines how the restored PVCs and DataVolumes are named
+type VolumeRestorePolicy string
+
+const (
+	// VolumeRestorePolicyRandomizeNames names the restored volumes restore-<restore UID>-<volume name>
+	VolumeRestorePolicyRandomizeNames VolumeRestorePolicy = "RandomizeNames"
+
+	// VolumeRestorePolicyPrefixTargetName names the restored volumes <target name>-<volume name>
+	VolumeRestorePolicyPrefixTargetName VolumeRestorePolicy = "PrefixTargetName"
+)
+
+// VolumeRestoreOverride sets the name of the restored PVC and DataVolume of a volume
+type VolumeRestoreOverride struct {
+	VolumeName  string `json:"volumeName"`
+	RestoreName string `json:"restoreName"`
 }
 
 // VirtualMachineRestoreStatus is the spec for a VirtualMachineRestoreresource
@@ -367,3 +459,323 @@ type VirtualMachineRestoreList struct {
 
 	Items []VirtualMachineRestore `json:"items"`
 }
+
+// VirtualMachineSnapshotScheduleLabel is set on the VirtualMachineSnapshots
+// created by a schedule and holds the name of the schedule
+const VirtualMachineSnapshotScheduleLabel = "snapshot.kubevirt.io/schedule"
+
+// VirtualMachineSnapshotSchedule defines recurring snapshots of a set of VMs
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineSnapshotScheduleSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineSnapshotScheduleStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource
+type VirtualMachineSnapshotScheduleSpec struct {
+	// Schedule in cron format (minute hour day-of-month month day-of-week),
+	// evaluated in UTC
+	Schedule string `json:"schedule"`
+
+	// Selector selects the VirtualMachines in the namespace of the schedule
+	// which are snapshotted. All VirtualMachines in the namespace are selected
+	// if the selector is omitted.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Template holds the defaults for the created VirtualMachineSnapshots
+	// +optional
+	Template *VirtualMachineSnapshotScheduleTemplate `json:"template,omitempty"`
+
+	// Retention defines which of the created snapshots are kept
+	// +optional
+	Retention *VirtualMachineSnapshotRetentionPolicy `json:"retention,omitempty"`
+
+	// Disabled suspends the creation of new snapshots
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleTemplate holds the settings applied to every
+// VirtualMachineSnapshot created by a schedule
+type VirtualMachineSnapshotScheduleTemplate struct {
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// +optional
+	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+}
+
+// VirtualMachineSnapshotRetentionPolicy defines how many snapshots are kept
+// for each VirtualMachine. A snapshot is kept if any of the rules retains it.
+// Snapshots are never pruned if no rule is set.
+type VirtualMachineSnapshotRetentionPolicy struct {
+	// KeepLast keeps the given number of most recent snapshots
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// KeepDaily keeps the most recent snapshot of each of the given number of days
+	// +optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+
+	// KeepWeekly keeps the most recent snapshot of each of the given number of weeks
+	// +optional
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource
+type VirtualMachineSnapshotScheduleStatus struct {
+	// +optional
+	// +nullable
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// +optional
+	// +nullable
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	VirtualMachines []ScheduledVirtualMachineStatus `json:"virtualMachines,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// ScheduledVirtualMachineStatus records the outcome of the scheduled snapshots of a VirtualMachine
+type ScheduledVirtualMachineStatus struct {
+	Name string `json:"name"`
+
+	// +optional
+	LastSuccessfulSnapshot string `json:"lastSuccessfulSnapshot,omitempty"`
+
+	// +optional
+	// +nullable
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+
+	// +optional
+	LastFailedSnapshot string `json:"lastFailedSnapshot,omitempty"`
+
+	// +optional
+	// +nullable
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// +optional
+	LastFailureMessage *string `json:"lastFailureMessage,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineSnapshotSchedule `json:"items"`
+}
+
+// VirtualMachineGroupSnapshotLabel is set on the VirtualMachineSnapshots
+// created by a group snapshot and holds the name of the group snapshot
+const VirtualMachineGroupSnapshotLabel = "snapshot.kubevirt.io/group-snapshot"
+
+// VirtualMachineGroupSnapshot defines the operation of snapshotting several VMs
+// at a consistent point in time
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineGroupSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineGroupSnapshotSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineGroupSnapshotStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineGroupSnapshotSpec is the spec for a VirtualMachineGroupSnapshot resource
+type VirtualMachineGroupSnapshotSpec struct {
+	// Selector selects the VirtualMachines in the namespace of the group
+	// snapshot which are snapshotted together. The selected VirtualMachines
+	// are fixed once the group snapshot started.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// DeletionPolicy is applied to the VirtualMachineSnapshot of each VirtualMachine
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// This time represents the number of seconds we permit the group snapshot
+	// to take. In case we pass this deadline we mark this snapshot
+	// as failed.
+	// Defaults to DefaultFailureDeadline - 5min
+	// +optional
+	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+}
+
+// VirtualMachineGroupSnapshotStatus is the status for a VirtualMachineGroupSnapshot resource
+type VirtualMachineGroupSnapshotStatus struct {
+	// +optional
+	// +nullable
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// +optional
+	Phase VirtualMachineSnapshotPhase `json:"phase,omitempty"`
+
+	// +optional
+	ReadyToUse *bool `json:"readyToUse,omitempty"`
+
+	// +optional
+	Error *Error `json:"error,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// VirtualMachineSnapshots lists the snapshot taken of each VirtualMachine of the group
+	// +optional
+	// +listType=map
+	// +listMapKey=virtualMachineName
+	VirtualMachineSnapshots []GroupSnapshotVirtualMachineStatus `json:"virtualMachineSnapshots,omitempty"`
+}
+
+// GroupSnapshotVirtualMachineStatus is the status of the snapshot of a single
+// VirtualMachine of a group snapshot
+type GroupSnapshotVirtualMachineStatus struct {
+	VirtualMachineName string `json:"virtualMachineName"`
+
+	VirtualMachineSnapshotName string `json:"virtualMachineSnapshotName"`
+
+	// +optional
+	VirtualMachineSnapshotContentName *string `json:"virtualMachineSnapshotContentName,omitempty"`
+
+	// FreezeTime is the time the VirtualMachine was frozen for the group snapshot,
+	// the freeze deadline of the group is measured from the first frozen VirtualMachine
+	// +optional
+	// +nullable
+	FreezeTime *metav1.Time `json:"freezeTime,omitempty"`
+
+	// +optional
+	ReadyToUse *bool `json:"readyToUse,omitempty"`
+}
+
+// VirtualMachineGroupSnapshotList is a list of VirtualMachineGroupSnapshot resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineGroupSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineGroupSnapshot `json:"items"`
+}
+
+// VirtualMachineBackup defines the operation of backing up the disks of a
+// running VM to a PersistentVolumeClaim
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineBackupSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineBackupStatus `json:"status,omitempty"`
+}
+
+// BackupType defines which data of the disks is backed up
+type BackupType string
+
+const (
+	// BackupTypeFull backs up all the data of the disks
+	BackupTypeFull BackupType = "Full"
+
+	// BackupTypeIncremental backs up the data changed since the latest
+	// backup of the VM, a full backup is taken if there is none
+	BackupTypeIncremental BackupType = "Incremental"
+)
+
+// VirtualMachineBackupSpec is the spec for a VirtualMachineBackup resource
+type VirtualMachineBackupSpec struct {
+	// Source is the running VirtualMachine whose disks are backed up
+	Source corev1.TypedLocalObjectReference `json:"source"`
+
+	// PersistentVolumeClaimName is the filesystem pvc the backup is written
+	// to, a previous backup in the pvc is replaced
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+
+	// Type defaults to Incremental
+	// +optional
+	Type *BackupType `json:"type,omitempty"`
+
+	// This time represents the number of seconds we permit the backup
+	// to take. In case we pass this deadline we mark this backup
+	// as failed.
+	// Defaults to DefaultFailureDeadline - 5min
+	// +optional
+	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+}
+
+// VirtualMachineBackupStatus is the status for a VirtualMachineBackup resource
+type VirtualMachineBackupStatus struct {
+	// VirtualMachineInstanceUID is the uid of the vmi the backup was taken
+	// from. Checkpoints do not outlive the vmi, incremental backups are
+	// only taken on top of backups of the same vmi.
+	// +optional
+	VirtualMachineInstanceUID *types.UID `json:"virtualMachineInstanceUID,omitempty"`
+
+	// +optional
+	Phase VirtualMachineSnapshotPhase `json:"phase,omitempty"`
+
+	// Type is the type of the backup which is taken, disks without a
+	// persistent dirty bitmap are backed up fully in an incremental backup
+	// +optional
+	Type BackupType `json:"type,omitempty"`
+
+	// Checkpoint is the name of the checkpoint created by the backup
+	// +optional
+	Checkpoint string `json:"checkpoint,omitempty"`
+
+	// IncrementalFrom is the checkpoint of the backup an incremental
+	// backup is taken on top of
+	// +optional
+	IncrementalFrom *string `json:"incrementalFrom,omitempty"`
+
+	// CheckpointChain lists the checkpoints from the latest full backup up
+	// to the checkpoint of this backup. The backups of all the checkpoints
+	// are needed to rebuild the disks.
+	// +optional
+	// +listType=atomic
+	CheckpointChain []string `json:"checkpointChain,omitempty"`
+
+	// BackupDirectory is the directory of the backup in the pvc
+	// +optional
+	BackupDirectory *string `json:"backupDirectory,omitempty"`
+
+	// +optional
+	// +nullable
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// +optional
+	// +nullable
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+
+	// +optional
+	Error *Error `json:"error,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// VirtualMachineBackupList is a list of VirtualMachineBackup resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineBackup `json:"items"`
+}
@@ -11,9 +11,10 @@ func (VirtualMachineSnapshot) SwaggerDoc() map[string]string {
 
 func (VirtualMachineSnapshotSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
-		"deletionPolicy":  "+optional",
-		"failureDeadline": "This time represents the number of seconds we permit the vm snapshot\nto take. In case we pass this deadline we mark this snapshot\nas failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+		"":                   "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
+		"deletionPolicy":     "+optional",
+		"failureDeadline":    "This time represents the number of seconds we permit the vm snapshot\nto take. In case we pass this deadline we mark this snapshot\nas failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+		"includeMemoryState": "IncludeMemoryState saves the guest memory and device state of a\nrunning vm next to the volume snapshots. The vm is paused while\nthe state and the volumes are captured, and a restore of the\nsnapshot resumes the vm from that state.\nOnly vms running on the qemu hypervisor can save their memory\nstate, snapshots of Cloud Hypervisor (ch) vms are rejected.\n+optional",
 	}
 }
 
@@ -139,9 +140,23 @@ func (VirtualMachineRestore) SwaggerDoc() map[string]string {
 
 func (VirtualMachineRestoreSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":        "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
-		"target":  "initially only VirtualMachine type supported",
-		"patches": "If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be\napplied to the target manifest before it's created. Patches should fit the target's Kind.\n\nExample for a patch: {\"op\": \"replace\", \"path\": \"/metadata/name\", \"value\": \"new-vm-name\"}\n\n+optional\n+listType=atomic",
+		"":                       "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
+		"target":                 "initially only VirtualMachine type supported",
+		"patches":                "If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be\napplied to the target manifest before it's created. Patches should fit the target's Kind.\n\nExample for a patch: {\"op\": \"replace\", \"path\": \"/metadata/name\", \"value\": \"new-vm-name\"}\n\n+optional\n+listType=atomic",
+		"targetNamespace":        "TargetNamespace is the namespace the target is restored into. Defaults to the namespace\nof the VirtualMachineRestore. Restoring into another namespace always creates a new VM\nand requires the CrossNamespaceVolumeDataSource feature of the cluster, along with a\nReferenceGrant allowing PVCs of the target namespace to use the VolumeSnapshots of the\nsnapshot namespace.\n+optional",
+		"volumeRestorePolicy":    "VolumeRestorePolicy defines how the restored PVCs and DataVolumes are named.\nDefaults to RandomizeNames.\n+optional",
+		"volumeRestoreOverrides": "VolumeRestoreOverrides names the restored PVCs and DataVolumes of specific volumes,\ntaking precedence over the VolumeRestorePolicy.\n+optional\n+listType=atomic",
+		"newMacAddresses":        "NewMacAddresses sets the MAC addresses of the interfaces of a VM created by the restore\nas a copy of the snapshotted VM. The key is the interface name and the value is the new\nMAC address. Interfaces that are not listed get a new MAC address assigned.\n+optional",
+		"newSMBiosSerial":        "NewSMBiosSerial sets the SMBIOS serial of a VM created by the restore as a copy of the\nsnapshotted VM. If not set, the serial is cleared.\n+optional",
+		"includedVolumes":        "IncludedVolumes restricts the restore to the listed volumes of the snapshot.\nMutually exclusive with ExcludedVolumes.\n+optional\n+listType=set",
+		"excludedVolumes":        "ExcludedVolumes lists the volumes of the snapshot that are not restored.\nMutually exclusive with IncludedVolumes.\n+optional\n+listType=set",
+		"restoreMode":            "RestoreMode defines how the restored volumes are applied to the target.\nDefaults to Full.\n+optional",
+	}
+}
+
+func (VolumeRestoreOverride) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VolumeRestoreOverride sets the name of the restored PVC and DataVolume of a volume",
 	}
 }
 
@@ -168,3 +183,148 @@ func (VirtualMachineRestoreList) SwaggerDoc() map[string]string {
 		"": "VirtualMachineRestoreList is a list of VirtualMachineRestore resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
 	}
 }
+
+func (VirtualMachineSnapshotSchedule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineSnapshotSchedule defines recurring snapshots of a set of VMs\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource",
+		"schedule":  "Schedule in cron format (minute hour day-of-month month day-of-week),\nevaluated in UTC",
+		"selector":  "Selector selects the VirtualMachines in the namespace of the schedule\nwhich are snapshotted. All VirtualMachines in the namespace are selected\nif the selector is omitted.\n+optional",
+		"template":  "Template holds the defaults for the created VirtualMachineSnapshots\n+optional",
+		"retention": "Retention defines which of the created snapshots are kept\n+optional",
+		"disabled":  "Disabled suspends the creation of new snapshots\n+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleTemplate) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineSnapshotScheduleTemplate holds the settings applied to every\nVirtualMachineSnapshot created by a schedule",
+		"deletionPolicy":  "+optional",
+		"failureDeadline": "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotRetentionPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "VirtualMachineSnapshotRetentionPolicy defines how many snapshots are kept\nfor each VirtualMachine. A snapshot is kept if any of the rules retains it.\nSnapshots are never pruned if no rule is set.",
+		"keepLast":   "KeepLast keeps the given number of most recent snapshots\n+optional",
+		"keepDaily":  "KeepDaily keeps the most recent snapshot of each of the given number of days\n+optional",
+		"keepWeekly": "KeepWeekly keeps the most recent snapshot of each of the given number of weeks\n+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource",
+		"lastScheduleTime": "+optional\n+nullable",
+		"nextScheduleTime": "+optional\n+nullable",
+		"virtualMachines":  "+optional\n+listType=map\n+listMapKey=name",
+		"conditions":       "+optional\n+listType=atomic",
+	}
+}
+
+func (ScheduledVirtualMachineStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                       "ScheduledVirtualMachineStatus records the outcome of the scheduled snapshots of a VirtualMachine",
+		"lastSuccessfulSnapshot": "+optional",
+		"lastSuccessTime":        "+optional\n+nullable",
+		"lastFailedSnapshot":     "+optional",
+		"lastFailureTime":        "+optional\n+nullable",
+		"lastFailureMessage":     "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
+
+func (VirtualMachineGroupSnapshot) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineGroupSnapshot defines the operation of snapshotting several VMs\nat a consistent point in time\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineGroupSnapshotSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineGroupSnapshotSpec is the spec for a VirtualMachineGroupSnapshot resource",
+		"selector":        "Selector selects the VirtualMachines in the namespace of the group\nsnapshot which are snapshotted together. The selected VirtualMachines\nare fixed once the group snapshot started.",
+		"deletionPolicy":  "DeletionPolicy is applied to the VirtualMachineSnapshot of each VirtualMachine\n+optional",
+		"failureDeadline": "This time represents the number of seconds we permit the group snapshot\nto take. In case we pass this deadline we mark this snapshot\nas failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+	}
+}
+
+func (VirtualMachineGroupSnapshotStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "VirtualMachineGroupSnapshotStatus is the status for a VirtualMachineGroupSnapshot resource",
+		"creationTime":            "+optional\n+nullable",
+		"phase":                   "+optional",
+		"readyToUse":              "+optional",
+		"error":                   "+optional",
+		"conditions":              "+optional\n+listType=atomic",
+		"virtualMachineSnapshots": "VirtualMachineSnapshots lists the snapshot taken of each VirtualMachine of the group\n+optional\n+listType=map\n+listMapKey=virtualMachineName",
+	}
+}
+
+func (GroupSnapshotVirtualMachineStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                  "GroupSnapshotVirtualMachineStatus is the status of the snapshot of a single\nVirtualMachine of a group snapshot",
+		"virtualMachineSnapshotContentName": "+optional",
+		"freezeTime":                        "FreezeTime is the time the VirtualMachine was frozen for the group snapshot,\nthe freeze deadline of the group is measured from the first frozen VirtualMachine\n+optional\n+nullable",
+		"readyToUse":                        "+optional",
+	}
+}
+
+func (VirtualMachineGroupSnapshotList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineGroupSnapshotList is a list of VirtualMachineGroupSnapshot resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
+
+func (VirtualMachineBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineBackup defines the operation of backing up the disks of a\nrunning VM to a PersistentVolumeClaim\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineBackupSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                          "VirtualMachineBackupSpec is the spec for a VirtualMachineBackup resource",
+		"source":                    "Source is the running VirtualMachine whose disks are backed up",
+		"persistentVolumeClaimName": "PersistentVolumeClaimName is the filesystem pvc the backup is written\nto, a previous backup in the pvc is replaced",
+		"type":                      "Type defaults to Incremental\n+optional",
+		"failureDeadline":           "This time represents the number of seconds we permit the backup\nto take. In case we pass this deadline we mark this backup\nas failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+	}
+}
+
+func (VirtualMachineBackupStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                          "VirtualMachineBackupStatus is the status for a VirtualMachineBackup resource",
+		"virtualMachineInstanceUID": "VirtualMachineInstanceUID is the uid of the vmi the backup was taken\nfrom. Checkpoints do not outlive the vmi, incremental backups are\nonly taken on top of backups of the same vmi.\n+optional",
+		"phase":                     "+optional",
+		"type":                      "Type is the type of the backup which is taken, disks without a\npersistent dirty bitmap are backed up fully in an incremental backup\n+optional",
+		"checkpoint":                "Checkpoint is the name of the checkpoint created by the backup\n+optional",
+		"incrementalFrom":           "IncrementalFrom is the checkpoint of the backup an incremental\nbackup is taken on top of\n+optional",
+		"checkpointChain":           "CheckpointChain lists the checkpoints from the latest full backup up\nto the checkpoint of this backup. The backups of all the checkpoints\nare needed to rebuild the disks.\n+optional\n+listType=atomic",
+		"backupDirectory":           "BackupDirectory is the directory of the backup in the pvc\n+optional",
+		"startTimestamp":            "+optional\n+nullable",
+		"endTimestamp":              "+optional\n+nullable",
+		"error":                     "+optional",
+		"conditions":                "+optional\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineBackupList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineBackupList is a list of VirtualMachineBackup resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
@@ -73,6 +73,16 @@ func (c *FakeVirtualMachines) Migrate(ctx context.Context, name string, migrateO
 	return err
 }
 
+func (c *FakeVirtualMachines) ConvertHypervisor(ctx context.Context, name string, convertHypervisorOptions *v1.ConvertHypervisorOptions) (*v1.HypervisorConversionReport, error) {
+	obj, err := c.Fake.
+		Invokes(fake2.NewPutSubresourceAction(virtualmachinesResource, c.ns, "convert-hypervisor", name, convertHypervisorOptions), &v1.HypervisorConversionReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.HypervisorConversionReport), err
+}
+
 func (c *FakeVirtualMachines) MemoryDump(ctx context.Context, name string, memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest) error {
 	_, err := c.Fake.
 		Invokes(fake2.NewPutSubresourceAction(virtualmachinesResource, c.ns, "memorydump", name, memoryDumpRequest), nil)
@@ -41,6 +41,7 @@ type VirtualMachineExpansion interface {
 	Start(ctx context.Context, name string, startOptions *v1.StartOptions) error
 	Stop(ctx context.Context, name string, stopOptions *v1.StopOptions) error
 	Migrate(ctx context.Context, name string, migrateOptions *v1.MigrateOptions) error
+	ConvertHypervisor(ctx context.Context, name string, convertHypervisorOptions *v1.ConvertHypervisorOptions) (*v1.HypervisorConversionReport, error)
 	AddVolume(ctx context.Context, name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(ctx context.Context, name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
 	PortForward(name string, port int, protocol string) (StreamInterface, error)
@@ -130,6 +131,24 @@ func (c *virtualMachines) Migrate(ctx context.Context, name string, migrateOptio
 		Error()
 }
 
+func (c *virtualMachines) ConvertHypervisor(ctx context.Context, name string, convertHypervisorOptions *v1.ConvertHypervisorOptions) (*v1.HypervisorConversionReport, error) {
+	optsJson, err := json.Marshal(convertHypervisorOptions)
+	if err != nil {
+		return nil, err
+	}
+	report := &v1.HypervisorConversionReport{}
+	err = c.client.Put().
+		AbsPath(fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion)).
+		Namespace(c.ns).
+		Resource("virtualmachines").
+		Name(name).
+		SubResource("convert-hypervisor").
+		Body(optsJson).
+		Do(ctx).
+		Into(report)
+	return report, err
+}
+
 func (c *virtualMachines) AddVolume(ctx context.Context, name string, addVolumeOptions *v1.AddVolumeOptions) error {
 	body, err := json.Marshal(addVolumeOptions)
 	if err != nil {
@@ -28,6 +28,14 @@ type FakeSnapshotV1beta1 struct {
 	*testing.Fake
 }
 
+func (c *FakeSnapshotV1beta1) VirtualMachineBackups(namespace string) v1beta1.VirtualMachineBackupInterface {
+	return &FakeVirtualMachineBackups{c, namespace}
+}
+
+func (c *FakeSnapshotV1beta1) VirtualMachineGroupSnapshots(namespace string) v1beta1.VirtualMachineGroupSnapshotInterface {
+	return &FakeVirtualMachineGroupSnapshots{c, namespace}
+}
+
 func (c *FakeSnapshotV1beta1) VirtualMachineRestores(namespace string) v1beta1.VirtualMachineRestoreInterface {
 	return &FakeVirtualMachineRestores{c, namespace}
 }
@@ -40,6 +48,10 @@ func (c *FakeSnapshotV1beta1) VirtualMachineSnapshotContents(namespace string) v
 	return &FakeVirtualMachineSnapshotContents{c, namespace}
 }
 
+func (c *FakeSnapshotV1beta1) VirtualMachineSnapshotSchedules(namespace string) v1beta1.VirtualMachineSnapshotScheduleInterface {
+	return &FakeVirtualMachineSnapshotSchedules{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeSnapshotV1beta1) RESTClient() rest.Interface {
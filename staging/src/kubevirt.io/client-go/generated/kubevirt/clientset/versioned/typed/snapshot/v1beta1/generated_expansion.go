@@ -18,8 +18,14 @@ limitations under the License.
 
 package v1beta1
 
+type VirtualMachineBackupExpansion interface{}
+
+type VirtualMachineGroupSnapshotExpansion interface{}
+
 type VirtualMachineRestoreExpansion interface{}
 
 type VirtualMachineSnapshotExpansion interface{}
 
 type VirtualMachineSnapshotContentExpansion interface{}
+
+type VirtualMachineSnapshotScheduleExpansion interface{}
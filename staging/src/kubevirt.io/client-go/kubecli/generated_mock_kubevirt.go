@@ -207,6 +207,36 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineRestore(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineRestore", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineSnapshotSchedule(namespace string) v1beta118.VirtualMachineSnapshotScheduleInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineSnapshotSchedule", namespace)
+	ret0, _ := ret[0].(v1beta118.VirtualMachineSnapshotScheduleInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineSnapshotSchedule(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineSnapshotSchedule", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineGroupSnapshot(namespace string) v1beta118.VirtualMachineGroupSnapshotInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineGroupSnapshot", namespace)
+	ret0, _ := ret[0].(v1beta118.VirtualMachineGroupSnapshotInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineGroupSnapshot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineGroupSnapshot", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineBackup(namespace string) v1beta118.VirtualMachineBackupInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineBackup", namespace)
+	ret0, _ := ret[0].(v1beta118.VirtualMachineBackupInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineBackup(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineBackup", arg0)
+}
+
 func (_m *MockKubevirtClient) VirtualMachineExport(namespace string) v1beta116.VirtualMachineExportInterface {
 	ret := _m.ctrl.Call(_m, "VirtualMachineExport", namespace)
 	ret0, _ := ret[0].(v1beta116.VirtualMachineExportInterface)
@@ -1775,6 +1805,17 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) Migrate(arg0, arg1, arg2 interf
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Migrate", arg0, arg1, arg2)
 }
 
+func (_m *MockVirtualMachineInterface) ConvertHypervisor(ctx context.Context, name string, convertHypervisorOptions *v121.ConvertHypervisorOptions) (*v121.HypervisorConversionReport, error) {
+	ret := _m.ctrl.Call(_m, "ConvertHypervisor", ctx, name, convertHypervisorOptions)
+	ret0, _ := ret[0].(*v121.HypervisorConversionReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) ConvertHypervisor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ConvertHypervisor", arg0, arg1, arg2)
+}
+
 func (_m *MockVirtualMachineInterface) AddVolume(ctx context.Context, name string, addVolumeOptions *v121.AddVolumeOptions) error {
 	ret := _m.ctrl.Call(_m, "AddVolume", ctx, name, addVolumeOptions)
 	ret0, _ := ret[0].(error)
@@ -65,6 +65,9 @@ type KubevirtClient interface {
 	VirtualMachineSnapshot(namespace string) snapshotv1.VirtualMachineSnapshotInterface
 	VirtualMachineSnapshotContent(namespace string) snapshotv1.VirtualMachineSnapshotContentInterface
 	VirtualMachineRestore(namespace string) snapshotv1.VirtualMachineRestoreInterface
+	VirtualMachineSnapshotSchedule(namespace string) snapshotv1.VirtualMachineSnapshotScheduleInterface
+	VirtualMachineGroupSnapshot(namespace string) snapshotv1.VirtualMachineGroupSnapshotInterface
+	VirtualMachineBackup(namespace string) snapshotv1.VirtualMachineBackupInterface
 	VirtualMachineExport(namespace string) exportv1.VirtualMachineExportInterface
 	VirtualMachineInstancetype(namespace string) instancetypev1beta1.VirtualMachineInstancetypeInterface
 	VirtualMachineClusterInstancetype() instancetypev1beta1.VirtualMachineClusterInstancetypeInterface
@@ -183,6 +186,18 @@ func (k kubevirt) VirtualMachineRestore(namespace string) snapshotv1.VirtualMach
 	return k.generatedKubeVirtClient.SnapshotV1beta1().VirtualMachineRestores(namespace)
 }
 
+func (k kubevirt) VirtualMachineSnapshotSchedule(namespace string) snapshotv1.VirtualMachineSnapshotScheduleInterface {
+	return k.generatedKubeVirtClient.SnapshotV1beta1().VirtualMachineSnapshotSchedules(namespace)
+}
+
+func (k kubevirt) VirtualMachineGroupSnapshot(namespace string) snapshotv1.VirtualMachineGroupSnapshotInterface {
+	return k.generatedKubeVirtClient.SnapshotV1beta1().VirtualMachineGroupSnapshots(namespace)
+}
+
+func (k kubevirt) VirtualMachineBackup(namespace string) snapshotv1.VirtualMachineBackupInterface {
+	return k.generatedKubeVirtClient.SnapshotV1beta1().VirtualMachineBackups(namespace)
+}
+
 func (k kubevirt) VirtualMachineExport(namespace string) exportv1.VirtualMachineExportInterface {
 	return k.generatedKubeVirtClient.ExportV1beta1().VirtualMachineExports(namespace)
 }
@@ -312,6 +312,33 @@ var _ = Describe("[rfe_id:500][crit:high][arm64][vendor:cnv-qe@redhat.com][level
 				denyModificationsFor("view"),
 				denyAllFor("instancetype:view"),
 				denyAllFor("default")),
+			Entry("given a vmsnapshotschedule",
+				snapshotv1.SchemeGroupVersion.Group,
+				"virtualmachinesnapshotschedules",
+				false,
+				allowAllFor("admin"),
+				denyDeleteCollectionFor("edit"),
+				denyModificationsFor("view"),
+				denyAllFor("instancetype:view"),
+				denyAllFor("default")),
+			Entry("given a vmgroupsnapshot",
+				snapshotv1.SchemeGroupVersion.Group,
+				"virtualmachinegroupsnapshots",
+				false,
+				allowAllFor("admin"),
+				denyDeleteCollectionFor("edit"),
+				denyModificationsFor("view"),
+				denyAllFor("instancetype:view"),
+				denyAllFor("default")),
+			Entry("given a vmbackup",
+				snapshotv1.SchemeGroupVersion.Group,
+				"virtualmachinebackups",
+				false,
+				allowAllFor("admin"),
+				denyDeleteCollectionFor("edit"),
+				denyModificationsFor("view"),
+				denyAllFor("instancetype:view"),
+				denyAllFor("default")),
 			Entry("[test_id:TODO]given a virtualmachineinstancetype",
 				instancetypeapi.GroupName,
 				instancetypeapi.PluralResourceName,
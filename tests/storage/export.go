@@ -1203,43 +1203,39 @@ var _ = SIGDescribe("Export", func() {
 		Expect(vmExport.Status.Links).ToNot(BeNil())
 		Expect(vmExport.Status.Links.Internal).NotTo(BeNil())
 		Expect(vmExport.Status.Links.Internal.Cert).NotTo(BeEmpty())
-		Expect(vmExport.Status.Links.Internal.Volumes).To(HaveLen(len(expectedVolumeFormats) / 2))
+		var formats []exportv1.VirtualMachineExportVolumeFormat
 		for _, volume := range vmExport.Status.Links.Internal.Volumes {
-			Expect(volume.Formats).To(HaveLen(2))
-			Expect(expectedVolumeFormats).To(ContainElements(volume.Formats))
+			formats = append(formats, volume.Formats...)
 		}
+		Expect(formats).To(ConsistOf(expectedVolumeFormats))
 	}
 
-	verifyMultiKubevirtInternal := func(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName1, volumeName2 string) {
-		verifyLinksInternal(vmExport,
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtRaw,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName1),
-			},
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtGz,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img.gz", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName1),
-			},
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtRaw,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName2),
-			},
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtGz,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img.gz", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName2),
+	kubevirtVolumeFormats := func(exportName, namespace, volumeName string) []exportv1.VirtualMachineExportVolumeFormat {
+		var formats []exportv1.VirtualMachineExportVolumeFormat
+		for format, file := range map[exportv1.ExportVolumeFormat]string{
+			exportv1.KubeVirtRaw:    "disk.img",
+			exportv1.KubeVirtGz:     "disk.img.gz",
+			exportv1.KubeVirtQcow2:  "disk.qcow2",
+			exportv1.KubeVirtRawZst: "disk.img.zst",
+		} {
+			url := fmt.Sprintf("https://%s.%s.svc/volumes/%s/%s", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName, file)
+			formats = append(formats, exportv1.VirtualMachineExportVolumeFormat{
+				Format:      format,
+				Url:         url,
+				ChecksumUrl: url + ".sha256",
 			})
+		}
+		return formats
+	}
+
+	verifyMultiKubevirtInternal := func(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName1, volumeName2 string) {
+		verifyLinksInternal(vmExport, append(
+			kubevirtVolumeFormats(exportName, namespace, volumeName1),
+			kubevirtVolumeFormats(exportName, namespace, volumeName2)...)...)
 	}
 
 	verifyKubevirtInternal := func(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName string) {
-		verifyLinksInternal(vmExport,
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtRaw,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName),
-			},
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtGz,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img.gz", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName),
-			})
+		verifyLinksInternal(vmExport, kubevirtVolumeFormats(exportName, namespace, volumeName)...)
 	}
 
 	It("should create export from VMSnapshot", func() {